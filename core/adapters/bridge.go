@@ -114,7 +114,11 @@ func (ba *Bridge) postToExternalAdapter(
 		return nil, fmt.Errorf("marshaling request body: %v", err)
 	}
 
-	request, err := http.NewRequest("POST", ba.URL.String(), bytes.NewBuffer(in))
+	bridgeURL, err := ba.URL.WithEnv()
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest("POST", bridgeURL.String(), bytes.NewBuffer(in))
 	if err != nil {
 		return nil, fmt.Errorf("building outgoing bridge http post: %v", err)
 	}
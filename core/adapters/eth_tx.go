@@ -47,6 +47,21 @@ type EthTx struct {
 
 	// MinRequiredOutgoingConfirmations only works with bulletprooftxmanager
 	MinRequiredOutgoingConfirmations uint64 `json:"minRequiredOutgoingConfirmations,omitempty"`
+
+	// IncludeReceipt adds the receipt of the transaction to the output under
+	// the "receipt" key once it has MinRequiredOutgoingConfirmations, so that
+	// later tasks can use how it was actually mined
+	IncludeReceipt bool `json:"includeReceipt,omitempty"`
+}
+
+// EthTxReceipt is the receipt added to the output of EthTx tasks with
+// IncludeReceipt set
+type EthTxReceipt struct {
+	TxHash            common.Hash `json:"transactionHash"`
+	BlockHash         common.Hash `json:"blockHash"`
+	BlockNumber       int64       `json:"blockNumber"`
+	GasUsed           uint64      `json:"gasUsed"`
+	EffectiveGasPrice *utils.Big  `json:"effectiveGasPrice"`
 }
 
 // TaskType returns the type of Adapter.
@@ -198,12 +213,22 @@ func (e *EthTx) checkEthTxForReceipt(ethTxID int64, input models.RunInput, s *st
 
 	hexHash := receipt.TxHash.Hex()
 
-	output := input.Data()
-	output, err = output.MultiAdd(models.KV{
+	kv := models.KV{
 		"result": hexHash,
 		// HACK: latestOutgoingTxHash is used for backwards compatibility with the stats pusher
 		"latestOutgoingTxHash": hexHash,
-	})
+	}
+	if e.IncludeReceipt {
+		txReceipt, err := newEthTxReceipt(*receipt, s.DB)
+		if err != nil {
+			logger.Error(err)
+			return models.NewRunOutputError(err)
+		}
+		kv["receipt"] = txReceipt
+	}
+
+	output := input.Data()
+	output, err = output.MultiAdd(kv)
 	if err != nil {
 		logger.Error("unable to add tx hash to output", err)
 		return models.NewRunOutputError(err)
@@ -232,6 +257,30 @@ func getConfirmedReceipt(ethTxID int64, db *gorm.DB, minRequiredOutgoingConfirma
 
 }
 
+// newEthTxReceipt returns the EthTxReceipt of a receipt, with the gas price
+// of the attempt that was mined
+func newEthTxReceipt(receipt models.EthReceipt, db *gorm.DB) (EthTxReceipt, error) {
+	var attempt models.EthTxAttempt
+	if err := db.Where("hash = ?", receipt.TxHash).First(&attempt).Error; err != nil {
+		return EthTxReceipt{}, errors.Wrap(err, "could not find the attempt of the receipt")
+	}
+
+	txReceipt := EthTxReceipt{
+		TxHash:            receipt.TxHash,
+		BlockHash:         receipt.BlockHash,
+		BlockNumber:       receipt.BlockNumber,
+		EffectiveGasPrice: &attempt.GasPrice,
+	}
+	if gasUsed := gjson.GetBytes(receipt.Receipt, "gasUsed"); gasUsed.Exists() {
+		var err error
+		txReceipt.GasUsed, err = hexutil.DecodeUint64(gasUsed.String())
+		if err != nil {
+			return EthTxReceipt{}, errors.Wrap(err, "invalid gasUsed in receipt")
+		}
+	}
+	return txReceipt, nil
+}
+
 var (
 	ErrInvalidABIEncoding = errors.New("invalid abi encoding")
 	// A base set of supported types, expand as needed.
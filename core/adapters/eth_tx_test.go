@@ -326,6 +326,43 @@ func TestEthTxAdapter_Perform_BPTXM(t *testing.T) {
 		assert.Equal(t, confirmedAttemptHash.Hex(), runOutput.Result().String())
 	})
 
+	t.Run("with includeReceipt set and a confirmed transaction, adds the receipt to the output", func(t *testing.T) {
+		adapter := adapters.EthTx{
+			ToAddress:                        toAddress,
+			GasLimit:                         gasLimit,
+			FunctionSelector:                 functionSelector,
+			DataPrefix:                       dataPrefix,
+			MinRequiredOutgoingConfirmations: 12,
+			IncludeReceipt:                   true,
+		}
+		jobRunID := uuid.NewV4()
+		taskRunID := cltest.MustInsertTaskRun(t, store)
+		etx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 6, 1, fromAddress)
+		attempt := etx.EthTxAttempts[0]
+
+		receipt := cltest.MustInsertEthReceipt(t, store, 1, cltest.NewHash(), attempt.Hash)
+		require.NoError(t, store.DB.Exec(`UPDATE eth_receipts SET receipt = ? WHERE id = ?`, `{"gasUsed": "0x5208"}`, receipt.ID).Error)
+		require.NoError(t, store.IdempotentInsertHead(context.TODO(), models.Head{
+			Hash:   cltest.NewHash(),
+			Number: 13,
+		}))
+		_, err := store.MustSQLDB().Exec(`INSERT INTO eth_task_run_txes (task_run_id, eth_tx_id) VALUES ($1, $2)`, taskRunID, etx.ID)
+		require.NoError(t, err)
+		input := models.NewRunInputWithResult(jobRunID, taskRunID, "0x9786856756", models.RunStatusUnstarted)
+
+		// Do the thing
+		runOutput := adapter.Perform(*input, store)
+
+		require.NoError(t, runOutput.Error())
+		assert.Equal(t, models.RunStatusCompleted, runOutput.Status())
+		assert.Equal(t, attempt.Hash.Hex(), runOutput.Result().String())
+		assert.Equal(t, attempt.Hash.Hex(), runOutput.Get("receipt.transactionHash").String())
+		assert.Equal(t, receipt.BlockHash.Hex(), runOutput.Get("receipt.blockHash").String())
+		assert.Equal(t, int64(1), runOutput.Get("receipt.blockNumber").Int())
+		assert.Equal(t, uint64(21000), runOutput.Get("receipt.gasUsed").Uint())
+		assert.Equal(t, attempt.GasPrice.String(), runOutput.Get("receipt.effectiveGasPrice").String())
+	})
+
 	t.Run("with transaction that ended up in fatal_error state returns job run error", func(t *testing.T) {
 		adapter := adapters.EthTx{
 			ToAddress:        toAddress,
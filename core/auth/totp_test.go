@@ -0,0 +1,91 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA1 test secret from RFC 6238 appendix B,
+// "12345678901234567890", base32 encoded
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			code, err := auth.TOTPCode(rfc6238Secret, auth.TOTPCounter(time.Unix(test.unix, 0)))
+			require.NoError(t, err)
+			assert.Equal(t, test.want, code)
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	t.Parallel()
+
+	secret, err := auth.NewTOTPSecret()
+	require.NoError(t, err)
+	now := time.Now()
+	current := auth.TOTPCounter(now)
+	code, err := auth.TOTPCode(secret, current)
+	require.NoError(t, err)
+
+	counter, ok := auth.ValidateTOTP(secret, code, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, current, counter)
+
+	_, ok = auth.ValidateTOTP(secret, code, now, counter)
+	assert.False(t, ok, "codes can only be used once")
+
+	_, ok = auth.ValidateTOTP(secret, code, now.Add(auth.TOTPPeriod), 0)
+	assert.True(t, ok, "codes from the previous period are accepted")
+
+	_, ok = auth.ValidateTOTP(secret, code, now.Add(5*auth.TOTPPeriod), 0)
+	assert.False(t, ok, "expired codes are rejected")
+
+	_, ok = auth.ValidateTOTP(secret, "12345", now, 0)
+	assert.False(t, ok)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	codes, err := auth.NewRecoveryCodes(3)
+	require.NoError(t, err)
+	require.Len(t, codes, 3)
+	assert.NotEqual(t, codes[0], codes[1])
+	assert.Len(t, codes[0], 17)
+	assert.Equal(t, "-", codes[0][8:9])
+
+	hash := auth.HashRecoveryCode(codes[0])
+	assert.Equal(t, hash, auth.HashRecoveryCode(" "+strings.ToUpper(strings.Replace(codes[0], "-", "", 1))))
+	assert.NotEqual(t, hash, auth.HashRecoveryCode(codes[1]))
+}
+
+func TestTOTPURI(t *testing.T) {
+	t.Parallel()
+
+	uri := auth.TOTPURI(rfc6238Secret, "Chainlink", "apiuser@chainlink.test")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Chainlink:apiuser@chainlink.test?"), uri)
+	assert.Contains(t, uri, "secret="+rfc6238Secret)
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
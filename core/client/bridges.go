@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// ListBridges returns a page of the bridges, and the number of bridges
+func (c *Client) ListBridges(ctx context.Context, opts ListOptions) ([]models.BridgeType, int, error) {
+	var bridges []models.BridgeType
+	count, err := c.do(ctx, http.MethodGet, "/v2/bridge_types", opts.query(), nil, &bridges)
+	return bridges, count, err
+}
+
+// GetBridge returns the bridge with the given name
+func (c *Client) GetBridge(ctx context.Context, name string) (models.BridgeType, error) {
+	var bridge models.BridgeType
+	_, err := c.do(ctx, http.MethodGet, bridgePath(name), nil, nil, &bridge)
+	return bridge, err
+}
+
+// CreateBridge creates a bridge. The token the node authenticates to the
+// bridge's adapter with is only returned here.
+func (c *Client) CreateBridge(ctx context.Context, request models.BridgeTypeRequest) (models.BridgeTypeAuthentication, error) {
+	var bridge models.BridgeTypeAuthentication
+	_, err := c.do(ctx, http.MethodPost, "/v2/bridge_types", nil, request, &bridge)
+	return bridge, err
+}
+
+// UpdateBridge changes the URL, confirmations and minimum contract payment
+// of a bridge
+func (c *Client) UpdateBridge(ctx context.Context, name string, request models.BridgeTypeRequest) (models.BridgeType, error) {
+	var bridge models.BridgeType
+	_, err := c.do(ctx, http.MethodPatch, bridgePath(name), nil, request, &bridge)
+	return bridge, err
+}
+
+// DeleteBridge deletes a bridge that no job uses
+func (c *Client) DeleteBridge(ctx context.Context, name string) error {
+	_, err := c.do(ctx, http.MethodDelete, bridgePath(name), nil, nil, nil)
+	return err
+}
+
+func bridgePath(name string) string {
+	return "/v2/bridge_types/" + url.PathEscape(name)
+}
@@ -0,0 +1,304 @@
+// Package client is a typed Go client for the node's HTTP API. It covers v2
+// jobs and their runs, bridges, keys and the transactions the node has sent,
+// and decodes responses into the same presenters the node serves them from:
+//
+//	c, err := client.NewClient("http://localhost:6688", client.APIToken(accessKey, secret))
+//	jb, err := c.CreateJob(ctx, specTOML)
+//	sub, err := c.SubscribeRuns(ctx, jb.ID)
+//	for run := range sub.Runs() { ... }
+//
+// Requests that the node rejects return an *APIError with the node's error
+// details.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// defaultTimeout bounds each request made by a client from NewClient.
+// Run subscriptions are not bounded by it.
+const defaultTimeout = 30 * time.Second
+
+// Client makes requests against the API of one node. It is safe for
+// concurrent use.
+type Client struct {
+	url         *url.URL
+	http        *http.Client
+	credentials Credentials
+}
+
+// NewClient returns a client for the node at nodeURL, such as
+// "http://localhost:6688"
+func NewClient(nodeURL string, credentials Credentials) (*Client, error) {
+	return NewClientWithHTTPClient(nodeURL, credentials, &http.Client{Timeout: defaultTimeout})
+}
+
+// NewClientWithHTTPClient returns a client for the node at nodeURL that makes
+// its requests with httpClient
+func NewClientWithHTTPClient(nodeURL string, credentials Credentials, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(nodeURL, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid node URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("invalid node URL %q: scheme must be http or https", nodeURL)
+	}
+	if credentials == nil {
+		return nil, errors.New("credentials are required")
+	}
+	return &Client{url: u, http: httpClient, credentials: credentials}, nil
+}
+
+// Credentials authenticate a client's requests
+type Credentials interface {
+	authenticate(ctx context.Context, c *Client, req *http.Request) error
+	// expire is called when a request was rejected as unauthenticated, and
+	// reports whether it is worth retrying with fresh credentials
+	expire() bool
+}
+
+type apiToken struct {
+	accessKey, secret string
+}
+
+// APIToken authenticates with an API token, as created with
+// POST /v2/user/token
+func APIToken(accessKey, secret string) Credentials {
+	return apiToken{accessKey, secret}
+}
+
+func (t apiToken) authenticate(_ context.Context, _ *Client, req *http.Request) error {
+	req.Header.Set("X-API-KEY", t.accessKey)
+	req.Header.Set("X-API-SECRET", t.secret)
+	return nil
+}
+
+func (apiToken) expire() bool { return false }
+
+type session struct {
+	request models.SessionRequest
+
+	mu      sync.Mutex
+	cookies []*http.Cookie
+}
+
+// Session authenticates with a session, which is logged in to with the
+// email and password, and TOTP or recovery code if two-factor authentication
+// is enabled, of the node's API user. The session is logged in to again when
+// it expires, which requires a fresh TOTP code.
+func Session(request models.SessionRequest) Credentials {
+	return &session{request: request}
+}
+
+func (s *session) authenticate(ctx context.Context, c *Client, req *http.Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cookies == nil {
+		cookies, err := s.login(ctx, c)
+		if err != nil {
+			return err
+		}
+		s.cookies = cookies
+	}
+	for _, cookie := range s.cookies {
+		req.AddCookie(cookie)
+	}
+	return nil
+}
+
+func (s *session) login(ctx context.Context, c *Client) ([]*http.Cookie, error) {
+	body, err := json.Marshal(s.request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url.String()+"/sessions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to log in")
+	}
+	defer logger.ErrorIfCalling(resp.Body.Close)
+	if err := checkResponse(resp); err != nil {
+		return nil, errors.Wrap(err, "failed to log in")
+	}
+	return resp.Cookies(), nil
+}
+
+func (s *session) expire() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cookies = nil
+	return true
+}
+
+// APIError is returned for requests the node responded to with an error
+// status
+type APIError struct {
+	StatusCode int
+	Errors     []models.JSONAPIError
+}
+
+func (e *APIError) Error() string {
+	details := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		details[i] = err.String()
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), strings.Join(details, "; "))
+}
+
+// IsNotFound reports whether err is an APIError for a resource that does not
+// exist
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// checkResponse returns an *APIError for error responses, with the errors of
+// the JSON API error document in the body, or the body itself if it is not
+// one
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %d response", resp.StatusCode)
+	}
+	var document models.JSONAPIErrors
+	if json.Unmarshal(body, &document) == nil && len(document.Errors) > 0 {
+		apiErr.Errors = document.Errors
+	} else if detail := strings.TrimSpace(string(body)); detail != "" {
+		apiErr.Errors = []models.JSONAPIError{{Detail: detail}}
+	}
+	return apiErr
+}
+
+// ListOptions selects a page of a listing. The zero value selects the
+// node's default page.
+type ListOptions struct {
+	// Page is the 1-based page number
+	Page int
+	Size int
+	// Sort is the attribute to sort by, prefixed with "-" for descending
+	// order. Not every listing can be sorted.
+	Sort string
+	// Filters are matched against the attributes of the same names. Not
+	// every listing can be filtered.
+	Filters map[string]string
+}
+
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+	if o.Page > 0 {
+		query.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Size > 0 {
+		query.Set("size", strconv.Itoa(o.Size))
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	for name, value := range o.Filters {
+		query.Set("filter["+name+"]", value)
+	}
+	return query
+}
+
+// send makes an authenticated request, retrying it once with fresh
+// credentials if it is rejected as unauthenticated. The response must be
+// closed by the caller.
+func (c *Client) send(ctx context.Context, httpClient *http.Client, method, path string, query url.Values, body []byte) (*http.Response, error) {
+	u := c.url.String() + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	for attempt := 0; ; attempt++ {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u, reader)
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if err = c.credentials.authenticate(ctx, c, req); err != nil {
+			return nil, err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 && c.credentials.expire() {
+			logger.ErrorIfCalling(resp.Body.Close)
+			continue
+		}
+		if err := checkResponse(resp); err != nil {
+			logger.ErrorIfCalling(resp.Body.Close)
+			return nil, err
+		}
+		return resp, nil
+	}
+}
+
+// do makes a request with in, if it is not nil, as its JSON body, and
+// unmarshals the JSON API document in the response into out, if it is not
+// nil. It returns the document's count meta, which is set on paginated
+// responses.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) (int, error) {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return 0, err
+		}
+	}
+	resp, err := c.send(ctx, c.http, method, path, query, body)
+	if err != nil {
+		return 0, errors.Wrapf(err, "%s %s", method, path)
+	}
+	defer logger.ErrorIfCalling(resp.Body.Close)
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return 0, nil
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, errors.Wrapf(err, "%s %s: failed to read response", method, path)
+	}
+	if err = jsonapi.Unmarshal(b, out); err != nil {
+		return 0, errors.Wrapf(err, "%s %s: failed to unmarshal %T", method, path, out)
+	}
+	var document struct {
+		Meta struct {
+			Count int `json:"count"`
+		} `json:"meta"`
+	}
+	if err = json.Unmarshal(b, &document); err != nil {
+		return 0, errors.Wrapf(err, "%s %s: failed to unmarshal meta", method, path)
+	}
+	return document.Meta.Count, nil
+}
@@ -0,0 +1,222 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/client"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func setupClientTests(t *testing.T) (*cltest.TestApplication, *client.Client, func()) {
+	t.Parallel()
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	require.NoError(t, app.Start())
+	gethClient.On("SubscribeFilterLogs", mock.Anything, mock.Anything, mock.Anything).Maybe().Return(cltest.EmptyMockSubscription(), nil)
+
+	c, err := client.NewClient(app.Server.URL, client.Session(models.SessionRequest{
+		Email:    cltest.APIEmail,
+		Password: cltest.Password,
+	}))
+	require.NoError(t, err)
+	return app, c, cleanup
+}
+
+func TestClient_JobsAndRuns(t *testing.T) {
+	_, c, cleanup := setupClientTests(t)
+	defer cleanup()
+	ctx := context.Background()
+	mockHTTP, cleanupHTTP := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"USD": 42}`)
+	defer cleanupHTTP()
+
+	spec := fmt.Sprintf(`
+type            = "directrequest"
+schemaVersion   = 1
+name            = "client test"
+contractAddress = "0x613a38AC1659769640aaE063C651F48E0250454C"
+observationSource = """
+    ds       [type=http method=GET url="%s" allowunrestrictednetworkaccess="true"];
+    ds_parse [type=jsonparse path="USD"];
+    ds -> ds_parse;
+"""
+`, mockHTTP.URL)
+
+	validated, err := c.ValidateJob(ctx, spec)
+	require.NoError(t, err)
+	assert.Equal(t, "client test", validated.Name)
+	assert.Empty(t, validated.ID)
+
+	_, err = c.ValidateJob(ctx, "type = \"directrequest\"\nschemaVersion = \"one\"\n")
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	require.Len(t, apiErr.Errors, 1)
+	assert.Equal(t, &models.JSONAPIErrorMeta{Line: 2, Column: 1}, apiErr.Errors[0].Meta)
+
+	jb, err := c.CreateJob(ctx, spec)
+	require.NoError(t, err)
+	require.NotEmpty(t, jb.ID)
+
+	jobs, count, err := c.ListJobs(ctx, client.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, jb.ID, jobs[0].ID)
+
+	got, err := c.GetJob(ctx, jb.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "client test", got.Name)
+
+	sub, err := c.SubscribeRuns(ctx, jb.ID)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	triggered, err := c.TriggerRun(ctx, jb.ID, nil)
+	require.NoError(t, err)
+
+	select {
+	case run := <-sub.Runs():
+		assert.Equal(t, triggered.ID, run.ID)
+		assert.NotNil(t, run.FinishedAt)
+		assert.False(t, run.Errored)
+		assert.Len(t, run.TaskRuns, 2)
+	case <-time.After(cltest.DBWaitTimeout):
+		t.Fatal("timed out waiting for the run to finish")
+	}
+
+	runs, count, err := c.ListRuns(ctx, jb.ID, client.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, runs, 1)
+	assert.Equal(t, triggered.ID, runs[0].ID)
+
+	taskRuns, err := c.ListTaskRuns(ctx, jb.ID, triggered.ID)
+	require.NoError(t, err)
+	assert.Len(t, taskRuns, 2)
+
+	sub.Close()
+	_, open := <-sub.Runs()
+	assert.False(t, open)
+	assert.NoError(t, sub.Err())
+
+	require.NoError(t, c.DeleteJob(ctx, jb.ID, true))
+	_, err = c.GetJob(ctx, jb.ID)
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestClient_Bridges(t *testing.T) {
+	_, c, cleanup := setupClientTests(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	created, err := c.CreateBridge(ctx, models.BridgeTypeRequest{
+		Name: models.MustNewTaskType("clientbridge"),
+		URL:  cltest.WebURL(t, "http://a.example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "clientbridge", created.Name.String())
+	assert.NotEmpty(t, created.OutgoingToken)
+
+	updated, err := c.UpdateBridge(ctx, "clientbridge", models.BridgeTypeRequest{
+		Name: models.MustNewTaskType("clientbridge"),
+		URL:  cltest.WebURL(t, "http://b.example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "http://b.example.com", updated.URL.String())
+
+	bridges, count, err := c.ListBridges(ctx, client.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, bridges, 1)
+	assert.Equal(t, "http://b.example.com", bridges[0].URL.String())
+
+	require.NoError(t, c.DeleteBridge(ctx, "clientbridge"))
+	_, err = c.GetBridge(ctx, "clientbridge")
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestClient_KeysAndTransactions(t *testing.T) {
+	app, c, cleanup := setupClientTests(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	bundle, err := c.CreateOCRKeyBundle(ctx)
+	require.NoError(t, err)
+	bundles, err := c.ListOCRKeyBundles(ctx)
+	require.NoError(t, err)
+	stored, err := app.Store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, bundles, len(stored))
+	var found bool
+	for _, b := range bundles {
+		found = found || b.ID == bundle.ID
+	}
+	assert.True(t, found)
+
+	p2pKey, err := c.CreateP2PKey(ctx)
+	require.NoError(t, err)
+	p2pKeys, err := c.ListP2PKeys(ctx)
+	require.NoError(t, err)
+	storedP2P, err := app.Store.OCRKeyStore.FindEncryptedP2PKeys()
+	require.NoError(t, err)
+	require.Len(t, p2pKeys, len(storedP2P))
+	found = false
+	for _, key := range p2pKeys {
+		found = found || key.PeerID == p2pKey.PeerID
+	}
+	assert.True(t, found)
+
+	_, from := cltest.MustAddRandomKeyToKeystore(t, app.Store, 0)
+	tx := cltest.MustInsertConfirmedEthTxWithAttempt(t, app.Store, 0, 1, from)
+
+	txs, count, err := c.ListTransactions(ctx, client.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, txs, 1)
+	assert.Equal(t, tx.EthTxAttempts[0].Hash, txs[0].Hash)
+
+	got, err := c.GetTransaction(ctx, tx.EthTxAttempts[0].Hash)
+	require.NoError(t, err)
+	assert.Equal(t, from, *got.From)
+}
+
+func TestClient_Session(t *testing.T) {
+	app, c, cleanup := setupClientTests(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_, _, err := c.ListJobs(ctx, client.ListOptions{})
+	require.NoError(t, err)
+
+	// The client logs in again once its session has expired
+	require.NoError(t, app.Store.DB.Exec("DELETE FROM sessions").Error)
+	_, _, err = c.ListJobs(ctx, client.ListOptions{})
+	require.NoError(t, err)
+
+	bad, err := client.NewClient(app.Server.URL, client.Session(models.SessionRequest{
+		Email:    cltest.APIEmail,
+		Password: "wrong",
+	}))
+	require.NoError(t, err)
+	_, _, err = bad.ListJobs(ctx, client.ListOptions{})
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+
+	_, err = client.NewClient("localhost:6688", client.APIToken("key", "secret"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// ListJobs returns a page of the v2 jobs, and the number of jobs
+func (c *Client) ListJobs(ctx context.Context, opts ListOptions) ([]presenters.JobResource, int, error) {
+	var jobs []presenters.JobResource
+	count, err := c.do(ctx, http.MethodGet, "/v2/jobs", opts.query(), nil, &jobs)
+	return jobs, count, err
+}
+
+// GetJob returns the v2 job with the given ID
+func (c *Client) GetJob(ctx context.Context, jobID string) (presenters.JobResource, error) {
+	var jb presenters.JobResource
+	_, err := c.do(ctx, http.MethodGet, "/v2/jobs/"+url.PathEscape(jobID), nil, nil, &jb)
+	return jb, err
+}
+
+// CreateJob creates and starts a v2 job from its TOML spec. Errors in the
+// spec are returned in an *APIError, with their positions in the spec.
+func (c *Client) CreateJob(ctx context.Context, specTOML string) (presenters.JobResource, error) {
+	var jb presenters.JobResource
+	_, err := c.do(ctx, http.MethodPost, "/v2/jobs", nil, models.CreateJobSpecRequest{TOML: specTOML}, &jb)
+	return jb, err
+}
+
+// ValidateJob validates a v2 job's TOML spec without creating the job, and
+// returns the job as it would be created, without an ID
+func (c *Client) ValidateJob(ctx context.Context, specTOML string) (presenters.JobResource, error) {
+	var jb presenters.JobResource
+	query := url.Values{"validateOnly": []string{"true"}}
+	_, err := c.do(ctx, http.MethodPost, "/v2/jobs", query, models.CreateJobSpecRequest{TOML: specTOML}, &jb)
+	return jb, err
+}
+
+// DeleteJob stops the v2 job with the given ID and archives it, so that it
+// is deleted along with its runs once JOB_ARCHIVE_RETENTION has passed. With
+// purge, the job and its runs are deleted immediately.
+func (c *Client) DeleteJob(ctx context.Context, jobID string, purge bool) error {
+	var query url.Values
+	if purge {
+		query = url.Values{"purge": []string{"true"}}
+	}
+	_, err := c.do(ctx, http.MethodDelete, "/v2/jobs/"+url.PathEscape(jobID), query, nil, nil)
+	return err
+}
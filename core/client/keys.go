@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+)
+
+// ListETHKeys returns a page of the node's ETH keys, with their balances, and
+// the number of keys
+func (c *Client) ListETHKeys(ctx context.Context, opts ListOptions) ([]presenters.ETHKey, int, error) {
+	var keys []presenters.ETHKey
+	count, err := c.do(ctx, http.MethodGet, "/v2/keys/eth", opts.query(), nil, &keys)
+	return keys, count, err
+}
+
+// CreateETHKey generates an ETH key
+func (c *Client) CreateETHKey(ctx context.Context) (presenters.ETHKey, error) {
+	var key presenters.ETHKey
+	_, err := c.do(ctx, http.MethodPost, "/v2/keys/eth", nil, nil, &key)
+	return key, err
+}
+
+// ListOCRKeyBundles returns the node's OCR key bundles
+func (c *Client) ListOCRKeyBundles(ctx context.Context) ([]ocrkey.EncryptedKeyBundle, error) {
+	var bundles []ocrkey.EncryptedKeyBundle
+	_, err := c.do(ctx, http.MethodGet, "/v2/keys/ocr", nil, nil, &bundles)
+	return bundles, err
+}
+
+// CreateOCRKeyBundle generates an OCR key bundle
+func (c *Client) CreateOCRKeyBundle(ctx context.Context) (ocrkey.EncryptedKeyBundle, error) {
+	var bundle ocrkey.EncryptedKeyBundle
+	_, err := c.do(ctx, http.MethodPost, "/v2/keys/ocr", nil, nil, &bundle)
+	return bundle, err
+}
+
+// ListP2PKeys returns the node's P2P keys
+func (c *Client) ListP2PKeys(ctx context.Context) ([]p2pkey.EncryptedP2PKey, error) {
+	var keys []p2pkey.EncryptedP2PKey
+	_, err := c.do(ctx, http.MethodGet, "/v2/keys/p2p", nil, nil, &keys)
+	return keys, err
+}
+
+// CreateP2PKey generates a P2P key
+func (c *Client) CreateP2PKey(ctx context.Context) (p2pkey.EncryptedP2PKey, error) {
+	var key p2pkey.EncryptedP2PKey
+	_, err := c.do(ctx, http.MethodPost, "/v2/keys/p2p", nil, nil, &key)
+	return key, err
+}
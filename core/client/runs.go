@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// ListRuns returns a page of the runs of a v2 job, and the number of runs of
+// the job
+func (c *Client) ListRuns(ctx context.Context, jobID string, opts ListOptions) ([]presenters.PipelineRunResource, int, error) {
+	var runs []presenters.PipelineRunResource
+	count, err := c.do(ctx, http.MethodGet, runsPath(jobID), opts.query(), nil, &runs)
+	return runs, count, err
+}
+
+// GetRun returns a run of a v2 job, with its task runs
+func (c *Client) GetRun(ctx context.Context, jobID, runID string) (presenters.PipelineRunResource, error) {
+	var run presenters.PipelineRunResource
+	_, err := c.do(ctx, http.MethodGet, runsPath(jobID)+"/"+url.PathEscape(runID), nil, nil, &run)
+	return run, err
+}
+
+// ListTaskRuns returns the task runs of a run of a v2 job
+func (c *Client) ListTaskRuns(ctx context.Context, jobID, runID string) ([]presenters.PipelineTaskRunResource, error) {
+	var taskRuns []presenters.PipelineTaskRunResource
+	_, err := c.do(ctx, http.MethodGet, runsPath(jobID)+"/"+url.PathEscape(runID)+"/task_runs", nil, nil, &taskRuns)
+	return taskRuns, err
+}
+
+// TriggerRun runs a v2 job with the given meta, which may be nil, and
+// returns the run. The run may not have finished yet; GetRun and
+// SubscribeRuns follow it to completion.
+func (c *Client) TriggerRun(ctx context.Context, jobID string, meta map[string]interface{}) (presenters.PipelineRunResource, error) {
+	var run presenters.PipelineRunResource
+	var in interface{}
+	if meta != nil {
+		in = meta
+	}
+	_, err := c.do(ctx, http.MethodPost, runsPath(jobID), nil, in, &run)
+	return run, err
+}
+
+func runsPath(jobID string) string {
+	return "/v2/jobs/" + url.PathEscape(jobID) + "/runs"
+}
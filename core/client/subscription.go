@@ -0,0 +1,197 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// streamReconnectDelay is how long a subscription waits before reconnecting
+// to an event stream that was cut off
+const streamReconnectDelay = time.Second
+
+// RunSubscription receives the runs of v2 jobs as they finish
+type RunSubscription struct {
+	client *Client
+	jobID  string
+	runs   chan presenters.PipelineRunResource
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// SubscribeRuns follows the runs of the v2 job with the given ID, or of every
+// v2 job if jobID is empty, over the node's GET /v2/events stream. Each run
+// is fetched with its task runs once it has finished, and sent on Runs.
+//
+// The stream is reconnected whenever the node cuts it off. Runs that finish
+// while it is reconnecting, or while the subscriber has fallen behind, are
+// missed, so subscribers that must see every run should catch up with
+// ListRuns after reconnecting.
+func (c *Client) SubscribeRuns(ctx context.Context, jobID string) (*RunSubscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &RunSubscription{
+		client: c,
+		jobID:  jobID,
+		runs:   make(chan presenters.PipelineRunResource),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	// The first connection is made up front so that bad credentials or an
+	// unreachable node are returned here
+	stream, err := sub.connect(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	go sub.run(ctx, stream)
+	return sub, nil
+}
+
+// Runs returns the channel the finished runs are received on. It is closed
+// once the subscription has ended, after which Err returns the reason.
+func (s *RunSubscription) Runs() <-chan presenters.PipelineRunResource {
+	return s.runs
+}
+
+// Err returns the error that ended the subscription, or nil if it was
+// closed or its context was canceled
+func (s *RunSubscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close ends the subscription. It may be called more than once.
+func (s *RunSubscription) Close() {
+	s.cancel()
+	<-s.done
+}
+
+func (s *RunSubscription) connect(ctx context.Context) (io.ReadCloser, error) {
+	// The stream lasts until the node cuts it off, so it must not be bound
+	// by the timeout of the client's requests
+	streamClient := *s.client.http
+	streamClient.Timeout = 0
+	query := url.Values{"topics": []string{string(eventbus.TopicRunFinished)}}
+	resp, err := s.client.send(ctx, &streamClient, http.MethodGet, "/v2/events", query, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to subscribe to runs")
+	}
+	return resp.Body, nil
+}
+
+func (s *RunSubscription) run(ctx context.Context, stream io.ReadCloser) {
+	defer close(s.done)
+	defer close(s.runs)
+
+	for {
+		err := s.follow(ctx, stream)
+		logger.ErrorIfCalling(stream.Close)
+		if ctx.Err() != nil {
+			return
+		} else if err != nil {
+			s.setErr(err)
+			return
+		}
+
+		select {
+		case <-time.After(streamReconnectDelay):
+		case <-ctx.Done():
+			return
+		}
+		if stream, err = s.connect(ctx); err != nil {
+			if ctx.Err() == nil {
+				s.setErr(err)
+			}
+			return
+		}
+	}
+}
+
+// follow sends the runs finished on the stream until the stream ends, which
+// returns nil, or a run can't be fetched
+func (s *RunSubscription) follow(ctx context.Context, stream io.Reader) error {
+	scanner := bufio.NewScanner(stream)
+	var topic, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			field, value := splitEventField(line)
+			switch field {
+			case "event":
+				topic = value
+			case "data":
+				data += value
+			}
+			continue
+		}
+
+		if topic == string(eventbus.TopicRunFinished) && data != "" {
+			if err := s.send(ctx, data); err != nil {
+				return err
+			}
+		}
+		topic, data = "", ""
+	}
+	return nil
+}
+
+func (s *RunSubscription) send(ctx context.Context, data string) error {
+	var event eventbus.RunFinished
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return errors.Wrap(err, "failed to unmarshal run_finished event")
+	}
+	if s.jobID != "" && event.JobID != s.jobID {
+		return nil
+	}
+	// The runs of v1 jobs are identified by UUIDs, and runs of deleted jobs
+	// have no job
+	if _, err := strconv.ParseInt(event.RunID, 10, 64); err != nil || event.JobID == "" {
+		return nil
+	}
+
+	run, err := s.client.GetRun(ctx, event.JobID, event.RunID)
+	if IsNotFound(err) {
+		// The job was deleted since the run finished
+		return nil
+	} else if err != nil {
+		return err
+	}
+	select {
+	case s.runs <- run:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func (s *RunSubscription) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// splitEventField splits a line of a server-sent event into its field name
+// and value
+func splitEventField(line string) (string, string) {
+	i := strings.IndexByte(line, ':')
+	if i < 0 {
+		return line, ""
+	}
+	return line[:i], strings.TrimPrefix(line[i+1:], " ")
+}
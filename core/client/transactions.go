@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+)
+
+// ListTransactions returns a page of the transactions the node has sent,
+// such as OCR transmissions and fulfillments, with their latest attempts,
+// and the number of transactions. Only the page and size of opts are used.
+func (c *Client) ListTransactions(ctx context.Context, opts ListOptions) ([]presenters.EthTx, int, error) {
+	var txs []presenters.EthTx
+	count, err := c.do(ctx, http.MethodGet, "/v2/transactions", opts.query(), nil, &txs)
+	return txs, count, err
+}
+
+// GetTransaction returns the transaction sent by the node that has an
+// attempt with the given hash
+func (c *Client) GetTransaction(ctx context.Context, hash common.Hash) (presenters.EthTx, error) {
+	var tx presenters.EthTx
+	_, err := c.do(ctx, http.MethodGet, "/v2/transactions/"+hash.Hex(), nil, nil, &tx)
+	return tx, err
+}
@@ -56,6 +56,41 @@ func NewApp(client *Client) *cli.App {
 							Name:  "file, f",
 							Usage: "text file holding the API email and password needed to create a session cookie",
 						},
+						cli.StringFlag{
+							Name:  "totp",
+							Usage: "two-factor authentication code, required if two-factor authentication is enabled",
+						},
+						cli.StringFlag{
+							Name:  "recovery-code",
+							Usage: "recovery code to use in place of a two-factor authentication code",
+						},
+					},
+				},
+				{
+					Name:   "export",
+					Usage:  "Export the node's jobs, bridges, external initiators and config overrides to an archive, from which a node can be rebuilt. Keys are not included, but bridge and external initiator credentials are, so keep the archive safe.",
+					Action: client.ExportNodeState,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "output, o",
+							Usage: "the path where the archive will be saved",
+						},
+					},
+				},
+				{
+					Name:   "import",
+					Usage:  "Import an archive created with export, skipping anything that already exists on the node",
+					Action: client.ImportNodeState,
+				},
+				{
+					Name:   "apply",
+					Usage:  "Make the node's bridges, ETH keys, OCR key bundles and config overrides match a YAML manifest. Missing bridges and keys are created, and bridges and config overrides that differ are updated. Nothing is deleted.",
+					Action: client.ApplyManifest,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "dry-run",
+							Usage: "only print the changes that would be made",
+						},
 					},
 				},
 			},
@@ -221,23 +256,48 @@ func NewApp(client *Client) *cli.App {
 							Name:  "page",
 							Usage: "page of results to display",
 						},
+						cli.BoolFlag{
+							Name:  "archived",
+							Usage: "list deleted jobs that are being kept until JOB_ARCHIVE_RETENTION has passed",
+						},
 					},
 				},
 				{
 					Name:   "create",
-					Usage:  "Create a V2 job",
+					Usage:  "Create a V2 job from a TOML or JSON spec, a path to one, an http(s) URL to fetch it from, or - to read it from stdin",
 					Action: client.CreateJobV2,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "validate-only",
+							Usage: "only validate the spec, printing any errors with their line in the TOML, without creating the job",
+						},
+					},
 				},
 				{
 					Name:   "delete",
-					Usage:  "Delete a V2 job",
+					Usage:  "Delete a V2 job. The job is archived, keeping it and its runs until JOB_ARCHIVE_RETENTION has passed. Its in-flight runs are cancelled.",
 					Action: client.DeleteJobV2,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "purge",
+							Usage: "delete the job and its runs immediately, rather than archiving them",
+						},
+						cli.BoolFlag{
+							Name:  "wait",
+							Usage: "wait for the job's in-flight runs to finish, rather than cancelling them",
+						},
+					},
 				},
 				{
 					Name:   "run",
 					Usage:  "Trigger a V2 job run",
 					Action: client.TriggerPipelineRun,
 				},
+				{
+					Name:   "convert",
+					Usage:  "Convert a V1 JSON job spec, or a path to one, to a V2 TOML job spec, printing it with what could not be converted in comments",
+					Action: client.ConvertJobSpecV1,
+				},
 			},
 		},
 		{
@@ -257,6 +317,12 @@ func NewApp(client *Client) *cli.App {
 							Name:   "list",
 							Usage:  "List available Ethereum accounts with their ETH & LINK balances, nonces, and other metadata",
 							Action: client.ListETHKeys,
+							Flags: []cli.Flag{
+								cli.IntFlag{
+									Name:  "page",
+									Usage: "page of results to display",
+								},
+							},
 						},
 						{
 							Name:  "delete",
@@ -585,9 +651,38 @@ func NewApp(client *Client) *cli.App {
 				{
 					Name:        "db",
 					Usage:       "Commands for managing the database.",
-					Description: "Potentially destructive commands for managing the database, only intended for dev/testing purposes.",
-					Hidden:      !client.Config.Dev(),
+					Description: "Potentially destructive commands for managing the database. Commands that erase data are only intended for dev/testing purposes.",
 					Subcommands: []cli.Command{
+						{
+							Name:   "migrate",
+							Usage:  "Apply pending database migrations. The node must be stopped first.",
+							Action: client.MigrateDatabase,
+							Flags: []cli.Flag{
+								cli.BoolFlag{
+									Name:  "dry-run",
+									Usage: "print the SQL of the pending migrations without running them",
+								},
+								cli.StringFlag{
+									Name:  "to",
+									Usage: "ID of the last migration to apply, defaults to the latest",
+								},
+							},
+						},
+						{
+							Name:   "rollback",
+							Usage:  "Roll back database migrations. The node must be stopped first.",
+							Action: client.RollbackDatabase,
+							Flags: []cli.Flag{
+								cli.BoolFlag{
+									Name:  "dry-run",
+									Usage: "print the SQL of the rollbacks without running them",
+								},
+								cli.StringFlag{
+									Name:  "to",
+									Usage: "ID of the migration to roll back to, which is kept",
+								},
+							},
+						},
 						{
 							Name:   "reset",
 							Usage:  "Drop, create and migrate database. Useful for setting up the database in order to run tests or resetting the dev database. WARNING: This will ERASE ALL DATA for the specified DATABASE_URL.",
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path"
@@ -24,11 +25,14 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/web"
+	"github.com/smartcontractkit/chainlink/core/web/grpcapi"
 
 	"github.com/gin-gonic/gin"
 	clipkg "github.com/urfave/cli"
 	"go.uber.org/multierr"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var (
@@ -75,11 +79,12 @@ func (n ChainlinkAppFactory) NewApplication(config *orm.Config, onConnectCallbac
 		logger.Info("ETH_DISABLED is set, using Null eth.Client")
 		ethClient = &eth.NullClient{}
 	} else {
-		var err error
-		ethClient, err = eth.NewClient(config.EthereumURL(), config.EthereumSecondaryURLs()...)
+		client, err := eth.NewClient(config.EthereumURL(), config.EthereumSecondaryURLs()...)
 		if err != nil {
 			return nil, err
 		}
+		client.EnableReadCache(config.EthReadCacheTTL())
+		ethClient = eth.NewMultiplexedClient(client)
 	}
 
 	advisoryLock := postgres.NewAdvisoryLock(config.DatabaseURL())
@@ -110,20 +115,57 @@ func (n ChainlinkRunner) Run(app chainlink.Application) error {
 		g.Go(func() error { return runServer(handler, config.Port(), config.HTTPServerWriteTimeout()) })
 	}
 
+	// The HTTPS and gRPC servers share the certificate, so that both pick up
+	// reloads of it
+	var reloader *web.CertReloader
 	if config.TLSPort() != 0 {
+		var err error
+		reloader, err = web.NewCertReloader(config.CertFile(), config.KeyFile())
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+		app.(*chainlink.ChainlinkApplication).Go(func(chStop <-chan struct{}) {
+			reloader.Run(chStop, config.TLSCertReloadInterval())
+		})
+
 		g.Go(func() error {
-			return runServerTLS(
-				handler,
-				config.TLSPort(),
-				config.CertFile(),
-				config.KeyFile(),
-				config.HTTPServerWriteTimeout())
+			return runServerTLS(handler, reloader, config)
 		})
 	}
 
+	if config.GRPCPort() != 0 {
+		g.Go(func() error { return runGRPCServer(app, reloader, config) })
+	}
+
 	return g.Wait()
 }
 
+// runGRPCServer serves the gRPC operator API, over TLS with the HTTPS
+// server's certificate when HTTPS is enabled
+func runGRPCServer(app chainlink.Application, reloader *web.CertReloader, config *orm.Config) error {
+	var opts []grpc.ServerOption
+	if reloader != nil {
+		tlsConfig, err := web.NewServerTLSConfig(reloader, "")
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	port := config.GRPCPort()
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	logger.Infof("Listening and serving gRPC on port %d", port)
+	err = grpcapi.NewServer(app, opts...).Serve(lis)
+	logger.ErrorIf(err)
+	return err
+}
+
 func runServer(handler *gin.Engine, port uint16, writeTimeout time.Duration) error {
 	logger.Infof("Listening and serving HTTP on port %d", port)
 	server := createServer(handler, port, writeTimeout)
@@ -132,10 +174,18 @@ func runServer(handler *gin.Engine, port uint16, writeTimeout time.Duration) err
 	return err
 }
 
-func runServerTLS(handler *gin.Engine, port uint16, certFile, keyFile string, writeTimeout time.Duration) error {
+func runServerTLS(handler *gin.Engine, reloader *web.CertReloader, config *orm.Config) error {
+	tlsConfig, err := web.NewServerTLSConfig(reloader, config.TLSClientCAPath())
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	port := config.TLSPort()
 	logger.Infof("Listening and serving HTTPS on port %d", port)
-	server := createServer(handler, port, writeTimeout)
-	err := server.ListenAndServeTLS(certFile, keyFile)
+	server := createServer(handler, port, config.HTTPServerWriteTimeout())
+	server.TLSConfig = tlsConfig
+	err = server.ListenAndServeTLS("", "")
 	logger.ErrorIf(err)
 	return err
 }
@@ -13,6 +13,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/go-gormigrate/gormigrate/v2"
 	"github.com/smartcontractkit/chainlink/core/store/dialects"
 	"github.com/smartcontractkit/chainlink/core/store/migrations"
 
@@ -76,6 +77,10 @@ func (cli *Client) RunNode(c *clipkg.Context) error {
 		return cli.errorOut(errors.Wrapf(authErr, "while authenticating with OCR password"))
 	}
 
+	if err = setupColumnEncryption(store, keyStorePwd); err != nil {
+		return cli.errorOut(errors.Wrap(err, "while enabling database column encryption"))
+	}
+
 	if len(c.String("vrfpassword")) != 0 {
 		vrfpwd, fileErr := passwordFromFile(c.String("vrfpassword"))
 		if fileErr != nil {
@@ -214,6 +219,30 @@ func logConfigVariables(store *strpkg.Store) error {
 	return nil
 }
 
+// setupColumnEncryption encrypts sensitive database columns with the key in
+// DATABASE_ENCRYPTION_KEY_FILE or, if it's unset, one derived from the
+// keystore password
+func setupColumnEncryption(store *strpkg.Store, keyStorePwd string) error {
+	var key []byte
+	var err error
+	if path := store.Config.DatabaseEncryptionKeyFile(); path != "" {
+		key, err = orm.ReadColumnEncryptionKey(path)
+	} else {
+		var salt []byte
+		if salt, err = store.ColumnEncryptionSalt(); err == nil {
+			key, err = orm.DeriveColumnEncryptionKey(keyStorePwd, salt)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	cipher, err := orm.NewColumnCipher(key)
+	if err != nil {
+		return err
+	}
+	return store.EnableColumnEncryption(cipher)
+}
+
 func setupFundingKey(ctx context.Context, str *strpkg.Store, pwd string) (*models.Key, *big.Int, error) {
 	key := models.Key{}
 	err := str.DB.Where("is_funding = TRUE").First(&key).Error
@@ -381,6 +410,84 @@ func (cli *Client) PrepareTestDatabase(c *clipkg.Context) error {
 	return nil
 }
 
+// MigrateDatabase applies the pending database migrations, up to and
+// including the one given by --to. With --dry-run, their SQL is printed
+// instead.
+func (cli *Client) MigrateDatabase(c *clipkg.Context) error {
+	return cli.runMigrations(c, false)
+}
+
+// RollbackDatabase rolls back the database migrations applied after the one
+// given by --to. With --dry-run, their SQL is printed instead.
+func (cli *Client) RollbackDatabase(c *clipkg.Context) error {
+	if c.String("to") == "" {
+		return cli.errorOut(errors.New("must pass the ID of the migration to roll back to with --to"))
+	}
+	return cli.runMigrations(c, true)
+}
+
+func (cli *Client) runMigrations(c *clipkg.Context, down bool) (err error) {
+	logger.SetLogger(cli.Config.CreateProductionLogger())
+	config := cli.Config
+	to := c.String("to")
+	dbURL := config.DatabaseURL()
+	orm, err := orm.NewORM(dbURL.String(), config.DatabaseTimeout(), gracefulpanic.NewSignal(), config.GetDatabaseDialectConfiguredOrDefault(), config.GetAdvisoryLockIDConfiguredOrDefault(), config.GlobalLockRetryInterval().Duration(), config.ORMMaxOpenConns(), config.ORMMaxIdleConns())
+	if err != nil {
+		return cli.errorOut(fmt.Errorf("failed to initialize orm: %v", err))
+	}
+	defer func() {
+		if cerr := orm.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var ms []*gormigrate.Migration
+	if down {
+		ms, err = migrations.Applied(orm.DB, to)
+	} else {
+		ms, err = migrations.Pending(orm.DB, to)
+	}
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	if len(ms) == 0 {
+		fmt.Println("No migrations to run")
+		return nil
+	}
+
+	if c.Bool("dry-run") {
+		results, err := migrations.DryRun(orm.DB, ms, down)
+		if err != nil {
+			return cli.errorOut(err)
+		}
+		for _, result := range results {
+			fmt.Printf("-- %s\n", result.ID)
+			for _, statement := range result.Statements {
+				fmt.Println(strings.TrimSpace(statement))
+			}
+			fmt.Println()
+		}
+		return nil
+	}
+
+	orm.SetLogging(config.LogSQLStatements() || config.LogSQLMigrations())
+	err = orm.RawDBWithAdvisoryLock(func(db *gorm.DB) error {
+		if down {
+			return migrations.MigrateDownTo(db, to)
+		}
+		return migrations.MigrateUp(db, to)
+	})
+	if err != nil {
+		return cli.errorOut(errors.Wrap(err, "failed to run migrations"))
+	}
+	current, err := migrations.Current(orm.DB)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	fmt.Printf("Ran %d migrations, the database is now at %s\n", len(ms), current)
+	return nil
+}
+
 func dropAndCreateDB(parsed url.URL) (err error) {
 	// Cannot drop the database if we are connected to it, so we must connect
 	// to a different one. template1 should be present on all postgres installations
@@ -83,6 +83,12 @@ func (j Job) GetName() string {
 	return "jobs"
 }
 
+// SetToManyReferenceIDs implements the api2go UnmarshalToManyRelations
+// interface. The CLI doesn't render the bridges and keys related to a job.
+func (j *Job) SetToManyReferenceIDs(name string, IDs []string) error {
+	return nil
+}
+
 // GetTasks extracts the tasks from the dependency graph
 //
 // TODO - Remove dependency on the pipeline package
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/manyminds/api2go/jsonapi"
 	homedir "github.com/mitchellh/go-homedir"
@@ -19,9 +20,10 @@ import (
 	"github.com/tidwall/gjson"
 	clipkg "github.com/urfave/cli"
 	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
-	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
 	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
@@ -33,6 +35,9 @@ import (
 
 var errUnauthorized = errors.New(http.StatusText(http.StatusUnauthorized))
 
+// jobSpecFetchTimeout is how long fetching a job spec from a URL may take
+const jobSpecFetchTimeout = 30 * time.Second
+
 // CreateServiceAgreement creates a ServiceAgreement based on JSON input
 func (cli *Client) CreateServiceAgreement(c *clipkg.Context) (err error) {
 	if !c.Args().Present() {
@@ -215,29 +220,41 @@ func (cli *Client) ArchiveJobSpec(c *clipkg.Context) error {
 
 // ListJobsV2 lists all v2 jobs
 func (cli *Client) ListJobsV2(c *clipkg.Context) (err error) {
+	if c.Bool("archived") {
+		return cli.getPage("/v2/jobs?archived=true", c.Int("page"), &[]Job{})
+	}
 	return cli.getPage("/v2/jobs", c.Int("page"), &[]Job{})
 }
 
 // CreateJobV2 creates a V2 job
-// Valid input is a TOML string or a path to TOML file
+// Valid input is a TOML or JSON string, a path to a TOML or JSON file, an
+// http(s) URL to fetch the spec from, or - to read it from stdin. With
+// --validate-only the spec is only validated by the node.
 func (cli *Client) CreateJobV2(c *clipkg.Context) (err error) {
 	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass in TOML or filepath"))
+		return cli.errorOut(errors.New("Must pass in TOML, JSON, filepath, URL or -"))
 	}
 
-	tomlString, err := getTOMLString(c.Args().First())
+	source, err := readJobSpecSource(c.Args().First())
 	if err != nil {
 		return cli.errorOut(err)
 	}
 
-	request, err := json.Marshal(models.CreateJobSpecRequest{
-		TOML: tomlString,
-	})
+	spec, err := getJobSpecRequest(source)
 	if err != nil {
 		return cli.errorOut(err)
 	}
 
-	resp, err := cli.HTTP.Post("/v2/jobs", bytes.NewReader(request))
+	request, err := json.Marshal(spec)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	path := "/v2/jobs"
+	if c.Bool("validate-only") {
+		path += "?validateOnly=true"
+	}
+	resp, err := cli.HTTP.Post(path, bytes.NewReader(request))
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -248,25 +265,80 @@ func (cli *Client) CreateJobV2(c *clipkg.Context) (err error) {
 	}()
 
 	if resp.StatusCode >= 400 {
-		body, rerr := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			err = multierr.Append(err, rerr)
-			return cli.errorOut(err)
-		}
-		fmt.Printf("Error : %v\n", string(body))
-		return cli.errorOut(err)
+		return cli.jobSpecErrorOut(resp)
 	}
 
+	message := "Job created"
+	if c.Bool("validate-only") {
+		message = "Job spec is valid"
+	}
 	var js Job
-	err = cli.renderAPIResponse(resp, &js, "Job created")
+	err = cli.renderAPIResponse(resp, &js, message)
 	return err
 }
 
+// jobSpecErrorOut returns the errors that the node found in a job spec, one
+// per line, each prefixed with its position in the TOML if it is known
+func (cli *Client) jobSpecErrorOut(resp *http.Response) error {
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	jae := models.JSONAPIErrors{}
+	if err = json.Unmarshal(b, &jae); err != nil || len(jae.Errors) == 0 {
+		return cli.errorOut(fmt.Errorf("%s: %s", resp.Status, b))
+	}
+	lines := make([]string, len(jae.Errors))
+	for i, e := range jae.Errors {
+		lines[i] = e.String()
+	}
+	return cli.errorOut(errors.New(strings.Join(lines, "\n")))
+}
+
+// ConvertJobSpecV1 converts a V1 JSON job spec to a V2 TOML job spec, which is
+// printed with the parts of the V1 spec that could not be converted listed in
+// comments at the top.
+// Valid input is a JSON string or a path to a JSON file.
+func (cli *Client) ConvertJobSpecV1(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/job_conversions", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var conversion webPresenter.JobConversionResource
+	return cli.renderAPIResponse(resp, &conversion)
+}
+
 func (cli *Client) DeleteJobV2(c *clipkg.Context) error {
 	if !c.Args().Present() {
 		return cli.errorOut(errors.New("Must pass the job id to be archived"))
 	}
-	resp, err := cli.HTTP.Delete("/v2/jobs/" + c.Args().First())
+	query := url.Values{}
+	if c.Bool("purge") {
+		query.Set("purge", "true")
+	}
+	if c.Bool("wait") {
+		query.Set("force", "false")
+	}
+	path := "/v2/jobs/" + c.Args().First()
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	resp, err := cli.HTTP.Delete(path)
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -294,7 +366,7 @@ func (cli *Client) TriggerPipelineRun(c *clipkg.Context) error {
 		}
 	}()
 
-	var run pipeline.Run
+	var run webPresenter.PipelineRunResource
 	err = cli.renderAPIResponse(resp, &run, "Pipeline run successfully triggered")
 	return err
 }
@@ -434,6 +506,8 @@ func (cli *Client) RemoteLogin(c *clipkg.Context) error {
 	if err != nil {
 		return cli.errorOut(err)
 	}
+	sessionRequest.TOTPCode = c.String("totp")
+	sessionRequest.RecoveryCode = c.String("recovery-code")
 	_, err = cli.CookieAuthenticator.Authenticate(sessionRequest)
 	return cli.errorOut(err)
 }
@@ -583,6 +657,56 @@ func getTOMLString(s string) (string, error) {
 	return buf.String(), nil
 }
 
+// readJobSpecSource returns the job spec that s refers to when s is - for
+// stdin or an http(s) URL, or s itself otherwise
+func readJobSpecSource(s string) (string, error) {
+	if s == "-" {
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return "", errors.Wrap(err, "error reading job spec from stdin")
+		}
+		return string(b), nil
+	}
+	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+		return s, nil
+	}
+	client := &http.Client{Timeout: jobSpecFetchTimeout}
+	resp, err := client.Get(s)
+	if err != nil {
+		return "", errors.Wrapf(err, "error fetching job spec from %s", s)
+	}
+	defer logger.ErrorIfCalling(resp.Body.Close)
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("error fetching job spec from %s: %s", s, resp.Status)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrapf(err, "error fetching job spec from %s", s)
+	}
+	return string(b), nil
+}
+
+// getJobSpecRequest builds a job creation request from a TOML or JSON spec,
+// or from a path to a file containing one
+func getJobSpecRequest(s string) (models.CreateJobSpecRequest, error) {
+	if isJSONObject(s) {
+		return models.CreateJobSpecRequest{JSON: json.RawMessage(s)}, nil
+	}
+	spec, err := getTOMLString(s)
+	if err != nil {
+		return models.CreateJobSpecRequest{}, err
+	}
+	if isJSONObject(spec) {
+		return models.CreateJobSpecRequest{JSON: json.RawMessage(spec)}, nil
+	}
+	return models.CreateJobSpecRequest{TOML: spec}, nil
+}
+
+func isJSONObject(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "{") && json.Valid([]byte(s))
+}
+
 func (cli *Client) parseResponse(resp *http.Response) ([]byte, error) {
 	b, err := parseResponse(resp)
 	if err == errUnauthorized {
@@ -712,7 +836,11 @@ func (cli *Client) CreateETHKey(c *clipkg.Context) (err error) {
 
 // ListETHKeys renders the active account address with its ETH & LINK balance
 func (cli *Client) ListETHKeys(c *clipkg.Context) (err error) {
-	resp, err := cli.HTTP.Get("/v2/keys/eth")
+	uri := "/v2/keys/eth"
+	if page := c.Int("page"); page > 0 {
+		uri += "?page=" + strconv.Itoa(page)
+	}
+	resp, err := cli.HTTP.Get(uri)
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -1155,6 +1283,111 @@ func (cli *Client) ExportOCRKey(c *clipkg.Context) (err error) {
 	return nil
 }
 
+// ExportNodeState saves an archive of the node's jobs, bridges, external
+// initiators and config overrides, from which a node can be rebuilt
+func (cli *Client) ExportNodeState(c *clipkg.Context) (err error) {
+	filepath := c.String("output")
+	if len(filepath) == 0 {
+		return cli.errorOut(errors.New("Must specify --output/-o flag"))
+	}
+
+	resp, err := cli.HTTP.Get("/v2/node_state")
+	if err != nil {
+		return cli.errorOut(errors.Wrap(err, "Could not make HTTP request"))
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	archive, err := cli.parseResponse(resp)
+	if err != nil {
+		return err
+	}
+	err = utils.WriteFileWithMaxPerms(filepath, archive, 0600)
+	if err != nil {
+		return cli.errorOut(errors.Wrapf(err, "Could not write %v", filepath))
+	}
+
+	_, err = os.Stderr.WriteString(fmt.Sprintf("Exported node state to %s\n", filepath))
+	return cli.errorOut(err)
+}
+
+// ImportNodeState recreates the state in an archive created by
+// ExportNodeState on the node
+func (cli *Client) ImportNodeState(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the filepath of the archive to be imported"))
+	}
+	archive, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/node_state", bytes.NewReader(archive))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var result webPresenter.NodeStateImportResource
+	return cli.renderAPIResponse(resp, &result)
+}
+
+// ApplyManifest makes the node's bridges, keys and config overrides match a
+// YAML manifest. With --dry-run it only prints the changes it would make.
+func (cli *Client) ApplyManifest(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the filepath of the manifest to be applied"))
+	}
+	b, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	request, err := manifestYAMLToJSON(b)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	path := "/v2/manifest"
+	if c.Bool("dry-run") {
+		path += "?dryRun=true"
+	}
+	resp, err := cli.HTTP.Post(path, bytes.NewReader(request))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var changes webPresenter.ManifestChangesResource
+	return cli.renderAPIResponse(resp, &changes)
+}
+
+// manifestYAMLToJSON converts a YAML manifest to the JSON that the node
+// accepts
+func manifestYAMLToJSON(b []byte) ([]byte, error) {
+	var manifest map[string]interface{}
+	if err := yaml.Unmarshal(b, &manifest); err != nil {
+		return nil, errors.Wrap(err, "invalid manifest")
+	}
+	// Config overrides are strings, however YAML typed them
+	if overrides, ok := manifest["configOverrides"].(map[string]interface{}); ok {
+		for name, value := range overrides {
+			overrides[name] = fmt.Sprint(value)
+		}
+	}
+	return json.Marshal(manifest)
+}
+
 func normalizePassword(password string) string {
 	return url.PathEscape(strings.TrimSpace(password))
 }
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -31,6 +33,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 	"github.com/smartcontractkit/chainlink/core/utils"
+	webPresenters "github.com/smartcontractkit/chainlink/core/web/presenters"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -1285,6 +1288,87 @@ func TestClient_CreateJobV2(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_CreateJobV2_FromURL(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplication(t)
+	client, _ := app.NewClientAndRenderer()
+
+	spec := cltest.MustReadFile(t, "./testdata/ocr-bootstrap-spec.toml")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(spec)
+	}))
+	defer server.Close()
+
+	set := flag.NewFlagSet("", flag.ExitOnError)
+	set.Parse([]string{server.URL + "/ocr-bootstrap-spec.toml"})
+	require.NoError(t, client.CreateJobV2(cli.NewContext(nil, set, nil)))
+	cltest.AssertCount(t, app.Store, job.Job{}, 1)
+}
+
+func TestClient_CreateJobV2_ValidateOnly(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplication(t)
+	client, _ := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("", flag.ExitOnError)
+	set.Bool("validate-only", true, "")
+	set.Parse([]string{"./testdata/ocr-bootstrap-spec.toml"})
+	require.NoError(t, client.CreateJobV2(cli.NewContext(nil, set, nil)))
+	cltest.AssertCount(t, app.Store, job.Job{}, 0)
+
+	set = flag.NewFlagSet("", flag.ExitOnError)
+	set.Bool("validate-only", true, "")
+	set.Parse([]string{"type = \"offchainreporting\"\nschemaVersion = \"one\"\n"})
+	err := client.CreateJobV2(cli.NewContext(nil, set, nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2, column 1: ")
+	assert.Contains(t, err.Error(), "Can't convert one(string) to uint32")
+	cltest.AssertCount(t, app.Store, job.Job{}, 0)
+}
+
+func TestClient_ApplyManifest(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplication(t)
+	client, r := app.NewClientAndRenderer()
+
+	testdir := filepath.Join(os.TempDir(), t.Name())
+	require.NoError(t, os.MkdirAll(testdir, 0700|os.ModeDir))
+	defer os.RemoveAll(testdir)
+	manifestPath := filepath.Join(testdir, "manifest.yaml")
+	require.NoError(t, ioutil.WriteFile(manifestPath, []byte(`
+bridges:
+  - name: manifest_bridge
+    url: http://bridge.example.com
+    minimumContractPayment: "100"
+configOverrides:
+  LOG_SQL: true
+`), 0600))
+
+	set := flag.NewFlagSet("test", 0)
+	set.Bool("dry-run", true, "")
+	set.Parse([]string{manifestPath})
+	require.NoError(t, client.ApplyManifest(cli.NewContext(nil, set, nil)))
+	require.Len(t, r.Renders, 1)
+	changes := r.Renders[0].(*webPresenters.ManifestChangesResource)
+	assert.True(t, changes.DryRun)
+	require.Len(t, changes.Changes, 2)
+	assert.Equal(t, "manifest_bridge", changes.Changes[0].Name)
+	assert.Equal(t, []string{"false -> true"}, changes.Changes[1].Diff)
+	_, err := app.Store.FindBridge("manifest_bridge")
+	require.Error(t, err)
+
+	set = flag.NewFlagSet("test", 0)
+	set.Parse([]string{manifestPath})
+	require.NoError(t, client.ApplyManifest(cli.NewContext(nil, set, nil)))
+	bridge, err := app.Store.FindBridge("manifest_bridge")
+	require.NoError(t, err)
+	assert.Equal(t, "100", bridge.MinimumContractPayment.String())
+	assert.True(t, app.Store.Config.LogSQLStatements())
+}
+
 func TestClient_AutoLogin(t *testing.T) {
 	t.Parallel()
 
@@ -1339,3 +1423,19 @@ func TestClient_SetLogConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, sqlEnabled, app.Config.LogSQLStatements())
 }
+
+func TestClient_ConvertJobSpecV1(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplication(t)
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Parse([]string{`{"initiators": [{"type": "cron", "params": {"schedule": "CRON_TZ=UTC * * * * *"}}], "tasks": [{"type": "httpget", "params": {"get": "https://example.com"}}]}`})
+	require.NoError(t, client.ConvertJobSpecV1(cli.NewContext(nil, set, nil)))
+	require.Len(t, r.Renders, 1)
+	conversion := r.Renders[0].(*webPresenters.JobConversionResource)
+	assert.Contains(t, conversion.TOML, `http1 [type="http" method="GET" url="https://example.com"];`)
+	require.Len(t, conversion.Warnings, 1)
+	assert.Contains(t, conversion.Warnings[0], "The cron initiator")
+}
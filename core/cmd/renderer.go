@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/smartcontractkit/chainlink/core/logger"
-	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
 	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
@@ -100,10 +101,16 @@ func (rt RendererTable) Render(v interface{}, headers ...string) error {
 		return rt.renderJobsV2(*typed)
 	case *Job:
 		return rt.renderJobsV2([]Job{*typed})
-	case *pipeline.Run:
+	case *webPresenters.PipelineRunResource:
 		return rt.renderPipelineRun(*typed)
 	case *webPresenters.LogResource:
 		return rt.renderLogResource(*typed)
+	case *webPresenters.NodeStateImportResource:
+		return rt.renderNodeStateImport(*typed)
+	case *webPresenters.ManifestChangesResource:
+		return rt.renderManifestChanges(*typed)
+	case *webPresenters.JobConversionResource:
+		return rt.renderJobConversion(*typed)
 	default:
 		return fmt.Errorf("unable to render object of type %T: %v", typed, typed)
 	}
@@ -120,6 +127,58 @@ func (rt RendererTable) renderLogResource(logResource webPresenters.LogResource)
 	return nil
 }
 
+func (rt RendererTable) renderNodeStateImport(result webPresenters.NodeStateImportResource) error {
+	table := rt.newTable([]string{"Name", "Outcome", "Error"})
+	for _, name := range result.Created {
+		table.Append([]string{name, "created", ""})
+	}
+	for _, name := range result.Skipped {
+		table.Append([]string{name, "skipped, already exists", ""})
+	}
+	names := make([]string, 0, len(result.Errors))
+	for name := range result.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		table.Append([]string{name, "failed", result.Errors[name]})
+	}
+	render("Import", table)
+	return nil
+}
+
+// renderManifestChanges prints the changes as a diff, + for what was created
+// and ~ for what was updated, each followed by what changed
+func (rt RendererTable) renderManifestChanges(result webPresenters.ManifestChangesResource) error {
+	switch {
+	case len(result.Changes) == 0:
+		fmt.Println("No changes, the node matches the manifest")
+		return nil
+	case result.DryRun:
+		fmt.Println("Dry run, applying the manifest would make these changes:")
+	default:
+		fmt.Println("Applied the manifest, making these changes:")
+	}
+	for _, change := range result.Changes {
+		sign := "~"
+		if change.Action == provisioning.ActionCreate {
+			sign = "+"
+		}
+		fmt.Println(strings.TrimSpace(fmt.Sprintf("%s %s %s", sign, change.Kind, change.Name)))
+		for _, line := range change.Diff {
+			fmt.Println("    " + line)
+		}
+	}
+	return nil
+}
+
+// renderJobConversion prints the TOML alone, so that it can be redirected to
+// a file. The warnings are comments at the top of it.
+func (rt RendererTable) renderJobConversion(result webPresenters.JobConversionResource) error {
+	fmt.Print(result.TOML)
+	return nil
+}
+
 func (rt RendererTable) renderJobs(jobs []models.JobSpec) error {
 	table := rt.newTable([]string{"ID", "Name", "Created At", "Initiators", "Tasks"})
 	for _, v := range jobs {
@@ -444,13 +503,14 @@ func (rt RendererTable) renderETHKeys(keys []presenters.ETHKey) error {
 			nextNonce,
 			lastUsed,
 			fmt.Sprintf("%v", key.IsFunding),
+			fmt.Sprintf("%v", key.IsTransmitter),
 			key.CreatedAt.String(),
 			key.UpdatedAt.String(),
 			deletedAt,
 		})
 	}
 
-	renderList([]string{"Address", "ETH", "LINK", "Next nonce", "Last used", "Is funding", "Created", "Updated", "Deleted"}, rows)
+	renderList([]string{"Address", "ETH", "LINK", "Next nonce", "Last used", "Is funding", "Is transmitter", "Created", "Updated", "Deleted"}, rows)
 	return nil
 }
 
@@ -497,7 +557,7 @@ func (rt RendererTable) renderOCRKeys(ocrKeys []ocrkey.EncryptedKeyBundle) error
 	return nil
 }
 
-func (rt RendererTable) renderPipelineRun(run pipeline.Run) error {
+func (rt RendererTable) renderPipelineRun(run webPresenters.PipelineRunResource) error {
 	table := rt.newTable([]string{"ID", "Created At", "Finished At"})
 
 	var finishedAt string
@@ -243,6 +243,7 @@ func NewTestConfig(t testing.TB, options ...interface{}) *TestConfig {
 	rawConfig.Set("SESSION_TIMEOUT", "2m")
 	rawConfig.Set("INSECURE_FAST_SCRYPT", "true")
 	rawConfig.Set("BALANCE_MONITOR_ENABLED", "false")
+	rawConfig.Set("ETH_EXTERNAL_TX_DETECTION_ENABLED", "false")
 	rawConfig.Set("P2P_LISTEN_PORT", "12345")
 	rawConfig.Set("P2P_PEER_ID", DefaultP2PPeerID.String())
 	rawConfig.Set("DATABASE_TIMEOUT", "5s")
@@ -268,7 +269,7 @@ func NewPipelineORM(t testing.TB, config *TestConfig, db *gorm.DB) (pipeline.ORM
 	t.Helper()
 	eventBroadcaster := postgres.NewEventBroadcaster(config.DatabaseURL(), 0, 0)
 	eventBroadcaster.Start()
-	return pipeline.NewORM(db, config, eventBroadcaster), eventBroadcaster, func() {
+	return pipeline.NewORM(db, config, eventBroadcaster, nil), eventBroadcaster, func() {
 		eventBroadcaster.Stop()
 	}
 }
@@ -1153,7 +1154,7 @@ func WaitForPipelineComplete(t testing.TB, nodeID int, jobID int32, count int, j
 	g := gomega.NewGomegaWithT(t)
 	var pr []pipeline.Run
 	g.Eventually(func() bool {
-		prs, _, err := jo.PipelineRunsByJobID(jobID, 0, 1000)
+		prs, _, err := jo.PipelineRunsByJobID(jobID, orm.Pagination{Limit: 1000})
 		assert.NoError(t, err)
 		var completed []pipeline.Run
 		for i := range prs {
@@ -141,7 +141,7 @@ func TestIntegration_HttpRequestWithHeaders(t *testing.T) {
 				return len(b) == 1 && cltest.BatchElemMatchesHash(b[0], tx.Hash())
 			})).Return(nil).Run(func(args mock.Arguments) {
 				elems := args.Get(1).([]rpc.BatchElem)
-				elems[0].Result = &bulletprooftxmanager.Receipt{TxHash: tx.Hash(), BlockNumber: big.NewInt(confirmed), BlockHash: cltest.NewHash()}
+				elems[0].Result = &bulletprooftxmanager.Receipt{TxHash: tx.Hash(), Status: 1, BlockNumber: big.NewInt(confirmed), BlockHash: cltest.NewHash()}
 			})
 		}).
 		Return(nil).Once()
@@ -865,7 +865,7 @@ func TestIntegration_FluxMonitor_Deviation(t *testing.T) {
 				return len(b) == 1 && cltest.BatchElemMatchesHash(b[0], tx.Hash())
 			})).Return(nil).Run(func(args mock.Arguments) {
 				elems := args.Get(1).([]rpc.BatchElem)
-				elems[0].Result = &bulletprooftxmanager.Receipt{TxHash: tx.Hash(), BlockNumber: big.NewInt(confirmed), BlockHash: cltest.NewHash()}
+				elems[0].Result = &bulletprooftxmanager.Receipt{TxHash: tx.Hash(), Status: 1, BlockNumber: big.NewInt(confirmed), BlockHash: cltest.NewHash()}
 			})
 		}).
 		Return(nil).Once()
@@ -1032,7 +1032,7 @@ func TestIntegration_FluxMonitor_NewRound(t *testing.T) {
 				return len(b) == 1 && cltest.BatchElemMatchesHash(b[0], tx.Hash())
 			})).Return(nil).Run(func(args mock.Arguments) {
 				elems := args.Get(1).([]rpc.BatchElem)
-				elems[0].Result = &bulletprooftxmanager.Receipt{TxHash: tx.Hash(), BlockNumber: big.NewInt(confirmed), BlockHash: cltest.NewHash()}
+				elems[0].Result = &bulletprooftxmanager.Receipt{TxHash: tx.Hash(), Status: 1, BlockNumber: big.NewInt(confirmed), BlockHash: cltest.NewHash()}
 			})
 		}).
 		Return(nil).Once()
@@ -1108,7 +1108,7 @@ func TestIntegration_MultiwordV1(t *testing.T) {
 				return len(b) == 1 && cltest.BatchElemMatchesHash(b[0], tx.Hash())
 			})).Return(nil).Run(func(args mock.Arguments) {
 				elems := args.Get(1).([]rpc.BatchElem)
-				elems[0].Result = &bulletprooftxmanager.Receipt{TxHash: tx.Hash(), BlockNumber: big.NewInt(confirmed), BlockHash: cltest.NewHash()}
+				elems[0].Result = &bulletprooftxmanager.Receipt{TxHash: tx.Hash(), Status: 1, BlockNumber: big.NewInt(confirmed), BlockHash: cltest.NewHash()}
 			}).Maybe()
 		}).
 		Return(nil).Once()
@@ -10,12 +10,16 @@ import (
 
 	job "github.com/smartcontractkit/chainlink/core/services/job"
 
+	maintenance "github.com/smartcontractkit/chainlink/core/services/maintenance"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/smartcontractkit/chainlink/core/store/models"
 
 	null "gopkg.in/guregu/null.v4"
 
+	ocrrotation "github.com/smartcontractkit/chainlink/core/services/ocrrotation"
+
 	packr "github.com/gobuffalo/packr"
 
 	store "github.com/smartcontractkit/chainlink/core/store"
@@ -93,6 +97,20 @@ func (_m *Application) ArchiveJob(_a0 models.JobID) error {
 	return r0
 }
 
+// ArchiveJobV2 provides a mock function with given fields: ctx, jobID, force
+func (_m *Application) ArchiveJobV2(ctx context.Context, jobID int32, force bool) error {
+	ret := _m.Called(ctx, jobID, force)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, bool) error); ok {
+		r0 = rf(ctx, jobID, force)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AwaitRun provides a mock function with given fields: ctx, runID
 func (_m *Application) AwaitRun(ctx context.Context, runID int64) error {
 	ret := _m.Called(ctx, runID)
@@ -176,13 +194,27 @@ func (_m *Application) CreateErrored(jobSpecID models.JobID, initiator models.In
 	return r0, r1
 }
 
-// DeleteJobV2 provides a mock function with given fields: ctx, jobID
-func (_m *Application) DeleteJobV2(ctx context.Context, jobID int32) error {
-	ret := _m.Called(ctx, jobID)
+// DeleteJobV2 provides a mock function with given fields: ctx, jobID, force
+func (_m *Application) DeleteJobV2(ctx context.Context, jobID int32, force bool) error {
+	ret := _m.Called(ctx, jobID, force)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, bool) error); ok {
+		r0 = rf(ctx, jobID, force)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireAllPendingBridge provides a mock function with given fields:
+func (_m *Application) ExpireAllPendingBridge() error {
+	ret := _m.Called()
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = rf(ctx, jobID)
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -222,6 +254,38 @@ func (_m *Application) GetJobORM() job.ORM {
 	return r0
 }
 
+// GetJobValidators provides a mock function with given fields:
+func (_m *Application) GetJobValidators() *job.ValidatorRegistry {
+	ret := _m.Called()
+
+	var r0 *job.ValidatorRegistry
+	if rf, ok := ret.Get(0).(func() *job.ValidatorRegistry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*job.ValidatorRegistry)
+		}
+	}
+
+	return r0
+}
+
+// GetMaintenance provides a mock function with given fields:
+func (_m *Application) GetMaintenance() *maintenance.Switch {
+	ret := _m.Called()
+
+	var r0 *maintenance.Switch
+	if rf, ok := ret.Get(0).(func() *maintenance.Switch); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*maintenance.Switch)
+		}
+	}
+
+	return r0
+}
+
 // GetStatsPusher provides a mock function with given fields:
 func (_m *Application) GetStatsPusher() synchronization.StatsPusher {
 	ret := _m.Called()
@@ -254,6 +318,22 @@ func (_m *Application) GetStore() *store.Store {
 	return r0
 }
 
+// GetTransmitterRotator provides a mock function with given fields:
+func (_m *Application) GetTransmitterRotator() ocrrotation.Rotator {
+	ret := _m.Called()
+
+	var r0 ocrrotation.Rotator
+	if rf, ok := ret.Get(0).(func() ocrrotation.Rotator); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ocrrotation.Rotator)
+		}
+	}
+
+	return r0
+}
+
 // NewBox provides a mock function with given fields:
 func (_m *Application) NewBox() packr.Box {
 	ret := _m.Called()
@@ -179,6 +179,50 @@ func (_m *KeyStoreInterface) NewAccount() (accounts.Account, error) {
 	return r0, r1
 }
 
+// Restore provides a mock function with given fields: keyJSON
+func (_m *KeyStoreInterface) Restore(keyJSON []byte) (accounts.Account, error) {
+	ret := _m.Called(keyJSON)
+
+	var r0 accounts.Account
+	if rf, ok := ret.Get(0).(func([]byte) accounts.Account); ok {
+		r0 = rf(keyJSON)
+	} else {
+		r0 = ret.Get(0).(accounts.Account)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(keyJSON)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignHash provides a mock function with given fields: account, hash
+func (_m *KeyStoreInterface) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	ret := _m.Called(account, hash)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(accounts.Account, []byte) []byte); ok {
+		r0 = rf(account, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(accounts.Account, []byte) error); ok {
+		r1 = rf(account, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignTx provides a mock function with given fields: account, tx, chainID
 func (_m *KeyStoreInterface) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	ret := _m.Called(account, tx, chainID)
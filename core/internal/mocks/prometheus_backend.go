@@ -2,18 +2,32 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // PrometheusBackend is an autogenerated mock type for the PrometheusBackend type
 type PrometheusBackend struct {
 	mock.Mock
 }
 
+// SetMaxPendingBridgeRunAge provides a mock function with given fields: _a0
+func (_m *PrometheusBackend) SetMaxPendingBridgeRunAge(_a0 time.Duration) {
+	_m.Called(_a0)
+}
+
 // SetMaxUnconfirmedBlocks provides a mock function with given fields: _a0
 func (_m *PrometheusBackend) SetMaxUnconfirmedBlocks(_a0 int64) {
 	_m.Called(_a0)
 }
 
+// SetPendingBridgeRuns provides a mock function with given fields: _a0
+func (_m *PrometheusBackend) SetPendingBridgeRuns(_a0 int64) {
+	_m.Called(_a0)
+}
+
 // SetPipelineRunsQueued provides a mock function with given fields: n
 func (_m *PrometheusBackend) SetPipelineRunsQueued(n int) {
 	_m.Called(n)
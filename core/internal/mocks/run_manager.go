@@ -85,6 +85,20 @@ func (_m *RunManager) CreateErrored(jobSpecID models.JobID, initiator models.Ini
 	return r0, r1
 }
 
+// ExpireAllPendingBridge provides a mock function with given fields:
+func (_m *RunManager) ExpireAllPendingBridge() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumeAllInProgress provides a mock function with given fields:
 func (_m *RunManager) ResumeAllInProgress() error {
 	ret := _m.Called()
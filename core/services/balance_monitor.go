@@ -8,6 +8,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -86,11 +87,13 @@ func (bm *balanceMonitor) updateBalance(ethBal assets.Eth, address gethCommon.Ad
 
 	if oldBal == nil {
 		logger.Infow(fmt.Sprintf("ETH balance for %s: %s", address.Hex(), ethBal.String()), loggerFields...)
+		bm.store.Events.Publish(eventbus.BalanceChanged{Address: address, Balance: &ethBal})
 		return
 	}
 
 	if ethBal.Cmp(oldBal) != 0 {
 		logger.Infow(fmt.Sprintf("New ETH balance for %s: %s", address.Hex(), ethBal.String()), loggerFields...)
+		bm.store.Events.Publish(eventbus.BalanceChanged{Address: address, Balance: &ethBal})
 	}
 }
 
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// BridgeChecker periodically checks that the bridges that v1 and v2 jobs call
+// exist, recording a job error for each job that calls one that doesn't.
+// Bridges are referenced by name, in task types and pipeline DOT, so a foreign
+// key can't stop them being deleted. Deleting a bridge through the API is
+// refused while jobs use it, but it can still be deleted by reconciling a
+// manifest, importing a node state or editing the database, and the jobs that
+// call it would otherwise only fail when they next run.
+type BridgeChecker struct {
+	utils.StartStopOnce
+
+	store    *store.Store
+	jobORM   job.ORM
+	interval time.Duration
+	chStop   chan struct{}
+	chDone   chan struct{}
+}
+
+// NewBridgeChecker returns a service that checks jobs' bridges every interval.
+// An interval of 0 disables it.
+func NewBridgeChecker(store *store.Store, jobORM job.ORM, interval time.Duration) *BridgeChecker {
+	return &BridgeChecker{
+		store:    store,
+		jobORM:   jobORM,
+		interval: interval,
+		chStop:   make(chan struct{}),
+		chDone:   make(chan struct{}),
+	}
+}
+
+func (bc *BridgeChecker) Start() error {
+	if !bc.OkayToStart() {
+		return errors.New("BridgeChecker has already been started")
+	}
+	if bc.interval <= 0 {
+		close(bc.chDone)
+		return nil
+	}
+	go bc.runLoop()
+	return nil
+}
+
+func (bc *BridgeChecker) Close() error {
+	if !bc.OkayToStop() {
+		return errors.New("BridgeChecker has already been stopped")
+	}
+	close(bc.chStop)
+	<-bc.chDone
+	return nil
+}
+
+func (bc *BridgeChecker) runLoop() {
+	defer close(bc.chDone)
+
+	ticker := time.NewTicker(utils.WithJitter(bc.interval))
+	defer ticker.Stop()
+
+	ctx, cancel := utils.CombinedContext(bc.chStop)
+	defer cancel()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := bc.Check(ctx); err != nil {
+				logger.Errorw("BridgeChecker: failed to check bridges", "error", err)
+			}
+		case <-bc.chStop:
+			return
+		}
+	}
+}
+
+// Check records a job error for each bridge that a job calls but that doesn't
+// exist. Errors are recorded again on every check until the bridge is created
+// or the job is deleted, adding to their occurrences.
+func (bc *BridgeChecker) Check(ctx context.Context) error {
+	missing, err := bc.jobORM.FindMissingBridges()
+	if err != nil {
+		return errors.Wrap(err, "failed to find the missing bridges of v2 jobs")
+	}
+	for jobID, names := range missing {
+		for _, name := range names {
+			logger.Warnw("BridgeChecker: job calls a bridge that does not exist", "jobID", jobID, "bridge", name)
+			bc.jobORM.RecordError(ctx, jobID, missingBridgeDescription(name))
+		}
+	}
+
+	taskTypes, err := bc.store.FindTaskTypesWithoutBridges()
+	if err != nil {
+		return errors.Wrap(err, "failed to find the missing bridges of v1 jobs")
+	}
+	for jobID, types := range taskTypes {
+		for _, taskType := range types {
+			if adapters.FindNativeAdapterFor(models.TaskSpec{Type: taskType}) != nil {
+				continue
+			}
+			logger.Warnw("BridgeChecker: job calls a bridge that does not exist", "jobID", jobID, "bridge", taskType)
+			bc.store.UpsertErrorFor(jobID, missingBridgeDescription(taskType.String()))
+		}
+	}
+	return nil
+}
+
+func missingBridgeDescription(name string) string {
+	return fmt.Sprintf("The job calls bridge %s, which does not exist. Its runs will fail until the bridge is created.", name)
+}
@@ -0,0 +1,82 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func TestBridgeChecker_Check(t *testing.T) {
+	t.Parallel()
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	store, cleanup := cltest.NewStoreWithConfig(t, config)
+	defer cleanup()
+	db := store.DB
+
+	pipelineORM, eventBroadcaster, cleanupORM := cltest.NewPipelineORM(t, config, db)
+	defer cleanupORM()
+	jobORM := job.NewORM(db, config.Config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
+	defer jobORM.Close()
+
+	// Calls the voter_turnout bridge, which doesn't exist yet
+	jbV2 := cltest.MustInsertSampleDirectRequestJob(t, db)
+
+	jbV1 := cltest.NewJobWithWebInitiator()
+	jbV1.Tasks = []models.TaskSpec{
+		cltest.NewTask(t, "election_winner"),
+		cltest.NewTask(t, "noop"),
+	}
+	require.NoError(t, store.CreateJob(&jbV1))
+
+	checker := services.NewBridgeChecker(store, jobORM, 0)
+
+	t.Run("records job errors for the bridges that don't exist", func(t *testing.T) {
+		require.NoError(t, checker.Check(context.Background()))
+
+		var specErrors []job.SpecError
+		require.NoError(t, db.Find(&specErrors).Error)
+		require.Len(t, specErrors, 1)
+		assert.Equal(t, jbV2.ID, specErrors[0].JobID)
+		assert.Equal(t, "The job calls bridge voter_turnout, which does not exist. Its runs will fail until the bridge is created.", specErrors[0].Description)
+
+		var jobSpecErrors []models.JobSpecError
+		require.NoError(t, db.Find(&jobSpecErrors).Error)
+		require.Len(t, jobSpecErrors, 1)
+		assert.Equal(t, jbV1.ID, jobSpecErrors[0].JobSpecID)
+		assert.Equal(t, "The job calls bridge election_winner, which does not exist. Its runs will fail until the bridge is created.", jobSpecErrors[0].Description)
+	})
+
+	t.Run("counts the errors again while the bridges don't exist", func(t *testing.T) {
+		require.NoError(t, checker.Check(context.Background()))
+
+		var specError job.SpecError
+		require.NoError(t, db.First(&specError).Error)
+		assert.Equal(t, uint(2), specError.Occurrences)
+		var jobSpecError models.JobSpecError
+		require.NoError(t, db.First(&jobSpecError).Error)
+		assert.Equal(t, uint(2), jobSpecError.Occurrences)
+	})
+
+	t.Run("records nothing once the bridges exist", func(t *testing.T) {
+		require.NoError(t, db.Exec(`DELETE FROM job_spec_errors_v2`).Error)
+		require.NoError(t, db.Exec(`DELETE FROM job_spec_errors`).Error)
+		_, bridge := cltest.NewBridgeType(t, "voter_turnout", "http://blah.com")
+		require.NoError(t, db.Create(bridge).Error)
+		_, bridge = cltest.NewBridgeType(t, "election_winner", "http://blah.com")
+		require.NoError(t, db.Create(bridge).Error)
+
+		require.NoError(t, checker.Check(context.Background()))
+
+		cltest.AssertCount(t, store, job.SpecError{}, 0)
+		cltest.AssertCount(t, store, models.JobSpecError{}, 0)
+	})
+}
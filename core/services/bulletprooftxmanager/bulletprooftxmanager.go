@@ -46,6 +46,12 @@ var (
 
 // SendEther creates a transaction that transfers the given value of ether
 func SendEther(s *strpkg.Store, from, to gethCommon.Address, value assets.Eth) (etx models.EthTx, err error) {
+	return SendEtherWithDB(s.DB, s.Config.EthGasLimitDefault(), from, to, value)
+}
+
+// SendEtherWithDB is like SendEther, but creates the transaction with db, so
+// that it can be part of a larger database transaction
+func SendEtherWithDB(db *gorm.DB, gasLimit uint64, from, to gethCommon.Address, value assets.Eth) (etx models.EthTx, err error) {
 	if to == utils.ZeroAddress {
 		return etx, errors.New("cannot send ether to zero address")
 	}
@@ -54,10 +60,10 @@ func SendEther(s *strpkg.Store, from, to gethCommon.Address, value assets.Eth) (
 		ToAddress:      to,
 		EncodedPayload: []byte{},
 		Value:          value,
-		GasLimit:       s.Config.EthGasLimitDefault(),
+		GasLimit:       gasLimit,
 		State:          models.EthTxUnstarted,
 	}
-	err = s.DB.Create(&etx).Error
+	err = db.Create(&etx).Error
 	return etx, err
 }
 
@@ -169,20 +175,33 @@ func saveReplacementInProgressAttempt(store *strpkg.Store, oldAttempt models.Eth
 // - A configured fixed amount of Wei (ETH_GAS_PRICE_WEI) on top of the baseline price.
 // The baseline price is the maximum of the previous gas price attempt and the node's current gas price.
 func BumpGas(config orm.ConfigReader, originalGasPrice *big.Int) (*big.Int, error) {
+	return bumpGas(config, originalGasPrice, config.EthMaxGasPriceWei())
+}
+
+// bumpGas is BumpGas with an explicit ceiling, for keys that have a lower
+// limit than ETH_MAX_GAS_PRICE_WEI
+func bumpGas(config orm.ConfigReader, originalGasPrice *big.Int, maxGasPrice *big.Int) (*big.Int, error) {
+	return bumpGasBy(config, 1, originalGasPrice, maxGasPrice)
+}
+
+// bumpGasBy is bumpGas with the percentage and fixed amount of the bump
+// multiplied by multiple, for transactions whose deadline is approaching
+func bumpGasBy(config orm.ConfigReader, multiple int64, originalGasPrice *big.Int, maxGasPrice *big.Int) (*big.Int, error) {
 	baselinePrice := max(originalGasPrice, config.EthGasPriceDefault())
 
 	var priceByPercentage = new(big.Int)
-	priceByPercentage.Mul(baselinePrice, big.NewInt(int64(100+config.EthGasBumpPercent())))
+	priceByPercentage.Mul(baselinePrice, big.NewInt(100+multiple*int64(config.EthGasBumpPercent())))
 	priceByPercentage.Div(priceByPercentage, big.NewInt(100))
 
 	var priceByIncrement = new(big.Int)
-	priceByIncrement.Add(baselinePrice, config.EthGasBumpWei())
+	priceByIncrement.Mul(config.EthGasBumpWei(), big.NewInt(multiple))
+	priceByIncrement.Add(baselinePrice, priceByIncrement)
 
 	bumpedGasPrice := max(priceByPercentage, priceByIncrement)
-	if bumpedGasPrice.Cmp(config.EthMaxGasPriceWei()) > 0 {
+	if bumpedGasPrice.Cmp(maxGasPrice) > 0 {
 		promGasBumpExceedsLimit.Inc()
-		return config.EthMaxGasPriceWei(), errors.Errorf("bumped gas price of %s would exceed configured max gas price of %s (original price was %s)",
-			bumpedGasPrice.String(), config.EthMaxGasPriceWei(), originalGasPrice.String())
+		return maxGasPrice, errors.Errorf("bumped gas price of %s would exceed configured max gas price of %s (original price was %s)",
+			bumpedGasPrice.String(), maxGasPrice, originalGasPrice.String())
 	} else if bumpedGasPrice.Cmp(originalGasPrice) == 0 {
 		// NOTE: This really shouldn't happen since we enforce minimums for
 		// ETH_GAS_BUMP_PERCENT and ETH_GAS_BUMP_WEI in the config validation,
@@ -195,6 +214,20 @@ func BumpGas(config orm.ConfigReader, originalGasPrice *big.Int) (*big.Int, erro
 	return bumpedGasPrice, nil
 }
 
+// maxGasPriceFor returns the highest gas price that transactions from the
+// given address may pay. Archived keys keep their limits so that their
+// pending transactions can still be bumped.
+func maxGasPriceFor(s *strpkg.Store, config orm.ConfigReader, address gethCommon.Address) (*big.Int, error) {
+	var key models.Key
+	err := s.DB.Unscoped().Where("address = ?", address).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return config.EthMaxGasPriceWei(), nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to load key %s", address.Hex())
+	}
+	return key.GasPriceCap(config.EthMaxGasPriceWei()), nil
+}
+
 func max(a, b *big.Int) *big.Int {
 	if a.Cmp(b) >= 0 {
 		return a
@@ -8,6 +8,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -181,8 +182,12 @@ func (eb *ethBroadcaster) monitorEthTxs() {
 }
 
 func (eb *ethBroadcaster) ProcessUnstartedEthTxs(key models.Key) error {
+	if !key.SupportsChain(eb.config.ChainID()) {
+		return errors.Errorf("key %s is restricted to chain ID %s but the node is connected to chain ID %s, refusing to send transactions from it",
+			key.Address.Hex(), key.ChainID, eb.config.ChainID())
+	}
 	return eb.store.AdvisoryLocker.WithAdvisoryLock(context.TODO(), postgres.AdvisoryLockClassID_EthBroadcaster, key.ID, func() error {
-		return eb.processUnstartedEthTxs(key.Address.Address())
+		return eb.processUnstartedEthTxs(key)
 	})
 }
 
@@ -190,7 +195,8 @@ func (eb *ethBroadcaster) ProcessUnstartedEthTxs(key models.Key) error {
 // result in undefined state or deadlocks.
 // First handle any in_progress transactions left over from last time.
 // Then keep looking up unstarted transactions and processing them until there are none remaining.
-func (eb *ethBroadcaster) processUnstartedEthTxs(fromAddress gethCommon.Address) error {
+func (eb *ethBroadcaster) processUnstartedEthTxs(key models.Key) error {
+	fromAddress := key.Address.Address()
 	var n uint = 0
 	mark := time.Now()
 	defer func() {
@@ -203,6 +209,16 @@ func (eb *ethBroadcaster) processUnstartedEthTxs(fromAddress gethCommon.Address)
 		return errors.Wrap(err, "processUnstartedEthTxs failed")
 	}
 	for {
+		if key.MaxInFlightTransactions != nil {
+			inFlight, err := countInFlightEthTxs(eb.store.DB, fromAddress)
+			if err != nil {
+				return errors.Wrap(err, "processUnstartedEthTxs failed")
+			}
+			if inFlight >= int64(*key.MaxInFlightTransactions) {
+				logger.Debugw("EthBroadcaster: key has reached its limit of in-flight transactions, waiting for confirmations", "address", fromAddress, "inFlight", inFlight, "id", "eth_broadcaster")
+				return nil
+			}
+		}
 		etx, err := eb.nextUnstartedTransactionWithNonce(fromAddress)
 		if err != nil {
 			return errors.Wrap(err, "processUnstartedEthTxs failed")
@@ -211,7 +227,20 @@ func (eb *ethBroadcaster) processUnstartedEthTxs(fromAddress gethCommon.Address)
 			return nil
 		}
 		n++
-		a, err := newAttempt(eb.store, *etx, eb.config.EthGasPriceDefault())
+		if key.IsTransmitter {
+			if err := eb.checkTransmitterDestination(etx); err != nil {
+				logger.Errorw("EthBroadcaster: refusing to send transaction from transmitter key", "ethTxID", etx.ID, "address", fromAddress, "toAddress", etx.ToAddress, "err", err)
+				if err := saveRejectedTransaction(eb.store, etx, err); err != nil {
+					return errors.Wrap(err, "processUnstartedEthTxs failed")
+				}
+				continue
+			}
+		}
+		gasPrice := eb.config.EthGasPriceDefault()
+		if gasPriceCap := key.GasPriceCap(eb.config.EthMaxGasPriceWei()); gasPrice.Cmp(gasPriceCap) > 0 {
+			gasPrice = gasPriceCap
+		}
+		a, err := newAttempt(eb.store, *etx, gasPrice)
 		if err != nil {
 			return errors.Wrap(err, "processUnstartedEthTxs failed")
 		}
@@ -397,6 +426,52 @@ func (eb *ethBroadcaster) saveInProgressTransaction(etx *models.EthTx, attempt *
 	})
 }
 
+// checkTransmitterDestination returns an error unless the transaction is
+// addressed to the contract, or the forwarder, of a non-bootstrap OCR job. The
+// one exception is a plain transfer of ether that funds the new transmitter of
+// a rotation away from this key, which is still waiting to be funded.
+func (eb *ethBroadcaster) checkTransmitterDestination(etx *models.EthTx) error {
+	var allowed bool
+	err := eb.store.DB.Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM offchainreporting_oracle_specs
+			WHERE (contract_address = ? OR forwarder_address = ?) AND NOT is_bootstrap_peer
+		) OR (? AND EXISTS (
+			SELECT 1 FROM ocr_transmitter_rotations
+			WHERE old_address = ? AND new_address = ? AND state = 'funding'
+		))
+	`, etx.ToAddress, etx.ToAddress, len(etx.EncodedPayload) == 0, etx.FromAddress, etx.ToAddress).Scan(&allowed).Error
+	if err != nil {
+		return errors.Wrap(err, "checkTransmitterDestination failed")
+	} else if !allowed {
+		return errors.Errorf("transmitter keys may only send transactions to OCR contracts, %s is not one", etx.ToAddress.Hex())
+	}
+	return nil
+}
+
+// countInFlightEthTxs returns the number of transactions from the given
+// address that have been picked up for broadcast but not yet confirmed
+func countInFlightEthTxs(db *gorm.DB, fromAddress gethCommon.Address) (count int64, err error) {
+	err = db.Model(&models.EthTx{}).
+		Where("from_address = ? AND state IN ('in_progress', 'unconfirmed')", fromAddress).
+		Count(&count).
+		Error
+	return count, errors.Wrap(err, "countInFlightEthTxs failed")
+}
+
+// saveRejectedTransaction marks an unstarted transaction as fatally errored
+// without ever broadcasting it
+func saveRejectedTransaction(store *store.Store, etx *models.EthTx, reason error) error {
+	if etx.State != models.EthTxUnstarted {
+		return errors.Errorf("can only reject unstarted transactions, transaction is currently %s", etx.State)
+	}
+	errString := reason.Error()
+	etx.Error = &errString
+	etx.Nonce = nil
+	etx.State = models.EthTxFatalError
+	return errors.Wrap(store.DB.Save(etx).Error, "saveRejectedTransaction failed to save eth_tx")
+}
+
 // Finds earliest saved transaction that has yet to be broadcast from the given address
 func findNextUnstartedTransactionFromAddress(db *gorm.DB, etx *models.EthTx, fromAddress gethCommon.Address) error {
 	return db.
@@ -438,7 +513,11 @@ func saveAttempt(store *store.Store, etx *models.EthTx, attempt models.EthTxAtte
 }
 
 func (eb *ethBroadcaster) tryAgainWithHigherGasPrice(sendError *eth.SendError, etx models.EthTx, attempt models.EthTxAttempt, initialBroadcastAt time.Time) error {
-	bumpedGasPrice, err := BumpGas(eb.config, attempt.GasPrice.ToInt())
+	maxGasPrice, err := maxGasPriceFor(eb.store, eb.config, etx.FromAddress)
+	if err != nil {
+		return errors.Wrap(err, "tryAgainWithHigherGasPrice failed")
+	}
+	bumpedGasPrice, err := bumpGas(eb.config, attempt.GasPrice.ToInt(), maxGasPrice)
 	if err != nil {
 		return errors.Wrap(err, "tryAgainWithHigherGasPrice failed")
 	}
@@ -446,7 +525,7 @@ func (eb *ethBroadcaster) tryAgainWithHigherGasPrice(sendError *eth.SendError, e
 		"Eth node returned: '%s'. "+
 		"Bumping to %v wei and retrying. ACTION REQUIRED: This is a configuration error. "+
 		"Consider increasing ETH_GAS_PRICE_DEFAULT", eb.config.EthGasPriceDefault(), sendError.Error(), bumpedGasPrice), "err", err)
-	if bumpedGasPrice.Cmp(attempt.GasPrice.ToInt()) == 0 && bumpedGasPrice.Cmp(eb.config.EthMaxGasPriceWei()) == 0 {
+	if bumpedGasPrice.Cmp(attempt.GasPrice.ToInt()) == 0 && bumpedGasPrice.Cmp(maxGasPrice) == 0 {
 		return errors.Errorf("Hit gas price bump ceiling, will not bump further. This is a terminal error")
 	}
 	replacementAttempt, err := newAttempt(eb.store, etx, bumpedGasPrice)
@@ -469,12 +548,21 @@ func saveFatallyErroredTransaction(store *store.Store, etx *models.EthTx) error
 	}
 	etx.Nonce = nil
 	etx.State = models.EthTxFatalError
-	return store.Transaction(func(tx *gorm.DB) error {
+	err := store.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Exec(`DELETE FROM eth_tx_attempts WHERE eth_tx_id = ?`, etx.ID).Error; err != nil {
 			return errors.Wrapf(err, "saveFatallyErroredTransaction failed to delete eth_tx_attempt with eth_tx.ID %v", etx.ID)
 		}
 		return errors.Wrap(tx.Save(etx).Error, "saveFatallyErroredTransaction failed to save eth_tx")
 	})
+	if err != nil {
+		return err
+	}
+	store.Events.Publish(eventbus.TxFailed{
+		EthTxID: etx.ID,
+		From:    etx.FromAddress,
+		Error:   *etx.Error,
+	})
+	return nil
 }
 
 // GetNextNonce returns keys.next_nonce for the given address
@@ -18,6 +18,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -218,6 +219,123 @@ func TestEthBroadcaster_ProcessUnstartedEthTxs_Success(t *testing.T) {
 	})
 }
 
+func TestEthBroadcaster_ProcessUnstartedEthTxs_KeySettings(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb, cleanup := cltest.NewEthBroadcaster(t, store, config)
+	defer cleanup()
+
+	toAddress := gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411")
+	newEthTx := func(fromAddress gethCommon.Address) models.EthTx {
+		etx := models.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, 42, 0},
+			Value:          assets.NewEthValue(0),
+			GasLimit:       uint64(242),
+			State:          models.EthTxUnstarted,
+		}
+		require.NoError(t, store.DB.Save(&etx).Error)
+		return etx
+	}
+	updateSettings := func(address gethCommon.Address, settings models.KeySettings) models.Key {
+		key, err := store.UpdateKeySettings(address, settings)
+		require.NoError(t, err)
+		return key
+	}
+
+	t.Run("refuses to send from keys restricted to another chain", func(t *testing.T) {
+		_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, store, 0)
+		key := updateSettings(fromAddress, models.KeySettings{ChainID: utils.NewBigI(1337)})
+		etx := newEthTx(fromAddress)
+
+		require.Error(t, eb.ProcessUnstartedEthTxs(key))
+
+		etx, err := store.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EthTxUnstarted, etx.State)
+	})
+
+	t.Run("caps the gas price at the key's maximum", func(t *testing.T) {
+		_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, store, 0)
+		maxGasPrice := new(big.Int).Sub(config.EthGasPriceDefault(), big.NewInt(1))
+		key := updateSettings(fromAddress, models.KeySettings{MaxGasPriceWei: utils.NewBig(maxGasPrice)})
+		etx := newEthTx(fromAddress)
+
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.Nonce() == uint64(0) && tx.GasPrice().Cmp(maxGasPrice) == 0
+		})).Return(nil).Once()
+
+		require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+		etx, err := store.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("does not exceed the key's in-flight transaction limit", func(t *testing.T) {
+		_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, store, 1)
+		maxInFlight := uint32(1)
+		key := updateSettings(fromAddress, models.KeySettings{MaxInFlightTransactions: &maxInFlight})
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0, fromAddress)
+		etx := newEthTx(fromAddress)
+
+		require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+		etx, err := store.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EthTxUnstarted, etx.State)
+	})
+
+	t.Run("rejects transactions from transmitter keys to non-OCR contracts", func(t *testing.T) {
+		_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, store, 0)
+		key := updateSettings(fromAddress, models.KeySettings{IsTransmitter: true})
+		etx := newEthTx(fromAddress)
+
+		require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+		etx, err := store.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EthTxFatalError, etx.State)
+		require.NotNil(t, etx.Error)
+		assert.Contains(t, *etx.Error, "transmitter keys may only send transactions to OCR contracts")
+		assert.Len(t, etx.EthTxAttempts, 0)
+	})
+
+	t.Run("lets transmitter keys fund the new transmitter of a rotation", func(t *testing.T) {
+		_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, store, 0)
+		key := updateSettings(fromAddress, models.KeySettings{IsTransmitter: true})
+		_, newAddress := cltest.MustAddRandomKeyToKeystore(t, store, 0)
+		jb := cltest.MustInsertV2JobSpec(t, store, fromAddress)
+		require.NoError(t, store.DB.Exec(`
+			INSERT INTO ocr_transmitter_rotations (job_id, old_address, new_address, state, created_at, updated_at)
+			VALUES (?, ?, ?, 'funding', NOW(), NOW())
+		`, jb.ID, fromAddress, newAddress).Error)
+		etx, err := bulletprooftxmanager.SendEther(store, fromAddress, newAddress, *assets.NewEth(1))
+		require.NoError(t, err)
+
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.To() != nil && *tx.To() == newAddress
+		})).Return(nil).Once()
+
+		require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+		etx, err = store.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+		ethClient.AssertExpectations(t)
+	})
+}
+
 func TestEthBroadcaster_AssignsNonceOnStart(t *testing.T) {
 	var err error
 	store, cleanup := cltest.NewStore(t)
@@ -13,15 +13,19 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	"gorm.io/gorm"
@@ -232,6 +236,7 @@ func (ec *ethConfirmer) CheckForReceipts(ctx context.Context, blockNum int64) er
 		if err := ec.saveFetchedReceipts(ctx, receipts); err != nil {
 			return errors.Wrap(err, "saveFetchedReceipts failed")
 		}
+		ec.saveRevertReasons(ctx, batch, receipts)
 	}
 
 	if err := ec.markConfirmedMissingReceipt(ctx); err != nil {
@@ -350,6 +355,8 @@ func (ec *ethConfirmer) saveFetchedReceipts(ctx context.Context, receipts []Rece
 	//
 	// # EthTxes update
 	// Should be self-explanatory. If we got a receipt, the eth_tx is confirmed.
+	// The eth_txes that were not confirmed before are returned, so that their
+	// confirmation is published on the event bus only once.
 	//
 	var valueStrs []string
 	var valueArgs []interface{}
@@ -383,17 +390,154 @@ func (ec *ethConfirmer) saveFetchedReceipts(ctx context.Context, receipts []Rece
 			broadcast_before_block_num = COALESCE(eth_tx_attempts.broadcast_before_block_num, inserted_receipts.block_number)
 		FROM inserted_receipts
 		WHERE inserted_receipts.tx_hash = eth_tx_attempts.hash
-		RETURNING eth_tx_attempts.eth_tx_id
+		RETURNING eth_tx_attempts.eth_tx_id, eth_tx_attempts.hash, inserted_receipts.block_number
 	)
 	UPDATE eth_txes
 	SET state = 'confirmed'
 	FROM updated_eth_tx_attempts
-	WHERE updated_eth_tx_attempts.eth_tx_id = eth_txes.id
+	WHERE updated_eth_tx_attempts.eth_tx_id = eth_txes.id AND eth_txes.state <> 'confirmed'
+	RETURNING eth_txes.id, eth_txes.from_address, updated_eth_tx_attempts.hash, updated_eth_tx_attempts.block_number
 	`
 
 	stmt := fmt.Sprintf(sql, strings.Join(valueStrs, ","))
-	_, err = ec.store.MustSQLDB().ExecContext(ctx, stmt, valueArgs...)
-	return errors.Wrap(err, "saveFetchedReceipts failed to save receipts")
+	rows, err := ec.store.MustSQLDB().QueryContext(ctx, stmt, valueArgs...)
+	if err != nil {
+		return errors.Wrap(err, "saveFetchedReceipts failed to save receipts")
+	}
+	defer logger.ErrorIfCalling(rows.Close)
+
+	var confirmed []eventbus.TxConfirmed
+	for rows.Next() {
+		var event eventbus.TxConfirmed
+		if err = rows.Scan(&event.EthTxID, &event.From, &event.Hash, &event.BlockNumber); err != nil {
+			return errors.Wrap(err, "saveFetchedReceipts failed to scan confirmed eth_txes")
+		}
+		confirmed = append(confirmed, event)
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Wrap(err, "saveFetchedReceipts failed to save receipts")
+	}
+	ec.recordJobGasCosts(ctx, confirmed, receipts)
+	for _, event := range confirmed {
+		ec.store.Events.Publish(event)
+	}
+	return nil
+}
+
+// recordJobGasCosts adds the gas spent by newly confirmed transactions that
+// were sent for a job to the job's costs for the day they were created on.
+// Each transaction is only counted once, even if it is confirmed again after
+// a re-org. The receipts have already been saved, so failing to record the
+// costs is only logged.
+func (ec *ethConfirmer) recordJobGasCosts(ctx context.Context, confirmed []eventbus.TxConfirmed, receipts []Receipt) {
+	if len(confirmed) == 0 {
+		return
+	}
+	gasUsed := make(map[gethCommon.Hash]uint64, len(receipts))
+	for _, r := range receipts {
+		gasUsed[r.TxHash] = r.GasUsed
+	}
+	var ids []int64
+	var hashes [][]byte
+	var gas []int64
+	for _, event := range confirmed {
+		ids = append(ids, event.EthTxID)
+		hashes = append(hashes, event.Hash.Bytes())
+		gas = append(gas, int64(gasUsed[event.Hash]))
+	}
+
+	_, err := ec.store.MustSQLDB().ExecContext(ctx, `
+		WITH recorded AS (
+			UPDATE eth_txes SET job_cost_recorded = true
+			WHERE id = ANY($1) AND job_id IS NOT NULL AND NOT job_cost_recorded
+			RETURNING id, job_id, to_address, created_at
+		)
+		INSERT INTO job_costs (job_id, day, kind, target, calls, gas_used, fee_wei)
+		SELECT recorded.job_id, (recorded.created_at AT TIME ZONE 'UTC')::date, 'transaction', '0x' || encode(recorded.to_address, 'hex'),
+			COUNT(*), SUM(g.gas_used), SUM(g.gas_used * eth_tx_attempts.gas_price)
+		FROM recorded
+		JOIN eth_tx_attempts ON eth_tx_attempts.eth_tx_id = recorded.id
+		JOIN unnest($2::bytea[], $3::bigint[]) AS g(hash, gas_used) ON g.hash = eth_tx_attempts.hash
+		GROUP BY 1, 2, 4
+		ON CONFLICT (job_id, day, kind, target) DO UPDATE SET
+			calls = job_costs.calls + EXCLUDED.calls,
+			gas_used = job_costs.gas_used + EXCLUDED.gas_used,
+			fee_wei = job_costs.fee_wei + EXCLUDED.fee_wei
+	`, pq.Array(ids), pq.Array(hashes), pq.Array(gas))
+	if err != nil {
+		logger.Errorw("EthConfirmer: could not record the gas costs of jobs' transactions", "err", err)
+	}
+}
+
+// saveRevertReasons replays the transactions of any reverted receipts with
+// eth_call against the state of the block before they were mined, and saves
+// the decoded revert reason on their attempts. The receipts have already been
+// saved, so failing to recover a reason is only logged.
+func (ec *ethConfirmer) saveRevertReasons(ctx context.Context, attempts []models.EthTxAttempt, receipts []Receipt) {
+	attemptsByHash := make(map[gethCommon.Hash]models.EthTxAttempt, len(attempts))
+	for _, attempt := range attempts {
+		attemptsByHash[attempt.Hash] = attempt
+	}
+
+	for _, receipt := range receipts {
+		// Receipts from before Byzantium have a post state root instead of a status
+		if receipt.Status != gethTypes.ReceiptStatusFailed || len(receipt.PostState) > 0 {
+			continue
+		}
+		attempt, exists := attemptsByHash[receipt.TxHash]
+		if !exists {
+			continue
+		}
+
+		l := logger.Default.With(
+			"txHash", attempt.Hash.Hex(), "ethTxAttemptID", attempt.ID, "ethTxID", attempt.EthTxID, "blockNumber", receipt.BlockNumber,
+		)
+		var etx models.EthTx
+		if err := ec.store.DB.First(&etx, attempt.EthTxID).Error; err != nil {
+			l.Errorw("EthConfirmer: transaction reverted, but could not load it", "err", err)
+			continue
+		}
+		reason, err := ec.fetchRevertReason(ctx, etx, attempt, receipt)
+		ec.store.Events.Publish(eventbus.TxFailed{
+			EthTxID:  etx.ID,
+			From:     etx.FromAddress,
+			Hash:     receipt.TxHash,
+			Error:    reason,
+			Reverted: true,
+		})
+		if err != nil {
+			l.Warnw("EthConfirmer: transaction reverted, but could not fetch its revert reason", "err", err)
+			continue
+		}
+		l.Warnw("EthConfirmer: transaction reverted", "revertReason", reason)
+
+		err = ec.store.DB.Exec(`UPDATE eth_tx_attempts SET revert_reason = ? WHERE id = ?`, reason, attempt.ID).Error
+		if err != nil {
+			l.Errorw("EthConfirmer: failed to save revert reason", "err", err)
+		}
+	}
+}
+
+func (ec *ethConfirmer) fetchRevertReason(ctx context.Context, etx models.EthTx, attempt models.EthTxAttempt, receipt Receipt) (string, error) {
+	msg := ethereum.CallMsg{
+		From:     etx.FromAddress,
+		To:       &etx.ToAddress,
+		Gas:      etx.GasLimit,
+		GasPrice: attempt.GasPrice.ToInt(),
+		Value:    etx.Value.ToInt(),
+		Data:     etx.EncodedPayload,
+	}
+	parentBlock := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	result := eth.SimulateCall(ctx, ec.ethClient, msg, hexutil.EncodeBig(parentBlock))
+	if result.Success {
+		return "", errors.New("transaction succeeded when replayed")
+	}
+	if result.RevertReason != "" {
+		return result.RevertReason, nil
+	}
+	// The eth node did not return revert data that could be decoded, but its
+	// error (e.g. "out of gas") is still more useful than nothing
+	return result.Error, nil
 }
 
 // markConfirmedMissingReceipt
@@ -524,6 +668,15 @@ func (ec *ethConfirmer) rebroadcastWhereNecessary(ctx context.Context, address g
 	if err != nil {
 		return errors.Wrap(err, "FindEthTxsRequiringRebroadcast failed")
 	}
+	now := time.Now()
+	window := ec.config.EthGasBumpDeadlineWindow()
+	if threshold > 0 && window > 0 {
+		priorityEtxs, err := FindEthTxsRequiringPriorityGasBump(ec.store.DB, address, blockHeight, now, window, depth)
+		if err != nil {
+			return errors.Wrap(err, "FindEthTxsRequiringPriorityGasBump failed")
+		}
+		etxs = mergeEthTxs(etxs, priorityEtxs)
+	}
 	logger.Debugf("EthConfirmer: Rebroadcasting %v transactions", len(etxs))
 	for _, etx := range etxs {
 		// NOTE: This races with OCR transaction insertion that checks for
@@ -534,7 +687,7 @@ func (ec *ethConfirmer) rebroadcastWhereNecessary(ctx context.Context, address g
 		//
 		// This still limits the worst case to a maximum of two transactions
 		// pending though which is probably acceptable.
-		attempt, err := ec.attemptForRebroadcast(etx)
+		attempt, err := ec.attemptForRebroadcast(etx, isNearDeadline(etx, now, window))
 		if err != nil {
 			return errors.Wrap(err, "attemptForRebroadcast failed")
 		}
@@ -602,13 +755,19 @@ func FindEthTxsRequiringRebroadcast(db *gorm.DB, address gethCommon.Address, blo
 		return nil, err
 	}
 
+	return mergeEthTxs(etxInsufficientEths, etxBumps), nil
+}
+
+// mergeEthTxs returns the transactions in a and b in nonce ASC order, without
+// the transactions of b that are also in a
+func mergeEthTxs(a, b []models.EthTx) (etxs []models.EthTx) {
 	seen := make(map[int64]struct{})
 
-	for _, etx := range etxInsufficientEths {
+	for _, etx := range a {
 		seen[etx.ID] = struct{}{}
 		etxs = append(etxs, etx)
 	}
-	for _, etx := range etxBumps {
+	for _, etx := range b {
 		if _, exists := seen[etx.ID]; !exists {
 			etxs = append(etxs, etx)
 		}
@@ -668,7 +827,40 @@ func FindEthTxsRequiringGasBump(db *gorm.DB, address gethCommon.Address, blockNu
 	return
 }
 
-func (ec *ethConfirmer) attemptForRebroadcast(etx models.EthTx) (attempt models.EthTxAttempt, err error) {
+// FindEthTxsRequiringPriorityGasBump returns unconfirmed transactions whose
+// deadline is less than window after now, and whose attempts were all
+// broadcast before blockNum, limited by depth pending transactions. They are
+// bumped on every block until their deadline has passed, rather than every
+// gasBumpThreshold blocks.
+func FindEthTxsRequiringPriorityGasBump(db *gorm.DB, address gethCommon.Address, blockNum int64, now time.Time, window time.Duration, depth int64) (etxs []models.EthTx, err error) {
+	q := db.
+		Preload("EthTxAttempts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("eth_tx_attempts.gas_price DESC")
+		}).
+		Joins("LEFT JOIN eth_tx_attempts ON eth_txes.id = eth_tx_attempts.eth_tx_id "+
+			"AND (broadcast_before_block_num > ? OR broadcast_before_block_num IS NULL OR eth_tx_attempts.state != 'broadcast')", blockNum-1).
+		Where("eth_txes.state = 'unconfirmed' AND eth_tx_attempts.id IS NULL AND eth_txes.from_address = ?", address).
+		Where("eth_txes.deadline > ? AND eth_txes.deadline <= ?", now, now.Add(window))
+
+	if depth > 0 {
+		q = q.Where("eth_txes.id IN (SELECT id FROM eth_txes WHERE state = 'unconfirmed' AND from_address = ? ORDER BY nonce ASC LIMIT ?)", address, depth)
+	}
+
+	err = q.Order("nonce ASC").Find(&etxs).Error
+	err = errors.Wrap(err, "FindEthTxsRequiringPriorityGasBump failed to load eth_txes requiring priority gas bump")
+
+	return
+}
+
+// isNearDeadline returns whether the transaction's deadline is less than
+// window after now
+func isNearDeadline(etx models.EthTx, now time.Time, window time.Duration) bool {
+	return etx.Deadline != nil && etx.Deadline.After(now) && !etx.Deadline.After(now.Add(window))
+}
+
+// attemptForRebroadcast returns the next attempt of the transaction. Attempts
+// of transactions near their deadline are bumped by twice the usual amount.
+func (ec *ethConfirmer) attemptForRebroadcast(etx models.EthTx, nearDeadline bool) (attempt models.EthTxAttempt, err error) {
 	var bumpedGasPrice *big.Int
 	if len(etx.EthTxAttempts) > 0 {
 		previousAttempt := etx.EthTxAttempts[0]
@@ -680,9 +872,18 @@ func (ec *ethConfirmer) attemptForRebroadcast(etx models.EthTx) (attempt models.
 			return previousAttempt, nil
 		}
 		previousGasPrice := previousAttempt.GasPrice
-		bumpedGasPrice, err = BumpGas(ec.config, previousGasPrice.ToInt())
+		var maxGasPrice *big.Int
+		maxGasPrice, err = maxGasPriceFor(ec.store, ec.config, etx.FromAddress)
+		if err != nil {
+			return attempt, errors.Wrap(err, "attemptForRebroadcast failed")
+		}
+		multiple := int64(1)
+		if nearDeadline {
+			multiple = 2
+		}
+		bumpedGasPrice, err = bumpGasBy(ec.config, multiple, previousGasPrice.ToInt(), maxGasPrice)
 		if err != nil {
-			logger.Errorw("Failed to bump gas", "err", err, "etxID", etx.ID, "txHash", attempt.Hash, "originalGasPrice", previousGasPrice.String(), "maxGasPrice", ec.config.EthMaxGasPriceWei())
+			logger.Errorw("Failed to bump gas", "err", err, "etxID", etx.ID, "txHash", attempt.Hash, "originalGasPrice", previousGasPrice.String(), "maxGasPrice", maxGasPrice)
 			// Do not create a new attempt if bumping gas would put us over the limit or cause some other problem
 			// Instead try to resubmit the previous attempt, and keep resubmitting until its accepted
 			previousAttempt.BroadcastBeforeBlockNum = nil
@@ -690,7 +891,7 @@ func (ec *ethConfirmer) attemptForRebroadcast(etx models.EthTx) (attempt models.
 			return previousAttempt, nil
 		}
 		logger.Debugw("EthConfirmer: rebroadcast bumping gas",
-			"ethTxID", etx.ID, "nonce", etx.Nonce, "originalGasPrice", previousGasPrice.String(),
+			"ethTxID", etx.ID, "nonce", etx.Nonce, "nearDeadline", nearDeadline, "originalGasPrice", previousGasPrice.String(),
 			"bumpedGasPrice", bumpedGasPrice.String(), "previousTxHash", previousAttempt.Hash, "previousAttemptID", previousAttempt.ID)
 	} else {
 		logger.Errorf("invariant violation: EthTx %v was unconfirmed but didn't have any attempts. "+
@@ -721,7 +922,11 @@ func (ec *ethConfirmer) handleInProgressAttempt(ctx context.Context, etx models.
 		// already bumped above the required minimum in ethBroadcaster.
 		//
 		// It could conceivably happen if the remote eth node changed its configuration.
-		bumpedGasPrice, err := BumpGas(ec.config, attempt.GasPrice.ToInt())
+		maxGasPrice, err := maxGasPriceFor(ec.store, ec.config, etx.FromAddress)
+		if err != nil {
+			return errors.Wrap(err, "could not bump gas for terminally underpriced transaction")
+		}
+		bumpedGasPrice, err := bumpGas(ec.config, attempt.GasPrice.ToInt(), maxGasPrice)
 		if err != nil {
 			return errors.Wrap(err, "could not bump gas for terminally underpriced transaction")
 		}
@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -184,6 +186,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 	t.Run("saves nothing if returned receipt does not match the attempt", func(t *testing.T) {
 		bptxmReceipt := bulletprooftxmanager.Receipt{
 			TxHash:           cltest.NewHash(),
+			Status:           1,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
@@ -210,6 +213,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 	t.Run("saves nothing if query returns error", func(t *testing.T) {
 		bptxmReceipt := bulletprooftxmanager.Receipt{
 			TxHash:           attempt1_1.Hash,
+			Status:           1,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
@@ -242,6 +246,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 	t.Run("saves eth_receipt and marks eth_tx as confirmed when geth client returns valid receipt", func(t *testing.T) {
 		bptxmReceipt := bulletprooftxmanager.Receipt{
 			TxHash:           attempt1_1.Hash,
+			Status:           1,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
@@ -300,6 +305,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 
 		bptxmReceipt := bulletprooftxmanager.Receipt{
 			TxHash:           attempt2_2.Hash,
+			Status:           1,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
@@ -393,6 +399,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 
 		bptxmReceipt := bulletprooftxmanager.Receipt{
 			TxHash:           attempt3_1.Hash,
+			Status:           1,
 			BlockHash:        ethReceipt.BlockHash,
 			BlockNumber:      big.NewInt(ethReceipt.BlockNumber),
 			TransactionIndex: ethReceipt.TransactionIndex,
@@ -438,6 +445,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 
 		bptxmReceipt := bulletprooftxmanager.Receipt{
 			TxHash:           attempt4_2.Hash,
+			Status:           1,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
@@ -542,6 +550,127 @@ func TestEthConfirmer_CheckForReceipts_batching(t *testing.T) {
 	ethClient.AssertExpectations(t)
 }
 
+func TestEthConfirmer_CheckForReceipts_recordsJobGasCosts(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, store, 0)
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+
+	ctx := context.Background()
+
+	jb := cltest.MustInsertSampleDirectRequestJob(t, store.DB)
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0, fromAddress)
+	attempt := etx.EthTxAttempts[0]
+	require.NoError(t, store.DB.Exec(`UPDATE eth_txes SET job_id = ? WHERE id = ?`, jb.ID, etx.ID).Error)
+	require.NoError(t, store.DB.Exec(`UPDATE eth_tx_attempts SET gas_price = 3 WHERE id = ?`, attempt.ID).Error)
+
+	bptxmReceipt := bulletprooftxmanager.Receipt{
+		TxHash:           attempt.Hash,
+		Status:           types.ReceiptStatusSuccessful,
+		BlockHash:        cltest.NewHash(),
+		BlockNumber:      big.NewInt(42),
+		TransactionIndex: uint(1),
+		GasUsed:          21000,
+	}
+	ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		return len(b) == 1 && cltest.BatchElemMatchesHash(b[0], attempt.Hash)
+	})).Return(nil).Run(func(args mock.Arguments) {
+		elems := args.Get(1).([]rpc.BatchElem)
+		elems[0].Result = &bptxmReceipt
+	}).Once()
+
+	require.NoError(t, ec.CheckForReceipts(ctx, 42))
+
+	var costs []job.Cost
+	require.NoError(t, store.DB.Find(&costs).Error)
+	require.Len(t, costs, 1)
+	assert.Equal(t, jb.ID, costs[0].JobID)
+	assert.Equal(t, job.CostKindTransaction, costs[0].Kind)
+	assert.Equal(t, strings.ToLower(etx.ToAddress.Hex()), costs[0].Target)
+	assert.Equal(t, int64(1), costs[0].Calls)
+	assert.Equal(t, "21000", costs[0].GasUsed.String())
+	assert.Equal(t, "63000", costs[0].FeeWei.String())
+
+	var recorded bool
+	require.NoError(t, store.DB.Raw(`SELECT job_cost_recorded FROM eth_txes WHERE id = ?`, etx.ID).Row().Scan(&recorded))
+	assert.True(t, recorded)
+
+	ethClient.AssertExpectations(t)
+}
+
+// revertError is an eth_call error carrying revert data, like those returned
+// by geth
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string          { return "execution reverted" }
+func (e revertError) ErrorCode() int         { return 3 }
+func (e revertError) ErrorData() interface{} { return e.data }
+
+func TestEthConfirmer_CheckForReceipts_revertReason(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, store, 0)
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+
+	ctx := context.Background()
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0, fromAddress)
+	attempt := etx.EthTxAttempts[0]
+
+	bptxmReceipt := bulletprooftxmanager.Receipt{
+		TxHash:           attempt.Hash,
+		Status:           types.ReceiptStatusFailed,
+		BlockHash:        cltest.NewHash(),
+		BlockNumber:      big.NewInt(42),
+		TransactionIndex: uint(1),
+	}
+	ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		return len(b) == 1 && cltest.BatchElemMatchesHash(b[0], attempt.Hash)
+	})).Return(nil).Run(func(args mock.Arguments) {
+		elems := args.Get(1).([]rpc.BatchElem)
+		elems[0].Result = &bptxmReceipt
+	}).Once()
+
+	// Replayed against the state before the block it was mined in
+	revertData := "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000000e" +
+		"4d7573742068617665204c494e4b000000000000000000000000000000000000"
+	ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.Anything, "0x29").
+		Return(revertError{revertData}).Once()
+
+	require.NoError(t, ec.CheckForReceipts(ctx, 42))
+
+	etx, err := store.FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxConfirmed, etx.State)
+	require.Len(t, etx.EthTxAttempts, 1)
+	require.NotNil(t, etx.EthTxAttempts[0].RevertReason)
+	assert.Equal(t, "Must have LINK", *etx.EthTxAttempts[0].RevertReason)
+
+	ethClient.AssertExpectations(t)
+}
+
 func TestEthConfirmer_CheckForReceipts_confirmed_missing_receipt(t *testing.T) {
 	t.Parallel()
 
@@ -603,12 +732,14 @@ func TestEthConfirmer_CheckForReceipts_confirmed_missing_receipt(t *testing.T) {
 	t.Run("marks buried eth_txes as 'confirmed_missing_receipt'", func(t *testing.T) {
 		bptxmReceipt0 := bulletprooftxmanager.Receipt{
 			TxHash:           attempt0_2.Hash,
+			Status:           1,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
 		}
 		bptxmReceipt3 := bulletprooftxmanager.Receipt{
 			TxHash:           attempt3_1.Hash,
+			Status:           1,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
@@ -675,6 +806,7 @@ func TestEthConfirmer_CheckForReceipts_confirmed_missing_receipt(t *testing.T) {
 	t.Run("marks eth_txes with state 'confirmed_missing_receipt' as 'confirmed' if a receipt finally shows up", func(t *testing.T) {
 		bptxmReceipt := bulletprooftxmanager.Receipt{
 			TxHash:           attempt2_1.Hash,
+			Status:           1,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(43),
 			TransactionIndex: uint(1),
@@ -2109,3 +2241,125 @@ func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 		ethClient.AssertExpectations(t)
 	})
 }
+
+func TestEthConfirmer_FindEthTxsRequiringPriorityGasBump(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, store, 0)
+
+	currentHead := int64(30)
+	window := 30 * time.Second
+	now := time.Now()
+	setDeadline := func(etx models.EthTx, deadline time.Time) {
+		require.NoError(t, store.DB.Exec(`UPDATE eth_txes SET deadline = ? WHERE id = ?`, deadline, etx.ID).Error)
+	}
+	setBroadcastBeforeBlockNum := func(etx models.EthTx, blockNum int64) {
+		attempt := etx.EthTxAttempts[0]
+		attempt.BroadcastBeforeBlockNum = &blockNum
+		require.NoError(t, store.DB.Save(&attempt).Error)
+	}
+
+	// Broadcast in the last block, but near its deadline
+	etx1 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0, fromAddress)
+	setBroadcastBeforeBlockNum(etx1, currentHead-1)
+	setDeadline(etx1, now.Add(10*time.Second))
+	// Not near its deadline
+	etx2 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 1, fromAddress)
+	setBroadcastBeforeBlockNum(etx2, currentHead-1)
+	setDeadline(etx2, now.Add(time.Minute))
+	// Past its deadline
+	etx3 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 2, fromAddress)
+	setBroadcastBeforeBlockNum(etx3, currentHead-1)
+	setDeadline(etx3, now.Add(-time.Second))
+	// Without a deadline
+	etx4 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 3, fromAddress)
+	setBroadcastBeforeBlockNum(etx4, currentHead-1)
+	// Near its deadline, but broadcast in this block
+	etx5 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 4, fromAddress)
+	setBroadcastBeforeBlockNum(etx5, currentHead)
+	setDeadline(etx5, now.Add(10*time.Second))
+
+	etxs, err := bulletprooftxmanager.FindEthTxsRequiringPriorityGasBump(store.DB, fromAddress, currentHead, now, window, 10)
+	require.NoError(t, err)
+	require.Len(t, etxs, 1)
+	assert.Equal(t, etx1.ID, etxs[0].ID)
+
+	t.Run("respects the depth", func(t *testing.T) {
+		require.NoError(t, store.DB.Exec(`UPDATE eth_txes SET deadline = ? WHERE id = ?`, now.Add(10*time.Second), etx2.ID).Error)
+		etxs, err := bulletprooftxmanager.FindEthTxsRequiringPriorityGasBump(store.DB, fromAddress, currentHead, now, window, 1)
+		require.NoError(t, err)
+		require.Len(t, etxs, 1)
+		assert.Equal(t, etx1.ID, etxs[0].ID)
+	})
+}
+
+func TestEthConfirmer_RebroadcastWhereNecessary_NearDeadline(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("ETH_GAS_BUMP_THRESHOLD", 10)
+	config.Set("ETH_GAS_BUMP_DEADLINE_WINDOW", "30s")
+	kst := new(mocks.KeyStoreInterface)
+	store.KeyStore = kst
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+	currentHead := int64(30)
+	recentlyBroadcast := currentHead - 1
+
+	key := cltest.MustInsertRandomKey(t, store.DB)
+	fromAddress := key.Address.Address()
+	kst.On("GetAccountByAddress", fromAddress).
+		Return(gethAccounts.Account{Address: fromAddress}, nil)
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0, fromAddress)
+	attempt := etx.EthTxAttempts[0]
+	attempt.BroadcastBeforeBlockNum = &recentlyBroadcast
+	require.NoError(t, store.DB.Save(&attempt).Error)
+
+	t.Run("waits for the gas bump threshold without a deadline", func(t *testing.T) {
+		require.NoError(t, ec.RebroadcastWhereNecessary(context.TODO(), []models.Key{key}, currentHead))
+
+		etx, err := store.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		require.Len(t, etx.EthTxAttempts, 1)
+	})
+
+	require.NoError(t, store.DB.Exec(`UPDATE eth_txes SET deadline = ? WHERE id = ?`, time.Now().Add(10*time.Second), etx.ID).Error)
+
+	t.Run("bumps by twice the usual amount every block near the deadline", func(t *testing.T) {
+		ethTx := *types.NewTx(&types.LegacyTx{})
+		kst.On("SignTx",
+			mock.AnythingOfType("accounts.Account"),
+			mock.MatchedBy(func(tx *types.Transaction) bool {
+				if tx.Nonce() != uint64(*etx.Nonce) {
+					return false
+				}
+				ethTx = *tx
+				return true
+			}),
+			mock.Anything).Return(&ethTx, nil).Once()
+		// 20 Gwei default gas price bumped by twice ETH_GAS_BUMP_WEI, which
+		// beats twice ETH_GAS_BUMP_PERCENT
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == uint64(*etx.Nonce) && tx.GasPrice().Int64() == int64(30000000000)
+		})).Return(nil).Once()
+
+		require.NoError(t, ec.RebroadcastWhereNecessary(context.TODO(), []models.Key{key}, currentHead))
+
+		etx, err := store.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		require.Len(t, etx.EthTxAttempts, 2)
+		assert.Equal(t, int64(30000000000), etx.EthTxAttempts[1].GasPrice.ToInt().Int64())
+
+		kst.AssertExpectations(t)
+		ethClient.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,247 @@
+package bulletprooftxmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"gorm.io/gorm"
+)
+
+// ExternalTxDetector scans every new block for transactions from the sending
+// keys that the node didn't send, e.g. from an external wallet or another
+// node sharing a key. Using a key elsewhere is not supported, and it is the
+// most common way for a key to get wedged: the node keeps sending
+// transactions with nonces that are already used, or leaves nonces that it
+// skipped unused.
+//
+// Each external transaction is reconciled with the key's nonce state as the
+// NonceSyncer would on startup: if the node hadn't used its nonce yet,
+// keys.next_nonce is fast forwarded past it, and the transaction is saved so
+// that the EthConfirmer tracks it. If the node had already used its nonce,
+// the node's eth_tx can no longer be mined, and is left for the EthConfirmer
+// to mark as missing its receipt. Either way, an ExternalTx event is
+// published so that the operator is alerted.
+type ExternalTxDetector struct {
+	utils.StartStopOnce
+
+	store       *store.Store
+	config      orm.ConfigReader
+	ethClient   eth.Client
+	mb          *utils.Mailbox
+	lastScanned int64
+	chStop      chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewExternalTxDetector returns a detector that scans the blocks of the heads
+// it is sent
+func NewExternalTxDetector(store *store.Store, config orm.ConfigReader, ethClient eth.Client) *ExternalTxDetector {
+	return &ExternalTxDetector{
+		store:     store,
+		config:    config,
+		ethClient: ethClient,
+		mb:        utils.NewMailbox(1),
+		chStop:    make(chan struct{}),
+	}
+}
+
+func (d *ExternalTxDetector) Connect(*models.Head) error { return nil }
+
+func (d *ExternalTxDetector) Disconnect() {}
+
+func (d *ExternalTxDetector) OnNewLongestChain(_ context.Context, head models.Head) {
+	d.mb.Deliver(head)
+}
+
+func (d *ExternalTxDetector) Start() error {
+	if !d.OkayToStart() {
+		return errors.New("ExternalTxDetector has already been started")
+	}
+	d.wg.Add(1)
+	go d.runLoop()
+	return nil
+}
+
+func (d *ExternalTxDetector) Close() error {
+	if !d.OkayToStop() {
+		return errors.New("ExternalTxDetector has already been stopped")
+	}
+	close(d.chStop)
+	d.wg.Wait()
+	return nil
+}
+
+func (d *ExternalTxDetector) runLoop() {
+	defer d.wg.Done()
+
+	ctx, cancel := utils.CombinedContext(d.chStop)
+	defer cancel()
+
+	for {
+		select {
+		case <-d.mb.Notify():
+			head, is := d.mb.Retrieve().(models.Head)
+			if !is {
+				continue
+			}
+			if err := d.ScanHead(ctx, head); err != nil {
+				logger.Errorw("ExternalTxDetector: failed to scan blocks for external transactions", "head", head.Number, "err", err)
+			}
+		case <-d.chStop:
+			return
+		}
+	}
+}
+
+// ScanHead scans the blocks since the last head that was scanned, up to and
+// including head, for external transactions. No more than ETH_FINALITY_DEPTH
+// blocks are scanned, and only head is scanned the first time.
+func (d *ExternalTxDetector) ScanHead(ctx context.Context, head models.Head) error {
+	keys, err := d.store.SendKeys()
+	if err != nil {
+		return errors.Wrap(err, "ExternalTxDetector failed to get keys")
+	}
+	addresses := make(map[common.Address]struct{})
+	for _, key := range keys {
+		addresses[key.Address.Address()] = struct{}{}
+	}
+
+	from := d.lastScanned + 1
+	if floor := head.Number - int64(d.config.EthFinalityDepth()) + 1; from < floor {
+		from = floor
+	}
+	// Only head is scanned the first time, and after a re-org to a shorter
+	// chain, when head hasn't been scanned even though its number has
+	if d.lastScanned == 0 || from > head.Number {
+		from = head.Number
+	}
+	var reqs []rpc.BatchElem
+	for i := from; i <= head.Number; i++ {
+		reqs = append(reqs, rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{models.Int64ToHex(i), true},
+			Result: &models.Block{},
+		})
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, maxEthNodeRequestTime)
+	defer cancel()
+	if err = d.ethClient.BatchCallContext(reqCtx, reqs); err != nil {
+		return errors.Wrap(err, "ExternalTxDetector failed to fetch blocks")
+	}
+
+	// The latest signer recovers the senders of typed transactions, e.g.
+	// EIP-2930 access list transactions, as well as legacy ones
+	signer := types.LatestSignerForChainID(d.config.ChainID())
+	for _, req := range reqs {
+		if req.Error != nil {
+			return errors.Wrapf(req.Error, "ExternalTxDetector failed to fetch block %v", req.Args[0])
+		}
+		block, is := req.Result.(*models.Block)
+		if !is {
+			panic(fmt.Sprintf("invariant violation, expected %T but got %T", &models.Block{}, req.Result))
+		}
+		txes := block.Transactions
+		sort.SliceStable(txes, func(i, j int) bool { return txes[i].Nonce() < txes[j].Nonce() })
+		for _, tx := range txes {
+			sender, err := types.Sender(signer, &tx)
+			if err != nil {
+				logger.Warnw("ExternalTxDetector: could not recover the sender of transaction", "txHash", tx.Hash(), "block", block.Number, "err", err)
+				continue
+			}
+			if _, managed := addresses[sender]; !managed {
+				continue
+			}
+			if err := d.handleTx(ctx, sender, tx, block.Number); err != nil {
+				return err
+			}
+		}
+		d.lastScanned = block.Number
+	}
+	return nil
+}
+
+// handleTx reconciles the key's nonces with tx if the node didn't send it
+func (d *ExternalTxDetector) handleTx(ctx context.Context, from common.Address, tx types.Transaction, blockNum int64) error {
+	var sent bool
+	err := d.store.DB.Raw(`SELECT EXISTS (SELECT 1 FROM eth_tx_attempts WHERE hash = ?)`, tx.Hash()).Scan(&sent).Error
+	if err != nil {
+		return errors.Wrap(err, "ExternalTxDetector failed to look up attempt")
+	} else if sent {
+		return nil
+	}
+
+	event := eventbus.ExternalTx{From: from, Hash: tx.Hash(), Nonce: tx.Nonce(), BlockNumber: blockNum}
+	nonce := int64(tx.Nonce())
+	err = postgres.GormTransaction(ctx, d.store.DB, func(dbtx *gorm.DB) error {
+		var etx models.EthTx
+		err := dbtx.First(&etx, "from_address = ? AND nonce = ?", from, nonce).Error
+		if err == nil {
+			event.ReplacedEthTxID = etx.ID
+			return nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Wrap(err, "ExternalTxDetector failed to look up eth_tx")
+		}
+
+		nextNonce, err := GetNextNonce(dbtx, from)
+		if err != nil {
+			return err
+		}
+		if nonce >= nextNonce {
+			// next_nonce is an optimistic lock, as in the NonceSyncer
+			res := dbtx.Exec(`UPDATE keys SET next_nonce = ?, updated_at = NOW() WHERE address = ? AND next_nonce = ?`, nonce+1, from, nextNonce)
+			if res.Error != nil {
+				return errors.Wrap(res.Error, "ExternalTxDetector failed to update keys.next_nonce")
+			}
+			if res.RowsAffected == 0 {
+				return errors.Errorf("ExternalTxDetector optimistic lock failure fastforwarding nonce %v to %v for key %s", nextNonce, nonce+1, from.Hex())
+			}
+		}
+
+		// Contract creations can't be saved as eth_txes, which always have a
+		// to_address
+		if tx.To() == nil {
+			return nil
+		}
+		account, err := d.store.KeyStore.GetAccountByAddress(from)
+		if err != nil {
+			return errors.Wrap(err, "ExternalTxDetector could not get account from keystore")
+		}
+		ins, err := NonceSyncer{d.store, d.config, d.ethClient}.MakeInsert(tx, account, blockNum, nonce)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		ins.Etx.BroadcastAt = &now
+		if err := dbtx.Create(&ins.Etx).Error; err != nil {
+			return errors.Wrap(err, "ExternalTxDetector failed to create eth_tx")
+		}
+		ins.Attempt.EthTxID = ins.Etx.ID
+		return errors.Wrap(dbtx.Create(&ins.Attempt).Error, "ExternalTxDetector failed to create eth_tx_attempt")
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Errorw(fmt.Sprintf("ExternalTxDetector: transaction %s with nonce %d was sent from %s by something other than this node. "+
+		"Please note that using the chainlink keys with an external wallet is NOT SUPPORTED and can lead to missed or stuck transactions.",
+		tx.Hash().Hex(), tx.Nonce(), from.Hex()),
+		"address", from.Hex(), "txHash", tx.Hash(), "nonce", tx.Nonce(), "blockNum", blockNum, "replacedEthTxID", event.ReplacedEthTxID)
+	d.store.Events.Publish(event)
+	return nil
+}
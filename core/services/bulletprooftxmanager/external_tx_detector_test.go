@@ -0,0 +1,143 @@
+package bulletprooftxmanager_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mustSignTx(t *testing.T, store *store.Store, from common.Address, nonce uint64) types.Transaction {
+	t.Helper()
+
+	unsigned := types.NewTransaction(nonce, cltest.NewAddress(), big.NewInt(0), 21000, big.NewInt(1000000000), nil)
+	signed, err := store.KeyStore.SignTx(accounts.Account{Address: from}, unsigned, store.Config.ChainID())
+	require.NoError(t, err)
+	return *signed
+}
+
+func mockBlocks(ethClient *mocks.Client, blocks ...models.Block) {
+	ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		if len(b) != len(blocks) {
+			return false
+		}
+		for i, block := range blocks {
+			if b[i].Method != "eth_getBlockByNumber" || b[i].Args[0] != models.Int64ToHex(block.Number) || b[i].Args[1] != true {
+				return false
+			}
+		}
+		return true
+	})).Return(nil).Run(func(args mock.Arguments) {
+		elems := args.Get(1).([]rpc.BatchElem)
+		for i, block := range blocks {
+			*elems[i].Result.(*models.Block) = block
+		}
+	}).Once()
+}
+
+func receiveExternalTx(t *testing.T, sub *eventbus.Subscription) eventbus.ExternalTx {
+	t.Helper()
+
+	select {
+	case event := <-sub.Events():
+		return event.(eventbus.ExternalTx)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for ExternalTx event")
+		return eventbus.ExternalTx{}
+	}
+}
+
+func Test_ExternalTxDetector_ScanHead(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+
+	_, from := cltest.MustAddRandomKeyToKeystore(t, store, 3)
+	sub := store.Events.Subscribe(eventbus.TopicExternalTx)
+	defer sub.Close()
+
+	detector := bulletprooftxmanager.NewExternalTxDetector(store, store.Config, ethClient)
+
+	t.Run("fast forwards the nonce past external transactions and saves them", func(t *testing.T) {
+		tx := mustSignTx(t, store, from, 4)
+		mockBlocks(ethClient, models.Block{Number: 10, Transactions: []types.Transaction{tx}})
+
+		require.NoError(t, detector.ScanHead(context.Background(), models.Head{Number: 10}))
+
+		event := receiveExternalTx(t, sub)
+		assert.Equal(t, from, event.From)
+		assert.Equal(t, tx.Hash(), event.Hash)
+		assert.Equal(t, uint64(4), event.Nonce)
+		assert.Equal(t, int64(10), event.BlockNumber)
+		assert.Zero(t, event.ReplacedEthTxID)
+
+		assertDatabaseNonce(t, store, from, 5)
+		var etx models.EthTx
+		require.NoError(t, store.DB.First(&etx, "nonce = ?", 4).Error)
+		assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+		assert.Equal(t, *tx.To(), etx.ToAddress)
+	})
+
+	t.Run("ignores transactions sent by the node, and scans every block since the last head", func(t *testing.T) {
+		etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 5, from)
+		tx := mustSignTx(t, store, from, 5)
+		require.NoError(t, store.DB.Exec(`UPDATE eth_tx_attempts SET hash = ? WHERE eth_tx_id = ?`, tx.Hash(), etx.ID).Error)
+		mockBlocks(ethClient, models.Block{Number: 11, Transactions: []types.Transaction{tx}}, models.Block{Number: 12})
+
+		require.NoError(t, detector.ScanHead(context.Background(), models.Head{Number: 12}))
+
+		assert.Len(t, sub.Events(), 0)
+		cltest.AssertCount(t, store, models.EthTx{}, 2)
+	})
+
+	t.Run("reports the eth_tx whose nonce an external transaction used", func(t *testing.T) {
+		etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 6, from)
+		tx := mustSignTx(t, store, from, 6)
+		mockBlocks(ethClient, models.Block{Number: 13, Transactions: []types.Transaction{tx}})
+
+		require.NoError(t, detector.ScanHead(context.Background(), models.Head{Number: 13}))
+
+		event := receiveExternalTx(t, sub)
+		assert.Equal(t, etx.ID, event.ReplacedEthTxID)
+		cltest.AssertCount(t, store, models.EthTx{}, 3)
+	})
+
+	t.Run("detects typed transactions", func(t *testing.T) {
+		unsigned := types.NewTx(&types.AccessListTx{
+			ChainID:  store.Config.ChainID(),
+			Nonce:    7,
+			GasPrice: big.NewInt(1000000000),
+			Gas:      21000,
+			To:       &common.Address{},
+			Value:    big.NewInt(0),
+		})
+		tx, err := store.KeyStore.SignTx(accounts.Account{Address: from}, unsigned, store.Config.ChainID())
+		require.NoError(t, err)
+		mockBlocks(ethClient, models.Block{Number: 14, Transactions: []types.Transaction{*tx}})
+
+		require.NoError(t, detector.ScanHead(context.Background(), models.Head{Number: 14}))
+
+		event := receiveExternalTx(t, sub)
+		assert.Equal(t, tx.Hash(), event.Hash)
+		assert.Equal(t, uint64(7), event.Nonce)
+		assertDatabaseNonce(t, store, from, 8)
+	})
+
+	ethClient.AssertExpectations(t)
+}
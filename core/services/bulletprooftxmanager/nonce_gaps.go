@@ -0,0 +1,319 @@
+package bulletprooftxmanager
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"go.uber.org/multierr"
+	"gorm.io/gorm"
+)
+
+// NonceRungState is the state of the eth_tx that uses a nonce of the ladder,
+// or one of the states below if no eth_tx uses it
+type NonceRungState string
+
+const (
+	// NonceRungExternal nonces are used by transactions that the eth node has
+	// but that this node didn't send, e.g. ones sent from an external wallet
+	NonceRungExternal = NonceRungState("external")
+	// NonceRungGap nonces aren't used by any transaction. None of the
+	// transactions with higher nonces can be mined until they are filled.
+	NonceRungGap = NonceRungState("gap")
+)
+
+// NonceRung is a nonce of a key that hasn't been mined yet
+type NonceRung struct {
+	Nonce   int64
+	State   NonceRungState
+	EthTxID int64
+}
+
+// NonceLadder is the nonces of a key from the next one to be mined to the
+// highest one that is in use, either by the node or by the eth node's mempool
+type NonceLadder struct {
+	Address common.Address
+	// MinedNonce is the nonce of the next transaction to be mined
+	MinedNonce uint64
+	// PendingNonce is the next nonce according to the eth node's mempool
+	PendingNonce uint64
+	// NextNonce is the nonce that the node will give its next transaction
+	NextNonce int64
+	Rungs     []NonceRung
+}
+
+// Gaps returns the nonces of the ladder that no transaction uses
+func (l NonceLadder) Gaps() (gaps []int64) {
+	for _, rung := range l.Rungs {
+		if rung.State == NonceRungGap {
+			gaps = append(gaps, rung.Nonce)
+		}
+	}
+	return gaps
+}
+
+// FindNonceLadder returns the nonce ladder of the key. A nonce below
+// keys.next_nonce without an eth_tx is a gap unless the eth node has a pending
+// transaction for it, which is external.
+func FindNonceLadder(ctx context.Context, db *gorm.DB, ethClient eth.Client, address common.Address) (ladder NonceLadder, err error) {
+	ladder.Address = address
+	ladder.NextNonce, err = GetNextNonce(db, address)
+	if err != nil {
+		return ladder, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, maxEthNodeRequestTime)
+	defer cancel()
+	var minedNonce hexutil.Uint64
+	if err = ethClient.CallContext(ctx, &minedNonce, "eth_getTransactionCount", address, "latest"); err != nil {
+		return ladder, errors.Wrap(err, "FindNonceLadder failed to get the mined nonce")
+	}
+	ladder.MinedNonce = uint64(minedNonce)
+	ladder.PendingNonce, err = ethClient.PendingNonceAt(ctx, address)
+	if err != nil {
+		return ladder, errors.Wrap(err, "FindNonceLadder failed to get the pending nonce")
+	}
+
+	top := ladder.NextNonce
+	if int64(ladder.PendingNonce) > top {
+		top = int64(ladder.PendingNonce)
+	}
+	var etxs []models.EthTx
+	err = db.
+		Where("from_address = ? AND nonce >= ? AND nonce < ?", address, ladder.MinedNonce, top).
+		Order("nonce ASC").
+		Find(&etxs).Error
+	if err != nil {
+		return ladder, errors.Wrap(err, "FindNonceLadder failed to load eth_txes")
+	}
+	byNonce := make(map[int64]models.EthTx)
+	for _, etx := range etxs {
+		byNonce[*etx.Nonce] = etx
+	}
+
+	for nonce := int64(ladder.MinedNonce); nonce < top; nonce++ {
+		rung := NonceRung{Nonce: nonce}
+		if etx, exists := byNonce[nonce]; exists {
+			rung.State = NonceRungState(etx.State)
+			rung.EthTxID = etx.ID
+		} else if nonce < int64(ladder.PendingNonce) {
+			rung.State = NonceRungExternal
+		} else {
+			rung.State = NonceRungGap
+		}
+		ladder.Rungs = append(ladder.Rungs, rung)
+	}
+	return ladder, nil
+}
+
+// FillNonceGaps inserts an empty transaction from the key for each of the
+// nonces, returning the eth_txes inserted. Their attempts are left in
+// progress, so that the EthConfirmer sends them on the next head and bumps
+// their gas like that of any other transaction. Nonces that are used by an
+// eth_tx by the time they are filled are skipped.
+func FillNonceGaps(ctx context.Context, store *store.Store, config orm.ConfigReader, address common.Address, nonces []int64) (etxs []models.EthTx, err error) {
+	account, err := store.KeyStore.GetAccountByAddress(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "FillNonceGaps could not get account from keystore")
+	}
+	gasLimit := config.EthGasLimitDefault()
+	gasPrice := config.EthGasPriceDefault()
+
+	now := time.Now()
+	err = postgres.GormTransaction(ctx, store.DB, func(dbtx *gorm.DB) error {
+		nextNonce, err := GetNextNonce(dbtx, address)
+		if err != nil {
+			return err
+		}
+		for _, nonce := range nonces {
+			if nonce >= nextNonce {
+				return errors.Errorf("nonce %d has not been used yet, the next nonce of %s is %d", nonce, address.Hex(), nextNonce)
+			}
+			var used bool
+			if err := dbtx.Raw(`SELECT EXISTS (SELECT 1 FROM eth_txes WHERE from_address = ? AND nonce = ?)`, address, nonce).Scan(&used).Error; err != nil {
+				return errors.Wrap(err, "FillNonceGaps failed to check for an eth_tx")
+			}
+			if used {
+				continue
+			}
+
+			tx, err := makeEmptyTransaction(store.KeyStore, uint64(nonce), gasLimit, gasPrice, account, config.ChainID())
+			if err != nil {
+				return errors.Wrap(err, "FillNonceGaps failed to makeEmptyTransaction")
+			}
+			rlp := new(bytes.Buffer)
+			if err := tx.EncodeRLP(rlp); err != nil {
+				return err
+			}
+			nonce := nonce
+			etx := models.EthTx{
+				Nonce:          &nonce,
+				FromAddress:    address,
+				ToAddress:      *tx.To(),
+				EncodedPayload: tx.Data(),
+				Value:          assets.Eth(*tx.Value()),
+				GasLimit:       tx.Gas(),
+				BroadcastAt:    &now,
+				State:          models.EthTxUnconfirmed,
+			}
+			if err := dbtx.Create(&etx).Error; err != nil {
+				return errors.Wrap(err, "FillNonceGaps failed to create eth_tx")
+			}
+			attempt := models.EthTxAttempt{
+				EthTxID:     etx.ID,
+				GasPrice:    utils.Big(*gasPrice),
+				SignedRawTx: rlp.Bytes(),
+				Hash:        tx.Hash(),
+				State:       models.EthTxAttemptInProgress,
+			}
+			if err := dbtx.Create(&attempt).Error; err != nil {
+				return errors.Wrap(err, "FillNonceGaps failed to create eth_tx_attempt")
+			}
+			etxs = append(etxs, etx)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return etxs, nil
+}
+
+// NonceGapDetector periodically checks the nonce ladders of the sending keys
+// for gaps. A gap wedges the key: every transaction sent after it is stuck in
+// the mempool until something uses its nonce. Gaps are left behind by
+// transactions that the node didn't send, e.g. when an external wallet's
+// transaction is dropped after the NonceSyncer fast forwarded past it.
+//
+// Gaps are logged, and if ETH_NONCE_GAP_AUTO_FILL is set, those found by two
+// consecutive checks are filled with empty transactions. Otherwise the
+// operator fills them through the API after reviewing the key's ladder.
+type NonceGapDetector struct {
+	utils.StartStopOnce
+
+	store     *store.Store
+	config    orm.ConfigReader
+	ethClient eth.Client
+	chStop    chan struct{}
+	chDone    chan struct{}
+
+	mu       sync.Mutex
+	lastGaps map[common.Address]map[int64]struct{}
+}
+
+// NewNonceGapDetector returns a service that checks for nonce gaps every
+// ETH_NONCE_GAP_CHECK_INTERVAL. An interval of 0 disables it.
+func NewNonceGapDetector(store *store.Store, config orm.ConfigReader, ethClient eth.Client) *NonceGapDetector {
+	return &NonceGapDetector{
+		store:     store,
+		config:    config,
+		ethClient: ethClient,
+		chStop:    make(chan struct{}),
+		chDone:    make(chan struct{}),
+		lastGaps:  make(map[common.Address]map[int64]struct{}),
+	}
+}
+
+func (d *NonceGapDetector) Start() error {
+	if !d.OkayToStart() {
+		return errors.New("NonceGapDetector has already been started")
+	}
+	if d.config.EthNonceGapCheckInterval() <= 0 {
+		close(d.chDone)
+		return nil
+	}
+	go d.runLoop()
+	return nil
+}
+
+func (d *NonceGapDetector) Close() error {
+	if !d.OkayToStop() {
+		return errors.New("NonceGapDetector has already been stopped")
+	}
+	close(d.chStop)
+	<-d.chDone
+	return nil
+}
+
+func (d *NonceGapDetector) runLoop() {
+	defer close(d.chDone)
+
+	ticker := time.NewTicker(utils.WithJitter(d.config.EthNonceGapCheckInterval()))
+	defer ticker.Stop()
+
+	ctx, cancel := utils.CombinedContext(d.chStop)
+	defer cancel()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := d.Check(ctx); err != nil {
+				logger.Errorw("NonceGapDetector: failed to check for nonce gaps", "error", err)
+			}
+		case <-d.chStop:
+			return
+		}
+	}
+}
+
+// Check looks for gaps in the nonce ladder of each sending key, filling those
+// that were also found by the previous check if auto fill is enabled
+func (d *NonceGapDetector) Check(ctx context.Context) (merr error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	keys, err := d.store.SendKeys()
+	if err != nil {
+		return errors.Wrap(err, "NonceGapDetector failed to get keys")
+	}
+	for _, key := range keys {
+		address := key.Address.Address()
+		ladder, err := FindNonceLadder(ctx, d.store.DB, d.ethClient, address)
+		if err != nil {
+			merr = multierr.Combine(merr, err)
+			continue
+		}
+
+		lastGaps := d.lastGaps[address]
+		gaps := make(map[int64]struct{})
+		var persistent []int64
+		for _, nonce := range ladder.Gaps() {
+			gaps[nonce] = struct{}{}
+			if _, exists := lastGaps[nonce]; exists {
+				persistent = append(persistent, nonce)
+			}
+		}
+		d.lastGaps[address] = gaps
+		if len(gaps) == 0 {
+			continue
+		}
+
+		logger.Warnw("NonceGapDetector: found gaps in the nonces of a key, its transactions will be stuck until they are filled",
+			"address", address.Hex(), "gaps", ladder.Gaps(), "minedNonce", ladder.MinedNonce, "nextNonce", ladder.NextNonce)
+		if !d.config.EthNonceGapAutoFill() || len(persistent) == 0 {
+			continue
+		}
+		etxs, err := FillNonceGaps(ctx, d.store, d.config, address, persistent)
+		if err != nil {
+			merr = multierr.Combine(merr, errors.Wrapf(err, "NonceGapDetector failed to fill the nonce gaps of %s", address.Hex()))
+			continue
+		}
+		for _, etx := range etxs {
+			logger.Infow("NonceGapDetector: filled nonce gap with an empty transaction", "address", address.Hex(), "nonce", *etx.Nonce, "ethTxID", etx.ID)
+			delete(gaps, *etx.Nonce)
+		}
+	}
+	return merr
+}
@@ -0,0 +1,150 @@
+package bulletprooftxmanager_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockChainNonces(ethClient *mocks.Client, address common.Address, mined, pending uint64) {
+	ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_getTransactionCount", address, "latest").
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*hexutil.Uint64) = hexutil.Uint64(mined)
+		})
+	ethClient.On("PendingNonceAt", mock.Anything, address).Return(pending, nil)
+}
+
+func Test_FindNonceLadder(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	_, from := cltest.MustAddRandomKeyToKeystore(t, store, 6)
+	cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 2, 1, from)
+	etx3 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 3, from)
+	etx5 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 5, from)
+
+	t.Run("finds the nonces that no transaction uses", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		mockChainNonces(ethClient, from, 3, 4)
+
+		ladder, err := bulletprooftxmanager.FindNonceLadder(context.Background(), store.DB, ethClient, from)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(3), ladder.MinedNonce)
+		assert.Equal(t, uint64(4), ladder.PendingNonce)
+		assert.Equal(t, int64(6), ladder.NextNonce)
+		assert.Equal(t, []bulletprooftxmanager.NonceRung{
+			{Nonce: 3, State: bulletprooftxmanager.NonceRungState(models.EthTxUnconfirmed), EthTxID: etx3.ID},
+			{Nonce: 4, State: bulletprooftxmanager.NonceRungGap},
+			{Nonce: 5, State: bulletprooftxmanager.NonceRungState(models.EthTxUnconfirmed), EthTxID: etx5.ID},
+		}, ladder.Rungs)
+		assert.Equal(t, []int64{4}, ladder.Gaps())
+
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("treats nonces of pending transactions that the node didn't send as external", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		mockChainNonces(ethClient, from, 3, 7)
+
+		ladder, err := bulletprooftxmanager.FindNonceLadder(context.Background(), store.DB, ethClient, from)
+		require.NoError(t, err)
+
+		require.Len(t, ladder.Rungs, 4)
+		assert.Equal(t, bulletprooftxmanager.NonceRungExternal, ladder.Rungs[1].State)
+		assert.Equal(t, bulletprooftxmanager.NonceRungExternal, ladder.Rungs[3].State)
+		assert.Empty(t, ladder.Gaps())
+
+		ethClient.AssertExpectations(t)
+	})
+}
+
+func Test_FillNonceGaps(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	_, from := cltest.MustAddRandomKeyToKeystore(t, store, 6)
+	cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 5, from)
+
+	etxs, err := bulletprooftxmanager.FillNonceGaps(context.Background(), store, store.Config, from, []int64{4})
+	require.NoError(t, err)
+	require.Len(t, etxs, 1)
+
+	etx, err := store.FindEthTxWithAttempts(etxs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), *etx.Nonce)
+	assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+	assert.Equal(t, from, etx.ToAddress)
+	require.Len(t, etx.EthTxAttempts, 1)
+	assert.Equal(t, models.EthTxAttemptInProgress, etx.EthTxAttempts[0].State)
+	assert.Equal(t, store.Config.EthGasPriceDefault(), etx.EthTxAttempts[0].GasPrice.ToInt())
+
+	t.Run("skips nonces that are used by the time they are filled", func(t *testing.T) {
+		etxs, err := bulletprooftxmanager.FillNonceGaps(context.Background(), store, store.Config, from, []int64{4, 5})
+		require.NoError(t, err)
+		assert.Empty(t, etxs)
+		cltest.AssertCount(t, store, models.EthTx{}, 2)
+	})
+
+	t.Run("refuses nonces that haven't been used yet", func(t *testing.T) {
+		_, err := bulletprooftxmanager.FillNonceGaps(context.Background(), store, store.Config, from, []int64{6})
+		assert.EqualError(t, err, "nonce 6 has not been used yet, the next nonce of "+from.Hex()+" is 6")
+		cltest.AssertCount(t, store, models.EthTx{}, 2)
+	})
+}
+
+func Test_NonceGapDetector_Check(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only reports gaps unless auto fill is enabled", func(t *testing.T) {
+		store, cleanup := cltest.NewStore(t)
+		defer cleanup()
+
+		_, from := cltest.MustAddRandomKeyToKeystore(t, store, 2)
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 1, from)
+		ethClient := new(mocks.Client)
+		mockChainNonces(ethClient, from, 0, 0)
+
+		detector := bulletprooftxmanager.NewNonceGapDetector(store, store.Config, ethClient)
+		require.NoError(t, detector.Check(context.Background()))
+		require.NoError(t, detector.Check(context.Background()))
+
+		cltest.AssertCount(t, store, models.EthTx{}, 1)
+	})
+
+	t.Run("fills gaps found by consecutive checks if auto fill is enabled", func(t *testing.T) {
+		store, cleanup := cltest.NewStore(t)
+		defer cleanup()
+		store.Config.Set("ETH_NONCE_GAP_AUTO_FILL", true)
+
+		_, from := cltest.MustAddRandomKeyToKeystore(t, store, 2)
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 1, from)
+		ethClient := new(mocks.Client)
+		mockChainNonces(ethClient, from, 0, 0)
+
+		detector := bulletprooftxmanager.NewNonceGapDetector(store, store.Config, ethClient)
+		require.NoError(t, detector.Check(context.Background()))
+		cltest.AssertCount(t, store, models.EthTx{}, 1)
+
+		require.NoError(t, detector.Check(context.Background()))
+		cltest.AssertCount(t, store, models.EthTx{}, 2)
+
+		ladder, err := bulletprooftxmanager.FindNonceLadder(context.Background(), store.DB, ethClient, from)
+		require.NoError(t, err)
+		assert.Empty(t, ladder.Gaps())
+	})
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	stderr "errors"
 	"fmt"
+	"io/ioutil"
+	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"sync"
 	"syscall"
 
@@ -19,25 +23,35 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/gobuffalo/packr"
+	uuid "github.com/satori/go.uuid"
 	"github.com/smartcontractkit/chainlink/core/gracefulpanic"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/directrequest"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/log"
+	"github.com/smartcontractkit/chainlink/core/services/maintenance"
+	"github.com/smartcontractkit/chainlink/core/services/messagequeue"
+	"github.com/smartcontractkit/chainlink/core/services/notifier"
+	"github.com/smartcontractkit/chainlink/core/services/ocrrotation"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	"github.com/smartcontractkit/chainlink/core/services/oncomplete"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/services/rpcusage"
+	"github.com/smartcontractkit/chainlink/core/services/runexport"
+	"github.com/smartcontractkit/chainlink/core/services/shadow"
 	"github.com/smartcontractkit/chainlink/core/services/synchronization"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
-	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
 	"go.uber.org/multierr"
 	"gopkg.in/guregu/null.v4"
 )
@@ -49,6 +63,12 @@ type (
 		onConnect func()
 	}
 
+	// logBroadcasterService adapts the log broadcaster to job.Service, so
+	// that the job spawner can own it as a dependency
+	logBroadcasterService struct {
+		log.Broadcaster
+	}
+
 	StartCloser interface {
 		Start() error
 		Close() error
@@ -69,6 +89,10 @@ func (c *headTrackableCallback) Connect(*models.Head) error {
 func (c *headTrackableCallback) Disconnect()                                    {}
 func (c *headTrackableCallback) OnNewLongestChain(context.Context, models.Head) {}
 
+func (s logBroadcasterService) Close() error {
+	return s.Stop()
+}
+
 //go:generate mockery --name Application --output ../../internal/mocks/ --case=underscore
 
 // Application implements the common functions used in the core node.
@@ -77,13 +101,17 @@ type Application interface {
 	Stop() error
 	GetStore() *strpkg.Store
 	GetJobORM() job.ORM
+	GetJobValidators() *job.ValidatorRegistry
+	GetTransmitterRotator() ocrrotation.Rotator
+	GetMaintenance() *maintenance.Switch
 	GetExternalInitiatorManager() ExternalInitiatorManager
 	GetStatsPusher() synchronization.StatsPusher
 	WakeSessionReaper()
 	AddJob(job models.JobSpec) error
 	AddJobV2(ctx context.Context, job job.Job, name null.String) (int32, error)
 	ArchiveJob(models.JobID) error
-	DeleteJobV2(ctx context.Context, jobID int32) error
+	ArchiveJobV2(ctx context.Context, jobID int32, force bool) error
+	DeleteJobV2(ctx context.Context, jobID int32, force bool) error
 	RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
 	AddServiceAgreement(*models.ServiceAgreement) error
 	NewBox() packr.Box
@@ -107,7 +135,10 @@ type ChainlinkApplication struct {
 	EventBroadcaster         postgres.EventBroadcaster
 	JobORM                   job.ORM
 	jobSpawner               job.Spawner
+	transmitterRotator       ocrrotation.Rotator
 	pipelineRunner           pipeline.Runner
+	maintenance              *maintenance.Switch
+	jobValidators            *job.ValidatorRegistry
 	FluxMonitor              fluxmonitor.Service
 	Scheduler                *services.Scheduler
 	Store                    *strpkg.Store
@@ -120,6 +151,11 @@ type ChainlinkApplication struct {
 	explorerClient           synchronization.ExplorerClient
 	subservices              []StartCloser
 
+	// chStop is closed when the application stops, and wgDone waits for the
+	// routines started with Go to return
+	chStop chan struct{}
+	wgDone sync.WaitGroup
+
 	started     bool
 	startStopMu sync.Mutex
 }
@@ -142,12 +178,31 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 
 	explorerClient := synchronization.ExplorerClient(&synchronization.NoopExplorerClient{})
 	statsPusher := synchronization.StatsPusher(&synchronization.NoopStatsPusher{})
-	monitoringEndpoint := ocrtypes.MonitoringEndpoint(&telemetry.NoopAgent{})
+	monitoringEndpoints := telemetry.MonitoringEndpointGenerator(&telemetry.NoopAgent{})
 
 	if config.ExplorerURL() != nil {
 		explorerClient = synchronization.NewExplorerClient(config.ExplorerURL(), config.ExplorerAccessKey(), config.ExplorerSecret(), config.StatsPusherLogging())
 		statsPusher = synchronization.NewStatsPusher(store.DB, explorerClient)
-		monitoringEndpoint = telemetry.NewAgent(explorerClient)
+		agent, err := newTelemetryAgent(config, explorerClient, config.TelemetrySpoolDir())
+		if err != nil {
+			return nil, err
+		}
+		multiplexer := telemetry.NewMultiplexer(agent, func(ingressURL *url.URL) (telemetry.Ingress, error) {
+			client := synchronization.NewExplorerClient(ingressURL, config.ExplorerAccessKey(), config.ExplorerSecret(), config.StatsPusherLogging())
+			// Each ingress spools separately, so that telemetry is only ever
+			// replayed to the ingress it was meant for
+			spoolDir, err := utils.Sha256(ingressURL.String())
+			if err != nil {
+				return nil, err
+			}
+			agent, err := newTelemetryAgent(config, client, filepath.Join(config.TelemetrySpoolDir(), spoolDir))
+			if err != nil {
+				return nil, err
+			}
+			return telemetry.NewExplorerIngress(client, agent), nil
+		})
+		subservices = append(subservices, agent, multiplexer)
+		monitoringEndpoints = multiplexer
 	}
 
 	if store.Config.GasUpdaterEnabled() {
@@ -159,6 +214,12 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 		logger.Debugw("GasUpdater: dynamic gas updating is disabled", "ethGasPriceDefault", store.Config.EthGasPriceDefault())
 	}
 
+	if store.Config.EthExternalTxDetectionEnabled() {
+		externalTxDetector := bulletprooftxmanager.NewExternalTxDetector(store, config, ethClient)
+		subservices = append(subservices, externalTxDetector)
+		headTrackables = append(headTrackables, externalTxDetector)
+	}
+
 	if store.Config.DatabaseBackupMode() != orm.DatabaseBackupModeNone && store.Config.DatabaseBackupFrequency() > 0 {
 		logger.Infow("DatabaseBackup: periodic database backups are enabled", "frequency", store.Config.DatabaseBackupFrequency())
 
@@ -168,9 +229,39 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 		logger.Info("DatabaseBackup: periodic database backups are disabled")
 	}
 
+	if path := store.Config.NotifierConfigFile(); path != "" {
+		notifierConfig, err := notifier.LoadConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		subservices = append(subservices, notifier.New(store.Events, notifierConfig))
+	}
+
+	if store.Config.RunExportURL() != nil {
+		exporter, err := runexport.NewExporter(store.DB, advisoryLocker, store.Config)
+		if err != nil {
+			return nil, err
+		}
+		subservices = append(subservices, exporter)
+	}
+
+	subservices = append(subservices, oncomplete.NewDeliverer(store.Events, store.DB, store.Config))
+
+	maintenanceSwitch, err := maintenance.NewSwitch(store.DB)
+	if err != nil {
+		return nil, err
+	}
+
 	runExecutor := services.NewRunExecutor(store, statsPusher)
-	runQueue := services.NewRunQueue(runExecutor)
+	runQueue := services.NewMaintenanceRunQueue(services.NewRunQueue(runExecutor), maintenanceSwitch)
 	runManager := services.NewRunManager(runQueue, config, store.ORM, statsPusher, store.Clock)
+	// Runs of v1 jobs are held in the run queue during maintenance, and
+	// queued again when the node is resumed
+	maintenanceSwitch.OnResume(func() {
+		if err := runManager.ResumeAllInProgress(); err != nil {
+			logger.Errorw("Maintenance: could not resume runs held during maintenance", "error", err)
+		}
+	})
 	jobSubscriber := services.NewJobSubscriber(store, runManager)
 	promReporter := services.NewPromReporter(store.MustSQLDB())
 	logBroadcaster := log.NewBroadcaster(log.NewORM(store.DB), ethClient, store.Config)
@@ -178,6 +269,8 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 	fluxMonitor := fluxmonitor.New(store, runManager, logBroadcaster)
 	ethBroadcaster := bulletprooftxmanager.NewEthBroadcaster(store, config, eventBroadcaster)
 	ethConfirmer := bulletprooftxmanager.NewEthConfirmer(store, config)
+	nonceGapDetector := bulletprooftxmanager.NewNonceGapDetector(store, config, ethClient)
+	rpcUsageMonitor := rpcusage.NewMonitor(store.DB, store.Events, config)
 	headBroadcaster := services.NewHeadBroadcaster()
 	var balanceMonitor services.BalanceMonitor
 	if config.BalanceMonitorEnabled() {
@@ -187,10 +280,14 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 	}
 
 	var (
-		pipelineORM    = pipeline.NewORM(store.ORM.DB, store.Config, eventBroadcaster)
-		pipelineRunner = pipeline.NewRunner(pipelineORM, store.Config)
+		pipelineORM    = pipeline.NewORM(store.ORM.DB, store.Config, eventBroadcaster, store.Events)
+		chainContext   = pipeline.NewChainContext(config.ChainID())
+		pipelineRunner = maintenance.NewRunner(pipeline.NewRunner(maintenance.NewPipelineORM(pipelineORM, maintenanceSwitch), store.Config, pipeline.NewRequestSigner(store.KeyStore, config.BridgeSigningAddress()), chainContext, ethClient), maintenanceSwitch)
 		jobORM         = job.NewORM(store.ORM.DB, store.Config, pipelineORM, eventBroadcaster, advisoryLocker)
 	)
+	// The chain context lives as long as the application, so it is never
+	// unsubscribed
+	headBroadcaster.Subscribe(chainContext)
 
 	var (
 		delegates = map[job.Type]job.Delegate{
@@ -203,6 +300,13 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 		}
 	)
 
+	if config.Dev() || config.FeatureShadow() {
+		delegates[job.Shadow] = shadow.NewDelegate()
+	}
+	if config.Dev() || config.FeatureMessageQueue() {
+		delegates[job.MessageQueue] = messagequeue.NewDelegate(pipelineRunner)
+	}
+
 	if config.Dev() || config.FeatureFluxMonitorV2() {
 		delegates[job.FluxMonitor] = fluxmonitorv2.NewDelegate(
 			store,
@@ -218,14 +322,19 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 				MinContractPayment:         store.Config.MinimumContractPayment(),
 				EthGasLimit:                store.Config.EthGasLimitDefault(),
 				MaxUnconfirmedTransactions: store.Config.EthMaxUnconfirmedTransactions(),
+				GasPrices:                  store.Config,
+				GasCosts:                   eth.NewGasCostEstimator(ethClient, store.Config.ChainType()),
+				WarmupTimeout:              store.Config.JobPipelineWarmupTimeout(),
 			},
 		)
 	}
 
+	var concretePW *offchainreporting.SingletonPeerWrapper
+	var ocrPartitions *offchainreporting.PartitionManager
 	if (config.Dev() && config.P2PListenPort() > 0) || config.FeatureOffchainReporting() {
 		logger.Debug("Off-chain reporting enabled")
-		concretePW := offchainreporting.NewSingletonPeerWrapper(store.OCRKeyStore, config, store.DB)
-		subservices = append(subservices, concretePW)
+		concretePW = offchainreporting.NewSingletonPeerWrapper(store.OCRKeyStore, config, store.DB)
+		ocrPartitions = offchainreporting.NewPartitionManager(store.DB, config.OCRPartitionRetention())
 		delegates[job.OffchainReporting] = offchainreporting.NewDelegate(
 			store.DB,
 			jobORM,
@@ -235,13 +344,25 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 			ethClient,
 			logBroadcaster,
 			concretePW,
-			monitoringEndpoint,
+			monitoringEndpoints,
+			maintenanceSwitch,
 		)
 	} else {
 		logger.Debug("Off-chain reporting disabled")
 	}
-	jobSpawner := job.NewSpawner(jobORM, store.Config, delegates)
-	subservices = append(subservices, jobSpawner, pipelineRunner, ethBroadcaster, ethConfirmer, headBroadcaster)
+	jobSpawner := job.NewSpawner(maintenance.NewJobORM(jobORM, maintenanceSwitch), store.Config, delegates, store.Events)
+	// The job spawner owns the services that jobs depend upon, so that they
+	// are always started before (and closed after) the jobs themselves
+	jobSpawner.AddDependency(job.Dependency{Name: job.DependencyPipelineRunner, Service: pipelineRunner})
+	jobSpawner.AddDependency(job.Dependency{Name: job.DependencyLogBroadcaster, Service: logBroadcasterService{logBroadcaster}})
+	if concretePW != nil {
+		jobSpawner.AddDependency(job.Dependency{Name: job.DependencyPeerWrapper, Service: concretePW})
+		jobSpawner.AddDependency(job.Dependency{Name: job.DependencyOCRPartitions, Service: ocrPartitions})
+	}
+	transmitterRotator := ocrrotation.NewRotator(store, jobORM, jobSpawner)
+	ensWatcher := job.NewENSWatcher(store.DB, ethClient, jobORM, config.ENSResolveInterval())
+	bridgeChecker := services.NewBridgeChecker(store, jobORM, config.BridgeCheckInterval())
+	subservices = append(subservices, jobSpawner, transmitterRotator, ensWatcher, bridgeChecker, ethBroadcaster, ethConfirmer, nonceGapDetector, rpcUsageMonitor, headBroadcaster)
 
 	store.NotifyNewEthTx = ethBroadcaster
 
@@ -255,7 +376,10 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 		EventBroadcaster:         eventBroadcaster,
 		JobORM:                   jobORM,
 		jobSpawner:               jobSpawner,
+		transmitterRotator:       transmitterRotator,
 		pipelineRunner:           pipelineRunner,
+		maintenance:              maintenanceSwitch,
+		jobValidators:            provisioning.NewValidators(),
 		FluxMonitor:              fluxMonitor,
 		StatsPusher:              statsPusher,
 		RunManager:               runManager,
@@ -272,6 +396,7 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 		// NOTE: Can keep things clean by putting more things in subservices
 		// instead of manually start/closing
 		subservices: subservices,
+		chStop:      make(chan struct{}),
 	}
 
 	headTrackables = append(
@@ -295,6 +420,20 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 	return app, nil
 }
 
+// newTelemetryAgent returns an Agent that sends telemetry with explorerClient,
+// spooling it in spoolDir while the explorer is unreachable unless the spool
+// is disabled
+func newTelemetryAgent(config *orm.Config, explorerClient synchronization.ExplorerClient, spoolDir string) (*telemetry.Agent, error) {
+	if config.TelemetrySpoolMaxSize() == 0 {
+		return telemetry.NewAgent(explorerClient), nil
+	}
+	spool, err := telemetry.NewSpool(spoolDir, config.TelemetrySpoolMaxSize())
+	if err != nil {
+		return nil, err
+	}
+	return telemetry.NewSpoolingAgent(explorerClient, spool, config.TelemetrySpoolReplayInterval()), nil
+}
+
 func setupConfig(config *orm.Config, store *strpkg.Store) {
 	config.SetRuntimeStore(store.ORM)
 
@@ -314,6 +453,34 @@ func setupConfig(config *orm.Config, store *strpkg.Store) {
 			}
 		}
 	}
+
+	if config.NodeID() == "" {
+		nodeID, err := loadOrCreateNodeID(config.RootDir())
+		if err != nil {
+			logger.Warnw("Failed to load node ID", "err", err)
+		} else {
+			logger.Debugw("NODE_ID was not set, using persisted node ID", "nodeID", nodeID)
+			config.Set("NODE_ID", nodeID)
+		}
+	}
+}
+
+// loadOrCreateNodeID returns the node ID persisted in the root directory,
+// generating and persisting a new one if none exists yet.
+func loadOrCreateNodeID(rootDir string) (string, error) {
+	path := filepath.Join(rootDir, "node_id")
+	if utils.FileExists(path) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	nodeID := uuid.NewV4().String()
+	if err := utils.EnsureDirAndMaxPerms(rootDir, os.FileMode(0700)); err != nil {
+		return "", err
+	}
+	return nodeID, utils.WriteFileWithMaxPerms(path, []byte(nodeID), os.FileMode(0600))
 }
 
 // Start all necessary services. If successful, nil will be returned.  Also
@@ -348,7 +515,6 @@ func (app *ChainlinkApplication) Start() error {
 		app.StatsPusher.Start,
 		app.RunQueue.Start,
 		app.RunManager.ResumeAllInProgress,
-		app.LogBroadcaster.Start,
 		app.EventBroadcaster.Start,
 		app.FluxMonitor.Start,
 	}
@@ -380,10 +546,25 @@ func (app *ChainlinkApplication) Start() error {
 		return err
 	}
 
+	if dir := app.Store.Config.ProvisioningDir(); dir != "" {
+		reconciler := provisioning.NewReconciler(app.Store, app.JobORM, app.jobSpawner, app.jobValidators)
+		reconciler.Reconcile(context.TODO(), dir, app.Store.Config.ProvisioningPrune()).Log()
+	}
+
 	app.started = true
 	return nil
 }
 
+// Go runs fn in a goroutine until the application stops. fn must return once
+// chStop is closed, and Stop waits for it to.
+func (app *ChainlinkApplication) Go(fn func(chStop <-chan struct{})) {
+	app.wgDone.Add(1)
+	go func() {
+		defer app.wgDone.Done()
+		fn(app.chStop)
+	}()
+}
+
 func (app *ChainlinkApplication) StopIfStarted() error {
 	app.startStopMu.Lock()
 	defer app.startStopMu.Unlock()
@@ -418,6 +599,10 @@ func (app *ChainlinkApplication) stop() error {
 		}()
 		logger.Info("Gracefully exiting...")
 
+		logger.Debug("Stopping background routines...")
+		close(app.chStop)
+		app.wgDone.Wait()
+
 		// Stop services in the reverse order from which they were started
 
 		logger.Debug("Stopping Scheduler...")
@@ -426,6 +611,11 @@ func (app *ChainlinkApplication) stop() error {
 		logger.Debug("Stopping HeadTracker...")
 		merr = multierr.Append(merr, app.HeadTracker.Stop())
 
+		// Release job claims first so that other nodes sharing the database
+		// can pick up our jobs as soon as possible
+		logger.Debug("Draining job spawner...")
+		merr = multierr.Append(merr, app.jobSpawner.Drain(context.Background()))
+
 		for i := len(app.subservices) - 1; i >= 0; i-- {
 			service := app.subservices[i]
 			logger.Debugw(fmt.Sprintf("Closing service %v...", i), "serviceType", reflect.TypeOf(service))
@@ -442,8 +632,6 @@ func (app *ChainlinkApplication) stop() error {
 		app.FluxMonitor.Stop()
 		logger.Debug("Stopping EventBroadcaster...")
 		merr = multierr.Append(merr, app.EventBroadcaster.Stop())
-		logger.Debug("Stopping LogBroadcaster...")
-		merr = multierr.Append(merr, app.LogBroadcaster.Stop())
 		logger.Debug("Stopping RunQueue...")
 		app.RunQueue.Stop()
 		logger.Debug("Stopping StatsPusher...")
@@ -471,6 +659,20 @@ func (app *ChainlinkApplication) GetJobORM() job.ORM {
 	return app.JobORM
 }
 
+// GetJobValidators returns the validators that job specs are created with
+func (app *ChainlinkApplication) GetJobValidators() *job.ValidatorRegistry {
+	return app.jobValidators
+}
+
+func (app *ChainlinkApplication) GetTransmitterRotator() ocrrotation.Rotator {
+	return app.transmitterRotator
+}
+
+// GetMaintenance returns the switch that puts the node into maintenance mode
+func (app *ChainlinkApplication) GetMaintenance() *maintenance.Switch {
+	return app.maintenance
+}
+
 func (app *ChainlinkApplication) GetExternalInitiatorManager() ExternalInitiatorManager {
 	return app.ExternalInitiatorManager
 }
@@ -496,9 +698,18 @@ func (app *ChainlinkApplication) AddJob(job models.JobSpec) error {
 	app.Scheduler.AddJob(job)
 	logger.ErrorIf(app.FluxMonitor.AddJob(job))
 	logger.ErrorIf(app.JobSubscriber.AddJob(job, nil))
+	app.publishJobCreated(job)
 	return nil
 }
 
+func (app *ChainlinkApplication) publishJobCreated(job models.JobSpec) {
+	event := eventbus.JobCreated{JobID: job.ID.String(), Name: job.Name}
+	if len(job.Initiators) > 0 {
+		event.Type = job.Initiators[0].Type
+	}
+	app.Store.Events.Publish(event)
+}
+
 func (app *ChainlinkApplication) AddJobV2(ctx context.Context, job job.Job, name null.String) (int32, error) {
 	return app.jobSpawner.CreateJob(ctx, job, name)
 }
@@ -523,11 +734,24 @@ func (app *ChainlinkApplication) ArchiveJob(ID models.JobID) error {
 	if err = app.ExternalInitiatorManager.DeleteJob(app.Store.DB, ID); err != nil {
 		err = errors.Wrapf(err, "failed to delete job with id %s from external initiator", ID)
 	}
-	return multierr.Combine(err, app.Store.ArchiveJob(ID))
+	if archiveErr := app.Store.ArchiveJob(ID); archiveErr != nil {
+		return multierr.Combine(err, archiveErr)
+	}
+	app.Store.Events.Publish(eventbus.JobDeleted{JobID: ID.String(), Archived: true})
+	return err
+}
+
+// ArchiveJobV2 stops the job and hides it from listings. It is permanently
+// deleted along with its runs once JOB_ARCHIVE_RETENTION has passed. Its
+// in-flight runs are cancelled if force is set, and otherwise waited for.
+func (app *ChainlinkApplication) ArchiveJobV2(ctx context.Context, jobID int32, force bool) error {
+	return app.jobSpawner.ArchiveJob(ctx, jobID, force)
 }
 
-func (app *ChainlinkApplication) DeleteJobV2(ctx context.Context, jobID int32) error {
-	return app.jobSpawner.DeleteJob(ctx, jobID)
+// DeleteJobV2 stops the job and deletes it along with its runs. Its in-flight
+// runs are cancelled if force is set, and otherwise waited for.
+func (app *ChainlinkApplication) DeleteJobV2(ctx context.Context, jobID int32, force bool) error {
+	return app.jobSpawner.DeleteJob(ctx, jobID, force)
 }
 
 // AddServiceAgreement adds a Service Agreement which includes a job that needs
@@ -545,6 +769,7 @@ func (app *ChainlinkApplication) AddServiceAgreement(sa *models.ServiceAgreement
 	// https://www.pivotaltracker.com/story/show/170349568
 	logger.ErrorIf(app.FluxMonitor.AddJob(sa.JobSpec))
 	logger.ErrorIf(app.JobSubscriber.AddJob(sa.JobSpec, nil))
+	app.publishJobCreated(sa.JobSpec)
 	return nil
 }
 
@@ -5,6 +5,7 @@ package chainlink_test
 import (
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
 	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tevino/abool"
 )
@@ -37,6 +39,27 @@ func TestChainlinkApplication_SignalShutdown(t *testing.T) {
 	}).Should(gomega.BeTrue())
 }
 
+func TestChainlinkApplication_Go(t *testing.T) {
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplication(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	stopped := abool.New()
+	app.Go(func(chStop <-chan struct{}) {
+		<-chStop
+		time.Sleep(100 * time.Millisecond)
+		stopped.Set()
+	})
+
+	// Stop waits for the routine to return
+	require.NoError(t, app.ChainlinkApplication.Stop())
+	assert.True(t, stopped.IsSet())
+}
+
 func TestChainlinkApplication_resumesPendingConnection_Happy(t *testing.T) {
 	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
 	defer assertMocksCalled()
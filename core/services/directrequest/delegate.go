@@ -1,11 +1,17 @@
 package directrequest
 
 import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
 	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated"
+	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/operator_wrapper"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/log"
@@ -31,6 +37,11 @@ func (d *Delegate) JobType() job.Type {
 	return job.DirectRequest
 }
 
+// Dependencies implements the job.DelegateWithDependencies interface
+func (d *Delegate) Dependencies() []string {
+	return []string{job.DependencyPipelineRunner, job.DependencyLogBroadcaster}
+}
+
 // ServicesForSpec returns the log listener service for a direct request job
 // TODO: This will need heavy test coverage
 func (d *Delegate) ServicesForSpec(spec job.Job) (services []job.Service, err error) {
@@ -39,13 +50,21 @@ func (d *Delegate) ServicesForSpec(spec job.Job) (services []job.Service, err er
 	}
 	concreteSpec := spec.DirectRequestSpec
 
-	logListener := listener{
-		d.logBroadcaster,
-		nil,
-		concreteSpec.ContractAddress.Address(),
-		d.pipelineRunner,
-		d.db,
-		spec.ID,
+	// The oracle is only used to decode its logs, so it needs no backend
+	oracle, err := operator_wrapper.NewOperator(concreteSpec.ContractAddress.Address(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create oracle contract")
+	}
+
+	logListener := &listener{
+		logBroadcaster:   d.logBroadcaster,
+		oracle:           oracle,
+		pipelineRunner:   d.pipelineRunner,
+		db:               d.db,
+		jobID:            spec.ID,
+		onChainJobSpecID: concreteSpec.OnChainJobSpecID,
+		chRequests:       make(chan oracleRequest, maxRequestBatchSize),
+		chStop:           make(chan struct{}),
 	}
 	services = append(services, logListener)
 
@@ -57,42 +76,70 @@ var (
 	_ job.Service  = &listener{}
 )
 
+const (
+	// requestBatchInterval is how long the listener waits for more oracle
+	// requests after the first, so that the requests of a block, which are
+	// broadcast together, have their runs created in one batch
+	requestBatchInterval = 100 * time.Millisecond
+	// maxRequestBatchSize is the most runs that are created in one batch
+	maxRequestBatchSize = 100
+)
+
 type listener struct {
-	logBroadcaster  log.Broadcaster
-	unsubscribeLogs func()
-	contractAddress gethCommon.Address
-	pipelineRunner  pipeline.Runner
-	db              *gorm.DB
-	jobID           int32
+	logBroadcaster   log.Broadcaster
+	unsubscribeLogs  func()
+	oracle           *operator_wrapper.Operator
+	pipelineRunner   pipeline.Runner
+	db               *gorm.DB
+	jobID            int32
+	onChainJobSpecID gethCommon.Hash
+
+	chRequests chan oracleRequest
+	chStop     chan struct{}
+	wg         sync.WaitGroup
+}
+
+// oracleRequest is an oracle request for the job, waiting for its run to be
+// created
+type oracleRequest struct {
+	lb      log.Broadcast
+	request *operator_wrapper.OperatorOracleRequest
 }
 
 // Start complies with job.Service
-func (d listener) Start() error {
+func (d *listener) Start() error {
 	connected, unsubscribe := d.logBroadcaster.Register(d, log.ListenerOpts{
-		Contract: nil,
-		Logs:     []generated.AbigenLog{},
+		Contract: d.oracle,
+		Logs: []generated.AbigenLog{
+			operator_wrapper.OperatorOracleRequest{},
+		},
 	})
 	if !connected {
 		return errors.New("Failed to register listener with logBroadcaster")
 	}
 	d.unsubscribeLogs = unsubscribe
+	d.wg.Add(1)
+	go d.run()
 	return nil
 }
 
-// Close complies with job.Service
-func (d listener) Close() error {
+// Close complies with job.Service. Requests whose runs haven't been created
+// yet are left unconsumed.
+func (d *listener) Close() error {
 	d.unsubscribeLogs()
+	close(d.chStop)
+	d.wg.Wait()
 	return nil
 }
 
 // OnConnect complies with log.Listener
-func (listener) OnConnect() {}
+func (*listener) OnConnect() {}
 
 // OnDisconnect complies with log.Listener
-func (listener) OnDisconnect() {}
+func (*listener) OnDisconnect() {}
 
-// OnConnect complies with log.Listener
-func (d listener) HandleLog(lb log.Broadcast) {
+// HandleLog complies with log.Listener
+func (d *listener) HandleLog(lb log.Broadcast) {
 	was, err := lb.WasAlreadyConsumed()
 	if err != nil {
 		logger.Errorw("DirectRequestListener: could not determine if log was already consumed", "error", err)
@@ -101,7 +148,15 @@ func (d listener) HandleLog(lb log.Broadcast) {
 		return
 	}
 
-	// TODO: Logic to handle log will go here
+	request, ok := lb.DecodedLog().(*operator_wrapper.OperatorOracleRequest)
+	if ok && request != nil && request.SpecId == d.onChainJobSpecID {
+		// The log is marked consumed once its run has been created
+		select {
+		case d.chRequests <- oracleRequest{lb, request}:
+		case <-d.chStop:
+		}
+		return
+	}
 
 	err = lb.MarkConsumed()
 	if err != nil {
@@ -109,17 +164,95 @@ func (d listener) HandleLog(lb log.Broadcast) {
 	}
 }
 
+// run creates the runs of the oracle requests handed to it by HandleLog, in
+// batches
+func (d *listener) run() {
+	defer d.wg.Done()
+	for {
+		var batch []oracleRequest
+		select {
+		case r := <-d.chRequests:
+			batch = append(batch, r)
+		case <-d.chStop:
+			return
+		}
+
+		timer := time.NewTimer(requestBatchInterval)
+	collect:
+		for len(batch) < maxRequestBatchSize {
+			select {
+			case r := <-d.chRequests:
+				batch = append(batch, r)
+			case <-timer.C:
+				break collect
+			case <-d.chStop:
+				timer.Stop()
+				return
+			}
+		}
+		timer.Stop()
+
+		d.handleOracleRequests(batch)
+	}
+}
+
+// handleOracleRequests creates a run of the job for each request, and marks
+// their logs consumed. The runs are keyed by their logs, so a request that is
+// delivered again, e.g. when its block is re-broadcast after a reorg, doesn't
+// run the job twice. If the runs can't be created the logs are left
+// unconsumed.
+func (d *listener) handleOracleRequests(batch []oracleRequest) {
+	requests := make([]pipeline.RunRequest, len(batch))
+	for i, r := range batch {
+		requests[i] = pipeline.RunRequest{
+			JobID:    d.jobID,
+			Meta:     oracleRequestMeta(r.request),
+			DedupKey: pipeline.LogDedupKey(d.jobID, r.request.Raw.BlockHash, r.request.Raw.Index),
+		}
+	}
+	runIDs, err := d.pipelineRunner.CreateRuns(context.Background(), requests)
+	if err != nil {
+		logger.Errorw("DirectRequestListener: could not create runs for oracle requests", "jobID", d.jobID, "count", len(batch), "error", err)
+		return
+	}
+
+	for i, r := range batch {
+		if runIDs[i] != 0 {
+			logger.Infow("DirectRequestListener: created run for oracle request", "jobID", d.jobID, "runID", runIDs[i], "requestID", fmt.Sprintf("0x%x", r.request.RequestId))
+		}
+		if err = r.lb.MarkConsumed(); err != nil {
+			logger.Errorf("Error marking log as consumed: %v", err)
+		}
+	}
+}
+
+func oracleRequestMeta(request *operator_wrapper.OperatorOracleRequest) map[string]interface{} {
+	return map[string]interface{}{
+		"oracleRequest": map[string]interface{}{
+			"specId":             fmt.Sprintf("0x%x", request.SpecId),
+			"requester":          request.Requester.Hex(),
+			"requestId":          fmt.Sprintf("0x%x", request.RequestId),
+			"payment":            request.Payment.String(),
+			"callbackAddr":       request.CallbackAddr.Hex(),
+			"callbackFunctionId": fmt.Sprintf("0x%x", request.CallbackFunctionId),
+			"cancelExpiration":   request.CancelExpiration.String(),
+			"dataVersion":        request.DataVersion.String(),
+			"data":               fmt.Sprintf("0x%x", request.Data),
+		},
+	}
+}
+
 // JobID complies with log.Listener
-func (listener) JobID() models.JobID {
+func (*listener) JobID() models.JobID {
 	return models.NilJobID
 }
 
 // Job complies with log.Listener
-func (d listener) JobIDV2() int32 {
+func (d *listener) JobIDV2() int32 {
 	return d.jobID
 }
 
 // IsV2Job complies with log.Listener
-func (listener) IsV2Job() bool {
+func (*listener) IsV2Job() bool {
 	return true
 }
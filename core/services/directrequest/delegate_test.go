@@ -0,0 +1,133 @@
+package directrequest_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/operator_wrapper"
+	"github.com/smartcontractkit/chainlink/core/services/directrequest"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/log"
+	logmocks "github.com/smartcontractkit/chainlink/core/services/log/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	pipelinemocks "github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func TestDelegate_HandleLog(t *testing.T) {
+	t.Parallel()
+
+	specID := common.HexToHash("0x1234")
+	jb := job.Job{
+		DirectRequestSpec: &job.DirectRequestSpec{
+			ContractAddress:  models.EIP55Address("0x613a38AC1659769640aaE063C651F48E0250454C"),
+			OnChainJobSpecID: specID,
+		},
+	}
+	jb.ID = 42
+
+	newRequest := func(specID common.Hash, logIndex uint) *operator_wrapper.OperatorOracleRequest {
+		return &operator_wrapper.OperatorOracleRequest{
+			SpecId:           specID,
+			RequestId:        common.HexToHash("0xabcd"),
+			Payment:          big.NewInt(100),
+			CancelExpiration: big.NewInt(0),
+			DataVersion:      big.NewInt(1),
+			Raw:              types.Log{BlockHash: common.HexToHash("0xb10c"), Index: logIndex},
+		}
+	}
+
+	// startListener starts the log listener of the job, with a runner that
+	// creates runs with createRuns
+	startListener := func(t *testing.T, runner *pipelinemocks.Runner) log.Listener {
+		lbr := new(logmocks.Broadcaster)
+		lbr.On("Register", mock.Anything, mock.Anything).Return(true, func() {})
+		services, err := directrequest.NewDelegate(lbr, runner, nil).ServicesForSpec(jb)
+		require.NoError(t, err)
+		require.NoError(t, services[0].Start())
+		t.Cleanup(func() { require.NoError(t, services[0].Close()) })
+		return services[0].(log.Listener)
+	}
+
+	t.Run("creates the runs of requests for the job in a batch, keyed by their logs", func(t *testing.T) {
+		runner := new(pipelinemocks.Runner)
+		listener := startListener(t, runner)
+
+		consumed := make(chan struct{}, 2)
+		var broadcasts []*logmocks.Broadcast
+		for i := uint(0); i < 2; i++ {
+			lb := new(logmocks.Broadcast)
+			lb.On("WasAlreadyConsumed").Return(false, nil)
+			lb.On("DecodedLog").Return(newRequest(specID, i))
+			lb.On("MarkConsumed").Return(nil).Run(func(mock.Arguments) { consumed <- struct{}{} })
+			broadcasts = append(broadcasts, lb)
+		}
+		runner.On("CreateRuns", mock.Anything, mock.MatchedBy(func(requests []pipeline.RunRequest) bool {
+			return len(requests) == 2 &&
+				requests[0].JobID == jb.ID &&
+				requests[0].DedupKey == pipeline.LogDedupKey(jb.ID, common.HexToHash("0xb10c"), 0) &&
+				requests[1].DedupKey == pipeline.LogDedupKey(jb.ID, common.HexToHash("0xb10c"), 1) &&
+				requests[0].Meta["oracleRequest"].(map[string]interface{})["payment"] == "100"
+		})).Return([]int64{1, 2}, nil).Once()
+
+		for _, lb := range broadcasts {
+			listener.HandleLog(lb)
+		}
+		for range broadcasts {
+			select {
+			case <-consumed:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the logs to be consumed")
+			}
+		}
+
+		runner.AssertExpectations(t)
+		for _, lb := range broadcasts {
+			lb.AssertExpectations(t)
+		}
+	})
+
+	t.Run("ignores requests for other jobs", func(t *testing.T) {
+		runner := new(pipelinemocks.Runner)
+		listener := startListener(t, runner)
+
+		lb := new(logmocks.Broadcast)
+		lb.On("WasAlreadyConsumed").Return(false, nil)
+		lb.On("DecodedLog").Return(newRequest(common.HexToHash("0x5678"), 0))
+		lb.On("MarkConsumed").Return(nil)
+
+		listener.HandleLog(lb)
+
+		runner.AssertNotCalled(t, "CreateRuns", mock.Anything, mock.Anything)
+		lb.AssertExpectations(t)
+	})
+
+	t.Run("leaves the logs unconsumed if the runs can't be created", func(t *testing.T) {
+		runner := new(pipelinemocks.Runner)
+		listener := startListener(t, runner)
+
+		lb := new(logmocks.Broadcast)
+		lb.On("WasAlreadyConsumed").Return(false, nil)
+		lb.On("DecodedLog").Return(newRequest(specID, 0))
+		created := make(chan struct{})
+		runner.On("CreateRuns", mock.Anything, mock.Anything).Return(nil, errors.New("db is down")).Once().
+			Run(func(mock.Arguments) { close(created) })
+
+		listener.HandleLog(lb)
+		select {
+		case <-created:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the runs to be created")
+		}
+
+		runner.AssertExpectations(t)
+		lb.AssertNotCalled(t, "MarkConsumed")
+	})
+}
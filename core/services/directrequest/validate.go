@@ -7,9 +7,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 )
 
-func ValidatedDirectRequestSpec(tomlString string) (job.Job, error) {
+func ValidatedDirectRequestSpec(config *orm.Config, tomlString string) (job.Job, error) {
 	var jb = job.Job{
 		Pipeline: *pipeline.NewTaskDAG(),
 	}
@@ -18,6 +19,11 @@ func ValidatedDirectRequestSpec(tomlString string) (job.Job, error) {
 	if err != nil {
 		return jb, err
 	}
+	if config.JobSpecStrictTOML() {
+		if err = job.CheckUnknownKeys(tree, job.DirectRequest); err != nil {
+			return jb, err
+		}
+	}
 	err = tree.Unmarshal(&jb)
 	if err != nil {
 		return jb, err
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,7 +23,7 @@ observationSource   = """
 """
 `
 
-	s, err := ValidatedDirectRequestSpec(toml)
+	s, err := ValidatedDirectRequestSpec(orm.NewConfig(), toml)
 	require.NoError(t, err)
 
 	sha := sha256.Sum256([]byte(toml))
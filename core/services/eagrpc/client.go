@@ -0,0 +1,177 @@
+package eagrpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// Target is where an external adapter serves the protocol
+type Target struct {
+	// Address is a gRPC dial target, such as "localhost:8081"
+	Address string
+	// TLS connects with TLS, verifying the adapter's certificate against
+	// CACert if it is set and the system roots otherwise
+	TLS    bool
+	CACert string
+}
+
+// conn is a connection to a target, with the number of calls in flight on it
+type conn struct {
+	*grpc.ClientConn
+	target   Target
+	inFlight int
+	// retired is set once no bridge uses the connection anymore, after
+	// which it is closed as soon as its last call finishes
+	retired bool
+}
+
+var (
+	// conns are the open connections to each target. A connection is
+	// shared by every call to its adapter, so that calls don't pay for
+	// connection setup.
+	conns = make(map[Target]*conn)
+	// bridgeTargets are the targets that each bridge was last called at. A
+	// connection is closed once no bridge uses its target anymore and no
+	// calls are in flight on it.
+	bridgeTargets = make(map[string]Target)
+	connsMu       sync.Mutex
+)
+
+// Conn returns the connection to target for the named bridge, dialing it if
+// there isn't one yet. The caller must call done once its call on the
+// connection has finished. If the bridge was called at another target before,
+// because it has since been updated, its previous connection is closed unless
+// another bridge still uses it, once the calls in flight on it have finished.
+// Dialing does not block, so an unreachable adapter fails the calls made to it
+// rather than this.
+func Conn(bridgeName string, target Target) (_ *grpc.ClientConn, done func(), _ error) {
+	connsMu.Lock()
+	defer connsMu.Unlock()
+	if previous, exists := bridgeTargets[bridgeName]; exists && previous != target {
+		delete(bridgeTargets, bridgeName)
+		release(previous)
+	}
+	c, exists := conns[target]
+	if !exists {
+		var opts []grpc.DialOption
+		if target.TLS {
+			config := &tls.Config{MinVersion: tls.VersionTLS12}
+			if target.CACert != "" {
+				config.RootCAs = x509.NewCertPool()
+				if !config.RootCAs.AppendCertsFromPEM([]byte(target.CACert)) {
+					return nil, nil, errors.New("could not parse the CA certificate of the adapter")
+				}
+			}
+			opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+		} else {
+			opts = append(opts, grpc.WithInsecure())
+		}
+		clientConn, err := grpc.Dial(target.Address, opts...)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not dial external adapter at %s", target.Address)
+		}
+		c = &conn{ClientConn: clientConn, target: target}
+		conns[target] = c
+	}
+	bridgeTargets[bridgeName] = target
+	c.inFlight++
+
+	var once sync.Once
+	return c.ClientConn, func() {
+		once.Do(func() {
+			connsMu.Lock()
+			defer connsMu.Unlock()
+			c.inFlight--
+			if c.retired && c.inFlight == 0 {
+				c.close()
+			}
+		})
+	}, nil
+}
+
+// Evict forgets the named bridge, closing its connection unless another
+// bridge still uses it. It is called when a bridge is deleted.
+func Evict(bridgeName string) {
+	connsMu.Lock()
+	defer connsMu.Unlock()
+	target, exists := bridgeTargets[bridgeName]
+	if !exists {
+		return
+	}
+	delete(bridgeTargets, bridgeName)
+	release(target)
+}
+
+// release retires the connection to target if no bridge uses it anymore,
+// closing it now if no calls are in flight on it. It must be called with
+// connsMu held.
+func release(target Target) {
+	for _, t := range bridgeTargets {
+		if t == target {
+			return
+		}
+	}
+	c, exists := conns[target]
+	if !exists {
+		return
+	}
+	delete(conns, target)
+	c.retired = true
+	if c.inFlight == 0 {
+		c.close()
+	}
+}
+
+func (c *conn) close() {
+	if err := c.Close(); err != nil {
+		logger.Warnw("Failed to close connection to external adapter", "address", c.target.Address, "error", err)
+	}
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, AuthorizationMetadata, "Bearer "+token)
+}
+
+// Run calls the adapter on conn, returning its response
+func Run(ctx context.Context, conn *grpc.ClientConn, token string, req *Request) (*Response, error) {
+	return NewExternalAdapterClient(conn).Run(withToken(ctx, token), req)
+}
+
+// RunStream calls the adapter on conn and reads its responses until the end
+// of the stream, returning the last one
+func RunStream(ctx context.Context, conn *grpc.ClientConn, token string, req *Request) (*Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := NewExternalAdapterClient(conn).RunStream(withToken(ctx, token), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *Response
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		last = resp
+	}
+	if last == nil {
+		return nil, errors.New("external adapter closed the stream without responding")
+	}
+	return last, nil
+}
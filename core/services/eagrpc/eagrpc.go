@@ -0,0 +1,75 @@
+// Package eagrpc is the gRPC protocol between the node and external adapters,
+// an alternative to HTTP bridges for adapters that are colocated with the
+// node. Connections are kept open between calls, so each call saves the
+// connection setup and HTTP/1.1 framing of a bridge request.
+//
+// The protocol is the chainlink.ExternalAdapter service of eagrpc.proto, from
+// which eagrpc.pb.go is generated. Adapters implement its two methods: Run
+// returns a single response, and RunStream streams responses, of which the
+// last is the answer. The bridge's outgoing token is sent as bearer
+// authorization metadata.
+package eagrpc
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. eagrpc.proto
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// AuthorizationMetadata is the metadata key of the bridge's outgoing token,
+// sent as "Bearer <token>"
+const AuthorizationMetadata = "authorization"
+
+// NewRequest returns a request with the given values, which are sent as they
+// would be encoded as JSON
+func NewRequest(id string, data, meta map[string]interface{}, inputs []interface{}) (*Request, error) {
+	req := &Request{Id: id, Data: new(structpb.Struct), Meta: new(structpb.Struct)}
+	if err := unmarshalJSON(data, req.Data); err != nil {
+		return nil, errors.Wrap(err, "could not encode request data")
+	}
+	if err := unmarshalJSON(meta, req.Meta); err != nil {
+		return nil, errors.Wrap(err, "could not encode request meta")
+	}
+	for _, input := range inputs {
+		value := new(structpb.Value)
+		if err := unmarshalJSON(input, value); err != nil {
+			return nil, errors.Wrap(err, "could not encode request input")
+		}
+		req.Inputs = append(req.Inputs, value)
+	}
+	return req, nil
+}
+
+// unmarshalJSON sets msg to the JSON encoding of v. A nil map is encoded as
+// an empty struct.
+func unmarshalJSON(v interface{}, msg proto.Message) error {
+	if m, ok := v.(map[string]interface{}); ok && m == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(b, msg)
+}
+
+// BearerToken returns the token sent with a call to an adapter, for adapters
+// that check the bridge's outgoing token
+func BearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(AuthorizationMetadata)
+	if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+		return "", status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	return strings.TrimPrefix(values[0], "Bearer "), nil
+}
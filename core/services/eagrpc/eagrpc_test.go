@@ -0,0 +1,122 @@
+package eagrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/smartcontractkit/chainlink/core/services/eagrpc"
+)
+
+// echoAdapter answers with its request's first input, and streams a
+// response for each input
+type echoAdapter struct {
+	tokens chan string
+}
+
+func (a echoAdapter) Run(ctx context.Context, req *eagrpc.Request) (*eagrpc.Response, error) {
+	token, err := eagrpc.BearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.tokens <- token
+	return &eagrpc.Response{Result: req.Inputs[0], Data: structpb.NewStructValue(req.Meta)}, nil
+}
+
+func (a echoAdapter) RunStream(req *eagrpc.Request, stream eagrpc.ExternalAdapter_RunStreamServer) error {
+	for _, input := range req.Inputs {
+		if err := stream.Send(&eagrpc.Response{Result: input}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAdapter(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	adapter := echoAdapter{tokens: make(chan string, 1)}
+	eagrpc.RegisterExternalAdapterServer(server, adapter)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	target := eagrpc.Target{Address: lis.Addr().String()}
+	conn, done, err := eagrpc.Conn("echo", target)
+	require.NoError(t, err)
+	defer done()
+	again, doneAgain, err := eagrpc.Conn("echo", target)
+	require.NoError(t, err)
+	doneAgain()
+	assert.True(t, conn == again, "expected the connection to be reused")
+
+	ctx := context.Background()
+	req, err := eagrpc.NewRequest("ds1",
+		map[string]interface{}{"from": "ETH"},
+		map[string]interface{}{"jobID": "1"},
+		[]interface{}{"1.5", "2.5", "3.5"},
+	)
+	require.NoError(t, err)
+
+	t.Run("Run", func(t *testing.T) {
+		resp, err := eagrpc.Run(ctx, conn, "outgoing", req)
+		require.NoError(t, err)
+		assert.Equal(t, "1.5", resp.Result.AsInterface())
+		assert.Equal(t, map[string]interface{}{"jobID": "1"}, resp.Data.AsInterface())
+		assert.Equal(t, "outgoing", <-adapter.tokens)
+	})
+
+	t.Run("Run without a token", func(t *testing.T) {
+		_, err := eagrpc.Run(ctx, conn, "", req)
+		require.Error(t, err)
+	})
+
+	t.Run("RunStream returns the last response", func(t *testing.T) {
+		resp, err := eagrpc.RunStream(ctx, conn, "outgoing", req)
+		require.NoError(t, err)
+		assert.Equal(t, "3.5", resp.Result.AsInterface())
+	})
+
+	t.Run("RunStream without responses", func(t *testing.T) {
+		_, err := eagrpc.RunStream(ctx, conn, "outgoing", &eagrpc.Request{})
+		require.Error(t, err)
+	})
+}
+
+func TestConn_Eviction(t *testing.T) {
+	t.Parallel()
+
+	target := eagrpc.Target{Address: "127.0.0.1:1"}
+	conn, done, err := eagrpc.Conn("evicted", target)
+	require.NoError(t, err)
+	done()
+	shared, done, err := eagrpc.Conn("evicted-shared", target)
+	require.NoError(t, err)
+	done()
+	assert.True(t, conn == shared, "expected bridges at the same target to share a connection")
+
+	// Updating a bridge's target leaves the connection open while another
+	// bridge uses it
+	updated, doneUpdated, err := eagrpc.Conn("evicted", eagrpc.Target{Address: "127.0.0.1:1", TLS: true})
+	require.NoError(t, err)
+	assert.False(t, conn == updated, "expected a new connection for the updated target")
+	assert.NotEqual(t, connectivity.Shutdown, conn.GetState())
+
+	eagrpc.Evict("evicted-shared")
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+
+	// A call in flight keeps its connection open until it finishes
+	eagrpc.Evict("evicted")
+	assert.NotEqual(t, connectivity.Shutdown, updated.GetState())
+	doneUpdated()
+	assert.Equal(t, connectivity.Shutdown, updated.GetState())
+	doneUpdated()
+}
@@ -0,0 +1,61 @@
+package eth
+
+import "math/big"
+
+// ChainType identifies chains whose gas is priced differently from Ethereum
+type ChainType string
+
+const (
+	// ChainTypeL1 is Ethereum and chains that price gas like it
+	ChainTypeL1 ChainType = ""
+	// ChainTypeOptimism is an Optimism rollup, which also charges every
+	// transaction a fee for publishing its data on L1
+	ChainTypeOptimism ChainType = "optimism"
+	// ChainTypeArbitrum is an Arbitrum rollup, which charges ArbGas for
+	// publishing the data of each transaction on L1 on top of its execution
+	ChainTypeArbitrum ChainType = "arbitrum"
+)
+
+// ChainProfile describes a known chain that needs different gas handling or
+// config defaults from Ethereum mainnet
+type ChainProfile struct {
+	Name string
+	Type ChainType
+	// ConfigDefaults replace the defaults of config values on this chain,
+	// keyed by their environment variable. Values that are set explicitly
+	// still take precedence.
+	ConfigDefaults map[string]string
+}
+
+var (
+	// On rollups the gas price is set by the sequencer rather than an
+	// auction, so the gas updater's block history and gas bumping only make
+	// things worse
+	optimismConfigDefaults = map[string]string{
+		"GAS_UPDATER_ENABLED":    "false",
+		"ETH_GAS_BUMP_THRESHOLD": "0",
+		"ETH_GAS_PRICE_DEFAULT":  "15000000", // 0.015 gwei
+	}
+	arbitrumConfigDefaults = map[string]string{
+		"GAS_UPDATER_ENABLED":    "false",
+		"ETH_GAS_BUMP_THRESHOLD": "0",
+		"ETH_GAS_PRICE_DEFAULT":  "1000000000", // 1 gwei
+	}
+
+	chainProfiles = map[int64]ChainProfile{
+		10:     {Name: "Optimism", Type: ChainTypeOptimism, ConfigDefaults: optimismConfigDefaults},
+		69:     {Name: "Optimism Kovan", Type: ChainTypeOptimism, ConfigDefaults: optimismConfigDefaults},
+		42161:  {Name: "Arbitrum One", Type: ChainTypeArbitrum, ConfigDefaults: arbitrumConfigDefaults},
+		421611: {Name: "Arbitrum Rinkeby", Type: ChainTypeArbitrum, ConfigDefaults: arbitrumConfigDefaults},
+	}
+)
+
+// ChainProfileFor returns the profile of the chain with the given ID, if it
+// is a known chain that has one
+func ChainProfileFor(chainID *big.Int) (ChainProfile, bool) {
+	if chainID == nil || !chainID.IsInt64() {
+		return ChainProfile{}, false
+	}
+	profile, exists := chainProfiles[chainID.Int64()]
+	return profile, exists
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -92,10 +93,12 @@ type client struct {
 	GethClient
 	RPCClient
 	url                  string // For reestablishing the connection after a disconnect
+	endpoint             string // The host of url, which RPC calls are counted against
 	SecondaryGethClients []GethClient
 	SecondaryRPCClients  []RPCClient
 	secondaryURLs        []url.URL
 	mocked               bool
+	cache                *readCache
 
 	roundRobinCount uint32
 }
@@ -116,7 +119,7 @@ func NewClient(rpcUrl string, secondaryRPCURLs ...url.URL) (*client, error) {
 			return nil, errors.Errorf("secondary ethereum rpc url scheme must be http(s): %s", url.String())
 		}
 	}
-	return &client{url: rpcUrl, secondaryURLs: secondaryRPCURLs}, nil
+	return &client{url: rpcUrl, endpoint: parsed.Host, secondaryURLs: secondaryRPCURLs}, nil
 }
 
 // This alternate constructor exists for testing purposes.
@@ -128,6 +131,16 @@ func NewClientWith(rpcClient RPCClient, gethClient GethClient) *client {
 	}
 }
 
+// EnableReadCache caches the results of the idempotent reads made while the
+// chain's head is a given block for up to ttl, or until the next head
+// arrives. A ttl of 0 leaves the cache disabled. It must be called before
+// Dial.
+func (client *client) EnableReadCache(ttl time.Duration) {
+	if ttl > 0 {
+		client.cache = newReadCache(ttl)
+	}
+}
+
 func (client *client) Dial(ctx context.Context) error {
 	logger.Debugw("eth.Client#Dial(...)")
 	if client.mocked {
@@ -178,6 +191,7 @@ func (client *client) GetERC20Balance(address common.Address, contractAddress co
 		To:   contractAddress,
 		Data: data,
 	}
+	recordRPCCall(client.endpoint, "eth_call")
 	err := client.RPCClient.Call(&result, "eth_call", args, "latest")
 	if err != nil {
 		return numLinkBigInt, err
@@ -209,6 +223,7 @@ func (client *client) SendRawTx(bytes []byte) (common.Hash, error) {
 		"bytes", bytes,
 	)
 	result := common.Hash{}
+	recordRPCCall(client.endpoint, "eth_sendRawTransaction")
 	err := client.RPCClient.Call(&result, "eth_sendRawTransaction", hexutil.Encode(bytes))
 	return result, err
 }
@@ -219,6 +234,7 @@ func (client *client) TransactionReceipt(ctx context.Context, txHash common.Hash
 	logger.Debugw("eth.Client#TransactionReceipt(...)",
 		"txHash", txHash,
 	)
+	recordRPCCall(client.endpoint, "eth_getTransactionReceipt")
 	receipt, err := client.GethClient.TransactionReceipt(ctx, txHash)
 	if err != nil && strings.Contains(err.Error(), "missing required field") {
 		return nil, ethereum.NotFound
@@ -228,7 +244,16 @@ func (client *client) TransactionReceipt(ctx context.Context, txHash common.Hash
 
 func (client *client) ChainID(ctx context.Context) (*big.Int, error) {
 	logger.Debugw("eth.Client#ChainID(...)")
-	return client.GethClient.ChainID(ctx)
+	if cached, ok := client.cache.get("chainID"); ok {
+		return new(big.Int).Set(cached.(*big.Int)), nil
+	}
+	head := client.cache.head()
+	recordRPCCall(client.endpoint, "eth_chainId")
+	chainID, err := client.GethClient.ChainID(ctx)
+	if err == nil {
+		client.cache.set("chainID", new(big.Int).Set(chainID), head)
+	}
+	return chainID, err
 }
 
 // SendTransaction also uses the secondary HTTP RPC URL if set
@@ -237,9 +262,10 @@ func (client *client) SendTransaction(ctx context.Context, tx *types.Transaction
 		"tx", tx,
 	)
 
-	for _, gethClient := range client.SecondaryGethClients {
+	for i, gethClient := range client.SecondaryGethClients {
 		// Parallel send to secondary node
 		logger.Tracew("eth.SecondaryClient#SendTransaction(...)", "tx", tx)
+		recordRPCCall(client.secondaryURLs[i].Host, "eth_sendRawTransaction")
 
 		var wg sync.WaitGroup
 		defer wg.Wait()
@@ -256,6 +282,7 @@ func (client *client) SendTransaction(ctx context.Context, tx *types.Transaction
 		}(gethClient)
 	}
 
+	recordRPCCall(client.endpoint, "eth_sendRawTransaction")
 	return client.GethClient.SendTransaction(ctx, tx)
 }
 
@@ -263,6 +290,7 @@ func (client *client) PendingNonceAt(ctx context.Context, account common.Address
 	logger.Debugw("eth.Client#PendingNonceAt(...)",
 		"account", account,
 	)
+	recordRPCCall(client.endpoint, "eth_getTransactionCount")
 	return client.GethClient.PendingNonceAt(ctx, account)
 }
 
@@ -270,6 +298,7 @@ func (client *client) PendingCodeAt(ctx context.Context, account common.Address)
 	logger.Debugw("eth.Client#PendingCodeAt(...)",
 		"account", account,
 	)
+	recordRPCCall(client.endpoint, "eth_getCode")
 	return client.GethClient.PendingCodeAt(ctx, account)
 }
 
@@ -277,11 +306,13 @@ func (client *client) EstimateGas(ctx context.Context, call ethereum.CallMsg) (g
 	logger.Debugw("eth.Client#EstimateGas(...)",
 		"call", call,
 	)
+	recordRPCCall(client.endpoint, "eth_estimateGas")
 	return client.GethClient.EstimateGas(ctx, call)
 }
 
 func (client *client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	logger.Debugw("eth.Client#SuggestGasPrice()")
+	recordRPCCall(client.endpoint, "eth_gasPrice")
 	return client.GethClient.SuggestGasPrice(ctx)
 }
 
@@ -289,18 +320,39 @@ func (client *client) BlockByNumber(ctx context.Context, number *big.Int) (*type
 	logger.Debugw("eth.Client#BlockByNumber(...)",
 		"number", number,
 	)
-	return client.GethClient.BlockByNumber(ctx, number)
+	key := "block:" + toBlockNumArg(number)
+	if cached, ok := client.cache.get(key); ok {
+		return cached.(*types.Block), nil
+	}
+	cacheHead := client.cache.head()
+	recordRPCCall(client.endpoint, "eth_getBlockByNumber")
+	block, err := client.GethClient.BlockByNumber(ctx, number)
+	if err == nil {
+		// Blocks are immutable, so they can be shared
+		client.cache.set(key, block, cacheHead)
+	}
+	return block, err
 }
 
 func (client *client) HeaderByNumber(ctx context.Context, number *big.Int) (*models.Head, error) {
 	logger.Debugw("eth.Client#HeaderByNumber(...)",
 		"number", number,
 	)
+	key := "header:" + toBlockNumArg(number)
+	if cached, ok := client.cache.get(key); ok {
+		head := cached.(models.Head)
+		return &head, nil
+	}
 	var head *models.Head
+	cacheHead := client.cache.head()
+	recordRPCCall(client.endpoint, "eth_getBlockByNumber")
 	err := client.RPCClient.CallContext(ctx, &head, "eth_getBlockByNumber", toBlockNumArg(number), false)
 	if err == nil && head == nil {
 		err = ethereum.NotFound
 	}
+	if err == nil {
+		client.cache.set(key, *head, cacheHead)
+	}
 	return head, err
 }
 
@@ -321,6 +373,7 @@ func (client *client) BalanceAt(ctx context.Context, account common.Address, blo
 		"account", account,
 		"blockNumber", blockNumber,
 	)
+	recordRPCCall(client.endpoint, "eth_getBalance")
 	return client.GethClient.BalanceAt(ctx, account, blockNumber)
 }
 
@@ -328,19 +381,59 @@ func (client *client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([
 	logger.Debugw("eth.Client#FilterLogs(...)",
 		"q", q,
 	)
+	recordRPCCall(client.endpoint, "eth_getLogs")
 	return client.GethClient.FilterLogs(ctx, q)
 }
 
+func (client *client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	logger.Debugw("eth.Client#CallContract(...)",
+		"msg", msg,
+		"blockNumber", blockNumber,
+	)
+	recordRPCCall(client.endpoint, "eth_call")
+	return client.GethClient.CallContract(ctx, msg, blockNumber)
+}
+
+func (client *client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	logger.Debugw("eth.Client#CodeAt(...)",
+		"account", account,
+		"blockNumber", blockNumber,
+	)
+	key := "code:" + account.Hex() + ":" + toBlockNumArg(blockNumber)
+	if cached, ok := client.cache.get(key); ok {
+		return common.CopyBytes(cached.([]byte)), nil
+	}
+	cacheHead := client.cache.head()
+	recordRPCCall(client.endpoint, "eth_getCode")
+	code, err := client.GethClient.CodeAt(ctx, account, blockNumber)
+	// Only the code of contracts is cached, as an account without code may
+	// have a contract deployed to it at any time
+	if err == nil && len(code) > 0 {
+		client.cache.set(key, common.CopyBytes(code), cacheHead)
+	}
+	return code, err
+}
+
 func (client *client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
 	logger.Debugw("eth.Client#SubscribeFilterLogs(...)",
 		"q", q,
 	)
+	recordRPCCall(client.endpoint, "eth_subscribe")
 	return client.GethClient.SubscribeFilterLogs(ctx, q, ch)
 }
 
 func (client *client) SubscribeNewHead(ctx context.Context, ch chan<- *models.Head) (ethereum.Subscription, error) {
 	logger.Debugw("eth.Client#SubscribeNewHead(...)")
-	return client.RPCClient.EthSubscribe(ctx, ch, "newHeads")
+	recordRPCCall(client.endpoint, "eth_subscribe")
+	if client.cache == nil {
+		return client.RPCClient.EthSubscribe(ctx, ch, "newHeads")
+	}
+	heads := make(chan *models.Head)
+	sub, err := client.RPCClient.EthSubscribe(ctx, heads, "newHeads")
+	if err != nil {
+		return nil, err
+	}
+	return newHeadSubscription(sub, client.cache, heads, ch), nil
 }
 
 type rpcClientWrapper struct {
@@ -356,6 +449,7 @@ func (client *client) Call(result interface{}, method string, args ...interface{
 		"method", method,
 		"args", args,
 	)
+	recordRPCCall(client.endpoint, method)
 	return client.RPCClient.Call(result, method, args...)
 }
 
@@ -364,6 +458,7 @@ func (client *client) CallContext(ctx context.Context, result interface{}, metho
 		"method", method,
 		"args", args,
 	)
+	recordRPCCall(client.endpoint, method)
 	return client.RPCClient.CallContext(ctx, result, method, args...)
 }
 
@@ -371,6 +466,7 @@ func (client *client) BatchCallContext(ctx context.Context, b []rpc.BatchElem) e
 	logger.Debugw("eth.Client#BatchCall(...)",
 		"nBatchElems", len(b),
 	)
+	recordBatchCalls(client.endpoint, b)
 	return client.RPCClient.BatchCallContext(ctx, b)
 }
 
@@ -389,5 +485,6 @@ func (client *client) RoundRobinBatchCallContext(ctx context.Context, b []rpc.Ba
 	if rr == 0 {
 		return client.BatchCallContext(ctx, b)
 	}
+	recordBatchCalls(client.secondaryURLs[rr-1].Host, b)
 	return client.SecondaryRPCClients[rr-1].BatchCallContext(ctx, b)
 }
@@ -360,3 +360,36 @@ func TestEthClient_SendTransaction_WithSecondaryURLs(t *testing.T) {
 		return len(requests)
 	}).Should(gomega.Equal(2))
 }
+
+func TestEthClient_CountsRPCCalls(t *testing.T) {
+	t.Parallel()
+
+	tx := types.NewTransaction(uint64(42), cltest.NewAddress(), big.NewInt(142), 242, big.NewInt(342), []byte{1, 2, 3})
+
+	response := `{
+  "id": 1,
+  "jsonrpc": "2.0",
+  "result": "` + tx.Hash().Hex() + `"
+}`
+
+	_, url, cleanup := cltest.NewWSServer(response, func([]byte) {})
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(response))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	secondaryUrl := *cltest.MustParseURL(server.URL)
+	ethClient, err := eth.NewClient(url, secondaryUrl)
+	require.NoError(t, err)
+	require.NoError(t, ethClient.Dial(context.Background()))
+
+	require.NoError(t, ethClient.SendTransaction(context.Background(), tx))
+
+	calls := eth.TakeRPCCalls()
+	primary := cltest.MustParseURL(url).Host
+	assert.Equal(t, uint64(1), calls[eth.RPCCallKey{Method: "eth_sendRawTransaction", Endpoint: primary}])
+	assert.Equal(t, uint64(1), calls[eth.RPCCallKey{Method: "eth_sendRawTransaction", Endpoint: secondaryUrl.Host}])
+}
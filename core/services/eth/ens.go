@@ -0,0 +1,96 @@
+package eth
+
+import (
+	"context"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// ENSRegistryAddress is the address of the ENS registry, which is the same on
+// mainnet and the public testnets
+var ENSRegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+// ErrENSNameNotFound is returned when resolving an ENS name that has no
+// resolver, or whose resolver has no address for it
+var ErrENSNameNotFound = errors.New("ENS name does not resolve to an address")
+
+var ensABI = MustGetABI(`[
+	{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"resolver","outputs":[{"name":"","type":"address"}],"type":"function"},
+	{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"addr","outputs":[{"name":"","type":"address"}],"type":"function"}
+]`)
+
+// IsENSName reports whether s looks like an ENS name such as "eth-usd.data.eth",
+// rather than a hex address
+func IsENSName(s string) bool {
+	if s == "" || common.IsHexAddress(s) || strings.HasPrefix(s, "0x") {
+		return false
+	}
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || strings.ContainsAny(label, " \t\n/:") {
+			return false
+		}
+	}
+	return true
+}
+
+// ENSNameHash returns the namehash of an ENS name, as defined by EIP-137.
+// Names are lowercased, but not otherwise normalized.
+func ENSNameHash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+	return node
+}
+
+// ResolveENS returns the address that an ENS name resolves to, by looking up
+// the name's resolver in the ENS registry and asking it for the address
+func ResolveENS(ctx context.Context, client GethClient, name string) (common.Address, error) {
+	node := ENSNameHash(name)
+
+	resolver, err := callENS(ctx, client, ENSRegistryAddress, "resolver", node)
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "failed to look up the resolver of %s", name)
+	} else if resolver == (common.Address{}) {
+		return common.Address{}, errors.Wrapf(ErrENSNameNotFound, "%s has no resolver", name)
+	}
+
+	address, err := callENS(ctx, client, resolver, "addr", node)
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "failed to resolve %s", name)
+	} else if address == (common.Address{}) {
+		return common.Address{}, errors.Wrapf(ErrENSNameNotFound, "%s", name)
+	}
+	return address, nil
+}
+
+func callENS(ctx context.Context, client GethClient, contract common.Address, method string, node common.Hash) (common.Address, error) {
+	data, err := ensABI.Pack(method, node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(result) == 0 {
+		return common.Address{}, nil
+	}
+	var address common.Address
+	if err = ensABI.UnpackIntoInterface(&address, method, result); err != nil {
+		return common.Address{}, errors.Wrapf(err, "could not decode the result of %s", method)
+	}
+	return address, nil
+}
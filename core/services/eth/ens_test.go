@@ -0,0 +1,78 @@
+package eth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestIsENSName(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, eth.IsENSName("eth-usd.data.eth"))
+	assert.True(t, eth.IsENSName("oracle.eth"))
+	assert.False(t, eth.IsENSName("0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"))
+	assert.False(t, eth.IsENSName("0xabc.eth"))
+	assert.False(t, eth.IsENSName("eth"))
+	assert.False(t, eth.IsENSName("oracle..eth"))
+	assert.False(t, eth.IsENSName(""))
+}
+
+func TestENSNameHash(t *testing.T) {
+	t.Parallel()
+
+	// Test vectors from EIP-137
+	assert.Equal(t, common.Hash{}, eth.ENSNameHash(""))
+	assert.Equal(t, common.HexToHash("0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"), eth.ENSNameHash("eth"))
+	assert.Equal(t, common.HexToHash("0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"), eth.ENSNameHash("foo.eth"))
+	assert.Equal(t, eth.ENSNameHash("foo.eth"), eth.ENSNameHash("Foo.ETH"))
+}
+
+func TestResolveENS(t *testing.T) {
+	t.Parallel()
+
+	resolver := common.HexToAddress("0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41")
+	address := common.HexToAddress("0x3cCad4715152693fE3BC4460591e3D3Fbd071b42")
+	callTo := func(to common.Address) interface{} {
+		return mock.MatchedBy(func(msg ethereum.CallMsg) bool { return *msg.To == to })
+	}
+
+	t.Run("resolves the name through its resolver", func(t *testing.T) {
+		client := new(mocks.GethClient)
+		client.On("CallContract", mock.Anything, callTo(eth.ENSRegistryAddress), mock.Anything).Return(common.LeftPadBytes(resolver.Bytes(), 32), nil).Once()
+		client.On("CallContract", mock.Anything, callTo(resolver), mock.Anything).Return(common.LeftPadBytes(address.Bytes(), 32), nil).Once()
+
+		resolved, err := eth.ResolveENS(context.Background(), client, "eth-usd.data.eth")
+		require.NoError(t, err)
+		assert.Equal(t, address, resolved)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("errors for names without a resolver", func(t *testing.T) {
+		client := new(mocks.GethClient)
+		client.On("CallContract", mock.Anything, callTo(eth.ENSRegistryAddress), mock.Anything).Return(make([]byte, 32), nil).Once()
+
+		_, err := eth.ResolveENS(context.Background(), client, "missing.eth")
+		assert.True(t, errors.Is(err, eth.ErrENSNameNotFound))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("errors for names without an address", func(t *testing.T) {
+		client := new(mocks.GethClient)
+		client.On("CallContract", mock.Anything, callTo(eth.ENSRegistryAddress), mock.Anything).Return(common.LeftPadBytes(resolver.Bytes(), 32), nil).Once()
+		client.On("CallContract", mock.Anything, callTo(resolver), mock.Anything).Return(make([]byte, 32), nil).Once()
+
+		_, err := eth.ResolveENS(context.Background(), client, "unset.eth")
+		assert.True(t, errors.Is(err, eth.ErrENSNameNotFound))
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,84 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// logsResponseTooLarge matches the errors that providers return when an
+// eth_getLogs query covers too many blocks or would return too many logs, e.g.
+// Infura's "query returned more than 10000 results" and Alchemy's "Log response
+// size exceeded".
+var logsResponseTooLarge = regexp.MustCompile(`(?i)(query returned more than [0-9]+ results|response size exceeded|block range is too (wide|large)|exceeds? (the )?(maximum|max) block range|range too large|too many blocks)`)
+
+// IsLogsResponseTooLarge returns true if err means that an eth_getLogs query
+// must be split into smaller ranges to succeed
+func IsLogsResponseTooLarge(err error) bool {
+	if err == nil {
+		return false
+	}
+	return logsResponseTooLarge.MatchString(errors.Cause(err).Error())
+}
+
+// FilterLogsInChunks runs q over its block range in chunks of no more than
+// chunkSize blocks, so that nodes on RPC plans that limit the size of
+// eth_getLogs responses can still query large ranges. A chunk that is still
+// too large is split in half until it succeeds or is a single block. q must
+// have both FromBlock and ToBlock set. A chunkSize of 0 queries the whole range
+// at once.
+func FilterLogsInChunks(ctx context.Context, client Client, q ethereum.FilterQuery, chunkSize uint64) ([]types.Log, error) {
+	if q.BlockHash != nil || q.FromBlock == nil || q.ToBlock == nil {
+		return nil, errors.New("FilterLogsInChunks needs a query with a FromBlock and ToBlock")
+	}
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if from > to {
+		return nil, nil
+	}
+	if chunkSize == 0 {
+		chunkSize = to - from + 1
+	}
+
+	var logs []types.Log
+	for start := from; start <= to; start += chunkSize {
+		end := start + chunkSize - 1
+		if end > to || end < start {
+			end = to
+		}
+		chunk, err := filterLogsInRange(ctx, client, q, start, end)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, chunk...)
+		if end == to {
+			break
+		}
+	}
+	return logs, nil
+}
+
+func filterLogsInRange(ctx context.Context, client Client, q ethereum.FilterQuery, from, to uint64) ([]types.Log, error) {
+	q.FromBlock = new(big.Int).SetUint64(from)
+	q.ToBlock = new(big.Int).SetUint64(to)
+	logs, err := client.FilterLogs(ctx, q)
+	if err == nil {
+		return logs, nil
+	} else if from == to || !IsLogsResponseTooLarge(err) {
+		return nil, errors.Wrapf(err, "failed to get logs for blocks %d to %d", from, to)
+	}
+
+	mid := from + (to-from)/2
+	first, err := filterLogsInRange(ctx, client, q, from, mid)
+	if err != nil {
+		return nil, err
+	}
+	second, err := filterLogsInRange(ctx, client, q, mid+1, to)
+	if err != nil {
+		return nil, err
+	}
+	return append(first, second...), nil
+}
@@ -0,0 +1,81 @@
+package eth_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func blockRange(from, to int64) interface{} {
+	return mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.FromBlock.Int64() == from && q.ToBlock.Int64() == to
+	})
+}
+
+func Test_IsLogsResponseTooLarge(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, eth.IsLogsResponseTooLarge(nil))
+	assert.False(t, eth.IsLogsResponseTooLarge(errors.New("connection refused")))
+	// Infura
+	assert.True(t, eth.IsLogsResponseTooLarge(errors.New("query returned more than 10000 results")))
+	// Alchemy
+	assert.True(t, eth.IsLogsResponseTooLarge(errors.New("Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range")))
+	// Others
+	assert.True(t, eth.IsLogsResponseTooLarge(errors.New("block range is too wide")))
+	assert.True(t, eth.IsLogsResponseTooLarge(errors.New("exceed maximum block range: 5000")))
+}
+
+func Test_FilterLogsInChunks(t *testing.T) {
+	t.Parallel()
+
+	q := ethereum.FilterQuery{FromBlock: big.NewInt(10), ToBlock: big.NewInt(34)}
+
+	t.Run("queries the range in chunks", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		ethClient.On("FilterLogs", mock.Anything, blockRange(10, 19)).Return([]types.Log{{BlockNumber: 12}}, nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, blockRange(20, 29)).Return(nil, nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, blockRange(30, 34)).Return([]types.Log{{BlockNumber: 33}}, nil).Once()
+
+		logs, err := eth.FilterLogsInChunks(context.Background(), ethClient, q, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Log{{BlockNumber: 12}, {BlockNumber: 33}}, logs)
+
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("splits chunks whose response is too large", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		tooLarge := errors.New("query returned more than 10000 results")
+		ethClient.On("FilterLogs", mock.Anything, blockRange(10, 34)).Return(nil, tooLarge).Once()
+		ethClient.On("FilterLogs", mock.Anything, blockRange(10, 22)).Return(nil, tooLarge).Once()
+		ethClient.On("FilterLogs", mock.Anything, blockRange(10, 16)).Return([]types.Log{{BlockNumber: 11}}, nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, blockRange(17, 22)).Return([]types.Log{{BlockNumber: 20}}, nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, blockRange(23, 34)).Return([]types.Log{{BlockNumber: 30}}, nil).Once()
+
+		logs, err := eth.FilterLogsInChunks(context.Background(), ethClient, q, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Log{{BlockNumber: 11}, {BlockNumber: 20}, {BlockNumber: 30}}, logs)
+
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("returns other errors", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		ethClient.On("FilterLogs", mock.Anything, blockRange(10, 34)).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := eth.FilterLogsInChunks(context.Background(), ethClient, q, 100)
+		require.EqualError(t, err, "failed to get logs for blocks 10 to 34: connection refused")
+
+		ethClient.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,119 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+var (
+	// OptimismGasPriceOracleAddress is the predeploy that computes the L1
+	// data fee of transactions on Optimism
+	OptimismGasPriceOracleAddress = common.HexToAddress("0x420000000000000000000000000000000000000F")
+	// ArbGasInfoAddress is the precompile that reports the ArbGas prices of
+	// Arbitrum
+	ArbGasInfoAddress = common.HexToAddress("0x000000000000000000000000000000000000006C")
+
+	getL1FeeSelector          = crypto.Keccak256([]byte("getL1Fee(bytes)"))[:4]
+	getPricesInArbGasSelector = crypto.Keccak256([]byte("getPricesInArbGas()"))[:4]
+
+	bytesArguments        abi.Arguments
+	arbGasPricesArguments abi.Arguments
+)
+
+func init() {
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	bytesArguments = abi.Arguments{{Type: bytesType}}
+	arbGasPricesArguments = abi.Arguments{{Type: uint256Type}, {Type: uint256Type}, {Type: uint256Type}}
+}
+
+// GasCostEstimator estimates what a transaction would cost in wei
+type GasCostEstimator interface {
+	// EstimateGasCost returns the cost of msg if it used all of msg.Gas at
+	// msg.GasPrice, including any fee the chain charges for its L1 data
+	EstimateGasCost(ctx context.Context, msg ethereum.CallMsg) (*big.Int, error)
+}
+
+// NewGasCostEstimator returns the GasCostEstimator for chains of the given
+// type. Multiplying the gas limit by the gas price is wildly wrong on rollups,
+// where publishing the transaction's data on L1 is often most of the cost.
+func NewGasCostEstimator(client Client, chainType ChainType) GasCostEstimator {
+	switch chainType {
+	case ChainTypeOptimism:
+		return &optimismGasCostEstimator{client}
+	case ChainTypeArbitrum:
+		return &arbitrumGasCostEstimator{client}
+	default:
+		return l1GasCostEstimator{}
+	}
+}
+
+type l1GasCostEstimator struct{}
+
+func (l1GasCostEstimator) EstimateGasCost(_ context.Context, msg ethereum.CallMsg) (*big.Int, error) {
+	return executionCost(msg), nil
+}
+
+func executionCost(msg ethereum.CallMsg) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas), msg.GasPrice)
+}
+
+// optimismGasCostEstimator adds the L1 data fee charged by Optimism, as
+// computed by its gas price oracle from the current L1 base fee, to the
+// execution cost
+type optimismGasCostEstimator struct {
+	client Client
+}
+
+func (e *optimismGasCostEstimator) EstimateGasCost(ctx context.Context, msg ethereum.CallMsg) (*big.Int, error) {
+	args, err := bytesArguments.Pack(msg.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not pack getL1Fee arguments")
+	}
+	data := append(append([]byte{}, getL1FeeSelector...), args...)
+	result, err := e.client.CallContract(ctx, ethereum.CallMsg{To: &OptimismGasPriceOracleAddress, Data: data}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get L1 fee from the gas price oracle")
+	}
+	if len(result) != 32 {
+		return nil, errors.Errorf("unexpected getL1Fee result 0x%x", result)
+	}
+	l1Fee := new(big.Int).SetBytes(result)
+	return l1Fee.Add(l1Fee, executionCost(msg)), nil
+}
+
+// arbitrumGasCostEstimator adds the ArbGas that Arbitrum charges for
+// publishing the transaction and its calldata on L1 to its gas limit, before
+// pricing it all at the gas price
+type arbitrumGasCostEstimator struct {
+	client Client
+}
+
+func (e *arbitrumGasCostEstimator) EstimateGasCost(ctx context.Context, msg ethereum.CallMsg) (*big.Int, error) {
+	result, err := e.client.CallContract(ctx, ethereum.CallMsg{To: &ArbGasInfoAddress, Data: getPricesInArbGasSelector}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get ArbGas prices")
+	}
+	prices, err := arbGasPricesArguments.Unpack(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unpack ArbGas prices")
+	}
+	perL2Tx, perL1CalldataByte := prices[0].(*big.Int), prices[1].(*big.Int)
+
+	arbGas := new(big.Int).Mul(perL1CalldataByte, big.NewInt(int64(len(msg.Data))))
+	arbGas.Add(arbGas, perL2Tx)
+	arbGas.Add(arbGas, new(big.Int).SetUint64(msg.Gas))
+	return arbGas.Mul(arbGas, msg.GasPrice), nil
+}
@@ -0,0 +1,72 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestGasCostEstimator(t *testing.T) {
+	t.Parallel()
+
+	to := common.HexToAddress("0x3cCad4715152693fE3BC4460591e3D3Fbd071b42")
+	msg := ethereum.CallMsg{To: &to, Gas: 100000, GasPrice: big.NewInt(2), Data: []byte{1, 2, 3, 4}}
+
+	t.Run("L1 charges for execution", func(t *testing.T) {
+		cost, err := eth.NewGasCostEstimator(nil, eth.ChainTypeL1).EstimateGasCost(context.Background(), msg)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(200000), cost)
+	})
+
+	t.Run("Optimism adds the L1 data fee", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		ethClient.On("CallContract", mock.Anything, mock.MatchedBy(func(call ethereum.CallMsg) bool {
+			return *call.To == eth.OptimismGasPriceOracleAddress && hexutil.Encode(call.Data[:4]) == "0x49948e0e"
+		}), (*big.Int)(nil)).Return(common.LeftPadBytes(big.NewInt(5000000).Bytes(), 32), nil).Once()
+
+		cost, err := eth.NewGasCostEstimator(ethClient, eth.ChainTypeOptimism).EstimateGasCost(context.Background(), msg)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(5200000), cost)
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("Arbitrum adds the ArbGas for L1 calldata", func(t *testing.T) {
+		// ArbGas per L2 tx, per L1 calldata byte and per storage allocation
+		var prices []byte
+		for _, price := range []int64{10000, 500, 0} {
+			prices = append(prices, common.LeftPadBytes(big.NewInt(price).Bytes(), 32)...)
+		}
+		ethClient := new(mocks.Client)
+		ethClient.On("CallContract", mock.Anything, mock.MatchedBy(func(call ethereum.CallMsg) bool {
+			return *call.To == eth.ArbGasInfoAddress
+		}), (*big.Int)(nil)).Return(prices, nil).Once()
+
+		cost, err := eth.NewGasCostEstimator(ethClient, eth.ChainTypeArbitrum).EstimateGasCost(context.Background(), msg)
+		require.NoError(t, err)
+		// (100,000 + 10,000 + 4 * 500) * 2
+		assert.Equal(t, big.NewInt(224000), cost)
+		ethClient.AssertExpectations(t)
+	})
+}
+
+func TestChainProfileFor(t *testing.T) {
+	t.Parallel()
+
+	profile, exists := eth.ChainProfileFor(big.NewInt(10))
+	require.True(t, exists)
+	assert.Equal(t, eth.ChainTypeOptimism, profile.Type)
+	assert.Equal(t, "0", profile.ConfigDefaults["ETH_GAS_BUMP_THRESHOLD"])
+
+	_, exists = eth.ChainProfileFor(big.NewInt(1))
+	assert.False(t, exists)
+}
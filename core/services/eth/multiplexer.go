@@ -0,0 +1,791 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+var (
+	promMultiplexedSubscribers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eth_multiplexed_subscribers",
+		Help: "The number of subscribers sharing the node's newHeads or logs subscriptions",
+	}, []string{"type"})
+	promMultiplexedQueueLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eth_multiplexed_queue_length",
+		Help: "The number of items queued for the subscriber that is furthest behind",
+	}, []string{"type"})
+	promMultiplexedOverflows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "eth_multiplexed_overflows_total",
+		Help: "The number of subscriptions closed because their subscriber fell too far behind",
+	}, []string{"type"})
+	promMultiplexedResubscribes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "eth_multiplexed_logs_resubscribes_total",
+		Help: "The number of times a logs subscription was replaced to cover a new subscriber's filter",
+	})
+)
+
+const (
+	headsQueueSize = 100
+	logsQueueSize  = 10000
+
+	// logsRetireDelay is how long the old logs subscription keeps delivering
+	// logs after it is replaced, so that the logs it received before the new
+	// subscription started aren't lost
+	logsRetireDelay = 10 * time.Second
+	// logsOverlapWindow is how long after the logs subscription is replaced
+	// that logs are deduplicated, as the old and new subscriptions both
+	// deliver the logs of the blocks mined while they overlap
+	logsOverlapWindow = time.Minute
+)
+
+// ErrSubscriberOverflow is sent on the Err channel of a subscription whose
+// subscriber fell so far behind that it was closed, as geth closes a
+// subscription whose client is too slow. Subscribers are expected to
+// resubscribe, and backfill, as they would after any subscription error.
+var ErrSubscriberOverflow = errors.New("subscriber fell too far behind, and was unsubscribed")
+
+// MultiplexedClient makes one newHeads subscription to the eth node, however
+// many subscribers there are, and as few logs subscriptions as it can, and
+// fans out what they receive. Subscribers share a logs subscription whose
+// filter is the union of theirs, as long as that union matches no logs that
+// none of them match, and each subscriber only receives the logs that match
+// its own filter. When a subscriber's filter isn't covered by a logs
+// subscription, one is replaced with a wider one, or a new one is made, and
+// logs are deduplicated while the old and new subscriptions overlap.
+//
+// Each subscriber has its own queue, so that a slow subscriber doesn't hold up
+// the others. A subscriber whose queue fills up is sent ErrSubscriberOverflow
+// and unsubscribed.
+type MultiplexedClient struct {
+	Client
+
+	heads *headsMultiplexer
+	logs  *logsMultiplexer
+}
+
+var _ Client = (*MultiplexedClient)(nil)
+
+// NewMultiplexedClient returns a Client that multiplexes the subscriptions of
+// client
+func NewMultiplexedClient(client Client) *MultiplexedClient {
+	return &MultiplexedClient{
+		Client: client,
+		heads:  &headsMultiplexer{client: client, subs: make(map[*muxSubscription]struct{})},
+		logs:   &logsMultiplexer{client: client},
+	}
+}
+
+func (m *MultiplexedClient) SubscribeNewHead(ctx context.Context, ch chan<- *models.Head) (ethereum.Subscription, error) {
+	return m.heads.subscribe(ctx, ch)
+}
+
+func (m *MultiplexedClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return m.logs.subscribe(ctx, q, ch)
+}
+
+func (m *MultiplexedClient) Close() {
+	m.heads.close()
+	m.logs.close()
+	m.Client.Close()
+}
+
+// muxSubscription is a subscriber's share of a multiplexed subscription
+type muxSubscription struct {
+	kind     string
+	filter   ethereum.FilterQuery
+	queue    chan interface{}
+	chErr    chan error
+	chStop   chan struct{}
+	stopOnce sync.Once
+	remove   func(*muxSubscription)
+	// delivered and maxQueued account for the subscriber's backpressure.
+	// They are guarded by the multiplexer's lock.
+	delivered uint64
+	maxQueued int
+}
+
+func newMuxSubscription(kind string, filter ethereum.FilterQuery, queueSize int, send func(item interface{}, chStop <-chan struct{}) bool, remove func(*muxSubscription)) *muxSubscription {
+	s := &muxSubscription{
+		kind:   kind,
+		filter: filter,
+		queue:  make(chan interface{}, queueSize),
+		chErr:  make(chan error, 1),
+		chStop: make(chan struct{}),
+		remove: remove,
+	}
+	go func() {
+		for {
+			select {
+			case item := <-s.queue:
+				if !send(item, s.chStop) {
+					return
+				}
+			case <-s.chStop:
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// enqueue returns false if the subscriber's queue is full. It is only called
+// by one goroutine at a time, with the multiplexer locked.
+func (s *muxSubscription) enqueue(item interface{}) bool {
+	select {
+	case s.queue <- item:
+		s.delivered++
+		if n := len(s.queue); n > s.maxQueued {
+			s.maxQueued = n
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// stop stops delivery to the subscriber, sending err on its Err channel if
+// it is set. The Err channel is never closed, as subscribers that select on it
+// after unsubscribing would spin.
+func (s *muxSubscription) stop(err error) {
+	s.stopOnce.Do(func() {
+		if err != nil {
+			s.chErr <- err
+		}
+		close(s.chStop)
+	})
+}
+
+func (s *muxSubscription) Err() <-chan error {
+	return s.chErr
+}
+
+func (s *muxSubscription) Unsubscribe() {
+	s.remove(s)
+	s.stop(nil)
+}
+
+// dispatch queues item for each subscriber that wants it, and returns the
+// subscribers whose queues were full
+func dispatch(kind string, subs map[*muxSubscription]struct{}, item func(*muxSubscription) (interface{}, bool)) (overflowed []*muxSubscription) {
+	maxQueued := 0
+	for s := range subs {
+		it, wanted := item(s)
+		if !wanted {
+			continue
+		}
+		if !s.enqueue(it) {
+			overflowed = append(overflowed, s)
+			continue
+		}
+		if n := len(s.queue); n > maxQueued {
+			maxQueued = n
+		}
+	}
+	promMultiplexedQueueLength.WithLabelValues(kind).Set(float64(maxQueued))
+	return overflowed
+}
+
+func overflow(kind string, subs map[*muxSubscription]struct{}, overflowed []*muxSubscription) {
+	for _, s := range overflowed {
+		delete(subs, s)
+		promMultiplexedOverflows.WithLabelValues(kind).Inc()
+		logger.Errorw("eth.MultiplexedClient: subscriber fell too far behind, closing its subscription", "type", kind, "delivered", s.delivered, "maxQueued", s.maxQueued)
+		s.stop(ErrSubscriberOverflow)
+	}
+	promMultiplexedSubscribers.WithLabelValues(kind).Set(float64(len(subs)))
+}
+
+type headsMultiplexer struct {
+	client Client
+
+	mu       sync.Mutex
+	subs     map[*muxSubscription]struct{}
+	upstream ethereum.Subscription
+	chStop   chan struct{}
+}
+
+func (m *headsMultiplexer) subscribe(ctx context.Context, ch chan<- *models.Head) (ethereum.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.upstream == nil {
+		chHeads := make(chan *models.Head)
+		upstream, err := m.client.SubscribeNewHead(ctx, chHeads)
+		if err != nil {
+			return nil, err
+		}
+		m.upstream = upstream
+		m.chStop = make(chan struct{})
+		go m.forward(upstream, chHeads, m.chStop)
+	}
+
+	s := newMuxSubscription("heads", ethereum.FilterQuery{}, headsQueueSize, func(item interface{}, chStop <-chan struct{}) bool {
+		select {
+		case ch <- item.(*models.Head):
+			return true
+		case <-chStop:
+			return false
+		}
+	}, m.remove)
+	m.subs[s] = struct{}{}
+	promMultiplexedSubscribers.WithLabelValues("heads").Set(float64(len(m.subs)))
+	return s, nil
+}
+
+func (m *headsMultiplexer) forward(upstream ethereum.Subscription, chHeads <-chan *models.Head, chStop <-chan struct{}) {
+	for {
+		select {
+		case head := <-chHeads:
+			if head == nil {
+				continue
+			}
+			m.mu.Lock()
+			overflowed := dispatch("heads", m.subs, func(*muxSubscription) (interface{}, bool) {
+				// Each subscriber gets its own copy, as heads are mutated
+				h := *head
+				return &h, true
+			})
+			overflow("heads", m.subs, overflowed)
+			m.mu.Unlock()
+		case err := <-upstream.Err():
+			if err == nil {
+				err = errors.New("subscription closed by the eth node")
+			}
+			m.mu.Lock()
+			if m.upstream == upstream {
+				m.failLocked(err)
+			}
+			m.mu.Unlock()
+			return
+		case <-chStop:
+			return
+		}
+	}
+}
+
+// failLocked passes an upstream error on to every subscriber, so that they
+// resubscribe
+func (m *headsMultiplexer) failLocked(err error) {
+	for s := range m.subs {
+		s.stop(err)
+	}
+	m.subs = make(map[*muxSubscription]struct{})
+	m.closeUpstreamLocked()
+}
+
+func (m *headsMultiplexer) remove(s *muxSubscription) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.subs[s]; exists {
+		delete(m.subs, s)
+		logger.Debugw("eth.MultiplexedClient: unsubscribed", "type", s.kind, "delivered", s.delivered, "maxQueued", s.maxQueued)
+	}
+	promMultiplexedSubscribers.WithLabelValues("heads").Set(float64(len(m.subs)))
+	if len(m.subs) == 0 {
+		m.closeUpstreamLocked()
+	}
+}
+
+func (m *headsMultiplexer) closeUpstreamLocked() {
+	if m.upstream == nil {
+		return
+	}
+	close(m.chStop)
+	go m.upstream.Unsubscribe()
+	m.upstream = nil
+}
+
+func (m *headsMultiplexer) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closeUpstreamLocked()
+}
+
+type logKey struct {
+	blockHash common.Hash
+	index     uint
+	removed   bool
+}
+
+// logsMultiplexer shares logs subscriptions between subscribers. Subscribers
+// are grouped so that each group's subscription matches no more logs than its
+// subscribers do between them, see unionExact. Usually every subscriber is in
+// one group, but a subscriber whose filter would widen a group's subscription
+// beyond that, such as one without addresses joining one with addresses and
+// topics, is given its own subscription instead.
+type logsMultiplexer struct {
+	client Client
+
+	// subscribeMu serializes the replacement of the upstream subscriptions
+	subscribeMu sync.Mutex
+
+	mu     sync.Mutex
+	groups []*logsGroup
+}
+
+// logsGroup is a set of subscribers sharing one logs subscription. It is
+// guarded by its multiplexer's lock.
+type logsGroup struct {
+	subs           map[*muxSubscription]struct{}
+	upstream       ethereum.Subscription
+	upstreamFilter ethereum.FilterQuery
+	chStop         chan struct{}
+	overlapUntil   time.Time
+	overlapSeen    map[logKey]struct{}
+}
+
+func (m *logsMultiplexer) subscribe(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	m.subscribeMu.Lock()
+	defer m.subscribeMu.Unlock()
+
+	var s *muxSubscription
+	var group *logsGroup
+	s = newMuxSubscription("logs", q, logsQueueSize, func(item interface{}, chStop <-chan struct{}) bool {
+		select {
+		case ch <- item.(types.Log):
+			return true
+		case <-chStop:
+			return false
+		}
+	}, func(s *muxSubscription) { m.remove(group, s) })
+
+	for {
+		m.mu.Lock()
+		for _, g := range m.groups {
+			if g.upstream != nil && filterCovers(g.upstreamFilter, q) {
+				group = g
+				g.subs[s] = struct{}{}
+				m.updateSubscribersLocked()
+				m.mu.Unlock()
+				return s, nil
+			}
+		}
+		var target *logsGroup
+		filters := []ethereum.FilterQuery{q}
+		for _, g := range m.groups {
+			widened := append(g.filtersLocked(), q)
+			if unionExact(widened) {
+				target, filters = g, widened
+				break
+			}
+		}
+		if target == nil {
+			target = &logsGroup{subs: make(map[*muxSubscription]struct{})}
+			m.groups = append(m.groups, target)
+		}
+		m.mu.Unlock()
+
+		if err := m.resubscribe(ctx, target, unionFilter(filters)); err != nil {
+			m.mu.Lock()
+			if len(target.subs) == 0 && target.upstream == nil {
+				m.dropGroupLocked(target)
+			}
+			m.mu.Unlock()
+			s.stop(nil)
+			return nil, err
+		}
+	}
+}
+
+// filtersLocked returns the filters of the group's subscribers
+func (g *logsGroup) filtersLocked() []ethereum.FilterQuery {
+	filters := make([]ethereum.FilterQuery, 0, len(g.subs))
+	for s := range g.subs {
+		filters = append(filters, s.filter)
+	}
+	return filters
+}
+
+// resubscribe replaces the group's logs subscription with one using filter.
+// The new subscription is made before the old one is closed, so that no logs
+// are missed in between.
+func (m *logsMultiplexer) resubscribe(ctx context.Context, g *logsGroup, filter ethereum.FilterQuery) error {
+	chLogs := make(chan types.Log)
+	upstream, err := m.client.SubscribeFilterLogs(ctx, filter, chLogs)
+	if err != nil {
+		return err
+	}
+	chStop := make(chan struct{})
+	go m.forward(g, upstream, chLogs, chStop)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.hasGroupLocked(g) {
+		// Its subscribers all left, or its subscription failed, meanwhile
+		m.groups = append(m.groups, g)
+	}
+	if g.upstream != nil {
+		old, oldStop := g.upstream, g.chStop
+		time.AfterFunc(logsRetireDelay, func() {
+			close(oldStop)
+			old.Unsubscribe()
+		})
+		g.overlapUntil = time.Now().Add(logsOverlapWindow)
+		g.overlapSeen = make(map[logKey]struct{})
+		promMultiplexedResubscribes.Inc()
+	}
+	g.upstream, g.upstreamFilter, g.chStop = upstream, filter, chStop
+	return nil
+}
+
+func (m *logsMultiplexer) forward(g *logsGroup, upstream ethereum.Subscription, chLogs <-chan types.Log, chStop <-chan struct{}) {
+	for {
+		select {
+		case log := <-chLogs:
+			m.mu.Lock()
+			if g.duplicateLocked(log) {
+				m.mu.Unlock()
+				continue
+			}
+			overflowed := dispatch("logs", g.subs, func(s *muxSubscription) (interface{}, bool) {
+				return log, filterMatches(s.filter, log)
+			})
+			overflow("logs", g.subs, overflowed)
+			m.updateSubscribersLocked()
+			m.mu.Unlock()
+		case err := <-upstream.Err():
+			if err == nil {
+				err = errors.New("subscription closed by the eth node")
+			}
+			m.mu.Lock()
+			if g.upstream == upstream {
+				m.failLocked(g, err)
+			}
+			m.mu.Unlock()
+			return
+		case <-chStop:
+			return
+		}
+	}
+}
+
+// duplicateLocked returns true if log was already delivered by another
+// subscription while the group's subscription was being replaced
+func (g *logsGroup) duplicateLocked(log types.Log) bool {
+	if g.overlapSeen == nil {
+		return false
+	} else if time.Now().After(g.overlapUntil) {
+		g.overlapSeen = nil
+		return false
+	}
+	key := logKey{log.BlockHash, log.Index, log.Removed}
+	if _, seen := g.overlapSeen[key]; seen {
+		return true
+	}
+	g.overlapSeen[key] = struct{}{}
+	return false
+}
+
+func (m *logsMultiplexer) failLocked(g *logsGroup, err error) {
+	for s := range g.subs {
+		s.stop(err)
+	}
+	g.subs = make(map[*muxSubscription]struct{})
+	g.closeUpstreamLocked()
+	m.dropGroupLocked(g)
+}
+
+// remove doesn't narrow the group's filter, as the subscriber is often about
+// to subscribe again with a wider one. The filter is narrowed when the
+// subscription is next replaced.
+func (m *logsMultiplexer) remove(g *logsGroup, s *muxSubscription) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if g == nil {
+		return
+	}
+	if _, exists := g.subs[s]; exists {
+		delete(g.subs, s)
+		logger.Debugw("eth.MultiplexedClient: unsubscribed", "type", s.kind, "delivered", s.delivered, "maxQueued", s.maxQueued)
+	}
+	if len(g.subs) == 0 {
+		g.closeUpstreamLocked()
+		m.dropGroupLocked(g)
+	}
+	m.updateSubscribersLocked()
+}
+
+func (m *logsMultiplexer) hasGroupLocked(g *logsGroup) bool {
+	for _, group := range m.groups {
+		if group == g {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *logsMultiplexer) dropGroupLocked(g *logsGroup) {
+	for i, group := range m.groups {
+		if group == g {
+			m.groups = append(m.groups[:i], m.groups[i+1:]...)
+			return
+		}
+	}
+}
+
+// updateSubscribersLocked sets the subscribers metric to the number of
+// subscribers across all groups
+func (m *logsMultiplexer) updateSubscribersLocked() {
+	n := 0
+	for _, g := range m.groups {
+		n += len(g.subs)
+	}
+	promMultiplexedSubscribers.WithLabelValues("logs").Set(float64(n))
+}
+
+func (g *logsGroup) closeUpstreamLocked() {
+	if g.upstream == nil {
+		return
+	}
+	close(g.chStop)
+	go g.upstream.Unsubscribe()
+	g.upstream = nil
+	g.upstreamFilter = ethereum.FilterQuery{}
+}
+
+func (m *logsMultiplexer) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, g := range m.groups {
+		g.closeUpstreamLocked()
+	}
+	m.groups = nil
+}
+
+// filterMatches returns true if log matches the addresses and topics of q, as
+// the eth node would match them
+func filterMatches(q ethereum.FilterQuery, log types.Log) bool {
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, address := range q.Addresses {
+			if address == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(q.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range q.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range topics {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// unionFilter returns a filter that matches every log that any of qs match.
+// It has the union of their addresses and first topics, and the later topics
+// that they share, see unionExact.
+func unionFilter(qs []ethereum.FilterQuery) ethereum.FilterQuery {
+	allAddresses, allTopics := false, false
+	addresses := make(map[common.Address]struct{})
+	topics := make(map[common.Hash]struct{})
+	for _, q := range qs {
+		if len(q.Addresses) == 0 {
+			allAddresses = true
+		}
+		for _, address := range q.Addresses {
+			addresses[address] = struct{}{}
+		}
+		if len(q.Topics) == 0 || len(q.Topics[0]) == 0 {
+			allTopics = true
+		} else {
+			for _, topic := range q.Topics[0] {
+				topics[topic] = struct{}{}
+			}
+		}
+	}
+
+	var union ethereum.FilterQuery
+	if !allAddresses {
+		for address := range addresses {
+			union.Addresses = append(union.Addresses, address)
+		}
+		sort.Slice(union.Addresses, func(i, j int) bool {
+			return bytes.Compare(union.Addresses[i].Bytes(), union.Addresses[j].Bytes()) < 0
+		})
+	}
+	var first []common.Hash
+	if !allTopics {
+		for topic := range topics {
+			first = append(first, topic)
+		}
+		sort.Slice(first, func(i, j int) bool {
+			return bytes.Compare(first[i].Bytes(), first[j].Bytes()) < 0
+		})
+	}
+	var rest [][]common.Hash
+	if len(qs) > 0 {
+		rest = laterTopics(qs[0])
+	}
+	if len(first) > 0 || len(rest) > 0 {
+		union.Topics = append([][]common.Hash{first}, rest...)
+	}
+	return union
+}
+
+// unionExact returns true if unionFilter(qs) matches no log that none of qs
+// match. That holds when qs share their later topics, and each pair of an
+// address and a first topic in the union is matched by one of qs. Otherwise,
+// such as for a filter without addresses and a filter with an address and a
+// different first topic, the union would match logs nobody asked for.
+func unionExact(qs []ethereum.FilterQuery) bool {
+	if len(qs) == 0 {
+		return true
+	}
+	rest := laterTopics(qs[0])
+	for _, q := range qs[1:] {
+		if !sameTopics(rest, laterTopics(q)) {
+			return false
+		}
+	}
+
+	// A nil address or topic stands for every address or topic that no
+	// filter names
+	var addresses []*common.Address
+	var topics []*common.Hash
+	allAddresses, allTopics := false, false
+	for _, q := range qs {
+		if len(q.Addresses) == 0 {
+			allAddresses = true
+		}
+		for i := range q.Addresses {
+			addresses = append(addresses, &q.Addresses[i])
+		}
+		if len(q.Topics) == 0 || len(q.Topics[0]) == 0 {
+			allTopics = true
+		} else {
+			for i := range q.Topics[0] {
+				topics = append(topics, &q.Topics[0][i])
+			}
+		}
+	}
+	if allAddresses {
+		addresses = append(addresses, nil)
+	}
+	if allTopics {
+		topics = append(topics, nil)
+	}
+
+	for _, address := range addresses {
+		for _, topic := range topics {
+			matched := false
+			for _, q := range qs {
+				addressMatches := len(q.Addresses) == 0 || (address != nil && containsAddress(q.Addresses, *address))
+				topicMatches := len(q.Topics) == 0 || len(q.Topics[0]) == 0 || (topic != nil && containsHash(q.Topics[0], *topic))
+				if addressMatches && topicMatches {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// laterTopics returns the topics of q after the first, without trailing
+// wildcards
+func laterTopics(q ethereum.FilterQuery) [][]common.Hash {
+	if len(q.Topics) < 2 {
+		return nil
+	}
+	rest := q.Topics[1:]
+	for len(rest) > 0 && len(rest[len(rest)-1]) == 0 {
+		rest = rest[:len(rest)-1]
+	}
+	return rest
+}
+
+func sameTopics(a, b [][]common.Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for _, topic := range a[i] {
+			if !containsHash(b[i], topic) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// filterCovers returns true if every log that q matches is matched by
+// upstream
+func filterCovers(upstream, q ethereum.FilterQuery) bool {
+	if len(upstream.Addresses) > 0 {
+		if len(q.Addresses) == 0 {
+			return false
+		}
+		for _, address := range q.Addresses {
+			if !containsAddress(upstream.Addresses, address) {
+				return false
+			}
+		}
+	}
+	for i, topics := range upstream.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		if len(q.Topics) <= i || len(q.Topics[i]) == 0 {
+			return false
+		}
+		for _, topic := range q.Topics[i] {
+			if !containsHash(topics, topic) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package eth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockUpstream returns a subscription whose Err channel is chErr, and a
+// channel that is closed when it is unsubscribed
+func mockUpstream() (*mocks.Subscription, chan error, chan struct{}) {
+	sub := new(mocks.Subscription)
+	chErr := make(chan error, 1)
+	unsubscribed := make(chan struct{})
+	sub.On("Err").Return((<-chan error)(chErr))
+	sub.On("Unsubscribe").Return().Run(func(mock.Arguments) { close(unsubscribed) }).Once()
+	return sub, chErr, unsubscribed
+}
+
+func receiveHead(t *testing.T, ch <-chan *models.Head) *models.Head {
+	t.Helper()
+	select {
+	case head := <-ch:
+		return head
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for head")
+		return nil
+	}
+}
+
+func receiveLog(t *testing.T, ch <-chan types.Log) types.Log {
+	t.Helper()
+	select {
+	case log := <-ch:
+		return log
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for log")
+		return types.Log{}
+	}
+}
+
+func TestMultiplexedClient_SubscribeNewHead(t *testing.T) {
+	t.Parallel()
+
+	ethClient := new(mocks.Client)
+	upstream, chErr, unsubscribed := mockUpstream()
+	var heads chan<- *models.Head
+	ethClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { heads = args.Get(1).(chan<- *models.Head) }).
+		Return(upstream, nil).
+		Once()
+
+	client := eth.NewMultiplexedClient(ethClient)
+	ch1, ch2 := make(chan *models.Head), make(chan *models.Head)
+	sub1, err := client.SubscribeNewHead(context.Background(), ch1)
+	require.NoError(t, err)
+	sub2, err := client.SubscribeNewHead(context.Background(), ch2)
+	require.NoError(t, err)
+
+	t.Run("delivers each head to every subscriber", func(t *testing.T) {
+		heads <- &models.Head{Number: 1}
+		head1, head2 := receiveHead(t, ch1), receiveHead(t, ch2)
+		assert.Equal(t, int64(1), head1.Number)
+		assert.Equal(t, int64(1), head2.Number)
+		assert.False(t, head1 == head2, "subscribers should get their own copies of heads")
+	})
+
+	t.Run("passes upstream errors on to every subscriber", func(t *testing.T) {
+		chErr <- errors.New("connection lost")
+		assert.EqualError(t, <-sub1.Err(), "connection lost")
+		assert.EqualError(t, <-sub2.Err(), "connection lost")
+		<-unsubscribed
+	})
+
+	t.Run("closes subscribers that fall too far behind", func(t *testing.T) {
+		upstream, _, _ := mockUpstream()
+		ethClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { heads = args.Get(1).(chan<- *models.Head) }).
+			Return(upstream, nil).
+			Once()
+
+		slow, fast := make(chan *models.Head), make(chan *models.Head)
+		slowSub, err := client.SubscribeNewHead(context.Background(), slow)
+		require.NoError(t, err)
+		fastSub, err := client.SubscribeNewHead(context.Background(), fast)
+		require.NoError(t, err)
+		defer fastSub.Unsubscribe()
+
+		for i := 0; i < 110; i++ {
+			heads <- &models.Head{Number: int64(i)}
+			assert.Equal(t, int64(i), receiveHead(t, fast).Number)
+		}
+
+		select {
+		case err := <-slowSub.Err():
+			assert.Equal(t, eth.ErrSubscriberOverflow, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for overflow")
+		}
+		assert.Len(t, fastSub.Err(), 0)
+	})
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestMultiplexedClient_SubscribeFilterLogs(t *testing.T) {
+	t.Parallel()
+
+	a1, a2 := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	topic := cltest.NewHash()
+
+	ethClient := new(mocks.Client)
+	upstream1, _, unsubscribed1 := mockUpstream()
+	var logs1 chan<- types.Log
+	ethClient.On("SubscribeFilterLogs", mock.Anything, ethereum.FilterQuery{Addresses: []common.Address{a1}}, mock.Anything).
+		Run(func(args mock.Arguments) { logs1 = args.Get(2).(chan<- types.Log) }).
+		Return(upstream1, nil).
+		Once()
+	upstream2, _, _ := mockUpstream()
+	var logs2 chan<- types.Log
+	ethClient.On("SubscribeFilterLogs", mock.Anything, ethereum.FilterQuery{Addresses: []common.Address{a1, a2}}, mock.Anything).
+		Run(func(args mock.Arguments) { logs2 = args.Get(2).(chan<- types.Log) }).
+		Return(upstream2, nil).
+		Once()
+
+	client := eth.NewMultiplexedClient(ethClient)
+	chA1, chA2, chA1Topic := make(chan types.Log, 10), make(chan types.Log, 10), make(chan types.Log, 10)
+	subA1, err := client.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{Addresses: []common.Address{a1}}, chA1)
+	require.NoError(t, err)
+	defer subA1.Unsubscribe()
+	// Not covered by the first subscription, so it is replaced
+	subA2, err := client.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{Addresses: []common.Address{a2}}, chA2)
+	require.NoError(t, err)
+	defer subA2.Unsubscribe()
+	// Covered by the second subscription
+	subA1Topic, err := client.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{
+		Addresses: []common.Address{a1},
+		Topics:    [][]common.Hash{{topic}},
+	}, chA1Topic)
+	require.NoError(t, err)
+	defer subA1Topic.Unsubscribe()
+
+	t.Run("delivers each log to the subscribers whose filter it matches", func(t *testing.T) {
+		logs2 <- types.Log{Address: a2, BlockHash: cltest.NewHash(), Index: 1}
+		assert.Equal(t, a2, receiveLog(t, chA2).Address)
+
+		logs2 <- types.Log{Address: a1, BlockHash: cltest.NewHash(), Index: 2, Topics: []common.Hash{topic}}
+		assert.Equal(t, uint(2), receiveLog(t, chA1).Index)
+		assert.Equal(t, uint(2), receiveLog(t, chA1Topic).Index)
+
+		assert.Len(t, chA1, 0)
+		assert.Len(t, chA2, 0)
+	})
+
+	t.Run("delivers logs from both subscriptions while they overlap, but only once", func(t *testing.T) {
+		log := types.Log{Address: a1, BlockHash: cltest.NewHash(), Index: 3}
+		logs1 <- log
+		logs2 <- log
+		logs2 <- types.Log{Address: a1, BlockHash: cltest.NewHash(), Index: 4}
+
+		assert.Equal(t, uint(3), receiveLog(t, chA1).Index)
+		assert.Equal(t, uint(4), receiveLog(t, chA1).Index)
+	})
+
+	select {
+	case <-unsubscribed1:
+	case <-time.After(15 * time.Second):
+		t.Fatal("timed out waiting for the replaced subscription to be closed")
+	}
+	ethClient.AssertExpectations(t)
+}
+
+func TestMultiplexedClient_SubscribeFilterLogs_SeparateSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	a1, a2 := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	t1, t2 := cltest.NewHash(), cltest.NewHash()
+	anyAddress := ethereum.FilterQuery{Topics: [][]common.Hash{{t1}}}
+	anyTopic := ethereum.FilterQuery{Addresses: []common.Address{a1}, Topics: [][]common.Hash{{t2}}}
+
+	ethClient := new(mocks.Client)
+	upstream1, _, unsubscribed1 := mockUpstream()
+	var logs1 chan<- types.Log
+	ethClient.On("SubscribeFilterLogs", mock.Anything, anyAddress, mock.Anything).
+		Run(func(args mock.Arguments) { logs1 = args.Get(2).(chan<- types.Log) }).
+		Return(upstream1, nil).
+		Once()
+	upstream2, _, unsubscribed2 := mockUpstream()
+	var logs2 chan<- types.Log
+	ethClient.On("SubscribeFilterLogs", mock.Anything, anyTopic, mock.Anything).
+		Run(func(args mock.Arguments) { logs2 = args.Get(2).(chan<- types.Log) }).
+		Return(upstream2, nil).
+		Once()
+
+	client := eth.NewMultiplexedClient(ethClient)
+	ch1, ch2 := make(chan types.Log, 10), make(chan types.Log, 10)
+	// Their union would match logs from a2 with t2, which neither asked for,
+	// so they get their own subscriptions
+	sub1, err := client.SubscribeFilterLogs(context.Background(), anyAddress, ch1)
+	require.NoError(t, err)
+	sub2, err := client.SubscribeFilterLogs(context.Background(), anyTopic, ch2)
+	require.NoError(t, err)
+
+	logs1 <- types.Log{Address: a2, BlockHash: cltest.NewHash(), Index: 1, Topics: []common.Hash{t1}}
+	assert.Equal(t, uint(1), receiveLog(t, ch1).Index)
+	logs2 <- types.Log{Address: a1, BlockHash: cltest.NewHash(), Index: 2, Topics: []common.Hash{t2}}
+	assert.Equal(t, uint(2), receiveLog(t, ch2).Index)
+	assert.Len(t, ch1, 0)
+	assert.Len(t, ch2, 0)
+
+	sub1.Unsubscribe()
+	<-unsubscribed1
+	sub2.Unsubscribe()
+	<-unsubscribed2
+	ethClient.AssertExpectations(t)
+}
@@ -0,0 +1,122 @@
+package eth
+
+import (
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// readCache holds the results of idempotent reads made while the chain's head
+// is a given block, so that the many delegates that make the same reads each
+// head only cost one RPC call between them. It is keyed by the hash of the
+// head: every entry is dropped when a new head arrives, including on a
+// re-org, and entries also expire after a TTL, in case heads stop arriving.
+// Nothing is cached until the first head arrives, nor is a read during which
+// a new head arrived. A nil readCache caches nothing.
+type readCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	headHash common.Hash
+	entries  map[string]readCacheEntry
+}
+
+type readCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{ttl: ttl, entries: make(map[string]readCacheEntry)}
+}
+
+// setHead drops the entries of the previous head if hash is a new head
+func (c *readCache) setHead(hash common.Hash) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hash == c.headHash {
+		return
+	}
+	c.headHash = hash
+	c.entries = make(map[string]readCacheEntry)
+}
+
+func (c *readCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, exists := c.entries[key]
+	if !exists || c.headHash == (common.Hash{}) || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// head returns the hash of the current head. Reads take it before their RPC
+// call and give it to set, so that a result read under a previous head is
+// never cached under a new one.
+func (c *readCache) head() common.Hash {
+	if c == nil {
+		return common.Hash{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.headHash
+}
+
+// set caches value, unless the head has changed from the given one since the
+// value was read
+func (c *readCache) set(key string, value interface{}, head common.Hash) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.headHash == (common.Hash{}) || c.headHash != head {
+		return
+	}
+	c.entries[key] = readCacheEntry{value, time.Now().Add(c.ttl)}
+}
+
+// headSubscription forwards the heads of a newHeads subscription, telling
+// the read cache about each one before it is delivered
+type headSubscription struct {
+	ethereum.Subscription
+	chStop   chan struct{}
+	stopOnce sync.Once
+}
+
+func newHeadSubscription(sub ethereum.Subscription, cache *readCache, in <-chan *models.Head, out chan<- *models.Head) *headSubscription {
+	s := &headSubscription{Subscription: sub, chStop: make(chan struct{})}
+	go func() {
+		for {
+			select {
+			case head := <-in:
+				if head != nil {
+					cache.setHead(head.Hash)
+				}
+				select {
+				case out <- head:
+				case <-s.chStop:
+					return
+				}
+			case <-s.chStop:
+				return
+			}
+		}
+	}()
+	return s
+}
+
+func (s *headSubscription) Unsubscribe() {
+	s.Subscription.Unsubscribe()
+	s.stopOnce.Do(func() { close(s.chStop) })
+}
@@ -0,0 +1,111 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthClient_ReadCache(t *testing.T) {
+	t.Parallel()
+
+	rpcClient := new(mocks.RPCClient)
+	gethClient := new(mocks.GethClient)
+	sub := new(mocks.Subscription)
+	ethClient := eth.NewClientWith(rpcClient, gethClient)
+	ethClient.EnableReadCache(time.Minute)
+
+	var heads chan *models.Head
+	rpcClient.On("EthSubscribe", mock.Anything, mock.Anything, "newHeads").
+		Run(func(args mock.Arguments) { heads = args.Get(1).(chan *models.Head) }).
+		Return(sub, nil)
+	sub.On("Unsubscribe").Return()
+
+	ch := make(chan *models.Head)
+	s, err := ethClient.SubscribeNewHead(context.Background(), ch)
+	require.NoError(t, err)
+	defer s.Unsubscribe()
+
+	newHead := func(n int64) {
+		heads <- &models.Head{Number: n, Hash: cltest.NewHash()}
+		<-ch
+	}
+
+	address := cltest.NewAddress()
+	code := []byte{1, 2, 3}
+	readAll := func() {
+		chainID, err := ethClient.ChainID(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(3), chainID)
+		c, err := ethClient.CodeAt(context.Background(), address, nil)
+		require.NoError(t, err)
+		assert.Equal(t, code, c)
+	}
+
+	t.Run("caches nothing until the first head", func(t *testing.T) {
+		gethClient.On("ChainID", mock.Anything).Return(big.NewInt(3), nil).Twice()
+		gethClient.On("CodeAt", mock.Anything, address, (*big.Int)(nil)).Return(code, nil).Twice()
+
+		readAll()
+		readAll()
+
+		gethClient.AssertExpectations(t)
+	})
+
+	t.Run("caches reads until the next head", func(t *testing.T) {
+		newHead(1)
+		gethClient.On("ChainID", mock.Anything).Return(big.NewInt(3), nil).Once()
+		gethClient.On("CodeAt", mock.Anything, address, (*big.Int)(nil)).Return(code, nil).Once()
+
+		readAll()
+		readAll()
+		gethClient.AssertExpectations(t)
+
+		newHead(2)
+		gethClient.On("ChainID", mock.Anything).Return(big.NewInt(3), nil).Once()
+		gethClient.On("CodeAt", mock.Anything, address, (*big.Int)(nil)).Return(code, nil).Once()
+
+		readAll()
+		readAll()
+		gethClient.AssertExpectations(t)
+	})
+
+	t.Run("does not cache accounts without code", func(t *testing.T) {
+		eoa := cltest.NewAddress()
+		gethClient.On("CodeAt", mock.Anything, eoa, (*big.Int)(nil)).Return([]byte{}, nil).Twice()
+
+		_, err := ethClient.CodeAt(context.Background(), eoa, nil)
+		require.NoError(t, err)
+		_, err = ethClient.CodeAt(context.Background(), eoa, nil)
+		require.NoError(t, err)
+
+		gethClient.AssertExpectations(t)
+	})
+	t.Run("does not cache reads that span a new head", func(t *testing.T) {
+		// The head changes while the RPC call is in flight, so its result
+		// may be of the previous head
+		newHead(3)
+		gethClient.On("CodeAt", mock.Anything, address, (*big.Int)(nil)).
+			Run(func(mock.Arguments) { newHead(4) }).
+			Return(code, nil).Once()
+		_, err := ethClient.CodeAt(context.Background(), address, nil)
+		require.NoError(t, err)
+
+		gethClient.On("CodeAt", mock.Anything, address, (*big.Int)(nil)).Return(code, nil).Once()
+		_, err = ethClient.CodeAt(context.Background(), address, nil)
+		require.NoError(t, err)
+		_, err = ethClient.CodeAt(context.Background(), address, nil)
+		require.NoError(t, err)
+
+		gethClient.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,65 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownRevertData is returned by DecodeRevertData for revert data that
+// is neither a standard Error(string) nor a Panic(uint256)
+var ErrUnknownRevertData = errors.New("unknown revert data")
+
+var (
+	errorStringSelector = [4]byte{0x08, 0xc3, 0x79, 0xa0}
+	panicSelector       = [4]byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// panicReasons describes the codes of the Panic(uint256) errors raised by
+// solidity, e.g. on a failed assert
+var panicReasons = map[uint64]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized internal function",
+}
+
+// DecodeRevertData decodes the data returned by a reverted call into a human
+// readable reason. It understands standard Error(string) reverts and
+// Panic(uint256) errors.
+func DecodeRevertData(data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", errors.Wrapf(ErrUnknownRevertData, "%s is too short", hexutil.Encode(data))
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+
+	switch selector {
+	case errorStringSelector:
+		return DecodeRevertReason(data)
+	case panicSelector:
+		return decodePanic(data[4:])
+	}
+
+	return "", errors.Wrapf(ErrUnknownRevertData, "no error with selector %s", hexutil.Encode(selector[:]))
+}
+
+func decodePanic(data []byte) (string, error) {
+	if len(data) != 32 {
+		return "", errors.Wrapf(ErrUnknownRevertData, "invalid Panic(uint256) data %s", hexutil.Encode(data))
+	}
+	code := new(big.Int).SetBytes(data)
+	if code.IsUint64() {
+		if reason, exists := panicReasons[code.Uint64()]; exists {
+			return fmt.Sprintf("panic: %s (0x%x)", reason, code), nil
+		}
+	}
+	return fmt.Sprintf("panic: 0x%x", code), nil
+}
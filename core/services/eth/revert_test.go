@@ -0,0 +1,45 @@
+package eth_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestDecodeRevertData(t *testing.T) {
+	t.Parallel()
+
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	require.NoError(t, err)
+
+	t.Run("Error(string)", func(t *testing.T) {
+		reason, err := eth.DecodeRevertData(mustRevertData(t, "Must have LINK"))
+		require.NoError(t, err)
+		assert.Equal(t, "Must have LINK", reason)
+	})
+
+	t.Run("Panic(uint256)", func(t *testing.T) {
+		packed, err := abi.Arguments{{Type: uint256Type}}.Pack(big.NewInt(0x11))
+		require.NoError(t, err)
+		reason, err := eth.DecodeRevertData(append(hexutil.MustDecode("0x4e487b71"), packed...))
+		require.NoError(t, err)
+		assert.Equal(t, "panic: arithmetic overflow or underflow (0x11)", reason)
+	})
+
+	t.Run("unknown selector", func(t *testing.T) {
+		_, err := eth.DecodeRevertData(hexutil.MustDecode("0xdeadbeef"))
+		assert.Equal(t, eth.ErrUnknownRevertData, errors.Cause(err))
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		_, err := eth.DecodeRevertData([]byte{1, 2})
+		assert.Equal(t, eth.ErrUnknownRevertData, errors.Cause(err))
+	})
+}
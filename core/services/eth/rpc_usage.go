@@ -0,0 +1,62 @@
+package eth
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var promEthRPCCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "eth_rpc_calls_total",
+	Help: "The number of calls made to eth RPC endpoints, by method and endpoint",
+}, []string{"method", "endpoint"})
+
+// RPCCallKey identifies the calls made with one RPC method to one endpoint.
+// Endpoint is the host of the endpoint's URL, which leaves out any API key in
+// its path or query.
+type RPCCallKey struct {
+	Method   string
+	Endpoint string
+}
+
+var rpcCalls = struct {
+	sync.Mutex
+	counts map[RPCCallKey]uint64
+}{counts: make(map[RPCCallKey]uint64)}
+
+// TakeRPCCalls returns the number of calls made with each method to each
+// endpoint since it was last called, and resets them. Every call in a batch is
+// counted, as providers count them towards their quotas.
+func TakeRPCCalls() map[RPCCallKey]uint64 {
+	rpcCalls.Lock()
+	defer rpcCalls.Unlock()
+	counts := rpcCalls.counts
+	rpcCalls.counts = make(map[RPCCallKey]uint64)
+	return counts
+}
+
+func recordRPCCalls(endpoint, method string, n int) {
+	if n <= 0 {
+		return
+	}
+	promEthRPCCalls.WithLabelValues(method, endpoint).Add(float64(n))
+	rpcCalls.Lock()
+	defer rpcCalls.Unlock()
+	rpcCalls.counts[RPCCallKey{method, endpoint}] += uint64(n)
+}
+
+func recordRPCCall(endpoint, method string) {
+	recordRPCCalls(endpoint, method, 1)
+}
+
+func recordBatchCalls(endpoint string, b []rpc.BatchElem) {
+	counts := make(map[string]int)
+	for _, elem := range b {
+		counts[elem.Method]++
+	}
+	for method, n := range counts {
+		recordRPCCalls(endpoint, method, n)
+	}
+}
@@ -0,0 +1,93 @@
+package eth
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Block tags that transactions can be simulated at
+const (
+	BlockTagLatest  = "latest"
+	BlockTagPending = "pending"
+)
+
+// SimulationResult is the outcome of simulating a transaction with eth_call
+type SimulationResult struct {
+	BlockTag   string
+	Success    bool
+	ReturnData []byte
+	// Error is the error returned by the eth node, if the call failed
+	Error string
+	// RevertReason is decoded from the revert data of a failed call, see
+	// DecodeRevertData
+	RevertReason string
+}
+
+// SimulateCall simulates msg as a transaction with eth_call against the state
+// at blockTag
+func SimulateCall(ctx context.Context, client Client, msg ethereum.CallMsg, blockTag string) SimulationResult {
+	result := SimulationResult{BlockTag: blockTag}
+	var returnData hexutil.Bytes
+	err := client.CallContext(ctx, &returnData, "eth_call", toCallArg(msg), blockTag)
+	if err != nil {
+		result.Error = err.Error()
+		result.RevertReason, _ = ExtractRevertReason(err)
+		return result
+	}
+	result.Success = true
+	result.ReturnData = returnData
+	return result
+}
+
+// ExtractRevertReason returns the revert reason carried by the error of an
+// eth_call, if the eth node returned the revert data along with the error and
+// it can be decoded by DecodeRevertData
+func ExtractRevertReason(err error) (string, bool) {
+	dataErr, ok := err.(rpc.DataError)
+	if !ok {
+		return "", false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return "", false
+	}
+	data, err := hexutil.Decode(hexData)
+	if err != nil {
+		return "", false
+	}
+	reason, err := DecodeRevertData(data)
+	if err != nil {
+		return "", false
+	}
+	return reason, true
+}
+
+// DecodeRevertReason decodes the revert data of a contract that reverted with
+// a standard Error(string), e.g. from require(condition, "reason")
+func DecodeRevertReason(data []byte) (string, error) {
+	return abi.UnpackRevert(data)
+}
+
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
@@ -0,0 +1,73 @@
+package eth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// revertError is an eth_call error carrying revert data, like those returned
+// by geth
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string          { return "execution reverted" }
+func (e revertError) ErrorCode() int         { return 3 }
+func (e revertError) ErrorData() interface{} { return e.data }
+
+func mustRevertData(t *testing.T, reason string) []byte {
+	t.Helper()
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return append(hexutil.MustDecode("0x08c379a0"), packed...)
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	t.Parallel()
+
+	reason, err := eth.DecodeRevertReason(mustRevertData(t, "Must have LINK"))
+	require.NoError(t, err)
+	assert.Equal(t, "Must have LINK", reason)
+
+	_, err = eth.DecodeRevertReason([]byte{1, 2, 3, 4})
+	assert.Error(t, err)
+}
+
+func TestSimulateCall(t *testing.T) {
+	t.Parallel()
+
+	to := common.HexToAddress("0x3cCad4715152693fE3BC4460591e3D3Fbd071b42")
+	msg := ethereum.CallMsg{To: &to, Data: []byte{1}}
+
+	rpcClient := new(mocks.RPCClient)
+	ethClient := eth.NewClientWith(rpcClient, new(mocks.GethClient))
+	rpcClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.Anything, eth.BlockTagLatest).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*hexutil.Bytes) = hexutil.Bytes{0xab}
+		}).Return(nil).Once()
+	rpcClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.Anything, eth.BlockTagPending).
+		Return(revertError{hexutil.Encode(mustRevertData(t, "Must have LINK"))}).Once()
+
+	latest := eth.SimulateCall(context.Background(), ethClient, msg, eth.BlockTagLatest)
+	assert.True(t, latest.Success)
+	assert.Equal(t, []byte{0xab}, latest.ReturnData)
+
+	pending := eth.SimulateCall(context.Background(), ethClient, msg, eth.BlockTagPending)
+	assert.False(t, pending.Success)
+	assert.Equal(t, "execution reverted", pending.Error)
+	assert.Equal(t, "Must have LINK", pending.RevertReason)
+	rpcClient.AssertExpectations(t)
+}
@@ -0,0 +1,118 @@
+// Package eventbus is an in-process bus of the node's lifecycle events, such
+// as jobs being created and runs finishing. Subsystems subscribe to the
+// topics they care about instead of polling the database for changes, and
+// external services can follow the events over the GET /v2/events stream.
+//
+// Delivery is best effort: the bus never blocks a publisher, so a subscriber
+// that falls more than subscriptionBufferSize events behind misses events.
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// subscriptionBufferSize is how many events a subscriber may fall behind by
+// before it misses events
+const subscriptionBufferSize = 100
+
+var promEventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "event_bus_events_dropped",
+	Help: "The number of events not delivered to a subscriber because it had fallen behind",
+},
+	[]string{"topic"},
+)
+
+// Bus fans events out to subscribers. Publish may be called on a nil Bus,
+// which drops every event.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+// NewBus returns a Bus without subscribers
+func NewBus() *Bus {
+	return &Bus{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe returns a subscription to events of the given topics, or to all
+// events if no topics are given. It must be closed once it is no longer read.
+func (b *Bus) Subscribe(topics ...Topic) *Subscription {
+	s := &Subscription{
+		bus: b,
+		ch:  make(chan Event, subscriptionBufferSize),
+	}
+	if len(topics) > 0 {
+		s.topics = make(map[Topic]struct{}, len(topics))
+		for _, topic := range topics {
+			s.topics[topic] = struct{}{}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[s] = struct{}{}
+	return s
+}
+
+// Publish delivers e to every subscriber of its topic without waiting on
+// any of them
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	topic := e.Topic()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subs {
+		if !s.wants(topic) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			promEventsDropped.WithLabelValues(string(topic)).Inc()
+			logger.Warnw("Event bus: subscriber has fallen behind, dropped event", "topic", topic)
+		}
+	}
+}
+
+func (b *Bus) unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, exists := b.subs[s]; exists {
+		delete(b.subs, s)
+		close(s.ch)
+	}
+}
+
+// Subscription receives the events of the topics it was made for
+type Subscription struct {
+	bus    *Bus
+	topics map[Topic]struct{}
+	ch     chan Event
+}
+
+// Events returns the channel the events are received on. It is closed once
+// the subscription is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Close stops the subscription. It may be called more than once.
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+}
+
+func (s *Subscription) wants(topic Topic) bool {
+	if s.topics == nil {
+		return true
+	}
+	_, exists := s.topics[topic]
+	return exists
+}
@@ -0,0 +1,69 @@
+package eventbus_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+)
+
+func TestBus(t *testing.T) {
+	t.Parallel()
+
+	bus := eventbus.NewBus()
+	all := bus.Subscribe()
+	defer all.Close()
+	jobs := bus.Subscribe(eventbus.TopicJobCreated, eventbus.TopicJobDeleted)
+	defer jobs.Close()
+
+	bus.Publish(eventbus.JobCreated{JobID: "1", Type: "cron"})
+	bus.Publish(eventbus.RunFinished{JobID: "1", RunID: "2"})
+	bus.Publish(eventbus.JobDeleted{JobID: "1"})
+
+	require.Len(t, all.Events(), 3)
+	assert.Equal(t, eventbus.JobCreated{JobID: "1", Type: "cron"}, <-all.Events())
+	assert.Equal(t, eventbus.RunFinished{JobID: "1", RunID: "2"}, <-all.Events())
+	assert.Equal(t, eventbus.JobDeleted{JobID: "1"}, <-all.Events())
+
+	require.Len(t, jobs.Events(), 2)
+	assert.Equal(t, eventbus.TopicJobCreated, (<-jobs.Events()).Topic())
+	assert.Equal(t, eventbus.TopicJobDeleted, (<-jobs.Events()).Topic())
+
+	t.Run("drops events for subscribers that have fallen behind", func(t *testing.T) {
+		behind := bus.Subscribe(eventbus.TopicTxConfirmed)
+		defer behind.Close()
+		for i := 0; i <= cap(behind.Events()); i++ {
+			bus.Publish(eventbus.TxConfirmed{EthTxID: int64(i)})
+		}
+		assert.Len(t, behind.Events(), cap(behind.Events()))
+		assert.Equal(t, int64(0), (<-behind.Events()).(eventbus.TxConfirmed).EthTxID)
+	})
+
+	t.Run("closes the channel of closed subscriptions", func(t *testing.T) {
+		sub := bus.Subscribe()
+		sub.Close()
+		sub.Close()
+		_, ok := <-sub.Events()
+		assert.False(t, ok)
+		bus.Publish(eventbus.JobCreated{})
+	})
+}
+
+func TestBus_Nil(t *testing.T) {
+	var bus *eventbus.Bus
+	bus.Publish(eventbus.JobCreated{})
+}
+
+func TestParseTopic(t *testing.T) {
+	t.Parallel()
+
+	for _, topic := range eventbus.Topics {
+		parsed, err := eventbus.ParseTopic(string(topic))
+		require.NoError(t, err)
+		assert.Equal(t, topic, parsed)
+	}
+	_, err := eventbus.ParseTopic("job_errored")
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package eventbus
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+)
+
+// Topic is the kind of an event
+type Topic string
+
+const (
+	TopicJobCreated     Topic = "job_created"
+	TopicJobDeleted     Topic = "job_deleted"
+	TopicRunFinished    Topic = "run_finished"
+	TopicTxConfirmed    Topic = "tx_confirmed"
+	TopicTxFailed       Topic = "tx_failed"
+	TopicKeyGenerated   Topic = "key_generated"
+	TopicBalanceChanged Topic = "balance_changed"
+	TopicExternalTx     Topic = "external_tx"
+	TopicRPCQuota       Topic = "rpc_quota"
+)
+
+// Topics are all the topics events are published on
+var Topics = []Topic{
+	TopicJobCreated,
+	TopicJobDeleted,
+	TopicRunFinished,
+	TopicTxConfirmed,
+	TopicTxFailed,
+	TopicKeyGenerated,
+	TopicBalanceChanged,
+	TopicExternalTx,
+	TopicRPCQuota,
+}
+
+// ParseTopic returns the topic named s
+func ParseTopic(s string) (Topic, error) {
+	for _, topic := range Topics {
+		if string(topic) == s {
+			return topic, nil
+		}
+	}
+	return "", errors.Errorf("unknown event topic %q", s)
+}
+
+// Event is published on the bus
+type Event interface {
+	Topic() Topic
+}
+
+// JobCreated is published when a job is created. JobID is the UUID of v1
+// jobs and the integer ID of v2 jobs. Type is the type of v2 jobs, and the
+// type of the first initiator of v1 jobs.
+type JobCreated struct {
+	JobID string `json:"jobID"`
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type"`
+}
+
+func (JobCreated) Topic() Topic { return TopicJobCreated }
+
+// JobDeleted is published when a job is deleted, or archived so that it no
+// longer runs
+type JobDeleted struct {
+	JobID    string `json:"jobID"`
+	Archived bool   `json:"archived"`
+}
+
+func (JobDeleted) Topic() Topic { return TopicJobDeleted }
+
+// RunFinished is published when a run has finished and been saved. RunID is
+// the UUID of v1 job runs and the integer ID of pipeline runs.
+type RunFinished struct {
+	JobID   string `json:"jobID"`
+	RunID   string `json:"runID"`
+	Errored bool   `json:"errored"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (RunFinished) Topic() Topic { return TopicRunFinished }
+
+// TxConfirmed is published when the receipt of a transaction sent by the
+// node has been saved
+type TxConfirmed struct {
+	EthTxID     int64          `json:"ethTxID"`
+	From        common.Address `json:"from"`
+	Hash        common.Hash    `json:"hash"`
+	BlockNumber int64          `json:"blockNumber"`
+}
+
+func (TxConfirmed) Topic() Topic { return TopicTxConfirmed }
+
+// TxFailed is published when a transaction sent by the node could not be
+// sent at all, or was mined but reverted
+type TxFailed struct {
+	EthTxID int64          `json:"ethTxID"`
+	From    common.Address `json:"from"`
+	// Hash is only set for reverted transactions
+	Hash     common.Hash `json:"hash,omitempty"`
+	Error    string      `json:"error"`
+	Reverted bool        `json:"reverted"`
+}
+
+func (TxFailed) Topic() Topic { return TopicTxFailed }
+
+// KeyType is the kind of a key generated by the node
+type KeyType string
+
+const (
+	KeyTypeETH KeyType = "eth"
+	KeyTypeOCR KeyType = "ocr"
+	KeyTypeP2P KeyType = "p2p"
+)
+
+// KeyGenerated is published when a key is generated through the API. ID is
+// the address of ETH keys, the bundle ID of OCR keys and the peer ID of P2P
+// keys.
+type KeyGenerated struct {
+	Type KeyType `json:"type"`
+	ID   string  `json:"id"`
+}
+
+func (KeyGenerated) Topic() Topic { return TopicKeyGenerated }
+
+// BalanceChanged is published when the balance monitor first sees the ETH
+// balance of a key, and whenever it changes
+type BalanceChanged struct {
+	Address common.Address `json:"address"`
+	Balance *assets.Eth    `json:"balance"`
+}
+
+func (BalanceChanged) Topic() Topic { return TopicBalanceChanged }
+
+// ExternalTx is published when a transaction from one of the node's sending
+// keys that the node didn't send is mined. ReplacedEthTxID is the eth_tx of
+// the node that used the same nonce, if any, which can no longer be mined.
+type ExternalTx struct {
+	From            common.Address `json:"from"`
+	Hash            common.Hash    `json:"hash"`
+	Nonce           uint64         `json:"nonce"`
+	BlockNumber     int64          `json:"blockNumber"`
+	ReplacedEthTxID int64          `json:"replacedEthTxID,omitempty"`
+}
+
+func (ExternalTx) Topic() Topic { return TopicExternalTx }
+
+// RPCQuotaProjected is published, at most once a day, while the eth RPC calls
+// made so far this month are projected to exceed ETH_RPC_MONTHLY_QUOTA by the
+// end of the month. Month is formatted as 2006-01.
+type RPCQuotaProjected struct {
+	Month       string `json:"month"`
+	MonthToDate int64  `json:"monthToDate"`
+	Projected   int64  `json:"projected"`
+	Quota       uint64 `json:"quota"`
+}
+
+func (RPCQuotaProjected) Topic() Topic { return TopicRPCQuota }
@@ -362,15 +362,15 @@ func ExtractFeedURLs(feeds models.Feeds, orm *orm.ORM) ([]*url.URL, error) {
 	return urls, nil
 }
 
-// GetBridgeURLFromName looks up a bridge in the DB by name, then extracts the url
+// GetBridgeURLFromName looks up a bridge in the DB by name, then extracts the
+// url with the values of the environment variables it references
 func GetBridgeURLFromName(name string, orm *orm.ORM) (*url.URL, error) {
 	task := models.TaskType(name)
 	bridge, err := orm.FindBridge(task)
 	if err != nil {
 		return nil, err
 	}
-	bridgeURL := url.URL(bridge.URL)
-	return &bridgeURL, nil
+	return bridge.URL.WithEnv()
 }
 
 // DeviationChecker encapsulate methods needed to initialize and check prices
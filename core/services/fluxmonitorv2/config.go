@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/job"
 )
 
 // Config defines the Flux Monitor configuration.
@@ -13,6 +15,13 @@ type Config struct {
 	MinContractPayment         *assets.Link
 	EthGasLimit                uint64
 	MaxUnconfirmedTransactions uint64
+	// GasPrices is the gas price checked against jobs' maxGasCostWei
+	GasPrices job.GasPriceSource
+	// GasCosts estimates the cost of submissions at that gas price
+	GasCosts eth.GasCostEstimator
+	// WarmupTimeout bounds the warmup run executed as each job starts. Zero
+	// disables warmup runs.
+	WarmupTimeout time.Duration
 }
 
 // MinimumPollingInterval returns the minimum duration between polling ticks
@@ -1,11 +1,15 @@
 package fluxmonitorv2
 
 import (
+	"context"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/flux_aggregator_wrapper"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/job"
 )
 
 //go:generate mockery --name ContractSubmitter --output ./mocks/ --case=underscore
@@ -21,6 +25,7 @@ type ContractSubmitter interface {
 // FluxAggregatorContractSubmitter submits the polled answer in an eth tx.
 type FluxAggregatorContractSubmitter struct {
 	flux_aggregator_wrapper.FluxAggregatorInterface
+	jobID                      int32
 	orm                        ORM
 	keyStore                   KeyStoreInterface
 	gasLimit                   uint64
@@ -28,7 +33,9 @@ type FluxAggregatorContractSubmitter struct {
 }
 
 // NewFluxAggregatorContractSubmitter constructs a new NewFluxAggregatorContractSubmitter
+// for the job with jobID
 func NewFluxAggregatorContractSubmitter(
+	jobID int32,
 	contract flux_aggregator_wrapper.FluxAggregatorInterface,
 	orm ORM,
 	keyStore KeyStoreInterface,
@@ -37,6 +44,7 @@ func NewFluxAggregatorContractSubmitter(
 ) *FluxAggregatorContractSubmitter {
 	return &FluxAggregatorContractSubmitter{
 		FluxAggregatorInterface:    contract,
+		jobID:                      jobID,
 		orm:                        orm,
 		keyStore:                   keyStore,
 		gasLimit:                   gasLimit,
@@ -58,7 +66,39 @@ func (c *FluxAggregatorContractSubmitter) Submit(roundID *big.Int, submission *b
 	}
 
 	return errors.Wrap(
-		c.orm.CreateEthTransaction(fromAddress, c.Address(), payload, c.gasLimit, c.maxUnconfirmedTransactions),
+		c.orm.CreateEthTransaction(c.jobID, fromAddress, c.Address(), payload, c.gasLimit, c.maxUnconfirmedTransactions),
 		"failed to send Eth transaction",
 	)
 }
+
+type gasCostGuardedSubmitter struct {
+	ContractSubmitter
+	guard           *job.GasCostGuard
+	contractAddress common.Address
+	gasLimit        uint64
+}
+
+// NewGasCostGuardedSubmitter wraps a submitter so that submissions fail
+// without sending a transaction while their estimated cost exceeds the job's
+// maxGasCostWei. The round is then retried on the next poll.
+func NewGasCostGuardedSubmitter(submitter ContractSubmitter, guard *job.GasCostGuard, contractAddress common.Address, gasLimit uint64) ContractSubmitter {
+	return &gasCostGuardedSubmitter{
+		ContractSubmitter: submitter,
+		guard:             guard,
+		contractAddress:   contractAddress,
+		gasLimit:          gasLimit,
+	}
+}
+
+// Submit checks the estimated gas cost before submitting the answer
+func (s *gasCostGuardedSubmitter) Submit(roundID *big.Int, submission *big.Int) error {
+	payload, err := FluxAggregatorABI.Pack("submit", roundID, submission)
+	if err != nil {
+		return errors.Wrap(err, "abi.Pack failed")
+	}
+	msg := ethereum.CallMsg{To: &s.contractAddress, Gas: s.gasLimit, Data: payload}
+	if err := s.guard.Check(context.Background(), msg); err != nil {
+		return err
+	}
+	return s.ContractSubmitter.Submit(roundID, submission)
+}
@@ -50,6 +50,11 @@ func (d *Delegate) JobType() job.Type {
 	return job.FluxMonitor
 }
 
+// Dependencies implements the job.DelegateWithDependencies interface
+func (d *Delegate) Dependencies() []string {
+	return []string{job.DependencyPipelineRunner, job.DependencyLogBroadcaster}
+}
+
 // ServicesForSpec returns the flux monitor service for the job spec
 func (d *Delegate) ServicesForSpec(spec job.Job) (services []job.Service, err error) {
 	if spec.FluxMonitorSpec == nil {
@@ -71,5 +76,8 @@ func (d *Delegate) ServicesForSpec(spec job.Job) (services []job.Service, err er
 		return nil, err
 	}
 
+	if d.cfg.WarmupTimeout > 0 {
+		return []job.Service{job.NewWarmup(spec, d.pipelineRunner, d.jobORM, d.cfg.WarmupTimeout), fm}, nil
+	}
 	return []job.Service{fm}, nil
 }
@@ -144,13 +144,22 @@ func NewFromJobSpec(
 		return nil, err
 	}
 
-	contractSubmitter := NewFluxAggregatorContractSubmitter(
+	var contractSubmitter ContractSubmitter = NewFluxAggregatorContractSubmitter(
+		jobSpec.ID,
 		fluxAggregator,
 		orm,
 		keyStore,
 		cfg.EthGasLimit,
 		cfg.MaxUnconfirmedTransactions,
 	)
+	if jobSpec.MaxGasCostWei != nil {
+		contractSubmitter = NewGasCostGuardedSubmitter(
+			contractSubmitter,
+			job.NewGasCostGuard(jobSpec, cfg.GasPrices, cfg.GasCosts, jobORM),
+			fmSpec.ContractAddress.Address(),
+			cfg.EthGasLimit,
+		)
+	}
 
 	flags, err := NewFlags(cfg.FlagsContractAddress, ethClient)
 	logger.ErrorIf(
@@ -13,13 +13,13 @@ type ORM struct {
 	mock.Mock
 }
 
-// CreateEthTransaction provides a mock function with given fields: fromAddress, toAddress, payload, gasLimit, maxUnconfirmedTransactions
-func (_m *ORM) CreateEthTransaction(fromAddress common.Address, toAddress common.Address, payload []byte, gasLimit uint64, maxUnconfirmedTransactions uint64) error {
-	ret := _m.Called(fromAddress, toAddress, payload, gasLimit, maxUnconfirmedTransactions)
+// CreateEthTransaction provides a mock function with given fields: jobID, fromAddress, toAddress, payload, gasLimit, maxUnconfirmedTransactions
+func (_m *ORM) CreateEthTransaction(jobID int32, fromAddress common.Address, toAddress common.Address, payload []byte, gasLimit uint64, maxUnconfirmedTransactions uint64) error {
+	ret := _m.Called(jobID, fromAddress, toAddress, payload, gasLimit, maxUnconfirmedTransactions)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(common.Address, common.Address, []byte, uint64, uint64) error); ok {
-		r0 = rf(fromAddress, toAddress, payload, gasLimit, maxUnconfirmedTransactions)
+	if rf, ok := ret.Get(0).(func(int32, common.Address, common.Address, []byte, uint64, uint64) error); ok {
+		r0 = rf(jobID, fromAddress, toAddress, payload, gasLimit, maxUnconfirmedTransactions)
 	} else {
 		r0 = ret.Error(0)
 	}
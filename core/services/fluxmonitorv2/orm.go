@@ -19,7 +19,7 @@ type ORM interface {
 	DeleteFluxMonitorRoundsBackThrough(aggregator common.Address, roundID uint32) error
 	FindOrCreateFluxMonitorRoundStats(aggregator common.Address, roundID uint32) (FluxMonitorRoundStatsV2, error)
 	UpdateFluxMonitorRoundStats(aggregator common.Address, roundID uint32, runID int64) error
-	CreateEthTransaction(fromAddress, toAddress common.Address, payload []byte, gasLimit uint64, maxUnconfirmedTransactions uint64) error
+	CreateEthTransaction(jobID int32, fromAddress, toAddress common.Address, payload []byte, gasLimit uint64, maxUnconfirmedTransactions uint64) error
 }
 
 type orm struct {
@@ -91,8 +91,11 @@ func (o *orm) CountFluxMonitorRoundStats() (int, error) {
 	return int(count), err
 }
 
-// CreateEthTransaction creates an ethereum transaction for the BPTXM to pick up
+// CreateEthTransaction creates an ethereum transaction for the BPTXM to pick
+// up. The gas it spends is counted in the costs of the job with jobID, if it
+// is not 0.
 func (o *orm) CreateEthTransaction(
+	jobID int32,
 	fromAddress common.Address,
 	toAddress common.Address,
 	payload []byte,
@@ -112,8 +115,8 @@ func (o *orm) CreateEthTransaction(
 	value := 0
 
 	dbtx := o.db.Exec(`
-INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at)
-SELECT ?,?,?,?,?,'unstarted',NOW()
+INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, job_id, state, created_at)
+SELECT ?,?,?,?,?,NULLIF(?, 0),'unstarted',NOW()
 WHERE NOT EXISTS (
     SELECT 1 FROM eth_tx_attempts
 	JOIN eth_txes ON eth_txes.id = eth_tx_attempts.eth_tx_id
@@ -121,7 +124,7 @@ WHERE NOT EXISTS (
 		AND eth_txes.state = 'unconfirmed'
 		AND eth_tx_attempts.state = 'insufficient_eth'
 );
-`, fromAddress, toAddress, payload, value, gasLimit, fromAddress)
+`, fromAddress, toAddress, payload, value, gasLimit, jobID, fromAddress)
 	if dbtx.Error != nil {
 		return errors.Wrap(dbtx.Error, "failed to insert eth_tx")
 	}
@@ -84,7 +84,7 @@ func TestORM_UpdateFluxMonitorRoundStats(t *testing.T) {
 		corestore.Config.DatabaseListenerMinReconnectInterval(),
 		corestore.Config.DatabaseListenerMaxReconnectDuration(),
 	)
-	pipelineORM := pipeline.NewORM(corestore.ORM.DB, corestore.Config, eventBroadcaster)
+	pipelineORM := pipeline.NewORM(corestore.ORM.DB, corestore.Config, eventBroadcaster, nil)
 	// Instantiate a real job ORM because we need to create a job to satisfy
 	// a check in pipeline.CreateRun
 	jobORM := job.NewORM(corestore.ORM.DB, corestore.Config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
@@ -151,7 +151,7 @@ func TestORM_CreateEthTransaction(t *testing.T) {
 		gasLimit = uint64(21000)
 	)
 
-	orm.CreateEthTransaction(from, to, payload, gasLimit, 0)
+	orm.CreateEthTransaction(0, from, to, payload, gasLimit, 0)
 
 	etx := models.EthTx{}
 	require.NoError(t, corestore.ORM.DB.First(&etx).Error)
@@ -183,7 +183,7 @@ func TestORM_CreateEthTransaction_OutOfEth(t *testing.T) {
 	t.Run("if another key has any transactions with insufficient eth errors, transmits as normal", func(t *testing.T) {
 		cltest.MustInsertUnconfirmedEthTxWithInsufficientEthAttempt(t, corestore, 0, otherKey.Address.Address())
 
-		err := orm.CreateEthTransaction(from, to, payload, gasLimit, 0)
+		err := orm.CreateEthTransaction(0, from, to, payload, gasLimit, 0)
 		require.NoError(t, err)
 
 		etx := models.EthTx{}
@@ -196,7 +196,7 @@ func TestORM_CreateEthTransaction_OutOfEth(t *testing.T) {
 	t.Run("if this key has any transactions with insufficient eth errors, skips transmission entirely", func(t *testing.T) {
 		cltest.MustInsertUnconfirmedEthTxWithInsufficientEthAttempt(t, corestore, 0, from)
 
-		err := orm.CreateEthTransaction(from, to, payload, gasLimit, 0)
+		err := orm.CreateEthTransaction(0, from, to, payload, gasLimit, 0)
 		require.EqualError(t, err, fmt.Sprintf("Skipped Flux Monitor submission because wallet is out of eth: %s", from))
 	})
 
@@ -204,7 +204,7 @@ func TestORM_CreateEthTransaction_OutOfEth(t *testing.T) {
 		require.NoError(t, corestore.DB.Exec(`UPDATE eth_tx_attempts SET state = 'broadcast'`).Error)
 		require.NoError(t, corestore.DB.Exec(`UPDATE eth_txes SET nonce = 0, state = 'confirmed', broadcast_at = NOW()`).Error)
 
-		err := orm.CreateEthTransaction(from, to, payload, gasLimit, 0)
+		err := orm.CreateEthTransaction(0, from, to, payload, gasLimit, 0)
 		require.NoError(t, err)
 
 		etx := models.EthTx{}
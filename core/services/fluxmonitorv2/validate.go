@@ -19,6 +19,11 @@ func ValidatedFluxMonitorSpec(config *coreorm.Config, ts string) (job.Job, error
 	if err != nil {
 		return jb, err
 	}
+	if config.JobSpecStrictTOML() {
+		if err = job.CheckUnknownKeys(tree, job.FluxMonitor); err != nil {
+			return jb, err
+		}
+	}
 	err = tree.Unmarshal(&jb)
 	if err != nil {
 		return jb, err
@@ -23,4 +23,7 @@ type Config interface {
 	DatabaseURL() url.URL
 	TriggerFallbackDBPollInterval() time.Duration
 	JobPipelineParallelism() uint8
+	JobSpawnerClaimBatchSize() uint32
+	JobSpawnerMaxClaimedJobs() uint32
+	JobArchiveRetention() time.Duration
 }
@@ -0,0 +1,88 @@
+package job
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Names of the node-level services that job delegates may depend upon
+const (
+	DependencyPeerWrapper    = "PeerWrapper"
+	DependencyPipelineRunner = "PipelineRunner"
+	DependencyOCRPartitions  = "OCRPartitions"
+	DependencyLogBroadcaster = "LogBroadcaster"
+)
+
+type (
+	// Dependency is a long-running, node-level service that job services rely
+	// on (for example, the OCR peer wrapper). Dependencies are owned by the
+	// spawner: they are started before any job services and closed after all
+	// job services have been stopped.
+	Dependency struct {
+		Name      string
+		Service   Service
+		DependsOn []string
+	}
+
+	// DelegateWithDependencies may be implemented by a Delegate whose job
+	// services cannot be started until some dependencies are running.
+	DelegateWithDependencies interface {
+		Delegate
+		Dependencies() []string
+	}
+)
+
+// SortDependencies returns the given dependencies in an order in which they
+// can be safely started, i.e. every dependency appears after all of the
+// dependencies it names in DependsOn. Dependencies with no ordering constraint
+// between them keep the order in which they were given. They should be closed
+// in reverse order.
+func SortDependencies(deps []Dependency) ([]Dependency, error) {
+	byName := make(map[string]int, len(deps))
+	for i, dep := range deps {
+		if _, exists := byName[dep.Name]; exists {
+			return nil, errors.Errorf("dependency %s was registered more than once", dep.Name)
+		}
+		byName[dep.Name] = i
+	}
+
+	nPreds := make([]int, len(deps))
+	dependents := make([][]int, len(deps))
+	for i, dep := range deps {
+		for _, name := range dep.DependsOn {
+			j, exists := byName[name]
+			if !exists {
+				return nil, errors.Errorf("dependency %s depends on %s, which has not been registered", dep.Name, name)
+			}
+			nPreds[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	var ready []int
+	for i := range deps {
+		if nPreds[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	sorted := make([]Dependency, 0, len(deps))
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		i := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, deps[i])
+		for _, j := range dependents[i] {
+			nPreds[j]--
+			if nPreds[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	if len(sorted) != len(deps) {
+		return nil, errors.New("dependencies contain a cycle")
+	}
+	return sorted, nil
+}
@@ -0,0 +1,60 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+func TestSortDependencies(t *testing.T) {
+	names := func(deps []job.Dependency) (out []string) {
+		for _, dep := range deps {
+			out = append(out, dep.Name)
+		}
+		return
+	}
+
+	t.Run("orders dependencies after the ones they depend on", func(t *testing.T) {
+		sorted, err := job.SortDependencies([]job.Dependency{
+			{Name: "a", DependsOn: []string{"c"}},
+			{Name: "b"},
+			{Name: "c", DependsOn: []string{"b"}},
+			{Name: "d"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b", "c", "a", "d"}, names(sorted))
+	})
+
+	t.Run("keeps the registration order of unrelated dependencies", func(t *testing.T) {
+		sorted, err := job.SortDependencies([]job.Dependency{
+			{Name: job.DependencyPipelineRunner},
+			{Name: job.DependencyPeerWrapper},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{job.DependencyPipelineRunner, job.DependencyPeerWrapper}, names(sorted))
+	})
+
+	t.Run("errors on cycles", func(t *testing.T) {
+		_, err := job.SortDependencies([]job.Dependency{
+			{Name: "a", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cycle")
+	})
+
+	t.Run("errors on unknown dependencies", func(t *testing.T) {
+		_, err := job.SortDependencies([]job.Dependency{
+			{Name: "a", DependsOn: []string{"b"}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("errors on duplicate names", func(t *testing.T) {
+		_, err := job.SortDependencies([]job.Dependency{{Name: "a"}, {Name: "a"}})
+		require.Error(t, err)
+	})
+}
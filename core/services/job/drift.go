@@ -0,0 +1,94 @@
+package job
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+type (
+	// ExpectedSpec identifies a job spec that is expected to be running on
+	// the node, e.g. because it is checked in to a GitOps repository. Specs
+	// are matched to jobs by name; specs without a name are matched by
+	// checksum alone.
+	ExpectedSpec struct {
+		Name     string `json:"name"`
+		Checksum string `json:"checksum"`
+	}
+
+	// DriftedJob is a job whose spec no longer matches the expected spec of
+	// the same name
+	DriftedJob struct {
+		Job      Job
+		Expected ExpectedSpec
+	}
+
+	// DriftReport is the difference between the jobs on the node and a set
+	// of expected specs
+	DriftReport struct {
+		// Missing are the expected specs with no matching job
+		Missing []ExpectedSpec
+		// Extra are the jobs that don't match any expected spec
+		Extra []Job
+		// Drifted are the jobs whose name matches an expected spec but whose
+		// checksum does not
+		Drifted []DriftedJob
+	}
+)
+
+// SpecChecksum returns the checksum of a TOML job spec: the hex encoded
+// SHA-256 of its canonical JSON form (see SpecTOMLToJSON). Formatting, key
+// order and whether the spec was submitted as TOML or JSON don't affect the
+// checksum.
+func SpecChecksum(specTOML string) (string, error) {
+	canonical, err := SpecTOMLToJSON(specTOML)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DetectDrift compares the jobs on the node against the expected specs. Jobs
+// created before checksums were recorded have no checksum, and so are always
+// reported as drifted (or extra, if they are unnamed).
+func DetectDrift(jobs []Job, expected []ExpectedSpec) DriftReport {
+	var report DriftReport
+	byName := make(map[string]ExpectedSpec)
+	byChecksum := make(map[string]int)
+	for _, spec := range expected {
+		if spec.Name != "" {
+			byName[spec.Name] = spec
+		} else {
+			byChecksum[spec.Checksum]++
+		}
+	}
+
+	matchedNames := make(map[string]bool)
+	for _, jb := range jobs {
+		checksum := jb.SpecChecksum.ValueOrZero()
+		if spec, exists := byName[jb.Name.ValueOrZero()]; exists && jb.Name.Valid {
+			matchedNames[spec.Name] = true
+			if checksum == "" || checksum != spec.Checksum {
+				report.Drifted = append(report.Drifted, DriftedJob{Job: jb, Expected: spec})
+			}
+			continue
+		}
+		if checksum != "" && byChecksum[checksum] > 0 {
+			byChecksum[checksum]--
+			continue
+		}
+		report.Extra = append(report.Extra, jb)
+	}
+
+	for _, spec := range expected {
+		if spec.Name != "" {
+			if !matchedNames[spec.Name] {
+				report.Missing = append(report.Missing, spec)
+			}
+		} else if byChecksum[spec.Checksum] > 0 {
+			byChecksum[spec.Checksum]--
+			report.Missing = append(report.Missing, spec)
+		}
+	}
+	return report
+}
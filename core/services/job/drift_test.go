@@ -0,0 +1,61 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+func TestSpecChecksum(t *testing.T) {
+	a, err := job.SpecChecksum(`
+type = "keeper"
+schemaVersion = 1
+`)
+	require.NoError(t, err)
+	b, err := job.SpecChecksum(`schemaVersion=1
+# comments and key order don't matter
+type="keeper"`)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := job.SpecChecksum(`
+type = "keeper"
+schemaVersion = 2
+`)
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+}
+
+func TestDetectDrift(t *testing.T) {
+	jobs := []job.Job{
+		{IDEmbed: job.IDEmbed{ID: 1}, Name: null.StringFrom("in sync"), SpecChecksum: null.StringFrom("aa")},
+		{IDEmbed: job.IDEmbed{ID: 2}, Name: null.StringFrom("drifted"), SpecChecksum: null.StringFrom("bb")},
+		{IDEmbed: job.IDEmbed{ID: 3}, Name: null.StringFrom("no checksum")},
+		{IDEmbed: job.IDEmbed{ID: 4}, Name: null.StringFrom("extra"), SpecChecksum: null.StringFrom("cc")},
+		{IDEmbed: job.IDEmbed{ID: 5}, SpecChecksum: null.StringFrom("dd")},
+		{IDEmbed: job.IDEmbed{ID: 6}, SpecChecksum: null.StringFrom("ee")},
+	}
+	expected := []job.ExpectedSpec{
+		{Name: "in sync", Checksum: "aa"},
+		{Name: "drifted", Checksum: "b2"},
+		{Name: "no checksum", Checksum: "ff"},
+		{Name: "missing", Checksum: "00"},
+		{Checksum: "dd"},
+		{Checksum: "dd"},
+	}
+
+	report := job.DetectDrift(jobs, expected)
+
+	assert.Equal(t, []job.ExpectedSpec{{Name: "missing", Checksum: "00"}, {Checksum: "dd"}}, report.Missing)
+	require.Len(t, report.Extra, 2)
+	assert.Equal(t, int32(4), report.Extra[0].ID)
+	assert.Equal(t, int32(6), report.Extra[1].ID)
+	require.Len(t, report.Drifted, 2)
+	assert.Equal(t, int32(2), report.Drifted[0].Job.ID)
+	assert.Equal(t, "b2", report.Drifted[0].Expected.Checksum)
+	assert.Equal(t, int32(3), report.Drifted[1].Job.ID)
+}
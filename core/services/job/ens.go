@@ -0,0 +1,141 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// ContractAddress returns the address of the contract that the job watches or
+// reports to, if its type has one
+func (j Job) ContractAddress() (common.Address, bool) {
+	switch {
+	case j.OffchainreportingOracleSpec != nil:
+		return j.OffchainreportingOracleSpec.ContractAddress.Address(), true
+	case j.DirectRequestSpec != nil:
+		return j.DirectRequestSpec.ContractAddress.Address(), true
+	case j.FluxMonitorSpec != nil:
+		return j.FluxMonitorSpec.ContractAddress.Address(), true
+	case j.KeeperSpec != nil:
+		return j.KeeperSpec.ContractAddress.Address(), true
+	}
+	return common.Address{}, false
+}
+
+// ENSWatcher periodically re-resolves the ENS names that jobs' contract
+// addresses were given as. Jobs keep using the address that their name
+// resolved to when they were created; when a name starts resolving to a
+// different address, e.g. after a contract upgrade, a job error is recorded
+// so that the operator can recreate the job.
+type ENSWatcher struct {
+	utils.StartStopOnce
+
+	db        *gorm.DB
+	ethClient eth.GethClient
+	orm       ORM
+	interval  time.Duration
+	chStop    chan struct{}
+	chDone    chan struct{}
+}
+
+// NewENSWatcher returns a service that re-resolves jobs' ENS names every
+// interval. An interval of 0 disables it.
+func NewENSWatcher(db *gorm.DB, ethClient eth.GethClient, orm ORM, interval time.Duration) *ENSWatcher {
+	return &ENSWatcher{
+		db:        db,
+		ethClient: ethClient,
+		orm:       orm,
+		interval:  interval,
+		chStop:    make(chan struct{}),
+		chDone:    make(chan struct{}),
+	}
+}
+
+func (w *ENSWatcher) Start() error {
+	if !w.OkayToStart() {
+		return errors.New("ENSWatcher has already been started")
+	}
+	if w.interval <= 0 {
+		close(w.chDone)
+		return nil
+	}
+	go w.runLoop()
+	return nil
+}
+
+func (w *ENSWatcher) Close() error {
+	if !w.OkayToStop() {
+		return errors.New("ENSWatcher has already been stopped")
+	}
+	close(w.chStop)
+	<-w.chDone
+	return nil
+}
+
+func (w *ENSWatcher) runLoop() {
+	defer close(w.chDone)
+
+	ticker := time.NewTicker(utils.WithJitter(w.interval))
+	defer ticker.Stop()
+
+	ctx, cancel := utils.CombinedContext(w.chStop)
+	defer cancel()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.Check(ctx); err != nil {
+				logger.Errorw("ENSWatcher: failed to check ENS names", "error", err)
+			}
+		case <-w.chStop:
+			return
+		}
+	}
+}
+
+// Check re-resolves the ENS name of every job that has one, recording a job
+// error for each job whose name now resolves to a different address. Names
+// that fail to resolve are logged and checked again next time.
+func (w *ENSWatcher) Check(ctx context.Context) error {
+	var jobs []Job
+	err := w.db.
+		Preload("OffchainreportingOracleSpec").
+		Preload("DirectRequestSpec").
+		Preload("FluxMonitorSpec").
+		Preload("KeeperSpec").
+		Where("contract_ens_name IS NOT NULL AND archived_at IS NULL").
+		Order("id ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return errors.Wrap(err, "failed to load jobs with ENS names")
+	}
+
+	for _, jb := range jobs {
+		current, ok := jb.ContractAddress()
+		if !ok {
+			continue
+		}
+		name := jb.ContractENSName.String
+		resolved, err := eth.ResolveENS(ctx, w.ethClient, name)
+		if err != nil {
+			logger.Warnw("ENSWatcher: failed to resolve ENS name", "jobID", jb.ID, "name", name, "error", err)
+			continue
+		}
+		if resolved != current {
+			logger.Warnw("ENSWatcher: ENS name resolves to a different address than the job uses",
+				"jobID", jb.ID, "name", name, "resolved", resolved.Hex(), "current", current.Hex())
+			w.orm.RecordError(ctx, jb.ID, fmt.Sprintf(
+				"ENS name %s now resolves to %s, but the job uses %s. Recreate the job to use the new address.",
+				name, resolved.Hex(), current.Hex()))
+		}
+	}
+	return nil
+}
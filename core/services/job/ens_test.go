@@ -0,0 +1,72 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+func TestENSWatcher_Check(t *testing.T) {
+	t.Parallel()
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	store, cleanup := cltest.NewStoreWithConfig(t, config)
+	defer cleanup()
+	db := store.DB
+
+	pipelineORM, eventBroadcaster, cleanupORM := cltest.NewPipelineORM(t, config, db)
+	defer cleanupORM()
+	orm := job.NewORM(db, config.Config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
+	defer orm.Close()
+
+	_, bridge := cltest.NewBridgeType(t, "voter_turnout", "http://blah.com")
+	require.NoError(t, db.Create(bridge).Error)
+	_, bridge2 := cltest.NewBridgeType(t, "election_winner", "http://blah.com")
+	require.NoError(t, db.Create(bridge2).Error)
+	key := cltest.MustInsertRandomKey(t, db)
+
+	jb := makeOCRJobSpec(t, key.Address.Address())
+	jb.ContractENSName = null.StringFrom("eth-usd.data.eth")
+	require.NoError(t, orm.CreateJob(context.Background(), jb, jb.Pipeline))
+	current := jb.OffchainreportingOracleSpec.ContractAddress.Address()
+
+	resolver := cltest.NewAddress()
+	resolvesTo := func(address common.Address) *mocks.GethClient {
+		ethClient := new(mocks.GethClient)
+		ethClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(common.LeftPadBytes(resolver.Bytes(), 32), nil).Once()
+		ethClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(common.LeftPadBytes(address.Bytes(), 32), nil).Once()
+		return ethClient
+	}
+
+	t.Run("does nothing while the name resolves to the job's address", func(t *testing.T) {
+		ethClient := resolvesTo(current)
+		watcher := job.NewENSWatcher(db, ethClient, orm, 0)
+		require.NoError(t, watcher.Check(context.Background()))
+
+		ethClient.AssertExpectations(t)
+		cltest.AssertCount(t, store, job.SpecError{}, 0)
+	})
+
+	t.Run("records a job error when the name resolves to a new address", func(t *testing.T) {
+		upgraded := cltest.NewAddress()
+		ethClient := resolvesTo(upgraded)
+		watcher := job.NewENSWatcher(db, ethClient, orm, 0)
+		require.NoError(t, watcher.Check(context.Background()))
+
+		ethClient.AssertExpectations(t)
+		var specErrors []job.SpecError
+		require.NoError(t, db.Where("job_id = ?", jb.ID).Find(&specErrors).Error)
+		require.Len(t, specErrors, 1)
+		assert.Contains(t, specErrors[0].Description, "ENS name eth-usd.data.eth now resolves to "+upgraded.Hex())
+	})
+}
@@ -0,0 +1,77 @@
+package job
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	storm "github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// ErrFeatureDisabled is returned when a job needs a feature flag that is
+// disabled
+var ErrFeatureDisabled = errors.New("feature is disabled")
+
+// ErrTaskTypeNotAllowed is returned when a job uses a task type that the
+// node's ALLOWED_TASK_TYPES or DISALLOWED_TASK_TYPES don't allow
+var ErrTaskTypeNotAllowed = errors.New("task type is not allowed on this node")
+
+// jobTypeFeatures are the feature flags gating job types. Job types not
+// listed here are always enabled.
+var jobTypeFeatures = map[Type]storm.Feature{
+	FluxMonitor:       storm.FeatureFlagFluxMonitorV2,
+	MessageQueue:      storm.FeatureFlagMessageQueue,
+	OffchainReporting: storm.FeatureFlagOffchainReporting,
+	Shadow:            storm.FeatureFlagShadow,
+}
+
+// CheckJobTypeEnabled returns an error wrapping ErrFeatureDisabled if jobs
+// of type t are gated by a disabled feature flag. Every feature is enabled
+// in dev mode.
+func CheckJobTypeEnabled(config *storm.Config, t Type) error {
+	if config.Dev() {
+		return nil
+	}
+	if f, gated := jobTypeFeatures[t]; gated && !config.FeatureEnabled(f) {
+		return errors.Wrapf(ErrFeatureDisabled, "%s jobs require %s to be enabled", t, f.EnvVarName())
+	}
+	return nil
+}
+
+// CheckTasksEnabled returns an error wrapping ErrFeatureDisabled if any of
+// the tasks in the pipeline are experimental and FEATURE_EXPERIMENTAL_TASKS
+// is disabled
+func CheckTasksEnabled(config *storm.Config, dag pipeline.TaskDAG) error {
+	if dag.DirectedGraph == nil || config.Dev() || config.FeatureEnabled(storm.FeatureFlagExperimentalTasks) {
+		return nil
+	}
+	tasks, err := dag.TasksInDependencyOrder()
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.Type().IsExperimental() {
+			return errors.Wrapf(ErrFeatureDisabled, "task %s is of experimental type %s, which requires %s to be enabled",
+				task.DotID(), task.Type(), storm.FeatureFlagExperimentalTasks.EnvVarName())
+		}
+	}
+	return nil
+}
+
+// CheckTasksAllowed returns an error wrapping ErrTaskTypeNotAllowed if any of
+// the tasks in the pipeline are of a type that the node doesn't allow. Unlike
+// feature flags, this applies in dev mode too.
+func CheckTasksAllowed(config *storm.Config, dag pipeline.TaskDAG) error {
+	if dag.DirectedGraph == nil {
+		return nil
+	}
+	tasks, err := dag.TasksInDependencyOrder()
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if !config.TaskTypeAllowed(string(task.Type())) {
+			return errors.Wrapf(ErrTaskTypeNotAllowed, "task %s is of type %s", task.DotID(), task.Type())
+		}
+	}
+	return nil
+}
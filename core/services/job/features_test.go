@@ -0,0 +1,85 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestCheckJobTypeEnabled(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	// Everything is enabled in dev mode
+	require.NoError(t, job.CheckJobTypeEnabled(config.Config, job.OffchainReporting))
+
+	config.Set("CHAINLINK_DEV", false)
+	config.Set("FEATURE_OFFCHAIN_REPORTING", false)
+	err := job.CheckJobTypeEnabled(config.Config, job.OffchainReporting)
+	assert.Equal(t, job.ErrFeatureDisabled, errors.Cause(err))
+	assert.EqualError(t, err, "offchainreporting jobs require FEATURE_OFFCHAIN_REPORTING to be enabled: feature is disabled")
+
+	config.Set("FEATURE_OFFCHAIN_REPORTING", true)
+	require.NoError(t, job.CheckJobTypeEnabled(config.Config, job.OffchainReporting))
+
+	config.Set("FEATURE_SHADOW", false)
+	err = job.CheckJobTypeEnabled(config.Config, job.Shadow)
+	assert.Equal(t, job.ErrFeatureDisabled, errors.Cause(err))
+	config.Set("FEATURE_MESSAGE_QUEUE", false)
+	err = job.CheckJobTypeEnabled(config.Config, job.MessageQueue)
+	assert.Equal(t, job.ErrFeatureDisabled, errors.Cause(err))
+
+	// Job types without a feature flag are always enabled
+	require.NoError(t, job.CheckJobTypeEnabled(config.Config, job.Keeper))
+}
+
+func TestCheckTasksEnabled(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("CHAINLINK_DEV", false)
+
+	dag := pipeline.TaskDAG{}
+	require.NoError(t, dag.UnmarshalText([]byte(`ds [type=http method=GET url="https://chain.link/voter_turnout/USA-2020" requestData="{\"hi\": \"hello\"}"];`)))
+	require.NoError(t, job.CheckTasksEnabled(config.Config, dag))
+
+	// Jobs without a pipeline have no tasks to check
+	require.NoError(t, job.CheckTasksEnabled(config.Config, pipeline.TaskDAG{}))
+}
+
+func TestCheckTasksAllowed(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	dag := pipeline.TaskDAG{}
+	require.NoError(t, dag.UnmarshalText([]byte(`
+		ds    [type=http method=GET url="https://chain.link/voter_turnout/USA-2020"];
+		parse [type=jsonparse path="data,result"];
+		ds -> parse;
+	`)))
+	require.NoError(t, job.CheckTasksAllowed(config.Config, dag))
+
+	// Disallowed task types are rejected, even in dev mode
+	config.Set("DISALLOWED_TASK_TYPES", "sql, http")
+	err := job.CheckTasksAllowed(config.Config, dag)
+	assert.Equal(t, job.ErrTaskTypeNotAllowed, errors.Cause(err))
+	assert.EqualError(t, err, "task ds is of type http: task type is not allowed on this node")
+
+	// Only allowed task types are accepted when there is an allowlist
+	config.Set("DISALLOWED_TASK_TYPES", "")
+	config.Set("ALLOWED_TASK_TYPES", "http")
+	err = job.CheckTasksAllowed(config.Config, dag)
+	assert.EqualError(t, err, "task parse is of type jsonparse: task type is not allowed on this node")
+
+	config.Set("ALLOWED_TASK_TYPES", "http,jsonparse")
+	require.NoError(t, job.CheckTasksAllowed(config.Config, dag))
+
+	// Jobs without a pipeline have no tasks to check
+	config.Set("ALLOWED_TASK_TYPES", "sql")
+	require.NoError(t, job.CheckTasksAllowed(config.Config, pipeline.TaskDAG{}))
+}
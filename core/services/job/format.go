@@ -0,0 +1,258 @@
+package job
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// SpecJSONToTOML converts a job spec written as a JSON object into its
+// canonical TOML form. The JSON object uses the same keys as the TOML spec,
+// e.g. {"type": "fluxmonitor", "schemaVersion": 1, ...}.
+func SpecJSONToTOML(specJSON []byte) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(specJSON))
+	decoder.UseNumber()
+	var m map[string]interface{}
+	if err := decoder.Decode(&m); err != nil {
+		return "", errors.Wrap(err, "job spec JSON must be an object")
+	}
+	if decoder.More() {
+		return "", errors.New("unexpected data after job spec JSON object")
+	}
+
+	values, err := fromJSONValue(m)
+	if err != nil {
+		return "", err
+	}
+	tree, err := toml.TreeFromMap(values.(map[string]interface{}))
+	if err != nil {
+		return "", errors.Wrap(err, "could not convert job spec JSON to TOML")
+	}
+	return tree.ToTomlString()
+}
+
+// SpecTOMLToJSON converts a TOML job spec into its canonical JSON form, with
+// keys in sorted order
+func SpecTOMLToJSON(specTOML string) ([]byte, error) {
+	tree, err := toml.Load(specTOML)
+	if err != nil {
+		return nil, errors.Wrap(err, "toml error on load")
+	}
+	return json.Marshal(tree.ToMap())
+}
+
+// fromJSONValue replaces the json.Numbers in a decoded JSON value with the
+// int64 or float64 that TOML would have produced for the same literal
+func fromJSONValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case map[string]interface{}:
+		for key, value := range v {
+			converted, err := fromJSONValue(value)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = converted
+		}
+		return v, nil
+	case []interface{}:
+		for i, value := range v {
+			converted, err := fromJSONValue(value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+		return v, nil
+	case nil:
+		return nil, errors.New("null is not a valid job spec value")
+	default:
+		return v, nil
+	}
+}
+
+// JobToSpecTOML reconstructs a TOML spec from a saved job, so that the job
+// can be recreated on another node. Fields with zero values are omitted, and
+// fields that were filled in from the node's configuration are included, so
+// the result may differ from the TOML the job was created from.
+//
+// The onComplete webhookSecret and queueURL are left out, as the queue URL
+// holds the queue's credentials, so they must be filled in again before the
+// job is recreated. Use JobToSpecTOMLWithSecrets to include them.
+func JobToSpecTOML(jb Job) (string, error) {
+	jb.OnComplete.WebhookSecret = null.String{}
+	jb.OnComplete.QueueURL = null.String{}
+	return jobToSpecTOML(jb)
+}
+
+// JobToSpecTOMLWithSecrets is like JobToSpecTOML, but includes the job's
+// secrets in plaintext, so that the job can be recreated as it was
+func JobToSpecTOMLWithSecrets(jb Job) (string, error) {
+	return jobToSpecTOML(jb)
+}
+
+func jobToSpecTOML(jb Job) (string, error) {
+	source, exists := specSchemaSources[jb.Type]
+	if !exists {
+		return "", errors.Wrapf(ErrUnknownJobType, "%s", jb.Type)
+	}
+	spec := jobTypeSpec(jb)
+	if spec.IsNil() {
+		return "", errors.Errorf("job %v has no %s spec", jb.ID, jb.Type)
+	}
+
+	values := make(map[string]interface{})
+	jobValue := reflect.ValueOf(jb)
+	for _, name := range jobFields {
+		if name == "Pipeline" {
+			continue
+		}
+		f, _ := jobValue.Type().FieldByName(name)
+		if err := addTOMLValue(values, tomlName(f), jobValue.FieldByName(name)); err != nil {
+			return "", err
+		}
+	}
+	// The parsed pipeline isn't loaded with the job, so use its source
+	if jb.PipelineSpec != nil && jb.PipelineSpec.DotDagSource != "" {
+		values["observationSource"] = jb.PipelineSpec.DotDagSource
+	}
+	if err := addTOMLStructValues(values, spec.Elem(), source.exclude); err != nil {
+		return "", err
+	}
+
+	tree, err := toml.TreeFromMap(values)
+	if err != nil {
+		return "", errors.Wrap(err, "could not convert job to TOML")
+	}
+	return tree.ToTomlString()
+}
+
+// jobTypeSpec returns a pointer to the type specific spec of a job
+func jobTypeSpec(jb Job) reflect.Value {
+	switch jb.Type {
+	case OffchainReporting:
+		return reflect.ValueOf(jb.OffchainreportingOracleSpec)
+	case DirectRequest:
+		return reflect.ValueOf(jb.DirectRequestSpec)
+	case FluxMonitor:
+		return reflect.ValueOf(jb.FluxMonitorSpec)
+	case Keeper:
+		return reflect.ValueOf(jb.KeeperSpec)
+	case Shadow:
+		return reflect.ValueOf(jb.ShadowSpec)
+	case MessageQueue:
+		return reflect.ValueOf(jb.MessageQueueSpec)
+	default:
+		return reflect.ValueOf((*struct{})(nil))
+	}
+}
+
+func addTOMLStructValues(values map[string]interface{}, v reflect.Value, exclude []string) error {
+	t := v.Type()
+outer:
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := addTOMLStructValues(values, v.Field(i), exclude); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		for _, name := range exclude {
+			if f.Name == name {
+				continue outer
+			}
+		}
+		if err := addTOMLValue(values, tomlName(f), v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addTOMLValue sets values[name] to the TOML representation of v, unless v
+// is a zero value
+func addTOMLValue(values map[string]interface{}, name string, v reflect.Value) error {
+	if name == "" || name == "-" || v.IsZero() {
+		return nil
+	}
+	value, err := tomlValue(v)
+	if err != nil {
+		return errors.Wrapf(err, "field %s", name)
+	}
+	values[name] = value
+	return nil
+}
+
+func tomlValue(v reflect.Value) (interface{}, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			return string(text), err
+		}
+		v = v.Elem()
+	}
+	switch x := v.Interface().(type) {
+	case encoding.TextMarshaler:
+		text, err := x.MarshalText()
+		return string(text), err
+	case time.Duration:
+		return x.String(), nil
+	case fmt.Stringer:
+		return x.String(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	case reflect.Float32:
+		// Round trip through the shortest decimal representation, so that
+		// e.g. 0.1 isn't written as 0.10000000149011612
+		return strconv.ParseFloat(strconv.FormatFloat(v.Float(), 'g', -1, 32), 64)
+	case reflect.Float64:
+		return v.Float(), nil
+	case reflect.Struct:
+		// Structs without a text form, e.g. onComplete, are written as tables
+		table := make(map[string]interface{})
+		if err := addTOMLStructValues(table, v, nil); err != nil {
+			return nil, err
+		}
+		return table, nil
+	case reflect.Slice, reflect.Array:
+		elems := make([]interface{}, v.Len())
+		for i := range elems {
+			elem, err := tomlValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return elems, nil
+	default:
+		return nil, errors.Errorf("unsupported type %s", v.Type())
+	}
+}
@@ -0,0 +1,95 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// ErrMaxGasCostExceeded is returned by GasCostGuard.Check for transmissions
+// that would cost more than the job's maxGasCostWei
+var ErrMaxGasCostExceeded = errors.New("estimated gas cost exceeds maxGasCostWei")
+
+var promGasCostSkippedTransmissions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "job_gas_cost_skipped_transmissions_total",
+	Help: "The number of transmissions skipped because their estimated gas cost exceeded the job's maxGasCostWei",
+}, []string{"job_id"})
+
+// GasPriceSource returns the gas price that new transactions are sent with,
+// as kept up to date by the gas updater
+type GasPriceSource interface {
+	EthGasPriceDefault() *big.Int
+}
+
+// GasCostGuard protects operators from gas spikes by skipping the
+// transmissions of a job with maxGasCostWei set whenever their estimated cost
+// at the current gas price exceeds it. Each skipped transmission is counted
+// on a job error, so that there is a record of the rounds that were missed.
+type GasCostGuard struct {
+	jobID       int32
+	maxGasCost  *big.Int
+	gasPrices   GasPriceSource
+	gasCosts    eth.GasCostEstimator
+	jobORM      ORM
+	description string
+}
+
+// NewGasCostGuard returns a GasCostGuard for the job, which estimates the
+// cost of transmissions with gasCosts. Its Check always passes if the job has
+// no maxGasCostWei.
+func NewGasCostGuard(jb Job, gasPrices GasPriceSource, gasCosts eth.GasCostEstimator, jobORM ORM) *GasCostGuard {
+	g := &GasCostGuard{
+		jobID:     jb.ID,
+		gasPrices: gasPrices,
+		gasCosts:  gasCosts,
+		jobORM:    jobORM,
+	}
+	if jb.MaxGasCostWei != nil {
+		g.maxGasCost = jb.MaxGasCostWei.ToInt()
+		g.description = fmt.Sprintf("Skipped transmission: estimated gas cost exceeded maxGasCostWei of %s wei", g.maxGasCost)
+	}
+	return g
+}
+
+// Check returns an error wrapping ErrMaxGasCostExceeded if msg, a transaction
+// with msg.Gas as its gas limit, would cost more than the job's maxGasCostWei
+// at the current gas price. If the estimator fails, e.g. because an L2 gas
+// price oracle could not be reached, only the execution cost is checked.
+func (g *GasCostGuard) Check(ctx context.Context, msg ethereum.CallMsg) error {
+	if g.maxGasCost == nil {
+		return nil
+	}
+	gasLimit := msg.Gas
+	gasPrice := g.gasPrices.EthGasPriceDefault()
+	msg.GasPrice = gasPrice
+	cost, err := g.gasCosts.EstimateGasCost(ctx, msg)
+	if err != nil {
+		logger.Warnw("Could not estimate gas cost of transmission, checking its execution cost only",
+			"jobID", g.jobID,
+			"err", err,
+		)
+		cost = new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+	}
+	if cost.Cmp(g.maxGasCost) <= 0 {
+		return nil
+	}
+
+	promGasCostSkippedTransmissions.WithLabelValues(fmt.Sprintf("%d", g.jobID)).Inc()
+	logger.Warnw("Skipped transmission because its estimated gas cost exceeds the job's maxGasCostWei",
+		"jobID", g.jobID,
+		"gasLimit", gasLimit,
+		"gasPrice", gasPrice.String(),
+		"estimatedGasCost", cost.String(),
+		"maxGasCostWei", g.maxGasCost.String(),
+	)
+	g.jobORM.RecordError(ctx, g.jobID, g.description)
+	return errors.Wrapf(ErrMaxGasCostExceeded, "%s wei (gas limit %d at %s wei) is more than %s wei", cost, gasLimit, gasPrice, g.maxGasCost)
+}
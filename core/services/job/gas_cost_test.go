@@ -0,0 +1,52 @@
+package job_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/job/mocks"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+type fixedGasPrice int64
+
+func (p fixedGasPrice) EthGasPriceDefault() *big.Int { return big.NewInt(int64(p)) }
+
+func TestGasCostGuard_Check(t *testing.T) {
+	t.Parallel()
+
+	jb := job.Job{MaxGasCostWei: utils.NewBig(big.NewInt(50000000000000000))}
+	jb.ID = 42
+
+	t.Run("passes jobs without maxGasCostWei", func(t *testing.T) {
+		jobORM := new(mocks.ORM)
+		guard := job.NewGasCostGuard(job.Job{}, fixedGasPrice(1000000000000), eth.NewGasCostEstimator(nil, eth.ChainTypeL1), jobORM)
+		assert.NoError(t, guard.Check(context.Background(), ethereum.CallMsg{Gas: 500000}))
+		jobORM.AssertExpectations(t)
+	})
+
+	t.Run("passes transmissions within the cap", func(t *testing.T) {
+		jobORM := new(mocks.ORM)
+		// 500,000 gas at 100 gwei costs 0.05 ETH
+		guard := job.NewGasCostGuard(jb, fixedGasPrice(100000000000), eth.NewGasCostEstimator(nil, eth.ChainTypeL1), jobORM)
+		assert.NoError(t, guard.Check(context.Background(), ethereum.CallMsg{Gas: 500000}))
+		jobORM.AssertExpectations(t)
+	})
+
+	t.Run("skips transmissions over the cap and records why", func(t *testing.T) {
+		jobORM := new(mocks.ORM)
+		jobORM.On("RecordError", mock.Anything, int32(42), "Skipped transmission: estimated gas cost exceeded maxGasCostWei of 50000000000000000 wei").Once()
+		guard := job.NewGasCostGuard(jb, fixedGasPrice(100000000001), eth.NewGasCostEstimator(nil, eth.ChainTypeL1), jobORM)
+		err := guard.Check(context.Background(), ethereum.CallMsg{Gas: 500000})
+		assert.True(t, errors.Is(err, job.ErrMaxGasCostExceeded))
+		jobORM.AssertExpectations(t)
+	})
+}
@@ -2,23 +2,32 @@ package job_test
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
 	gormpostgres "gorm.io/driver/postgres"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/keeper"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/services/shadow"
+	storm "github.com/smartcontractkit/chainlink/core/store/orm"
 )
 
 func TestORM(t *testing.T) {
@@ -53,6 +62,16 @@ func TestORM(t *testing.T) {
 		compareOCRJobSpecs(t, *dbSpec, returnedSpec)
 	})
 
+	t.Run("it rejects job specs with task types the node doesn't allow", func(t *testing.T) {
+		config.Set("DISALLOWED_TASK_TYPES", "http")
+		defer config.Set("DISALLOWED_TASK_TYPES", "")
+
+		spec := makeOCRJobSpec(t, address)
+		err := orm.CreateJob(context.Background(), spec, spec.Pipeline)
+		require.Error(t, err)
+		require.Equal(t, job.ErrTaskTypeNotAllowed, errors.Cause(err))
+	})
+
 	dbURL := config.DatabaseURL()
 	db2, err := gorm.Open(gormpostgres.New(gormpostgres.Config{
 		DSN: dbURL.String(),
@@ -62,7 +81,7 @@ func TestORM(t *testing.T) {
 	require.NoError(t, err)
 	defer d.Close()
 
-	orm2 := job.NewORM(db2, config.Config, pipeline.NewORM(db2, config, eventBroadcaster), eventBroadcaster, &postgres.NullAdvisoryLocker{})
+	orm2 := job.NewORM(db2, config.Config, pipeline.NewORM(db2, config, eventBroadcaster, nil), eventBroadcaster, &postgres.NullAdvisoryLocker{})
 	defer orm2.Close()
 
 	t.Run("it correctly returns the unclaimed jobs in the DB", func(t *testing.T) {
@@ -302,6 +321,30 @@ func TestORM_DeleteJob_DeletesAssociatedRecords(t *testing.T) {
 		cltest.AssertCount(t, store, job.Job{}, 0)
 	})
 
+	t.Run("it keeps pipeline specs shared with other jobs", func(t *testing.T) {
+		key := cltest.MustInsertRandomKey(t, store.DB)
+		address := key.Address.Address()
+		first := makeOCRJobSpec(t, address)
+		second := makeOCRJobSpec(t, address)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, orm.CreateJob(ctx, first, first.Pipeline))
+		require.NoError(t, orm.CreateJob(ctx, second, second.Pipeline))
+		require.Equal(t, first.PipelineSpecID, second.PipelineSpecID)
+		cltest.AssertCount(t, store, pipeline.Spec{}, 1)
+
+		jobIDs, err := orm.FindJobIDsWithPipelineSpec(first.PipelineSpecID)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{first.ID, second.ID}, jobIDs)
+
+		require.NoError(t, orm.DeleteJob(ctx, first.ID))
+		cltest.AssertCount(t, store, pipeline.Spec{}, 1)
+
+		require.NoError(t, orm.DeleteJob(ctx, second.ID))
+		cltest.AssertCount(t, store, pipeline.Spec{}, 0)
+	})
+
 	t.Run("it deletes records for keeper jobs", func(t *testing.T) {
 		registry, keeperJob := cltest.MustInsertKeeperRegistry(t, store)
 		cltest.MustInsertUpkeepForRegistry(t, store, registry)
@@ -320,3 +363,278 @@ func TestORM_DeleteJob_DeletesAssociatedRecords(t *testing.T) {
 		cltest.AssertCount(t, store, job.Job{}, 0)
 	})
 }
+
+func TestORM_ArchiveJob(t *testing.T) {
+	t.Parallel()
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	store, cleanup := cltest.NewStoreWithConfig(t, config)
+	defer cleanup()
+	db := store.DB
+
+	pipelineORM, eventBroadcaster, cleanupORM := cltest.NewPipelineORM(t, config, db)
+	defer cleanupORM()
+	orm := job.NewORM(db, config.Config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
+	defer orm.Close()
+
+	_, bridge := cltest.NewBridgeType(t, "voter_turnout", "http://blah.com")
+	require.NoError(t, db.Create(bridge).Error)
+	_, bridge2 := cltest.NewBridgeType(t, "election_winner", "http://blah.com")
+	require.NoError(t, db.Create(bridge2).Error)
+	key := cltest.MustInsertRandomKey(t, db)
+	dbSpec := makeOCRJobSpec(t, key.Address.Address())
+	require.NoError(t, orm.CreateJob(context.Background(), dbSpec, dbSpec.Pipeline))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	claimed, err := orm.ClaimUnclaimedJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	runID, err := pipelineORM.CreateRun(ctx, dbSpec.ID, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, orm.ArchiveJob(ctx, dbSpec.ID))
+	assert.NotContains(t, job.GetORMClaimedJobIDs(orm), dbSpec.ID)
+	assert.Equal(t, storm.ErrorNotFound, orm.ArchiveJob(ctx, dbSpec.ID))
+
+	t.Run("hides archived jobs but keeps their runs", func(t *testing.T) {
+		jobs, err := orm.JobsV2()
+		require.NoError(t, err)
+		assert.Empty(t, jobs)
+
+		archived, err := orm.ArchivedJobsV2()
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.True(t, archived[0].ArchivedAt.Valid)
+
+		runs, count, err := orm.PipelineRunsByJobID(dbSpec.ID, storm.Pagination{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, runID, runs[0].ID)
+
+		claimed, err := orm.ClaimUnclaimedJobs(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		_, err = pipelineORM.CreateRun(ctx, dbSpec.ID, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("leaves the unfinished runs of archived jobs unprocessed", func(t *testing.T) {
+		run, err := pipelineORM.ProcessNextUnfinishedRun(ctx, func(context.Context, *gorm.DB, pipeline.Spec, pipeline.JSONSerializable, logger.Logger) (pipeline.TaskRunResults, bool, error) {
+			t.Fatal("processed a run of an archived job")
+			return nil, false, nil
+		})
+		require.NoError(t, err)
+		assert.Nil(t, run)
+	})
+
+	t.Run("purges jobs archived before the given time", func(t *testing.T) {
+		purged, err := orm.PurgeArchivedJobs(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 0, purged)
+		cltest.AssertCount(t, store, job.Job{}, 1)
+
+		purged, err = orm.PurgeArchivedJobs(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		cltest.AssertCount(t, store, job.Job{}, 0)
+		cltest.AssertCount(t, store, pipeline.Run{}, 0)
+	})
+
+	t.Run("allows the contract of an archived OCR job to be given a new job", func(t *testing.T) {
+		archived := makeOCRJobSpec(t, key.Address.Address())
+		require.NoError(t, orm.CreateJob(ctx, archived, archived.Pipeline))
+		replacement := makeOCRJobSpec(t, key.Address.Address())
+		replacement.OffchainreportingOracleSpec.ContractAddress = archived.OffchainreportingOracleSpec.ContractAddress
+		require.Error(t, orm.CreateJob(ctx, replacement, replacement.Pipeline))
+
+		require.NoError(t, orm.ArchiveJob(ctx, archived.ID))
+		replacement = makeOCRJobSpec(t, key.Address.Address())
+		replacement.OffchainreportingOracleSpec.ContractAddress = archived.OffchainreportingOracleSpec.ContractAddress
+		require.NoError(t, orm.CreateJob(ctx, replacement, replacement.Pipeline))
+	})
+}
+
+func TestORM_DependsOn(t *testing.T) {
+	t.Parallel()
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	store, cleanup := cltest.NewStoreWithConfig(t, config)
+	defer cleanup()
+	db := store.DB
+
+	pipelineORM, eventBroadcaster, cleanupORM := cltest.NewPipelineORM(t, config, db)
+	defer cleanupORM()
+	orm := job.NewORM(db, config.Config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
+	defer orm.Close()
+
+	_, bridge := cltest.NewBridgeType(t, "voter_turnout", "http://blah.com")
+	require.NoError(t, db.Create(bridge).Error)
+	_, bridge2 := cltest.NewBridgeType(t, "election_winner", "http://blah.com")
+	require.NoError(t, db.Create(bridge2).Error)
+	key := cltest.MustInsertRandomKey(t, db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	newJob := func(name, dependsOn string) *job.Job {
+		jb := makeOCRJobSpec(t, key.Address.Address())
+		jb.Name = null.StringFrom(name)
+		jb.DependsOn = null.NewString(dependsOn, dependsOn != "")
+		return jb
+	}
+
+	upstream := newJob("ETH/USD", "")
+	require.NoError(t, orm.CreateJob(ctx, upstream, upstream.Pipeline))
+	downstream := newJob("ETH/EUR", "ETH/USD")
+	require.NoError(t, orm.CreateJob(ctx, downstream, downstream.Pipeline))
+
+	found, err := orm.FindJob(downstream.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("ETH/USD"), found.DependsOn)
+
+	t.Run("rejects jobs that depend on a job that doesn't exist", func(t *testing.T) {
+		jb := newJob("BTC/EUR", "BTC/USD")
+		err := orm.CreateJob(ctx, jb, jb.Pipeline)
+		assert.True(t, errors.Is(err, job.ErrInvalidDependsOn))
+	})
+
+	t.Run("rejects jobs that depend on an ambiguous name", func(t *testing.T) {
+		duplicate := newJob("ETH/EUR", "")
+		require.NoError(t, orm.CreateJob(ctx, duplicate, duplicate.Pipeline))
+		defer func() { require.NoError(t, orm.DeleteJob(ctx, duplicate.ID)) }()
+
+		jb := newJob("ETH/GBP", "ETH/EUR")
+		err := orm.CreateJob(ctx, jb, jb.Pipeline)
+		assert.True(t, errors.Is(err, job.ErrInvalidDependsOn))
+	})
+
+	t.Run("rejects jobs that would make a cycle", func(t *testing.T) {
+		require.NoError(t, orm.DeleteJob(ctx, upstream.ID))
+
+		jb := newJob("ETH/USD", "ETH/EUR")
+		err := orm.CreateJob(ctx, jb, jb.Pipeline)
+		assert.True(t, errors.Is(err, job.ErrInvalidDependsOn))
+	})
+}
+
+func TestORM_ShadowJobs(t *testing.T) {
+	t.Parallel()
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	store, cleanup := cltest.NewStoreWithConfig(t, config)
+	defer cleanup()
+	db := store.DB
+
+	pipelineORM, eventBroadcaster, cleanupORM := cltest.NewPipelineORM(t, config, db)
+	defer cleanupORM()
+	orm := job.NewORM(db, config.Config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
+	defer orm.Close()
+	runner := pipeline.NewRunner(pipelineORM, config, nil, nil, nil)
+	require.NoError(t, runner.Start())
+	defer runner.Close()
+
+	primaryServer, cleanupPrimary := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"USD": 1.5}`)
+	defer cleanupPrimary()
+	shadowServer, cleanupShadow := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"USD": 1.6}`)
+	defer cleanupShadow()
+
+	key := cltest.MustInsertRandomKey(t, db)
+	primary := makeSimpleFetchOCRJobSpecWithHTTPURL(t, db, key.Address.Address(), primaryServer.URL, false)
+	require.NoError(t, orm.CreateJob(context.Background(), primary, primary.Pipeline))
+
+	newShadow := func(shadowOf int32) job.Job {
+		jb, err := shadow.ValidatedShadowSpec(config.Config, fmt.Sprintf(`
+type              = "shadow"
+schemaVersion     = 1
+shadowOf          = %d
+observationSource = """
+    ds1          [type=http method=GET url="%s" allowunrestrictednetworkaccess="true"];
+    ds1_parse    [type=jsonparse path="USD"];
+    ds1_multiply [type=multiply times=100];
+    ds1 -> ds1_parse -> ds1_multiply;
+"""
+`, shadowOf, shadowServer.URL))
+		require.NoError(t, err)
+		return jb
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("rejects shadows of jobs that don't exist", func(t *testing.T) {
+		jb := newShadow(primary.ID + 100)
+		err := orm.CreateJob(ctx, &jb, jb.Pipeline)
+		assert.True(t, errors.Is(err, job.ErrInvalidShadowOf))
+	})
+
+	shadowJob := newShadow(primary.ID)
+	require.NoError(t, orm.CreateJob(ctx, &shadowJob, shadowJob.Pipeline))
+
+	t.Run("rejects shadows of shadows", func(t *testing.T) {
+		jb := newShadow(shadowJob.ID)
+		err := orm.CreateJob(ctx, &jb, jb.Pipeline)
+		assert.True(t, errors.Is(err, job.ErrInvalidShadowOf))
+	})
+
+	t.Run("runs shadows alongside the primary job and compares them", func(t *testing.T) {
+		found, err := orm.FindJob(primary.ID)
+		require.NoError(t, err)
+		spec := *found.PipelineSpec
+		spec.JobID = found.ID
+
+		primaryRunID, result, err := runner.ExecuteAndInsertNewRun(ctx, spec, pipeline.JSONSerializable{}, *logger.Default)
+		require.NoError(t, err)
+		require.False(t, result.HasErrors())
+
+		g := gomega.NewGomegaWithT(t)
+		g.Eventually(func() int {
+			_, count, err := orm.ShadowComparisonsByJobID(shadowJob.ID, 0, 10)
+			require.NoError(t, err)
+			return count
+		}).Should(gomega.Equal(1))
+
+		comparisons, _, err := orm.ShadowComparisonsByJobID(shadowJob.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, primaryRunID, comparisons[0].PrimaryRunID)
+		assert.NotEqual(t, primaryRunID, comparisons[0].ShadowRunID)
+		require.True(t, comparisons[0].Delta.Valid)
+		assert.Equal(t, "10", comparisons[0].Delta.Decimal.String())
+
+		runs, count, err := orm.PipelineRunsByJobID(shadowJob.ID, storm.Pagination{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		assert.Equal(t, comparisons[0].ShadowRunID, runs[0].ID)
+	})
+
+	t.Run("runs shadows of primary runs finished by the unfinished runs processor", func(t *testing.T) {
+		primaryRunID, err := runner.CreateRun(ctx, primary.ID, nil)
+		require.NoError(t, err)
+		require.NoError(t, runner.AwaitRun(ctx, primaryRunID))
+
+		g := gomega.NewGomegaWithT(t)
+		g.Eventually(func() int {
+			_, count, err := orm.ShadowComparisonsByJobID(shadowJob.ID, 0, 10)
+			require.NoError(t, err)
+			return count
+		}).Should(gomega.Equal(2))
+
+		comparisons, _, err := orm.ShadowComparisonsByJobID(shadowJob.ID, 0, 10)
+		require.NoError(t, err)
+		var primaryRunIDs []int64
+		for _, c := range comparisons {
+			primaryRunIDs = append(primaryRunIDs, c.PrimaryRunID)
+		}
+		assert.Contains(t, primaryRunIDs, primaryRunID)
+	})
+
+	t.Run("deleting the primary job deletes its shadows", func(t *testing.T) {
+		require.NoError(t, orm.DeleteJob(ctx, primary.ID))
+		cltest.AssertCount(t, store, job.Job{}, 0)
+		cltest.AssertCount(t, store, job.ShadowSpec{}, 0)
+		cltest.AssertCount(t, store, pipeline.Spec{}, 0)
+		cltest.AssertCount(t, store, pipeline.ShadowComparison{}, 0)
+	})
+}
@@ -99,7 +99,7 @@ func TestPipelineORM_Integration(t *testing.T) {
 		err := g.UnmarshalText([]byte(pipeline.DotStr))
 		require.NoError(t, err)
 
-		specID, err = orm.CreateSpec(context.Background(), db, *g, models.Interval(0))
+		specID, err = orm.CreateSpec(context.Background(), db, *g, models.Interval(0), pipeline.NumericPolicy{})
 		require.NoError(t, err)
 
 		var specs []pipeline.Spec
@@ -289,8 +289,8 @@ func TestPipelineORM_Integration(t *testing.T) {
 
 				// Process the run
 				{
-					var anyRemaining bool
-					anyRemaining, err = orm.ProcessNextUnfinishedRun(context.Background(), func(_ context.Context, db *gorm.DB, spec pipeline.Spec, _ pipeline.JSONSerializable, l logger.Logger) (trrs pipeline.TaskRunResults, retry bool, err error) {
+					var run *pipeline.Run
+					run, err = orm.ProcessNextUnfinishedRun(context.Background(), func(_ context.Context, db *gorm.DB, spec pipeline.Spec, _ pipeline.JSONSerializable, l logger.Logger) (trrs pipeline.TaskRunResults, retry bool, err error) {
 						for dotID, result := range test.answers {
 							var tr pipeline.TaskRun
 							require.NoError(t, db.
@@ -309,17 +309,18 @@ func TestPipelineORM_Integration(t *testing.T) {
 						return trrs, false, nil
 					})
 					require.NoError(t, err)
-					require.True(t, anyRemaining)
+					require.NotNil(t, run)
+					require.Equal(t, runID, run.ID)
 				}
 
 				// Ensure that the ORM doesn't think there are more runs
 				{
-					anyRemaining, err2 := orm.ProcessNextUnfinishedRun(context.Background(), func(_ context.Context, db *gorm.DB, spec pipeline.Spec, _ pipeline.JSONSerializable, l logger.Logger) (pipeline.TaskRunResults, bool, error) {
+					run, err2 := orm.ProcessNextUnfinishedRun(context.Background(), func(_ context.Context, db *gorm.DB, spec pipeline.Spec, _ pipeline.JSONSerializable, l logger.Logger) (pipeline.TaskRunResults, bool, error) {
 						t.Fatal("this callback should never be reached")
 						return nil, false, nil
 					})
 					require.NoError(t, err2)
-					require.False(t, anyRemaining)
+					require.Nil(t, run)
 				}
 
 				// Allow the extra run to be deleted
@@ -8,9 +8,15 @@ import (
 	job "github.com/smartcontractkit/chainlink/core/services/job"
 	mock "github.com/stretchr/testify/mock"
 
+	models "github.com/smartcontractkit/chainlink/core/store/models"
+
+	orm "github.com/smartcontractkit/chainlink/core/store/orm"
+
 	pipeline "github.com/smartcontractkit/chainlink/core/services/pipeline"
 
 	postgres "github.com/smartcontractkit/chainlink/core/services/postgres"
+
+	time "time"
 )
 
 // ORM is an autogenerated mock type for the ORM type
@@ -18,6 +24,43 @@ type ORM struct {
 	mock.Mock
 }
 
+// ArchiveJob provides a mock function with given fields: ctx, id
+func (_m *ORM) ArchiveJob(ctx context.Context, id int32) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArchivedJobsV2 provides a mock function with given fields:
+func (_m *ORM) ArchivedJobsV2() ([]job.Job, error) {
+	ret := _m.Called()
+
+	var r0 []job.Job
+	if rf, ok := ret.Get(0).(func() []job.Job); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]job.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckForDeletedJobs provides a mock function with given fields: ctx
 func (_m *ORM) CheckForDeletedJobs(ctx context.Context) ([]int32, error) {
 	ret := _m.Called(ctx)
@@ -41,6 +84,34 @@ func (_m *ORM) CheckForDeletedJobs(ctx context.Context) ([]int32, error) {
 	return r0, r1
 }
 
+// ClaimJob provides a mock function with given fields: ctx, id
+func (_m *ORM) ClaimJob(ctx context.Context, id int32) (job.Job, bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 job.Job
+	if rf, ok := ret.Get(0).(func(context.Context, int32) job.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(job.Job)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, int32) bool); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int32) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ClaimUnclaimedJobs provides a mock function with given fields: ctx
 func (_m *ORM) ClaimUnclaimedJobs(ctx context.Context) ([]job.Job, error) {
 	ret := _m.Called(ctx)
@@ -64,6 +135,29 @@ func (_m *ORM) ClaimUnclaimedJobs(ctx context.Context) ([]job.Job, error) {
 	return r0, r1
 }
 
+// Claims provides a mock function with given fields:
+func (_m *ORM) Claims() ([]job.Claim, error) {
+	ret := _m.Called()
+
+	var r0 []job.Claim
+	if rf, ok := ret.Get(0).(func() []job.Claim); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]job.Claim)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *ORM) Close() error {
 	ret := _m.Called()
@@ -78,6 +172,52 @@ func (_m *ORM) Close() error {
 	return r0
 }
 
+// CostsByJobID provides a mock function with given fields: jobID, from, to
+func (_m *ORM) CostsByJobID(jobID int32, from time.Time, to time.Time) ([]job.Cost, error) {
+	ret := _m.Called(jobID, from, to)
+
+	var r0 []job.Cost
+	if rf, ok := ret.Get(0).(func(int32, time.Time, time.Time) []job.Cost); ok {
+		r0 = rf(jobID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]job.Cost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int32, time.Time, time.Time) error); ok {
+		r1 = rf(jobID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountJobsByType provides a mock function with given fields:
+func (_m *ORM) CountJobsByType() (map[job.Type]int, error) {
+	ret := _m.Called()
+
+	var r0 map[job.Type]int
+	if rf, ok := ret.Get(0).(func() map[job.Type]int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[job.Type]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateJob provides a mock function with given fields: ctx, jobSpec, taskDAG
 func (_m *ORM) CreateJob(ctx context.Context, jobSpec *job.Job, taskDAG pipeline.TaskDAG) error {
 	ret := _m.Called(ctx, jobSpec, taskDAG)
@@ -150,6 +290,66 @@ func (_m *ORM) FindJobIDsWithBridge(name string) ([]int32, error) {
 	return r0, r1
 }
 
+// FindMissingBridges provides a mock function with given fields:
+func (_m *ORM) FindMissingBridges() (map[int32][]string, error) {
+	ret := _m.Called()
+
+	var r0 map[int32][]string
+	if rf, ok := ret.Get(0).(func() map[int32][]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int32][]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindJobIDsWithPipelineSpec provides a mock function with given fields: specID
+func (_m *ORM) FindJobIDsWithPipelineSpec(specID int32) ([]int32, error) {
+	ret := _m.Called(specID)
+
+	var r0 []int32
+	if rf, ok := ret.Get(0).(func(int32) []int32); ok {
+		r0 = rf(specID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(specID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HeartbeatClaims provides a mock function with given fields: ctx
+func (_m *ORM) HeartbeatClaims(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // JobsV2 provides a mock function with given fields:
 func (_m *ORM) JobsV2() ([]job.Job, error) {
 	ret := _m.Called()
@@ -219,13 +419,89 @@ func (_m *ORM) ListenForNewJobs() (postgres.Subscription, error) {
 	return r0, r1
 }
 
-// PipelineRunsByJobID provides a mock function with given fields: jobID, offset, size
-func (_m *ORM) PipelineRunsByJobID(jobID int32, offset int, size int) ([]pipeline.Run, int, error) {
-	ret := _m.Called(jobID, offset, size)
+// OCRKeyBundleUsage provides a mock function with given fields: defaultID
+func (_m *ORM) OCRKeyBundleUsage(defaultID *models.Sha256Hash) (map[models.Sha256Hash]job.KeyUsage, error) {
+	ret := _m.Called(defaultID)
+
+	var r0 map[models.Sha256Hash]job.KeyUsage
+	if rf, ok := ret.Get(0).(func(*models.Sha256Hash) map[models.Sha256Hash]job.KeyUsage); ok {
+		r0 = rf(defaultID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[models.Sha256Hash]job.KeyUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Sha256Hash) error); ok {
+		r1 = rf(defaultID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// P2PKeyUsage provides a mock function with given fields: defaultPeerID
+func (_m *ORM) P2PKeyUsage(defaultPeerID *models.PeerID) (map[models.PeerID]job.KeyUsage, error) {
+	ret := _m.Called(defaultPeerID)
+
+	var r0 map[models.PeerID]job.KeyUsage
+	if rf, ok := ret.Get(0).(func(*models.PeerID) map[models.PeerID]job.KeyUsage); ok {
+		r0 = rf(defaultPeerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[models.PeerID]job.KeyUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.PeerID) error); ok {
+		r1 = rf(defaultPeerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaginatedJobsV2 provides a mock function with given fields: p, archived
+func (_m *ORM) PaginatedJobsV2(p orm.Pagination, archived bool) ([]job.Job, int, error) {
+	ret := _m.Called(p, archived)
+
+	var r0 []job.Job
+	if rf, ok := ret.Get(0).(func(orm.Pagination, bool) []job.Job); ok {
+		r0 = rf(p, archived)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]job.Job)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(orm.Pagination, bool) int); ok {
+		r1 = rf(p, archived)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(orm.Pagination, bool) error); ok {
+		r2 = rf(p, archived)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PipelineRunsByJobID provides a mock function with given fields: jobID, p
+func (_m *ORM) PipelineRunsByJobID(jobID int32, p orm.Pagination) ([]pipeline.Run, int, error) {
+	ret := _m.Called(jobID, p)
 
 	var r0 []pipeline.Run
-	if rf, ok := ret.Get(0).(func(int32, int, int) []pipeline.Run); ok {
-		r0 = rf(jobID, offset, size)
+	if rf, ok := ret.Get(0).(func(int32, orm.Pagination) []pipeline.Run); ok {
+		r0 = rf(jobID, p)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pipeline.Run)
@@ -233,15 +509,15 @@ func (_m *ORM) PipelineRunsByJobID(jobID int32, offset int, size int) ([]pipelin
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(int32, int, int) int); ok {
-		r1 = rf(jobID, offset, size)
+	if rf, ok := ret.Get(1).(func(int32, orm.Pagination) int); ok {
+		r1 = rf(jobID, p)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int32, int, int) error); ok {
-		r2 = rf(jobID, offset, size)
+	if rf, ok := ret.Get(2).(func(int32, orm.Pagination) error); ok {
+		r2 = rf(jobID, p)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -249,11 +525,62 @@ func (_m *ORM) PipelineRunsByJobID(jobID int32, offset int, size int) ([]pipelin
 	return r0, r1, r2
 }
 
+// PurgeArchivedJobs provides a mock function with given fields: ctx, archivedBefore
+func (_m *ORM) PurgeArchivedJobs(ctx context.Context, archivedBefore time.Time) (int, error) {
+	ret := _m.Called(ctx, archivedBefore)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, archivedBefore)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, archivedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RecordError provides a mock function with given fields: ctx, jobID, description
 func (_m *ORM) RecordError(ctx context.Context, jobID int32, description string) {
 	_m.Called(ctx, jobID, description)
 }
 
+// ShadowComparisonsByJobID provides a mock function with given fields: jobID, offset, size
+func (_m *ORM) ShadowComparisonsByJobID(jobID int32, offset int, size int) ([]pipeline.ShadowComparison, int, error) {
+	ret := _m.Called(jobID, offset, size)
+
+	var r0 []pipeline.ShadowComparison
+	if rf, ok := ret.Get(0).(func(int32, int, int) []pipeline.ShadowComparison); ok {
+		r0 = rf(jobID, offset, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.ShadowComparison)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int32, int, int) int); ok {
+		r1 = rf(jobID, offset, size)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int32, int, int) error); ok {
+		r2 = rf(jobID, offset, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UnclaimJob provides a mock function with given fields: ctx, id
 func (_m *ORM) UnclaimJob(ctx context.Context, id int32) error {
 	ret := _m.Called(ctx, id)
@@ -16,6 +16,20 @@ type Spawner struct {
 	mock.Mock
 }
 
+// ArchiveJob provides a mock function with given fields: ctx, jobID, force
+func (_m *Spawner) ArchiveJob(ctx context.Context, jobID int32, force bool) error {
+	ret := _m.Called(ctx, jobID, force)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, bool) error); ok {
+		r0 = rf(ctx, jobID, force)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *Spawner) Close() error {
 	ret := _m.Called()
@@ -51,8 +65,36 @@ func (_m *Spawner) CreateJob(ctx context.Context, spec job.Job, name null.String
 	return r0, r1
 }
 
-// DeleteJob provides a mock function with given fields: ctx, jobID
-func (_m *Spawner) DeleteJob(ctx context.Context, jobID int32) error {
+// DeleteJob provides a mock function with given fields: ctx, jobID, force
+func (_m *Spawner) DeleteJob(ctx context.Context, jobID int32, force bool) error {
+	ret := _m.Called(ctx, jobID, force)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, bool) error); ok {
+		r0 = rf(ctx, jobID, force)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Drain provides a mock function with given fields: ctx
+func (_m *Spawner) Drain(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestartJob provides a mock function with given fields: ctx, jobID
+func (_m *Spawner) RestartJob(ctx context.Context, jobID int32) error {
 	ret := _m.Called(ctx, jobID)
 
 	var r0 error
@@ -1,11 +1,15 @@
 package job
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/pkg/errors"
 
 	"gorm.io/gorm"
 
@@ -13,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	null "gopkg.in/guregu/null.v4"
@@ -23,6 +28,8 @@ const (
 	FluxMonitor       Type = "fluxmonitor"
 	OffchainReporting Type = "offchainreporting"
 	Keeper            Type = "keeper"
+	Shadow            Type = "shadow"
+	MessageQueue      Type = "messagequeue"
 )
 
 type IDEmbed struct {
@@ -52,6 +59,10 @@ type Job struct {
 	FluxMonitorSpec               *FluxMonitorSpec             `json:"fluxMonitorSpec"`
 	KeeperSpecID                  *int32                       `json:"-"`
 	KeeperSpec                    *KeeperSpec                  `json:"keeperSpec"`
+	ShadowSpecID                  *int32                       `json:"-"`
+	ShadowSpec                    *ShadowSpec                  `json:"shadowSpec"`
+	MessageQueueSpecID            *int32                       `json:"-"`
+	MessageQueueSpec              *MessageQueueSpec            `json:"messageQueueSpec"`
 	PipelineSpecID                int32                        `json:"-"`
 	PipelineSpec                  *pipeline.Spec               `json:"pipelineSpec"`
 	JobSpecErrors                 []SpecError                  `json:"errors" gorm:"foreignKey:JobID"`
@@ -60,12 +71,199 @@ type Job struct {
 	Name                          null.String                  `json:"name"`
 	MaxTaskDuration               models.Interval              `json:"maxTaskDuration"`
 	Pipeline                      pipeline.TaskDAG             `json:"-" toml:"observationSource" gorm:"-"`
+	// MetaSchema is an optional JSON schema that the meta of runs created
+	// for the job, e.g. from webhook payloads, must match
+	MetaSchema null.String `json:"metaSchema" toml:"metaSchema"`
+	// DependsOn is the name of an upstream job. Each successful run of the
+	// upstream job triggers a run of this job, with the upstream outputs as
+	// meta.
+	DependsOn null.String `json:"dependsOn" toml:"dependsOn"`
+	// NumericPolicy is the precision of the job's arithmetic tasks. It is
+	// stored with the pipeline spec.
+	NumericPolicy pipeline.NumericPolicy `json:"numericPolicy" toml:"numericPolicy" gorm:"-"`
+	// MaxGasCostWei is an optional cap on the estimated cost of each of the
+	// job's transmissions. Transmissions that would cost more at the current
+	// gas price are skipped, see GasCostGuard.
+	MaxGasCostWei *utils.Big `json:"maxGasCostWei" toml:"maxGasCostWei"`
+	// OnComplete is what the node does once each of the job's runs has
+	// finished
+	OnComplete OnComplete `json:"onComplete" toml:"onComplete" gorm:"embedded;embeddedPrefix:on_complete_"`
+	// SpecChecksum is the checksum of the spec the job was created from
+	SpecChecksum null.String `json:"specChecksum" toml:"-"`
+	// ContractENSName is the ENS name that the job's contractAddress was
+	// given as. It was resolved when the job was created, and is re-resolved
+	// periodically to detect when it changes.
+	ContractENSName null.String `json:"contractENSName" toml:"-"`
+	// ArchivedAt is set when the job is deleted. Archived jobs are no longer
+	// run, but are kept along with their runs until JOB_ARCHIVE_RETENTION
+	// has passed.
+	ArchivedAt null.Time `json:"archivedAt" toml:"-"`
 }
 
 func (Job) TableName() string {
 	return "jobs"
 }
 
+// OnComplete is the [onComplete] table of a job's TOML spec. The result of
+// each run of the job is delivered to its webhook and its message queue
+// once the run has finished, see package oncomplete.
+type OnComplete struct {
+	// WebhookURL is POSTed the result of each run
+	WebhookURL null.String `json:"webhookURL" toml:"webhookURL"`
+	// WebhookSecret signs the webhook's requests with HMAC-SHA256, so that
+	// the receiver can tell that they came from the node
+	WebhookSecret null.String `json:"-" toml:"webhookSecret" gorm:"encrypted"`
+	// QueueURL is the message queue the result of each run is published to:
+	// kafka://broker1:9092,broker2:9092, nats://host:4222 or
+	// redis://host:6379/0, with credentials in the URL's user info
+	QueueURL null.String `json:"-" toml:"queueURL" gorm:"encrypted"`
+	// QueueTopic is the Kafka topic, NATS subject or Redis stream that
+	// results are published to
+	QueueTopic null.String `json:"queueTopic" toml:"queueTopic"`
+	// QueueFormat is how results are serialized for the queue, json by
+	// default
+	QueueFormat null.String `json:"queueFormat" toml:"queueFormat"`
+}
+
+// Formats of the results published to a message queue
+const (
+	QueueFormatJSON = "json"
+	QueueFormatAvro = "avro"
+)
+
+// Validate returns an error if the webhook URL is not an http or https URL,
+// the queue URL is not of a supported queue, or settings are given without
+// the URL they apply to
+func (oc OnComplete) Validate() error {
+	if err := oc.validateWebhook(); err != nil {
+		return err
+	}
+	return oc.validateQueue()
+}
+
+func (oc OnComplete) validateWebhook() error {
+	if !oc.WebhookURL.Valid {
+		if oc.WebhookSecret.Valid {
+			return errors.New("onComplete.webhookSecret requires onComplete.webhookURL")
+		}
+		return nil
+	}
+	u, err := url.Parse(oc.WebhookURL.String)
+	if err != nil {
+		return errors.Wrap(err, "invalid onComplete.webhookURL")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("invalid onComplete.webhookURL %q, must be an http or https URL", oc.WebhookURL.String)
+	}
+	if oc.WebhookSecret.Valid && oc.WebhookSecret.String == "" {
+		return errors.New("onComplete.webhookSecret must not be empty")
+	}
+	return nil
+}
+
+func (oc OnComplete) validateQueue() error {
+	if !oc.QueueURL.Valid {
+		if oc.QueueTopic.Valid || oc.QueueFormat.Valid {
+			return errors.New("onComplete.queueTopic and onComplete.queueFormat require onComplete.queueURL")
+		}
+		return nil
+	}
+	u, err := url.Parse(oc.QueueURL.String)
+	if err != nil {
+		// The URL is not included, as it may hold credentials
+		return errors.New("invalid onComplete.queueURL")
+	}
+	switch u.Scheme {
+	case "kafka", "nats", "redis":
+	default:
+		return errors.Errorf("unsupported onComplete.queueURL scheme %q, must be kafka, nats or redis", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("onComplete.queueURL must name the host of the queue, such as kafka://broker1:9092,broker2:9092")
+	}
+	if oc.QueueTopic.String == "" {
+		return errors.New("onComplete.queueTopic must name the kafka topic, nats subject or redis stream to publish to")
+	}
+	if err := ValidateQueueTopic(u.Scheme, oc.QueueTopic.String); err != nil {
+		return errors.Wrap(err, "invalid onComplete.queueTopic")
+	}
+	tlsConfig, err := QueueTLSConfig(u)
+	if err != nil {
+		return errors.Wrap(err, "invalid onComplete.queueURL")
+	}
+	if u.User != nil && tlsConfig == nil {
+		return errors.New("onComplete.queueURL must have tls=true to be given credentials, so that they aren't sent in plaintext")
+	}
+	if oc.QueueFormat.Valid && oc.QueueFormat.String != QueueFormatJSON && oc.QueueFormat.String != QueueFormatAvro {
+		return errors.Errorf("unsupported onComplete.queueFormat %q, must be json or avro", oc.QueueFormat.String)
+	}
+	return nil
+}
+
+// QueueTLSConfig returns the TLS settings of a message queue's URL, which
+// connects over TLS when it has tls=true, and nil if it doesn't
+func QueueTLSConfig(u *url.URL) (*tls.Config, error) {
+	switch value := u.Query().Get("tls"); value {
+	case "", "false":
+		return nil, nil
+	case "true":
+		return &tls.Config{MinVersion: tls.VersionTLS12}, nil
+	default:
+		return nil, errors.Errorf("tls must be true or false, got %q", value)
+	}
+}
+
+var (
+	kafkaTopicRegexp  = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+	natsSubjectRegexp = regexp.MustCompile(`^[^\x00-\x20\x7f.*>]+(\.[^\x00-\x20\x7f.*>]+)*$`)
+	redisStreamRegexp = regexp.MustCompile(`^[^\x00-\x20\x7f]+$`)
+)
+
+// ValidateQueueTopic returns an error unless topic is a valid kafka topic,
+// nats subject or redis stream name, according to scheme. Topics are written
+// into the protocols of the queues, so whitespace and control characters are
+// never allowed, and nats subjects must not contain wildcards.
+func ValidateQueueTopic(scheme, topic string) error {
+	switch scheme {
+	case "kafka":
+		if !kafkaTopicRegexp.MatchString(topic) {
+			return errors.Errorf("%q is not a valid kafka topic, which may only contain letters, digits, '.', '_' and '-'", topic)
+		}
+	case "nats":
+		if !natsSubjectRegexp.MatchString(topic) {
+			return errors.Errorf("%q is not a valid nats subject, which must be non-empty tokens separated by '.', without whitespace, control characters or wildcards", topic)
+		}
+	case "redis":
+		if !redisStreamRegexp.MatchString(topic) {
+			return errors.Errorf("%q is not a valid redis stream, which must not contain whitespace or control characters", topic)
+		}
+	default:
+		return errors.Errorf("unsupported queue scheme %q", scheme)
+	}
+	return nil
+}
+
+// BridgeNames returns the names of the bridges that the job's pipeline calls,
+// each once
+func (j Job) BridgeNames() ([]string, error) {
+	names := []string{}
+	if j.PipelineSpec == nil {
+		return names, nil
+	}
+	tasks, err := j.PipelineSpec.TasksInDependencyOrder()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		if name, ok := bridgeName(task); ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
 type SpecError struct {
 	ID          int64     `json:"id" gorm:"primary_key"`
 	JobID       int32     `json:"-"`
@@ -79,6 +277,59 @@ func (SpecError) TableName() string {
 	return "job_spec_errors_v2"
 }
 
+// Claim records which node instance currently holds the lock on a job.
+// LastHeartbeatAt is refreshed periodically while the claim is held, so a
+// stale heartbeat indicates that the claiming node has likely gone away.
+type Claim struct {
+	JobID           int32     `json:"-" gorm:"primary_key"`
+	NodeID          string    `json:"nodeID"`
+	ClaimedAt       time.Time `json:"claimedAt"`
+	LastHeartbeatAt time.Time `json:"lastHeartbeatAt"`
+}
+
+func (Claim) TableName() string {
+	return "job_claims"
+}
+
+// CostKind is what a job spent resources on
+type CostKind string
+
+const (
+	CostKindHTTP        CostKind = "http"
+	CostKindBridge      CostKind = "bridge"
+	CostKindTransaction CostKind = "transaction"
+)
+
+// Cost is the resources that a job's runs used on one day, for one kind of
+// cost and one target. The target is the host called by http and bridge
+// tasks, and the contract address of transactions. GasUsed and FeeWei are
+// only set for transactions, which are counted on the day they were created
+// on once they have been confirmed.
+type Cost struct {
+	JobID         int32      `json:"jobID"`
+	Day           time.Time  `json:"day"`
+	Kind          CostKind   `json:"kind"`
+	Target        string     `json:"target"`
+	Calls         int64      `json:"calls"`
+	BytesSent     int64      `json:"bytesSent"`
+	BytesReceived int64      `json:"bytesReceived"`
+	GasUsed       *utils.Big `json:"gasUsed"`
+	FeeWei        *utils.Big `json:"feeWei"`
+}
+
+func (Cost) TableName() string {
+	return "job_costs"
+}
+
+// KeyUsage describes the jobs that reference a key. LastUsedAt is the last
+// time that any of those jobs updated its OCR protocol state, and
+// ConfigDigests lists the on-chain configs that those jobs have seen.
+type KeyUsage struct {
+	JobIDs        []int32
+	LastUsedAt    *time.Time
+	ConfigDigests []string
+}
+
 type PipelineRun struct {
 	ID int64 `json:"-" gorm:"primary_key"`
 }
@@ -103,11 +354,18 @@ type OffchainReportingOracleSpec struct {
 	ContractAddress                        models.EIP55Address  `json:"contractAddress" toml:"contractAddress"`
 	P2PPeerID                              *models.PeerID       `json:"p2pPeerID" toml:"p2pPeerID" gorm:"column:p2p_peer_id;default:null"`
 	P2PBootstrapPeers                      pq.StringArray       `json:"p2pBootstrapPeers" toml:"p2pBootstrapPeers" gorm:"column:p2p_bootstrap_peers;type:text[]"`
+	P2PPeers                               pq.StringArray       `json:"p2pPeers" toml:"p2pPeers" gorm:"column:p2p_peers;type:text[]"`
 	IsBootstrapPeer                        bool                 `json:"isBootstrapPeer" toml:"isBootstrapPeer"`
 	EncryptedOCRKeyBundleID                *models.Sha256Hash   `json:"keyBundleID" toml:"keyBundleID"                 gorm:"type:bytea"`
+	MonitoringEndpoint                     string               `json:"monitoringEndpoint" toml:"monitoringEndpoint"`
 	TransmitterAddress                     *models.EIP55Address `json:"transmitterAddress" toml:"transmitterAddress"`
+	ForwarderAddress                       *models.EIP55Address `json:"forwarderAddress" toml:"forwarderAddress"`
+	TransmitDisabled                       bool                 `json:"transmitDisabled" toml:"transmitDisabled"`
+	Decimals                               *uint8               `json:"decimals" toml:"decimals" gorm:"type:smallint"`
 	ObservationTimeout                     models.Interval      `json:"observationTimeout" toml:"observationTimeout" gorm:"type:bigint;default:null"`
+	LatencyBudget                          bool                 `json:"latencyBudget" toml:"latencyBudget"`
 	BlockchainTimeout                      models.Interval      `json:"blockchainTimeout" toml:"blockchainTimeout" gorm:"type:bigint;default:null"`
+	TransmissionDeadline                   models.Interval      `json:"transmissionDeadline" toml:"transmissionDeadline" gorm:"type:bigint;default:null"`
 	ContractConfigTrackerSubscribeInterval models.Interval      `json:"contractConfigTrackerSubscribeInterval" toml:"contractConfigTrackerSubscribeInterval" gorm:"default:null"`
 	ContractConfigTrackerPollInterval      models.Interval      `json:"contractConfigTrackerPollInterval" toml:"contractConfigTrackerPollInterval" gorm:"type:bigint;default:null"`
 	ContractConfigConfirmations            uint16               `json:"contractConfigConfirmations" toml:"contractConfigConfirmations"`
@@ -181,3 +439,29 @@ type KeeperSpec struct {
 	CreatedAt       time.Time           `json:"createdAt" toml:"-"`
 	UpdatedAt       time.Time           `json:"updatedAt" toml:"-"`
 }
+
+// ShadowSpec is the spec of a job that shadows another job. It runs its own
+// observationSource each time the primary job runs, and records how its
+// result compares with the primary's, but never transmits anything.
+type ShadowSpec struct {
+	IDEmbed
+	ShadowOfJobID int32     `json:"shadowOfJobID" toml:"shadowOf"`
+	CreatedAt     time.Time `json:"createdAt" toml:"-"`
+	UpdatedAt     time.Time `json:"updatedAt" toml:"-"`
+}
+
+// MessageQueueSpec is the spec of a job that runs once for each message
+// consumed from a Kafka topic or an AMQP queue. URL is either
+// kafka://broker1:9092,broker2:9092 or an amqp:// or amqps:// URL, and Topic
+// names the Kafka topic or AMQP queue to consume.
+type MessageQueueSpec struct {
+	IDEmbed
+	URL   string `json:"url" toml:"url"`
+	Topic string `json:"topic" toml:"topic"`
+	// ConsumerGroup is the Kafka consumer group the job joins. Jobs in the
+	// same group share the topic's partitions between them. It defaults to a
+	// group of the job's own, and is unused by AMQP queues.
+	ConsumerGroup string    `json:"consumerGroup" toml:"consumerGroup"`
+	CreatedAt     time.Time `json:"createdAt" toml:"-"`
+	UpdatedAt     time.Time `json:"updatedAt" toml:"-"`
+}
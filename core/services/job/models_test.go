@@ -0,0 +1,54 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+func TestOnComplete_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		onComplete job.OnComplete
+		valid      bool
+	}{
+		{"no webhook", job.OnComplete{}, true},
+		{"webhook", job.OnComplete{WebhookURL: null.StringFrom("https://example.com/runs")}, true},
+		{"signed webhook", job.OnComplete{WebhookURL: null.StringFrom("http://localhost:8080"), WebhookSecret: null.StringFrom("secret")}, true},
+		{"secret without webhook", job.OnComplete{WebhookSecret: null.StringFrom("secret")}, false},
+		{"empty secret", job.OnComplete{WebhookURL: null.StringFrom("https://example.com"), WebhookSecret: null.StringFrom("")}, false},
+		{"not http", job.OnComplete{WebhookURL: null.StringFrom("ftp://example.com")}, false},
+		{"no host", job.OnComplete{WebhookURL: null.StringFrom("https://")}, false},
+		{"unparseable", job.OnComplete{WebhookURL: null.StringFrom("https://exa mple.com:port")}, false},
+		{"kafka", job.OnComplete{QueueURL: null.StringFrom("kafka://user:pass@b1:9092,b2:9092?tls=true"), QueueTopic: null.StringFrom("runs")}, true},
+		{"credentials without tls", job.OnComplete{QueueURL: null.StringFrom("redis://:pass@localhost:6379"), QueueTopic: null.StringFrom("runs")}, false},
+		{"invalid tls", job.OnComplete{QueueURL: null.StringFrom("nats://localhost:4222?tls=yes"), QueueTopic: null.StringFrom("runs")}, false},
+		{"nats with avro", job.OnComplete{QueueURL: null.StringFrom("nats://localhost:4222"), QueueTopic: null.StringFrom("runs.results"), QueueFormat: null.StringFrom("avro")}, true},
+		{"redis and webhook", job.OnComplete{WebhookURL: null.StringFrom("https://example.com"), QueueURL: null.StringFrom("redis://localhost:6379/1"), QueueTopic: null.StringFrom("runs")}, true},
+		{"unsupported queue", job.OnComplete{QueueURL: null.StringFrom("amqp://localhost"), QueueTopic: null.StringFrom("runs")}, false},
+		{"queue without host", job.OnComplete{QueueURL: null.StringFrom("kafka:///runs"), QueueTopic: null.StringFrom("runs")}, false},
+		{"queue without topic", job.OnComplete{QueueURL: null.StringFrom("nats://localhost:4222")}, false},
+		{"topic without queue", job.OnComplete{QueueTopic: null.StringFrom("runs")}, false},
+		{"kafka topic with invalid characters", job.OnComplete{QueueURL: null.StringFrom("kafka://localhost:9092"), QueueTopic: null.StringFrom("runs/results"), QueueFormat: null.StringFrom("json")}, false},
+		{"nats subject with whitespace", job.OnComplete{QueueURL: null.StringFrom("nats://localhost:4222"), QueueTopic: null.StringFrom("runs 5\r\nPUB other")}, false},
+		{"nats subject with empty token", job.OnComplete{QueueURL: null.StringFrom("nats://localhost:4222"), QueueTopic: null.StringFrom("runs..results")}, false},
+		{"nats subject with wildcard", job.OnComplete{QueueURL: null.StringFrom("nats://localhost:4222"), QueueTopic: null.StringFrom("runs.>")}, false},
+		{"redis stream with control characters", job.OnComplete{QueueURL: null.StringFrom("redis://localhost:6379"), QueueTopic: null.StringFrom("runs\r\nFLUSHALL")}, false},
+		{"unsupported format", job.OnComplete{QueueURL: null.StringFrom("nats://localhost:4222"), QueueTopic: null.StringFrom("runs"), QueueFormat: null.StringFrom("protobuf")}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.onComplete.Validate()
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	storm "github.com/smartcontractkit/chainlink/core/store/orm"
 
 	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
 
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
@@ -24,10 +25,18 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
+func init() {
+	// A job's onComplete webhook secret and queue URL are encrypted columns
+	storm.RegisterEncryptedModel(&Job{})
+}
+
 var (
 	ErrNoSuchPeerID             = errors.New("no such peer id exists")
 	ErrNoSuchKeyBundle          = errors.New("no such key bundle exists")
 	ErrNoSuchTransmitterAddress = errors.New("no such transmitter address exists")
+	ErrNoSuchForwarder          = errors.New("no such forwarder exists")
+	ErrInvalidShadowOf          = errors.New("invalid job to shadow")
+	ErrInvalidDependsOn         = errors.New("invalid job to depend on")
 )
 
 //go:generate mockery --name ORM --output ./mocks/ --case=underscore
@@ -40,16 +49,30 @@ type ORM interface {
 	ListenForNewJobs() (postgres.Subscription, error)
 	ListenForDeletedJobs() (postgres.Subscription, error)
 	ClaimUnclaimedJobs(ctx context.Context) ([]Job, error)
+	ClaimJob(ctx context.Context, id int32) (Job, bool, error)
 	CreateJob(ctx context.Context, jobSpec *Job, taskDAG pipeline.TaskDAG) error
 	JobsV2() ([]Job, error)
+	PaginatedJobsV2(p storm.Pagination, archived bool) ([]Job, int, error)
 	FindJob(id int32) (Job, error)
 	FindJobIDsWithBridge(name string) ([]int32, error)
+	FindMissingBridges() (map[int32][]string, error)
+	FindJobIDsWithPipelineSpec(specID int32) ([]int32, error)
+	OCRKeyBundleUsage(defaultID *models.Sha256Hash) (map[models.Sha256Hash]KeyUsage, error)
+	P2PKeyUsage(defaultPeerID *models.PeerID) (map[models.PeerID]KeyUsage, error)
 	DeleteJob(ctx context.Context, id int32) error
+	ArchiveJob(ctx context.Context, id int32) error
+	ArchivedJobsV2() ([]Job, error)
+	CountJobsByType() (map[Type]int, error)
+	PurgeArchivedJobs(ctx context.Context, archivedBefore time.Time) (int, error)
 	RecordError(ctx context.Context, jobID int32, description string)
 	UnclaimJob(ctx context.Context, id int32) error
+	HeartbeatClaims(ctx context.Context) error
+	Claims() ([]Claim, error)
 	CheckForDeletedJobs(ctx context.Context) (deletedJobIDs []int32, err error)
 	Close() error
-	PipelineRunsByJobID(jobID int32, offset, size int) ([]pipeline.Run, int, error)
+	PipelineRunsByJobID(jobID int32, p storm.Pagination) ([]pipeline.Run, int, error)
+	ShadowComparisonsByJobID(jobID int32, offset, size int) ([]pipeline.ShadowComparison, int, error)
+	CostsByJobID(jobID int32, from, to time.Time) ([]Cost, error)
 }
 
 type orm struct {
@@ -90,35 +113,91 @@ func (o *orm) ListenForDeletedJobs() (postgres.Subscription, error) {
 	return o.eventBroadcaster.Subscribe(postgres.ChannelJobDeleted, "")
 }
 
-// ClaimUnclaimedJobs locks all currently unlocked jobs and returns all jobs locked by this process
+// ClaimUnclaimedJobs locks up to JobSpawnerClaimBatchSize jobs that are not
+// currently claimed by any node and returns the newly claimed jobs. No jobs
+// are claimed once this node holds JobSpawnerMaxClaimedJobs claims.
 func (o *orm) ClaimUnclaimedJobs(ctx context.Context) ([]Job, error) {
 	o.claimedJobsMu.Lock()
 	defer o.claimedJobsMu.Unlock()
 
-	claimedJobIDs := o.claimedJobIDs()
-
-	var join string
-	var args []interface{}
-	if len(claimedJobIDs) > 0 {
-		// NOTE: OFFSET 0 is a postgres trick that doesn't change the result,
-		// but prevents the optimiser from trying to pull the where condition
-		// up out of the subquery
-		join = `
-            INNER JOIN (
-                SELECT not_claimed_by_us.id, pg_try_advisory_lock(?::integer, not_claimed_by_us.id) AS locked
-                FROM (SELECT id FROM jobs WHERE NOT (id = ANY(?)) OFFSET 0) not_claimed_by_us
-            ) claimed_jobs ON jobs.id = claimed_jobs.id AND claimed_jobs.locked
-        `
-		args = []interface{}{o.advisoryLockClassID, pq.Array(claimedJobIDs)}
-	} else {
-		join = `
-            INNER JOIN (
-                SELECT not_claimed_by_us.id, pg_try_advisory_lock(?::integer, not_claimed_by_us.id) AS locked
-                FROM jobs not_claimed_by_us
-            ) claimed_jobs ON jobs.id = claimed_jobs.id AND claimed_jobs.locked
-        `
-		args = []interface{}{o.advisoryLockClassID}
+	limit, ok := o.claimLimit(int(o.config.JobSpawnerClaimBatchSize()))
+	if !ok {
+		return nil, nil
+	}
+	jobs, err := o.claimJobs("", nil, limit)
+	return jobs, errors.Wrap(err, "ClaimUnclaimedJobs failed to load jobs")
+}
+
+// ClaimJob attempts to lock a single job, for example one that was just
+// announced by a NOTIFY event. It returns whether the claim succeeded.
+func (o *orm) ClaimJob(ctx context.Context, id int32) (Job, bool, error) {
+	o.claimedJobsMu.Lock()
+	defer o.claimedJobsMu.Unlock()
+
+	if _, exists := o.claimedJobs[id]; exists {
+		return Job{}, false, nil
+	}
+	if _, ok := o.claimLimit(1); !ok {
+		return Job{}, false, nil
+	}
+	jobs, err := o.claimJobs("AND id = ?", []interface{}{id}, 1)
+	if err != nil {
+		return Job{}, false, errors.Wrapf(err, "ClaimJob failed to load job %v", id)
+	} else if len(jobs) == 0 {
+		return Job{}, false, nil
 	}
+	return jobs[0], true, nil
+}
+
+// claimLimit returns the maximum number of jobs that may be claimed right
+// now, where 0 means unlimited. ok is false if no more claims may be taken.
+func (o *orm) claimLimit(batchSize int) (limit int, ok bool) {
+	limit = batchSize
+	if maxClaimed := int(o.config.JobSpawnerMaxClaimedJobs()); maxClaimed > 0 {
+		remaining := maxClaimed - len(o.claimedJobs)
+		if remaining <= 0 {
+			return 0, false
+		}
+		if limit == 0 || remaining < limit {
+			limit = remaining
+		}
+	}
+	return limit, true
+}
+
+// claimJobs locks jobs that are neither claimed by us nor by any other node.
+// Jobs locked by other nodes are filtered out using pg_locks up front, so
+// that they don't count towards the limit.
+// The caller must hold claimedJobsMu.
+func (o *orm) claimJobs(filter string, filterArgs []interface{}, limit int) ([]Job, error) {
+	var limitClause string
+	if limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", limit)
+	}
+
+	// NOTE: OFFSET 0 is a postgres trick that doesn't change the result,
+	// but prevents the optimiser from trying to pull the where condition
+	// up out of the subquery
+	/* #nosec G201 */
+	join := fmt.Sprintf(`
+        INNER JOIN (
+            SELECT not_claimed.id, pg_try_advisory_lock(?::integer, not_claimed.id) AS locked
+            FROM (
+                SELECT id FROM jobs
+                WHERE archived_at IS NULL
+                AND NOT (id = ANY(?))
+                AND id NOT IN (
+                    SELECT objid::bigint FROM pg_locks
+                    WHERE locktype = 'advisory' AND classid::bigint = ? AND objsubid = 2 AND granted
+                )
+                %s
+                ORDER BY id ASC
+                %s
+                OFFSET 0
+            ) not_claimed
+        ) claimed_jobs ON jobs.id = claimed_jobs.id AND claimed_jobs.locked
+    `, filter, limitClause)
+	args := append([]interface{}{o.advisoryLockClassID, pq.Array(o.claimedJobIDs()), o.advisoryLockClassID}, filterArgs...)
 
 	var newlyClaimedJobs []Job
 	err := o.db.
@@ -126,17 +205,59 @@ func (o *orm) ClaimUnclaimedJobs(ctx context.Context) ([]Job, error) {
 		Preload("FluxMonitorSpec").
 		Preload("OffchainreportingOracleSpec").
 		Preload("KeeperSpec").
+		Preload("ShadowSpec").
+		Preload("MessageQueueSpec").
 		Preload("PipelineSpec").
 		Find(&newlyClaimedJobs).Error
 	if err != nil {
-		return nil, errors.Wrap(err, "ClaimUnclaimedJobs failed to load jobs")
+		return nil, err
 	}
 
 	for _, job := range newlyClaimedJobs {
 		o.claimedJobs[job.ID] = job
 	}
+	if err := o.recordClaims(newlyClaimedJobs); err != nil {
+		logger.Errorw("Failed to record job claims", "error", err)
+	}
+	return newlyClaimedJobs, nil
+}
 
-	return newlyClaimedJobs, errors.Wrap(err, "Job Spawner ORM could not load unclaimed job specs")
+// recordClaims stores this node's claim on the given jobs, taking over any
+// claims left behind by nodes that have since released their locks.
+func (o *orm) recordClaims(jobs []Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	claims := make([]Claim, len(jobs))
+	for i, job := range jobs {
+		claims[i] = Claim{JobID: job.ID, NodeID: o.config.NodeID(), ClaimedAt: now, LastHeartbeatAt: now}
+	}
+	return o.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "job_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"node_id", "claimed_at", "last_heartbeat_at"}),
+		}).
+		Create(&claims).
+		Error
+}
+
+// HeartbeatClaims refreshes the heartbeat on every claim held by this node
+func (o *orm) HeartbeatClaims(ctx context.Context) error {
+	o.claimedJobsMu.RLock()
+	defer o.claimedJobsMu.RUnlock()
+	err := o.db.WithContext(ctx).Exec(
+		`UPDATE job_claims SET last_heartbeat_at = NOW() WHERE node_id = ? AND job_id = ANY(?)`,
+		o.config.NodeID(), pq.Array(o.claimedJobIDs()),
+	).Error
+	return errors.Wrap(err, "HeartbeatClaims failed")
+}
+
+// Claims returns the most recently recorded claim for every claimed job
+func (o *orm) Claims() ([]Claim, error) {
+	var claims []Claim
+	err := o.db.Order("job_id ASC").Find(&claims).Error
+	return claims, errors.Wrap(err, "Claims failed")
 }
 
 func (o *orm) claimedJobIDs() (ids []int32) {
@@ -147,33 +268,102 @@ func (o *orm) claimedJobIDs() (ids []int32) {
 	return
 }
 
+// checkResponseSchema checks that the responseSchema of an http or bridge
+// task, if it has one, is a valid schema
+func checkResponseSchema(task pipeline.Task) error {
+	var schema string
+	switch t := task.(type) {
+	case *pipeline.HTTPTask:
+		schema = t.ResponseSchema
+	case *pipeline.BridgeTask:
+		schema = t.ResponseSchema
+	}
+	if schema == "" {
+		return nil
+	}
+	_, err := pipeline.ParseJSONSchema(schema)
+	return errors.Wrapf(err, "invalid responseSchema for task %s", task.DotID())
+}
+
+// checkAllowedStatusCodes checks that the allowedStatusCodes of an http or
+// bridge task, if it has any, can be parsed
+func checkAllowedStatusCodes(task pipeline.Task) error {
+	var codes string
+	switch t := task.(type) {
+	case *pipeline.HTTPTask:
+		codes = t.AllowedStatusCodes
+	case *pipeline.BridgeTask:
+		codes = t.AllowedStatusCodes
+	}
+	if codes == "" {
+		return nil
+	}
+	_, err := pipeline.ParseStatusCodes(codes)
+	return errors.Wrapf(err, "invalid allowedStatusCodes for task %s", task.DotID())
+}
+
 func (o *orm) CreateJob(ctx context.Context, jobSpec *Job, taskDAG pipeline.TaskDAG) error {
 	if taskDAG.HasCycles() {
 		return errors.New("task DAG has cycles, which are not permitted")
 	}
+	checked := *jobSpec
+	checked.Pipeline = taskDAG
+	if err := CheckJob(o.config, checked); err != nil {
+		return err
+	}
 	tasks, err := taskDAG.TasksInDependencyOrder()
 	if err != nil {
 		return err
 	}
 	for _, task := range tasks {
-		if task.Type() == pipeline.TaskTypeBridge {
+		if err := checkResponseSchema(task); err != nil {
+			return err
+		}
+		if err := checkAllowedStatusCodes(task); err != nil {
+			return err
+		}
+		if name, ok := bridgeName(task); ok {
 			// Bridge must exist
-			name := task.(*pipeline.BridgeTask).Name
 			bt := models.BridgeType{}
 			if err := o.db.First(&bt, "name = ?", name).Error; err != nil {
 				if errors.Is(err, gorm.ErrRecordNotFound) {
-					return errors.Wrap(pipeline.ErrNoSuchBridge, name)
+					return pipeline.NoSuchBridgeError{Name: name}
 				}
 				return err
 			}
 		}
 	}
 
+	if err := jobSpec.NumericPolicy.Validate(); err != nil {
+		return err
+	}
+	if jobSpec.MaxGasCostWei != nil && jobSpec.MaxGasCostWei.ToInt().Sign() <= 0 {
+		return errors.New("maxGasCostWei must be positive")
+	}
+
+	if jobSpec.MetaSchema.Valid {
+		if _, err := pipeline.ParseJSONSchema(jobSpec.MetaSchema.String); err != nil {
+			return errors.Wrap(err, "invalid metaSchema")
+		}
+	}
+
+	if jobSpec.Type == Shadow {
+		if err := o.checkShadowOf(jobSpec.ShadowSpec.ShadowOfJobID); err != nil {
+			return err
+		}
+	}
+
+	if jobSpec.DependsOn.Valid {
+		if err := o.checkDependsOn(jobSpec.Name, jobSpec.DependsOn.String); err != nil {
+			return err
+		}
+	}
+
 	ctx, cancel := utils.CombinedContext(ctx, o.config.DatabaseMaximumTxDuration())
 	defer cancel()
 
 	return postgres.GormTransaction(ctx, o.db, func(tx *gorm.DB) error {
-		pipelineSpecID, err := o.pipelineORM.CreateSpec(ctx, tx, taskDAG, jobSpec.MaxTaskDuration)
+		pipelineSpecID, err := o.pipelineORM.CreateSpec(ctx, tx, taskDAG, jobSpec.MaxTaskDuration, jobSpec.NumericPolicy)
 		if err != nil {
 			return errors.Wrap(err, "failed to create pipeline spec")
 		}
@@ -189,6 +379,9 @@ func (o *orm) CreateJob(ctx context.Context, jobSpec *Job, taskDAG pipeline.Task
 				if pqErr.ConstraintName == "offchainreporting_oracle_specs_transmitter_address_fkey" {
 					return errors.Wrapf(ErrNoSuchTransmitterAddress, "%v", jobSpec.OffchainreportingOracleSpec.TransmitterAddress)
 				}
+				if pqErr.ConstraintName == "offchainreporting_oracle_specs_forwarder_address_fkey" {
+					return errors.Wrapf(ErrNoSuchForwarder, "%v", jobSpec.OffchainreportingOracleSpec.ForwarderAddress)
+				}
 				if pqErr.ConstraintName == "offchainreporting_oracle_specs_encrypted_ocr_key_bundle_id_fkey" {
 					return errors.Wrapf(ErrNoSuchKeyBundle, "%v", jobSpec.OffchainreportingOracleSpec.EncryptedOCRKeyBundleID)
 				}
@@ -198,23 +391,82 @@ func (o *orm) CreateJob(ctx context.Context, jobSpec *Job, taskDAG pipeline.Task
 	})
 }
 
-// DeleteJob removes a job that is claimed by this orm
+// checkShadowOf returns an error wrapping ErrInvalidShadowOf unless the job
+// with the given ID can be shadowed. Shadow jobs can't themselves be shadowed,
+// and nor can bootstrap peers, which never run their pipeline.
+func (o *orm) checkShadowOf(id int32) error {
+	var primary Job
+	err := o.db.Preload("OffchainreportingOracleSpec").First(&primary, "jobs.id = ? AND archived_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.Wrapf(ErrInvalidShadowOf, "no such job %v", id)
+	} else if err != nil {
+		return err
+	}
+	if primary.Type == Shadow {
+		return errors.Wrapf(ErrInvalidShadowOf, "job %v is itself a shadow job", id)
+	}
+	if primary.OffchainreportingOracleSpec != nil && primary.OffchainreportingOracleSpec.IsBootstrapPeer {
+		return errors.Wrapf(ErrInvalidShadowOf, "job %v is a bootstrap peer", id)
+	}
+	return nil
+}
+
+// checkDependsOn returns an error wrapping ErrInvalidDependsOn unless the job
+// named upstream can be depended on by a job with the given name. Exactly one
+// job must have the upstream name, and following the chain of jobs it
+// depends on must not lead back to the new job.
+func (o *orm) checkDependsOn(name null.String, upstream string) error {
+	seen := map[string]bool{}
+	for next := null.StringFrom(upstream); next.Valid; {
+		if (name.Valid && next.String == name.String) || seen[next.String] {
+			return errors.Wrapf(ErrInvalidDependsOn, "depending on %q would make a cycle", upstream)
+		}
+		seen[next.String] = true
+		var jobs []Job
+		err := o.db.Where("name = ? AND archived_at IS NULL", next.String).Limit(2).Find(&jobs).Error
+		if err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return errors.Wrapf(ErrInvalidDependsOn, "no job named %q", next.String)
+		} else if len(jobs) > 1 {
+			return errors.Wrapf(ErrInvalidDependsOn, "more than one job is named %q", next.String)
+		}
+		next = jobs[0].DependsOn
+	}
+	return nil
+}
+
+// DeleteJob removes a job that is claimed by this orm, along with any jobs
+// shadowing it
 func (o *orm) DeleteJob(ctx context.Context, id int32) error {
 	o.claimedJobsMu.Lock()
 	defer o.claimedJobsMu.Unlock()
 
 	err := o.db.Exec(`
 			WITH deleted_jobs AS (
-				DELETE FROM jobs WHERE id = ? RETURNING offchainreporting_oracle_spec_id, pipeline_spec_id, keeper_spec_id
+				DELETE FROM jobs WHERE id = ? OR shadow_spec_id IN (SELECT id FROM shadow_specs WHERE shadow_of_job_id = ?)
+				RETURNING id, offchainreporting_oracle_spec_id, pipeline_spec_id, keeper_spec_id, shadow_spec_id, message_queue_spec_id
 			),
 			deleted_oracle_specs AS (
 				DELETE FROM offchainreporting_oracle_specs WHERE id IN (SELECT offchainreporting_oracle_spec_id FROM deleted_jobs)
 			),
 			deleted_keeper_specs AS (
 				DELETE FROM keeper_specs WHERE id IN (SELECT keeper_spec_id FROM deleted_jobs)
+			),
+			deleted_shadow_specs AS (
+				DELETE FROM shadow_specs WHERE id IN (SELECT shadow_spec_id FROM deleted_jobs)
+			),
+			deleted_message_queue_specs AS (
+				DELETE FROM message_queue_specs WHERE id IN (SELECT message_queue_spec_id FROM deleted_jobs)
 			)
 			DELETE FROM pipeline_specs WHERE id IN (SELECT pipeline_spec_id FROM deleted_jobs)
-    	`, id).Error
+			-- Pipeline specs are shared by jobs with the same pipeline
+			AND NOT EXISTS (
+				SELECT 1 FROM jobs
+				WHERE jobs.pipeline_spec_id = pipeline_specs.id AND jobs.id NOT IN (SELECT id FROM deleted_jobs)
+			)
+    	`, id, id).Error
 	if err != nil {
 		return errors.Wrap(err, "DeleteJob failed to delete job")
 	}
@@ -226,12 +478,60 @@ func (o *orm) DeleteJob(ctx context.Context, id int32) error {
 	return nil
 }
 
+// ArchiveJob marks a job as deleted, which stops it being run, but keeps it
+// and its runs until they are purged by PurgeArchivedJobs
+func (o *orm) ArchiveJob(ctx context.Context, id int32) error {
+	o.claimedJobsMu.Lock()
+	defer o.claimedJobsMu.Unlock()
+
+	err := postgres.GormTransaction(ctx, o.db, func(tx *gorm.DB) error {
+		result := tx.Exec(`UPDATE jobs SET archived_at = NOW() WHERE id = ? AND archived_at IS NULL`, id)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return storm.ErrorNotFound
+		}
+		// An OCR spec's contract address is only unique among the specs of
+		// jobs that aren't archived
+		return tx.Exec(`
+			UPDATE offchainreporting_oracle_specs SET archived_at = jobs.archived_at
+			FROM jobs WHERE jobs.id = ? AND jobs.offchainreporting_oracle_spec_id = offchainreporting_oracle_specs.id
+		`, id).Error
+	})
+	if errors.Cause(err) == storm.ErrorNotFound {
+		return storm.ErrorNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "ArchiveJob failed to archive job")
+	}
+
+	if err := o.unclaimJob(ctx, id); err != nil {
+		return errors.Wrap(err, "ArchiveJob failed to unclaim job")
+	}
+	return nil
+}
+
+// PurgeArchivedJobs permanently deletes the jobs that were archived before
+// archivedBefore, returning how many were deleted
+func (o *orm) PurgeArchivedJobs(ctx context.Context, archivedBefore time.Time) (int, error) {
+	var ids []int32
+	err := o.db.WithContext(ctx).Model(&Job{}).Where("archived_at < ?", archivedBefore).Pluck("id", &ids).Error
+	if err != nil {
+		return 0, errors.Wrap(err, "PurgeArchivedJobs failed to load archived jobs")
+	}
+	for i, id := range ids {
+		if err := o.DeleteJob(ctx, id); err != nil {
+			return i, errors.Wrapf(err, "PurgeArchivedJobs failed to delete job %v", id)
+		}
+	}
+	return len(ids), nil
+}
+
 func (o *orm) CheckForDeletedJobs(ctx context.Context) (deletedJobIDs []int32, err error) {
 	o.claimedJobsMu.RLock()
 	defer o.claimedJobsMu.RUnlock()
 	var claimedJobIDs []int32 = o.claimedJobIDs()
 
-	rows, err := o.db.Raw(`SELECT id FROM jobs WHERE id = ANY(?)`, pq.Array(claimedJobIDs)).Rows()
+	rows, err := o.db.Raw(`SELECT id FROM jobs WHERE id = ANY(?) AND archived_at IS NULL`, pq.Array(claimedJobIDs)).Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not query for jobs")
 	}
@@ -266,6 +566,8 @@ func (o *orm) UnclaimJob(ctx context.Context, id int32) error {
 func (o *orm) unclaimJob(ctx context.Context, id int32) error {
 	if _, ok := o.claimedJobs[id]; ok {
 		delete(o.claimedJobs, id)
+		err := o.db.Exec(`DELETE FROM job_claims WHERE job_id = ? AND node_id = ?`, id, o.config.NodeID()).Error
+		logger.ErrorIf(err, fmt.Sprintf("failed to delete claim on job %v", id))
 		return errors.Wrap(o.advisoryLocker.Unlock(ctx, o.advisoryLockClassID, id), "DeleteJob failed to unlock job")
 	}
 	return nil
@@ -290,16 +592,65 @@ func (o *orm) RecordError(ctx context.Context, jobID int32, description string)
 	logger.ErrorIf(err, fmt.Sprintf("error creating SpecError %v", description))
 }
 
-// OffChainReportingJobs returns job specs
+// JobsV2 returns all jobs that haven't been archived
 func (o *orm) JobsV2() ([]Job, error) {
+	return o.loadJobs(o.db.Where("archived_at IS NULL"))
+}
+
+// ArchivedJobsV2 returns the jobs that have been archived but not yet purged
+func (o *orm) ArchivedJobsV2() ([]Job, error) {
+	return o.loadJobs(o.db.Where("archived_at IS NOT NULL"))
+}
+
+// jobsListing lists the fields jobs can be sorted and filtered by
+var jobsListing = storm.Listing{
+	Columns: map[string]string{
+		"id":            "jobs.id",
+		"name":          "jobs.name",
+		"type":          "jobs.type",
+		"schemaVersion": "jobs.schema_version",
+	},
+	Key:         "id",
+	DefaultSort: "id",
+}
+
+// PaginatedJobsV2 returns the page of jobs selected by p, along with the
+// number of jobs matching its filters. With archived set, archived jobs are
+// listed instead.
+func (o *orm) PaginatedJobsV2(p storm.Pagination, archived bool) ([]Job, int, error) {
+	filter := "archived_at IS NULL"
+	if archived {
+		filter = "archived_at IS NOT NULL"
+	}
+
+	filtered, err := jobsListing.Filter(o.db.Model(Job{}).Where(filter), p)
+	if err != nil {
+		return nil, 0, err
+	}
+	var count int64
+	if err = filtered.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page, err := jobsListing.Page(o.db.Where(filter), p)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobs, err := o.loadJobs(page)
+	return jobs, int(count), err
+}
+
+func (o *orm) loadJobs(db *gorm.DB) ([]Job, error) {
 	var jobs []Job
-	err := o.db.
+	err := db.
 		Preload("PipelineSpec").
 		Preload("OffchainreportingOracleSpec").
 		Preload("DirectRequestSpec").
 		Preload("FluxMonitorSpec").
 		Preload("JobSpecErrors").
 		Preload("KeeperSpec").
+		Preload("ShadowSpec").
+		Preload("MessageQueueSpec").
 		Find(&jobs).
 		Error
 	for i := range jobs {
@@ -319,11 +670,18 @@ func loadDynamicConfigVars(cfg *storm.Config, os OffchainReportingOracleSpec) *O
 		ContractAddress:                        os.ContractAddress,
 		P2PPeerID:                              os.P2PPeerID,
 		P2PBootstrapPeers:                      os.P2PBootstrapPeers,
+		P2PPeers:                               os.P2PPeers,
 		IsBootstrapPeer:                        os.IsBootstrapPeer,
 		EncryptedOCRKeyBundleID:                os.EncryptedOCRKeyBundleID,
+		MonitoringEndpoint:                     cfg.OCRMonitoringEndpoint(os.MonitoringEndpoint),
 		TransmitterAddress:                     os.TransmitterAddress,
+		ForwarderAddress:                       os.ForwarderAddress,
+		TransmitDisabled:                       os.TransmitDisabled,
+		Decimals:                               os.Decimals,
 		ObservationTimeout:                     models.Interval(cfg.OCRObservationTimeout(time.Duration(os.ObservationTimeout))),
+		LatencyBudget:                          os.LatencyBudget,
 		BlockchainTimeout:                      models.Interval(cfg.OCRBlockchainTimeout(time.Duration(os.BlockchainTimeout))),
+		TransmissionDeadline:                   os.TransmissionDeadline,
 		ContractConfigTrackerSubscribeInterval: models.Interval(cfg.OCRContractSubscribeInterval(time.Duration(os.ContractConfigTrackerSubscribeInterval))),
 		ContractConfigTrackerPollInterval:      models.Interval(cfg.OCRContractPollInterval(time.Duration(os.ContractConfigTrackerPollInterval))),
 		ContractConfigConfirmations:            cfg.OCRContractConfirmations(os.ContractConfigConfirmations),
@@ -332,6 +690,24 @@ func loadDynamicConfigVars(cfg *storm.Config, os OffchainReportingOracleSpec) *O
 	}
 }
 
+// CountJobsByType returns the number of jobs of each type, not counting
+// archived jobs
+func (o *orm) CountJobsByType() (map[Type]int, error) {
+	var rows []struct {
+		Type  Type
+		Count int
+	}
+	err := o.db.Raw(`SELECT type, COUNT(*) AS count FROM jobs WHERE archived_at IS NULL GROUP BY type`).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "CountJobsByType failed")
+	}
+	counts := make(map[Type]int, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+	return counts, nil
+}
+
 // FindJob returns job by ID
 func (o *orm) FindJob(id int32) (Job, error) {
 	var job Job
@@ -342,6 +718,8 @@ func (o *orm) FindJob(id int32) (Job, error) {
 		Preload("DirectRequestSpec").
 		Preload("JobSpecErrors").
 		Preload("KeeperSpec").
+		Preload("ShadowSpec").
+		Preload("MessageQueueSpec").
 		First(&job, "jobs.id = ?", id).
 		Error
 	if job.OffchainreportingOracleSpec != nil {
@@ -351,62 +729,291 @@ func (o *orm) FindJob(id int32) (Job, error) {
 }
 
 func (o *orm) FindJobIDsWithBridge(name string) ([]int32, error) {
-	var jobs []Job
-	err := o.db.Preload("PipelineSpec").Find(&jobs).Error
+	bridgesByJob, jids, err := o.bridgesByJob()
 	if err != nil {
 		return nil, err
 	}
-	var jids []int32
-	for _, job := range jobs {
+	var withBridge []int32
+	for _, jid := range jids {
+		for _, bridge := range bridgesByJob[jid] {
+			if bridge == name {
+				withBridge = append(withBridge, jid)
+				break
+			}
+		}
+	}
+	return withBridge, nil
+}
+
+// FindMissingBridges returns the names of the bridges that each job calls but
+// that don't exist, for the jobs that call any. Bridges can't be deleted while
+// jobs use them, but can be by reconciling a manifest, importing a node state
+// or editing the database.
+func (o *orm) FindMissingBridges() (map[int32][]string, error) {
+	bridgesByJob, jids, err := o.bridgesByJob()
+	if err != nil {
+		return nil, err
+	}
+	var existing []string
+	if err = o.db.Model(&models.BridgeType{}).Pluck("name", &existing).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to load bridges")
+	}
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	missing := make(map[int32][]string)
+	for _, jid := range jids {
+		for _, bridge := range bridgesByJob[jid] {
+			if !exists[bridge] {
+				missing[jid] = append(missing[jid], bridge)
+			}
+		}
+	}
+	return missing, nil
+}
+
+// bridgesByJob returns the names of the bridges that each unarchived job
+// calls, and the IDs of the jobs in order
+func (o *orm) bridgesByJob() (map[int32][]string, []int32, error) {
+	var jobs []Job
+	err := o.db.Preload("PipelineSpec").Where("archived_at IS NULL").Order("id ASC").Find(&jobs).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	bridgesByJob := make(map[int32][]string, len(jobs))
+	jids := make([]int32, len(jobs))
+	for i, job := range jobs {
+		jids[i] = job.ID
 		d := pipeline.TaskDAG{}
 		err = d.UnmarshalText([]byte(job.PipelineSpec.DotDagSource))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		tasks, err := d.TasksInDependencyOrder()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		seen := make(map[string]bool)
 		for _, task := range tasks {
-			if task.Type() == pipeline.TaskTypeBridge {
-				if task.(*pipeline.BridgeTask).Name == name {
-					jids = append(jids, job.ID)
+			if bridge, ok := bridgeName(task); ok && !seen[bridge] {
+				seen[bridge] = true
+				bridgesByJob[job.ID] = append(bridgesByJob[job.ID], bridge)
+			}
+		}
+	}
+	return bridgesByJob, jids, nil
+}
+
+// bridgeName returns the name of the bridge that task calls, if it is a
+// bridge or grpcbridge task
+func bridgeName(task pipeline.Task) (string, bool) {
+	switch t := task.(type) {
+	case *pipeline.BridgeTask:
+		return t.Name, true
+	case *pipeline.GRPCBridgeTask:
+		return t.Name, true
+	default:
+		return "", false
+	}
+}
+
+// FindJobIDsWithPipelineSpec returns the IDs of the jobs that run the given
+// pipeline spec. Jobs with the same pipeline share a pipeline spec.
+func (o *orm) FindJobIDsWithPipelineSpec(specID int32) ([]int32, error) {
+	var jids []int32
+	err := o.db.Model(&Job{}).Where("pipeline_spec_id = ? AND archived_at IS NULL", specID).Order("id ASC").Pluck("id", &jids).Error
+	return jids, err
+}
+
+// OCRKeyBundleUsage returns the usage of every OCR key bundle referenced by
+// an OCR job. Jobs that don't specify a key bundle are counted against
+// defaultID, if given.
+func (o *orm) OCRKeyBundleUsage(defaultID *models.Sha256Hash) (map[models.Sha256Hash]KeyUsage, error) {
+	var defaultKey *string
+	if defaultID != nil {
+		str := defaultID.String()
+		defaultKey = &str
+	}
+	usagesByKey, err := o.keyUsage("encode(s.encrypted_ocr_key_bundle_id, 'hex')", defaultKey, "NOT s.is_bootstrap_peer")
+	if err != nil {
+		return nil, errors.Wrap(err, "OCRKeyBundleUsage failed")
+	}
+	usages := make(map[models.Sha256Hash]KeyUsage, len(usagesByKey))
+	for key, usage := range usagesByKey {
+		id, err := models.Sha256HashFromHex(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "OCRKeyBundleUsage failed")
+		}
+		usages[id] = usage
+	}
+	return usages, nil
+}
+
+// P2PKeyUsage returns the usage of every P2P key referenced by an OCR job.
+// Jobs that don't specify a peer ID are counted against defaultPeerID, if
+// given.
+func (o *orm) P2PKeyUsage(defaultPeerID *models.PeerID) (map[models.PeerID]KeyUsage, error) {
+	var defaultKey *string
+	if defaultPeerID != nil {
+		str := defaultPeerID.Raw()
+		defaultKey = &str
+	}
+	usagesByKey, err := o.keyUsage("s.p2p_peer_id", defaultKey, "TRUE")
+	if err != nil {
+		return nil, errors.Wrap(err, "P2PKeyUsage failed")
+	}
+	usages := make(map[models.PeerID]KeyUsage, len(usagesByKey))
+	for key, usage := range usagesByKey {
+		var peerID models.PeerID
+		if err := peerID.UnmarshalText([]byte(key)); err != nil {
+			return nil, errors.Wrap(err, "P2PKeyUsage failed")
+		}
+		usages[peerID] = usage
+	}
+	return usages, nil
+}
+
+// keyUsage aggregates the usage of keys referenced by OCR oracle specs, keyed
+// by keyExpr. Specs where keyExpr is NULL are counted against defaultKey.
+func (o *orm) keyUsage(keyExpr string, defaultKey *string, filter string) (map[string]KeyUsage, error) {
+	/* #nosec G201 */
+	query := fmt.Sprintf(`
+        SELECT COALESCE(%s, ?::text) AS key, jobs.id,
+            (SELECT MAX(ps.updated_at) FROM offchainreporting_persistent_states ps WHERE ps.offchainreporting_oracle_spec_id = s.id),
+            ARRAY(
+                SELECT DISTINCT encode(digests.config_digest, 'hex') FROM (
+                    SELECT config_digest FROM offchainreporting_contract_configs cc WHERE cc.offchainreporting_oracle_spec_id = s.id
+                    UNION
+                    SELECT config_digest FROM offchainreporting_persistent_states ps WHERE ps.offchainreporting_oracle_spec_id = s.id
+                ) digests
+            )
+        FROM jobs
+        INNER JOIN offchainreporting_oracle_specs s ON jobs.offchainreporting_oracle_spec_id = s.id
+        WHERE %s
+        ORDER BY jobs.id ASC
+    `, keyExpr, filter)
+
+	rows, err := o.db.Raw(query, defaultKey).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer logger.ErrorIfCalling(rows.Close)
+
+	usages := make(map[string]KeyUsage)
+	for rows.Next() {
+		var (
+			key           *string
+			jobID         int32
+			lastUsedAt    *time.Time
+			configDigests pq.StringArray
+		)
+		if err := rows.Scan(&key, &jobID, &lastUsedAt, &configDigests); err != nil {
+			return nil, err
+		} else if key == nil {
+			continue
+		}
+
+		usage := usages[*key]
+		usage.JobIDs = append(usage.JobIDs, jobID)
+		if lastUsedAt != nil && (usage.LastUsedAt == nil || lastUsedAt.After(*usage.LastUsedAt)) {
+			usage.LastUsedAt = lastUsedAt
+		}
+	digests:
+		for _, digest := range configDigests {
+			for _, existing := range usage.ConfigDigests {
+				if existing == digest {
+					continue digests
 				}
 			}
+			usage.ConfigDigests = append(usage.ConfigDigests, digest)
 		}
+		usages[*key] = usage
 	}
-	return jids, nil
+	return usages, rows.Err()
+}
+
+// pipelineRunsListing lists the fields pipeline runs can be sorted and
+// filtered by
+var pipelineRunsListing = storm.Listing{
+	Columns: map[string]string{
+		"id":         "pipeline_runs.id",
+		"createdAt":  "pipeline_runs.created_at",
+		"finishedAt": "pipeline_runs.finished_at",
+	},
+	Key:         "id",
+	DefaultSort: "-createdAt",
 }
 
-// PipelineRunsByJobID returns pipeline runs for a job
-func (o *orm) PipelineRunsByJobID(jobID int32, offset, size int) ([]pipeline.Run, int, error) {
+// PipelineRunsByJobID returns the page of a job's pipeline runs selected by
+// p, most recent first by default, along with the number of the job's runs
+// matching its filters
+func (o *orm) PipelineRunsByJobID(jobID int32, p storm.Pagination) ([]pipeline.Run, int, error) {
 	var pipelineRuns []pipeline.Run
 	var count int64
-	err := o.db.
+	filtered, err := pipelineRunsListing.Filter(o.db.
 		Model(pipeline.Run{}).
-		Joins("INNER JOIN jobs ON pipeline_runs.pipeline_spec_id = jobs.pipeline_spec_id").
-		Where("jobs.id = ?", jobID).
-		Count(&count).
-		Error
-
+		Where("pipeline_runs.job_id = ?", jobID), p)
 	if err != nil {
 		return pipelineRuns, 0, err
 	}
+	if err = filtered.Count(&count).Error; err != nil {
+		return pipelineRuns, 0, err
+	}
 
-	err = o.db.
+	page, err := pipelineRunsListing.Page(o.db.
 		Preload("PipelineSpec").
 		Preload("PipelineTaskRuns", func(db *gorm.DB) *gorm.DB {
 			return db.
 				Where(`pipeline_task_runs.type != 'result'`).
 				Order("created_at ASC, id ASC")
 		}).
-		Joins("INNER JOIN jobs ON pipeline_runs.pipeline_spec_id = jobs.pipeline_spec_id").
-		Where("jobs.id = ?", jobID).
+		Where("pipeline_runs.job_id = ?", jobID), p)
+	if err != nil {
+		return pipelineRuns, 0, err
+	}
+	err = page.Find(&pipelineRuns).Error
+
+	return pipelineRuns, int(count), err
+}
+
+// ShadowComparisonsByJobID returns the comparisons recorded by a shadow job,
+// most recent first
+func (o *orm) ShadowComparisonsByJobID(jobID int32, offset, size int) ([]pipeline.ShadowComparison, int, error) {
+	var comparisons []pipeline.ShadowComparison
+	var count int64
+	err := o.db.
+		Model(pipeline.ShadowComparison{}).
+		Joins("INNER JOIN pipeline_runs ON pipeline_shadow_comparisons.shadow_run_id = pipeline_runs.id").
+		Where("pipeline_runs.job_id = ?", jobID).
+		Count(&count).
+		Error
+
+	if err != nil {
+		return comparisons, 0, err
+	}
+
+	err = o.db.
+		Joins("INNER JOIN pipeline_runs ON pipeline_shadow_comparisons.shadow_run_id = pipeline_runs.id").
+		Where("pipeline_runs.job_id = ?", jobID).
 		Limit(size).
 		Offset(offset).
-		Order("created_at DESC, id DESC").
-		Find(&pipelineRuns).
+		Order("pipeline_shadow_comparisons.created_at DESC, pipeline_shadow_comparisons.id DESC").
+		Find(&comparisons).
 		Error
 
-	return pipelineRuns, int(count), err
+	return comparisons, int(count), err
+}
+
+// CostsByJobID returns a job's costs for the days from from to to inclusive,
+// by day, kind and target
+func (o *orm) CostsByJobID(jobID int32, from, to time.Time) ([]Cost, error) {
+	var costs []Cost
+	err := o.db.
+		Where("job_id = ? AND day BETWEEN ? AND ?", jobID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("day ASC, kind ASC, target ASC").
+		Find(&costs).
+		Error
+	return costs, err
 }
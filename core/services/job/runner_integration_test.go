@@ -24,7 +24,6 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/telemetry"
 
 	"github.com/smartcontractkit/chainlink/core/store/models"
-	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,7 +33,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 )
 
-var monitoringEndpoint = ocrtypes.MonitoringEndpoint(&telemetry.NoopAgent{})
+var monitoringEndpoint = telemetry.MonitoringEndpointGenerator(&telemetry.NoopAgent{})
 
 func TestRunner(t *testing.T) {
 	config, oldORM, cleanupDB := cltest.BootstrapThrowawayORM(t, "pipeline_runner", true, true)
@@ -45,8 +44,8 @@ func TestRunner(t *testing.T) {
 	eventBroadcaster.Start()
 	defer eventBroadcaster.Stop()
 
-	pipelineORM := pipeline.NewORM(db, config, eventBroadcaster)
-	runner := pipeline.NewRunner(pipelineORM, config)
+	pipelineORM := pipeline.NewORM(db, config, eventBroadcaster, nil)
+	runner := pipeline.NewRunner(pipelineORM, config, nil, nil, nil)
 	jobORM := job.NewORM(db, config.Config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
 	defer jobORM.Close()
 
@@ -402,7 +401,8 @@ ds1 -> ds1_parse;
 			eth.NewClientWith(rpc, geth),
 			nil,
 			nil,
-			monitoringEndpoint)
+			monitoringEndpoint,
+			nil)
 		_, err = sd.ServicesForSpec(jb)
 		// We expect this to fail as neither the required vars are not set either via the env nor the job itself.
 		require.Error(t, err)
@@ -450,6 +450,7 @@ ds1 -> ds1_parse;
 			nil,
 			pw,
 			monitoringEndpoint,
+			nil,
 		)
 		_, err = sd.ServicesForSpec(jb)
 		require.NoError(t, err)
@@ -512,7 +513,8 @@ ds1 -> ds1_parse;
 			eth.NewClientWith(rpc, geth),
 			nil,
 			pw,
-			monitoringEndpoint)
+			monitoringEndpoint,
+			nil)
 		_, err = sd.ServicesForSpec(jb)
 		require.NoError(t, err)
 	})
@@ -557,7 +559,8 @@ ds1 -> ds1_parse;
 			eth.NewClientWith(rpc, geth),
 			nil,
 			pw,
-			monitoringEndpoint)
+			monitoringEndpoint,
+			nil)
 		_, err = sd.ServicesForSpec(jb)
 		require.NoError(t, err)
 	})
@@ -595,7 +598,8 @@ ds1 -> ds1_parse;
 			eth.NewClientWith(rpc, geth),
 			nil,
 			pw,
-			monitoringEndpoint)
+			monitoringEndpoint,
+			nil)
 		_, err = sd.ServicesForSpec(jb)
 		require.NoError(t, err)
 	})
@@ -634,7 +638,8 @@ ds1 -> ds1_parse;
 			ethClient,
 			log.NewBroadcaster(log.NewORM(db), ethClient, config),
 			pw,
-			monitoringEndpoint)
+			monitoringEndpoint,
+			nil)
 		services, err := sd.ServicesForSpec(jb)
 		require.NoError(t, err)
 
@@ -0,0 +1,331 @@
+package job
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+type (
+	// SpecSchema describes the TOML accepted for a job type, along with the
+	// tasks that may appear in its observationSource
+	SpecSchema struct {
+		Type   Type          `json:"type"`
+		Fields []FieldSchema `json:"fields"`
+		Tasks  []TaskSchema  `json:"tasks"`
+	}
+
+	// TaskSchema describes the attributes accepted by a pipeline task type
+	TaskSchema struct {
+		Type   pipeline.TaskType `json:"type"`
+		Fields []FieldSchema     `json:"fields"`
+	}
+
+	// FieldSchema describes a single spec field or task attribute. Fields
+	// with an EnvFallback take their value from that node configuration
+	// variable when they are omitted.
+	FieldSchema struct {
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Required    bool   `json:"required"`
+		EnvFallback string `json:"envFallback,omitempty"`
+	}
+
+	// specSchemaSource is the information needed to generate a SpecSchema
+	// that can't be derived from the spec struct itself
+	specSchemaSource struct {
+		spec         interface{}
+		required     []string
+		exclude      []string
+		envFallbacks map[string]string
+	}
+)
+
+// ErrUnknownJobType is returned when asking for the schema of a job type
+// that doesn't exist
+var ErrUnknownJobType = errors.New("unknown job type")
+
+// jobFields are the fields of Job that are set in every job type's TOML
+var jobFields = []string{"Type", "SchemaVersion", "Name", "MaxTaskDuration", "Pipeline", "MetaSchema", "DependsOn", "NumericPolicy", "MaxGasCostWei", "OnComplete"}
+
+var specSchemaSources = map[Type]specSchemaSource{
+	OffchainReporting: {
+		spec:     OffchainReportingOracleSpec{},
+		required: []string{"contractAddress", "isBootstrapPeer"},
+		envFallbacks: map[string]string{
+			"p2pPeerID":                              "P2P_PEER_ID",
+			"p2pBootstrapPeers":                      "P2P_BOOTSTRAP_PEERS",
+			"p2pPeers":                               "P2P_PEERS",
+			"keyBundleID":                            "OCR_KEY_BUNDLE_ID",
+			"monitoringEndpoint":                     "OCR_MONITORING_ENDPOINT",
+			"transmitterAddress":                     "OCR_TRANSMITTER_ADDRESS",
+			"observationTimeout":                     "OCR_OBSERVATION_TIMEOUT",
+			"blockchainTimeout":                      "OCR_BLOCKCHAIN_TIMEOUT",
+			"contractConfigTrackerSubscribeInterval": "OCR_CONTRACT_SUBSCRIBE_INTERVAL",
+			"contractConfigTrackerPollInterval":      "OCR_CONTRACT_POLL_INTERVAL",
+			"contractConfigConfirmations":            "OCR_CONTRACT_CONFIRMATIONS",
+		},
+	},
+	DirectRequest: {
+		spec:     DirectRequestSpec{},
+		required: []string{"contractAddress", "observationSource"},
+		exclude:  []string{"OnChainJobSpecID"},
+	},
+	FluxMonitor: {
+		spec:     FluxMonitorSpec{},
+		required: []string{"contractAddress", "observationSource"},
+	},
+	Keeper: {
+		spec:     KeeperSpec{},
+		required: []string{"contractAddress", "fromAddress"},
+	},
+	Shadow: {
+		spec:     ShadowSpec{},
+		required: []string{"shadowOf", "observationSource"},
+	},
+	MessageQueue: {
+		spec:     MessageQueueSpec{},
+		required: []string{"url", "topic", "observationSource"},
+	},
+}
+
+// pipelineTaskTypes are the task types that may be used in job pipelines
+var pipelineTaskTypes = []pipeline.TaskType{
+	pipeline.TaskTypeHTTP,
+	pipeline.TaskTypeBridge,
+	pipeline.TaskTypeMedian,
+	pipeline.TaskTypeWeightedAggregate,
+	pipeline.TaskTypeMultiply,
+	pipeline.TaskTypeScale,
+	pipeline.TaskTypeJSONParse,
+	pipeline.TaskTypeAny,
+	pipeline.TaskTypeSQL,
+	pipeline.TaskTypeEthCall,
+	pipeline.TaskTypeKVGet,
+	pipeline.TaskTypeKVSet,
+	pipeline.TaskTypeDeltaThreshold,
+	pipeline.TaskTypeGRPCBridge,
+}
+
+// requiredTaskAttributes are the attributes that each task type can't do
+// without
+var requiredTaskAttributes = map[pipeline.TaskType][]string{
+	pipeline.TaskTypeHTTP:       {"method", "url"},
+	pipeline.TaskTypeBridge:     {"name"},
+	pipeline.TaskTypeGRPCBridge: {"name"},
+	pipeline.TaskTypeMultiply:   {"times"},
+	pipeline.TaskTypeScale:      {"decimals"},
+	pipeline.TaskTypeJSONParse:  {"path"},
+	pipeline.TaskTypeSQL:        {"datasource", "query"},
+	pipeline.TaskTypeEthCall:    {"contract", "data"},
+	pipeline.TaskTypeKVGet:      {"key"},
+	pipeline.TaskTypeKVSet:      {"key"},
+}
+
+// SpecSchemas returns the schemas of all job types, ordered by type
+func SpecSchemas() ([]SpecSchema, error) {
+	types := make([]string, 0, len(specSchemaSources))
+	for t := range specSchemaSources {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+
+	schemas := make([]SpecSchema, 0, len(types))
+	for _, t := range types {
+		schema, err := SpecSchemaFor(Type(t))
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+// SpecSchemaFor returns the schema of a single job type, generated from its
+// spec struct
+func SpecSchemaFor(t Type) (SpecSchema, error) {
+	source, exists := specSchemaSources[t]
+	if !exists {
+		return SpecSchema{}, errors.Wrapf(ErrUnknownJobType, "%s", t)
+	}
+
+	required := map[string]bool{"type": true, "schemaVersion": true}
+	for _, name := range source.required {
+		required[name] = true
+	}
+
+	var fields []FieldSchema
+	jobType := reflect.TypeOf(Job{})
+	for _, name := range jobFields {
+		f, _ := jobType.FieldByName(name)
+		fields = append(fields, fieldSchema(f, tomlName(f), required))
+	}
+	fields = append(fields, structFieldSchemas(reflect.TypeOf(source.spec), tomlName, required, source.exclude)...)
+	for i := range fields {
+		fields[i].EnvFallback = source.envFallbacks[fields[i].Name]
+	}
+
+	tasks, err := TaskSchemas()
+	if err != nil {
+		return SpecSchema{}, err
+	}
+	return SpecSchema{Type: t, Fields: fields, Tasks: tasks}, nil
+}
+
+// TaskSchemas returns the schemas of all pipeline task types
+func TaskSchemas() ([]TaskSchema, error) {
+	schemas := make([]TaskSchema, 0, len(pipelineTaskTypes))
+	for _, taskType := range pipelineTaskTypes {
+		task, err := pipeline.UnmarshalTaskFromMap(taskType, map[string]interface{}{}, "", nil, nil, nil, 0)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to instantiate %s task", taskType)
+		}
+		required := make(map[string]bool)
+		for _, name := range requiredTaskAttributes[taskType] {
+			required[name] = true
+		}
+		schemas = append(schemas, TaskSchema{
+			Type:   taskType,
+			Fields: structFieldSchemas(reflect.TypeOf(task).Elem(), mapstructureName, required, nil),
+		})
+	}
+	return schemas, nil
+}
+
+func structFieldSchemas(t reflect.Type, nameOf func(reflect.StructField) string, required map[string]bool, exclude []string) []FieldSchema {
+	var fields []FieldSchema
+outer:
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, structFieldSchemas(f.Type, nameOf, required, exclude)...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		for _, name := range exclude {
+			if f.Name == name {
+				continue outer
+			}
+		}
+		name := nameOf(f)
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, fieldSchema(f, name, required))
+	}
+	return fields
+}
+
+func fieldSchema(f reflect.StructField, name string, required map[string]bool) FieldSchema {
+	return FieldSchema{
+		Name:     name,
+		Type:     schemaType(f.Type),
+		Required: required[name],
+	}
+}
+
+// tomlName returns the TOML key of a spec field. go-toml falls back to
+// matching the field name case-insensitively when there is no tag.
+func tomlName(f reflect.StructField) string {
+	if tag, ok := f.Tag.Lookup("toml"); ok {
+		return strings.Split(tag, ",")[0]
+	}
+	if f.Tag.Get("json") == "-" {
+		return ""
+	}
+	return lowerCamel(f.Name)
+}
+
+// mapstructureName returns the DOT attribute name of a task field
+func mapstructureName(f reflect.StructField) string {
+	if tag, ok := f.Tag.Lookup("mapstructure"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name
+		}
+	}
+	return lowerCamel(f.Name)
+}
+
+// lowerCamel lower-cases the leading word of a Go identifier, treating a run
+// of capitals as an initialism (URL => url, P2PPeerID => p2pPeerID)
+func lowerCamel(s string) string {
+	runes := []rune(s)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) && !unicode.IsDigit(runes[i]) {
+			if i > 1 {
+				i--
+			}
+			for j := 0; j < i; j++ {
+				runes[j] = unicode.ToLower(runes[j])
+			}
+			return string(runes)
+		}
+	}
+	return strings.ToLower(s)
+}
+
+var (
+	durationTypes = []reflect.Type{reflect.TypeOf(time.Duration(0)), reflect.TypeOf(models.Interval(0))}
+	namedTypes    = map[reflect.Type]string{
+		reflect.TypeOf(models.EIP55Address("")):    "address",
+		reflect.TypeOf(models.PeerID("")):          "peerID",
+		reflect.TypeOf(models.Sha256Hash{}):        "sha256",
+		reflect.TypeOf(models.WebURL{}):            "url",
+		reflect.TypeOf(assets.Link{}):              "link",
+		reflect.TypeOf(decimal.Decimal{}):          "decimal",
+		reflect.TypeOf(pq.StringArray{}):           "list<string>",
+		reflect.TypeOf(pipeline.JSONPath{}):        "list<string>",
+		reflect.TypeOf(pipeline.HttpRequestData{}): "object",
+		reflect.TypeOf(pipeline.MaybeBool("")):     "boolean",
+		reflect.TypeOf(pipeline.TaskDAG{}):         "dot",
+		reflect.TypeOf(Type("")):                   "string",
+		reflect.TypeOf(utils.Big{}):                "bigint",
+	}
+)
+
+func schemaType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for _, d := range durationTypes {
+		if t == d {
+			return "duration"
+		}
+	}
+	if name, ok := namedTypes[t]; ok {
+		return name
+	}
+	if t.Kind() == reflect.Struct {
+		if _, isNullString := t.FieldByName("NullString"); isNullString {
+			return "string"
+		}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "list<" + schemaType(t.Elem()) + ">"
+	default:
+		return "object"
+	}
+}
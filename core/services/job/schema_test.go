@@ -0,0 +1,59 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestSpecSchemaFor(t *testing.T) {
+	fieldsByName := func(fields []job.FieldSchema) map[string]job.FieldSchema {
+		m := make(map[string]job.FieldSchema)
+		for _, f := range fields {
+			m[f.Name] = f
+		}
+		return m
+	}
+
+	t.Run("offchainreporting", func(t *testing.T) {
+		schema, err := job.SpecSchemaFor(job.OffchainReporting)
+		require.NoError(t, err)
+		fields := fieldsByName(schema.Fields)
+
+		assert.Equal(t, job.FieldSchema{Name: "type", Type: "string", Required: true}, fields["type"])
+		assert.Equal(t, job.FieldSchema{Name: "observationSource", Type: "dot"}, fields["observationSource"])
+		assert.Equal(t, job.FieldSchema{Name: "maxTaskDuration", Type: "duration"}, fields["maxTaskDuration"])
+		assert.Equal(t, job.FieldSchema{Name: "contractAddress", Type: "address", Required: true}, fields["contractAddress"])
+		assert.Equal(t, job.FieldSchema{Name: "transmitterAddress", Type: "address", EnvFallback: "OCR_TRANSMITTER_ADDRESS"}, fields["transmitterAddress"])
+		assert.Equal(t, job.FieldSchema{Name: "p2pBootstrapPeers", Type: "list<string>", EnvFallback: "P2P_BOOTSTRAP_PEERS"}, fields["p2pBootstrapPeers"])
+		assert.NotContains(t, fields, "createdAt")
+		assert.NotContains(t, fields, "id")
+	})
+
+	t.Run("includes pipeline tasks", func(t *testing.T) {
+		schema, err := job.SpecSchemaFor(job.FluxMonitor)
+		require.NoError(t, err)
+
+		var http *job.TaskSchema
+		for i := range schema.Tasks {
+			if schema.Tasks[i].Type == pipeline.TaskTypeHTTP {
+				http = &schema.Tasks[i]
+			}
+		}
+		require.NotNil(t, http)
+		fields := fieldsByName(http.Fields)
+		assert.Equal(t, job.FieldSchema{Name: "url", Type: "url", Required: true}, fields["url"])
+		assert.Equal(t, job.FieldSchema{Name: "timeout", Type: "duration"}, fields["timeout"])
+		assert.Equal(t, job.FieldSchema{Name: "allowUnrestrictedNetworkAccess", Type: "boolean"}, fields["allowUnrestrictedNetworkAccess"])
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := job.SpecSchemaFor(job.Type("nope"))
+		require.True(t, errors.Is(err, job.ErrUnknownJobType))
+	})
+}
@@ -3,14 +3,18 @@ package job
 import (
 	"context"
 	"reflect"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/atomic"
+	"go.uber.org/multierr"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
@@ -31,7 +35,21 @@ type (
 		Start() error
 		Close() error
 		CreateJob(ctx context.Context, spec Job, name null.String) (int32, error)
-		DeleteJob(ctx context.Context, jobID int32) error
+		// DeleteJob stops a job and deletes it and its runs. The runs of the
+		// job that this node is executing are cancelled if force is set, and
+		// otherwise waited for, so that none of them finish after the job is
+		// deleted.
+		DeleteJob(ctx context.Context, jobID int32, force bool) error
+		// ArchiveJob stops a job and hides it from listings, keeping it and
+		// its runs until JOB_ARCHIVE_RETENTION has passed. Its in-flight runs
+		// are stopped as they are by DeleteJob.
+		ArchiveJob(ctx context.Context, jobID int32, force bool) error
+		// Drain stops all locally running job services, releases their claims
+		// so that other nodes may pick them up, and stops claiming new jobs.
+		Drain(ctx context.Context) error
+		// RestartJob stops the services of a job claimed by this node and
+		// then claims it again, so that changes to its spec take effect.
+		RestartJob(ctx context.Context, jobID int32) error
 	}
 
 	spawner struct {
@@ -39,15 +57,38 @@ type (
 		config                       Config
 		jobTypeDelegates             map[Type]Delegate
 		jobTypeDelegatesMu           sync.RWMutex
+		eventBus                     *eventbus.Bus
 		startUnclaimedServicesWorker utils.SleeperTask
 		services                     map[int32][]Service
+		servicesMu                   sync.Mutex
 		chStopJob                    chan int32
+		chRestartJob                 chan restartJobRequest
+		chReconcile                  chan struct{}
+		claimBatchTimer              *time.Timer
+		dependencies                 []Dependency
+		startedDependencies          []Dependency
+		pendingClaims                map[int32]struct{}
+		reconcilePending             bool
+		pendingClaimsMu              sync.Mutex
+		draining                     atomic.Bool
 
 		utils.StartStopOnce
 		chStop chan struct{}
 		chDone chan struct{}
 	}
 
+	restartJobRequest struct {
+		jobID int32
+		chErr chan error
+	}
+
+	// runStopper is implemented by the pipeline runner, to stop the runs of
+	// jobs that are deleted
+	runStopper interface {
+		CancelRuns(jobID int32)
+		AwaitRuns(ctx context.Context, jobID int32) error
+	}
+
 	// TODO(spook): I can't wait for Go generics
 	Delegate interface {
 		JobType() Type
@@ -58,17 +99,31 @@ type (
 	}
 )
 
-const checkForDeletedJobsPollInterval = 5 * time.Minute
+const (
+	checkForDeletedJobsPollInterval = 5 * time.Minute
+	// claimBatchInterval is how long to wait between consecutive claim rounds
+	// while there are still unclaimed jobs, so that other nodes sharing the
+	// database get a chance to claim some of them too
+	claimBatchInterval = 1 * time.Second
+)
 
 var _ Spawner = (*spawner)(nil)
 
-func NewSpawner(orm ORM, config Config, jobTypeDelegates map[Type]Delegate) *spawner {
+// ErrJobNotClaimed is returned when an operation requires a job to be claimed
+// by this node, but it isn't
+var ErrJobNotClaimed = errors.New("job is not claimed by this node")
+
+func NewSpawner(orm ORM, config Config, jobTypeDelegates map[Type]Delegate, eventBus *eventbus.Bus) *spawner {
 	s := &spawner{
 		orm:              orm,
 		config:           config,
 		jobTypeDelegates: jobTypeDelegates,
+		eventBus:         eventBus,
 		services:         make(map[int32][]Service),
+		pendingClaims:    make(map[int32]struct{}),
 		chStopJob:        make(chan int32),
+		chRestartJob:     make(chan restartJobRequest),
+		chReconcile:      make(chan struct{}),
 		chStop:           make(chan struct{}),
 		chDone:           make(chan struct{}),
 	}
@@ -78,10 +133,19 @@ func NewSpawner(orm ORM, config Config, jobTypeDelegates map[Type]Delegate) *spa
 	return s
 }
 
+// AddDependency registers a node-level service that job services rely on.
+// It must be called before Start.
+func (js *spawner) AddDependency(dep Dependency) {
+	js.dependencies = append(js.dependencies, dep)
+}
+
 func (js *spawner) Start() error {
 	if !js.OkayToStart() {
 		return errors.New("Job spawner has already been started")
 	}
+	if err := js.startDependencies(); err != nil {
+		return err
+	}
 	go js.runLoop()
 	return nil
 }
@@ -94,9 +158,61 @@ func (js *spawner) Close() error {
 	close(js.chStop)
 	<-js.chDone
 
+	return js.closeDependencies()
+}
+
+// startDependencies starts the registered dependencies in topological order,
+// ensuring that no job service can observe a dependency that isn't running yet
+func (js *spawner) startDependencies() error {
+	sorted, err := SortDependencies(js.dependencies)
+	if err != nil {
+		return errors.Wrap(err, "Job spawner could not order dependencies")
+	}
+
+	registered := make(map[string]struct{}, len(sorted))
+	for _, dep := range sorted {
+		registered[dep.Name] = struct{}{}
+	}
+	js.jobTypeDelegatesMu.RLock()
+	for jobType, delegate := range js.jobTypeDelegates {
+		withDeps, ok := delegate.(DelegateWithDependencies)
+		if !ok {
+			continue
+		}
+		for _, name := range withDeps.Dependencies() {
+			if _, exists := registered[name]; !exists {
+				js.jobTypeDelegatesMu.RUnlock()
+				return errors.Errorf("Job spawner: delegate for job type '%s' depends on %s, which has not been registered", jobType, name)
+			}
+		}
+	}
+	js.jobTypeDelegatesMu.RUnlock()
+
+	for _, dep := range sorted {
+		logger.Debugw("Job spawner starting dependency", "name", dep.Name)
+		if err := dep.Service.Start(); err != nil {
+			return multierr.Combine(
+				errors.Wrapf(err, "Job spawner could not start dependency %s", dep.Name),
+				js.closeDependencies(),
+			)
+		}
+		js.startedDependencies = append(js.startedDependencies, dep)
+	}
 	return nil
 }
 
+// closeDependencies closes the started dependencies in the reverse of the
+// order in which they were started
+func (js *spawner) closeDependencies() (merr error) {
+	for i := len(js.startedDependencies) - 1; i >= 0; i-- {
+		dep := js.startedDependencies[i]
+		logger.Debugw("Job spawner closing dependency", "name", dep.Name)
+		merr = multierr.Append(merr, errors.Wrapf(dep.Service.Close(), "Job spawner could not close dependency %s", dep.Name))
+	}
+	js.startedDependencies = nil
+	return merr
+}
+
 func (js *spawner) destroy() {
 	js.stopAllServices()
 
@@ -104,6 +220,9 @@ func (js *spawner) destroy() {
 	if err != nil {
 		logger.Error(err)
 	}
+	if js.claimBatchTimer != nil {
+		js.claimBatchTimer.Stop()
+	}
 }
 
 func (js *spawner) runLoop() {
@@ -141,20 +260,30 @@ func (js *spawner) runLoop() {
 	ctx, cancel := utils.CombinedContext(js.chStop)
 	defer cancel()
 
-	js.startUnclaimedServicesWorker.WakeUp()
+	js.wakeUpReconcile()
 	for {
 		select {
-		case <-newJobEvents:
-			js.startUnclaimedServicesWorker.WakeUp()
+		case ev := <-newJobEvents:
+			js.handlePGNewJobEvent(ev)
 
 		case <-dbPollTicker.C:
-			js.startUnclaimedServicesWorker.WakeUp()
+			js.wakeUpReconcile()
+			if err := js.orm.HeartbeatClaims(ctx); err != nil {
+				logger.Errorw("Failed to heartbeat job claims", "error", err)
+			}
 
 		case jobID := <-js.chStopJob:
 			js.stopService(jobID)
 
+		case req := <-js.chRestartJob:
+			req.chErr <- js.restartJob(ctx, req.jobID)
+
+		case <-js.chReconcile:
+			js.wakeUpReconcile()
+
 		case <-deletedPollTicker.C:
 			js.checkForDeletedJobs(ctx)
+			js.purgeArchivedJobs(ctx)
 
 		case deleteJobEvent := <-pgDeletedJobEvents:
 			js.handlePGDeleteEvent(ctx, deleteJobEvent)
@@ -165,20 +294,109 @@ func (js *spawner) runLoop() {
 	}
 }
 
+// wakeUpReconcile schedules a full reconciliation round, in which a batch of
+// any unclaimed jobs is claimed and excess claims are released
+func (js *spawner) wakeUpReconcile() {
+	js.pendingClaimsMu.Lock()
+	js.reconcilePending = true
+	js.pendingClaimsMu.Unlock()
+	js.startUnclaimedServicesWorker.WakeUp()
+}
+
+func (js *spawner) handlePGNewJobEvent(ev postgres.Event) {
+	jobID, err := strconv.ParseInt(ev.Payload, 10, 32)
+	if err != nil {
+		logger.Errorw("Unexpected error decoding new job event payload, expected 32-bit integer", "payload", ev.Payload, "channel", ev.Channel)
+		js.wakeUpReconcile()
+		return
+	}
+	js.pendingClaimsMu.Lock()
+	js.pendingClaims[int32(jobID)] = struct{}{}
+	js.pendingClaimsMu.Unlock()
+	js.startUnclaimedServicesWorker.WakeUp()
+}
+
+func (js *spawner) takePendingClaims() (jobIDs []int32, reconcile bool) {
+	js.pendingClaimsMu.Lock()
+	defer js.pendingClaimsMu.Unlock()
+	for jobID := range js.pendingClaims {
+		jobIDs = append(jobIDs, jobID)
+	}
+	js.pendingClaims = make(map[int32]struct{})
+	reconcile = js.reconcilePending
+	js.reconcilePending = false
+	return jobIDs, reconcile
+}
+
 func (js *spawner) startUnclaimedServices() {
+	if js.draining.Load() {
+		return
+	}
+
 	ctx, cancel := utils.CombinedContext(js.chStop, 5*time.Second)
 	defer cancel()
 
-	jobs, err := js.orm.ClaimUnclaimedJobs(ctx)
-	if err != nil {
-		logger.Errorf("Couldn't fetch unclaimed jobs: %v", err)
-		return
+	jobIDs, reconcile := js.takePendingClaims()
+
+	var jobs []Job
+	for _, jobID := range jobIDs {
+		job, claimed, err := js.orm.ClaimJob(ctx, jobID)
+		if err != nil {
+			logger.Errorw("Couldn't claim new job", "jobID", jobID, "error", err)
+			continue
+		} else if claimed {
+			jobs = append(jobs, job)
+		}
+	}
+
+	if reconcile {
+		claimed, err := js.orm.ClaimUnclaimedJobs(ctx)
+		if err != nil {
+			logger.Errorf("Couldn't fetch unclaimed jobs: %v", err)
+		} else {
+			jobs = append(jobs, claimed...)
+			// A full batch means there are probably more jobs left to claim
+			if batchSize := int(js.config.JobSpawnerClaimBatchSize()); batchSize > 0 && len(claimed) >= batchSize {
+				js.scheduleReconcile(utils.WithJitter(claimBatchInterval))
+			}
+		}
+	}
+
+	js.startServicesForJobs(ctx, jobs)
+
+	if reconcile {
+		js.releaseExcessClaims(ctx)
 	}
+}
 
+// scheduleReconcile schedules another reconciliation round after delay. The
+// wake-up goes through the run loop, so that it can never reach the worker
+// once the spawner has been stopped.
+func (js *spawner) scheduleReconcile(delay time.Duration) {
+	if js.claimBatchTimer != nil {
+		js.claimBatchTimer.Stop()
+	}
+	js.claimBatchTimer = time.AfterFunc(delay, func() {
+		select {
+		case js.chReconcile <- struct{}{}:
+		case <-js.chStop:
+		}
+	})
+}
+
+func (js *spawner) startServicesForJobs(ctx context.Context, jobs []Job) {
 	js.jobTypeDelegatesMu.RLock()
 	defer js.jobTypeDelegatesMu.RUnlock()
+	js.servicesMu.Lock()
+	defer js.servicesMu.Unlock()
 
 	for _, job := range jobs {
+		if js.draining.Load() {
+			// Drain may already have released the claims it knew of, so give
+			// up this one too rather than starting it
+			js.releaseClaim(ctx, job.ID)
+			continue
+		}
 		if _, exists := js.services[job.ID]; exists {
 			logger.Warnw("Job spawner ORM attempted to claim locally-claimed job, skipping", "jobID", job.ID)
 			continue
@@ -209,14 +427,54 @@ func (js *spawner) startUnclaimedServices() {
 	}
 }
 
-func (js *spawner) stopAllServices() {
+// releaseExcessClaims gives up claims in excess of JobSpawnerMaxClaimedJobs,
+// most recently created jobs first, so that they can be rebalanced onto other
+// nodes
+func (js *spawner) releaseExcessClaims(ctx context.Context) {
+	maxClaimed := int(js.config.JobSpawnerMaxClaimedJobs())
+	jobIDs := js.claimedJobIDs()
+	if maxClaimed == 0 || len(jobIDs) <= maxClaimed {
+		return
+	}
+
+	sort.Slice(jobIDs, func(i, j int) bool { return jobIDs[i] < jobIDs[j] })
+
+	for _, jobID := range jobIDs[maxClaimed:] {
+		logger.Infow("Releasing claim on job in excess of JOB_SPAWNER_MAX_CLAIMED_JOBS", "jobID", jobID, "max", maxClaimed)
+		js.unloadJob(ctx, jobID)
+	}
+}
+
+// claimedJobIDs returns the IDs of the jobs whose services run on this node
+func (js *spawner) claimedJobIDs() []int32 {
+	js.servicesMu.Lock()
+	defer js.servicesMu.Unlock()
+	jobIDs := make([]int32, 0, len(js.services))
 	for jobID := range js.services {
+		jobIDs = append(jobIDs, jobID)
+	}
+	return jobIDs
+}
+
+func (js *spawner) isClaimed(jobID int32) bool {
+	js.servicesMu.Lock()
+	defer js.servicesMu.Unlock()
+	_, exists := js.services[jobID]
+	return exists
+}
+
+func (js *spawner) stopAllServices() {
+	for _, jobID := range js.claimedJobIDs() {
 		js.stopService(jobID)
 	}
 }
 
 func (js *spawner) stopService(jobID int32) {
+	js.servicesMu.Lock()
 	services := js.services[jobID]
+	delete(js.services, jobID)
+	js.servicesMu.Unlock()
+
 	for i := len(services) - 1; i >= 0; i-- {
 		service := services[i]
 		err := service.Close()
@@ -226,7 +484,6 @@ func (js *spawner) stopService(jobID int32) {
 			logger.Infow("Stopped job service", "jobID", jobID, "subservice", i, "serviceType", reflect.TypeOf(service))
 		}
 	}
-	delete(js.services, jobID)
 }
 
 func (js *spawner) checkForDeletedJobs(ctx context.Context) {
@@ -240,9 +497,40 @@ func (js *spawner) checkForDeletedJobs(ctx context.Context) {
 	}
 }
 
+// purgeArchivedJobs permanently deletes the jobs that were archived longer
+// than JOB_ARCHIVE_RETENTION ago
+func (js *spawner) purgeArchivedJobs(ctx context.Context) {
+	retention := js.config.JobArchiveRetention()
+	if retention == 0 {
+		return
+	}
+	purged, err := js.orm.PurgeArchivedJobs(ctx, time.Now().Add(-retention))
+	if err != nil {
+		logger.Errorw("Failed to purge archived jobs", "error", err)
+	}
+	if purged > 0 {
+		logger.Infow("Purged archived jobs", "count", purged, "retention", retention)
+	}
+}
+
 func (js *spawner) unloadDeletedJob(ctx context.Context, jobID int32) {
 	logger.Infow("Unloading deleted job", "jobID", jobID)
+	js.unloadJob(ctx, jobID)
+	// The runs of a deleted job are deleted with it, so there is no point
+	// finishing them
+	if runner := js.runStopper(); runner != nil {
+		runner.CancelRuns(jobID)
+	}
+}
+
+// unloadJob stops the services for a job and releases this node's claim on it
+func (js *spawner) unloadJob(ctx context.Context, jobID int32) {
 	js.stopService(jobID)
+	js.releaseClaim(ctx, jobID)
+}
+
+// releaseClaim releases this node's claim on a job
+func (js *spawner) releaseClaim(ctx context.Context, jobID int32) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	if err := js.orm.UnclaimJob(ctx, jobID); err != nil {
@@ -250,6 +538,65 @@ func (js *spawner) unloadDeletedJob(ctx context.Context, jobID int32) {
 	}
 }
 
+func (js *spawner) RestartJob(ctx context.Context, jobID int32) error {
+	req := restartJobRequest{jobID: jobID, chErr: make(chan error, 1)}
+	select {
+	case js.chRestartJob <- req:
+	case <-js.chStop:
+		return errors.New("Job spawner has been stopped")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return <-req.chErr
+}
+
+func (js *spawner) restartJob(ctx context.Context, jobID int32) error {
+	if !js.isClaimed(jobID) {
+		return ErrJobNotClaimed
+	}
+	logger.Infow("Restarting job", "jobID", jobID)
+	js.reclaimJob(ctx, jobID)
+	return nil
+}
+
+// reclaimJob stops the services for a job and releases this node's claim on
+// it, then claims it again
+func (js *spawner) reclaimJob(ctx context.Context, jobID int32) {
+	js.unloadJob(ctx, jobID)
+	js.pendingClaimsMu.Lock()
+	js.pendingClaims[jobID] = struct{}{}
+	js.pendingClaimsMu.Unlock()
+	js.startUnclaimedServicesWorker.WakeUp()
+}
+
+// runStopper returns the pipeline runner, if it is a dependency
+func (js *spawner) runStopper() runStopper {
+	for _, dep := range js.dependencies {
+		if dep.Name != DependencyPipelineRunner {
+			continue
+		}
+		if runner, ok := dep.Service.(runStopper); ok {
+			return runner
+		}
+	}
+	return nil
+}
+
+// stopRuns stops the job's runs that this node is executing, cancelling them
+// if force is set and otherwise waiting for them to finish. The job's services
+// must already be stopped, so that they start no more runs.
+func (js *spawner) stopRuns(ctx context.Context, jobID int32, force bool) error {
+	runner := js.runStopper()
+	if runner == nil {
+		return nil
+	}
+	if force {
+		runner.CancelRuns(jobID)
+	}
+	// Cancelled runs are waited for too, as they may still save their results
+	return errors.Wrap(runner.AwaitRuns(ctx, jobID), "failed waiting for the job's runs to finish")
+}
+
 func (js *spawner) handlePGDeleteEvent(ctx context.Context, ev postgres.Event) {
 	jobIDString := ev.Payload
 	jobID64, err := strconv.ParseInt(jobIDString, 10, 32)
@@ -280,25 +627,83 @@ func (js *spawner) CreateJob(ctx context.Context, spec Job, name null.String) (i
 	}
 
 	logger.Infow("Created job", "type", spec.Type, "jobID", spec.ID)
+	js.eventBus.Publish(eventbus.JobCreated{
+		JobID: strconv.Itoa(int(spec.ID)),
+		Name:  spec.Name.ValueOrZero(),
+		Type:  string(spec.Type),
+	})
 	return spec.ID, err
 }
 
-func (js *spawner) DeleteJob(ctx context.Context, jobID int32) error {
+func (js *spawner) DeleteJob(ctx context.Context, jobID int32, force bool) error {
 	if jobID == 0 {
 		return errors.New("will not delete job with 0 ID")
 	}
 
 	// Stop the service if we own the job.
+	claimed := js.isClaimed(jobID)
 	js.stopService(jobID)
 
 	ctx, cancel := utils.CombinedContext(js.chStop, ctx)
 	defer cancel()
+	if err := js.stopRuns(ctx, jobID, force); err != nil {
+		if claimed {
+			// The job wasn't deleted, so start it again
+			js.reclaimJob(context.Background(), jobID)
+		}
+		return err
+	}
 	err := js.orm.DeleteJob(ctx, jobID)
 	if err != nil {
 		logger.Errorw("Error deleting job", "jobID", jobID, "error", err)
 		return err
 	}
 	logger.Infow("Deleted job", "jobID", jobID)
+	js.eventBus.Publish(eventbus.JobDeleted{JobID: strconv.Itoa(int(jobID))})
+
+	return nil
+}
+
+func (js *spawner) ArchiveJob(ctx context.Context, jobID int32, force bool) error {
+	if jobID == 0 {
+		return errors.New("will not archive job with 0 ID")
+	}
+
+	// Stop the service if we own the job.
+	claimed := js.isClaimed(jobID)
+	js.stopService(jobID)
+
+	ctx, cancel := utils.CombinedContext(js.chStop, ctx)
+	defer cancel()
+	if err := js.stopRuns(ctx, jobID, force); err != nil {
+		if claimed {
+			// The job wasn't archived, so start it again
+			js.reclaimJob(context.Background(), jobID)
+		}
+		return err
+	}
+	err := js.orm.ArchiveJob(ctx, jobID)
+	if err != nil {
+		logger.Errorw("Error archiving job", "jobID", jobID, "error", err)
+		return err
+	}
+	logger.Infow("Archived job", "jobID", jobID)
+	js.eventBus.Publish(eventbus.JobDeleted{JobID: strconv.Itoa(int(jobID)), Archived: true})
 
 	return nil
 }
+
+func (js *spawner) Drain(ctx context.Context) error {
+	if !js.draining.CAS(false, true) {
+		return nil
+	}
+	logger.Info("Job spawner draining, releasing all job claims")
+
+	ctx, cancel := utils.CombinedContext(js.chStop, ctx)
+	defer cancel()
+
+	for _, jobID := range js.claimedJobIDs() {
+		js.unloadJob(ctx, jobID)
+	}
+	return nil
+}
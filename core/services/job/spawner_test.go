@@ -21,6 +21,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/job/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	pipelinemocks "github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"gopkg.in/guregu/null.v4"
 )
@@ -82,25 +83,25 @@ func TestSpawner_CreateJobDeleteJob(t *testing.T) {
 		jobSpecB := makeOCRJobSpec(t, address)
 		jobSpecB.Type = jobTypeB
 
-		orm := job.NewORM(db, config.Config, pipeline.NewORM(db, config, eventBroadcaster), eventBroadcaster, &postgres.NullAdvisoryLocker{})
+		orm := job.NewORM(db, config.Config, pipeline.NewORM(db, config, eventBroadcaster, nil), eventBroadcaster, &postgres.NullAdvisoryLocker{})
 		defer orm.Close()
 		eventuallyA := cltest.NewAwaiter()
 		serviceA1 := new(mocks.Service)
 		serviceA2 := new(mocks.Service)
 		serviceA1.On("Start").Return(nil).Once()
 		serviceA2.On("Start").Return(nil).Once().Run(func(mock.Arguments) { eventuallyA.ItHappened() })
-		delegateA := &delegate{jobTypeA, []job.Service{serviceA1, serviceA2}, 0, make(chan struct{}), offchainreporting.NewDelegate(nil, orm, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint)}
+		delegateA := &delegate{jobTypeA, []job.Service{serviceA1, serviceA2}, 0, make(chan struct{}), offchainreporting.NewDelegate(nil, orm, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint, nil)}
 		eventuallyB := cltest.NewAwaiter()
 		serviceB1 := new(mocks.Service)
 		serviceB2 := new(mocks.Service)
 		serviceB1.On("Start").Return(nil).Once()
 		serviceB2.On("Start").Return(nil).Once().Run(func(mock.Arguments) { eventuallyB.ItHappened() })
 
-		delegateB := &delegate{jobTypeB, []job.Service{serviceB1, serviceB2}, 0, make(chan struct{}), offchainreporting.NewDelegate(nil, orm, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint)}
+		delegateB := &delegate{jobTypeB, []job.Service{serviceB1, serviceB2}, 0, make(chan struct{}), offchainreporting.NewDelegate(nil, orm, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint, nil)}
 		spawner := job.NewSpawner(orm, config, map[job.Type]job.Delegate{
 			jobTypeA: delegateA,
 			jobTypeB: delegateB,
-		})
+		}, nil)
 		spawner.Start()
 		jobSpecIDA, err := spawner.CreateJob(context.Background(), *jobSpecA, null.String{})
 		require.NoError(t, err)
@@ -123,11 +124,11 @@ func TestSpawner_CreateJobDeleteJob(t *testing.T) {
 
 		serviceA1.On("Close").Return(nil).Once()
 		serviceA2.On("Close").Return(nil).Once()
-		require.NoError(t, spawner.DeleteJob(ctx, jobSpecIDA))
+		require.NoError(t, spawner.DeleteJob(ctx, jobSpecIDA, true))
 
 		serviceB1.On("Close").Return(nil).Once()
 		serviceB2.On("Close").Return(nil).Once()
-		require.NoError(t, spawner.DeleteJob(ctx, jobSpecIDB))
+		require.NoError(t, spawner.DeleteJob(ctx, jobSpecIDB, true))
 
 		require.NoError(t, spawner.Close())
 		serviceA1.AssertExpectations(t)
@@ -148,12 +149,12 @@ func TestSpawner_CreateJobDeleteJob(t *testing.T) {
 		serviceA1.On("Start").Return(nil).Once()
 		serviceA2.On("Start").Return(nil).Once().Run(func(mock.Arguments) { eventually.ItHappened() })
 
-		orm := job.NewORM(db, config.Config, pipeline.NewORM(db, config, eventBroadcaster), eventBroadcaster, &postgres.NullAdvisoryLocker{})
+		orm := job.NewORM(db, config.Config, pipeline.NewORM(db, config, eventBroadcaster, nil), eventBroadcaster, &postgres.NullAdvisoryLocker{})
 		defer orm.Close()
-		delegateA := &delegate{jobTypeA, []job.Service{serviceA1, serviceA2}, 0, nil, offchainreporting.NewDelegate(nil, orm, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint)}
+		delegateA := &delegate{jobTypeA, []job.Service{serviceA1, serviceA2}, 0, nil, offchainreporting.NewDelegate(nil, orm, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint, nil)}
 		spawner := job.NewSpawner(orm, config, map[job.Type]job.Delegate{
 			jobTypeA: delegateA,
-		})
+		}, nil)
 
 		jobSpecIDA, err := spawner.CreateJob(context.Background(), *jobSpecA, null.String{})
 		require.NoError(t, err)
@@ -178,12 +179,12 @@ func TestSpawner_CreateJobDeleteJob(t *testing.T) {
 		eventually := cltest.NewAwaiter()
 		serviceA1 := new(mocks.Service)
 		serviceA2 := new(mocks.Service)
-		orm := job.NewORM(db, config.Config, pipeline.NewORM(db, config, eventBroadcaster), eventBroadcaster, &postgres.NullAdvisoryLocker{})
+		orm := job.NewORM(db, config.Config, pipeline.NewORM(db, config, eventBroadcaster, nil), eventBroadcaster, &postgres.NullAdvisoryLocker{})
 		defer orm.Close()
-		delegateA := &delegate{jobTypeA, []job.Service{serviceA1, serviceA2}, 0, nil, offchainreporting.NewDelegate(nil, orm, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint)}
+		delegateA := &delegate{jobTypeA, []job.Service{serviceA1, serviceA2}, 0, nil, offchainreporting.NewDelegate(nil, orm, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint, nil)}
 		spawner := job.NewSpawner(orm, config, map[job.Type]job.Delegate{
 			jobTypeA: delegateA,
-		})
+		}, nil)
 
 		serviceA1.On("Start").Return(nil).Once()
 		serviceA2.On("Start").Return(nil).Once().Run(func(mock.Arguments) { eventually.ItHappened() })
@@ -216,12 +217,12 @@ func TestSpawner_CreateJobDeleteJob(t *testing.T) {
 		serviceA1.On("Start").Return(nil).Once()
 		serviceA2.On("Start").Return(nil).Once().Run(func(mock.Arguments) { eventuallyStart.ItHappened() })
 
-		orm := job.NewORM(db, config.Config, pipeline.NewORM(db, config, eventBroadcaster), eventBroadcaster, &postgres.NullAdvisoryLocker{})
+		orm := job.NewORM(db, config.Config, pipeline.NewORM(db, config, eventBroadcaster, nil), eventBroadcaster, &postgres.NullAdvisoryLocker{})
 		defer orm.Close()
-		delegateA := &delegate{jobTypeA, []job.Service{serviceA1, serviceA2}, 0, nil, offchainreporting.NewDelegate(nil, nil, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint)}
+		delegateA := &delegate{jobTypeA, []job.Service{serviceA1, serviceA2}, 0, nil, offchainreporting.NewDelegate(nil, nil, nil, nil, nil, eth.NewClientWith(rpc, geth), nil, nil, monitoringEndpoint, nil)}
 		spawner := job.NewSpawner(orm, config, map[job.Type]job.Delegate{
 			jobTypeA: delegateA,
-		})
+		}, nil)
 
 		jobSpecIDA, err := spawner.CreateJob(context.Background(), *jobSpecA, null.String{})
 		require.NoError(t, err)
@@ -266,3 +267,72 @@ func TestSpawner_CreateJobDeleteJob(t *testing.T) {
 		mock.AssertExpectationsForObjects(t, serviceA1, serviceA2)
 	})
 }
+
+func TestSpawner_DeleteJob_StopsRuns(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	store, cleanup := cltest.NewStoreWithConfig(t, config)
+	defer cleanup()
+	db := store.DB
+
+	pipelineORM, eventBroadcaster, cleanupORM := cltest.NewPipelineORM(t, config, db)
+	defer cleanupORM()
+	orm := job.NewORM(db, config.Config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
+	defer orm.Close()
+
+	_, bridge := cltest.NewBridgeType(t, "voter_turnout", "http://blah.com")
+	require.NoError(t, db.Create(bridge).Error)
+	_, bridge2 := cltest.NewBridgeType(t, "election_winner", "http://blah.com")
+	require.NoError(t, db.Create(bridge2).Error)
+	key := cltest.MustInsertRandomKey(t, db)
+	createJob := func() int32 {
+		jb := makeOCRJobSpec(t, key.Address.Address())
+		require.NoError(t, orm.CreateJob(context.Background(), jb, jb.Pipeline))
+		return jb.ID
+	}
+
+	runner := new(pipelinemocks.Runner)
+	spawner := job.NewSpawner(orm, config, map[job.Type]job.Delegate{}, nil)
+	spawner.AddDependency(job.Dependency{Name: job.DependencyPipelineRunner, Service: runner})
+
+	t.Run("cancels the job's runs and waits for them before deleting it", func(t *testing.T) {
+		jobID := createJob()
+		var cancelled bool
+		runner.On("CancelRuns", jobID).Run(func(mock.Arguments) { cancelled = true }).Once()
+		runner.On("AwaitRuns", mock.Anything, jobID).Run(func(mock.Arguments) {
+			assert.True(t, cancelled)
+			_, err := orm.FindJob(jobID)
+			assert.NoError(t, err, "the job was deleted before its runs finished")
+		}).Return(nil).Once()
+
+		require.NoError(t, spawner.DeleteJob(context.Background(), jobID, true))
+		runner.AssertExpectations(t)
+		_, err := orm.FindJob(jobID)
+		require.Error(t, err)
+	})
+
+	t.Run("without force, only waits for the job's runs", func(t *testing.T) {
+		jobID := createJob()
+		runner.On("AwaitRuns", mock.Anything, jobID).Return(nil).Once()
+
+		require.NoError(t, spawner.ArchiveJob(context.Background(), jobID, false))
+		runner.AssertExpectations(t)
+		runner.AssertNotCalled(t, "CancelRuns", jobID)
+		archived, err := orm.ArchivedJobsV2()
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.Equal(t, jobID, archived[0].ID)
+	})
+
+	t.Run("keeps the job if its runs don't finish in time", func(t *testing.T) {
+		jobID := createJob()
+		runner.On("AwaitRuns", mock.Anything, jobID).Return(context.DeadlineExceeded).Once()
+
+		err := spawner.DeleteJob(context.Background(), jobID, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed waiting for the job's runs to finish")
+		runner.AssertExpectations(t)
+		_, err = orm.FindJob(jobID)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,41 @@
+package job
+
+import (
+	"sort"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// ignoredKeys are keys that aren't part of a job type's spec but are still
+// accepted in strict mode, so that existing specs keep working
+var ignoredKeys = map[Type][]string{}
+
+// CheckUnknownKeys returns an error naming every top-level key in the TOML
+// tree that isn't a field of the given job type. go-toml silently drops
+// undecoded keys, so without this check a misspelled field quietly falls back
+// to its default.
+func CheckUnknownKeys(tree *toml.Tree, t Type) error {
+	schema, err := SpecSchemaFor(t)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(schema.Fields))
+	for _, f := range schema.Fields {
+		known[f.Name] = true
+	}
+	for _, key := range ignoredKeys[t] {
+		known[key] = true
+	}
+
+	keys := tree.Keys()
+	sort.Strings(keys)
+	var merr error
+	for _, key := range keys {
+		if !known[key] {
+			merr = multierr.Append(merr, errors.Errorf("unrecognised key %q for %s job", key, t))
+		}
+	}
+	return merr
+}
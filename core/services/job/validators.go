@@ -0,0 +1,110 @@
+package job
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	storm "github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// Validator parses and validates a TOML spec of a single job type, returning
+// the job that it describes
+type Validator func(config *storm.Config, specTOML string) (Job, error)
+
+// ValidatorRegistry maps job types to the validators of their specs. The
+// application builds one with the validators of the built-in job types, and
+// specs submitted through the API, the operator gRPC API, a manifest or an
+// archive are all validated through it. Only the built-in job types can be
+// created: a job must have exactly one of their spec tables, as enforced by
+// the chk_only_one_spec constraint.
+type ValidatorRegistry struct {
+	mu         sync.RWMutex
+	validators map[Type]Validator
+}
+
+// NewValidatorRegistry returns a registry with no validators
+func NewValidatorRegistry() *ValidatorRegistry {
+	return &ValidatorRegistry{validators: make(map[Type]Validator)}
+}
+
+// Register sets the validator of a job type. Each type may only be registered
+// once.
+func (r *ValidatorRegistry) Register(t Type, validator Validator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.validators[t]; exists {
+		return errors.Errorf("a validator is already registered for %s jobs", t)
+	}
+	r.validators[t] = validator
+	return nil
+}
+
+// Types returns the registered job types, in order
+func (r *ValidatorRegistry) Types() []Type {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]Type, 0, len(r.validators))
+	for t := range r.validators {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// Validate validates a TOML job spec of any registered type. On top of the
+// type's own validation, it makes the checks of CheckJob. Specs of a type
+// without a validator return an error wrapping ErrUnknownJobType.
+func (r *ValidatorRegistry) Validate(config *storm.Config, specTOML string) (Job, error) {
+	t, err := SpecType(specTOML)
+	if err != nil {
+		return Job{}, err
+	}
+	if err = CheckJobTypeEnabled(config, t); err != nil {
+		return Job{}, err
+	}
+
+	r.mu.RLock()
+	validator, exists := r.validators[t]
+	r.mu.RUnlock()
+	if !exists {
+		return Job{}, errors.Wrapf(ErrUnknownJobType, "%s", t)
+	}
+
+	jb, err := validator(config, specTOML)
+	if err != nil {
+		return jb, err
+	}
+	return jb, CheckJob(config, jb)
+}
+
+// CheckJob checks that the job's type and its pipeline's tasks are enabled,
+// that the node allows the pipeline's task types and that onComplete is
+// valid. The ORM checks these again when the job is created, so that they
+// apply to jobs that weren't validated from TOML too.
+func CheckJob(config *storm.Config, jb Job) error {
+	if err := CheckJobTypeEnabled(config, jb.Type); err != nil {
+		return err
+	}
+	if err := jb.OnComplete.Validate(); err != nil {
+		return err
+	}
+	if err := CheckTasksEnabled(config, jb.Pipeline); err != nil {
+		return err
+	}
+	return CheckTasksAllowed(config, jb.Pipeline)
+}
+
+// SpecType returns the type of a TOML job spec, without validating the rest
+// of it
+func SpecType(specTOML string) (Type, error) {
+	var genericJS struct {
+		Type Type `toml:"type"`
+	}
+	if err := toml.Unmarshal([]byte(specTOML), &genericJS); err != nil {
+		return "", errors.Wrap(err, "failed to parse V2 job TOML")
+	}
+	return genericJS.Type, nil
+}
@@ -0,0 +1,63 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	storm "github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+func TestValidatorRegistry(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	const custom = job.Type("custom")
+	var validated string
+	registry := job.NewValidatorRegistry()
+	require.NoError(t, registry.Register(custom, func(config *storm.Config, specTOML string) (job.Job, error) {
+		validated = specTOML
+		return job.Job{Type: custom, SchemaVersion: 1, Name: null.StringFrom("custom")}, nil
+	}))
+
+	t.Run("validates specs with the validator of their type", func(t *testing.T) {
+		spec := `type = "custom"` + "\n" + `schemaVersion = 1`
+		jb, err := registry.Validate(config.Config, spec)
+		require.NoError(t, err)
+		assert.Equal(t, spec, validated)
+		assert.Equal(t, custom, jb.Type)
+		assert.Equal(t, "custom", jb.Name.ValueOrZero())
+	})
+
+	t.Run("rejects specs of a type without a validator", func(t *testing.T) {
+		_, err := registry.Validate(config.Config, `type = "keeper"`)
+		assert.Equal(t, job.ErrUnknownJobType, errors.Cause(err))
+		assert.EqualError(t, err, "keeper: unknown job type")
+	})
+
+	t.Run("rejects specs that aren't TOML", func(t *testing.T) {
+		_, err := registry.Validate(config.Config, `type = `)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse V2 job TOML")
+	})
+
+	t.Run("checks the validated job's onComplete", func(t *testing.T) {
+		const invalid = job.Type("invalid")
+		require.NoError(t, registry.Register(invalid, func(*storm.Config, string) (job.Job, error) {
+			return job.Job{Type: invalid, OnComplete: job.OnComplete{WebhookSecret: null.StringFrom("secret")}}, nil
+		}))
+		_, err := registry.Validate(config.Config, `type = "invalid"`)
+		assert.EqualError(t, err, "onComplete.webhookSecret requires onComplete.webhookURL")
+	})
+
+	t.Run("only registers one validator per type", func(t *testing.T) {
+		err := registry.Register(custom, func(*storm.Config, string) (job.Job, error) { return job.Job{}, nil })
+		assert.EqualError(t, err, "a validator is already registered for custom jobs")
+		assert.Equal(t, []job.Type{custom, "invalid"}, registry.Types())
+	})
+}
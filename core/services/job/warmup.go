@@ -0,0 +1,81 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// Warmup is a job service that executes one throwaway run of the job's
+// pipeline as the job starts, before the services after it. The run warms up
+// DNS caches, TLS sessions and provider-side caches, so that the job's first
+// real run is not the slow one.
+//
+// The warmup run is saved with {"warmup": true} meta, and is the readiness
+// signal for the job: if it fails, the failure is recorded as a job error.
+// A failed warmup run never stops the job from starting.
+type Warmup struct {
+	utils.StartStopOnce
+
+	job     Job
+	runner  pipeline.Runner
+	orm     ORM
+	timeout time.Duration
+	logger  *logger.Logger
+}
+
+// NewWarmup returns a service that warms up the job by executing its pipeline
+// once, giving up after timeout
+func NewWarmup(job Job, runner pipeline.Runner, orm ORM, timeout time.Duration) *Warmup {
+	job.PipelineSpec.JobID = job.ID
+	job.PipelineSpec.JobName = job.Name.ValueOrZero()
+	return &Warmup{
+		job:     job,
+		runner:  runner,
+		orm:     orm,
+		timeout: timeout,
+		logger: logger.CreateLogger(logger.Default.With(
+			"jobID", job.ID,
+			"jobName", job.Name.ValueOrZero(),
+		)),
+	}
+}
+
+// Start executes the warmup run, and returns once it has finished or timed out
+func (w *Warmup) Start() error {
+	if !w.OkayToStart() {
+		return errors.New("Warmup has already been started")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	start := time.Now()
+	meta := pipeline.JSONSerializable{Val: map[string]interface{}{"warmup": true}}
+	runID, result, err := w.runner.ExecuteAndInsertNewRun(ctx, *w.job.PipelineSpec, meta, *w.logger)
+	elapsed := time.Since(start)
+	if err == nil && result.HasErrors() {
+		err = errors.Errorf("run errored: %v", result.Errors)
+	}
+	if err != nil {
+		w.logger.Warnw("Warmup: warmup run failed, starting job anyway", "runID", runID, "elapsed", elapsed, "err", err)
+		w.orm.RecordError(context.Background(), w.job.ID, fmt.Sprintf("warmup run failed: %v", err))
+		return nil
+	}
+	w.logger.Infow("Warmup: warmup run succeeded", "runID", runID, "elapsed", elapsed)
+	return nil
+}
+
+// Close is a no-op, the warmup run finishes before Start returns
+func (w *Warmup) Close() error {
+	if !w.OkayToStop() {
+		return errors.New("Warmup has already been stopped")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package job_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/job/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	pipelinemocks "github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+func TestWarmup_Start(t *testing.T) {
+	t.Parallel()
+
+	jb := job.Job{
+		IDEmbed:      job.IDEmbed{ID: 42},
+		Name:         null.StringFrom("warm"),
+		PipelineSpec: &pipeline.Spec{ID: 7},
+	}
+	warmupMeta := pipeline.JSONSerializable{Val: map[string]interface{}{"warmup": true}}
+	matchSpec := mock.MatchedBy(func(spec pipeline.Spec) bool {
+		return spec.ID == 7 && spec.JobID == 42 && spec.JobName == "warm"
+	})
+
+	t.Run("saves a successful warmup run", func(t *testing.T) {
+		runner := new(pipelinemocks.Runner)
+		orm := new(mocks.ORM)
+		runner.On("ExecuteAndInsertNewRun", mock.Anything, matchSpec, warmupMeta, mock.Anything).
+			Return(int64(1), pipeline.FinalResult{Values: []interface{}{"100"}, Errors: []error{nil}}, nil).
+			Once()
+
+		warmup := job.NewWarmup(jb, runner, orm, time.Second)
+		require.NoError(t, warmup.Start())
+		require.NoError(t, warmup.Close())
+
+		runner.AssertExpectations(t)
+		orm.AssertNotCalled(t, "RecordError", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("records a job error when the warmup run fails, without failing to start", func(t *testing.T) {
+		runner := new(pipelinemocks.Runner)
+		orm := new(mocks.ORM)
+		runner.On("ExecuteAndInsertNewRun", mock.Anything, matchSpec, warmupMeta, mock.Anything).
+			Return(int64(1), pipeline.FinalResult{Values: []interface{}{nil}, Errors: []error{errors.New("connection refused")}}, nil).
+			Once()
+		orm.On("RecordError", mock.Anything, int32(42), "warmup run failed: run errored: [connection refused]").Once()
+
+		warmup := job.NewWarmup(jb, runner, orm, time.Second)
+		require.NoError(t, warmup.Start())
+		require.NoError(t, warmup.Close())
+
+		runner.AssertExpectations(t)
+		orm.AssertExpectations(t)
+	})
+}
@@ -69,6 +69,9 @@ func (b *nextBlockWorker) Work() {
 	if err != nil {
 		logger.Errorw("Failed to resume confirming tasks on new head", "error", err)
 	}
+	if err := b.runManager.ExpireAllPendingBridge(); err != nil {
+		logger.Errorw("Failed to expire runs pending bridge callbacks", "error", err)
+	}
 }
 
 // NewJobSubscriber returns a new job subscriber.
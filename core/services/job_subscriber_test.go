@@ -25,6 +25,7 @@ func TestJobSubscriber_OnNewLongestChain(t *testing.T) {
 	defer cleanup()
 
 	runManager := new(mocks.RunManager)
+	runManager.On("ExpireAllPendingBridge").Return(nil)
 	jobSubscriber := services.NewJobSubscriber(store, runManager)
 	defer jobSubscriber.Stop()
 
@@ -0,0 +1,276 @@
+// Package jobmigration converts legacy v1 JSON job specs to v2 TOML job specs,
+// to help move jobs off the v1 run system. Chains of httpget, httppost,
+// jsonparse, multiply and bridge tasks become the job's pipeline, and runlog
+// initiators become directrequest jobs. Anything that can't be converted, or
+// behaves differently in v2, is flagged in the conversion's warnings rather
+// than silently dropped.
+package jobmigration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// Conversion is a v1 job spec converted to a v2 TOML job spec
+type Conversion struct {
+	TOML string `json:"toml"`
+	// Warnings are the parts of the v1 spec that were not converted, or that
+	// need reviewing. They are also at the top of TOML, as comments.
+	Warnings []string `json:"warnings"`
+}
+
+// node is a task of the converted pipeline
+type node struct {
+	id    string
+	attrs [][2]string
+}
+
+type converter struct {
+	jobType         string
+	contractAddress common.Address
+	nodes           []node
+	warnings        []string
+}
+
+func (c *converter) warn(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// ConvertV1JobSpec converts a v1 job spec to a v2 TOML job spec. It only
+// fails if the spec's task parameters are invalid; unconvertible parts of the
+// spec are returned as warnings.
+func ConvertV1JobSpec(jsr models.JobSpecRequest) (Conversion, error) {
+	c := converter{warnings: []string{}}
+	c.convertInitiators(jsr.Initiators)
+	for i, task := range jsr.Tasks {
+		if err := c.convertTask(i, task); err != nil {
+			return Conversion{}, errors.Wrapf(err, "task %d (%s)", i, task.Type)
+		}
+	}
+	if len(c.nodes) == 0 {
+		c.warn("None of the tasks could be converted, so the pipeline is empty")
+	}
+	if jsr.StartAt.Valid || jsr.EndAt.Valid {
+		c.warn("startAt and endAt have no v2 equivalent, so the job runs until it is deleted")
+	}
+	if jsr.MinPayment != nil {
+		c.warn("minPayment has no v2 directrequest equivalent")
+	}
+
+	source := c.observationSource()
+	if len(c.nodes) > 0 {
+		// Guards against producing a pipeline that doesn't parse
+		dag := pipeline.NewTaskDAG()
+		if err := dag.UnmarshalText([]byte(source)); err != nil {
+			return Conversion{}, errors.Wrap(err, "converted pipeline is invalid")
+		}
+		if _, err := dag.TasksInDependencyOrder(); err != nil {
+			return Conversion{}, errors.Wrap(err, "converted pipeline is invalid")
+		}
+	}
+	return Conversion{TOML: c.toml(jsr.Name, source), Warnings: c.warnings}, nil
+}
+
+func (c *converter) convertInitiators(initiators []models.InitiatorRequest) {
+	if len(initiators) == 0 {
+		c.warn("The spec has no initiator, so the job has no type")
+		return
+	}
+	for i, initiator := range initiators {
+		if i > 0 {
+			c.warn("Only the first initiator was converted, %s was left out", initiator.Type)
+			continue
+		}
+		switch strings.ToLower(initiator.Type) {
+		case models.InitiatorRunLog:
+			c.jobType = string(job.DirectRequest)
+			c.contractAddress = initiator.Address
+			if initiator.Address == (common.Address{}) {
+				c.warn("The runlog initiator has no address, so contractAddress must be set to the oracle contract's address")
+			}
+			if len(initiator.Requesters) > 0 {
+				c.warn("The runlog initiator's requesters have no v2 equivalent, so requests from any address are run")
+			}
+		case models.InitiatorCron:
+			c.warn("The cron initiator (schedule %q) has no v2 equivalent, so the job has no type. Its runs can be triggered with POST /v2/jobs/:ID/runs instead.", initiator.Schedule)
+		default:
+			c.warn("The %s initiator has no v2 equivalent, so the job has no type", initiator.Type)
+		}
+	}
+}
+
+func (c *converter) convertTask(i int, task models.TaskSpecRequest) error {
+	taskType := models.TaskType(strings.ToLower(task.Type.String()))
+	if task.MinRequiredIncomingConfirmations.Valid {
+		c.warn("Task %d (%s): confirmations have no v2 equivalent", i, taskType)
+	}
+	params := task.Params.Bytes()
+	if len(params) == 0 {
+		params = []byte("{}")
+	}
+
+	switch taskType {
+	case adapters.TaskTypeHTTPGet, adapters.TaskTypeHTTPGetWithUnrestrictedNetworkAccess:
+		var get adapters.HTTPGet
+		if err := json.Unmarshal(params, &get); err != nil {
+			return err
+		}
+		request, err := get.GetRequest()
+		if err != nil {
+			return err
+		}
+		c.addHTTP(i, taskType, "GET", request, get.Headers)
+		c.requireURL(i, taskType, get.GetURL())
+	case adapters.TaskTypeHTTPPost, adapters.TaskTypeHTTPPostWithUnrestrictedNetworkAccess:
+		var post adapters.HTTPPost
+		if err := json.Unmarshal(params, &post); err != nil {
+			return err
+		}
+		request, err := post.GetRequest("")
+		if err != nil {
+			return err
+		}
+		n := c.addHTTP(i, taskType, "POST", request, post.Headers)
+		c.requireURL(i, taskType, post.GetURL())
+		var body map[string]interface{}
+		switch {
+		case post.Body == nil:
+			c.warn("Task %d (%s): v1 posted the run's data, which v2 http tasks can't send, so requestData must be set to the body the endpoint expects", i, taskType)
+		case json.Unmarshal([]byte(*post.Body), &body) != nil:
+			c.warn("Task %d (%s): the body is not a JSON object, which is all that v2 http tasks can send, so it was left out", i, taskType)
+		default:
+			n.attrs = append(n.attrs, [2]string{"requestData", *post.Body})
+		}
+	case adapters.TaskTypeJSONParse:
+		var parse adapters.JSONParse
+		if err := json.Unmarshal(params, &parse); err != nil {
+			return err
+		}
+		if len(parse.Path) == 0 {
+			c.warn("Task %d (%s): the path is not in the spec; in v1 it came from the run's request, which v2 tasks can't read", i, taskType)
+		}
+		c.add(i, "jsonparse", [2]string{"type", "jsonparse"}, [2]string{"path", strings.Join(parse.Path, ",")})
+	case adapters.TaskTypeMultiply:
+		var multiply adapters.Multiply
+		if err := json.Unmarshal(params, &multiply); err != nil {
+			return err
+		}
+		times := ""
+		if multiply.Times != nil {
+			times = multiply.Times.String()
+		} else {
+			c.warn("Task %d (%s): times is not in the spec; in v1 it came from the run's request, which v2 tasks can't read", i, taskType)
+		}
+		c.add(i, "multiply", [2]string{"type", "multiply"}, [2]string{"times", times})
+	case adapters.TaskTypeNoOp:
+	case adapters.TaskTypeEthTx, adapters.TaskTypeEthBool, adapters.TaskTypeEthBytes32,
+		adapters.TaskTypeEthInt256, adapters.TaskTypeEthUint256:
+		c.warn("Task %d (%s): v2 pipelines have no task that encodes results or sends transactions, so it was left out", i, taskType)
+	default:
+		if adapters.FindNativeAdapterFor(models.TaskSpec{Type: taskType}) != nil {
+			c.warn("Task %d (%s): the adapter has no v2 equivalent, so it was left out", i, taskType)
+			return nil
+		}
+		// Any other task type is the name of a bridge, which v2 bridge tasks
+		// call with the same request data
+		attrs := [][2]string{{"type", "bridge"}, {"name", taskType.String()}}
+		var data map[string]interface{}
+		if err := json.Unmarshal(params, &data); err != nil {
+			return err
+		}
+		if len(data) > 0 {
+			requestData, err := json.Marshal(map[string]interface{}{"data": data})
+			if err != nil {
+				return err
+			}
+			attrs = append(attrs, [2]string{"requestData", string(requestData)})
+		}
+		c.add(i, "bridge", attrs...)
+	}
+	return nil
+}
+
+func (c *converter) add(i int, kind string, attrs ...[2]string) *node {
+	c.nodes = append(c.nodes, node{id: fmt.Sprintf("%s%d", kind, i+1), attrs: attrs})
+	return &c.nodes[len(c.nodes)-1]
+}
+
+func (c *converter) addHTTP(i int, taskType models.TaskType, method string, request *http.Request, headers http.Header) *node {
+	attrs := [][2]string{{"type", "http"}, {"method", method}, {"url", request.URL.String()}}
+	if strings.HasSuffix(taskType.String(), "withunrestrictednetworkaccess") {
+		attrs = append(attrs, [2]string{"allowunrestrictednetworkaccess", "true"})
+	}
+	if len(headers) > 0 {
+		c.warn("Task %d (%s): v2 http tasks can't send headers, so they were left out", i, taskType)
+	}
+	return c.add(i, "http", attrs...)
+}
+
+func (c *converter) requireURL(i int, taskType models.TaskType, url string) {
+	if url == "" {
+		c.warn("Task %d (%s): the URL is not in the spec; in v1 it came from the run's request, which v2 tasks can't read", i, taskType)
+	}
+}
+
+// observationSource returns the DOT of the pipeline, with the tasks chained in
+// the order of the v1 spec
+func (c *converter) observationSource() string {
+	var b strings.Builder
+	ids := make([]string, len(c.nodes))
+	for i, n := range c.nodes {
+		ids[i] = n.id
+		attrs := make([]string, len(n.attrs))
+		for j, attr := range n.attrs {
+			attrs[j] = fmt.Sprintf("%s=%s", attr[0], dotQuote(attr[1]))
+		}
+		fmt.Fprintf(&b, "%s [%s];\n", n.id, strings.Join(attrs, " "))
+	}
+	if len(ids) > 1 {
+		fmt.Fprintf(&b, "\n%s;\n", strings.Join(ids, " -> "))
+	}
+	return b.String()
+}
+
+func (c *converter) toml(name, source string) string {
+	var b strings.Builder
+	if len(c.warnings) > 0 {
+		b.WriteString("# Converted from a v1 job spec. Review these before creating the job:\n")
+		for _, warning := range c.warnings {
+			fmt.Fprintf(&b, "# - %s\n", strings.ReplaceAll(warning, "\n", " "))
+		}
+	}
+	if c.jobType != "" {
+		fmt.Fprintf(&b, "type = %s\n", tomlQuote(c.jobType))
+	}
+	b.WriteString("schemaVersion = 1\n")
+	if name != "" {
+		fmt.Fprintf(&b, "name = %s\n", tomlQuote(name))
+	}
+	if c.jobType == string(job.DirectRequest) {
+		fmt.Fprintf(&b, "contractAddress = %s\n", tomlQuote(c.contractAddress.Hex()))
+	}
+	// A literal string, so that the DOT needs no escaping for TOML
+	fmt.Fprintf(&b, "observationSource = '''\n%s'''\n", source)
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// tomlQuote returns s as a TOML basic string, whose escapes are a superset of
+// those produced by JSON
+func tomlQuote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,173 @@
+package jobmigration_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/directrequest"
+	"github.com/smartcontractkit/chainlink/core/services/jobmigration"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+func mustJobSpecRequest(t *testing.T, spec string) models.JobSpecRequest {
+	t.Helper()
+	var jsr models.JobSpecRequest
+	require.NoError(t, json.Unmarshal([]byte(spec), &jsr))
+	return jsr
+}
+
+func TestConvertV1JobSpec_RunLog(t *testing.T) {
+	jsr := mustJobSpecRequest(t, `{
+		"name": "eth-usd",
+		"initiators": [{"type": "RunLog", "params": {"address": "0x613a38AC1659769640aaE063C651F48E0250454C"}}],
+		"tasks": [
+			{"type": "HttpGet", "params": {"get": "https://example.com/api", "queryParams": "fsym=ETH"}},
+			{"type": "JsonParse", "params": {"path": ["data", "USD"]}},
+			{"type": "Multiply", "params": {"times": 100}},
+			{"type": "EthUint256"},
+			{"type": "EthTx"}
+		]
+	}`)
+
+	conversion, err := jobmigration.ConvertV1JobSpec(jsr)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Task 3 (ethuint256): v2 pipelines have no task that encodes results or sends transactions, so it was left out",
+		"Task 4 (ethtx): v2 pipelines have no task that encodes results or sends transactions, so it was left out",
+	}, conversion.Warnings)
+	assert.Equal(t, `# Converted from a v1 job spec. Review these before creating the job:
+# - Task 3 (ethuint256): v2 pipelines have no task that encodes results or sends transactions, so it was left out
+# - Task 4 (ethtx): v2 pipelines have no task that encodes results or sends transactions, so it was left out
+type = "directrequest"
+schemaVersion = 1
+name = "eth-usd"
+contractAddress = "0x613a38AC1659769640aaE063C651F48E0250454C"
+observationSource = '''
+http1 [type="http" method="GET" url="https://example.com/api?fsym=ETH"];
+jsonparse2 [type="jsonparse" path="data,USD"];
+multiply3 [type="multiply" times="100"];
+
+http1 -> jsonparse2 -> multiply3;
+'''
+`, conversion.TOML)
+
+	jb, err := directrequest.ValidatedDirectRequestSpec(orm.NewConfig(), conversion.TOML)
+	require.NoError(t, err)
+	assert.Equal(t, "eth-usd", jb.Name.ValueOrZero())
+	assert.Equal(t, "0x613a38AC1659769640aaE063C651F48E0250454C", jb.DirectRequestSpec.ContractAddress.Hex())
+	tasks, err := jb.Pipeline.TasksInDependencyOrder()
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3)
+}
+
+func TestConvertV1JobSpec_Tasks(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     string
+		source   string
+		warnings []string
+	}{
+		{
+			"httppost with a JSON body",
+			`{"type": "httppost", "params": {"post": "https://example.com", "body": "{\"a\":1}"}}`,
+			"http1 [type=\"http\" method=\"POST\" url=\"https://example.com\" requestData=\"{\\\"a\\\":1}\"];\n",
+			nil,
+		},
+		{
+			"httppost without a body",
+			`{"type": "httppost", "params": {"post": "https://example.com"}}`,
+			"http1 [type=\"http\" method=\"POST\" url=\"https://example.com\"];\n",
+			[]string{"Task 0 (httppost): v1 posted the run's data, which v2 http tasks can't send, so requestData must be set to the body the endpoint expects"},
+		},
+		{
+			"httpget with unrestricted network access and headers",
+			`{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "http://localhost:8080", "extPath": "price", "headers": {"X-Key": ["abc"]}}}`,
+			"http1 [type=\"http\" method=\"GET\" url=\"http://localhost:8080/price\" allowunrestrictednetworkaccess=\"true\"];\n",
+			[]string{"Task 0 (httpgetwithunrestrictednetworkaccess): v2 http tasks can't send headers, so they were left out"},
+		},
+		{
+			"httpget with the URL from the request",
+			`{"type": "httpget"}`,
+			"http1 [type=\"http\" method=\"GET\" url=\"\"];\n",
+			[]string{"Task 0 (httpget): the URL is not in the spec; in v1 it came from the run's request, which v2 tasks can't read"},
+		},
+		{
+			"bridge",
+			`{"type": "coinmarketcap", "params": {"coin": "ETH"}}`,
+			"bridge1 [type=\"bridge\" name=\"coinmarketcap\" requestData=\"{\\\"data\\\":{\\\"coin\\\":\\\"ETH\\\"}}\"];\n",
+			nil,
+		},
+		{
+			"adapter without a v2 equivalent",
+			`{"type": "compare", "params": {"operator": "eq", "value": "1"}}`,
+			"",
+			[]string{
+				"Task 0 (compare): the adapter has no v2 equivalent, so it was left out",
+				"None of the tasks could be converted, so the pipeline is empty",
+			},
+		},
+		{
+			"confirmations",
+			`{"type": "noop", "confirmations": 3}`,
+			"",
+			[]string{
+				"Task 0 (noop): confirmations have no v2 equivalent",
+				"None of the tasks could be converted, so the pipeline is empty",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jsr := mustJobSpecRequest(t, `{
+				"initiators": [{"type": "runlog", "params": {"address": "0x613a38AC1659769640aaE063C651F48E0250454C"}}],
+				"tasks": [`+test.task+`]
+			}`)
+
+			conversion, err := jobmigration.ConvertV1JobSpec(jsr)
+			require.NoError(t, err)
+			assert.Contains(t, conversion.TOML, "observationSource = '''\n"+test.source+"'''\n")
+			if test.warnings == nil {
+				test.warnings = []string{}
+			}
+			assert.Equal(t, test.warnings, conversion.Warnings)
+		})
+	}
+}
+
+func TestConvertV1JobSpec_Initiators(t *testing.T) {
+	jsr := mustJobSpecRequest(t, `{
+		"initiators": [
+			{"type": "cron", "params": {"schedule": "CRON_TZ=UTC * * * * *"}},
+			{"type": "web"}
+		],
+		"tasks": [{"type": "httpget", "params": {"get": "https://example.com"}}],
+		"minPayment": "100"
+	}`)
+
+	conversion, err := jobmigration.ConvertV1JobSpec(jsr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`The cron initiator (schedule "CRON_TZ=UTC * * * * *") has no v2 equivalent, so the job has no type. Its runs can be triggered with POST /v2/jobs/:ID/runs instead.`,
+		"Only the first initiator was converted, web was left out",
+		"minPayment has no v2 directrequest equivalent",
+	}, conversion.Warnings)
+	assert.NotContains(t, conversion.TOML, "\ntype =")
+	assert.NotContains(t, conversion.TOML, "contractAddress")
+}
+
+func TestConvertV1JobSpec_InvalidParams(t *testing.T) {
+	jsr := mustJobSpecRequest(t, `{
+		"initiators": [{"type": "web"}],
+		"tasks": [{"type": "multiply", "params": {"times": "lots"}}]
+	}`)
+
+	_, err := jobmigration.ConvertV1JobSpec(jsr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "task 0 (multiply)")
+}
@@ -39,6 +39,11 @@ func (d *Delegate) JobType() job.Type {
 	return job.Keeper
 }
 
+// Dependencies implements the job.DelegateWithDependencies interface
+func (d *Delegate) Dependencies() []string {
+	return []string{job.DependencyLogBroadcaster}
+}
+
 func (d *Delegate) ServicesForSpec(spec job.Job) (services []job.Service, err error) {
 	if spec.KeeperSpec == nil {
 		return nil, errors.Errorf("Delegate expects a *job.KeeperSpec to be present, got %v", spec)
@@ -146,8 +146,8 @@ func (korm ORM) CreateEthTransactionForUpkeep(ctx context.Context, upkeep Upkeep
 
 	value := 0
 	res, err := sqlDB.ExecContext(ctx, `
-		INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at)
-		SELECT $1,$2,$3,$4,$5,'unstarted',NOW()
+		INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, job_id, state, created_at)
+		SELECT $1,$2,$3,$4,$5,NULLIF($6, 0),'unstarted',NOW()
 		WHERE NOT EXISTS (
 			SELECT 1 FROM eth_tx_attempts
 			JOIN eth_txes ON eth_txes.id = eth_tx_attempts.eth_tx_id
@@ -160,6 +160,7 @@ func (korm ORM) CreateEthTransactionForUpkeep(ctx context.Context, upkeep Upkeep
 		payload,
 		value,
 		upkeep.ExecuteGas+gasBuffer,
+		upkeep.Registry.JobID,
 	)
 
 	if err != nil {
@@ -5,9 +5,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 )
 
-func ValidatedKeeperSpec(tomlString string) (job.Job, error) {
+func ValidatedKeeperSpec(config *orm.Config, tomlString string) (job.Job, error) {
 	var j = job.Job{
 		Pipeline: *pipeline.NewTaskDAG(),
 	}
@@ -16,6 +17,11 @@ func ValidatedKeeperSpec(tomlString string) (job.Job, error) {
 	if err != nil {
 		return j, err
 	}
+	if config.JobSpecStrictTOML() {
+		if err = job.CheckUnknownKeys(tree, job.Keeper); err != nil {
+			return j, err
+		}
+	}
 	err = tree.Unmarshal(&j)
 	if err != nil {
 		return j, err
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,7 +18,7 @@ func TestValidatedKeeperSpec(t *testing.T) {
 		fromAddress         = "0xa8037A20989AFcBC51798de9762b351D63ff462e"
 	`
 
-	s, err := ValidatedKeeperSpec(toml)
+	s, err := ValidatedKeeperSpec(orm.NewConfig(), toml)
 	require.NoError(t, err)
 
 	require.Equal(t, int32(0), s.ID)
@@ -26,3 +27,22 @@ func TestValidatedKeeperSpec(t *testing.T) {
 	require.Equal(t, time.Time{}, s.KeeperSpec.CreatedAt)
 	require.Equal(t, time.Time{}, s.KeeperSpec.UpdatedAt)
 }
+
+func TestValidatedKeeperSpec_UnknownKeys(t *testing.T) {
+	t.Parallel()
+	toml := `
+		type                = "keeper"
+		schemaVersion       = 1
+		contractAddress     = "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba"
+		fromAdress          = "0xa8037A20989AFcBC51798de9762b351D63ff462e"
+	`
+
+	_, err := ValidatedKeeperSpec(orm.NewConfig(), toml)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unrecognised key "fromAdress"`)
+
+	config := orm.NewConfig()
+	config.Set("JOB_SPEC_STRICT_TOML", false)
+	_, err = ValidatedKeeperSpec(config, toml)
+	require.NoError(t, err)
+}
@@ -0,0 +1,183 @@
+// Package maintenance puts the node into maintenance mode, giving operators
+// a safe window for database maintenance. While the node is in maintenance
+// mode, no pipeline runs are created, executed or reaped, archived jobs
+// aren't purged, runs of v1 jobs are held in the run queue, and OCR jobs
+// don't transmit. OCR jobs can optionally stop observing too. The mode is
+// saved in the database, so the node stays in maintenance mode across
+// restarts until it is resumed.
+//
+// Maintenance mode quiets the job pipelines, but it doesn't make the node
+// read-only. Runs of v1 jobs are still recorded when they are triggered, so
+// that no requests are lost, and the head tracker, log broadcaster and
+// transaction manager keep writing.
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// ErrMaintenanceMode is returned by whatever refuses to run while the node is
+// in maintenance mode
+var ErrMaintenanceMode = errors.New("node is in maintenance mode")
+
+// configurationName is the name of the configurations row that the mode is
+// saved in
+const configurationName = "MAINTENANCE_MODE"
+
+var promMaintenanceMode = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "node_maintenance_mode",
+	Help: "Whether the node is in maintenance mode",
+})
+
+// Mode is the maintenance mode of the node
+type Mode struct {
+	Enabled bool `json:"enabled"`
+	// PauseObservations stops OCR jobs from observing, as well as from
+	// transmitting. Without it, OCR jobs keep taking part in the protocol
+	// for other nodes' transmissions.
+	PauseObservations bool       `json:"pauseObservations"`
+	Reason            string     `json:"reason,omitempty"`
+	Since             *time.Time `json:"since,omitempty"`
+}
+
+// Switch holds the maintenance mode of the node. The methods reporting what
+// is paused may be called on a nil Switch, which never pauses anything.
+type Switch struct {
+	db *gorm.DB
+
+	mu       sync.RWMutex
+	mode     Mode
+	onResume []func()
+}
+
+// NewSwitch returns a Switch in the mode last saved in db
+func NewSwitch(db *gorm.DB) (*Switch, error) {
+	s := &Switch{db: db}
+	var config models.Configuration
+	err := db.First(&config, "name = ?", configurationName).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not load maintenance mode")
+	}
+	if err := json.Unmarshal([]byte(config.Value), &s.mode); err != nil {
+		return nil, errors.Wrap(err, "could not parse maintenance mode")
+	}
+	if s.mode.Enabled {
+		logger.Warnw("Maintenance: node is still in maintenance mode, resume it to create runs and transmit again",
+			"since", s.mode.Since,
+			"reason", s.mode.Reason,
+		)
+		promMaintenanceMode.Set(1)
+	}
+	return s, nil
+}
+
+// Mode returns the current maintenance mode
+func (s *Switch) Mode() Mode {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mode
+}
+
+// Enable puts the node into maintenance mode. Enabling it again updates
+// PauseObservations and the reason, keeping the time it was first enabled.
+func (s *Switch) Enable(ctx context.Context, pauseObservations bool, reason string) (Mode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mode := Mode{
+		Enabled:           true,
+		PauseObservations: pauseObservations,
+		Reason:            reason,
+		Since:             s.mode.Since,
+	}
+	if mode.Since == nil {
+		now := time.Now()
+		mode.Since = &now
+	}
+	if err := s.save(ctx, mode); err != nil {
+		return s.mode, err
+	}
+	s.mode = mode
+	promMaintenanceMode.Set(1)
+	logger.Warnw("Maintenance: node is now in maintenance mode",
+		"pauseObservations", pauseObservations,
+		"reason", reason,
+	)
+	return s.mode, nil
+}
+
+// Resume takes the node out of maintenance mode and calls the OnResume
+// callbacks, so that the runs held back are picked up again
+func (s *Switch) Resume(ctx context.Context) (Mode, error) {
+	s.mu.Lock()
+	wasEnabled := s.mode.Enabled
+	if err := s.save(ctx, Mode{}); err != nil {
+		s.mu.Unlock()
+		return s.mode, err
+	}
+	s.mode = Mode{}
+	onResume := s.onResume
+	s.mu.Unlock()
+
+	promMaintenanceMode.Set(0)
+	if wasEnabled {
+		logger.Infow("Maintenance: node resumed")
+		for _, fn := range onResume {
+			fn()
+		}
+	}
+	return Mode{}, nil
+}
+
+// OnResume registers fn to be called whenever the node is resumed
+func (s *Switch) OnResume(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onResume = append(s.onResume, fn)
+}
+
+// RunsPaused reports whether runs may not be created or executed
+func (s *Switch) RunsPaused() bool {
+	if s == nil {
+		return false
+	}
+	return s.Mode().Enabled
+}
+
+// TransmissionsPaused reports whether OCR jobs may not transmit
+func (s *Switch) TransmissionsPaused() bool {
+	return s.RunsPaused()
+}
+
+// ObservationsPaused reports whether OCR jobs may not observe
+func (s *Switch) ObservationsPaused() bool {
+	if s == nil {
+		return false
+	}
+	mode := s.Mode()
+	return mode.Enabled && mode.PauseObservations
+}
+
+func (s *Switch) save(ctx context.Context, mode Mode) error {
+	value, err := json.Marshal(mode)
+	if err != nil {
+		return err
+	}
+	err = s.db.WithContext(ctx).Where(models.Configuration{Name: configurationName}).
+		Assign(models.Configuration{Name: configurationName, Value: string(value)}).
+		FirstOrCreate(&models.Configuration{}).Error
+	return errors.Wrap(err, "could not save maintenance mode")
+}
@@ -0,0 +1,159 @@
+package maintenance_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	jobmocks "github.com/smartcontractkit/chainlink/core/services/job/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/maintenance"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+func TestSwitch(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sw, err := maintenance.NewSwitch(store.DB)
+	require.NoError(t, err)
+	assert.False(t, sw.RunsPaused())
+	assert.False(t, sw.TransmissionsPaused())
+	assert.False(t, sw.ObservationsPaused())
+
+	mode, err := sw.Enable(ctx, false, "vacuum")
+	require.NoError(t, err)
+	assert.True(t, mode.Enabled)
+	assert.Equal(t, "vacuum", mode.Reason)
+	require.NotNil(t, mode.Since)
+	assert.True(t, sw.RunsPaused())
+	assert.True(t, sw.TransmissionsPaused())
+	assert.False(t, sw.ObservationsPaused())
+
+	// Enabling it again keeps the time it was first enabled
+	since := *mode.Since
+	mode, err = sw.Enable(ctx, true, "reindex")
+	require.NoError(t, err)
+	assert.True(t, since.Equal(*mode.Since))
+	assert.True(t, sw.ObservationsPaused())
+
+	t.Run("persists across restarts", func(t *testing.T) {
+		restarted, err := maintenance.NewSwitch(store.DB)
+		require.NoError(t, err)
+		restartedMode := restarted.Mode()
+		assert.True(t, restartedMode.Enabled)
+		assert.True(t, restartedMode.PauseObservations)
+		assert.Equal(t, "reindex", restartedMode.Reason)
+	})
+
+	resumed := make(chan struct{}, 1)
+	sw.OnResume(func() { resumed <- struct{}{} })
+	mode, err = sw.Resume(ctx)
+	require.NoError(t, err)
+	assert.False(t, mode.Enabled)
+	assert.False(t, sw.RunsPaused())
+	assert.Len(t, resumed, 1)
+
+	restarted, err := maintenance.NewSwitch(store.DB)
+	require.NoError(t, err)
+	assert.False(t, restarted.RunsPaused())
+}
+
+func TestSwitch_Nil(t *testing.T) {
+	var sw *maintenance.Switch
+	assert.False(t, sw.RunsPaused())
+	assert.False(t, sw.TransmissionsPaused())
+	assert.False(t, sw.ObservationsPaused())
+}
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sw, err := maintenance.NewSwitch(store.DB)
+	require.NoError(t, err)
+	r := new(mocks.Runner)
+	runner := maintenance.NewRunner(r, sw)
+
+	r.On("CreateRun", ctx, int32(1), map[string]interface{}(nil)).Return(int64(42), nil).Once()
+	runID, err := runner.CreateRun(ctx, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), runID)
+
+	_, err = sw.Enable(ctx, false, "")
+	require.NoError(t, err)
+
+	_, err = runner.CreateRun(ctx, 1, nil)
+	assert.Equal(t, maintenance.ErrMaintenanceMode, err)
+	_, _, err = runner.ExecuteAndInsertNewRun(ctx, pipeline.Spec{}, pipeline.JSONSerializable{}, *logger.Default)
+	assert.Equal(t, maintenance.ErrMaintenanceMode, err)
+	_, err = runner.InsertFinishedRunWithResults(ctx, pipeline.Run{}, nil)
+	assert.Equal(t, maintenance.ErrMaintenanceMode, err)
+
+	r.AssertExpectations(t)
+}
+
+func TestPipelineORM(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sw, err := maintenance.NewSwitch(store.DB)
+	require.NoError(t, err)
+	o := new(mocks.ORM)
+	orm := maintenance.NewPipelineORM(o, sw)
+
+	o.On("DeleteRunsOlderThan", time.Hour).Return(nil).Once()
+	require.NoError(t, orm.DeleteRunsOlderThan(time.Hour))
+
+	_, err = sw.Enable(ctx, false, "")
+	require.NoError(t, err)
+
+	run, err := orm.ProcessNextUnfinishedRun(ctx, nil)
+	require.NoError(t, err)
+	assert.Nil(t, run)
+	require.NoError(t, orm.DeleteRunsOlderThan(time.Hour))
+
+	o.AssertExpectations(t)
+}
+
+func TestJobORM(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sw, err := maintenance.NewSwitch(store.DB)
+	require.NoError(t, err)
+	o := new(jobmocks.ORM)
+	orm := maintenance.NewJobORM(o, sw)
+
+	before := time.Now()
+	o.On("PurgeArchivedJobs", ctx, before).Return(2, nil).Once()
+	purged, err := orm.PurgeArchivedJobs(ctx, before)
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	_, err = sw.Enable(ctx, false, "")
+	require.NoError(t, err)
+
+	purged, err = orm.PurgeArchivedJobs(ctx, before)
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	o.AssertExpectations(t)
+}
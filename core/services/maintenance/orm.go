@@ -0,0 +1,54 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+type pipelineORM struct {
+	pipeline.ORM
+	sw *Switch
+}
+
+// NewPipelineORM wraps the pipeline ORM of the runner, so that its background
+// workers leave unfinished runs unfinished, and don't reap old runs, while the
+// node is in maintenance mode
+func NewPipelineORM(orm pipeline.ORM, sw *Switch) pipeline.ORM {
+	return &pipelineORM{ORM: orm, sw: sw}
+}
+
+func (o *pipelineORM) ProcessNextUnfinishedRun(ctx context.Context, fn pipeline.ProcessRunFunc) (*pipeline.Run, error) {
+	if o.sw.RunsPaused() {
+		return nil, nil
+	}
+	return o.ORM.ProcessNextUnfinishedRun(ctx, fn)
+}
+
+func (o *pipelineORM) DeleteRunsOlderThan(threshold time.Duration) error {
+	if o.sw.RunsPaused() {
+		return nil
+	}
+	return o.ORM.DeleteRunsOlderThan(threshold)
+}
+
+type jobORM struct {
+	job.ORM
+	sw *Switch
+}
+
+// NewJobORM wraps the job ORM of the spawner, so that archived jobs aren't
+// purged while the node is in maintenance mode. They are purged on the first
+// pass after it is resumed.
+func NewJobORM(orm job.ORM, sw *Switch) job.ORM {
+	return &jobORM{ORM: orm, sw: sw}
+}
+
+func (o *jobORM) PurgeArchivedJobs(ctx context.Context, archivedBefore time.Time) (int, error) {
+	if o.sw.RunsPaused() {
+		return 0, nil
+	}
+	return o.ORM.PurgeArchivedJobs(ctx, archivedBefore)
+}
@@ -0,0 +1,49 @@
+package maintenance
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+type runner struct {
+	pipeline.Runner
+	sw *Switch
+}
+
+// NewRunner wraps a pipeline runner so that no runs are created or saved
+// while the node is in maintenance mode. ExecuteRun is let through, since it
+// saves nothing; OCR jobs check ObservationsPaused themselves before
+// observing.
+func NewRunner(r pipeline.Runner, sw *Switch) pipeline.Runner {
+	return &runner{Runner: r, sw: sw}
+}
+
+func (r *runner) ExecuteAndInsertNewRun(ctx context.Context, spec pipeline.Spec, meta pipeline.JSONSerializable, l logger.Logger) (int64, pipeline.FinalResult, error) {
+	if r.sw.RunsPaused() {
+		return 0, pipeline.FinalResult{}, ErrMaintenanceMode
+	}
+	return r.Runner.ExecuteAndInsertNewRun(ctx, spec, meta, l)
+}
+
+func (r *runner) InsertFinishedRunWithResults(ctx context.Context, run pipeline.Run, trrs pipeline.TaskRunResults) (int64, error) {
+	if r.sw.RunsPaused() {
+		return 0, ErrMaintenanceMode
+	}
+	return r.Runner.InsertFinishedRunWithResults(ctx, run, trrs)
+}
+
+func (r *runner) CreateRun(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error) {
+	if r.sw.RunsPaused() {
+		return 0, ErrMaintenanceMode
+	}
+	return r.Runner.CreateRun(ctx, jobID, meta)
+}
+
+func (r *runner) CreateRuns(ctx context.Context, requests []pipeline.RunRequest) ([]int64, error) {
+	if r.sw.RunsPaused() {
+		return nil, ErrMaintenanceMode
+	}
+	return r.Runner.CreateRuns(ctx, requests)
+}
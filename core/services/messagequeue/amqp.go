@@ -0,0 +1,126 @@
+package messagequeue
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/streadway/amqp"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// amqpReconnectDelay is how long to wait before reconnecting to the broker
+// after the connection was lost
+const amqpReconnectDelay = 5 * time.Second
+
+// amqpConsumer consumes an AMQP queue with manual acknowledgements. Messages
+// that are unacknowledged when the connection is lost are requeued by the
+// broker.
+type amqpConsumer struct {
+	url      string
+	queue    string
+	messages chan Message
+	chStop   chan struct{}
+	wgDone   sync.WaitGroup
+
+	connMu sync.Mutex
+	conn   *amqp.Connection
+}
+
+func newAMQPConsumer(url, queue string) *amqpConsumer {
+	return &amqpConsumer{
+		url:      url,
+		queue:    queue,
+		messages: make(chan Message),
+		chStop:   make(chan struct{}),
+	}
+}
+
+func (c *amqpConsumer) Start() error {
+	deliveries, err := c.connect()
+	if err != nil {
+		return err
+	}
+	c.wgDone.Add(1)
+	go c.consume(deliveries)
+	return nil
+}
+
+func (c *amqpConsumer) connect() (<-chan amqp.Delivery, error) {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to AMQP broker")
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		logger.ErrorIfCalling(conn.Close)
+		return nil, errors.Wrap(err, "could not open AMQP channel")
+	}
+	// Only one message is handled at a time, so there's no use in the broker
+	// sending more before it is acknowledged
+	if err = ch.Qos(1, 0, false); err != nil {
+		logger.ErrorIfCalling(conn.Close)
+		return nil, errors.Wrap(err, "could not set AMQP prefetch count")
+	}
+	deliveries, err := ch.Consume(c.queue, "", false, false, false, false, nil)
+	if err != nil {
+		logger.ErrorIfCalling(conn.Close)
+		return nil, errors.Wrapf(err, "could not consume AMQP queue %s", c.queue)
+	}
+
+	c.connMu.Lock()
+	c.conn = conn
+	c.connMu.Unlock()
+	return deliveries, nil
+}
+
+func (c *amqpConsumer) consume(deliveries <-chan amqp.Delivery) {
+	defer c.wgDone.Done()
+	for {
+		for d := range deliveries {
+			d := d
+			message := Message{
+				ID:   d.MessageId,
+				Body: d.Body,
+				ack:  func() error { return d.Ack(false) },
+			}
+			select {
+			case c.messages <- message:
+			case <-c.chStop:
+				return
+			}
+		}
+
+		// The deliveries channel is closed when the connection is lost, so
+		// keep trying to reconnect until stopped
+		for {
+			select {
+			case <-c.chStop:
+				return
+			case <-time.After(amqpReconnectDelay):
+			}
+			var err error
+			if deliveries, err = c.connect(); err == nil {
+				break
+			}
+			logger.Errorw("Could not reconnect to AMQP broker", "queue", c.queue, "error", err)
+		}
+	}
+}
+
+func (c *amqpConsumer) Close() error {
+	close(c.chStop)
+	c.connMu.Lock()
+	err := c.conn.Close()
+	c.connMu.Unlock()
+	c.wgDone.Wait()
+	if errors.Is(err, amqp.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func (c *amqpConsumer) Messages() <-chan Message {
+	return c.messages
+}
@@ -0,0 +1,53 @@
+package messagequeue
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Message is a message consumed from a Kafka topic or an AMQP queue
+type Message struct {
+	// ID identifies the message, and is the same each time it is delivered.
+	// Kafka messages are identified by their topic, partition and offset, and
+	// AMQP messages by their message-id property, which is empty unless the
+	// publisher set it.
+	ID   string
+	Body []byte
+
+	ack func() error
+}
+
+// Ack acknowledges the message, so that it won't be delivered again
+func (m Message) Ack() error {
+	return m.ack()
+}
+
+// Consumer consumes the messages of a Kafka topic or an AMQP queue. Messages
+// are delivered at least once: those that are not acknowledged, such as when
+// the node stops between consuming a message and acknowledging it, are
+// delivered again.
+type Consumer interface {
+	Start() error
+	Close() error
+	Messages() <-chan Message
+}
+
+// NewConsumer returns the consumer for the queue at rawURL, which is either
+// kafka://broker1:9092,broker2:9092 or an amqp:// or amqps:// URL. Kafka
+// consumers join consumerGroup.
+func NewConsumer(rawURL, topic, consumerGroup string) (Consumer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "kafka":
+		return newKafkaConsumer(strings.Split(u.Host, ","), topic, consumerGroup)
+	case "amqp", "amqps":
+		return newAMQPConsumer(rawURL, topic), nil
+	default:
+		return nil, errors.Errorf("unsupported message queue scheme %q, must be kafka, amqp or amqps", u.Scheme)
+	}
+}
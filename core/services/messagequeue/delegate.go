@@ -0,0 +1,162 @@
+package messagequeue
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// runRetryDelay is how long to wait before retrying a message whose run could
+// not be saved
+const runRetryDelay = 5 * time.Second
+
+type Delegate struct {
+	pipelineRunner pipeline.Runner
+	newConsumer    func(url, topic, consumerGroup string) (Consumer, error)
+}
+
+func NewDelegate(pipelineRunner pipeline.Runner) *Delegate {
+	return &Delegate{pipelineRunner, NewConsumer}
+}
+
+func (d *Delegate) JobType() job.Type {
+	return job.MessageQueue
+}
+
+// Dependencies implements the job.DelegateWithDependencies interface
+func (d *Delegate) Dependencies() []string {
+	return []string{job.DependencyPipelineRunner}
+}
+
+// ServicesForSpec returns the trigger that runs the job for each consumed
+// message
+func (d *Delegate) ServicesForSpec(spec job.Job) ([]job.Service, error) {
+	if spec.MessageQueueSpec == nil {
+		return nil, errors.Errorf("messagequeue.Delegate expects a *job.MessageQueueSpec to be present, got %v", spec)
+	}
+	mqSpec := spec.MessageQueueSpec
+
+	consumerGroup := mqSpec.ConsumerGroup
+	if consumerGroup == "" {
+		consumerGroup = fmt.Sprintf("chainlink-job-%d", spec.ID)
+	}
+	consumer, err := d.newConsumer(mqSpec.URL, mqSpec.Topic, consumerGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	spec.PipelineSpec.JobID = spec.ID
+	spec.PipelineSpec.JobName = spec.Name.ValueOrZero()
+	return []job.Service{&trigger{
+		consumer:       consumer,
+		pipelineRunner: d.pipelineRunner,
+		spec:           *spec.PipelineSpec,
+		topic:          mqSpec.Topic,
+		logger:         *logger.CreateLogger(logger.Default.With("jobID", spec.ID, "topic", mqSpec.Topic)),
+		chStop:         make(chan struct{}),
+	}}, nil
+}
+
+var _ job.Service = (*trigger)(nil)
+
+// trigger runs the job once for each message consumed, then acknowledges the
+// message. Messages are handled one at a time, in the order they arrive.
+//
+// A message is acknowledged only once its run is saved, so a message is never
+// lost, but it may start more than one run if the node stops between saving
+// the run and acknowledging the message. The message's ID is included in the
+// run's meta so that these runs can be told apart.
+type trigger struct {
+	consumer       Consumer
+	pipelineRunner pipeline.Runner
+	spec           pipeline.Spec
+	topic          string
+	logger         logger.Logger
+	chStop         chan struct{}
+	wgDone         sync.WaitGroup
+	utils.StartStopOnce
+}
+
+func (t *trigger) Start() error {
+	return t.StartOnce("MessageQueueTrigger", func() error {
+		if err := t.consumer.Start(); err != nil {
+			return err
+		}
+		t.wgDone.Add(1)
+		go t.run()
+		return nil
+	})
+}
+
+func (t *trigger) Close() error {
+	return t.StopOnce("MessageQueueTrigger", func() error {
+		close(t.chStop)
+		t.wgDone.Wait()
+		return t.consumer.Close()
+	})
+}
+
+func (t *trigger) run() {
+	defer t.wgDone.Done()
+	for {
+		select {
+		case <-t.chStop:
+			return
+		case msg := <-t.consumer.Messages():
+			t.handle(msg)
+		}
+	}
+}
+
+// handle runs the job for the message, retrying until the run is saved or the
+// trigger is stopped. Errors from the pipeline's tasks are saved with the run,
+// so only failures to save the run itself are retried.
+func (t *trigger) handle(msg Message) {
+	meta := pipeline.JSONSerializable{Val: map[string]interface{}{
+		"messageQueue": map[string]interface{}{
+			"id":    msg.ID,
+			"topic": t.topic,
+			"body":  messageBody(msg.Body),
+		},
+	}}
+
+	ctx, cancel := utils.ContextFromChan(t.chStop)
+	defer cancel()
+	for {
+		runID, _, err := t.pipelineRunner.ExecuteAndInsertNewRun(ctx, t.spec, meta, t.logger)
+		if err == nil {
+			t.logger.Debugw("Message queue trigger: started run", "runID", runID, "messageID", msg.ID)
+			break
+		}
+		t.logger.Errorw("Message queue trigger: could not save run, retrying", "messageID", msg.ID, "error", err)
+		select {
+		case <-t.chStop:
+			// The message is left unacknowledged, so it will be delivered
+			// again
+			return
+		case <-time.After(runRetryDelay):
+		}
+	}
+
+	if err := msg.Ack(); err != nil {
+		t.logger.Errorw("Message queue trigger: could not acknowledge message", "messageID", msg.ID, "error", err)
+	}
+}
+
+// messageBody decodes a JSON message body, so that pipelines can refer to its
+// fields. Bodies that aren't JSON are passed on as strings.
+func messageBody(body []byte) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return string(body)
+	}
+	return decoded
+}
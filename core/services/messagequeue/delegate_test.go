@@ -0,0 +1,79 @@
+package messagequeue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	null "gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+type fakeConsumer struct {
+	messages chan Message
+	started  bool
+	closed   bool
+}
+
+func (c *fakeConsumer) Start() error             { c.started = true; return nil }
+func (c *fakeConsumer) Close() error             { c.closed = true; return nil }
+func (c *fakeConsumer) Messages() <-chan Message { return c.messages }
+
+func TestDelegate_ServicesForSpec(t *testing.T) {
+	runner := new(mocks.Runner)
+	consumer := &fakeConsumer{messages: make(chan Message)}
+	var consumerGroup string
+	delegate := &Delegate{runner, func(url, topic, group string) (Consumer, error) {
+		consumerGroup = group
+		return consumer, nil
+	}}
+
+	services, err := delegate.ServicesForSpec(job.Job{
+		IDEmbed:          job.IDEmbed{ID: 42},
+		Name:             null.StringFrom("prices"),
+		PipelineSpec:     &pipeline.Spec{},
+		MessageQueueSpec: &job.MessageQueueSpec{URL: "kafka://broker1:9092", Topic: "prices"},
+	})
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "chainlink-job-42", consumerGroup)
+
+	acked := make(chan struct{})
+	runner.On("ExecuteAndInsertNewRun", mock.Anything, mock.MatchedBy(func(spec pipeline.Spec) bool {
+		return spec.JobID == 42 && spec.JobName == "prices"
+	}), pipeline.JSONSerializable{Val: map[string]interface{}{
+		"messageQueue": map[string]interface{}{
+			"id":    "prices/0/7",
+			"topic": "prices",
+			"body":  map[string]interface{}{"symbol": "ETH"},
+		},
+	}}, mock.Anything).Return(int64(1), pipeline.FinalResult{}, nil).Once()
+
+	require.NoError(t, services[0].Start())
+	assert.True(t, consumer.started)
+
+	consumer.messages <- Message{
+		ID:   "prices/0/7",
+		Body: []byte(`{"symbol": "ETH"}`),
+		ack:  func() error { close(acked); return nil },
+	}
+	select {
+	case <-acked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not acknowledged")
+	}
+
+	require.NoError(t, services[0].Close())
+	assert.True(t, consumer.closed)
+	runner.AssertExpectations(t)
+}
+
+func TestMessageBody(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, messageBody([]byte(`{"a": 1}`)))
+	assert.Equal(t, "not json", messageBody([]byte("not json")))
+}
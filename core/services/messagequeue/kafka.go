@@ -0,0 +1,121 @@
+package messagequeue
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// kafkaRejoinDelay is how long to wait before rejoining the consumer group
+// after it failed
+const kafkaRejoinDelay = 5 * time.Second
+
+var _ sarama.ConsumerGroupHandler = (*kafkaConsumer)(nil)
+
+// kafkaConsumer consumes a Kafka topic as a member of a consumer group.
+// Offsets are marked only once a message is acknowledged, and committed
+// periodically, so a message is only skipped after it has been handled.
+type kafkaConsumer struct {
+	group    sarama.ConsumerGroup
+	topic    string
+	messages chan Message
+	chStop   chan struct{}
+	wgDone   sync.WaitGroup
+}
+
+func newKafkaConsumer(brokers []string, topic, consumerGroup string) (*kafkaConsumer, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Return.Errors = true
+
+	group, err := sarama.NewConsumerGroup(brokers, consumerGroup, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create Kafka consumer group")
+	}
+	return &kafkaConsumer{
+		group:    group,
+		topic:    topic,
+		messages: make(chan Message),
+		chStop:   make(chan struct{}),
+	}, nil
+}
+
+func (c *kafkaConsumer) Start() error {
+	c.wgDone.Add(2)
+	go c.consume()
+	go func() {
+		defer c.wgDone.Done()
+		for err := range c.group.Errors() {
+			logger.Errorw("Kafka consumer error", "topic", c.topic, "error", err)
+		}
+	}()
+	return nil
+}
+
+func (c *kafkaConsumer) consume() {
+	defer c.wgDone.Done()
+	ctx, cancel := utils.ContextFromChan(c.chStop)
+	defer cancel()
+	// Consume returns whenever the group rebalances, so it is called in a
+	// loop to rejoin the group
+	for {
+		if err := c.group.Consume(ctx, []string{c.topic}, c); err != nil {
+			logger.Errorw("Kafka consumer group failed, rejoining", "topic", c.topic, "error", err)
+			select {
+			case <-c.chStop:
+				return
+			case <-time.After(kafkaRejoinDelay):
+			}
+		}
+		select {
+		case <-c.chStop:
+			return
+		default:
+		}
+	}
+}
+
+func (c *kafkaConsumer) Close() error {
+	close(c.chStop)
+	err := c.group.Close()
+	c.wgDone.Wait()
+	return err
+}
+
+func (c *kafkaConsumer) Messages() <-chan Message {
+	return c.messages
+}
+
+// Setup implements sarama.ConsumerGroupHandler
+func (c *kafkaConsumer) Setup(sarama.ConsumerGroupSession) error { return nil }
+
+// Cleanup implements sarama.ConsumerGroupHandler
+func (c *kafkaConsumer) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+// ConsumeClaim implements sarama.ConsumerGroupHandler
+func (c *kafkaConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		msg := msg
+		message := Message{
+			ID:   fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset),
+			Body: msg.Value,
+			ack: func() error {
+				session.MarkMessage(msg, "")
+				return nil
+			},
+		}
+		select {
+		case c.messages <- message:
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package messagequeue
+
+import (
+	"net/url"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+func ValidatedMessageQueueSpec(config *orm.Config, tomlString string) (job.Job, error) {
+	var j = job.Job{
+		Pipeline: *pipeline.NewTaskDAG(),
+	}
+	var spec job.MessageQueueSpec
+	tree, err := toml.Load(tomlString)
+	if err != nil {
+		return j, err
+	}
+	if config.JobSpecStrictTOML() {
+		if err = job.CheckUnknownKeys(tree, job.MessageQueue); err != nil {
+			return j, err
+		}
+	}
+	err = tree.Unmarshal(&j)
+	if err != nil {
+		return j, err
+	}
+	err = tree.Unmarshal(&spec)
+	if err != nil {
+		return j, err
+	}
+	j.MessageQueueSpec = &spec
+
+	if j.Type != job.MessageQueue {
+		return j, errors.Errorf("unsupported type %s", j.Type)
+	}
+	if j.SchemaVersion != uint32(1) {
+		return j, errors.Errorf("the only supported schema version is currently 1, got %d", j.SchemaVersion)
+	}
+	u, err := url.Parse(spec.URL)
+	if err != nil {
+		return j, errors.Wrap(err, "invalid url")
+	}
+	switch u.Scheme {
+	case "kafka":
+		if u.Host == "" {
+			return j, errors.New("kafka urls must list at least one broker, such as kafka://broker1:9092,broker2:9092")
+		}
+	case "amqp", "amqps":
+		if spec.ConsumerGroup != "" {
+			return j, errors.New("consumerGroup is only supported for kafka topics")
+		}
+	default:
+		return j, errors.Errorf("unsupported url scheme %q, must be kafka, amqp or amqps", u.Scheme)
+	}
+	if spec.Topic == "" {
+		return j, errors.New("topic must name the kafka topic or amqp queue to consume")
+	}
+	tasks, err := j.Pipeline.TasksInDependencyOrder()
+	if err != nil {
+		return j, err
+	}
+	if len(tasks) == 0 {
+		return j, errors.New("message queue jobs must have an observationSource")
+	}
+	return j, nil
+}
@@ -0,0 +1,165 @@
+package notifier
+
+import (
+	"io/ioutil"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+)
+
+// Config is read from the TOML file at NOTIFIER_CONFIG_FILE, e.g.
+//
+//	jobErrorThreshold = 3
+//	lowBalanceThreshold = "0.5"
+//	feedStallTimeout = "1h"
+//
+//	[[routes]]
+//	name = "ops"
+//	type = "slack"
+//	url = "https://hooks.slack.com/services/..."
+//	kinds = ["job_errored", "feed_stalled"]
+//
+//	[[routes]]
+//	name = "oncall"
+//	type = "pagerduty"
+//	routingKey = "..."
+//	kinds = ["low_balance", "tx_failed"]
+type Config struct {
+	// JobErrorThreshold is how many runs of a job in a row must error before
+	// a job_errored notification is sent. It defaults to 1.
+	JobErrorThreshold uint32 `toml:"jobErrorThreshold"`
+	// LowBalanceThreshold is the ETH balance of a key below which a
+	// low_balance notification is sent. Empty disables these notifications.
+	LowBalanceThreshold string `toml:"lowBalanceThreshold"`
+	// FeedStallTimeout is how long a job that has run since the node
+	// started may go without finishing a run before a feed_stalled
+	// notification is sent. Empty disables these notifications.
+	FeedStallTimeout string  `toml:"feedStallTimeout"`
+	Routes           []Route `toml:"routes"`
+
+	lowBalanceThreshold *assets.Eth   `toml:"-"`
+	feedStallTimeout    time.Duration `toml:"-"`
+}
+
+// Route sends the notifications of the given kinds to a sink
+type Route struct {
+	Name string `toml:"name"`
+	// Type is slack, pagerduty or webhook
+	Type SinkType `toml:"type"`
+	// URL is the Slack or generic webhook URL. PagerDuty routes default to
+	// the PagerDuty Events API v2.
+	URL string `toml:"url"`
+	// RoutingKey is the integration key of PagerDuty routes
+	RoutingKey string `toml:"routingKey"`
+	// Kinds are the kinds of notifications sent, or all of them if empty
+	Kinds []Kind `toml:"kinds"`
+	// Template, if set, is a text/template of the request body, executed
+	// with the Notification. The json function marshals its argument.
+	Template string            `toml:"template"`
+	Headers  map[string]string `toml:"headers"`
+
+	template *template.Template `toml:"-"`
+}
+
+// LoadConfig reads and validates the config file at path
+func LoadConfig(path string) (Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "could not read notifier config")
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates a TOML notifier config
+func ParseConfig(data []byte) (Config, error) {
+	var config Config
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return Config{}, errors.Wrap(err, "could not parse notifier config")
+	}
+
+	if config.JobErrorThreshold == 0 {
+		config.JobErrorThreshold = 1
+	}
+	if config.LowBalanceThreshold != "" {
+		threshold, err := assets.NewEthValueS(config.LowBalanceThreshold)
+		if err != nil {
+			return Config{}, errors.Wrapf(err, "invalid lowBalanceThreshold %q", config.LowBalanceThreshold)
+		}
+		config.lowBalanceThreshold = &threshold
+	}
+	if config.FeedStallTimeout != "" {
+		timeout, err := time.ParseDuration(config.FeedStallTimeout)
+		if err != nil || timeout < 0 {
+			return Config{}, errors.Errorf("invalid feedStallTimeout %q", config.FeedStallTimeout)
+		}
+		config.feedStallTimeout = timeout
+	}
+
+	names := make(map[string]bool)
+	for i := range config.Routes {
+		route := &config.Routes[i]
+		if route.Name == "" {
+			return Config{}, errors.Errorf("route %d has no name", i)
+		} else if names[route.Name] {
+			return Config{}, errors.Errorf("there is more than one route named %s", route.Name)
+		}
+		names[route.Name] = true
+		if err := route.validate(); err != nil {
+			return Config{}, errors.Wrapf(err, "invalid route %s", route.Name)
+		}
+	}
+	return config, nil
+}
+
+func (r *Route) validate() error {
+	switch r.Type {
+	case SinkTypeSlack, SinkTypeWebhook:
+		if r.URL == "" {
+			return errors.Errorf("%s routes need a url", r.Type)
+		}
+	case SinkTypePagerDuty:
+		if r.RoutingKey == "" {
+			return errors.New("pagerduty routes need a routingKey")
+		}
+		if r.URL == "" {
+			r.URL = pagerDutyEventsURL
+		}
+	default:
+		return errors.Errorf("unknown type %q, must be slack, pagerduty or webhook", r.Type)
+	}
+	if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.Errorf("invalid url %q", r.URL)
+	}
+
+	for _, kind := range r.Kinds {
+		if !kind.valid() {
+			return errors.Errorf("unknown notification kind %q", kind)
+		}
+	}
+
+	if r.Template != "" {
+		tmpl, err := template.New(r.Name).Funcs(templateFuncs).Parse(r.Template)
+		if err != nil {
+			return errors.Wrap(err, "invalid template")
+		}
+		r.template = tmpl
+	}
+	return nil
+}
+
+func (r Route) wants(kind Kind) bool {
+	if len(r.Kinds) == 0 {
+		return true
+	}
+	for _, k := range r.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
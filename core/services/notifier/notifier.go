@@ -0,0 +1,277 @@
+// Package notifier sends notifications of trouble on the node, derived from
+// the events on the event bus, to Slack, PagerDuty or generic webhooks. The
+// routes and thresholds are read from the TOML file at NOTIFIER_CONFIG_FILE,
+// see Config.
+package notifier
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// Kind is the kind of a notification
+type Kind string
+
+const (
+	// KindJobErrored is sent when JobErrorThreshold runs of a job in a row
+	// have errored
+	KindJobErrored Kind = "job_errored"
+	// KindLowBalance is sent when the ETH balance of a key drops below
+	// LowBalanceThreshold
+	KindLowBalance Kind = "low_balance"
+	// KindFeedStalled is sent when a job goes FeedStallTimeout without
+	// finishing a run
+	KindFeedStalled Kind = "feed_stalled"
+	// KindTxFailed is sent when a transaction could not be sent, or reverted
+	KindTxFailed Kind = "tx_failed"
+	// KindExternalTx is sent when a transaction from a sending key that the
+	// node didn't send is mined
+	KindExternalTx Kind = "external_tx"
+	// KindRPCQuota is sent when the eth RPC calls made this month are
+	// projected to exceed the provider plan's monthly quota
+	KindRPCQuota Kind = "rpc_quota"
+)
+
+func (k Kind) valid() bool {
+	switch k {
+	case KindJobErrored, KindLowBalance, KindFeedStalled, KindTxFailed, KindExternalTx, KindRPCQuota:
+		return true
+	}
+	return false
+}
+
+const sendTimeout = 10 * time.Second
+
+// Notification is sent over the routes for its kind
+type Notification struct {
+	Kind    Kind   `json:"kind"`
+	Summary string `json:"summary"`
+	// DedupKey identifies what the notification is about, e.g. the job, so
+	// that PagerDuty groups repeated notifications into one incident
+	DedupKey string `json:"dedupKey"`
+	// Source is the host name of the node
+	Source string         `json:"source"`
+	Time   time.Time      `json:"time"`
+	Event  eventbus.Event `json:"event"`
+}
+
+// Notifier sends notifications derived from the events on the bus
+type Notifier struct {
+	utils.StartStopOnce
+	bus    *eventbus.Bus
+	config Config
+	client *http.Client
+	source string
+
+	sub    *eventbus.Subscription
+	chStop chan struct{}
+	wg     sync.WaitGroup
+
+	// The state below is only used by the run goroutine
+	jobErrors  map[string]uint32
+	lowBalance map[common.Address]bool
+	lastRun    map[string]time.Time
+	stalled    map[string]bool
+}
+
+// New returns a Notifier for the events on bus
+func New(bus *eventbus.Bus, config Config) *Notifier {
+	source, err := os.Hostname()
+	if err != nil {
+		source = "chainlink"
+	}
+	return &Notifier{
+		bus:        bus,
+		config:     config,
+		client:     utils.UnrestrictedClient,
+		source:     source,
+		chStop:     make(chan struct{}),
+		jobErrors:  make(map[string]uint32),
+		lowBalance: make(map[common.Address]bool),
+		lastRun:    make(map[string]time.Time),
+		stalled:    make(map[string]bool),
+	}
+}
+
+func (n *Notifier) Start() error {
+	return n.StartOnce("Notifier", func() error {
+		n.sub = n.bus.Subscribe(
+			eventbus.TopicRunFinished,
+			eventbus.TopicJobDeleted,
+			eventbus.TopicBalanceChanged,
+			eventbus.TopicTxFailed,
+			eventbus.TopicExternalTx,
+			eventbus.TopicRPCQuota,
+		)
+		n.wg.Add(1)
+		go n.run()
+		logger.Infow("Notifier: started", "routes", len(n.config.Routes))
+		return nil
+	})
+}
+
+func (n *Notifier) Close() error {
+	if !n.OkayToStop() {
+		return errors.New("Notifier has already been stopped")
+	}
+	close(n.chStop)
+	n.sub.Close()
+	n.wg.Wait()
+	return nil
+}
+
+func (n *Notifier) run() {
+	defer n.wg.Done()
+
+	var chStallCheck <-chan time.Time
+	if n.config.feedStallTimeout > 0 {
+		ticker := time.NewTicker(stallCheckInterval(n.config.feedStallTimeout))
+		defer ticker.Stop()
+		chStallCheck = ticker.C
+	}
+
+	for {
+		select {
+		case event, ok := <-n.sub.Events():
+			if !ok {
+				return
+			}
+			for _, notification := range n.handle(event, time.Now()) {
+				n.dispatch(notification)
+			}
+		case now := <-chStallCheck:
+			for _, notification := range n.checkStalls(now) {
+				n.dispatch(notification)
+			}
+		case <-n.chStop:
+			return
+		}
+	}
+}
+
+// stallCheckInterval checks for stalled feeds often enough to notify within
+// a quarter of the timeout, but no more than once a second
+func stallCheckInterval(timeout time.Duration) time.Duration {
+	interval := timeout / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// handle returns the notifications that event calls for
+func (n *Notifier) handle(event eventbus.Event, now time.Time) []Notification {
+	switch e := event.(type) {
+	case eventbus.RunFinished:
+		if e.JobID == "" {
+			return nil
+		}
+		n.lastRun[e.JobID] = now
+		if n.stalled[e.JobID] {
+			delete(n.stalled, e.JobID)
+			logger.Infow("Notifier: stalled job has finished a run again", "jobID", e.JobID)
+		}
+		if !e.Errored {
+			delete(n.jobErrors, e.JobID)
+			return nil
+		}
+		n.jobErrors[e.JobID]++
+		if count := n.jobErrors[e.JobID]; count == n.config.JobErrorThreshold {
+			summary := fmt.Sprintf("Job %s has errored %d times in a row: %s", e.JobID, count, e.Error)
+			return []Notification{n.notification(KindJobErrored, summary, "job-"+e.JobID, now, e)}
+		}
+
+	case eventbus.JobDeleted:
+		delete(n.jobErrors, e.JobID)
+		delete(n.lastRun, e.JobID)
+		delete(n.stalled, e.JobID)
+
+	case eventbus.BalanceChanged:
+		threshold := n.config.lowBalanceThreshold
+		if threshold == nil || e.Balance == nil {
+			return nil
+		}
+		low := e.Balance.Cmp(threshold) < 0
+		wasLow := n.lowBalance[e.Address]
+		n.lowBalance[e.Address] = low
+		if low && !wasLow {
+			summary := fmt.Sprintf("ETH balance of %s is %s, below %s", e.Address.Hex(), e.Balance.String(), threshold.String())
+			return []Notification{n.notification(KindLowBalance, summary, "balance-"+e.Address.Hex(), now, e)}
+		}
+
+	case eventbus.TxFailed:
+		summary := fmt.Sprintf("Transaction %d from %s failed: %s", e.EthTxID, e.From.Hex(), e.Error)
+		if e.Reverted {
+			summary = fmt.Sprintf("Transaction %s from %s reverted: %s", e.Hash.Hex(), e.From.Hex(), e.Error)
+		}
+		return []Notification{n.notification(KindTxFailed, summary, fmt.Sprintf("tx-%d", e.EthTxID), now, e)}
+
+	case eventbus.ExternalTx:
+		summary := fmt.Sprintf("Transaction %s with nonce %d was sent from %s by something other than this node", e.Hash.Hex(), e.Nonce, e.From.Hex())
+		if e.ReplacedEthTxID != 0 {
+			summary += fmt.Sprintf(", replacing transaction %d", e.ReplacedEthTxID)
+		}
+		return []Notification{n.notification(KindExternalTx, summary, "external-tx-"+e.From.Hex(), now, e)}
+
+	case eventbus.RPCQuotaProjected:
+		summary := fmt.Sprintf("Eth RPC usage is projected to reach %d calls in %s, above the monthly quota of %d (%d calls so far)", e.Projected, e.Month, e.Quota, e.MonthToDate)
+		return []Notification{n.notification(KindRPCQuota, summary, "rpc-quota-"+e.Month, now, e)}
+	}
+	return nil
+}
+
+// checkStalls returns a notification for each job that has newly gone
+// FeedStallTimeout without finishing a run
+func (n *Notifier) checkStalls(now time.Time) []Notification {
+	var notifications []Notification
+	for jobID, lastRun := range n.lastRun {
+		if n.stalled[jobID] || now.Sub(lastRun) < n.config.feedStallTimeout {
+			continue
+		}
+		n.stalled[jobID] = true
+		summary := fmt.Sprintf("Job %s has not finished a run since %s", jobID, lastRun.Format(time.RFC3339))
+		notifications = append(notifications, n.notification(KindFeedStalled, summary, "job-"+jobID, now, nil))
+	}
+	return notifications
+}
+
+func (n *Notifier) notification(kind Kind, summary, dedupKey string, now time.Time, event eventbus.Event) Notification {
+	return Notification{
+		Kind:     kind,
+		Summary:  summary,
+		DedupKey: dedupKey,
+		Source:   n.source,
+		Time:     now,
+		Event:    event,
+	}
+}
+
+// dispatch sends notification over the routes for its kind, without
+// waiting for them
+func (n *Notifier) dispatch(notification Notification) {
+	logger.Warnw("Notifier: "+notification.Summary, "kind", notification.Kind)
+	for _, route := range n.config.Routes {
+		if !route.wants(notification.Kind) {
+			continue
+		}
+		n.wg.Add(1)
+		go func(route Route) {
+			defer n.wg.Done()
+			ctx, cancel := utils.CombinedContext(n.chStop, sendTimeout)
+			defer cancel()
+			if err := route.send(ctx, n.client, notification); err != nil {
+				logger.Errorw("Notifier: could not send notification", "route", route.Name, "kind", notification.Kind, "err", err)
+			}
+		}(route)
+	}
+}
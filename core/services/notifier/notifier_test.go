@@ -0,0 +1,215 @@
+package notifier_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/services/notifier"
+)
+
+type request struct {
+	header http.Header
+	body   map[string]interface{}
+}
+
+func newSink(t *testing.T) (*httptest.Server, chan request) {
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &body))
+		requests <- request{r.Header, body}
+	}))
+	return server, requests
+}
+
+func receive(t *testing.T, requests chan request) request {
+	select {
+	case r := <-requests:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+		return request{}
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	t.Parallel()
+
+	webhook, webhookRequests := newSink(t)
+	defer webhook.Close()
+	slack, slackRequests := newSink(t)
+	defer slack.Close()
+	pagerDuty, pagerDutyRequests := newSink(t)
+	defer pagerDuty.Close()
+
+	config, err := notifier.ParseConfig([]byte(fmt.Sprintf(`
+jobErrorThreshold = 2
+lowBalanceThreshold = "1"
+feedStallTimeout = "1s"
+
+[[routes]]
+name = "all"
+type = "webhook"
+url = "%s"
+[routes.headers]
+Authorization = "Bearer secret"
+
+[[routes]]
+name = "slack"
+type = "slack"
+url = "%s"
+kinds = ["job_errored"]
+template = '{"text": "{{ .Kind }}: {{ .Summary }}", "event": {{ json .Event }}}'
+
+[[routes]]
+name = "oncall"
+type = "pagerduty"
+url = "%s"
+routingKey = "key"
+kinds = ["low_balance"]
+`, webhook.URL, slack.URL, pagerDuty.URL)))
+	require.NoError(t, err)
+
+	bus := eventbus.NewBus()
+	n := notifier.New(bus, config)
+	require.NoError(t, n.Start())
+	defer n.Close()
+
+	t.Run("job errored", func(t *testing.T) {
+		bus.Publish(eventbus.RunFinished{JobID: "1", RunID: "1", Errored: true, Error: "boom"})
+		bus.Publish(eventbus.RunFinished{JobID: "1", RunID: "2", Errored: true, Error: "boom"})
+
+		r := receive(t, webhookRequests)
+		assert.Equal(t, "Bearer secret", r.header.Get("Authorization"))
+		assert.Equal(t, "job_errored", r.body["kind"])
+		assert.Equal(t, "job-1", r.body["dedupKey"])
+		assert.Contains(t, r.body["summary"], "errored 2 times in a row: boom")
+
+		r = receive(t, slackRequests)
+		assert.Contains(t, r.body["text"], "job_errored: Job 1 has errored 2 times")
+		assert.Equal(t, "2", r.body["event"].(map[string]interface{})["runID"])
+	})
+
+	t.Run("low balance", func(t *testing.T) {
+		address := common.HexToAddress("0x1")
+		balance, err := assets.NewEthValueS("0.5")
+		require.NoError(t, err)
+		bus.Publish(eventbus.BalanceChanged{Address: address, Balance: &balance})
+
+		r := receive(t, pagerDutyRequests)
+		assert.Equal(t, "key", r.body["routing_key"])
+		assert.Equal(t, "trigger", r.body["event_action"])
+		assert.Equal(t, "balance-"+address.Hex(), r.body["dedup_key"])
+		assert.Contains(t, r.body["payload"].(map[string]interface{})["summary"], "below 1")
+
+		r = receive(t, webhookRequests)
+		assert.Equal(t, "low_balance", r.body["kind"])
+	})
+
+	t.Run("tx failed", func(t *testing.T) {
+		bus.Publish(eventbus.TxFailed{EthTxID: 3, Error: "insufficient funds"})
+
+		r := receive(t, webhookRequests)
+		assert.Equal(t, "tx_failed", r.body["kind"])
+		assert.Equal(t, "tx-3", r.body["dedupKey"])
+	})
+
+	t.Run("external tx", func(t *testing.T) {
+		from := common.HexToAddress("0x2")
+		bus.Publish(eventbus.ExternalTx{From: from, Nonce: 4, ReplacedEthTxID: 5})
+
+		r := receive(t, webhookRequests)
+		assert.Equal(t, "external_tx", r.body["kind"])
+		assert.Equal(t, "external-tx-"+from.Hex(), r.body["dedupKey"])
+		assert.Contains(t, r.body["summary"], "with nonce 4")
+		assert.Contains(t, r.body["summary"], "replacing transaction 5")
+	})
+
+	t.Run("rpc quota", func(t *testing.T) {
+		bus.Publish(eventbus.RPCQuotaProjected{Month: "2021-04", MonthToDate: 400, Projected: 1200, Quota: 1000})
+
+		r := receive(t, webhookRequests)
+		assert.Equal(t, "rpc_quota", r.body["kind"])
+		assert.Equal(t, "rpc-quota-2021-04", r.body["dedupKey"])
+		assert.Contains(t, r.body["summary"], "projected to reach 1200 calls in 2021-04")
+	})
+
+	t.Run("feed stalled", func(t *testing.T) {
+		r := receive(t, webhookRequests)
+		assert.Equal(t, "feed_stalled", r.body["kind"])
+		assert.Equal(t, "job-1", r.body["dedupKey"])
+	})
+
+	assert.Len(t, slackRequests, 0)
+	assert.Len(t, pagerDutyRequests, 0)
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		toml   string
+		errStr string
+	}{
+		{"unknown type", `[[routes]]
+name = "a"
+type = "email"`, "unknown type"},
+		{"no url", `[[routes]]
+name = "a"
+type = "slack"`, "need a url"},
+		{"no routing key", `[[routes]]
+name = "a"
+type = "pagerduty"`, "need a routingKey"},
+		{"unknown kind", `[[routes]]
+name = "a"
+type = "webhook"
+url = "https://example.com"
+kinds = ["job_created"]`, "unknown notification kind"},
+		{"bad template", `[[routes]]
+name = "a"
+type = "webhook"
+url = "https://example.com"
+template = "{{ .Kind"`, "invalid template"},
+		{"duplicate names", `[[routes]]
+name = "a"
+type = "webhook"
+url = "https://example.com"
+[[routes]]
+name = "a"
+type = "webhook"
+url = "https://example.com"`, "more than one route named a"},
+		{"bad threshold", `lowBalanceThreshold = "lots"`, "invalid lowBalanceThreshold"},
+		{"bad timeout", `feedStallTimeout = "soon"`, "invalid feedStallTimeout"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := notifier.ParseConfig([]byte(test.toml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.errStr)
+		})
+	}
+
+	config, err := notifier.ParseConfig([]byte(`[[routes]]
+name = "oncall"
+type = "pagerduty"
+routingKey = "key"`))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), config.JobErrorThreshold)
+	assert.Equal(t, "https://events.pagerduty.com/v2/enqueue", config.Routes[0].URL)
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// SinkType is where a route sends notifications to
+type SinkType string
+
+const (
+	SinkTypeSlack     SinkType = "slack"
+	SinkTypePagerDuty SinkType = "pagerduty"
+	SinkTypeWebhook   SinkType = "webhook"
+)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// body returns the request body sending n over route r
+func (r Route) body(n Notification) ([]byte, error) {
+	if r.template != nil {
+		var buf bytes.Buffer
+		if err := r.template.Execute(&buf, n); err != nil {
+			return nil, errors.Wrap(err, "could not execute template")
+		}
+		return buf.Bytes(), nil
+	}
+
+	switch r.Type {
+	case SinkTypeSlack:
+		return json.Marshal(map[string]interface{}{
+			"text": n.Summary,
+		})
+	case SinkTypePagerDuty:
+		return json.Marshal(map[string]interface{}{
+			"routing_key":  r.RoutingKey,
+			"event_action": "trigger",
+			"dedup_key":    n.DedupKey,
+			"payload": map[string]interface{}{
+				"summary":        n.Summary,
+				"source":         n.Source,
+				"severity":       "error",
+				"timestamp":      n.Time,
+				"component":      "chainlink",
+				"class":          n.Kind,
+				"custom_details": n.Event,
+			},
+		})
+	default:
+		return json.Marshal(n)
+	}
+}
+
+// send posts n to the sink of route r
+func (r Route) send(ctx context.Context, client *http.Client, n Notification) error {
+	body, err := r.body(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range r.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer logger.ErrorIfCalling(resp.Body.Close)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("got status %d", resp.StatusCode)
+	}
+	return nil
+}
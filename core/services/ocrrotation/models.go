@@ -0,0 +1,55 @@
+package ocrrotation
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+)
+
+// State is the progress of a transmitter rotation
+type State string
+
+const (
+	// StateFunding waits for the new transmitter to be funded from the old one
+	StateFunding = State("funding")
+	// StateAwaitingConfig waits for the new transmitter to appear in the
+	// on-chain contract config, which is set by the contract owner
+	StateAwaitingConfig = State("awaiting_config")
+	// StateSwitching updates the job spec and restarts the job
+	StateSwitching = State("switching")
+	// StateDraining waits for pending transactions from the old transmitter
+	// to be confirmed
+	StateDraining = State("draining")
+	// StateArchiving archives the old transmitter key
+	StateArchiving = State("archiving")
+	// StateComplete means that the rotation has finished successfully
+	StateComplete = State("complete")
+	// StateFailed means that the rotation was aborted, see Error
+	StateFailed = State("failed")
+)
+
+// Rotation tracks the rotation of an OCR job's transmitter address
+type Rotation struct {
+	ID             int64
+	JobID          int32
+	OldAddress     common.Address
+	NewAddress     common.Address
+	FundingAmount  assets.Eth
+	FundingEthTxID *int64
+	State          State
+	Error          null.String
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (Rotation) TableName() string {
+	return "ocr_transmitter_rotations"
+}
+
+// Active returns whether the rotation is still in progress
+func (r Rotation) Active() bool {
+	return r.State != StateComplete && r.State != StateFailed
+}
@@ -0,0 +1,402 @@
+package ocrrotation
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+//go:generate mockery --name Rotator --output ./mocks/ --case=underscore
+
+var (
+	// ErrRotationInProgress is returned when a job already has an active
+	// transmitter rotation
+	ErrRotationInProgress = errors.New("a transmitter rotation is already in progress for this job")
+	// ErrNotOCRJob is returned when attempting to rotate the transmitter of a
+	// job that doesn't have one
+	ErrNotOCRJob = errors.New("only non-bootstrap offchainreporting jobs have a transmitter")
+	// ErrNoSuchKey is returned when the new transmitter is not a key of this node
+	ErrNoSuchKey = errors.New("no such ETH key exists")
+)
+
+const pollInterval = 15 * time.Second
+
+type (
+	// Rotator moves an OCR job onto a new transmitter address without
+	// downtime. A rotation funds the new transmitter from the old one, waits
+	// for the contract owner to add the new transmitter to the on-chain
+	// config, switches the job over, waits for the old transmitter's pending
+	// transactions to be confirmed and finally archives the old key.
+	//
+	// Rotations are persisted and resumed after a restart. Every node sharing
+	// the database runs a Rotator, but each rotation is only advanced by the
+	// node holding its advisory lock, and only the node that claims the job
+	// can switch it over.
+	Rotator interface {
+		Start() error
+		Close() error
+		Rotate(ctx context.Context, jobID int32, newAddress common.Address, fundingAmount assets.Eth) (Rotation, error)
+		RotationsForJob(jobID int32) ([]Rotation, error)
+	}
+
+	rotator struct {
+		store   *strpkg.Store
+		jobORM  job.ORM
+		spawner job.Spawner
+
+		utils.StartStopOnce
+		chWake chan struct{}
+		chStop chan struct{}
+		chDone chan struct{}
+	}
+
+	// rotationFailure wraps errors that abort a rotation rather than being
+	// retried
+	rotationFailure struct {
+		error
+	}
+)
+
+var _ Rotator = (*rotator)(nil)
+
+func NewRotator(store *strpkg.Store, jobORM job.ORM, spawner job.Spawner) *rotator {
+	return &rotator{
+		store:   store,
+		jobORM:  jobORM,
+		spawner: spawner,
+		chWake:  make(chan struct{}, 1),
+		chStop:  make(chan struct{}),
+		chDone:  make(chan struct{}),
+	}
+}
+
+func (r *rotator) Start() error {
+	if !r.OkayToStart() {
+		return errors.New("Transmitter rotator has already been started")
+	}
+	go r.runLoop()
+	return nil
+}
+
+func (r *rotator) Close() error {
+	if !r.OkayToStop() {
+		return errors.New("Transmitter rotator has already been stopped")
+	}
+	close(r.chStop)
+	<-r.chDone
+	return nil
+}
+
+// Rotate validates and begins a rotation of the given job's transmitter to
+// newAddress. If fundingAmount is non-zero, it is sent from the old
+// transmitter to the new one first.
+func (r *rotator) Rotate(ctx context.Context, jobID int32, newAddress common.Address, fundingAmount assets.Eth) (Rotation, error) {
+	j, err := r.jobORM.FindJob(jobID)
+	if err != nil {
+		return Rotation{}, errors.Wrap(err, "failed to load job")
+	} else if j.Type != job.OffchainReporting || j.OffchainreportingOracleSpec == nil || j.OffchainreportingOracleSpec.IsBootstrapPeer {
+		return Rotation{}, ErrNotOCRJob
+	}
+
+	oldAddress, err := r.store.Config.OCRTransmitterAddress(j.OffchainreportingOracleSpec.TransmitterAddress)
+	if err != nil {
+		return Rotation{}, errors.Wrap(err, "failed to determine current transmitter address")
+	} else if oldAddress.Address() == newAddress {
+		return Rotation{}, errors.Errorf("job is already using transmitter address %s", newAddress.Hex())
+	}
+	if exists, err := r.store.KeyExists(newAddress); err != nil {
+		return Rotation{}, err
+	} else if !exists {
+		return Rotation{}, errors.Wrap(ErrNoSuchKey, newAddress.Hex())
+	}
+	if fundingAmount.ToInt().Sign() < 0 {
+		return Rotation{}, errors.New("funding amount must not be negative")
+	}
+
+	var active int64
+	err = r.store.DB.Model(&Rotation{}).Where("job_id = ? AND state NOT IN (?)", jobID, []State{StateComplete, StateFailed}).Count(&active).Error
+	if err != nil {
+		return Rotation{}, err
+	} else if active > 0 {
+		return Rotation{}, ErrRotationInProgress
+	}
+
+	rotation := Rotation{
+		JobID:         jobID,
+		OldAddress:    oldAddress.Address(),
+		NewAddress:    newAddress,
+		FundingAmount: fundingAmount,
+		State:         StateFunding,
+	}
+	err = r.store.DB.WithContext(ctx).Create(&rotation).Error
+	var pqErr *pgconn.PgError
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return Rotation{}, ErrRotationInProgress
+	} else if err != nil {
+		return Rotation{}, errors.Wrap(err, "failed to create transmitter rotation")
+	}
+
+	logger.Infow("Started transmitter rotation", "jobID", jobID, "oldAddress", rotation.OldAddress.Hex(), "newAddress", newAddress.Hex())
+	select {
+	case r.chWake <- struct{}{}:
+	default:
+	}
+	return rotation, nil
+}
+
+// RotationsForJob returns all rotations of the given job, most recent first
+func (r *rotator) RotationsForJob(jobID int32) ([]Rotation, error) {
+	var rotations []Rotation
+	err := r.store.DB.Where("job_id = ?", jobID).Order("id DESC").Find(&rotations).Error
+	return rotations, err
+}
+
+func (r *rotator) runLoop() {
+	defer close(r.chDone)
+
+	ticker := time.NewTicker(utils.WithJitter(pollInterval))
+	defer ticker.Stop()
+
+	ctx, cancel := utils.CombinedContext(r.chStop)
+	defer cancel()
+
+	r.processRotations(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			r.processRotations(ctx)
+		case <-r.chWake:
+			r.processRotations(ctx)
+		case <-r.chStop:
+			return
+		}
+	}
+}
+
+func (r *rotator) processRotations(ctx context.Context) {
+	var rotations []Rotation
+	err := r.store.DB.Where("state NOT IN (?)", []State{StateComplete, StateFailed}).Order("id ASC").Find(&rotations).Error
+	if err != nil {
+		logger.Errorw("Failed to load transmitter rotations", "error", err)
+		return
+	}
+	for _, rotation := range rotations {
+		err := r.store.AdvisoryLocker.WithAdvisoryLock(ctx, postgres.AdvisoryLockClassID_TransmitterRotation, int32(rotation.ID), func() error {
+			return r.reloadAndAdvance(ctx, rotation.ID)
+		})
+		if err != nil {
+			logger.Debugw("Not advancing transmitter rotation", "rotationID", rotation.ID, "jobID", rotation.JobID, "error", err)
+		}
+	}
+}
+
+// reloadAndAdvance advances the rotation from its latest saved state, as
+// another node may have advanced it since it was loaded. It must be called
+// while holding the rotation's advisory lock.
+func (r *rotator) reloadAndAdvance(ctx context.Context, rotationID int64) error {
+	var rotation Rotation
+	if err := r.store.DB.First(&rotation, rotationID).Error; err != nil {
+		return errors.Wrap(err, "failed to reload transmitter rotation")
+	}
+	r.advance(ctx, rotation)
+	return nil
+}
+
+// advance steps the rotation through as many states as it can without
+// waiting, recording its progress after each step
+func (r *rotator) advance(ctx context.Context, rotation Rotation) {
+	for rotation.Active() {
+		next, err := r.step(ctx, &rotation)
+		var failure *rotationFailure
+		if errors.As(err, &failure) {
+			logger.Errorw("Transmitter rotation failed", "rotationID", rotation.ID, "jobID", rotation.JobID, "error", err)
+			rotation.Error.SetValid(err.Error())
+			next = StateFailed
+		} else if err != nil {
+			logger.Warnw("Transmitter rotation step failed, will retry", "rotationID", rotation.ID, "jobID", rotation.JobID, "state", rotation.State, "error", err)
+			return
+		}
+
+		if next == rotation.State {
+			if err := r.store.DB.Save(&rotation).Error; err != nil {
+				logger.Errorw("Failed to save transmitter rotation", "rotationID", rotation.ID, "error", err)
+			}
+			return
+		}
+		logger.Infow("Transmitter rotation progressed", "rotationID", rotation.ID, "jobID", rotation.JobID, "from", rotation.State, "to", next)
+		rotation.State = next
+		if err := r.store.DB.Save(&rotation).Error; err != nil {
+			logger.Errorw("Failed to save transmitter rotation", "rotationID", rotation.ID, "error", err)
+			return
+		}
+	}
+}
+
+// step performs the work for the rotation's current state and returns the
+// next state, which is the current state if the rotation has to wait.
+// Errors are retried on the next poll unless they are rotationFailures.
+func (r *rotator) step(ctx context.Context, rotation *Rotation) (State, error) {
+	switch rotation.State {
+	case StateFunding:
+		return r.fund(ctx, rotation)
+	case StateAwaitingConfig:
+		return r.awaitConfig(rotation)
+	case StateSwitching:
+		return r.switchTransmitter(ctx, rotation)
+	case StateDraining:
+		return r.drain(rotation)
+	case StateArchiving:
+		err := r.store.ArchiveKey(rotation.OldAddress)
+		return StateComplete, errors.Wrap(err, "failed to archive old transmitter key")
+	default:
+		return rotation.State, &rotationFailure{errors.Errorf("unknown state %s", rotation.State)}
+	}
+}
+
+// fund sends the funding amount from the old transmitter to the new one and
+// waits for it to be confirmed. The funding transaction is created in the
+// same database transaction that records it on the rotation, so that it is
+// never sent twice.
+func (r *rotator) fund(ctx context.Context, rotation *Rotation) (State, error) {
+	if rotation.FundingAmount.IsZero() {
+		return StateAwaitingConfig, nil
+	}
+
+	if rotation.FundingEthTxID == nil {
+		err := postgres.GormTransaction(ctx, r.store.DB, func(tx *gorm.DB) error {
+			etx, err := bulletprooftxmanager.SendEtherWithDB(tx, r.store.Config.EthGasLimitDefault(), rotation.OldAddress, rotation.NewAddress, rotation.FundingAmount)
+			if err != nil {
+				return &rotationFailure{errors.Wrap(err, "failed to fund new transmitter")}
+			}
+			rotation.FundingEthTxID = &etx.ID
+			return errors.Wrap(tx.Save(rotation).Error, "failed to save funding transaction")
+		})
+		if err != nil {
+			rotation.FundingEthTxID = nil
+		}
+		return rotation.State, err
+	}
+
+	var etx models.EthTx
+	err := r.store.DB.First(&etx, *rotation.FundingEthTxID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return rotation.State, &rotationFailure{errors.New("funding transaction was deleted")}
+	} else if err != nil {
+		return rotation.State, err
+	}
+	switch etx.State {
+	case models.EthTxConfirmed:
+		return StateAwaitingConfig, nil
+	case models.EthTxFatalError:
+		var reason string
+		if etx.Error != nil {
+			reason = *etx.Error
+		}
+		return rotation.State, &rotationFailure{errors.Errorf("funding transaction failed: %s", reason)}
+	default:
+		return rotation.State, nil
+	}
+}
+
+// awaitConfig waits until the latest on-chain config seen by the job
+// includes the new transmitter. Jobs that transmit through a forwarder keep
+// the forwarder as their on-chain transmitter, so there is nothing to wait
+// for; the forwarder must already authorize the new key.
+func (r *rotator) awaitConfig(rotation *Rotation) (State, error) {
+	var inConfig bool
+	err := r.store.DB.Raw(`
+        SELECT EXISTS (
+            SELECT 1 FROM offchainreporting_oracle_specs s
+            INNER JOIN jobs ON jobs.offchainreporting_oracle_spec_id = s.id
+            WHERE jobs.id = ? AND s.forwarder_address IS NOT NULL
+        ) OR EXISTS (
+            SELECT 1 FROM offchainreporting_contract_configs cc
+            INNER JOIN jobs ON jobs.offchainreporting_oracle_spec_id = cc.offchainreporting_oracle_spec_id
+            WHERE jobs.id = ? AND ? = ANY(cc.transmitters)
+        )
+    `, rotation.JobID, rotation.JobID, rotation.NewAddress.Bytes()).Scan(&inConfig).Error
+	if err != nil {
+		return rotation.State, err
+	} else if inConfig {
+		return StateSwitching, nil
+	}
+	return rotation.State, nil
+}
+
+// switchTransmitter points the job spec at the new transmitter and restarts
+// the job. If another node claims the job, it is left for that node's
+// rotator to restart it.
+func (r *rotator) switchTransmitter(ctx context.Context, rotation *Rotation) (State, error) {
+	err := r.store.DB.Exec(`
+        UPDATE offchainreporting_oracle_specs SET transmitter_address = ?, updated_at = NOW()
+        FROM jobs WHERE jobs.offchainreporting_oracle_spec_id = offchainreporting_oracle_specs.id AND jobs.id = ?
+    `, rotation.NewAddress, rotation.JobID).Error
+	if err != nil {
+		return rotation.State, errors.Wrap(err, "failed to update job spec")
+	}
+
+	err = r.spawner.RestartJob(ctx, rotation.JobID)
+	if errors.Is(err, job.ErrJobNotClaimed) {
+		var claimedElsewhere bool
+		err = r.store.DB.Raw(`SELECT EXISTS (SELECT 1 FROM job_claims WHERE job_id = ?)`, rotation.JobID).Scan(&claimedElsewhere).Error
+		if err != nil || claimedElsewhere {
+			return rotation.State, err
+		}
+	} else if err != nil {
+		return rotation.State, errors.Wrap(err, "failed to restart job")
+	}
+	return StateDraining, nil
+}
+
+// drain waits for the old transmitter's pending transactions to be
+// confirmed. The old key is left alone if anything else still uses it.
+func (r *rotator) drain(rotation *Rotation) (State, error) {
+	inUse, err := r.oldAddressInUse(rotation)
+	if err != nil {
+		return rotation.State, err
+	} else if inUse {
+		logger.Infow("Old transmitter is still in use, not archiving it", "rotationID", rotation.ID, "address", rotation.OldAddress.Hex())
+		return StateComplete, nil
+	}
+
+	var pending int64
+	err = r.store.DB.Model(&models.EthTx{}).
+		Where("from_address = ? AND state IN (?)", rotation.OldAddress, []models.EthTxState{models.EthTxUnstarted, models.EthTxInProgress, models.EthTxUnconfirmed}).
+		Count(&pending).Error
+	if err != nil {
+		return rotation.State, err
+	} else if pending > 0 {
+		return rotation.State, nil
+	}
+	return StateArchiving, nil
+}
+
+func (r *rotator) oldAddressInUse(rotation *Rotation) (bool, error) {
+	if defaultAddress, err := r.store.Config.OCRTransmitterAddress(nil); err == nil && defaultAddress.Address() == rotation.OldAddress {
+		return true, nil
+	}
+	var inUse bool
+	err := r.store.DB.Raw(`
+        SELECT EXISTS (
+            SELECT 1 FROM offchainreporting_oracle_specs s
+            INNER JOIN jobs ON jobs.offchainreporting_oracle_spec_id = s.id
+            WHERE jobs.id != ? AND NOT s.is_bootstrap_peer AND s.transmitter_address = ?
+        ) OR EXISTS (
+            SELECT 1 FROM keeper_specs WHERE from_address = ?
+        )
+    `, rotation.JobID, rotation.OldAddress, rotation.OldAddress).Scan(&inUse).Error
+	return inUse, err
+}
@@ -0,0 +1,258 @@
+package offchainreporting
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+)
+
+// batchingDB is an ocrtypes.Database that buffers the writes made by the OCR
+// protocol that are safe to lose in memory, and flushes them to the database
+// in a single transaction every flushInterval.
+//
+// The persistent state and pending transmissions are written straight
+// through, since the protocol relies on them surviving a crash: losing the
+// state could make the oracle sign conflicting messages for an epoch it has
+// already taken part in, and losing a pending transmission could skip a
+// transmission. Nor would buffering the state behind a flush before each
+// message the oracle sends save anything: libocr writes its state right
+// before sending the messages that depend on it, so every write would be
+// flushed straight away. What is batched is the contract config, which is
+// read back from the chain if it is lost, and the deletes of pending
+// transmissions, which the transmission protocol checks against the contract
+// before acting on anyway. Repeated writes are coalesced so that only the latest is flushed.
+//
+// Reads are served from the buffer where possible, so the protocol always
+// sees its own writes. Anything still buffered is flushed on Close.
+type batchingDB struct {
+	utils.StartStopOnce
+	*db
+	flushInterval time.Duration
+	flushTimeout  time.Duration
+
+	// flushMu is held for reading by reads that may fall through to the
+	// database and by writes that go straight to it, so that neither
+	// interleaves with a flush
+	flushMu sync.RWMutex
+	mu      sync.Mutex
+	config  *ocrtypes.ContractConfig
+	// deletedTransmissions holds the pending transmissions to delete
+	deletedTransmissions map[ocrtypes.PendingTransmissionKey]struct{}
+	// deleteOlderThan is the latest time passed to
+	// DeletePendingTransmissionsOlderThan, if there are deletes to flush
+	deleteOlderThan *time.Time
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+var _ ocrtypes.Database = &batchingDB{}
+
+// NewBatchingDB returns an ocrtypes.Database that batches the writes to d that
+// are safe to lose, flushing them every flushInterval. It must be started
+// before use and closed after the oracle using it, to flush any buffered
+// writes.
+func NewBatchingDB(d *db, flushInterval, flushTimeout time.Duration) *batchingDB {
+	return &batchingDB{
+		db:                   d,
+		flushInterval:        flushInterval,
+		flushTimeout:         flushTimeout,
+		deletedTransmissions: make(map[ocrtypes.PendingTransmissionKey]struct{}),
+		chStop:               make(chan struct{}),
+	}
+}
+
+func (b *batchingDB) Start() error {
+	if !b.OkayToStart() {
+		return errors.New("cannot start already started batching OCR database")
+	}
+	b.wg.Add(1)
+	go b.runLoop()
+	return nil
+}
+
+func (b *batchingDB) Close() error {
+	if !b.OkayToStop() {
+		return errors.New("cannot close unstarted batching OCR database")
+	}
+	close(b.chStop)
+	b.wg.Wait()
+	return b.flush()
+}
+
+func (b *batchingDB) runLoop() {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := b.flush(); err != nil {
+				logger.Errorw("OCR: failed to flush protocol state to the database, will retry", "oracleSpecID", b.oracleSpecID, "err", err)
+			}
+		case <-b.chStop:
+			return
+		}
+	}
+}
+
+func (b *batchingDB) WriteState(ctx context.Context, cd ocrtypes.ConfigDigest, state ocrtypes.PersistentState) error {
+	b.flushMu.RLock()
+	defer b.flushMu.RUnlock()
+	return b.db.WriteState(ctx, cd, state)
+}
+
+func (b *batchingDB) ReadConfig(ctx context.Context) (*ocrtypes.ContractConfig, error) {
+	b.flushMu.RLock()
+	defer b.flushMu.RUnlock()
+
+	b.mu.Lock()
+	config := b.config
+	b.mu.Unlock()
+	if config != nil {
+		c := *config
+		return &c, nil
+	}
+	return b.db.ReadConfig(ctx)
+}
+
+func (b *batchingDB) WriteConfig(_ context.Context, c ocrtypes.ContractConfig) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config = &c
+	return nil
+}
+
+// StorePendingTransmission writes the transmission straight to the database,
+// and drops any buffered delete of it so that the delete isn't flushed after
+// the write
+func (b *batchingDB) StorePendingTransmission(ctx context.Context, k ocrtypes.PendingTransmissionKey, p ocrtypes.PendingTransmission) error {
+	b.flushMu.RLock()
+	defer b.flushMu.RUnlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.db.StorePendingTransmission(ctx, k, p); err != nil {
+		return err
+	}
+	delete(b.deletedTransmissions, k)
+	return nil
+}
+
+func (b *batchingDB) PendingTransmissionsWithConfigDigest(ctx context.Context, cd ocrtypes.ConfigDigest) (map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission, error) {
+	b.flushMu.RLock()
+	defer b.flushMu.RUnlock()
+
+	m, err := b.db.PendingTransmissionsWithConfigDigest(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for k, p := range m {
+		if _, deleted := b.deletedTransmissions[k]; deleted {
+			delete(m, k)
+		} else if b.deleteOlderThan != nil && p.Time.Before(*b.deleteOlderThan) {
+			delete(m, k)
+		}
+	}
+	return m, nil
+}
+
+func (b *batchingDB) DeletePendingTransmission(_ context.Context, k ocrtypes.PendingTransmissionKey) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.deletedTransmissions[k] = struct{}{}
+	return nil
+}
+
+func (b *batchingDB) DeletePendingTransmissionsOlderThan(_ context.Context, t time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.deleteOlderThan == nil || t.After(*b.deleteOlderThan) {
+		b.deleteOlderThan = &t
+	}
+	return nil
+}
+
+// flush writes everything buffered so far to the database in a single
+// transaction. If that fails, the writes are put back in the buffer, unless
+// they have been superseded in the meantime, to be retried on the next flush.
+func (b *batchingDB) flush() error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	config, deletedTransmissions, deleteOlderThan := b.config, b.deletedTransmissions, b.deleteOlderThan
+	b.config = nil
+	b.deletedTransmissions = make(map[ocrtypes.PendingTransmissionKey]struct{})
+	b.deleteOlderThan = nil
+	b.mu.Unlock()
+
+	if config == nil && len(deletedTransmissions) == 0 && deleteOlderThan == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.flushTimeout)
+	defer cancel()
+	err := b.writeBatch(ctx, config, deletedTransmissions, deleteOlderThan)
+	if err == nil {
+		return nil
+	}
+
+	// Writes that go straight to the database are held off by flushMu, so
+	// only buffered writes can have happened in the meantime
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.config == nil {
+		b.config = config
+	}
+	for k := range deletedTransmissions {
+		b.deletedTransmissions[k] = struct{}{}
+	}
+	if deleteOlderThan != nil && (b.deleteOlderThan == nil || deleteOlderThan.After(*b.deleteOlderThan)) {
+		b.deleteOlderThan = deleteOlderThan
+	}
+	return err
+}
+
+func (b *batchingDB) writeBatch(
+	ctx context.Context,
+	config *ocrtypes.ContractConfig,
+	deletedTransmissions map[ocrtypes.PendingTransmissionKey]struct{},
+	deleteOlderThan *time.Time,
+) (err error) {
+	tx, err := b.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			logger.ErrorIfCalling(tx.Rollback)
+		}
+	}()
+
+	if config != nil {
+		if err = b.writeConfig(ctx, tx, *config); err != nil {
+			return err
+		}
+	}
+	if deleteOlderThan != nil {
+		if err = b.deletePendingTransmissionsOlderThan(ctx, tx, *deleteOlderThan); err != nil {
+			return err
+		}
+	}
+	for k := range deletedTransmissions {
+		if err = b.deletePendingTransmission(ctx, tx, k); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(tx.Commit(), "failed to commit transaction")
+}
@@ -0,0 +1,129 @@
+package offchainreporting_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPendingTransmission(median int64, at time.Time) ocrtypes.PendingTransmission {
+	return ocrtypes.PendingTransmission{
+		Time:             at,
+		Median:           ocrtypes.Observation(big.NewInt(median)),
+		SerializedReport: []byte{0, 2, 3},
+		Rs:               [][32]byte{cltest.Random32Byte()},
+		Ss:               [][32]byte{cltest.Random32Byte()},
+		Vs:               cltest.Random32Byte(),
+	}
+}
+
+func Test_BatchingDB(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	sqldb, _ := store.DB.DB()
+	key := cltest.MustInsertRandomKey(t, store.DB)
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, store, key.Address)
+	configDigest := cltest.MakeConfigDigest(t)
+
+	db := offchainreporting.NewDB(sqldb, spec.ID)
+	// The interval is long enough that writes are only flushed on Close
+	batchingDB := offchainreporting.NewBatchingDB(db, time.Hour, 10*time.Second)
+	require.NoError(t, batchingDB.Start())
+
+	state := ocrtypes.PersistentState{
+		Epoch:                3,
+		HighestSentEpoch:     3,
+		HighestReceivedEpoch: []uint32{3},
+	}
+	config := ocrtypes.ContractConfig{
+		ConfigDigest:         configDigest,
+		Signers:              []common.Address{cltest.NewAddress()},
+		Transmitters:         []common.Address{cltest.NewAddress()},
+		Threshold:            uint8(1),
+		EncodedConfigVersion: uint64(1),
+		Encoded:              []byte{1, 2, 3},
+	}
+	stale := ocrtypes.PendingTransmissionKey{ConfigDigest: configDigest, Epoch: 1, Round: 1}
+	deleted := ocrtypes.PendingTransmissionKey{ConfigDigest: configDigest, Epoch: 2, Round: 1}
+	kept := ocrtypes.PendingTransmissionKey{ConfigDigest: configDigest, Epoch: 3, Round: 1}
+	keptTransmission := newPendingTransmission(43, time.Now())
+
+	// Written straight to the database before the batched deletes
+	require.NoError(t, db.StorePendingTransmission(ctx, stale, newPendingTransmission(41, time.Now().Add(-time.Hour))))
+	require.NoError(t, db.StorePendingTransmission(ctx, deleted, newPendingTransmission(42, time.Now())))
+
+	t.Run("reads its own writes before they are flushed", func(t *testing.T) {
+		require.NoError(t, batchingDB.WriteState(ctx, configDigest, state))
+		require.NoError(t, batchingDB.WriteConfig(ctx, config))
+		require.NoError(t, batchingDB.StorePendingTransmission(ctx, kept, keptTransmission))
+		require.NoError(t, batchingDB.DeletePendingTransmission(ctx, deleted))
+		require.NoError(t, batchingDB.DeletePendingTransmissionsOlderThan(ctx, time.Now().Add(-time.Minute)))
+
+		readState, err := batchingDB.ReadState(ctx, configDigest)
+		require.NoError(t, err)
+		assert.Equal(t, state, *readState)
+
+		readConfig, err := batchingDB.ReadConfig(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, config, *readConfig)
+
+		m, err := batchingDB.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		assertPendingTransmissionEqual(t, m[kept], keptTransmission)
+
+		readConfig, err = db.ReadConfig(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, readConfig)
+	})
+
+	t.Run("writes the state and pending transmissions straight through", func(t *testing.T) {
+		readState, err := db.ReadState(ctx, configDigest)
+		require.NoError(t, err)
+		require.NotNil(t, readState)
+		assert.Equal(t, state, *readState)
+
+		m, err := db.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+		require.NoError(t, err)
+		require.Len(t, m, 3)
+		assertPendingTransmissionEqual(t, m[kept], keptTransmission)
+	})
+
+	t.Run("storing a transmission again undoes its buffered delete", func(t *testing.T) {
+		restored := newPendingTransmission(44, time.Now())
+		require.NoError(t, batchingDB.StorePendingTransmission(ctx, deleted, restored))
+
+		m, err := batchingDB.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+		require.NoError(t, err)
+		require.Len(t, m, 2)
+		assertPendingTransmissionEqual(t, m[deleted], restored)
+		require.NoError(t, batchingDB.DeletePendingTransmission(ctx, deleted))
+	})
+
+	t.Run("flushes writes on close", func(t *testing.T) {
+		require.NoError(t, batchingDB.Close())
+
+		readState, err := db.ReadState(ctx, configDigest)
+		require.NoError(t, err)
+		require.NotNil(t, readState)
+		assert.Equal(t, state, *readState)
+
+		readConfig, err := db.ReadConfig(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, readConfig)
+		assert.Equal(t, config, *readConfig)
+
+		m, err := db.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		assertPendingTransmissionEqual(t, m[kept], keptTransmission)
+	})
+}
@@ -32,6 +32,11 @@ import (
 // in some kind of unforeseen insane situation.
 const configMailboxSanityLimit = 100
 
+// logLookbackChunkSize is the number of blocks requested at a time when
+// looking back through the contract's logs. Chunks that are still too large for
+// the eth node are split further.
+const logLookbackChunkSize = 1000
+
 var (
 	_ ocrtypes.ContractConfigTracker = &OCRContractTracker{}
 	_ log.Listener                   = &OCRContractTracker{}
@@ -69,6 +74,14 @@ type (
 		// ContractConfig
 		configsMB utils.Mailbox
 		chConfigs chan ocrtypes.ContractConfig
+
+		// onNewConfig, if set, is called with each config read from the
+		// contract
+		onNewConfig func(ocrtypes.ContractConfig)
+
+		// logLookbackBlocks is how far back Start looks for the latest
+		// RoundRequested log if none has been saved
+		logLookbackBlocks uint64
 	}
 
 	OCRContractTrackerDB interface {
@@ -107,9 +120,25 @@ func NewOCRContractTracker(
 		sync.RWMutex{},
 		*utils.NewMailbox(configMailboxSanityLimit),
 		make(chan ocrtypes.ContractConfig),
+		nil,
+		0,
 	}, nil
 }
 
+// OnNewConfig sets a function to be called with each config read from the
+// contract, both from ConfigSet logs and when libocr polls for the latest
+// config. It must be called before Start.
+func (t *OCRContractTracker) OnNewConfig(fn func(ocrtypes.ContractConfig)) {
+	t.onNewConfig = fn
+}
+
+// SetLogLookbackBlocks sets how many blocks back from the latest block Start
+// looks for the latest RoundRequested log, if none has been saved. 0, the
+// default, disables the lookup. It must be called before Start.
+func (t *OCRContractTracker) SetLogLookbackBlocks(blocks uint64) {
+	t.logLookbackBlocks = blocks
+}
+
 // Start must be called before logs can be delivered
 // It ought to be called before starting OCR
 func (t *OCRContractTracker) Start() (err error) {
@@ -133,11 +162,69 @@ func (t *OCRContractTracker) Start() (err error) {
 		unsubscribe()
 		return errors.Wrap(err, "OCRContractTracker#Start: failed to load latest round requested")
 	}
+	if t.latestRoundRequested.Raw.BlockNumber == 0 && t.logLookbackBlocks > 0 {
+		t.wg.Add(1)
+		go t.lookBackForLatestRoundRequested()
+	}
 	t.wg.Add(1)
 	go t.processLogs()
 	return nil
 }
 
+// lookBackForLatestRoundRequested fills in the latest round requested from the
+// contract's logs, if the log broadcaster hasn't delivered a later one by the
+// time they are fetched. The RoundRequested logs of a newly bootstrapped feed
+// are usually older than the log broadcaster backfills.
+func (t *OCRContractTracker) lookBackForLatestRoundRequested() {
+	defer t.wg.Done()
+
+	latest, err := t.LatestBlockHeight(t.ctx)
+	if err != nil {
+		t.logger.Errorw("OCRContractTracker: failed to get latest block to look back for RoundRequested logs", "err", err, "jobID", t.jobID)
+		return
+	}
+	var from uint64
+	if latest >= t.logLookbackBlocks {
+		from = latest - t.logLookbackBlocks + 1
+	}
+	q := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(latest),
+		Addresses: []gethCommon.Address{t.contract.Address()},
+		Topics: [][]gethCommon.Hash{
+			{OCRContractLatestRoundRequested},
+		},
+	}
+	logs, err := eth.FilterLogsInChunks(t.ctx, t.ethClient, q, logLookbackChunkSize)
+	if err != nil {
+		t.logger.Errorw("OCRContractTracker: failed to look back for RoundRequested logs", "err", err, "jobID", t.jobID, "fromBlock", from, "toBlock", latest)
+		return
+	}
+	if len(logs) == 0 {
+		return
+	}
+
+	raw := logs[len(logs)-1]
+	rr, err := t.contractFilterer.ParseRoundRequested(raw)
+	if err != nil {
+		t.logger.Errorw("OCRContractTracker: could not parse round requested", "err", err, "jobID", t.jobID)
+		return
+	}
+	rr.Raw = raw
+
+	t.lrrMu.Lock()
+	defer t.lrrMu.Unlock()
+	if !IsLaterThan(raw, t.latestRoundRequested.Raw) {
+		return
+	}
+	if err := t.db.SaveLatestRoundRequested(*rr); err != nil {
+		t.logger.Error(err)
+		return
+	}
+	t.latestRoundRequested = *rr
+	t.logger.Infow("OCRContractTracker: found latest RoundRequested event in past logs", "latestRoundRequested", *rr)
+}
+
 // Close should be called after teardown of the OCR job relying on this tracker
 func (t *OCRContractTracker) Close() error {
 	if !t.OkayToStop() {
@@ -220,6 +307,9 @@ func (t *OCRContractTracker) HandleLog(lb log.Broadcast) {
 		configSet.Raw = lb.RawLog()
 		cc := confighelper.ContractConfigFromConfigSetEvent(*configSet)
 
+		if t.onNewConfig != nil {
+			t.onNewConfig(cc)
+		}
 		t.configsMB.Deliver(cc)
 	case OCRContractLatestRoundRequested:
 		var rr *offchainaggregator.OffchainAggregatorRoundRequested
@@ -229,18 +319,19 @@ func (t *OCRContractTracker) HandleLog(lb log.Broadcast) {
 			t.logger.ErrorIfCalling(lb.MarkConsumed)
 			return
 		}
+		t.lrrMu.Lock()
 		if IsLaterThan(raw, t.latestRoundRequested.Raw) {
 			if err := t.db.SaveLatestRoundRequested(*rr); err != nil {
+				t.lrrMu.Unlock()
 				t.logger.Error(err)
 				return
 			}
-			t.lrrMu.Lock()
 			t.latestRoundRequested = *rr
-			t.lrrMu.Unlock()
 			t.logger.Infow("OCRContractTracker: received new latest RoundRequested event", "latestRoundRequested", *rr)
 		} else {
 			t.logger.Warnw("OCRContractTracker: ignoring out of date RoundRequested event", "latestRoundRequested", t.latestRoundRequested, "roundRequested", rr)
 		}
+		t.lrrMu.Unlock()
 	default:
 		logger.Debugw("OCRContractTracker: got unrecognised log topic", "topic", topics[0])
 	}
@@ -325,7 +416,11 @@ func (t *OCRContractTracker) ConfigFromLogs(ctx context.Context, changedInBlock
 	if latest.Raw.Address != t.contract.Address() {
 		return c, errors.Errorf("log address of 0x%x does not match configured contract address of 0x%x", latest.Raw.Address, t.contract.Address())
 	}
-	return confighelper.ContractConfigFromConfigSetEvent(*latest), err
+	c = confighelper.ContractConfigFromConfigSetEvent(*latest)
+	if t.onNewConfig != nil {
+		t.onNewConfig(c)
+	}
+	return c, nil
 }
 
 // LatestBlockHeight queries the eth node for the most recent header
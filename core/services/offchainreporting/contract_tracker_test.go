@@ -2,17 +2,22 @@ package offchainreporting_test
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/offchain_aggregator_wrapper"
+	ethmocks "github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/log/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	ocrmocks "github.com/smartcontractkit/chainlink/core/services/offchainreporting/mocks"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/libocr/gethwrappers/offchainaggregator"
 	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
 	"github.com/stretchr/testify/assert"
@@ -284,6 +289,51 @@ func Test_OCRContractTracker_HandleLog_OCRContractLatestRoundRequested(t *testin
 		db.AssertExpectations(t)
 		broadcaster.AssertExpectations(t)
 	})
+
+	t.Run("looks back through the contract's logs on start if no latest round requested is saved", func(t *testing.T) {
+		db := new(ocrmocks.OCRContractTrackerDB)
+		broadcaster := new(mocks.Broadcaster)
+		ethClient := new(ethmocks.Client)
+		contract := mustNewContract(t, fixtureLogAddress)
+		tracker, err := offchainreporting.NewOCRContractTracker(
+			contract,
+			contractFilterer,
+			nil,
+			ethClient,
+			broadcaster,
+			42,
+			*logger.Default,
+			db,
+		)
+		require.NoError(t, err)
+		tracker.SetLogLookbackBlocks(1500)
+
+		rawLog := cltest.LogFromFixture(t, "./testdata/round_requested_log_1_1.json")
+		broadcaster.On("Register", tracker, mock.Anything).Return(true, func() {})
+		db.On("LoadLatestRoundRequested").Return(offchainaggregator.OffchainAggregatorRoundRequested{}, nil)
+		ethClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&models.Head{Number: 2000}, nil)
+		ethClient.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+			return q.FromBlock.Int64() == 501 && q.ToBlock.Int64() == 1500
+		})).Return([]types.Log{rawLog}, nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+			return q.FromBlock.Int64() == 1501 && q.ToBlock.Int64() == 2000
+		})).Return(nil, nil).Once()
+		db.On("SaveLatestRoundRequested", mock.MatchedBy(func(rr offchainaggregator.OffchainAggregatorRoundRequested) bool {
+			return rr.Epoch == 1 && rr.Round == 1
+		})).Return(nil)
+
+		require.NoError(t, tracker.Start())
+		defer tracker.Close()
+
+		gomega.NewGomegaWithT(t).Eventually(func() uint8 {
+			_, _, round, err := tracker.LatestRoundRequested(context.Background(), 0)
+			require.NoError(t, err)
+			return round
+		}).Should(gomega.Equal(uint8(1)))
+
+		db.AssertExpectations(t)
+		ethClient.AssertExpectations(t)
+	})
 }
 
 func Test_OCRContractTracker_IsLaterThan(t *testing.T) {
@@ -8,7 +8,9 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/maintenance"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
@@ -23,6 +25,20 @@ type dataSource struct {
 	ocrLogger             logger.Logger
 	runResults            chan<- pipeline.RunWithResults
 	currentBridgeMetadata models.BridgeMetaData
+	// decimals, when set, is the number of decimal places the result is
+	// scaled by before being converted to an integer observation
+	decimals *uint8
+	// auditMode records an audit of each run, see pipeline.RunAudit
+	auditMode bool
+	// latencyBudget, when set, is shared out between the tasks of each run,
+	// see pipeline.WithLatencyBudget
+	latencyBudget time.Duration
+	// membership, when set, pauses observations while the node has been
+	// removed from the DON
+	membership *DONMembership
+	// maintenance pauses observations while the node is in maintenance mode
+	// with PauseObservations
+	maintenance *maintenance.Switch
 }
 
 var _ ocrtypes.DataSource = (*dataSource)(nil)
@@ -31,11 +47,20 @@ var _ ocrtypes.DataSource = (*dataSource)(nil)
 // Upon context cancellation, its expected that we return any usable values within ObservationGracePeriod.
 func (ds *dataSource) Observe(ctx context.Context) (ocrtypes.Observation, error) {
 	var observation ocrtypes.Observation
+	if ds.membership != nil && ds.membership.Paused() {
+		return observation, ErrRemovedFromDON
+	}
+	if ds.maintenance.ObservationsPaused() {
+		return observation, maintenance.ErrMaintenanceMode
+	}
 	start := time.Now()
 	md, err := models.MarshalBridgeMetaData(ds.currentBridgeMetadata.LatestAnswer, ds.currentBridgeMetadata.UpdatedAt)
 	if err != nil {
 		logger.Warnw("unable to attach metadata for run", "err", err)
 	}
+	if ds.latencyBudget > 0 {
+		ctx = pipeline.WithLatencyBudget(ctx, ds.latencyBudget)
+	}
 	trrs, err := ds.pipelineRunner.ExecuteRun(ctx, ds.spec, pipeline.JSONSerializable{
 		Val: md,
 	}, ds.ocrLogger)
@@ -46,6 +71,9 @@ func (ds *dataSource) Observe(ctx context.Context) (ocrtypes.Observation, error)
 
 	var run pipeline.Run
 	run.PipelineSpecID = ds.spec.ID
+	if ds.spec.JobID != 0 {
+		run.JobID = &ds.spec.JobID
+	}
 	run.CreatedAt = start
 	run.FinishedAt = &end
 
@@ -53,6 +81,17 @@ func (ds *dataSource) Observe(ctx context.Context) (ocrtypes.Observation, error)
 	run.Outputs = finalResult.OutputsDB()
 	run.Errors = finalResult.ErrorsDB()
 
+	asDecimal, err := ds.scaledResult(finalResult)
+	if err == nil {
+		observation = asDecimal.BigInt()
+	}
+	if ds.auditMode {
+		run.Audit = pipeline.NewRunAudit(trrs)
+		if err == nil {
+			run.Audit.AddRounding("observation", asDecimal, decimal.NewFromBigInt(observation, 0))
+		}
+	}
+
 	// Do the database write in a non-blocking fashion
 	// so we can return the observation results immediately.
 	// This is helpful in the case of a blocking API call, where
@@ -68,22 +107,35 @@ func (ds *dataSource) Observe(ctx context.Context) (ocrtypes.Observation, error)
 		return nil, errors.Errorf("unable to enqueue run save for job ID %v, buffer full", ds.spec.JobID)
 	}
 
+	if err != nil {
+		return nil, err
+	}
+	ds.currentBridgeMetadata = models.BridgeMetaData{
+		LatestAnswer: observation,
+		UpdatedAt:    big.NewInt(time.Now().Unix()),
+	}
+	return observation, nil
+}
+
+// scaledResult returns the final result of a run scaled by decimals, if set.
+// Any fractional part left is truncated when it is converted to an
+// observation.
+func (ds *dataSource) scaledResult(finalResult pipeline.FinalResult) (decimal.Decimal, error) {
 	result, err := finalResult.SingularResult()
 	if err != nil {
-		return nil, errors.Wrapf(err, "error getting singular result for job ID %v", ds.spec.JobID)
+		return decimal.Decimal{}, errors.Wrapf(err, "error getting singular result for job ID %v", ds.spec.JobID)
 	}
 
 	if result.Error != nil {
-		return nil, result.Error
+		return decimal.Decimal{}, result.Error
 	}
 
 	asDecimal, err := utils.ToDecimal(result.Value)
 	if err != nil {
-		return nil, err
+		return decimal.Decimal{}, err
 	}
-	ds.currentBridgeMetadata = models.BridgeMetaData{
-		LatestAnswer: asDecimal.BigInt(),
-		UpdatedAt:    big.NewInt(time.Now().Unix()),
+	if ds.decimals != nil {
+		asDecimal = asDecimal.Shift(int32(*ds.decimals)).Round(0)
 	}
-	return asDecimal.BigInt(), nil
+	return asDecimal, nil
 }
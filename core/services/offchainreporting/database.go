@@ -12,6 +12,7 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/libocr/gethwrappers/offchainaggregator"
 	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
@@ -27,6 +28,12 @@ var (
 	_ OCRContractTrackerDB = &db{}
 )
 
+// execer is satisfied by both *sql.DB and *sql.Tx, so that writes can either
+// go straight to the database or be batched into a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
 // NewDB returns a new DB scoped to this oracleSpecID
 func NewDB(sqldb *sql.DB, oracleSpecID int32) *db {
 	return &db{sqldb, oracleSpecID}
@@ -62,18 +69,21 @@ func (d *db) WriteState(ctx context.Context, cd ocrtypes.ConfigDigest, state ocr
 	for _, v := range state.HighestReceivedEpoch {
 		highestReceivedEpoch = append(highestReceivedEpoch, int64(v))
 	}
-	_, err := d.ExecContext(ctx, `
+	// The table is partitioned by updated_at, so the primary key cannot be
+	// used to upsert with ON CONFLICT. Updating updated_at moves the state to
+	// the current month's partition.
+	err := d.upsertPartitioned(ctx, postgres.AdvisoryLockClassID_OCRPersistentStates, `
+WITH updated AS (
+	UPDATE offchainreporting_persistent_states SET
+		(epoch, highest_sent_epoch, highest_received_epoch, updated_at)
+		=
+		($3, $4, $5, NOW())
+	WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2
+	RETURNING 1
+)
 INSERT INTO offchainreporting_persistent_states (offchainreporting_oracle_spec_id, config_digest, epoch, highest_sent_epoch, highest_received_epoch, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
-ON CONFLICT (offchainreporting_oracle_spec_id, config_digest) DO UPDATE SET
-	(epoch, highest_sent_epoch, highest_received_epoch, updated_at)
-	=
-	(
-	 EXCLUDED.epoch,
-	 EXCLUDED.highest_sent_epoch,
-	 EXCLUDED.highest_received_epoch,
-	 NOW()
-	)
+SELECT $1::integer, $2::bytea, $3::bigint, $4::bigint, $5::bigint[], NOW(), NOW()
+WHERE NOT EXISTS (SELECT 1 FROM updated)
 `, d.oracleSpecID, cd, state.Epoch, state.HighestSentEpoch, pq.Array(&highestReceivedEpoch))
 
 	return errors.Wrap(err, "WriteState failed")
@@ -109,6 +119,10 @@ func (d *db) ReadConfig(ctx context.Context) (c *ocrtypes.ContractConfig, err er
 }
 
 func (d *db) WriteConfig(ctx context.Context, c ocrtypes.ContractConfig) error {
+	return d.writeConfig(ctx, d.DB, c)
+}
+
+func (d *db) writeConfig(ctx context.Context, q execer, c ocrtypes.ContractConfig) error {
 	var signers [][]byte
 	var transmitters [][]byte
 	for _, s := range c.Signers {
@@ -117,7 +131,7 @@ func (d *db) WriteConfig(ctx context.Context, c ocrtypes.ContractConfig) error {
 	for _, t := range c.Transmitters {
 		transmitters = append(transmitters, t.Bytes())
 	}
-	_, err := d.ExecContext(ctx, `
+	_, err := q.ExecContext(ctx, `
 INSERT INTO offchainreporting_contract_configs (offchainreporting_oracle_spec_id, config_digest, signers, transmitters, threshold, encoded_config_version, encoded, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
 ON CONFLICT (offchainreporting_oracle_spec_id) DO UPDATE SET
@@ -148,7 +162,21 @@ func (d *db) StorePendingTransmission(ctx context.Context, k ocrtypes.PendingTra
 		ss = append(ss, v[:])
 	}
 
-	_, err := d.ExecContext(ctx, `
+	// The table is partitioned by time, which is not part of the key, so
+	// upsert with an update falling back to an insert rather than ON CONFLICT
+	err := d.upsertPartitioned(ctx, postgres.AdvisoryLockClassID_OCRPendingTransmissions, `
+WITH updated AS (
+	UPDATE offchainreporting_pending_transmissions SET
+		time = $5,
+		median = $6,
+		serialized_report = $7,
+		rs = $8,
+		ss = $9,
+		vs = $10,
+		updated_at = NOW()
+	WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2 AND epoch = $3 AND round = $4
+	RETURNING 1
+)
 INSERT INTO offchainreporting_pending_transmissions (
 	offchainreporting_oracle_spec_id,
 	config_digest,
@@ -163,20 +191,39 @@ INSERT INTO offchainreporting_pending_transmissions (
 	created_at,
 	updated_at
 )
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,NOW(),NOW())
-ON CONFLICT (offchainreporting_oracle_spec_id, config_digest, epoch, round) DO UPDATE SET
-	time = EXCLUDED.time,
-	median = EXCLUDED.median,
-	serialized_report = EXCLUDED.serialized_report,
-	rs = EXCLUDED.rs,
-	ss = EXCLUDED.ss,
-	vs = EXCLUDED.vs,
-	updated_at = NOW()
+SELECT $1::integer, $2::bytea, $3::bigint, $4::bigint, $5::timestamptz, $6::numeric, $7::bytea, $8::bytea[], $9::bytea[], $10::bytea, NOW(), NOW()
+WHERE NOT EXISTS (SELECT 1 FROM updated)
 `, d.oracleSpecID, k.ConfigDigest, k.Epoch, k.Round, p.Time, median, p.SerializedReport, pq.ByteaArray(rs), pq.ByteaArray(ss), p.Vs[:])
 
 	return errors.Wrap(err, "StorePendingTransmission failed")
 }
 
+// upsertPartitioned runs an upsert into one of the partitioned OCR tables.
+// Their primary keys include the partition key, so they can't stop two rows
+// from having the same logical key, and two concurrent upserts of the same
+// key could both insert it. The upsert runs in a transaction that first takes
+// an advisory lock on the oracle spec, so that the upserts of a spec are
+// serialized and each one sees the rows inserted before it.
+func (d *db) upsertPartitioned(ctx context.Context, lockClassID int32, query string, args ...interface{}) (err error) {
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			logger.ErrorIfCalling(tx.Rollback)
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, $2)`, lockClassID, d.oracleSpecID); err != nil {
+		return errors.Wrap(err, "failed to take advisory lock")
+	}
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "failed to commit transaction")
+}
+
 func (d *db) PendingTransmissionsWithConfigDigest(ctx context.Context, cd ocrtypes.ConfigDigest) (map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission, error) {
 	rows, err := d.QueryContext(ctx, `
 SELECT
@@ -234,8 +281,12 @@ WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2
 	return m, nil
 }
 
-func (d *db) DeletePendingTransmission(ctx context.Context, k ocrtypes.PendingTransmissionKey) (err error) {
-	_, err = d.ExecContext(ctx, `
+func (d *db) DeletePendingTransmission(ctx context.Context, k ocrtypes.PendingTransmissionKey) error {
+	return d.deletePendingTransmission(ctx, d.DB, k)
+}
+
+func (d *db) deletePendingTransmission(ctx context.Context, q execer, k ocrtypes.PendingTransmissionKey) (err error) {
+	_, err = q.ExecContext(ctx, `
 DELETE FROM offchainreporting_pending_transmissions
 WHERE offchainreporting_oracle_spec_id = $1 AND  config_digest = $2 AND epoch = $3 AND round = $4
 `, d.oracleSpecID, k.ConfigDigest, k.Epoch, k.Round)
@@ -245,8 +296,12 @@ WHERE offchainreporting_oracle_spec_id = $1 AND  config_digest = $2 AND epoch =
 	return
 }
 
-func (d *db) DeletePendingTransmissionsOlderThan(ctx context.Context, t time.Time) (err error) {
-	_, err = d.ExecContext(ctx, `
+func (d *db) DeletePendingTransmissionsOlderThan(ctx context.Context, t time.Time) error {
+	return d.deletePendingTransmissionsOlderThan(ctx, d.DB, t)
+}
+
+func (d *db) deletePendingTransmissionsOlderThan(ctx context.Context, q execer, t time.Time) (err error) {
+	_, err = q.ExecContext(ctx, `
 DELETE FROM offchainreporting_pending_transmissions
 WHERE offchainreporting_oracle_spec_id = $1 AND time < $2
 `, d.oracleSpecID, t)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -98,6 +99,27 @@ func Test_DB_ReadWriteState(t *testing.T) {
 
 		require.Nil(t, readState)
 	})
+
+	t.Run("keeps a single state per config digest under concurrent writes", func(t *testing.T) {
+		db := offchainreporting.NewDB(sqldb, spec.ID)
+		configDigest := cltest.MakeConfigDigest(t)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(epoch uint32) {
+				defer wg.Done()
+				state := ocrtypes.PersistentState{Epoch: epoch, HighestReceivedEpoch: []uint32{epoch}}
+				assert.NoError(t, db.WriteState(ctx, configDigest, state))
+			}(uint32(i))
+		}
+		wg.Wait()
+
+		var count int
+		err := sqldb.QueryRow(`SELECT count(*) FROM offchainreporting_persistent_states WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2`, spec.ID, configDigest).Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
 }
 
 func Test_DB_ReadWriteConfig(t *testing.T) {
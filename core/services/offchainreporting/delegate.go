@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
@@ -15,7 +17,9 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/log"
+	"github.com/smartcontractkit/chainlink/core/services/maintenance"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/telemetry"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/libocr/gethwrappers/offchainaggregator"
 	ocr "github.com/smartcontractkit/libocr/offchainreporting"
@@ -31,7 +35,8 @@ type Delegate struct {
 	ethClient          eth.Client
 	logBroadcaster     log.Broadcaster
 	peerWrapper        *SingletonPeerWrapper
-	monitoringEndpoint ocrtypes.MonitoringEndpoint
+	monitoringEndpoint telemetry.MonitoringEndpointGenerator
+	maintenance        *maintenance.Switch
 }
 
 func NewDelegate(
@@ -43,7 +48,8 @@ func NewDelegate(
 	ethClient eth.Client,
 	logBroadcaster log.Broadcaster,
 	peerWrapper *SingletonPeerWrapper,
-	monitoringEndpoint ocrtypes.MonitoringEndpoint,
+	monitoringEndpoint telemetry.MonitoringEndpointGenerator,
+	maintenance *maintenance.Switch,
 ) *Delegate {
 	return &Delegate{db,
 		jobORM,
@@ -54,6 +60,7 @@ func NewDelegate(
 		logBroadcaster,
 		peerWrapper,
 		monitoringEndpoint,
+		maintenance,
 	}
 }
 
@@ -61,6 +68,14 @@ func (d Delegate) JobType() job.Type {
 	return job.OffchainReporting
 }
 
+// Dependencies implements the job.DelegateWithDependencies interface. OCR
+// oracles need a running libp2p peer, pipeline runner and log broadcaster as
+// soon as they start, and this month's partitions of the tables they write
+// their state to.
+func (d Delegate) Dependencies() []string {
+	return []string{job.DependencyPeerWrapper, job.DependencyPipelineRunner, job.DependencyLogBroadcaster, job.DependencyOCRPartitions}
+}
+
 func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err error) {
 	if jobSpec.OffchainreportingOracleSpec == nil {
 		return nil, errors.Errorf("offchainreporting.Delegate expects an *job.OffchainreportingOracleSpec to be present, got %v", jobSpec)
@@ -87,21 +102,12 @@ func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err
 		return nil, errors.Wrap(errdb, "unable to open sql db")
 	}
 	ocrdb := NewDB(gormdb, concreteSpec.ID)
-
-	tracker, err := NewOCRContractTracker(
-		contract,
-		contractFilterer,
-		contractCaller,
-		d.ethClient,
-		d.logBroadcaster,
-		jobSpec.ID,
-		*logger.Default,
-		ocrdb,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "error calling NewOCRContract")
+	var ocrDatabase ocrtypes.Database = ocrdb
+	var batchedDB *batchingDB
+	if interval := d.config.OCRDatabaseWriteInterval(); interval > 0 {
+		batchedDB = NewBatchingDB(ocrdb, interval, d.config.OCRDatabaseTimeout())
+		ocrDatabase = batchedDB
 	}
-	services = append(services, tracker)
 
 	peerID, err := d.config.P2PPeerID(concreteSpec.P2PPeerID)
 	if err != nil {
@@ -115,9 +121,22 @@ func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err
 	} else if peerWrapper.PeerID != peerID {
 		return nil, errors.Errorf("given peer with ID '%s' does not match OCR configured peer with ID: %s", peerWrapper.PeerID.String(), peerID.String())
 	}
-	bootstrapPeers, err := d.config.P2PBootstrapPeers(concreteSpec.P2PBootstrapPeers)
-	if err != nil {
-		return nil, err
+	var bootstrapPeers []string
+	if d.config.P2PDiscoveryMode() == orm.P2PDiscoveryModeExplicit {
+		// The peer table stands in for the bootstrap peers, so that the
+		// oracle only ever talks to the peers it was given
+		bootstrapPeers, err = d.config.P2PPeers(concreteSpec.P2PPeers)
+		if err != nil {
+			return nil, err
+		}
+		if err = peerWrapper.AddPeers(bootstrapPeers); err != nil {
+			return nil, errors.Wrap(err, "could not add p2pPeers to the peer table")
+		}
+	} else {
+		bootstrapPeers, err = d.config.P2PBootstrapPeers(concreteSpec.P2PBootstrapPeers)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	loggerWith := logger.CreateLogger(logger.Default.With(
@@ -147,12 +166,28 @@ func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err
 	}
 	logger.Info(fmt.Sprintf("OCR job using local config %+v", lc))
 
+	tracker, err := NewOCRContractTracker(
+		contract,
+		contractFilterer,
+		contractCaller,
+		d.ethClient,
+		d.logBroadcaster,
+		jobSpec.ID,
+		*logger.Default,
+		ocrdb,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error calling NewOCRContract")
+	}
+	tracker.SetLogLookbackBlocks(d.config.OCRContractLogLookbackBlocks())
+	services = append(services, tracker)
+
 	if concreteSpec.IsBootstrapPeer {
 		bootstrapper, err := ocr.NewBootstrapNode(ocr.BootstrapNodeArgs{
 			BootstrapperFactory:   peerWrapper.Peer,
 			Bootstrappers:         bootstrapPeers,
 			ContractConfigTracker: tracker,
-			Database:              ocrdb,
+			Database:              ocrDatabase,
 			LocalConfig:           lc,
 			Logger:                ocrLogger,
 		})
@@ -172,6 +207,12 @@ func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err
 		if !exists {
 			return nil, errors.Errorf("OCR key '%v' does not exist", concreteSpec.EncryptedOCRKeyBundleID)
 		}
+
+		if concreteSpec.Decimals != nil {
+			if err = checkDecimals(contract, *concreteSpec.Decimals, lc.BlockchainTimeout); err != nil {
+				return nil, err
+			}
+		}
 		contractABI, err := abi.JSON(strings.NewReader(offchainaggregator.OffchainAggregatorABI))
 		if err != nil {
 			return nil, errors.Wrap(err, "could not get contract ABI JSON")
@@ -181,25 +222,62 @@ func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err
 		if err != nil {
 			return nil, err
 		}
-		contractTransmitter := NewOCRContractTransmitter(
+		deadline := NewTransmissionDeadline(jobSpec.ID, time.Duration(concreteSpec.TransmissionDeadline))
+		transmitter := NewTransmitter(gormdb, jobSpec.ID, ta.Address(), d.config.EthGasLimitDefault(), d.config.EthMaxUnconfirmedTransactions(), deadline)
+		if concreteSpec.ForwarderAddress != nil {
+			transmitter = NewForwardingTransmitter(transmitter, concreteSpec.ForwarderAddress.Address())
+		}
+		if jobSpec.MaxGasCostWei != nil {
+			guard := job.NewGasCostGuard(jobSpec, d.config, eth.NewGasCostEstimator(d.ethClient, d.config.ChainType()), d.jobORM)
+			transmitter = NewGasCostGuardedTransmitter(transmitter, guard, d.config.EthGasLimitDefault())
+		}
+		if concreteSpec.TransmitDisabled {
+			loggerWith.Infow("OCR: transmitDisabled is set, so this job will take part in the protocol but never transmit")
+			transmitter = NewDryRunTransmitter(transmitter, jobSpec.ID)
+		}
+		// The forwarder, rather than the sending key, is the transmitter
+		// address seen by the contract
+		membership := NewDONMembership(jobSpec.ID, transmitter.FromAddress(), gethCommon.Address(ocrkey.PublicKeyAddressOnChain()), d.jobORM)
+		tracker.OnNewConfig(func(cc ocrtypes.ContractConfig) {
+			membership.OnConfig(cc)
+			deadline.OnConfig(cc)
+		})
+		transmitter = NewPausableTransmitter(transmitter, membership)
+		var contractTransmitter ocrtypes.ContractTransmitter = NewOCRContractTransmitter(
 			concreteSpec.ContractAddress.Address(),
 			contractCaller,
 			contractABI,
-			NewTransmitter(gormdb, ta.Address(), d.config.EthGasLimitDefault(), d.config.EthMaxUnconfirmedTransactions()),
+			transmitter,
 			d.logBroadcaster,
 			tracker,
 		)
+		contractTransmitter = NewMaintenanceContractTransmitter(contractTransmitter, d.maintenance, jobSpec.ID)
+
+		monitoringEndpoint, err := d.monitoringEndpoint.GenMonitoringEndpoint(concreteSpec.ContractAddress, d.config.OCRMonitoringEndpoint(concreteSpec.MonitoringEndpoint))
+		if err != nil {
+			return nil, err
+		}
+
+		var latencyBudget time.Duration
+		if concreteSpec.LatencyBudget {
+			latencyBudget = lc.DataSourceTimeout
+		}
 
 		runResults := make(chan pipeline.RunWithResults, d.config.JobPipelineResultWriteQueueDepth())
 		jobSpec.PipelineSpec.JobName = jobSpec.Name.ValueOrZero()
 		jobSpec.PipelineSpec.JobID = jobSpec.ID
 		oracle, err := ocr.NewOracle(ocr.OracleArgs{
-			Database: ocrdb,
+			Database: ocrDatabase,
 			Datasource: &dataSource{
 				pipelineRunner: d.pipelineRunner,
 				ocrLogger:      *loggerWith,
 				spec:           *jobSpec.PipelineSpec,
 				runResults:     runResults,
+				decimals:       concreteSpec.Decimals,
+				auditMode:      d.config.JobPipelineAuditMode(),
+				latencyBudget:  latencyBudget,
+				membership:     membership,
+				maintenance:    d.maintenance,
 			},
 			LocalConfig:                  lc,
 			ContractTransmitter:          contractTransmitter,
@@ -208,11 +286,14 @@ func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err
 			BinaryNetworkEndpointFactory: peerWrapper.Peer,
 			Logger:                       ocrLogger,
 			Bootstrappers:                bootstrapPeers,
-			MonitoringEndpoint:           d.monitoringEndpoint,
+			MonitoringEndpoint:           monitoringEndpoint,
 		})
 		if err != nil {
 			return nil, errors.Wrap(err, "error calling NewOracle")
 		}
+		if timeout := d.config.JobPipelineWarmupTimeout(); timeout > 0 {
+			services = append(services, job.NewWarmup(jobSpec, d.pipelineRunner, d.jobORM, timeout))
+		}
 		services = append(services, oracle)
 
 		// RunResultSaver needs to be started first so its available
@@ -226,5 +307,29 @@ func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err
 		)}, services...)
 	}
 
+	if batchedDB != nil {
+		// The batching database is started first and closed last, so that
+		// everything the oracle wrote is flushed when the job stops
+		services = append([]job.Service{batchedDB}, services...)
+	}
+
 	return services, nil
 }
+
+// checkDecimals compares the decimals set on the job spec with those reported
+// by the aggregator contract. A mismatch would make every observation off by
+// a power of ten, so it is an error; failing to read the contract is only
+// logged, so that an unreachable node does not stop the job from starting.
+func checkDecimals(contract *offchain_aggregator_wrapper.OffchainAggregator, decimals uint8, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	onChain, err := contract.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		logger.Warnw("OCR: unable to read decimals from aggregator contract, skipping check", "err", err, "decimals", decimals)
+		return nil
+	}
+	if onChain != decimals {
+		return errors.Errorf("job spec sets decimals to %d but the aggregator contract uses %d", decimals, onChain)
+	}
+	return nil
+}
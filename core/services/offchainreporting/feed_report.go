@@ -0,0 +1,194 @@
+package offchainreporting
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/offchain_aggregator_wrapper"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+type (
+	// FeedReport compares the rounds transmitted to an OCR contract over a
+	// range of blocks with this node's part in them, so that operators can
+	// tell whether their node is contributing to the feed
+	FeedReport struct {
+		ContractAddress    gethCommon.Address
+		TransmitterAddress gethCommon.Address
+		// OracleIndex is the index of this node in the contract's list of
+		// oracles, or -1 if its transmitter address isn't one of them
+		OracleIndex        int
+		ConfigDigest       [16]byte
+		FromBlock          uint64
+		ToBlock            uint64
+		Rounds             []FeedRound
+		ParticipatedRounds int
+		MissedRounds       int
+		TransmittedRounds  int
+		// MaxDivergence is the largest difference, in percent of the answer,
+		// between this node's observation and the answer of a round
+		MaxDivergence float64
+		// LatestLocalObservation is the result of this node's most recent
+		// successful pipeline run for the feed, if any
+		LatestLocalObservation *LocalObservation
+	}
+
+	// FeedRound is a round transmitted to an OCR contract
+	FeedRound struct {
+		RoundID      uint32
+		BlockNumber  uint64
+		Answer       *big.Int
+		Transmitter  gethCommon.Address
+		Participated bool
+		Transmitted  bool
+		// Observation is this node's observation, if it participated
+		Observation *big.Int
+		// Divergence is the difference between Observation and Answer, in
+		// percent of the answer
+		Divergence float64
+	}
+
+	// LocalObservation is a value observed by this node's pipeline
+	LocalObservation struct {
+		Value      decimal.Decimal
+		ObservedAt time.Time
+	}
+)
+
+// NewFeedReport fetches the oracles of the OCR contract and the rounds it
+// received over the last lookbackBlocks blocks, and compares them with the
+// observations and transmissions of the node transmitting from
+// transmitterAddress
+func NewFeedReport(ctx context.Context, ethClient eth.Client, contractAddress, transmitterAddress gethCommon.Address, lookbackBlocks uint64) (FeedReport, error) {
+	report := FeedReport{ContractAddress: contractAddress, TransmitterAddress: transmitterAddress}
+	contract, err := offchain_aggregator_wrapper.NewOffchainAggregator(contractAddress, ethClient)
+	if err != nil {
+		return report, errors.Wrap(err, "could not instantiate NewOffchainAggregator")
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	details, err := contract.LatestConfigDetails(opts)
+	if err != nil {
+		return report, errors.Wrap(err, "error getting LatestConfigDetails")
+	}
+	report.ConfigDigest = details.ConfigDigest
+	transmitters, err := contract.Transmitters(opts)
+	if err != nil {
+		return report, errors.Wrap(err, "error getting Transmitters")
+	}
+
+	head, err := ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return report, errors.Wrap(err, "error getting latest head")
+	}
+	report.ToBlock = uint64(head.Number)
+	if report.ToBlock > lookbackBlocks {
+		report.FromBlock = report.ToBlock - lookbackBlocks
+	}
+
+	it, err := contract.FilterNewTransmission(&bind.FilterOpts{Start: report.FromBlock, End: &report.ToBlock, Context: ctx}, nil)
+	if err != nil {
+		return report, errors.Wrap(err, "error filtering NewTransmission logs")
+	}
+	defer it.Close()
+	var transmissions []offchain_aggregator_wrapper.OffchainAggregatorNewTransmission
+	for it.Next() {
+		transmissions = append(transmissions, *it.Event)
+	}
+	if err = it.Error(); err != nil {
+		return report, errors.Wrap(err, "error reading NewTransmission logs")
+	}
+
+	report.OracleIndex = -1
+	for i, transmitter := range transmitters {
+		if transmitter == transmitterAddress {
+			report.OracleIndex = i
+		}
+	}
+	report.AddRounds(transmissions)
+	return report, nil
+}
+
+// AddRounds adds the transmitted rounds to the report, recording whether this
+// node observed and transmitted each one
+func (r *FeedReport) AddRounds(transmissions []offchain_aggregator_wrapper.OffchainAggregatorNewTransmission) {
+	for _, t := range transmissions {
+		round := FeedRound{
+			RoundID:     t.AggregatorRoundId,
+			BlockNumber: t.Raw.BlockNumber,
+			Answer:      t.Answer,
+			Transmitter: t.Transmitter,
+			Transmitted: t.Transmitter == r.TransmitterAddress,
+		}
+		for i, observer := range t.Observers {
+			if r.OracleIndex >= 0 && int(observer) == r.OracleIndex && i < len(t.Observations) {
+				round.Participated = true
+				round.Observation = t.Observations[i]
+				round.Divergence = divergence(round.Observation, round.Answer)
+			}
+		}
+
+		if round.Participated {
+			r.ParticipatedRounds++
+			if round.Divergence > r.MaxDivergence {
+				r.MaxDivergence = round.Divergence
+			}
+		} else {
+			r.MissedRounds++
+		}
+		if round.Transmitted {
+			r.TransmittedRounds++
+		}
+		r.Rounds = append(r.Rounds, round)
+	}
+}
+
+// SetLatestLocalObservation records the result of the most recent of the
+// given pipeline runs that finished without errors
+func (r *FeedReport) SetLatestLocalObservation(runs []pipeline.Run) {
+	for _, run := range runs {
+		if run.FinishedAt == nil || run.HasErrors() {
+			continue
+		}
+		outputs, ok := run.Outputs.Val.([]interface{})
+		if !ok || len(outputs) != 1 {
+			continue
+		}
+		value, err := utils.ToDecimal(outputs[0])
+		if err != nil {
+			continue
+		}
+		if r.LatestLocalObservation == nil || run.FinishedAt.After(r.LatestLocalObservation.ObservedAt) {
+			r.LatestLocalObservation = &LocalObservation{Value: value, ObservedAt: *run.FinishedAt}
+		}
+	}
+}
+
+// divergence returns the difference between observation and answer in
+// percent of the answer. Any observation other than zero diverges from a zero
+// answer by 100%.
+func divergence(observation, answer *big.Int) float64 {
+	if observation == nil || answer == nil {
+		return 0
+	}
+	diff := new(big.Int).Sub(observation, answer)
+	if diff.Sign() == 0 {
+		return 0
+	}
+	if answer.Sign() == 0 {
+		return 100
+	}
+	percent, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(new(big.Int).Mul(diff.Abs(diff), big.NewInt(100))),
+		new(big.Float).SetInt(new(big.Int).Abs(answer)),
+	).Float64()
+	return percent
+}
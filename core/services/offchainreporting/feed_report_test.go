@@ -0,0 +1,104 @@
+package offchainreporting_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/offchain_aggregator_wrapper"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestFeedReport_AddRounds(t *testing.T) {
+	us := cltest.NewAddress()
+	them := cltest.NewAddress()
+	report := offchainreporting.FeedReport{TransmitterAddress: us, OracleIndex: 2}
+
+	report.AddRounds([]offchain_aggregator_wrapper.OffchainAggregatorNewTransmission{
+		{
+			AggregatorRoundId: 1,
+			Answer:            big.NewInt(100),
+			Transmitter:       us,
+			Observers:         []byte{0, 2, 1},
+			Observations:      []*big.Int{big.NewInt(99), big.NewInt(100), big.NewInt(101)},
+			Raw:               gethTypes.Log{BlockNumber: 10},
+		},
+		{
+			AggregatorRoundId: 2,
+			Answer:            big.NewInt(200),
+			Transmitter:       them,
+			Observers:         []byte{1, 0, 3},
+			Observations:      []*big.Int{big.NewInt(199), big.NewInt(200), big.NewInt(201)},
+			Raw:               gethTypes.Log{BlockNumber: 11},
+		},
+		{
+			AggregatorRoundId: 3,
+			Answer:            big.NewInt(200),
+			Transmitter:       them,
+			Observers:         []byte{2, 0, 1},
+			Observations:      []*big.Int{big.NewInt(190), big.NewInt(200), big.NewInt(201)},
+			Raw:               gethTypes.Log{BlockNumber: 12},
+		},
+	})
+
+	require.Len(t, report.Rounds, 3)
+	assert.Equal(t, 2, report.ParticipatedRounds)
+	assert.Equal(t, 1, report.MissedRounds)
+	assert.Equal(t, 1, report.TransmittedRounds)
+	assert.Equal(t, float64(5), report.MaxDivergence)
+
+	assert.True(t, report.Rounds[0].Participated)
+	assert.True(t, report.Rounds[0].Transmitted)
+	assert.Equal(t, big.NewInt(100), report.Rounds[0].Observation)
+	assert.Equal(t, float64(0), report.Rounds[0].Divergence)
+	assert.Equal(t, uint64(10), report.Rounds[0].BlockNumber)
+
+	assert.False(t, report.Rounds[1].Participated)
+	assert.Nil(t, report.Rounds[1].Observation)
+
+	assert.Equal(t, big.NewInt(190), report.Rounds[2].Observation)
+	assert.Equal(t, float64(5), report.Rounds[2].Divergence)
+
+	t.Run("counts every round as missed when the node isn't an oracle", func(t *testing.T) {
+		report := offchainreporting.FeedReport{TransmitterAddress: us, OracleIndex: -1}
+		report.AddRounds([]offchain_aggregator_wrapper.OffchainAggregatorNewTransmission{
+			{Answer: big.NewInt(1), Observers: []byte{0}, Observations: []*big.Int{big.NewInt(1)}},
+		})
+		assert.Equal(t, 0, report.ParticipatedRounds)
+		assert.Equal(t, 1, report.MissedRounds)
+	})
+}
+
+func TestFeedReport_SetLatestLocalObservation(t *testing.T) {
+	earlier := time.Now().Add(-time.Minute)
+	later := time.Now()
+	var report offchainreporting.FeedReport
+
+	report.SetLatestLocalObservation([]pipeline.Run{
+		{
+			Outputs:    pipeline.JSONSerializable{Val: []interface{}{"123.45"}},
+			Errors:     pipeline.RunErrors{{}},
+			FinishedAt: &earlier,
+		},
+		{
+			Outputs:    pipeline.JSONSerializable{Val: []interface{}{nil}},
+			Errors:     pipeline.RunErrors{null.StringFrom("failed")},
+			FinishedAt: &later,
+		},
+		{
+			Outputs: pipeline.JSONSerializable{Val: []interface{}{"200"}},
+			Errors:  pipeline.RunErrors{{}},
+		},
+	})
+
+	require.NotNil(t, report.LatestLocalObservation)
+	assert.Equal(t, "123.45", report.LatestLocalObservation.Value.String())
+	assert.Equal(t, earlier, report.LatestLocalObservation.ObservedAt)
+}
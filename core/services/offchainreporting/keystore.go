@@ -170,6 +170,31 @@ func (ks KeyStore) DeleteEncryptedP2PKey(key *p2pkey.EncryptedP2PKey) error {
 	return nil
 }
 
+// RestoreEncryptedP2PKey restores an archived P2P key
+func (ks KeyStore) RestoreEncryptedP2PKey(id int32) (*p2pkey.EncryptedP2PKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	var key p2pkey.EncryptedP2PKey
+	err := ks.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	k, err := key.Decrypt(ks.password)
+	if err != nil {
+		return nil, errors.Wrap(err, "while decrypting archived P2P key")
+	}
+	peerID, err := k.GetPeerID()
+	if err != nil {
+		return nil, err
+	}
+	err = ks.Unscoped().Model(&key).Update("deleted_at", nil).Error
+	if err != nil {
+		return nil, err
+	}
+	ks.p2pkeys[models.PeerID(peerID)] = k
+	return &key, nil
+}
+
 func (ks KeyStore) GenerateEncryptedOCRKeyBundle() (ocrkey.KeyBundle, ocrkey.EncryptedKeyBundle, error) {
 	key, err := ocrkey.NewKeyBundle()
 	if err != nil {
@@ -251,6 +276,65 @@ func (ks KeyStore) DeleteEncryptedOCRKeyBundle(key *ocrkey.EncryptedKeyBundle) e
 	return nil
 }
 
+// RestoreEncryptedOCRKeyBundle restores an archived OCR key bundle
+func (ks KeyStore) RestoreEncryptedOCRKeyBundle(id models.Sha256Hash) (ocrkey.EncryptedKeyBundle, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	var key ocrkey.EncryptedKeyBundle
+	err := ks.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&key).Error
+	if err != nil {
+		return key, err
+	}
+	k, err := key.Decrypt(ks.password)
+	if err != nil {
+		return key, errors.Wrap(err, "while decrypting archived OCR key bundle")
+	}
+	err = ks.Unscoped().Model(&key).Update("deleted_at", nil).Error
+	if err != nil {
+		return key, err
+	}
+	ks.ocrkeys[k.ID] = *k
+	return key, nil
+}
+
+// PurgeArchivedKeys hard-deletes all P2P keys and OCR key bundles that were
+// archived before the given time. Keys that are still referenced by a job
+// are kept.
+func (ks KeyStore) PurgeArchivedKeys(before time.Time) error {
+	var p2pKeys []p2pkey.EncryptedP2PKey
+	var ocrKeys []ocrkey.EncryptedKeyBundle
+	err := multierr.Combine(
+		ks.Unscoped().
+			Where("deleted_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM offchainreporting_oracle_specs WHERE offchainreporting_oracle_specs.p2p_peer_id = encrypted_p2p_keys.peer_id)").
+			Find(&p2pKeys).Error,
+		ks.Unscoped().
+			Where("deleted_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM offchainreporting_oracle_specs WHERE offchainreporting_oracle_specs.encrypted_ocr_key_bundle_id = encrypted_ocr_key_bundles.id)").
+			Find(&ocrKeys).Error,
+	)
+	if err != nil {
+		return errors.Wrap(err, "while loading archived keys")
+	}
+
+	var merr error
+	for i := range p2pKeys {
+		if err := ks.DeleteEncryptedP2PKey(&p2pKeys[i]); err != nil {
+			merr = multierr.Append(merr, errors.Wrapf(err, "while purging archived P2P key %s", p2pKeys[i].PeerID))
+			continue
+		}
+		logger.Infow("Purged archived P2P key", "peerID", p2pKeys[i].PeerID)
+	}
+	for i := range ocrKeys {
+		if err := ks.DeleteEncryptedOCRKeyBundle(&ocrKeys[i]); err != nil {
+			merr = multierr.Append(merr, errors.Wrapf(err, "while purging archived OCR key bundle %s", ocrKeys[i].ID))
+			continue
+		}
+		logger.Infow("Purged archived OCR key bundle", "id", ocrKeys[i].ID)
+	}
+	return merr
+}
+
 // ImportP2PKey imports a p2p key to the database
 func (ks KeyStore) ImportP2PKey(keyJSON []byte, oldPassword string) (*p2pkey.EncryptedP2PKey, error) {
 	ks.mu.Lock()
@@ -0,0 +1,37 @@
+package offchainreporting
+
+import (
+	"context"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/maintenance"
+)
+
+type maintenanceContractTransmitter struct {
+	ocrtypes.ContractTransmitter
+	maintenance *maintenance.Switch
+	jobID       int32
+}
+
+// NewMaintenanceContractTransmitter wraps a contract transmitter so that
+// transmissions are skipped while the node is in maintenance mode. Skipped
+// reports are left to the other oracles to transmit.
+func NewMaintenanceContractTransmitter(transmitter ocrtypes.ContractTransmitter, maintenance *maintenance.Switch, jobID int32) ocrtypes.ContractTransmitter {
+	return &maintenanceContractTransmitter{
+		ContractTransmitter: transmitter,
+		maintenance:         maintenance,
+		jobID:               jobID,
+	}
+}
+
+func (t *maintenanceContractTransmitter) Transmit(ctx context.Context, report []byte, rs, ss [][32]byte, vs [32]byte) error {
+	if t.maintenance.TransmissionsPaused() {
+		logger.Debugw("OCR: skipped transmission because the node is in maintenance mode",
+			"jobID", t.jobID,
+		)
+		return nil
+	}
+	return t.ContractTransmitter.Transmit(ctx, report, rs, ss, vs)
+}
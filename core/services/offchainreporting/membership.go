@@ -0,0 +1,149 @@
+package offchainreporting
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// ErrRemovedFromDON is returned by the observations of a job whose node is
+// not one of the oracles in the OCR contract's latest config
+var ErrRemovedFromDON = errors.New("this node is not one of the oracles in the OCR contract's latest config")
+
+var (
+	promOCRConfigChanges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ocr_contract_config_changes_total",
+		Help: "The number of times the config of an OCR job's contract has changed on-chain",
+	}, []string{"job_id"})
+	promOCRRemovedFromDON = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ocr_removed_from_don",
+		Help: "Whether an OCR job is paused because its node is not in the contract's latest config",
+	}, []string{"job_id"})
+)
+
+// DONMembership follows the configs set on an OCR job's contract, and
+// whether this node is still one of the oracles in them. libocr reloads the
+// config itself; DONMembership pauses the job's observations and
+// transmissions while the node has been removed from the DON, rather than
+// letting them fail on every round, and resumes them if it is added back.
+type DONMembership struct {
+	jobID       int32
+	transmitter gethCommon.Address
+	signer      gethCommon.Address
+	jobORM      job.ORM
+	logger      *logger.Logger
+
+	mu      sync.RWMutex
+	digest  ocrtypes.ConfigDigest
+	removed bool
+}
+
+// NewDONMembership returns a DONMembership for the oracle with the given
+// transmitter and on-chain signing addresses
+func NewDONMembership(jobID int32, transmitter, signer gethCommon.Address, jobORM job.ORM) *DONMembership {
+	return &DONMembership{
+		jobID:       jobID,
+		transmitter: transmitter,
+		signer:      signer,
+		jobORM:      jobORM,
+		logger:      logger.CreateLogger(logger.Default.With("jobID", jobID)),
+	}
+}
+
+// OnConfig is called with each config read from the contract. Configs that
+// have already been seen are ignored.
+func (m *DONMembership) OnConfig(cc ocrtypes.ContractConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cc.ConfigDigest == m.digest {
+		return
+	}
+	previous := m.digest
+	m.digest = cc.ConfigDigest
+	if previous != (ocrtypes.ConfigDigest{}) {
+		promOCRConfigChanges.WithLabelValues(fmt.Sprintf("%d", m.jobID)).Inc()
+		m.logger.Infow("OCR: contract config changed",
+			"previousConfigDigest", hex.EncodeToString(previous[:]),
+			"configDigest", hex.EncodeToString(cc.ConfigDigest[:]),
+		)
+	}
+
+	member := m.isMember(cc)
+	switch {
+	case !member && !m.removed:
+		m.removed = true
+		m.logger.Errorw("OCR: this node is not in the contract's latest config, pausing job",
+			"configDigest", hex.EncodeToString(cc.ConfigDigest[:]),
+			"transmitter", m.transmitter.Hex(),
+			"signer", m.signer.Hex(),
+		)
+		m.jobORM.RecordError(context.Background(), m.jobID, fmt.Sprintf(
+			"Paused: this node (transmitter %s, signer %s) was removed from the DON by contract config %s. The job will resume if the node is added back.",
+			m.transmitter.Hex(), m.signer.Hex(), hex.EncodeToString(cc.ConfigDigest[:])))
+	case member && m.removed:
+		m.removed = false
+		m.logger.Infow("OCR: this node was added back to the contract's config, resuming job",
+			"configDigest", hex.EncodeToString(cc.ConfigDigest[:]),
+		)
+	}
+	var removed float64
+	if m.removed {
+		removed = 1
+	}
+	promOCRRemovedFromDON.WithLabelValues(fmt.Sprintf("%d", m.jobID)).Set(removed)
+}
+
+// isMember reports whether this node is one of the oracles in the config,
+// with the same index as both signer and transmitter
+func (m *DONMembership) isMember(cc ocrtypes.ContractConfig) bool {
+	for i, signer := range cc.Signers {
+		if signer == m.signer {
+			return i < len(cc.Transmitters) && cc.Transmitters[i] == m.transmitter
+		}
+	}
+	return false
+}
+
+// Paused reports whether the node has been removed from the DON
+func (m *DONMembership) Paused() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.removed
+}
+
+type pausableTransmitter struct {
+	Transmitter
+	membership *DONMembership
+}
+
+// NewPausableTransmitter wraps a transmitter so that transmissions are
+// skipped while the node has been removed from the DON
+func NewPausableTransmitter(transmitter Transmitter, membership *DONMembership) Transmitter {
+	return &pausableTransmitter{
+		Transmitter: transmitter,
+		membership:  membership,
+	}
+}
+
+func (t *pausableTransmitter) CreateEthTransaction(ctx context.Context, toAddress gethCommon.Address, payload []byte) error {
+	if t.membership.Paused() {
+		logger.Debugw("OCR: skipped transmission because this node was removed from the DON",
+			"jobID", t.membership.jobID,
+			"fromAddress", t.FromAddress(),
+			"toAddress", toAddress,
+		)
+		return nil
+	}
+	return t.Transmitter.CreateEthTransaction(ctx, toAddress, payload)
+}
@@ -0,0 +1,102 @@
+package offchainreporting_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/job/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func TestDONMembership_OnConfig(t *testing.T) {
+	t.Parallel()
+
+	transmitter := cltest.NewAddress()
+	signer := cltest.NewAddress()
+	config := func(digest byte, member bool) ocrtypes.ContractConfig {
+		cc := ocrtypes.ContractConfig{
+			ConfigDigest: ocrtypes.ConfigDigest{digest},
+			Signers:      []common.Address{cltest.NewAddress()},
+			Transmitters: []common.Address{cltest.NewAddress()},
+		}
+		if member {
+			cc.Signers = append(cc.Signers, signer)
+			cc.Transmitters = append(cc.Transmitters, transmitter)
+		}
+		return cc
+	}
+
+	jobORM := new(mocks.ORM)
+	membership := offchainreporting.NewDONMembership(42, transmitter, signer, jobORM)
+
+	membership.OnConfig(config(1, true))
+	assert.False(t, membership.Paused())
+
+	jobORM.On("RecordError", mock.Anything, int32(42), mock.MatchedBy(func(description string) bool {
+		return assert.Contains(t, description, "was removed from the DON by contract config 02")
+	})).Once()
+	membership.OnConfig(config(2, false))
+	assert.True(t, membership.Paused())
+
+	// The same config delivered again, e.g. by polling, is ignored
+	membership.OnConfig(config(2, false))
+	assert.True(t, membership.Paused())
+
+	membership.OnConfig(config(3, true))
+	assert.False(t, membership.Paused())
+	jobORM.AssertExpectations(t)
+}
+
+func TestDONMembership_RequiresMatchingSignerAndTransmitter(t *testing.T) {
+	t.Parallel()
+
+	transmitter := cltest.NewAddress()
+	signer := cltest.NewAddress()
+	jobORM := new(mocks.ORM)
+	jobORM.On("RecordError", mock.Anything, int32(42), mock.Anything).Once()
+	membership := offchainreporting.NewDONMembership(42, transmitter, signer, jobORM)
+
+	// The node's signer is still in the config, but with another transmitter
+	membership.OnConfig(ocrtypes.ContractConfig{
+		ConfigDigest: ocrtypes.ConfigDigest{1},
+		Signers:      []common.Address{signer},
+		Transmitters: []common.Address{cltest.NewAddress()},
+	})
+	assert.True(t, membership.Paused())
+	jobORM.AssertExpectations(t)
+}
+
+func TestPausableTransmitter_CreateEthTransaction(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	db, err := store.DB.DB()
+	require.NoError(t, err)
+
+	key := cltest.MustInsertRandomKey(t, store.DB, 0)
+	signer := cltest.NewAddress()
+	jobORM := new(mocks.ORM)
+	jobORM.On("RecordError", mock.Anything, int32(42), mock.Anything).Once()
+	membership := offchainreporting.NewDONMembership(42, key.Address.Address(), signer, jobORM)
+	transmitter := offchainreporting.NewPausableTransmitter(offchainreporting.NewTransmitter(db, 0, key.Address.Address(), 1000, 0, nil), membership)
+
+	membership.OnConfig(ocrtypes.ContractConfig{ConfigDigest: ocrtypes.ConfigDigest{1}})
+	require.NoError(t, transmitter.CreateEthTransaction(context.Background(), cltest.NewAddress(), []byte{1}))
+	cltest.AssertCount(t, store, models.EthTx{}, 0)
+
+	membership.OnConfig(ocrtypes.ContractConfig{
+		ConfigDigest: ocrtypes.ConfigDigest{2},
+		Signers:      []common.Address{signer},
+		Transmitters: []common.Address{key.Address.Address()},
+	})
+	require.NoError(t, transmitter.CreateEthTransaction(context.Background(), cltest.NewAddress(), []byte{1}))
+	cltest.AssertCount(t, store, models.EthTx{}, 1)
+	jobORM.AssertExpectations(t)
+}
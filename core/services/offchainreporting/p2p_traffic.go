@@ -0,0 +1,247 @@
+package offchainreporting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+)
+
+var (
+	promP2PBytesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ocr_p2p_bytes_sent_total",
+		Help: "The total number of bytes of OCR protocol messages sent to each peer",
+	}, []string{"peer_id"})
+	promP2PBytesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ocr_p2p_bytes_received_total",
+		Help: "The total number of bytes of OCR protocol messages received from each peer",
+	}, []string{"peer_id"})
+	promP2PMessagesDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ocr_p2p_messages_dropped_total",
+		Help: "The total number of OCR protocol messages from each peer dropped for exceeding the bandwidth limits",
+	}, []string{"peer_id"})
+)
+
+type (
+	// meteredPeer wraps a peer so that the endpoints it makes report their
+	// traffic as metrics and enforce the bandwidth limits
+	meteredPeer struct {
+		peer
+		limiter *bandwidthLimiter
+	}
+
+	// meteredEndpoint counts the bytes sent to and received from each peer,
+	// and drops incoming messages from peers over their bandwidth limit.
+	// Outgoing messages are never dropped, so the node's own part in the
+	// protocol is unaffected by the limits.
+	meteredEndpoint struct {
+		ocrtypes.BinaryNetworkEndpoint
+		ownPeerID string
+		peerIDs   []string
+		limiter   *bandwidthLimiter
+
+		chReceive chan ocrtypes.BinaryMessageWithSender
+		chStop    chan struct{}
+		wg        sync.WaitGroup
+	}
+)
+
+func newMeteredPeer(p peer, limiter *bandwidthLimiter) *meteredPeer {
+	return &meteredPeer{p, limiter}
+}
+
+func (p *meteredPeer) MakeEndpoint(
+	cd ocrtypes.ConfigDigest,
+	peerIDs []string,
+	bootstrappers []string,
+	failureThreshold int,
+	tokenBucketRefillRate float64,
+	tokenBucketSize int,
+) (ocrtypes.BinaryNetworkEndpoint, error) {
+	endpoint, err := p.peer.MakeEndpoint(cd, peerIDs, bootstrappers, failureThreshold, tokenBucketRefillRate, tokenBucketSize)
+	if err != nil {
+		return nil, err
+	}
+	return newMeteredEndpoint(endpoint, p.PeerID(), peerIDs, p.limiter), nil
+}
+
+func newMeteredEndpoint(endpoint ocrtypes.BinaryNetworkEndpoint, ownPeerID string, peerIDs []string, limiter *bandwidthLimiter) *meteredEndpoint {
+	return &meteredEndpoint{
+		BinaryNetworkEndpoint: endpoint,
+		ownPeerID:             ownPeerID,
+		peerIDs:               peerIDs,
+		limiter:               limiter,
+		chReceive:             make(chan ocrtypes.BinaryMessageWithSender),
+		chStop:                make(chan struct{}),
+	}
+}
+
+func (e *meteredEndpoint) Start() error {
+	if err := e.BinaryNetworkEndpoint.Start(); err != nil {
+		return err
+	}
+	e.wg.Add(1)
+	go e.forwardReceived()
+	return nil
+}
+
+func (e *meteredEndpoint) Close() error {
+	close(e.chStop)
+	err := e.BinaryNetworkEndpoint.Close()
+	e.wg.Wait()
+	return err
+}
+
+func (e *meteredEndpoint) SendTo(payload []byte, to ocrtypes.OracleID) {
+	promP2PBytesSent.WithLabelValues(e.peerID(to)).Add(float64(len(payload)))
+	e.BinaryNetworkEndpoint.SendTo(payload, to)
+}
+
+func (e *meteredEndpoint) Broadcast(payload []byte) {
+	for _, peerID := range e.peerIDs {
+		if peerID != e.ownPeerID {
+			promP2PBytesSent.WithLabelValues(peerID).Add(float64(len(payload)))
+		}
+	}
+	e.BinaryNetworkEndpoint.Broadcast(payload)
+}
+
+func (e *meteredEndpoint) Receive() <-chan ocrtypes.BinaryMessageWithSender {
+	return e.chReceive
+}
+
+func (e *meteredEndpoint) forwardReceived() {
+	defer e.wg.Done()
+	chIncoming := e.BinaryNetworkEndpoint.Receive()
+	for {
+		select {
+		case msg, ok := <-chIncoming:
+			if !ok {
+				return
+			}
+			peerID := e.peerID(msg.Sender)
+			promP2PBytesReceived.WithLabelValues(peerID).Add(float64(len(msg.Msg)))
+			if !e.limiter.allow(peerID, len(msg.Msg), len(e.peerIDs)-1) {
+				promP2PMessagesDropped.WithLabelValues(peerID).Inc()
+				continue
+			}
+			select {
+			case e.chReceive <- msg:
+			case <-e.chStop:
+				return
+			}
+		case <-e.chStop:
+			return
+		}
+	}
+}
+
+func (e *meteredEndpoint) peerID(oid ocrtypes.OracleID) string {
+	if int(oid) < 0 || int(oid) >= len(e.peerIDs) {
+		return "unknown"
+	}
+	return e.peerIDs[oid]
+}
+
+type (
+	// bandwidthLimiter enforces the limits on bytes received per second,
+	// from each peer and from all peers together. When the global limit is
+	// reached, messages are still accepted from peers that are within their
+	// fair share of it, so that a noisy peer can't starve the others of the
+	// bandwidth needed for their observations.
+	bandwidthLimiter struct {
+		perPeer float64 // bytes per second, 0 for no limit
+		global  float64 // bytes per second, 0 for no limit
+		now     func() time.Time
+
+		mu    sync.Mutex
+		total byteBucket
+		peers map[string]*peerBuckets
+	}
+
+	peerBuckets struct {
+		limit byteBucket
+		share byteBucket
+	}
+
+	// byteBucket is a token bucket holding up to one second's worth of
+	// bytes. It may go into debt, so that messages larger than the bucket
+	// are let through on their own rather than never.
+	byteBucket struct {
+		tokens float64
+		last   time.Time
+	}
+)
+
+func newBandwidthLimiter(perPeer, global uint64, now func() time.Time) *bandwidthLimiter {
+	return &bandwidthLimiter{
+		perPeer: float64(perPeer),
+		global:  float64(global),
+		now:     now,
+		total:   byteBucket{tokens: float64(global), last: now()},
+		peers:   make(map[string]*peerBuckets),
+	}
+}
+
+// allow reports whether a message of n bytes from peerID, one of numPeers
+// peers in the sender's OCR network, is within the limits, and if so
+// charges it against them
+func (l *bandwidthLimiter) allow(peerID string, n int, numPeers int) bool {
+	if l.perPeer == 0 && l.global == 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	buckets, exists := l.peers[peerID]
+	if !exists {
+		buckets = &peerBuckets{
+			limit: byteBucket{tokens: l.perPeer, last: now},
+			share: byteBucket{tokens: l.fairShare(numPeers), last: now},
+		}
+		l.peers[peerID] = buckets
+	}
+	size := float64(n)
+
+	if l.perPeer > 0 && !buckets.limit.available(now, l.perPeer) {
+		return false
+	}
+	if l.global > 0 {
+		share := l.fairShare(numPeers)
+		withinShare := buckets.share.available(now, share)
+		if !l.total.available(now, l.global) && !withinShare {
+			return false
+		}
+		buckets.share.take(size)
+		l.total.take(size)
+	}
+	if l.perPeer > 0 {
+		buckets.limit.take(size)
+	}
+	return true
+}
+
+func (l *bandwidthLimiter) fairShare(numPeers int) float64 {
+	if numPeers < 1 {
+		numPeers = 1
+	}
+	return l.global / float64(numPeers)
+}
+
+// available refills the bucket at rate bytes per second and reports whether
+// it has any bytes left
+func (b *byteBucket) available(now time.Time, rate float64) bool {
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > rate {
+		b.tokens = rate
+	}
+	b.last = now
+	return b.tokens > 0
+}
+
+func (b *byteBucket) take(n float64) {
+	b.tokens -= n
+}
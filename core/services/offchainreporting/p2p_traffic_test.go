@@ -0,0 +1,96 @@
+package offchainreporting
+
+import (
+	"testing"
+	"time"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEndpoint struct {
+	chReceive chan ocrtypes.BinaryMessageWithSender
+	sent      []ocrtypes.OracleID
+	started   bool
+	closed    bool
+}
+
+func (e *fakeEndpoint) SendTo(payload []byte, to ocrtypes.OracleID) { e.sent = append(e.sent, to) }
+func (e *fakeEndpoint) Broadcast(payload []byte)                    {}
+func (e *fakeEndpoint) Receive() <-chan ocrtypes.BinaryMessageWithSender {
+	return e.chReceive
+}
+func (e *fakeEndpoint) Start() error { e.started = true; return nil }
+func (e *fakeEndpoint) Close() error { e.closed = true; return nil }
+
+func TestBandwidthLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+
+	t.Run("allows everything without limits", func(t *testing.T) {
+		l := newBandwidthLimiter(0, 0, clock)
+		for i := 0; i < 100; i++ {
+			assert.True(t, l.allow("a", 1<<20, 3))
+		}
+	})
+
+	t.Run("limits each peer separately", func(t *testing.T) {
+		l := newBandwidthLimiter(100, 0, clock)
+		assert.True(t, l.allow("a", 100, 3))
+		assert.False(t, l.allow("a", 1, 3))
+		assert.True(t, l.allow("b", 100, 3))
+
+		now = now.Add(time.Second)
+		assert.True(t, l.allow("a", 100, 3))
+	})
+
+	t.Run("lets large messages through on their own", func(t *testing.T) {
+		l := newBandwidthLimiter(100, 0, clock)
+		assert.True(t, l.allow("a", 1000, 3))
+		now = now.Add(time.Second)
+		assert.False(t, l.allow("a", 1, 3))
+		now = now.Add(9 * time.Second)
+		assert.True(t, l.allow("a", 1, 3))
+	})
+
+	t.Run("serves peers within their fair share when the global limit is reached", func(t *testing.T) {
+		l := newBandwidthLimiter(0, 300, clock)
+		// A noisy peer uses up the global limit, and then its own share of it
+		assert.True(t, l.allow("noisy", 300, 3))
+		assert.False(t, l.allow("noisy", 1, 3))
+		// The quiet peers still get their share
+		assert.True(t, l.allow("quiet1", 100, 3))
+		assert.True(t, l.allow("quiet2", 100, 3))
+		assert.False(t, l.allow("quiet1", 1, 3))
+	})
+}
+
+func TestMeteredEndpoint(t *testing.T) {
+	t.Parallel()
+
+	inner := &fakeEndpoint{chReceive: make(chan ocrtypes.BinaryMessageWithSender)}
+	now := time.Unix(0, 0)
+	limiter := newBandwidthLimiter(10, 0, func() time.Time { return now })
+	endpoint := newMeteredEndpoint(inner, "self", []string{"self", "other"}, limiter)
+	require.NoError(t, endpoint.Start())
+	assert.True(t, inner.started)
+
+	endpoint.SendTo([]byte{1}, 1)
+	assert.Equal(t, []ocrtypes.OracleID{1}, inner.sent)
+
+	// The second message is over the limit and is dropped, so the third is
+	// the next to be received
+	inner.chReceive <- ocrtypes.BinaryMessageWithSender{Msg: make([]byte, 10), Sender: 1}
+	msg := <-endpoint.Receive()
+	assert.Len(t, msg.Msg, 10)
+	inner.chReceive <- ocrtypes.BinaryMessageWithSender{Msg: make([]byte, 5), Sender: 1}
+	inner.chReceive <- ocrtypes.BinaryMessageWithSender{Msg: make([]byte, 1), Sender: 0}
+	msg = <-endpoint.Receive()
+	assert.Equal(t, ocrtypes.OracleID(0), msg.Sender)
+
+	require.NoError(t, endpoint.Close())
+	assert.True(t, inner.closed)
+}
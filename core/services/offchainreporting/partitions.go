@@ -0,0 +1,167 @@
+package offchainreporting
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// PartitionArchiveSchema is the schema that old partitions of the OCR state
+// tables are moved to once they are detached. Archived partitions are no
+// longer read or vacuumed by the node, and can be dumped and dropped by the
+// operator.
+const PartitionArchiveSchema = "ocr_archive"
+
+// partitionMaintenanceInterval is how often partitions are created and archived
+const partitionMaintenanceInterval = time.Hour
+
+// partitionedTable is an OCR table that is partitioned by month on a
+// timestamp column
+type partitionedTable struct {
+	name string
+	key  string
+}
+
+// partitionedTables are the high-churn OCR tables. Persistent states are
+// partitioned by updated_at, so only the state of configs that have not been
+// written to for a while is archived.
+var partitionedTables = []partitionedTable{
+	{"offchainreporting_persistent_states", "updated_at"},
+	{"offchainreporting_pending_transmissions", "time"},
+}
+
+// PartitionManager keeps the monthly partitions of the OCR state tables. It
+// creates each month's partitions ahead of time, and detaches partitions older
+// than the retention period into the archive schema.
+type PartitionManager struct {
+	utils.StartStopOnce
+
+	db        *gorm.DB
+	retention time.Duration
+	chStop    chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewPartitionManager returns a PartitionManager that archives partitions
+// once all of their rows are older than retention. A retention of 0 never
+// archives.
+func NewPartitionManager(db *gorm.DB, retention time.Duration) *PartitionManager {
+	return &PartitionManager{
+		db:        db,
+		retention: retention,
+		chStop:    make(chan struct{}),
+	}
+}
+
+// Start creates the partitions for this month and next before returning, so
+// that OCR jobs started after it never write to the default partition
+func (pm *PartitionManager) Start() error {
+	if !pm.OkayToStart() {
+		return errors.New("PartitionManager has already been started")
+	}
+	if err := pm.Maintain(time.Now()); err != nil {
+		return err
+	}
+	pm.wg.Add(1)
+	go pm.run()
+	return nil
+}
+
+func (pm *PartitionManager) Close() error {
+	if !pm.OkayToStop() {
+		return errors.New("PartitionManager has already been stopped")
+	}
+	close(pm.chStop)
+	pm.wg.Wait()
+	return nil
+}
+
+func (pm *PartitionManager) run() {
+	defer pm.wg.Done()
+	ticker := time.NewTicker(partitionMaintenanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pm.chStop:
+			return
+		case <-ticker.C:
+			if err := pm.Maintain(time.Now()); err != nil {
+				logger.Errorw("PartitionManager: failed to maintain OCR partitions", "err", err)
+			}
+		}
+	}
+}
+
+// Maintain creates the partitions for the month of now and the month after,
+// and archives partitions past the retention period
+func (pm *PartitionManager) Maintain(now time.Time) error {
+	for _, table := range partitionedTables {
+		for _, month := range []time.Time{now, now.AddDate(0, 1, 0)} {
+			err := pm.db.Exec(`SELECT ocr_create_monthly_partition(?, ?, ?)`, table.name, table.key, month).Error
+			if err != nil {
+				return errors.Wrapf(err, "could not create partition of %s", table.name)
+			}
+		}
+	}
+	if pm.retention == 0 {
+		return nil
+	}
+	archived, err := pm.ArchivePartitions(now.Add(-pm.retention))
+	if len(archived) > 0 {
+		logger.Infow("PartitionManager: archived OCR partitions", "partitions", archived, "schema", PartitionArchiveSchema)
+	}
+	return err
+}
+
+// ArchivePartitions detaches the monthly partitions that end before cutoff,
+// moving them to the archive schema. It returns the names of the archived
+// partitions.
+func (pm *PartitionManager) ArchivePartitions(cutoff time.Time) (archived []string, err error) {
+	for _, table := range partitionedTables {
+		var partitions []string
+		err = pm.db.Raw(`
+			SELECT child.relname FROM pg_inherits
+			JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+			JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+			WHERE parent.relname = ?
+		`, table.name).Scan(&partitions).Error
+		if err != nil {
+			return archived, errors.Wrapf(err, "could not list partitions of %s", table.name)
+		}
+		for _, partition := range partitions {
+			month, ok := partitionMonth(table.name, partition)
+			if !ok || month.AddDate(0, 1, 0).After(cutoff) {
+				continue
+			}
+			err = pm.db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s DETACH PARTITION %s`, table.name, partition)).Error; err != nil {
+					return err
+				}
+				return tx.Exec(fmt.Sprintf(`ALTER TABLE %s SET SCHEMA %s`, partition, PartitionArchiveSchema)).Error
+			})
+			if err != nil {
+				return archived, errors.Wrapf(err, "could not archive partition %s", partition)
+			}
+			archived = append(archived, partition)
+		}
+	}
+	return archived, nil
+}
+
+// partitionMonth parses the month from the name of a monthly partition, e.g.
+// offchainreporting_pending_transmissions_y2021m03
+func partitionMonth(table, partition string) (time.Time, bool) {
+	suffix := strings.TrimPrefix(partition, table+"_")
+	if suffix == partition {
+		return time.Time{}, false
+	}
+	month, err := time.Parse("y2006m01", suffix)
+	return month, err == nil
+}
@@ -0,0 +1,72 @@
+package offchainreporting_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PartitionManager(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	sqldb, _ := store.DB.DB()
+	key := cltest.MustInsertRandomKey(t, store.DB)
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, store, key.Address)
+	db := offchainreporting.NewDB(sqldb, spec.ID)
+	configDigest := cltest.MakeConfigDigest(t)
+	pm := offchainreporting.NewPartitionManager(store.DB, 0)
+
+	partitionExists := func(name string) bool {
+		var exists bool
+		require.NoError(t, store.DB.Raw(`SELECT to_regclass(?) IS NOT NULL`, name).Scan(&exists).Error)
+		return exists
+	}
+
+	t.Run("creates partitions for this month and next", func(t *testing.T) {
+		now := time.Date(2031, 12, 15, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, pm.Maintain(now))
+
+		require.True(t, partitionExists("offchainreporting_persistent_states_y2031m12"))
+		require.True(t, partitionExists("offchainreporting_persistent_states_y2032m01"))
+		require.True(t, partitionExists("offchainreporting_pending_transmissions_y2031m12"))
+		require.True(t, partitionExists("offchainreporting_pending_transmissions_y2032m01"))
+	})
+
+	t.Run("moves rows out of the default partition and archives old partitions", func(t *testing.T) {
+		k := ocrtypes.PendingTransmissionKey{ConfigDigest: configDigest, Epoch: 1, Round: 1}
+		p := ocrtypes.PendingTransmission{
+			Time:             time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC),
+			Median:           ocrtypes.Observation(big.NewInt(41)),
+			SerializedReport: []byte{0, 2, 3},
+			Rs:               [][32]byte{cltest.Random32Byte()},
+			Ss:               [][32]byte{cltest.Random32Byte()},
+			Vs:               cltest.Random32Byte(),
+		}
+		require.NoError(t, db.StorePendingTransmission(ctx, k, p))
+
+		require.NoError(t, pm.Maintain(p.Time))
+		var count int
+		require.NoError(t, store.DB.Raw(`SELECT COUNT(*) FROM offchainreporting_pending_transmissions_y1970m01`).Scan(&count).Error)
+		require.Equal(t, 1, count)
+
+		archived, err := pm.ArchivePartitions(time.Date(1970, 2, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			"offchainreporting_persistent_states_y1970m01",
+			"offchainreporting_pending_transmissions_y1970m01",
+		}, archived)
+
+		m, err := db.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+		require.NoError(t, err)
+		require.Len(t, m, 0)
+		require.NoError(t, store.DB.Raw(`SELECT COUNT(*) FROM ocr_archive.offchainreporting_pending_transmissions_y1970m01`).Scan(&count).Error)
+		require.Equal(t, 1, count)
+		require.True(t, partitionExists("offchainreporting_pending_transmissions_y1970m02"))
+	})
+}
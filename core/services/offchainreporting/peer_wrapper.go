@@ -3,7 +3,11 @@ package offchainreporting
 import (
 	"strings"
 	"sync"
+	"time"
 
+	p2ppeer "github.com/libp2p/go-libp2p-core/peer"
+	p2ppeerstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -118,7 +122,7 @@ func (p *SingletonPeerWrapper) Start() (err error) {
 
 	peerLogger := NewLogger(logger.Default, p.config.OCRTraceLogging(), func(string) {})
 
-	p.Peer, err = ocrnetworking.NewPeer(ocrnetworking.PeerConfig{
+	ocrPeer, err := ocrnetworking.NewPeer(ocrnetworking.PeerConfig{
 		PrivKey:      key.PrivKey,
 		ListenIP:     p.config.P2PListenIP(),
 		ListenPort:   listenPort,
@@ -138,9 +142,48 @@ func (p *SingletonPeerWrapper) Start() (err error) {
 	if err != nil {
 		return errors.Wrap(err, "error calling NewPeer")
 	}
+	limiter := newBandwidthLimiter(p.config.P2PPeerBandwidthLimit(), p.config.P2PBandwidthLimit(), time.Now)
+	p.Peer = newMeteredPeer(ocrPeer, limiter)
+	if p.config.P2PDiscoveryMode() == orm.P2PDiscoveryModeExplicit {
+		if peers, err := p.config.P2PPeers(nil); err == nil {
+			if err := p.addPeers(peers); err != nil {
+				return errors.Wrap(err, "could not add P2P_PEERS to the peer table")
+			}
+		}
+	}
 	return p.pstoreWrapper.Start()
 }
 
+// AddPeers adds peers to the peer table, given as multiaddrs that end in the
+// /p2p/ ID of the peer. Their addresses never expire, so they are dialled
+// directly without being looked up in the DHT.
+func (p *SingletonPeerWrapper) AddPeers(addrs []string) error {
+	p.startMu.Lock()
+	defer p.startMu.Unlock()
+	return p.addPeers(addrs)
+}
+
+func (p *SingletonPeerWrapper) addPeers(addrs []string) error {
+	if p.pstoreWrapper == nil {
+		return errors.New("peer wrapper has no peerstore")
+	}
+	for _, addr := range addrs {
+		m, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid peer address %s", addr)
+		}
+		info, err := p2ppeer.AddrInfoFromP2pAddr(m)
+		if err != nil {
+			return errors.Wrapf(err, "invalid peer address %s", addr)
+		}
+		if models.PeerID(info.ID) == p.PeerID {
+			continue
+		}
+		p.pstoreWrapper.Peerstore.AddAddrs(info.ID, info.Addrs, p2ppeerstore.PermanentAddrTTL)
+	}
+	return nil
+}
+
 // Close closes the peer and peerstore
 func (p SingletonPeerWrapper) Close() (err error) {
 	p.startMu.Lock()
@@ -0,0 +1,58 @@
+package offchainreporting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting/confighelper"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// TransmissionDeadline is how long after an OCR transmission is queued it
+// must be mined, before the report of a later round supersedes it. It is the
+// job's transmissionDeadline if that is set, and otherwise the deltaRound of
+// the contract's latest config, which is how often the leader starts a new
+// round. Until a config has been read there is no deadline.
+type TransmissionDeadline struct {
+	jobID    int32
+	override time.Duration
+
+	mu         sync.RWMutex
+	fromConfig time.Duration
+}
+
+// NewTransmissionDeadline returns the TransmissionDeadline of a job, which is
+// override if it is not 0
+func NewTransmissionDeadline(jobID int32, override time.Duration) *TransmissionDeadline {
+	return &TransmissionDeadline{jobID: jobID, override: override}
+}
+
+// OnConfig is called with each config read from the contract
+func (d *TransmissionDeadline) OnConfig(cc ocrtypes.ContractConfig) {
+	if d.override > 0 {
+		return
+	}
+	pc, err := confighelper.PublicConfigFromContractConfig(cc)
+	if err != nil {
+		logger.Warnw("OCR: could not decode contract config, keeping the previous transmission deadline", "jobID", d.jobID, "error", err)
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fromConfig = pc.DeltaRound
+}
+
+// Duration returns the current deadline, or 0 if there is none
+func (d *TransmissionDeadline) Duration() time.Duration {
+	if d == nil {
+		return 0
+	}
+	if d.override > 0 {
+		return d.override
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.fromConfig
+}
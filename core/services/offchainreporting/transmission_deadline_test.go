@@ -0,0 +1,74 @@
+package offchainreporting_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/libocr/offchainreporting/confighelper"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+)
+
+func TestTransmissionDeadline(t *testing.T) {
+	t.Parallel()
+
+	var oracles []confighelper.OracleIdentityExtra
+	for i := 1; i <= 4; i++ {
+		oracles = append(oracles, confighelper.OracleIdentityExtra{
+			OracleIdentity: confighelper.OracleIdentity{
+				OnChainSigningAddress: ocrtypes.OnChainSigningAddress(common.BigToAddress(big.NewInt(int64(i)))),
+				TransmitAddress:       common.BigToAddress(big.NewInt(int64(10 + i))),
+			},
+			SharedSecretEncryptionPublicKey: sharedSecretEncryptionPublicKey(t),
+		})
+	}
+	signers, transmitters, threshold, encodedConfigVersion, encodedConfig, err := confighelper.ContractSetConfigArgsForIntegrationTest(oracles, 1, 1000000000/100)
+	require.NoError(t, err)
+	cc := ocrtypes.ContractConfig{
+		ConfigDigest:         ocrtypes.ConfigDigest{1},
+		Signers:              signers,
+		Transmitters:         transmitters,
+		Threshold:            threshold,
+		EncodedConfigVersion: encodedConfigVersion,
+		Encoded:              encodedConfig,
+	}
+
+	t.Run("has no deadline until a config has been read", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), offchainreporting.NewTransmissionDeadline(1, 0).Duration())
+		var deadline *offchainreporting.TransmissionDeadline
+		assert.Equal(t, time.Duration(0), deadline.Duration())
+	})
+
+	t.Run("is the deltaRound of the contract's config", func(t *testing.T) {
+		deadline := offchainreporting.NewTransmissionDeadline(1, 0)
+		deadline.OnConfig(cc)
+		assert.Equal(t, time.Second, deadline.Duration())
+	})
+
+	t.Run("keeps the previous deadline if the config can't be decoded", func(t *testing.T) {
+		deadline := offchainreporting.NewTransmissionDeadline(1, 0)
+		deadline.OnConfig(cc)
+		deadline.OnConfig(ocrtypes.ContractConfig{Encoded: []byte("garbage")})
+		assert.Equal(t, time.Second, deadline.Duration())
+	})
+
+	t.Run("is the job's transmissionDeadline if it is set", func(t *testing.T) {
+		deadline := offchainreporting.NewTransmissionDeadline(1, time.Minute)
+		deadline.OnConfig(cc)
+		assert.Equal(t, time.Minute, deadline.Duration())
+	})
+}
+
+func sharedSecretEncryptionPublicKey(t *testing.T) ocrtypes.SharedSecretEncryptionPublicKey {
+	pk, err := curve25519.X25519(make([]byte, 32), curve25519.Basepoint)
+	require.NoError(t, err)
+	var key ocrtypes.SharedSecretEncryptionPublicKey
+	copy(key[:], pk)
+	return key
+}
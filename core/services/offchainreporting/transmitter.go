@@ -4,27 +4,51 @@ import (
 	"context"
 	"database/sql"
 	"encoding/hex"
+	"fmt"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
+// forwarderABI is the subset of the operator forwarder contract's ABI used
+// to relay transmissions
+var forwarderABI = eth.MustGetABI(`[{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"forward","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+
+var promDryRunTransmissions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ocr_dry_run_transmissions_total",
+	Help: "The total number of OCR transmissions skipped by jobs with transmitDisabled set",
+}, []string{"job_id"})
+
 type transmitter struct {
 	db                         *sql.DB
+	jobID                      int32
 	fromAddress                gethCommon.Address
 	gasLimit                   uint64
 	maxUnconfirmedTransactions uint64
+	deadline                   *TransmissionDeadline
 }
 
-// NewTransmitter creates a new eth transmitter
-func NewTransmitter(sqldb *sql.DB, fromAddress gethCommon.Address, gasLimit, maxUnconfirmedTransactions uint64) Transmitter {
+// NewTransmitter creates a new eth transmitter. The gas spent by its
+// transactions is counted in the costs of the job with jobID, if it is not 0.
+// Each transaction must be mined within the deadline current when it is
+// queued, if there is one, and has its gas bumped more aggressively as the
+// deadline approaches. A nil deadline queues transactions without one.
+func NewTransmitter(sqldb *sql.DB, jobID int32, fromAddress gethCommon.Address, gasLimit, maxUnconfirmedTransactions uint64, deadline *TransmissionDeadline) Transmitter {
 	return &transmitter{
 		db:                         sqldb,
+		jobID:                      jobID,
 		fromAddress:                fromAddress,
 		gasLimit:                   gasLimit,
 		maxUnconfirmedTransactions: maxUnconfirmedTransactions,
+		deadline:                   deadline,
 	}
 }
 
@@ -35,9 +59,14 @@ func (t *transmitter) CreateEthTransaction(ctx context.Context, toAddress gethCo
 	}
 
 	value := 0
+	var deadline *time.Time
+	if duration := t.deadline.Duration(); duration > 0 {
+		d := time.Now().Add(duration)
+		deadline = &d
+	}
 	res, err := t.db.ExecContext(ctx, `
-INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at)
-SELECT $1,$2,$3,$4,$5,'unstarted',NOW()
+INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, job_id, deadline, state, created_at)
+SELECT $1,$2,$3,$4,$5,NULLIF($6, 0),$7,'unstarted',NOW()
 WHERE NOT EXISTS (
     SELECT 1 FROM eth_tx_attempts
 	JOIN eth_txes ON eth_txes.id = eth_tx_attempts.eth_tx_id
@@ -45,7 +74,7 @@ WHERE NOT EXISTS (
 		AND eth_txes.state = 'unconfirmed'
 		AND eth_tx_attempts.state = 'insufficient_eth'
 );
-`, t.fromAddress, toAddress, payload, value, t.gasLimit)
+`, t.fromAddress, toAddress, payload, value, t.gasLimit, t.jobID, deadline)
 	if err != nil {
 		return errors.Wrap(err, "transmitter failed to insert eth_tx")
 	}
@@ -71,3 +100,81 @@ WHERE NOT EXISTS (
 func (t *transmitter) FromAddress() gethCommon.Address {
 	return t.fromAddress
 }
+
+type forwardingTransmitter struct {
+	Transmitter
+	forwarderAddress gethCommon.Address
+}
+
+// NewForwardingTransmitter wraps a transmitter so that transmissions are
+// relayed through the given forwarder contract. The forwarder, rather than
+// the sending key, is the transmitter address seen by the OCR contract.
+func NewForwardingTransmitter(transmitter Transmitter, forwarderAddress gethCommon.Address) Transmitter {
+	return &forwardingTransmitter{
+		Transmitter:      transmitter,
+		forwarderAddress: forwarderAddress,
+	}
+}
+
+func (t *forwardingTransmitter) CreateEthTransaction(ctx context.Context, toAddress gethCommon.Address, payload []byte) error {
+	forwardedPayload, err := forwarderABI.Pack("forward", toAddress, payload)
+	if err != nil {
+		return errors.Wrap(err, "forwardingTransmitter failed to encode forwarded payload")
+	}
+	return t.Transmitter.CreateEthTransaction(ctx, t.forwarderAddress, forwardedPayload)
+}
+
+func (t *forwardingTransmitter) FromAddress() gethCommon.Address {
+	return t.forwarderAddress
+}
+
+type dryRunTransmitter struct {
+	Transmitter
+	jobID int32
+}
+
+// NewDryRunTransmitter wraps a transmitter so that transmissions are logged
+// and counted but never sent. This lets a new feed be soak tested on a
+// production node, taking part in the protocol without spending any gas.
+func NewDryRunTransmitter(transmitter Transmitter, jobID int32) Transmitter {
+	return &dryRunTransmitter{
+		Transmitter: transmitter,
+		jobID:       jobID,
+	}
+}
+
+func (t *dryRunTransmitter) CreateEthTransaction(ctx context.Context, toAddress gethCommon.Address, payload []byte) error {
+	promDryRunTransmissions.WithLabelValues(fmt.Sprintf("%d", t.jobID)).Inc()
+	logger.Infow("OCR: skipped transmission because transmitDisabled is set",
+		"jobID", t.jobID,
+		"fromAddress", t.FromAddress(),
+		"toAddress", toAddress,
+		"payload", "0x"+hex.EncodeToString(payload),
+	)
+	return nil
+}
+
+type gasCostGuardedTransmitter struct {
+	Transmitter
+	guard    *job.GasCostGuard
+	gasLimit uint64
+}
+
+// NewGasCostGuardedTransmitter wraps a transmitter so that transmissions are
+// skipped while their estimated cost exceeds the job's maxGasCostWei
+func NewGasCostGuardedTransmitter(transmitter Transmitter, guard *job.GasCostGuard, gasLimit uint64) Transmitter {
+	return &gasCostGuardedTransmitter{
+		Transmitter: transmitter,
+		guard:       guard,
+		gasLimit:    gasLimit,
+	}
+}
+
+func (t *gasCostGuardedTransmitter) CreateEthTransaction(ctx context.Context, toAddress gethCommon.Address, payload []byte) error {
+	msg := ethereum.CallMsg{From: t.FromAddress(), To: &toAddress, Gas: t.gasLimit, Data: payload}
+	if err := t.guard.Check(ctx, msg); err != nil {
+		// The guard has logged and recorded the skipped transmission
+		return nil
+	}
+	return t.Transmitter.CreateEthTransaction(ctx, toAddress, payload)
+}
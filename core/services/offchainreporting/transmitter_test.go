@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/stretchr/testify/require"
@@ -25,7 +27,7 @@ func Test_Transmitter_CreateEthTransaction(t *testing.T) {
 	toAddress := cltest.NewAddress()
 	payload := []byte{1, 2, 3}
 
-	transmitter := offchainreporting.NewTransmitter(db, fromAddress, gasLimit, 0)
+	transmitter := offchainreporting.NewTransmitter(db, 0, fromAddress, gasLimit, 0, nil)
 
 	require.NoError(t, transmitter.CreateEthTransaction(context.Background(), toAddress, payload))
 
@@ -37,6 +39,26 @@ func Test_Transmitter_CreateEthTransaction(t *testing.T) {
 	require.Equal(t, toAddress, etx.ToAddress)
 	require.Equal(t, payload, etx.EncodedPayload)
 	require.Equal(t, assets.NewEthValue(0), etx.Value)
+	require.Nil(t, etx.Deadline)
+}
+
+func Test_Transmitter_CreateEthTransaction_Deadline(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	db, _ := store.DB.DB()
+
+	key := cltest.MustInsertRandomKey(t, store.DB, 0)
+
+	transmitter := offchainreporting.NewTransmitter(db, 0, key.Address.Address(), 1000, 0, offchainreporting.NewTransmissionDeadline(0, time.Minute))
+
+	queuedAt := time.Now()
+	require.NoError(t, transmitter.CreateEthTransaction(context.Background(), cltest.NewAddress(), []byte{1, 2, 3}))
+
+	etx := models.EthTx{}
+	require.NoError(t, store.ORM.DB.First(&etx).Error)
+	require.NotNil(t, etx.Deadline)
+	require.WithinDuration(t, queuedAt.Add(time.Minute), *etx.Deadline, 5*time.Second)
 }
 
 func Test_Transmitter_CreateEthTransaction_OutOfEth(t *testing.T) {
@@ -51,7 +73,7 @@ func Test_Transmitter_CreateEthTransaction_OutOfEth(t *testing.T) {
 	gasLimit := uint64(1000)
 	toAddress := cltest.NewAddress()
 
-	transmitter := offchainreporting.NewTransmitter(db, thisKey.Address.Address(), gasLimit, 0)
+	transmitter := offchainreporting.NewTransmitter(db, 0, thisKey.Address.Address(), gasLimit, 0, nil)
 
 	t.Run("if another key has any transactions with insufficient eth errors, transmits as normal", func(t *testing.T) {
 		payload := cltest.MustRandomBytes(t, 100)
@@ -86,3 +108,58 @@ func Test_Transmitter_CreateEthTransaction_OutOfEth(t *testing.T) {
 		require.Equal(t, payload, etx.EncodedPayload)
 	})
 }
+
+func Test_ForwardingTransmitter_CreateEthTransaction(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	db, _ := store.DB.DB()
+
+	key := cltest.MustInsertRandomKey(t, store.DB, 0)
+
+	fromAddress := key.Address.Address()
+	forwarderAddress := cltest.NewAddress()
+	toAddress := cltest.NewAddress()
+	payload := []byte{1, 2, 3}
+
+	transmitter := offchainreporting.NewForwardingTransmitter(
+		offchainreporting.NewTransmitter(db, 0, fromAddress, uint64(1000), 0, nil),
+		forwarderAddress,
+	)
+	require.Equal(t, forwarderAddress, transmitter.FromAddress())
+
+	require.NoError(t, transmitter.CreateEthTransaction(context.Background(), toAddress, payload))
+
+	etx := models.EthTx{}
+	require.NoError(t, store.ORM.DB.First(&etx).Error)
+
+	require.Equal(t, fromAddress, etx.FromAddress)
+	require.Equal(t, forwarderAddress, etx.ToAddress)
+
+	forwardABI := eth.MustGetABI(`[{"inputs":[{"name":"to","type":"address"},{"name":"data","type":"bytes"}],"name":"forward","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+	expected, err := forwardABI.Pack("forward", toAddress, payload)
+	require.NoError(t, err)
+	require.Equal(t, expected, etx.EncodedPayload)
+}
+
+func Test_DryRunTransmitter_CreateEthTransaction(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	db, _ := store.DB.DB()
+
+	key := cltest.MustInsertRandomKey(t, store.DB, 0)
+
+	fromAddress := key.Address.Address()
+	transmitter := offchainreporting.NewDryRunTransmitter(
+		offchainreporting.NewTransmitter(db, 0, fromAddress, uint64(1000), 0, nil),
+		1,
+	)
+	require.Equal(t, fromAddress, transmitter.FromAddress())
+
+	require.NoError(t, transmitter.CreateEthTransaction(context.Background(), cltest.NewAddress(), []byte{1, 2, 3}))
+
+	count, err := store.ORM.CountOf(&models.EthTx{})
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
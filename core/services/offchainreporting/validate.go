@@ -1,6 +1,7 @@
 package offchainreporting
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/multiformats/go-multiaddr"
@@ -24,6 +25,11 @@ func ValidatedOracleSpecToml(config *orm.Config, tomlString string) (job.Job, er
 	if err != nil {
 		return jb, errors.Wrap(err, "toml error on load")
 	}
+	if config.JobSpecStrictTOML() {
+		if err = job.CheckUnknownKeys(tree, job.OffchainReporting); err != nil {
+			return jb, err
+		}
+	}
 	// Note this validates all the fields which implement an UnmarshalText
 	// i.e. TransmitterAddress, PeerID...
 	err = tree.Unmarshal(&spec)
@@ -36,7 +42,6 @@ func ValidatedOracleSpecToml(config *orm.Config, tomlString string) (job.Job, er
 	}
 	jb.OffchainreportingOracleSpec = &spec
 
-	// TODO(#175801426): upstream a way to check for undecoded keys in go-toml
 	// TODO(#175801038): upstream support for time.Duration defaults in go-toml
 	if jb.Type != job.OffchainReporting {
 		return jb, errors.Errorf("the only supported type is currently 'offchainreporting', got %s", jb.Type)
@@ -52,6 +57,21 @@ func ValidatedOracleSpecToml(config *orm.Config, tomlString string) (job.Job, er
 			return jb, errors.Wrapf(err, "p2p bootstrap peer %v is invalid", spec.P2PBootstrapPeers[i])
 		}
 	}
+	for i := range spec.P2PPeers {
+		if err := orm.ValidateP2PPeer(spec.P2PPeers[i]); err != nil {
+			return jb, errors.Wrapf(err, "p2p peer %v is invalid", spec.P2PPeers[i])
+		}
+	}
+	if spec.MonitoringEndpoint != "" {
+		if _, err := url.Parse(spec.MonitoringEndpoint); err != nil {
+			return jb, errors.Wrapf(err, "monitoring endpoint %v is invalid", spec.MonitoringEndpoint)
+		}
+	}
+	if config.P2PDiscoveryMode() == orm.P2PDiscoveryModeExplicit {
+		if peers, err := config.P2PPeers(spec.P2PPeers); err != nil || len(peers) == 0 {
+			return jb, errors.New("p2pPeers must be set in the job spec or with P2P_PEERS when P2P_DISCOVERY_MODE is explicit")
+		}
+	}
 	if spec.IsBootstrapPeer {
 		if err := validateBootstrapSpec(tree, jb); err != nil {
 			return jb, err
@@ -115,6 +135,21 @@ func validateBootstrapSpec(tree *toml.Tree, spec job.Job) error {
 	if err := validateExplicitlySetKeys(tree, expected, notExpected, "bootstrap"); err != nil {
 		return err
 	}
+	if spec.OffchainreportingOracleSpec.ForwarderAddress != nil {
+		return errors.New("bootstrap peers do not transmit and cannot use a forwarder")
+	}
+	if spec.OffchainreportingOracleSpec.Decimals != nil {
+		return errors.New("bootstrap peers do not make observations and cannot set decimals")
+	}
+	if spec.OffchainreportingOracleSpec.TransmitDisabled {
+		return errors.New("bootstrap peers do not transmit and cannot set transmitDisabled")
+	}
+	if spec.OffchainreportingOracleSpec.LatencyBudget {
+		return errors.New("bootstrap peers do not make observations and cannot set latencyBudget")
+	}
+	if spec.OffchainreportingOracleSpec.TransmissionDeadline != 0 {
+		return errors.New("bootstrap peers do not transmit and cannot set transmissionDeadline")
+	}
 	return nil
 }
 
@@ -142,6 +177,14 @@ func validateNonBootstrapSpec(tree *toml.Tree, config *orm.Config, spec job.Job)
 		if set && timeout > observationTimeout {
 			return errors.Errorf("individual max task duration must be < observation timeout")
 		}
+		// When decimals is set the observation is scaled by the data source,
+		// so scaling it in the pipeline as well would be off by that factor.
+		if spec.OffchainreportingOracleSpec.Decimals != nil {
+			switch task.Type() {
+			case pipeline.TaskTypeMultiply, pipeline.TaskTypeScale:
+				return errors.Errorf("task %s of type %s cannot be used when decimals is set, as the observation is already scaled by 10^decimals", task.DotID(), task.Type())
+			}
+		}
 	}
 	return nil
 }
@@ -315,6 +315,237 @@ answer1      [type=median index=0];
 				c.Set("OCR_OBSERVATION_TIMEOUT", "20m")
 			},
 		},
+		{
+			name: "unknown key",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = false
+observationTimout  = "10s"
+observationSource = """
+ds1          [type=bridge name=voter_turnout];
+ds1_parse    [type=jsonparse path="one,two"];
+ds1_multiply [type=multiply times=1.23];
+ds1 -> ds1_parse -> ds1_multiply -> answer1;
+answer1      [type=median index=0];
+"""
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), `unrecognised key "observationTimout"`)
+			},
+		},
+		{
+			name: "unknown key with strict parsing disabled",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = false
+observationTimout  = "10s"
+observationSource = """
+ds1          [type=bridge name=voter_turnout];
+ds1_parse    [type=jsonparse path="one,two"];
+ds1_multiply [type=multiply times=1.23];
+ds1 -> ds1_parse -> ds1_multiply -> answer1;
+answer1      [type=median index=0];
+"""
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+			},
+			setGlobals: func(t *testing.T, c *orm.Config) {
+				c.Set("JOB_SPEC_STRICT_TOML", false)
+			},
+		},
+		{
+			name: "decimals",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = false
+decimals           = 8
+observationSource = """
+ds1          [type=bridge name=voter_turnout];
+ds1_parse    [type=jsonparse path="one,two"];
+ds1 -> ds1_parse -> answer1;
+answer1      [type=median index=0];
+"""
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, os.OffchainreportingOracleSpec.Decimals)
+				assert.Equal(t, uint8(8), *os.OffchainreportingOracleSpec.Decimals)
+			},
+		},
+		{
+			name: "decimals with scaling in the pipeline",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = false
+decimals           = 8
+observationSource = """
+ds1          [type=bridge name=voter_turnout];
+ds1_parse    [type=jsonparse path="one,two"];
+ds1_multiply [type=multiply times=100000000];
+ds1 -> ds1_parse -> ds1_multiply -> answer1;
+answer1      [type=median index=0];
+"""
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "cannot be used when decimals is set")
+			},
+		},
+		{
+			name: "decimals on a bootstrap peer",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = true
+decimals           = 8
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "transmit disabled",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = false
+transmitDisabled   = true
+observationSource = """
+ds1          [type=bridge name=voter_turnout];
+ds1 -> answer1;
+answer1      [type=median index=0];
+"""
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				assert.True(t, os.OffchainreportingOracleSpec.TransmitDisabled)
+			},
+		},
+		{
+			name: "transmit disabled on a bootstrap peer",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = true
+transmitDisabled   = true
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, "bootstrap peers do not transmit and cannot set transmitDisabled")
+			},
+		},
+		{
+			name: "latency budget",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = false
+latencyBudget      = true
+observationSource = """
+ds1          [type=bridge name=voter_turnout];
+ds1 -> answer1;
+answer1      [type=median index=0];
+"""
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				assert.True(t, os.OffchainreportingOracleSpec.LatencyBudget)
+			},
+		},
+		{
+			name: "latency budget on a bootstrap peer",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = true
+latencyBudget      = true
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, "bootstrap peers do not make observations and cannot set latencyBudget")
+			},
+		},
+		{
+			name: "negative decimals",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = false
+decimals           = -1
+observationSource = """
+ds1          [type=bridge name=voter_turnout];
+ds1 -> answer1;
+answer1      [type=median index=0];
+"""
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.Error(t, err)
+			},
+		},
+
+		{
+			name: "p2p peers in explicit discovery mode",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = true
+p2pPeers           = [
+"/ip4/10.0.0.1/tcp/1234/p2p/16Uiu2HAm58SP7UL8zsnpeuwHfytLocaqgnyaYKP8wu7qRdrixLju",
+]
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				assert.Len(t, os.OffchainreportingOracleSpec.P2PPeers, 1)
+			},
+			setGlobals: func(t *testing.T, c *orm.Config) {
+				c.Set("P2P_DISCOVERY_MODE", "explicit")
+			},
+		},
+		{
+			name: "p2p peer without a peer ID",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = true
+p2pPeers           = ["/ip4/10.0.0.1/tcp/1234"]
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "does not include a /p2p/ peer ID")
+			},
+		},
+		{
+			name: "no p2p peers in explicit discovery mode",
+			toml: `
+type               = "offchainreporting"
+schemaVersion      = 1
+contractAddress    = "0x613a38AC1659769640aaE063C651F48E0250454C"
+isBootstrapPeer    = true
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "p2pPeers must be set")
+			},
+			setGlobals: func(t *testing.T, c *orm.Config) {
+				c.Set("P2P_DISCOVERY_MODE", "explicit")
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -0,0 +1,127 @@
+package oncomplete
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+)
+
+// AvroSchema is the schema of the results published to queues in the avro
+// format. Outputs holds the JSON of the run's outputs, as they have no fixed
+// type.
+const AvroSchema = `{
+  "type": "record",
+  "name": "RunResult",
+  "namespace": "com.chainlink.node",
+  "fields": [
+    {"name": "jobID", "type": "int"},
+    {"name": "jobName", "type": ["null", "string"]},
+    {"name": "runID", "type": "long"},
+    {"name": "status", "type": "string"},
+    {"name": "outputs", "type": "string"},
+    {"name": "errors", "type": {"type": "array", "items": ["null", "string"]}},
+    {"name": "createdAt", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+    {"name": "finishedAt", "type": {"type": "long", "logicalType": "timestamp-micros"}}
+  ]
+}`
+
+// avroCanonicalSchema is the Parsing Canonical Form of AvroSchema, which its
+// fingerprint is taken of
+const avroCanonicalSchema = `{"name":"com.chainlink.node.RunResult","type":"record","fields":[` +
+	`{"name":"jobID","type":"int"},` +
+	`{"name":"jobName","type":["null","string"]},` +
+	`{"name":"runID","type":"long"},` +
+	`{"name":"status","type":"string"},` +
+	`{"name":"outputs","type":"string"},` +
+	`{"name":"errors","type":{"type":"array","items":["null","string"]}},` +
+	`{"name":"createdAt","type":"long"},` +
+	`{"name":"finishedAt","type":"long"}]}`
+
+// AvroSchemaFingerprint is the CRC-64-AVRO fingerprint of AvroSchema, that
+// identifies the schema in the header of each message
+var AvroSchemaFingerprint = AvroFingerprint([]byte(avroCanonicalSchema))
+
+const avroEmptyFingerprint uint64 = 0xc15d213aa4d7a795
+
+var avroFingerprintTable = func() (table [256]uint64) {
+	for i := range table {
+		fp := uint64(i)
+		for j := 0; j < 8; j++ {
+			fp = (fp >> 1) ^ (avroEmptyFingerprint & -(fp & 1))
+		}
+		table[i] = fp
+	}
+	return table
+}()
+
+// AvroFingerprint returns the CRC-64-AVRO fingerprint of a schema in Parsing
+// Canonical Form
+func AvroFingerprint(canonicalSchema []byte) uint64 {
+	fp := avroEmptyFingerprint
+	for _, b := range canonicalSchema {
+		fp = (fp >> 8) ^ avroFingerprintTable[byte(fp)^b]
+	}
+	return fp
+}
+
+// EncodeAvro encodes payload with AvroSchema, in Avro's single object
+// encoding: the marker 0xC3 0x01, the schema's fingerprint in little endian
+// and the binary encoding of payload
+func EncodeAvro(payload Payload) ([]byte, error) {
+	outputs, err := json.Marshal(payload.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	e := avroEncoder{buf: []byte{0xC3, 0x01}}
+	e.buf = append(e.buf, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(e.buf[2:], AvroSchemaFingerprint)
+
+	e.long(int64(payload.JobID))
+	if payload.JobName.Valid {
+		e.long(1)
+		e.string(payload.JobName.String)
+	} else {
+		e.long(0)
+	}
+	e.long(payload.RunID)
+	e.string(string(payload.Status))
+	e.string(string(outputs))
+	if len(payload.Errors) > 0 {
+		e.long(int64(len(payload.Errors)))
+		for _, runErr := range payload.Errors {
+			if runErr.Valid {
+				e.long(1)
+				e.string(runErr.String)
+			} else {
+				e.long(0)
+			}
+		}
+	}
+	e.long(0)
+	e.long(unixMicro(payload.CreatedAt))
+	e.long(unixMicro(payload.FinishedAt))
+	return e.buf, nil
+}
+
+// unixMicro returns t in microseconds since the epoch, which, unlike
+// UnixNano, is defined for the zero time
+func unixMicro(t time.Time) int64 {
+	return t.Unix()*1e6 + int64(t.Nanosecond()/1e3)
+}
+
+// avroEncoder appends values in Avro's binary encoding
+type avroEncoder struct {
+	buf []byte
+}
+
+// long appends an int or long, zig-zag encoded as a varint
+func (e *avroEncoder) long(n int64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf = append(e.buf, b[:binary.PutVarint(b[:], n)]...)
+}
+
+func (e *avroEncoder) string(s string) {
+	e.long(int64(len(s)))
+	e.buf = append(e.buf, s...)
+}
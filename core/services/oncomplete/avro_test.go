@@ -0,0 +1,78 @@
+package oncomplete_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/oncomplete"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestAvroFingerprint(t *testing.T) {
+	t.Parallel()
+
+	// From the test vectors of the Avro specification
+	assert.Equal(t, int64(7195948357588979594), int64(oncomplete.AvroFingerprint([]byte(`"null"`))))
+	assert.Equal(t, int64(8247732601305521295), int64(oncomplete.AvroFingerprint([]byte(`"int"`))))
+}
+
+type avroDecoder struct {
+	t *testing.T
+	r *bytes.Reader
+}
+
+func (d avroDecoder) long() int64 {
+	n, err := binary.ReadVarint(d.r)
+	require.NoError(d.t, err)
+	return n
+}
+
+func (d avroDecoder) string() string {
+	b := make([]byte, d.long())
+	_, err := d.r.Read(b)
+	require.NoError(d.t, err)
+	return string(b)
+}
+
+func TestEncodeAvro(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Unix(1600000000, 123456000)
+	encoded, err := oncomplete.EncodeAvro(oncomplete.Payload{
+		JobID:      7,
+		JobName:    null.StringFrom("avro job"),
+		RunID:      -42,
+		Status:     oncomplete.StatusErrored,
+		Outputs:    pipeline.JSONSerializable{Val: []interface{}{nil, "1"}},
+		Errors:     pipeline.RunErrors{null.StringFrom("boom"), null.String{}},
+		CreatedAt:  createdAt,
+		FinishedAt: createdAt.Add(time.Second),
+	})
+	require.NoError(t, err)
+
+	require.True(t, len(encoded) > 10)
+	assert.Equal(t, []byte{0xC3, 0x01}, encoded[:2])
+	assert.Equal(t, oncomplete.AvroSchemaFingerprint, binary.LittleEndian.Uint64(encoded[2:10]))
+
+	d := avroDecoder{t, bytes.NewReader(encoded[10:])}
+	assert.Equal(t, int64(7), d.long())
+	assert.Equal(t, int64(1), d.long())
+	assert.Equal(t, "avro job", d.string())
+	assert.Equal(t, int64(-42), d.long())
+	assert.Equal(t, "errored", d.string())
+	assert.Equal(t, `[null,"1"]`, d.string())
+	assert.Equal(t, int64(2), d.long())
+	assert.Equal(t, int64(1), d.long())
+	assert.Equal(t, "boom", d.string())
+	assert.Equal(t, int64(0), d.long())
+	assert.Equal(t, int64(0), d.long())
+	assert.Equal(t, int64(1600000000123456), d.long())
+	assert.Equal(t, int64(1600000001123456), d.long())
+	assert.Equal(t, 0, d.r.Len())
+}
@@ -0,0 +1,70 @@
+package oncomplete
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// kafkaPublisher publishes to the brokers of a kafka://host1:port,host2:port
+// URL, authenticating with SASL/PLAIN when the URL has user info and
+// connecting over TLS when the URL has tls=true
+type kafkaPublisher struct {
+	producer sarama.SyncProducer
+}
+
+func newKafkaPublisher(u *url.URL) (*kafkaPublisher, error) {
+	tlsConfig, err := job.QueueTLSConfig(u)
+	if err != nil {
+		return nil, err
+	}
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	config.ClientID = "chainlink"
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	if u.User != nil {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.User = u.User.Username()
+		config.Net.SASL.Password, _ = u.User.Password()
+	}
+	if tlsConfig != nil {
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
+
+	producer, err := sarama.NewSyncProducer(strings.Split(u.Host, ","), config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create Kafka producer")
+	}
+	return &kafkaPublisher{producer}, nil
+}
+
+func (k *kafkaPublisher) Publish(ctx context.Context, topic, key string, body []byte) error {
+	// SendMessage can't be cancelled, but gives up after the producer's
+	// timeouts
+	chErr := make(chan error, 1)
+	go func() {
+		_, _, err := k.producer.SendMessage(&sarama.ProducerMessage{
+			Topic: topic,
+			Key:   sarama.StringEncoder(key),
+			Value: sarama.ByteEncoder(body),
+		})
+		chErr <- err
+	}()
+	select {
+	case err := <-chErr:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (k *kafkaPublisher) Close() error {
+	return k.producer.Close()
+}
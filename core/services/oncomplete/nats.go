@@ -0,0 +1,57 @@
+package oncomplete
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// natsPublisher publishes to the subjects of a nats://host1:port,host2:port
+// cluster, authenticating with the URL's user info and connecting over TLS
+// when the URL has tls=true. Each message is flushed, so that Publish returns
+// once the server has processed it.
+type natsPublisher struct {
+	conn *nats.Conn
+}
+
+func newNATSPublisher(u *url.URL) (*natsPublisher, error) {
+	tlsConfig, err := job.QueueTLSConfig(u)
+	if err != nil {
+		return nil, err
+	}
+	var servers []string
+	for _, host := range strings.Split(u.Host, ",") {
+		servers = append(servers, "nats://"+host)
+	}
+	options := []nats.Option{nats.Name("chainlink")}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		options = append(options, nats.UserInfo(u.User.Username(), password))
+	}
+	if tlsConfig != nil {
+		options = append(options, nats.Secure(tlsConfig))
+	}
+
+	conn, err := nats.Connect(strings.Join(servers, ","), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to nats")
+	}
+	return &natsPublisher{conn}, nil
+}
+
+func (n *natsPublisher) Publish(ctx context.Context, topic, _ string, body []byte) error {
+	if err := n.conn.Publish(topic, body); err != nil {
+		return err
+	}
+	return n.conn.FlushWithContext(ctx)
+}
+
+func (n *natsPublisher) Close() error {
+	n.conn.Close()
+	return nil
+}
@@ -0,0 +1,505 @@
+// Package oncomplete delivers the result of each run of a v2 job to the sinks
+// of the job's [onComplete] table once the run has finished: a webhook, that
+// is POSTed the result, and a Kafka, NATS or Redis Streams message queue,
+// that the result is published to. Downstream systems consuming the job's
+// outputs don't have to poll the node for them.
+//
+// Deliveries that fail are retried up to JOB_ON_COMPLETE_MAX_ATTEMPTS times,
+// with a delay that starts at JOB_ON_COMPLETE_RETRY_DELAY and doubles with
+// each retry. Deliveries that still fail, or that fail in a way that
+// retrying won't fix, such as webhooks rejected with a 4xx status other than
+// 408 or 429, are recorded in the job_on_complete_dead_letters table.
+//
+// Each run to be delivered is recorded in the
+// job_on_complete_pending_deliveries table in the same transaction as the
+// run finishing, and removed once it has been delivered or dead lettered, so
+// runs are delivered even if the node stops first. Pending deliveries are
+// claimed for long enough to make every attempt, so that only one node
+// delivers them at a time. A node that stops releases the deliveries it
+// hasn't finished, and those of a node that crashes are delivered again once
+// its claim expires.
+package oncomplete
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/atomic"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+const (
+	sendTimeout = 10 * time.Second
+	// pollInterval is how often pending deliveries are looked for, besides
+	// whenever a run finishes
+	pollInterval = 5 * time.Second
+	// maxInFlight is how many runs are delivered at once
+	maxInFlight = 100
+	// maxClaim is the longest that pending deliveries are claimed for
+	maxClaim = 24 * time.Hour
+)
+
+// Status is the outcome of a run
+type Status string
+
+const (
+	StatusCompleted  Status = "completed"
+	StatusErrored    Status = "errored"
+	StatusSuppressed Status = "suppressed"
+)
+
+var promDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "job_on_complete_deliveries",
+	Help: "The number of run results delivered to onComplete sinks, by sink and whether they were delivered or dead lettered",
+},
+	[]string{"sink", "result"},
+)
+
+type Config interface {
+	JobOnCompleteMaxAttempts() uint32
+	JobOnCompleteRetryDelay() time.Duration
+}
+
+// Payload is the result of a run, as delivered to the sinks. Sinks should
+// expect to be sent a run more than once, e.g. when their response was lost,
+// and can tell runs apart by their RunID.
+type Payload struct {
+	JobID      int32                     `json:"jobID"`
+	JobName    null.String               `json:"jobName"`
+	RunID      int64                     `json:"runID"`
+	Status     Status                    `json:"status"`
+	Outputs    pipeline.JSONSerializable `json:"outputs"`
+	Errors     pipeline.RunErrors        `json:"errors"`
+	CreatedAt  time.Time                 `json:"createdAt"`
+	FinishedAt time.Time                 `json:"finishedAt"`
+}
+
+// DeadLetter records a run whose result could not be delivered to a sink
+type DeadLetter struct {
+	ID            int64 `json:"id" gorm:"primary_key"`
+	JobID         int32 `json:"jobID"`
+	PipelineRunID int64 `json:"pipelineRunID"`
+	// Sink is the webhook URL, or the queue URL, without its credentials,
+	// and topic
+	Sink string `json:"sink"`
+	// Payload is the JSON of the result, whatever format it was to be
+	// delivered in
+	Payload   models.JSON `json:"payload" gorm:"type:jsonb"`
+	Error     string      `json:"error"`
+	Attempts  uint32      `json:"attempts"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+func (DeadLetter) TableName() string {
+	return "job_on_complete_dead_letters"
+}
+
+// pendingDelivery is a finished run whose result is yet to be delivered to
+// its job's sinks
+type pendingDelivery struct {
+	ID            int64
+	JobID         int32
+	PipelineRunID int64
+	// LockedUntil is when the node delivering the run gives up its claim
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+}
+
+func (pendingDelivery) TableName() string {
+	return "job_on_complete_pending_deliveries"
+}
+
+// sink is where the results of a job's runs are delivered
+type sink interface {
+	// send delivers payload. Errors that retrying won't fix are permanent.
+	send(ctx context.Context, payload Payload, attempt uint32) (permanent bool, err error)
+	// kind names the kind of sink in metrics
+	kind() string
+	// String identifies the sink in dead letters and logs, without
+	// credentials
+	String() string
+}
+
+// Deliverer delivers the results of runs to their jobs' sinks as the runs
+// finish
+type Deliverer struct {
+	utils.StartStopOnce
+	bus        *eventbus.Bus
+	db         *gorm.DB
+	config     Config
+	client     *http.Client
+	publishers *publisherPool
+	inFlight   atomic.Int32
+
+	sub    *eventbus.Subscription
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewDeliverer returns a Deliverer of the pending deliveries in db. It looks
+// for them whenever a run finishes on bus, and every pollInterval.
+func NewDeliverer(bus *eventbus.Bus, db *gorm.DB, config Config) *Deliverer {
+	return &Deliverer{
+		bus:        bus,
+		db:         db,
+		config:     config,
+		client:     utils.UnrestrictedClient,
+		publishers: newPublisherPool(),
+		chStop:     make(chan struct{}),
+	}
+}
+
+func (d *Deliverer) Start() error {
+	return d.StartOnce("OnComplete deliverer", func() error {
+		d.sub = d.bus.Subscribe(eventbus.TopicRunFinished)
+		d.wg.Add(1)
+		go d.run()
+		return nil
+	})
+}
+
+// Close stops the deliverer once the deliveries in progress have been
+// attempted. Deliveries waiting to be retried are left pending, to be
+// delivered once the node starts again.
+func (d *Deliverer) Close() error {
+	if !d.OkayToStop() {
+		return errors.New("OnComplete deliverer has already been stopped")
+	}
+	close(d.chStop)
+	d.sub.Close()
+	d.wg.Wait()
+	d.publishers.close()
+	return nil
+}
+
+func (d *Deliverer) run() {
+	defer d.wg.Done()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	d.deliverPending()
+	for {
+		select {
+		case _, ok := <-d.sub.Events():
+			if !ok {
+				return
+			}
+			// Runs that finished together are looked for together
+			if !d.drainEvents() {
+				return
+			}
+			d.deliverPending()
+		case <-ticker.C:
+			d.deliverPending()
+		case <-d.chStop:
+			return
+		}
+	}
+}
+
+// drainEvents discards the events waiting to be received, returning false
+// if the subscription was closed
+func (d *Deliverer) drainEvents() bool {
+	for {
+		select {
+		case _, ok := <-d.sub.Events():
+			if !ok {
+				return false
+			}
+		default:
+			return true
+		}
+	}
+}
+
+// deliverPending claims the pending deliveries that no other node is
+// delivering, and delivers them in the background
+func (d *Deliverer) deliverPending() {
+	limit := maxInFlight - int(d.inFlight.Load())
+	if limit <= 0 {
+		return
+	}
+	var claimed []pendingDelivery
+	err := d.db.Raw(`
+		UPDATE job_on_complete_pending_deliveries SET locked_until = ?
+		WHERE id IN (
+			SELECT id FROM job_on_complete_pending_deliveries
+			WHERE locked_until IS NULL OR locked_until < NOW()
+			ORDER BY id ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, job_id, pipeline_run_id, locked_until, created_at
+	`, time.Now().Add(d.claimDuration()), limit).Scan(&claimed).Error
+	if err != nil {
+		logger.Errorw("OnComplete: could not claim pending deliveries", "err", err)
+		return
+	} else if len(claimed) == 0 {
+		return
+	}
+
+	payloads, sinks, err := d.load(claimed)
+	if err != nil {
+		logger.Errorw("OnComplete: could not load pending deliveries", "err", err)
+		d.release(claimed)
+		return
+	}
+	for _, pd := range claimed {
+		payload, exists := payloads[pd.ID]
+		if !exists || len(sinks[pd.JobID]) == 0 {
+			d.complete(pd)
+			continue
+		}
+		d.inFlight.Inc()
+		d.wg.Add(1)
+		go func(pd pendingDelivery) {
+			defer d.wg.Done()
+			defer d.inFlight.Dec()
+			d.deliverRun(pd, sinks[pd.JobID], payload)
+		}(pd)
+	}
+}
+
+// claimDuration is how long pending deliveries are claimed for: long enough
+// to make every attempt
+func (d *Deliverer) claimDuration() time.Duration {
+	claim := time.Minute
+	delay := d.config.JobOnCompleteRetryDelay()
+	for i := uint32(0); i < d.maxAttempts() && claim < maxClaim; i++ {
+		claim += sendTimeout + delay
+		delay *= 2
+	}
+	if claim > maxClaim {
+		claim = maxClaim
+	}
+	return claim
+}
+
+func (d *Deliverer) maxAttempts() uint32 {
+	if maxAttempts := d.config.JobOnCompleteMaxAttempts(); maxAttempts > 0 {
+		return maxAttempts
+	}
+	return 1
+}
+
+// load returns the payloads of the pending deliveries by their IDs, and the
+// sinks of their jobs by the jobs' IDs. Deliveries whose runs no longer
+// exist, and jobs whose onComplete settings are invalid, are left out.
+func (d *Deliverer) load(pending []pendingDelivery) (map[int64]Payload, map[int32][]sink, error) {
+	var jobIDs []int32
+	var runIDs []int64
+	for _, pd := range pending {
+		jobIDs = append(jobIDs, pd.JobID)
+		runIDs = append(runIDs, pd.PipelineRunID)
+	}
+
+	var jobs []job.Job
+	err := d.db.Select("id", "name", "on_complete_webhook_url", "on_complete_webhook_secret",
+		"on_complete_queue_url", "on_complete_queue_topic", "on_complete_queue_format").
+		Find(&jobs, "id IN ?", jobIDs).Error
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not load jobs")
+	}
+	var runs []pipeline.Run
+	err = d.db.Select("id", "outputs", "errors", "suppressed", "created_at", "finished_at").
+		Find(&runs, "id IN ?", runIDs).Error
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not load runs")
+	}
+
+	jobsByID := make(map[int32]job.Job)
+	sinks := make(map[int32][]sink)
+	for _, jb := range jobs {
+		jobsByID[jb.ID] = jb
+		jobSinks, err := d.sinks(jb.OnComplete)
+		if err != nil {
+			logger.Errorw("OnComplete: invalid onComplete settings", "jobID", jb.ID, "err", err)
+			continue
+		}
+		sinks[jb.ID] = jobSinks
+	}
+	runsByID := make(map[int64]pipeline.Run)
+	for _, run := range runs {
+		runsByID[run.ID] = run
+	}
+
+	payloads := make(map[int64]Payload)
+	for _, pd := range pending {
+		jb, jobExists := jobsByID[pd.JobID]
+		run, runExists := runsByID[pd.PipelineRunID]
+		if !jobExists || !runExists {
+			continue
+		}
+		payload := Payload{
+			JobID:     jb.ID,
+			JobName:   jb.Name,
+			RunID:     run.ID,
+			Status:    StatusCompleted,
+			Outputs:   run.Outputs,
+			Errors:    run.Errors,
+			CreatedAt: run.CreatedAt,
+		}
+		if run.HasErrors() {
+			payload.Status = StatusErrored
+		} else if run.Suppressed {
+			payload.Status = StatusSuppressed
+		}
+		if run.FinishedAt != nil {
+			payload.FinishedAt = *run.FinishedAt
+		}
+		payloads[pd.ID] = payload
+	}
+	return payloads, sinks, nil
+}
+
+// deliverRun delivers payload to each of the sinks, then removes the pending
+// delivery. If the deliverer is stopped first, the delivery is left pending.
+func (d *Deliverer) deliverRun(pd pendingDelivery, sinks []sink, payload Payload) {
+	var wg sync.WaitGroup
+	var stopped atomic.Bool
+	for _, s := range sinks {
+		wg.Add(1)
+		go func(s sink) {
+			defer wg.Done()
+			if !d.deliver(s, payload) {
+				stopped.Store(true)
+			}
+		}(s)
+	}
+	wg.Wait()
+	if stopped.Load() {
+		d.release([]pendingDelivery{pd})
+	} else {
+		d.complete(pd)
+	}
+}
+
+// complete removes a pending delivery once it has been delivered or dead
+// lettered
+func (d *Deliverer) complete(pd pendingDelivery) {
+	if err := d.db.Delete(&pd).Error; err != nil {
+		logger.Errorw("OnComplete: could not remove pending delivery", "jobID", pd.JobID, "runID", pd.PipelineRunID, "err", err)
+	}
+}
+
+// release gives up the claim on pending deliveries, so that they are
+// delivered again
+func (d *Deliverer) release(pending []pendingDelivery) {
+	var ids []int64
+	for _, pd := range pending {
+		ids = append(ids, pd.ID)
+	}
+	err := d.db.Exec(`UPDATE job_on_complete_pending_deliveries SET locked_until = NULL WHERE id IN ?`, ids).Error
+	if err != nil {
+		logger.Errorw("OnComplete: could not release pending deliveries", "err", err)
+	}
+}
+
+// sinks returns the sinks of a job's onComplete settings
+func (d *Deliverer) sinks(oc job.OnComplete) ([]sink, error) {
+	var sinks []sink
+	if oc.WebhookURL.Valid {
+		sinks = append(sinks, newWebhookSink(d.client, oc.WebhookURL.String, oc.WebhookSecret))
+	}
+	if oc.QueueURL.Valid {
+		s, err := newQueueSink(d.publishers, oc.QueueURL.String, oc.QueueTopic.String, oc.QueueFormat.String)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+// deliver sends payload to the sink until it is delivered or its attempts
+// run out, in which case it is dead lettered. It returns false if the
+// deliverer was stopped first.
+func (d *Deliverer) deliver(s sink, payload Payload) bool {
+	maxAttempts := d.maxAttempts()
+	delay := d.config.JobOnCompleteRetryDelay()
+	var attempts uint32
+	var err error
+	for {
+		attempts++
+		var permanent bool
+		permanent, err = d.send(s, payload, attempts)
+		if err == nil {
+			promDeliveries.WithLabelValues(s.kind(), "delivered").Inc()
+			return true
+		}
+		if d.stopped() {
+			return false
+		}
+		logger.Warnw("OnComplete: delivery failed", "sink", s.String(), "jobID", payload.JobID, "runID", payload.RunID, "attempt", attempts, "err", err)
+		if permanent || attempts >= maxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-d.chStop:
+			return false
+		}
+	}
+	d.deadLetter(s, payload, err, attempts)
+	return true
+}
+
+func (d *Deliverer) stopped() bool {
+	select {
+	case <-d.chStop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *Deliverer) send(s sink, payload Payload, attempt uint32) (bool, error) {
+	ctx, cancel := utils.CombinedContext(d.chStop, sendTimeout)
+	defer cancel()
+	return s.send(ctx, payload, attempt)
+}
+
+func (d *Deliverer) deadLetter(s sink, payload Payload, deliveryErr error, attempts uint32) {
+	promDeliveries.WithLabelValues(s.kind(), "dead_lettered").Inc()
+	logger.Errorw("OnComplete: could not deliver run, recording it as a dead letter",
+		"sink", s.String(), "jobID", payload.JobID, "runID", payload.RunID, "attempts", attempts, "err", deliveryErr)
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorw("OnComplete: could not marshal payload", "jobID", payload.JobID, "runID", payload.RunID, "err", err)
+		return
+	}
+	payloadJSON, err := models.ParseJSON(body)
+	if err != nil {
+		logger.Errorw("OnComplete: could not parse payload", "jobID", payload.JobID, "runID", payload.RunID, "err", err)
+		return
+	}
+	err = d.db.Create(&DeadLetter{
+		JobID:         payload.JobID,
+		PipelineRunID: payload.RunID,
+		Sink:          s.String(),
+		Payload:       payloadJSON,
+		Error:         deliveryErr.Error(),
+		Attempts:      attempts,
+		CreatedAt:     time.Now(),
+	}).Error
+	if err != nil {
+		logger.Errorw("OnComplete: could not record dead letter", "jobID", payload.JobID, "runID", payload.RunID, "err", err)
+	}
+}
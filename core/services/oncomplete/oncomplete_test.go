@@ -0,0 +1,486 @@
+package oncomplete_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/oncomplete"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+)
+
+type config struct {
+	maxAttempts uint32
+	retryDelay  time.Duration
+}
+
+func (c config) JobOnCompleteMaxAttempts() uint32       { return c.maxAttempts }
+func (c config) JobOnCompleteRetryDelay() time.Duration { return c.retryDelay }
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, chan request) {
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		status := http.StatusOK
+		if len(requests) < len(statuses) {
+			status = statuses[len(requests)]
+		}
+		requests <- request{r.Header, b}
+		w.WriteHeader(status)
+	}))
+	return server, requests
+}
+
+func mustInsertJob(t *testing.T, store *strpkg.Store) job.Job {
+	_, address := cltest.MustAddRandomKeyToKeystore(t, store)
+	return cltest.MustInsertV2JobSpec(t, store, address)
+}
+
+func mustSetWebhook(t *testing.T, db *gorm.DB, jb job.Job, url string, secret null.String) {
+	err := db.Exec(`UPDATE jobs SET name = 'webhook job', on_complete_webhook_url = ?, on_complete_webhook_secret = ? WHERE id = ?`, url, secret, jb.ID).Error
+	require.NoError(t, err)
+}
+
+func mustInsertFinishedRun(t *testing.T, db *gorm.DB, jb job.Job, errored bool) pipeline.Run {
+	finishedAt := time.Now()
+	run := pipeline.Run{
+		PipelineSpecID: jb.PipelineSpecID,
+		JobID:          &jb.ID,
+		Outputs:        pipeline.JSONSerializable{Val: []interface{}{"42"}},
+		Errors:         pipeline.RunErrors{null.String{}},
+		CreatedAt:      finishedAt.Add(-time.Second),
+		FinishedAt:     &finishedAt,
+	}
+	if errored {
+		run.Outputs = pipeline.JSONSerializable{Val: []interface{}{nil}}
+		run.Errors = pipeline.RunErrors{null.StringFrom("boom")}
+	}
+	require.NoError(t, db.Create(&run).Error)
+	err := db.Exec(`INSERT INTO job_on_complete_pending_deliveries (job_id, pipeline_run_id, created_at) VALUES (?, ?, NOW())`, jb.ID, run.ID).Error
+	require.NoError(t, err)
+	return run
+}
+
+func countPendingDeliveries(t *testing.T, db *gorm.DB, jb job.Job) int {
+	var count int64
+	require.NoError(t, db.Table("job_on_complete_pending_deliveries").Where("job_id = ?", jb.ID).Count(&count).Error)
+	return int(count)
+}
+
+func runFinished(jb job.Job, run pipeline.Run) eventbus.RunFinished {
+	return eventbus.RunFinished{
+		JobID:   strconv.FormatInt(int64(jb.ID), 10),
+		RunID:   strconv.FormatInt(run.ID, 10),
+		Errored: run.HasErrors(),
+	}
+}
+
+func TestDeliverer_DeliversSignedRuns(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	receiver, requests := newReceiver(t)
+	defer receiver.Close()
+
+	bus := eventbus.NewBus()
+	deliverer := oncomplete.NewDeliverer(bus, store.DB, config{maxAttempts: 3, retryDelay: time.Millisecond})
+	require.NoError(t, deliverer.Start())
+	defer deliverer.Close()
+
+	jb := mustInsertJob(t, store)
+	mustSetWebhook(t, store.DB, jb, receiver.URL, null.StringFrom("secret"))
+	run := mustInsertFinishedRun(t, store.DB, jb, true)
+
+	// Runs of v1 jobs and jobs without a webhook are not delivered
+	bus.Publish(eventbus.RunFinished{JobID: "2d3b36ef-6f5b-4c83-b8a8-0a2c8dd1e6ba", RunID: "ad3b2ca3-d5a9-4e4c-9b47-0a7b05fc6e11"})
+	other := mustInsertJob(t, store)
+	bus.Publish(runFinished(other, mustInsertFinishedRun(t, store.DB, other, false)))
+
+	bus.Publish(runFinished(jb, run))
+
+	var req request
+	select {
+	case req = <-requests:
+	case <-time.After(cltest.DBWaitTimeout):
+		t.Fatal("timed out waiting for the webhook")
+	}
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "1", req.header.Get(oncomplete.AttemptHeader))
+	assert.Equal(t, oncomplete.Sign([]byte("secret"), req.body), req.header.Get(oncomplete.SignatureHeader))
+
+	var payload oncomplete.Payload
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	assert.Equal(t, jb.ID, payload.JobID)
+	assert.Equal(t, null.StringFrom("webhook job"), payload.JobName)
+	assert.Equal(t, run.ID, payload.RunID)
+	assert.Equal(t, oncomplete.StatusErrored, payload.Status)
+	assert.Equal(t, []interface{}{nil}, payload.Outputs.Val)
+	assert.Equal(t, pipeline.RunErrors{null.StringFrom("boom")}, payload.Errors)
+
+	require.NoError(t, deliverer.Close())
+	assert.Len(t, requests, 0)
+	assert.Equal(t, 0, countPendingDeliveries(t, store.DB, jb))
+	assert.Equal(t, 0, countPendingDeliveries(t, store.DB, other))
+}
+
+func TestDeliverer_DeliversRunsThatFinishedWhileStopped(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	receiver, requests := newReceiver(t)
+	defer receiver.Close()
+
+	jb := mustInsertJob(t, store)
+	mustSetWebhook(t, store.DB, jb, receiver.URL, null.String{})
+	run := mustInsertFinishedRun(t, store.DB, jb, false)
+
+	// No event is published for the run, as it finished before the
+	// deliverer started
+	deliverer := oncomplete.NewDeliverer(eventbus.NewBus(), store.DB, config{maxAttempts: 3, retryDelay: time.Millisecond})
+	require.NoError(t, deliverer.Start())
+	defer deliverer.Close()
+
+	var req request
+	select {
+	case req = <-requests:
+	case <-time.After(cltest.DBWaitTimeout):
+		t.Fatal("timed out waiting for the webhook")
+	}
+	var payload oncomplete.Payload
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	assert.Equal(t, run.ID, payload.RunID)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() int { return countPendingDeliveries(t, store.DB, jb) }, cltest.DBWaitTimeout).Should(gomega.Equal(0))
+}
+
+func TestDeliverer_LeavesDeliveriesPendingWhenStopped(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	receiver, requests := newReceiver(t, http.StatusServiceUnavailable)
+	defer receiver.Close()
+
+	bus := eventbus.NewBus()
+	deliverer := oncomplete.NewDeliverer(bus, store.DB, config{maxAttempts: 3, retryDelay: time.Hour})
+	require.NoError(t, deliverer.Start())
+
+	jb := mustInsertJob(t, store)
+	mustSetWebhook(t, store.DB, jb, receiver.URL, null.String{})
+	bus.Publish(runFinished(jb, mustInsertFinishedRun(t, store.DB, jb, false)))
+
+	select {
+	case <-requests:
+	case <-time.After(cltest.DBWaitTimeout):
+		t.Fatal("timed out waiting for the webhook")
+	}
+	// Stopped while waiting to retry
+	require.NoError(t, deliverer.Close())
+
+	var deadLetters []oncomplete.DeadLetter
+	require.NoError(t, store.DB.Where("job_id = ?", jb.ID).Find(&deadLetters).Error)
+	assert.Empty(t, deadLetters)
+	var lockedUntil []*time.Time
+	require.NoError(t, store.DB.Table("job_on_complete_pending_deliveries").Where("job_id = ?", jb.ID).Pluck("locked_until", &lockedUntil).Error)
+	require.Len(t, lockedUntil, 1)
+	assert.Nil(t, lockedUntil[0])
+}
+
+func TestDeliverer_RetriesAndRecordsDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	g := gomega.NewGomegaWithT(t)
+
+	bus := eventbus.NewBus()
+	deliverer := oncomplete.NewDeliverer(bus, store.DB, config{maxAttempts: 3, retryDelay: time.Millisecond})
+	require.NoError(t, deliverer.Start())
+	defer deliverer.Close()
+
+	t.Run("delivers once the receiver recovers", func(t *testing.T) {
+		receiver, requests := newReceiver(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		defer receiver.Close()
+		jb := mustInsertJob(t, store)
+		mustSetWebhook(t, store.DB, jb, receiver.URL, null.String{})
+		run := mustInsertFinishedRun(t, store.DB, jb, false)
+
+		bus.Publish(runFinished(jb, run))
+		g.Eventually(func() int { return len(requests) }, cltest.DBWaitTimeout).Should(gomega.Equal(3))
+		var last request
+		for i := 0; i < 3; i++ {
+			last = <-requests
+		}
+		assert.Equal(t, "3", last.header.Get(oncomplete.AttemptHeader))
+		assert.Empty(t, last.header.Get(oncomplete.SignatureHeader))
+
+		g.Consistently(func() int {
+			var count int64
+			require.NoError(t, store.DB.Model(&oncomplete.DeadLetter{}).Where("job_id = ?", jb.ID).Count(&count).Error)
+			return int(count)
+		}, 100*time.Millisecond).Should(gomega.Equal(0))
+	})
+
+	t.Run("dead letters once the attempts run out", func(t *testing.T) {
+		receiver, requests := newReceiver(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+		defer receiver.Close()
+		jb := mustInsertJob(t, store)
+		mustSetWebhook(t, store.DB, jb, receiver.URL, null.String{})
+		run := mustInsertFinishedRun(t, store.DB, jb, false)
+
+		bus.Publish(runFinished(jb, run))
+		var deadLetters []oncomplete.DeadLetter
+		g.Eventually(func() []oncomplete.DeadLetter {
+			require.NoError(t, store.DB.Where("job_id = ?", jb.ID).Find(&deadLetters).Error)
+			return deadLetters
+		}, cltest.DBWaitTimeout).Should(gomega.HaveLen(1))
+		assert.Len(t, requests, 3)
+		assert.Equal(t, run.ID, deadLetters[0].PipelineRunID)
+		assert.Equal(t, receiver.URL, deadLetters[0].Sink)
+		assert.Equal(t, uint32(3), deadLetters[0].Attempts)
+		assert.Equal(t, "got status 500", deadLetters[0].Error)
+		assert.Equal(t, float64(run.ID), deadLetters[0].Payload.Get("runID").Float())
+		assert.Equal(t, "completed", deadLetters[0].Payload.Get("status").String())
+	})
+
+	t.Run("does not retry rejected deliveries", func(t *testing.T) {
+		receiver, requests := newReceiver(t, http.StatusBadRequest)
+		defer receiver.Close()
+		jb := mustInsertJob(t, store)
+		mustSetWebhook(t, store.DB, jb, receiver.URL, null.String{})
+		run := mustInsertFinishedRun(t, store.DB, jb, false)
+
+		bus.Publish(runFinished(jb, run))
+		var deadLetters []oncomplete.DeadLetter
+		g.Eventually(func() []oncomplete.DeadLetter {
+			require.NoError(t, store.DB.Where("job_id = ?", jb.ID).Find(&deadLetters).Error)
+			return deadLetters
+		}, cltest.DBWaitTimeout).Should(gomega.HaveLen(1))
+		assert.Len(t, requests, 1)
+		assert.Equal(t, uint32(1), deadLetters[0].Attempts)
+	})
+}
+
+func mustSetQueue(t *testing.T, db *gorm.DB, jb job.Job, url, topic string, format null.String) {
+	err := db.Exec(`UPDATE jobs SET on_complete_queue_url = ?, on_complete_queue_topic = ?, on_complete_queue_format = ? WHERE id = ?`, url, topic, format, jb.ID).Error
+	require.NoError(t, err)
+}
+
+// newNATSServer accepts NATS clients, sending the subject and body of each
+// message they publish to the returned channel
+func newNATSServer(t *testing.T) (string, chan [2]string) {
+	messages := make(chan [2]string, 10)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					fields := strings.Fields(line)
+					switch fields[0] {
+					case "PUB":
+						n, _ := strconv.Atoi(fields[2])
+						body := make([]byte, n+2)
+						if _, err := io.ReadFull(r, body); err != nil {
+							return
+						}
+						messages <- [2]string{fields[1], string(body[:n])}
+					case "PING":
+						fmt.Fprint(conn, "PONG\r\n")
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String(), messages
+}
+
+// newRedisServer accepts redis clients, sending each command they send to
+// the returned channel, and replying to it with reply
+func newRedisServer(t *testing.T, reply func(cmd []string) string) (string, chan []string) {
+	commands := make(chan []string, 10)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					var cmd []string
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+					for i := 0; i < n; i++ {
+						line, err = r.ReadString('\n')
+						if err != nil {
+							return
+						}
+						size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+						arg := make([]byte, size+2)
+						if _, err = io.ReadFull(r, arg); err != nil {
+							return
+						}
+						cmd = append(cmd, string(arg[:size]))
+					}
+					// Clients may send commands in either case
+					cmd[0] = strings.ToUpper(cmd[0])
+					commands <- cmd
+					fmt.Fprint(conn, reply(cmd))
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String(), commands
+}
+
+func TestDeliverer_PublishesToQueues(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	g := gomega.NewGomegaWithT(t)
+
+	bus := eventbus.NewBus()
+	deliverer := oncomplete.NewDeliverer(bus, store.DB, config{maxAttempts: 2, retryDelay: time.Millisecond})
+	require.NoError(t, deliverer.Start())
+	defer deliverer.Close()
+
+	t.Run("nats", func(t *testing.T) {
+		addr, messages := newNATSServer(t)
+		jb := mustInsertJob(t, store)
+		mustSetQueue(t, store.DB, jb, "nats://"+addr, "runs.results", null.String{})
+		run := mustInsertFinishedRun(t, store.DB, jb, false)
+
+		bus.Publish(runFinished(jb, run))
+		var msg [2]string
+		select {
+		case msg = <-messages:
+		case <-time.After(cltest.DBWaitTimeout):
+			t.Fatal("timed out waiting for the message")
+		}
+		assert.Equal(t, "runs.results", msg[0])
+		var payload oncomplete.Payload
+		require.NoError(t, json.Unmarshal([]byte(msg[1]), &payload))
+		assert.Equal(t, run.ID, payload.RunID)
+		assert.Equal(t, oncomplete.StatusCompleted, payload.Status)
+	})
+
+	t.Run("redis with avro", func(t *testing.T) {
+		addr, commands := newRedisServer(t, func(cmd []string) string {
+			if cmd[0] == "XADD" {
+				return "$3\r\n1-0\r\n"
+			}
+			return "+OK\r\n"
+		})
+		jb := mustInsertJob(t, store)
+		mustSetQueue(t, store.DB, jb, "redis://:hunter2@"+addr+"/3", "results", null.StringFrom("avro"))
+		run := mustInsertFinishedRun(t, store.DB, jb, false)
+
+		bus.Publish(runFinished(jb, run))
+		var cmds [][]string
+		g.Eventually(func() int {
+			for len(commands) > 0 {
+				cmds = append(cmds, <-commands)
+			}
+			return len(cmds)
+		}, cltest.DBWaitTimeout).Should(gomega.Equal(3))
+		assert.Equal(t, []string{"AUTH", "hunter2"}, cmds[0])
+		assert.Equal(t, []string{"SELECT", "3"}, cmds[1])
+		require.Len(t, cmds[2], 7)
+		assert.Equal(t, []string{"XADD", "results", "*", "jobID", strconv.Itoa(int(jb.ID)), "payload"}, cmds[2][:6])
+
+		header := make([]byte, 10)
+		header[0], header[1] = 0xC3, 0x01
+		binary.LittleEndian.PutUint64(header[2:], oncomplete.AvroSchemaFingerprint)
+		assert.Equal(t, header, []byte(cmds[2][6])[:10])
+	})
+
+	t.Run("does not retry invalid topics", func(t *testing.T) {
+		addr, messages := newNATSServer(t)
+		jb := mustInsertJob(t, store)
+		mustSetQueue(t, store.DB, jb, "nats://"+addr, "runs.>", null.String{})
+		run := mustInsertFinishedRun(t, store.DB, jb, false)
+
+		bus.Publish(runFinished(jb, run))
+		var deadLetters []oncomplete.DeadLetter
+		g.Eventually(func() []oncomplete.DeadLetter {
+			require.NoError(t, store.DB.Where("job_id = ?", jb.ID).Find(&deadLetters).Error)
+			return deadLetters
+		}, cltest.DBWaitTimeout).Should(gomega.HaveLen(1))
+		assert.Equal(t, uint32(1), deadLetters[0].Attempts)
+		assert.Contains(t, deadLetters[0].Error, "not a valid nats subject")
+		assert.Len(t, messages, 0)
+	})
+
+	t.Run("dead letters without the queue's credentials", func(t *testing.T) {
+		addr, _ := newRedisServer(t, func([]string) string {
+			return "-WRONGPASS invalid username-password pair\r\n"
+		})
+		jb := mustInsertJob(t, store)
+		mustSetQueue(t, store.DB, jb, "redis://:hunter2@"+addr, "results", null.String{})
+		run := mustInsertFinishedRun(t, store.DB, jb, false)
+
+		bus.Publish(runFinished(jb, run))
+		var deadLetters []oncomplete.DeadLetter
+		g.Eventually(func() []oncomplete.DeadLetter {
+			require.NoError(t, store.DB.Where("job_id = ?", jb.ID).Find(&deadLetters).Error)
+			return deadLetters
+		}, cltest.DBWaitTimeout).Should(gomega.HaveLen(1))
+		assert.Equal(t, uint32(2), deadLetters[0].Attempts)
+		assert.Equal(t, "redis://:xxxxx@"+addr+"#results", deadLetters[0].Sink)
+		assert.Contains(t, deadLetters[0].Error, "WRONGPASS")
+		assert.Equal(t, "completed", deadLetters[0].Payload.Get("status").String())
+	})
+}
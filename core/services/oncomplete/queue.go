@@ -0,0 +1,138 @@
+package oncomplete
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// publisher publishes messages to a message queue. Publish returns once the
+// queue has acknowledged the message.
+type publisher interface {
+	Publish(ctx context.Context, topic, key string, body []byte) error
+	Close() error
+}
+
+func newPublisher(u *url.URL) (publisher, error) {
+	switch u.Scheme {
+	case "kafka":
+		return newKafkaPublisher(u)
+	case "nats":
+		return newNATSPublisher(u)
+	case "redis":
+		return newRedisPublisher(u)
+	default:
+		return nil, errors.Errorf("unsupported queue scheme %q", u.Scheme)
+	}
+}
+
+// publisherPool shares the connections to each queue between the jobs that
+// publish to it. Publishers are connected when first used, and reconnected
+// after they fail.
+type publisherPool struct {
+	mu         sync.Mutex
+	publishers map[string]publisher
+}
+
+func newPublisherPool() *publisherPool {
+	return &publisherPool{publishers: make(map[string]publisher)}
+}
+
+func (p *publisherPool) publish(ctx context.Context, u *url.URL, topic, key string, body []byte) error {
+	pub, err := p.get(u)
+	if err != nil {
+		return err
+	}
+	err = pub.Publish(ctx, topic, key, body)
+	if err != nil {
+		p.evict(u, pub)
+	}
+	return err
+}
+
+func (p *publisherPool) get(u *url.URL) (publisher, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pub, exists := p.publishers[u.String()]; exists {
+		return pub, nil
+	}
+	pub, err := newPublisher(u)
+	if err != nil {
+		return nil, err
+	}
+	p.publishers[u.String()] = pub
+	return pub, nil
+}
+
+// evict closes pub, unless it has already been replaced
+func (p *publisherPool) evict(u *url.URL, pub publisher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.publishers[u.String()] != pub {
+		return
+	}
+	delete(p.publishers, u.String())
+	logger.ErrorIfCalling(pub.Close)
+}
+
+func (p *publisherPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, pub := range p.publishers {
+		logger.ErrorIfCalling(pub.Close)
+		delete(p.publishers, key)
+	}
+}
+
+// queueSink publishes the results to a topic of a message queue, keyed by
+// the job's ID so that the results of a job keep their order on queues that
+// partition topics
+type queueSink struct {
+	pool   *publisherPool
+	url    *url.URL
+	topic  string
+	format string
+}
+
+func newQueueSink(pool *publisherPool, rawURL, topic, format string) (*queueSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.New("invalid queue URL")
+	}
+	if format == "" {
+		format = job.QueueFormatJSON
+	}
+	return &queueSink{pool, u, topic, format}, nil
+}
+
+func (q *queueSink) kind() string { return q.url.Scheme }
+
+func (q *queueSink) String() string {
+	return fmt.Sprintf("%s#%s", q.url.Redacted(), q.topic)
+}
+
+func (q *queueSink) send(ctx context.Context, payload Payload, _ uint32) (permanent bool, err error) {
+	if err = job.ValidateQueueTopic(q.url.Scheme, q.topic); err != nil {
+		return true, err
+	}
+	var body []byte
+	switch q.format {
+	case job.QueueFormatAvro:
+		body, err = EncodeAvro(payload)
+	default:
+		body, err = json.Marshal(payload)
+	}
+	if err != nil {
+		return true, err
+	}
+	key := strconv.FormatInt(int64(payload.JobID), 10)
+	return false, q.pool.publish(ctx, q.url, q.topic, key, body)
+}
@@ -0,0 +1,54 @@
+package oncomplete
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// redisPublisher appends to the streams of a redis://host:port/db server with
+// XADD, authenticating with the URL's user info and connecting over TLS when
+// the URL has tls=true. A URL naming more than one host, as in
+// redis://host1:port,host2:port, is a redis cluster.
+type redisPublisher struct {
+	client redis.UniversalClient
+}
+
+func newRedisPublisher(u *url.URL) (*redisPublisher, error) {
+	tlsConfig, err := job.QueueTLSConfig(u)
+	if err != nil {
+		return nil, err
+	}
+	options := &redis.UniversalOptions{
+		Addrs:     strings.Split(u.Host, ","),
+		TLSConfig: tlsConfig,
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		options.DB, err = strconv.Atoi(path)
+		if err != nil {
+			return nil, errors.Errorf("invalid redis database %q", path)
+		}
+	}
+	if u.User != nil {
+		options.Username = u.User.Username()
+		options.Password, _ = u.User.Password()
+	}
+	return &redisPublisher{redis.NewUniversalClient(options)}, nil
+}
+
+func (r *redisPublisher) Publish(ctx context.Context, topic, key string, body []byte) error {
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: topic,
+		Values: []interface{}{"jobID", key, "payload", body},
+	}).Err()
+}
+
+func (r *redisPublisher) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,78 @@
+package oncomplete
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+const (
+	// SignatureHeader is the header of the hex encoded HMAC-SHA256 of the
+	// request body, keyed with the job's onComplete.webhookSecret and
+	// prefixed with "sha256=". It is only set for jobs with a secret.
+	SignatureHeader = "X-Chainlink-Signature"
+	// AttemptHeader is the header of the number of the delivery attempt,
+	// starting at 1
+	AttemptHeader = "X-Chainlink-Delivery-Attempt"
+)
+
+// webhookSink POSTs the JSON of the results to a URL
+type webhookSink struct {
+	client *http.Client
+	url    string
+	secret null.String
+}
+
+func newWebhookSink(client *http.Client, url string, secret null.String) *webhookSink {
+	return &webhookSink{client, url, secret}
+}
+
+func (w *webhookSink) kind() string   { return "webhook" }
+func (w *webhookSink) String() string { return w.url }
+
+func (w *webhookSink) send(ctx context.Context, payload Payload, attempt uint32) (permanent bool, err error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return true, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(AttemptHeader, fmt.Sprint(attempt))
+	if w.secret.Valid {
+		req.Header.Set(SignatureHeader, Sign([]byte(w.secret.String), body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer logger.ErrorIfCalling(resp.Body.Close)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = errors.Errorf("got status %d", resp.StatusCode)
+	permanent = resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests
+	return permanent, err
+}
+
+// Sign returns the value of the SignatureHeader of body, for receivers to
+// compare the header to
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -28,6 +28,8 @@ var (
 		"sync_events",
 		"pipeline_runs",
 		"pipeline_task_runs",
+		// Detached partitions of the OCR state tables
+		"ocr_archive.*",
 	}
 )
 
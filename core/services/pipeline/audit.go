@@ -0,0 +1,118 @@
+package pipeline
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+type (
+	// RunAudit is a compact record of how a pipeline run arrived at its
+	// result: the raw value returned by each source, the inputs and output of
+	// every step that followed and any rounding applied along the way. It is
+	// only recorded when JobPipelineAuditMode is enabled.
+	RunAudit struct {
+		Steps    []AuditStep     `json:"steps"`
+		Rounding []AuditRounding `json:"rounding,omitempty"`
+	}
+
+	// AuditStep is the execution of a single task within an audited run
+	AuditStep struct {
+		DotID  string        `json:"dotId"`
+		Type   TaskType      `json:"type"`
+		Inputs []interface{} `json:"inputs,omitempty"`
+		Output interface{}   `json:"output,omitempty"`
+		Error  string        `json:"error,omitempty"`
+	}
+
+	// AuditRounding records a value that lost precision, either within a task
+	// or when the final result was converted for submission on-chain
+	AuditRounding struct {
+		Step string `json:"step"`
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+)
+
+// NewRunAudit builds the audit of a run from its task run results. Steps are
+// ordered by completion, so every step appears after the steps feeding it.
+func NewRunAudit(trrs TaskRunResults) *RunAudit {
+	sorted := make(TaskRunResults, len(trrs))
+	copy(sorted, trrs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].FinishedAt.Equal(sorted[j].FinishedAt) {
+			return sorted[i].FinishedAt.Before(sorted[j].FinishedAt)
+		}
+		return sorted[i].Task.DotID() < sorted[j].Task.DotID()
+	})
+
+	audit := &RunAudit{Steps: make([]AuditStep, 0, len(sorted))}
+	for _, trr := range sorted {
+		step := AuditStep{
+			DotID:  trr.Task.DotID(),
+			Type:   trr.Task.Type(),
+			Output: auditValue(trr.Result.Value),
+		}
+		for _, input := range trr.Inputs {
+			if input.Error != nil {
+				step.Inputs = append(step.Inputs, nil)
+			} else {
+				step.Inputs = append(step.Inputs, auditValue(input.Value))
+			}
+		}
+		if trr.Result.Error != nil {
+			step.Error = trr.Result.Error.Error()
+		}
+		audit.Steps = append(audit.Steps, step)
+
+		if scale, is := trr.Task.(*ScaleTask); is && trr.Result.Error == nil && len(trr.Inputs) == 1 {
+			if rounded, is := trr.Result.Value.(decimal.Decimal); is {
+				if value, err := utils.ToDecimal(trr.Inputs[0].Value); err == nil {
+					audit.AddRounding(step.DotID, value.Shift(int32(scale.Decimals)), rounded)
+				}
+			}
+		}
+	}
+	return audit
+}
+
+// AddRounding records that the given step rounded from to to. Nothing is
+// recorded if no precision was lost.
+func (a *RunAudit) AddRounding(step string, from, to decimal.Decimal) {
+	if from.Equal(to) {
+		return
+	}
+	a.Rounding = append(a.Rounding, AuditRounding{Step: step, From: from.String(), To: to.String()})
+}
+
+func (a *RunAudit) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("RunAudit#Scan received a value of type %T", value)
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+func (a RunAudit) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// auditValue returns v in a form that is faithfully represented in JSON. Raw
+// responses are kept as strings so they can be compared byte for byte with
+// what the source returned.
+func auditValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case []byte:
+		return string(x)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,99 @@
+package pipeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestNewRunAudit(t *testing.T) {
+	t.Parallel()
+
+	g := pipeline.NewTaskDAG()
+	err := g.UnmarshalText([]byte(`
+		ds1 [type=bridge name=one];
+		ds2 [type=bridge name=two];
+		scale [type=scale decimals=2];
+		answer [type=median];
+		ds1 -> answer;
+		ds2 -> answer;
+		answer -> scale;
+	`))
+	require.NoError(t, err)
+	tasks, err := g.TasksInDependencyOrder()
+	require.NoError(t, err)
+	byID := make(map[string]pipeline.Task)
+	for _, task := range tasks {
+		byID[task.DotID()] = task
+	}
+
+	start := time.Now()
+	trrs := pipeline.TaskRunResults{
+		{
+			Task:       byID["scale"],
+			Inputs:     []pipeline.Result{{Value: decimal.RequireFromString("1.2345")}},
+			Result:     pipeline.Result{Value: decimal.RequireFromString("123")},
+			FinishedAt: start.Add(2 * time.Second),
+			IsTerminal: true,
+		},
+		{
+			Task:       byID["ds2"],
+			Result:     pipeline.Result{Error: errors.New("bridge unavailable")},
+			FinishedAt: start,
+		},
+		{
+			Task:       byID["answer"],
+			Inputs:     []pipeline.Result{{Value: []byte("1.2345")}, {Error: errors.New("bridge unavailable")}},
+			Result:     pipeline.Result{Value: decimal.RequireFromString("1.2345")},
+			FinishedAt: start.Add(time.Second),
+		},
+		{
+			Task:       byID["ds1"],
+			Result:     pipeline.Result{Value: []byte("1.2345")},
+			FinishedAt: start,
+		},
+	}
+
+	audit := pipeline.NewRunAudit(trrs)
+	require.Len(t, audit.Steps, 4)
+
+	assert.Equal(t, "ds1", audit.Steps[0].DotID)
+	assert.Equal(t, pipeline.TaskTypeBridge, audit.Steps[0].Type)
+	assert.Equal(t, "1.2345", audit.Steps[0].Output)
+	assert.Equal(t, "ds2", audit.Steps[1].DotID)
+	assert.Equal(t, "bridge unavailable", audit.Steps[1].Error)
+	assert.Equal(t, "answer", audit.Steps[2].DotID)
+	assert.Equal(t, []interface{}{"1.2345", nil}, audit.Steps[2].Inputs)
+	assert.Equal(t, "scale", audit.Steps[3].DotID)
+
+	require.Len(t, audit.Rounding, 1)
+	assert.Equal(t, pipeline.AuditRounding{Step: "scale", From: "123.45", To: "123"}, audit.Rounding[0])
+
+	// The given results are left in their original order
+	assert.Equal(t, "scale", trrs[0].Task.DotID())
+
+	t.Run("round trips through the database", func(t *testing.T) {
+		v, err := audit.Value()
+		require.NoError(t, err)
+		var scanned pipeline.RunAudit
+		require.NoError(t, scanned.Scan(v))
+		assert.Equal(t, audit.Rounding, scanned.Rounding)
+		assert.Equal(t, "1.2345", scanned.Steps[0].Output)
+	})
+}
+
+func TestRunAudit_AddRounding(t *testing.T) {
+	t.Parallel()
+
+	var audit pipeline.RunAudit
+	audit.AddRounding("observation", decimal.RequireFromString("100"), decimal.RequireFromString("100"))
+	assert.Empty(t, audit.Rounding)
+	audit.AddRounding("observation", decimal.RequireFromString("100.5"), decimal.RequireFromString("100"))
+	assert.Equal(t, []pipeline.AuditRounding{{Step: "observation", From: "100.5", To: "100"}}, audit.Rounding)
+}
@@ -0,0 +1,198 @@
+package pipeline
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+const (
+	// autotuneInterval is how often the runner's parallelism is adjusted
+	autotuneInterval = 10 * time.Second
+
+	// Above autotuneHighDBLatency the database is taken to be struggling and
+	// a worker is taken away. Workers are only added below
+	// autotuneLowDBLatency.
+	autotuneHighDBLatency = 250 * time.Millisecond
+	autotuneLowDBLatency  = 50 * time.Millisecond
+
+	// The same goes for the share of the machine's CPU used by the node
+	autotuneHighCPU = 0.85
+	autotuneLowCPU  = 0.6
+)
+
+var promPipelineRunnerWorkers = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "pipeline_runner_workers",
+	Help: "The number of workers the pipeline runner processes runs with",
+})
+
+// autotuneSample is what the runner's parallelism is adjusted by
+type autotuneSample struct {
+	// dbLatency is how long counting the unfinished runs took
+	dbLatency time.Duration
+	// queueDepth is the number of unfinished runs
+	queueDepth int64
+	// cpu is the share of the machine's CPU used by the node since the last
+	// sample, or negative if unknown
+	cpu float64
+}
+
+// nextParallelism adjusts the number of workers by at most one, within
+// [min, max]. Backing off from a slow database or a busy CPU takes
+// precedence over working through the queue.
+func nextParallelism(current, min, max int, s autotuneSample) int {
+	next := current
+	switch {
+	case s.dbLatency > autotuneHighDBLatency, s.cpu > autotuneHighCPU:
+		next--
+	case s.queueDepth > int64(current) && s.dbLatency < autotuneLowDBLatency && s.cpu < autotuneLowCPU:
+		next++
+	case s.queueDepth == 0:
+		next--
+	}
+	if next < min {
+		next = min
+	}
+	if next > max {
+		next = max
+	}
+	return next
+}
+
+// workerPool runs a resizable number of copies of work. Each copy must
+// return once its stop channel is closed.
+type workerPool struct {
+	work  func(stop <-chan struct{})
+	mu    sync.Mutex
+	stops []chan struct{}
+}
+
+func (p *workerPool) resize(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.stops) < n {
+		stop := make(chan struct{})
+		p.stops = append(p.stops, stop)
+		go p.work(stop)
+	}
+	for len(p.stops) > n {
+		last := len(p.stops) - 1
+		close(p.stops[last])
+		p.stops = p.stops[:last]
+	}
+	promPipelineRunnerWorkers.Set(float64(n))
+}
+
+func (p *workerPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.stops)
+}
+
+// cpuSampler measures the share of the machine's CPU used by the node
+// between calls to sample
+type cpuSampler struct {
+	lastCPU  time.Duration
+	lastWall time.Time
+}
+
+func (c *cpuSampler) sample() float64 {
+	cpu, ok := processCPUTime()
+	if !ok {
+		return -1
+	}
+	now := time.Now()
+	defer func() { c.lastCPU, c.lastWall = cpu, now }()
+	if c.lastWall.IsZero() {
+		return -1
+	}
+	wall := now.Sub(c.lastWall) * time.Duration(runtime.NumCPU())
+	if wall <= 0 {
+		return -1
+	}
+	return float64(cpu-c.lastCPU) / float64(wall)
+}
+
+// parallelismBounds returns the bounds the runner's parallelism is autotuned
+// within, and whether it is autotuned at all
+func (r *runner) parallelismBounds() (min, max int, autotune bool) {
+	max = int(r.config.JobPipelineParallelismMax())
+	if max == 0 {
+		return 0, 0, false
+	}
+	min = int(r.config.JobPipelineParallelismMin())
+	if min < 1 {
+		min = 1
+	}
+	if max < min {
+		max = min
+	}
+	return min, max, true
+}
+
+// initialParallelism is the number of workers the runner starts with
+func (r *runner) initialParallelism() int {
+	n := int(r.config.JobPipelineParallelism())
+	if min, max, autotune := r.parallelismBounds(); autotune {
+		if n < min {
+			n = min
+		}
+		if n > max {
+			n = max
+		}
+	}
+	return n
+}
+
+// autotune adjusts the number of workers every autotuneInterval until the
+// runner is stopped
+func (r *runner) autotune() {
+	defer r.wgAutotune.Done()
+	min, max, _ := r.parallelismBounds()
+	var cpu cpuSampler
+	cpu.sample()
+
+	ticker := time.NewTicker(autotuneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s, err := r.sampleLoad(cpu.sample())
+			if err != nil {
+				logger.Errorw("Pipeline runner could not sample load to autotune parallelism", "err", err)
+				continue
+			}
+			current := r.workers.size()
+			next := nextParallelism(current, min, max, s)
+			if next == current {
+				continue
+			}
+			logger.Debugw("Pipeline runner: autotuned parallelism",
+				"from", current,
+				"to", next,
+				"dbLatency", s.dbLatency,
+				"queueDepth", s.queueDepth,
+				"cpu", s.cpu,
+			)
+			r.workers.resize(next)
+		case <-r.chStop:
+			return
+		}
+	}
+}
+
+func (r *runner) sampleLoad(cpu float64) (autotuneSample, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), autotuneInterval)
+	defer cancel()
+	s := autotuneSample{cpu: cpu}
+	start := time.Now()
+	err := r.orm.DB().WithContext(ctx).Raw(`SELECT count(*) FROM pipeline_runs WHERE finished_at IS NULL`).Scan(&s.queueDepth).Error
+	s.dbLatency = time.Since(start)
+	return s, err
+}
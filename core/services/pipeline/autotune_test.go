@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextParallelism(t *testing.T) {
+	t.Parallel()
+
+	idle := autotuneSample{dbLatency: 10 * time.Millisecond, cpu: 0.1}
+	queued := idle
+	queued.queueDepth = 100
+
+	tests := []struct {
+		name     string
+		current  int
+		sample   autotuneSample
+		expected int
+	}{
+		{"runs queued up", 4, queued, 5},
+		{"runs queued up, cpu unknown", 4, autotuneSample{dbLatency: idle.dbLatency, queueDepth: 100, cpu: -1}, 5},
+		{"runs queued up, at max", 8, queued, 8},
+		{"runs queued up, slow database", 4, autotuneSample{dbLatency: time.Second, queueDepth: 100, cpu: 0.1}, 3},
+		{"runs queued up, busy cpu", 4, autotuneSample{dbLatency: idle.dbLatency, queueDepth: 100, cpu: 0.95}, 3},
+		{"runs queued up, database neither slow nor fast", 4, autotuneSample{dbLatency: 100 * time.Millisecond, queueDepth: 100, cpu: 0.1}, 4},
+		{"fewer runs than workers", 4, autotuneSample{dbLatency: idle.dbLatency, queueDepth: 2, cpu: 0.1}, 4},
+		{"no runs", 4, idle, 3},
+		{"no runs, at min", 2, idle, 2},
+		{"below min", 1, autotuneSample{dbLatency: time.Second}, 2},
+		{"above max", 10, queued, 8},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, nextParallelism(test.current, 2, 8, test.sample))
+		})
+	}
+}
+
+func TestWorkerPool(t *testing.T) {
+	t.Parallel()
+
+	running := make(chan int, 10)
+	p := &workerPool{work: func(stop <-chan struct{}) {
+		running <- 1
+		<-stop
+		running <- -1
+	}}
+	count := func(n int) int {
+		total := 0
+		for i := 0; i < n; i++ {
+			total += <-running
+		}
+		return total
+	}
+
+	p.resize(3)
+	assert.Equal(t, 3, p.size())
+	assert.Equal(t, 3, count(3))
+
+	p.resize(1)
+	assert.Equal(t, 1, p.size())
+	assert.Equal(t, -2, count(2))
+
+	p.resize(0)
+	assert.Equal(t, 0, p.size())
+	assert.Equal(t, -1, count(1))
+}
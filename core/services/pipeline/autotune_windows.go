@@ -0,0 +1,11 @@
+// +build windows
+
+package pipeline
+
+import "time"
+
+// processCPUTime is not supported on windows, where autotuning goes by the
+// database and the run queue alone
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
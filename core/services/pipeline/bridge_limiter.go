@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// bridgeLimiter enforces the MaxConcurrentCalls of bridges, with a semaphore
+// for each bridge that is shared by every run on the node
+var bridgeLimiter = &bridgeSemaphores{sems: make(map[string]*bridgeSemaphore)}
+
+type bridgeSemaphores struct {
+	mu   sync.Mutex
+	sems map[string]*bridgeSemaphore
+}
+
+type bridgeSemaphore struct {
+	limit uint32
+	slots chan struct{}
+}
+
+// acquire waits for one of the limit call slots of the named bridge, or for
+// ctx to be done. The returned func must be called to give the slot back.
+// A limit of zero means no limit.
+//
+// If the limit of a bridge changes, calls already made under the old limit
+// count against it until they finish, but not against the new one.
+func (b *bridgeSemaphores) acquire(ctx context.Context, name string, limit uint32) (release func(), err error) {
+	if limit == 0 {
+		return func() {}, nil
+	}
+
+	b.mu.Lock()
+	sem, exists := b.sems[name]
+	if !exists || sem.limit != limit {
+		sem = &bridgeSemaphore{limit: limit, slots: make(chan struct{}, limit)}
+		b.sems[name] = sem
+	}
+	b.mu.Unlock()
+
+	select {
+	case sem.slots <- struct{}{}:
+		return func() { <-sem.slots }, nil
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "timed out waiting for one of the %d concurrent calls allowed to bridge %s", limit, name)
+	}
+}
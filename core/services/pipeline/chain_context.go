@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// ChainContextKey is the key of the chain context in the meta of pipeline
+// runs, and so in the meta sent to bridges
+const ChainContextKey = "chain"
+
+// ChainContext follows the latest head of the chain the node is on, so that
+// pipeline runs can be told about it. Tasks and external adapters can then
+// make chain-aware decisions without an eth_call of their own.
+type ChainContext struct {
+	chainID *big.Int
+
+	mu   sync.RWMutex
+	head *models.Head
+}
+
+// NewChainContext returns a ChainContext for the chain with the given ID. It
+// must be subscribed to new heads to know the latest block.
+func NewChainContext(chainID *big.Int) *ChainContext {
+	return &ChainContext{chainID: chainID}
+}
+
+// OnNewLongestChain implements services.HeadBroadcastable
+func (c *ChainContext) OnNewLongestChain(_ context.Context, head models.Head) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head = &head
+}
+
+// Values returns the chain context as it is added to the meta of runs. The
+// block is omitted until the first head has been seen.
+func (c *ChainContext) Values() map[string]interface{} {
+	values := map[string]interface{}{
+		"chainID": c.chainID.String(),
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.head != nil {
+		values["blockNumber"] = c.head.Number
+		values["blockHash"] = c.head.Hash.Hex()
+		values["blockTimestamp"] = c.head.Timestamp.Unix()
+	}
+	return values
+}
+
+// withChainContext returns meta with the chain context added. Meta that is
+// not an object is returned as is, as is meta that already has a chain
+// context, so that reruns see the chain as it was when the run was created.
+func withChainContext(meta JSONSerializable, chainContext *ChainContext) JSONSerializable {
+	if chainContext == nil {
+		return meta
+	}
+	var metaMap map[string]interface{}
+	switch v := meta.Val.(type) {
+	case map[string]interface{}:
+		if _, exists := v[ChainContextKey]; exists {
+			return meta
+		}
+		metaMap = make(map[string]interface{}, len(v)+1)
+		for k, val := range v {
+			metaMap[k] = val
+		}
+	case nil:
+		metaMap = make(map[string]interface{}, 1)
+	default:
+		return meta
+	}
+	metaMap[ChainContextKey] = chainContext.Values()
+	return JSONSerializable{Val: metaMap, Null: false}
+}
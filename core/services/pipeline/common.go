@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"net/url"
 	"reflect"
 	"sort"
@@ -45,9 +46,15 @@ type (
 		DefaultHTTPTimeout() models.Duration
 		DefaultMaxHTTPAttempts() uint
 		DefaultHTTPAllowUnrestrictedNetworkAccess() bool
+		Dev() bool
+		FeatureShadow() bool
 		TriggerFallbackDBPollInterval() time.Duration
+		JobPipelineAuditMode() bool
+		JobPipelineJSONParseLimit() int64
 		JobPipelineMaxRunDuration() time.Duration
 		JobPipelineParallelism() uint8
+		JobPipelineParallelismMax() uint8
+		JobPipelineParallelismMin() uint8
 		JobPipelineReaperInterval() time.Duration
 		JobPipelineReaperThreshold() time.Duration
 	}
@@ -56,8 +63,29 @@ type (
 var (
 	ErrWrongInputCardinality = errors.New("wrong number of task inputs")
 	ErrBadInput              = errors.New("bad input for task")
+	// ErrSchemaValidation is the error of an http or bridge task whose
+	// response does not match the task's responseSchema
+	ErrSchemaValidation = errors.New("response does not match responseSchema")
+	// ErrTaskPanicked is the error of a task that panicked, see TaskPanicError
+	ErrTaskPanicked = errors.New("pipeline task panicked")
 )
 
+// TaskPanicError is the error of a task that panicked. The runner recovers
+// from the panic, so that only the task fails, and saves the stack trace with
+// the task run.
+type TaskPanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *TaskPanicError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrTaskPanicked, e.Value)
+}
+
+func (e *TaskPanicError) Unwrap() error {
+	return ErrTaskPanicked
+}
+
 // Bundled tx and txmutex for multiple goroutines inside the same transaction.
 // This mutex is necessary to work to avoid
 // concurrent database calls inside the same transaction to fail.
@@ -79,19 +107,33 @@ func (result Result) OutputDB() JSONSerializable {
 	return JSONSerializable{Val: result.Value, Null: result.Value == nil}
 }
 
-// ErrorDB dumps a single result error for a pipeline_task_run
+// ErrorDB dumps a single result error for a pipeline_task_run. Suppression
+// isn't an error, so suppressed tasks have none.
 func (result Result) ErrorDB() null.String {
 	var errString null.String
-	if result.Error != nil {
+	if result.Error != nil && !errors.Is(result.Error, ErrRunSuppressed) {
 		errString = null.StringFrom(result.Error.Error())
 	}
 	return errString
 }
 
+// StackTraceDB dumps the stack trace of a task that panicked for a
+// pipeline_task_run
+func (result Result) StackTraceDB() null.String {
+	var panicErr *TaskPanicError
+	if errors.As(result.Error, &panicErr) {
+		return null.StringFrom(string(panicErr.Stack))
+	}
+	return null.String{}
+}
+
 // FinalResult is the result of a Run
 type FinalResult struct {
 	Values []interface{}
 	Errors []error
+	// Suppressed is set if a deltathreshold task suppressed any of the
+	// outputs, which then have neither a value nor an error
+	Suppressed bool
 }
 
 // OutputsDB dumps a result output for a pipeline_run
@@ -123,11 +165,15 @@ func (result FinalResult) HasErrors() bool {
 	return false
 }
 
-// SingularResult returns a single result if the FinalResult only has one set of outputs/errors
+// SingularResult returns a single result if the FinalResult only has one set of outputs/errors.
+// The result of a suppressed run has ErrRunSuppressed as its error, as it has no value.
 func (result FinalResult) SingularResult() (Result, error) {
 	if len(result.Errors) != 1 || len(result.Values) != 1 {
 		return Result{}, errors.Errorf("cannot cast FinalResult to singular result; it does not have exactly 1 error and exactly 1 output: %#v", result)
 	}
+	if result.Suppressed {
+		return Result{Error: ErrRunSuppressed}, nil
+	}
 	return Result{Error: result.Errors[0], Value: result.Values[0]}, nil
 }
 
@@ -139,10 +185,15 @@ type TaskRunResult struct {
 	ID         int64
 	Task       Task
 	TaskRun    TaskRun
+	Inputs     []Result
 	Result     Result
 	CreatedAt  time.Time
+	StartedAt  time.Time
 	FinishedAt time.Time
+	QueueWait  time.Duration
 	IsTerminal bool
+	// HTTPCalls is the requests made by the task, see RunCosts
+	HTTPCalls []HTTPCall
 }
 
 // TaskRunResults represents a collection of results for all task runs for one pipeline run
@@ -157,11 +208,18 @@ func (trrs TaskRunResults) FinalResult() FinalResult {
 		return trrs[i].Task.OutputIndex() < trrs[j].Task.OutputIndex()
 	})
 	for _, trr := range trrs {
-		if trr.IsTerminal {
-			fr.Values = append(fr.Values, trr.Result.Value)
-			fr.Errors = append(fr.Errors, trr.Result.Error)
-			found = true
+		if !trr.IsTerminal {
+			continue
+		}
+		found = true
+		if errors.Is(trr.Result.Error, ErrRunSuppressed) {
+			fr.Values = append(fr.Values, nil)
+			fr.Errors = append(fr.Errors, nil)
+			fr.Suppressed = true
+			continue
 		}
+		fr.Values = append(fr.Values, trr.Result.Value)
+		fr.Errors = append(fr.Errors, trr.Result.Error)
 	}
 
 	if !found {
@@ -222,17 +280,44 @@ func (js JSONSerializable) Value() (driver.Value, error) {
 type TaskType string
 
 const (
-	TaskTypeHTTP      TaskType = "http"
-	TaskTypeBridge    TaskType = "bridge"
-	TaskTypeMedian    TaskType = "median"
-	TaskTypeMultiply  TaskType = "multiply"
-	TaskTypeJSONParse TaskType = "jsonparse"
-	TaskTypeAny       TaskType = "any"
+	TaskTypeHTTP              TaskType = "http"
+	TaskTypeBridge            TaskType = "bridge"
+	TaskTypeMedian            TaskType = "median"
+	TaskTypeWeightedAggregate TaskType = "weightedaggregate"
+	TaskTypeMultiply          TaskType = "multiply"
+	TaskTypeScale             TaskType = "scale"
+	TaskTypeJSONParse         TaskType = "jsonparse"
+	TaskTypeAny               TaskType = "any"
+	TaskTypeSQL               TaskType = "sql"
+	TaskTypeEthCall           TaskType = "ethcall"
+	TaskTypeKVGet             TaskType = "kvget"
+	TaskTypeKVSet             TaskType = "kvset"
+	TaskTypeDeltaThreshold    TaskType = "deltathreshold"
+	TaskTypeGRPCBridge        TaskType = "grpcbridge"
 
 	// Testing only.
 	TaskTypePanic TaskType = "panic"
 )
 
+// experimentalTaskTypes are the task types that may only be used in jobs
+// with FEATURE_EXPERIMENTAL_TASKS enabled. New task types start out here
+// until they have proven themselves in production.
+var experimentalTaskTypes = map[TaskType]bool{
+	TaskTypeSQL:               true,
+	TaskTypeWeightedAggregate: true,
+	TaskTypeEthCall:           true,
+	TaskTypeKVGet:             true,
+	TaskTypeKVSet:             true,
+	TaskTypeDeltaThreshold:    true,
+	TaskTypeGRPCBridge:        true,
+}
+
+// IsExperimental reports whether the task type is gated by
+// FEATURE_EXPERIMENTAL_TASKS
+func (t TaskType) IsExperimental() bool {
+	return experimentalTaskTypes[TaskType(strings.ToLower(string(t)))]
+}
+
 func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, dotID string, config Config, txdb *gorm.DB, txdbMutex *sync.Mutex, nPreds int) (_ Task, err error) {
 	defer utils.WrapIfError(&err, "UnmarshalTaskFromMap")
 
@@ -254,12 +339,28 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, dotID string,
 		task = &BridgeTask{config: config, safeTx: SafeTx{txdb, txdbMutex}, BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
 	case TaskTypeMedian:
 		task = &MedianTask{BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
+	case TaskTypeWeightedAggregate:
+		task = &WeightedAggregateTask{BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
 	case TaskTypeAny:
 		task = &AnyTask{BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
 	case TaskTypeJSONParse:
 		task = &JSONParseTask{BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
 	case TaskTypeMultiply:
 		task = &MultiplyTask{BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
+	case TaskTypeScale:
+		task = &ScaleTask{BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
+	case TaskTypeSQL:
+		task = &SQLTask{safeTx: SafeTx{txdb, txdbMutex}, BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
+	case TaskTypeEthCall:
+		task = &EthCallTask{BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
+	case TaskTypeKVGet:
+		task = &KVGetTask{safeTx: SafeTx{txdb, txdbMutex}, BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
+	case TaskTypeKVSet:
+		task = &KVSetTask{safeTx: SafeTx{txdb, txdbMutex}, BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
+	case TaskTypeDeltaThreshold:
+		task = &DeltaThresholdTask{safeTx: SafeTx{txdb, txdbMutex}, BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
+	case TaskTypeGRPCBridge:
+		task = &GRPCBridgeTask{safeTx: SafeTx{txdb, txdbMutex}, BaseTask: BaseTask{dotID: dotID, nPreds: nPreds}}
 	default:
 		return nil, errors.Errorf(`unknown task type: "%v"`, taskType)
 	}
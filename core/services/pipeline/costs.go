@@ -0,0 +1,133 @@
+package pipeline
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type (
+	// HTTPCall is a request made by an http or bridge task. Each attempt of
+	// a retried request is a call of its own.
+	HTTPCall struct {
+		Host          string
+		BytesSent     int64
+		BytesReceived int64
+	}
+
+	// RunCosts is the resources used by a run, so that operators can tell
+	// what each of their jobs costs to run. It is recorded with every run,
+	// and added up for each job and day in the job_costs table, which
+	// outlives the run. The gas spent on the transactions that follow from
+	// runs is added to job_costs as they are confirmed.
+	RunCosts struct {
+		HTTP []HTTPCost `json:"http"`
+	}
+
+	// HTTPCost is the requests made by a run's tasks of one type to one host
+	HTTPCost struct {
+		Type          TaskType `json:"type"`
+		Host          string   `json:"host"`
+		Calls         int64    `json:"calls"`
+		BytesSent     int64    `json:"bytesSent"`
+		BytesReceived int64    `json:"bytesReceived"`
+	}
+)
+
+// NewRunCosts adds up the HTTP calls of a run's task runs by task type and
+// host
+func NewRunCosts(trrs TaskRunResults) *RunCosts {
+	type key struct {
+		typ  TaskType
+		host string
+	}
+	byKey := make(map[key]*HTTPCost)
+	costs := &RunCosts{HTTP: []HTTPCost{}}
+	for _, trr := range trrs {
+		for _, call := range trr.HTTPCalls {
+			k := key{trr.Task.Type(), call.Host}
+			cost, exists := byKey[k]
+			if !exists {
+				cost = &HTTPCost{Type: k.typ, Host: k.host}
+				byKey[k] = cost
+			}
+			cost.Calls++
+			cost.BytesSent += call.BytesSent
+			cost.BytesReceived += call.BytesReceived
+		}
+	}
+	for _, cost := range byKey {
+		costs.HTTP = append(costs.HTTP, *cost)
+	}
+	sort.Slice(costs.HTTP, func(i, j int) bool {
+		if costs.HTTP[i].Type != costs.HTTP[j].Type {
+			return costs.HTTP[i].Type < costs.HTTP[j].Type
+		}
+		return costs.HTTP[i].Host < costs.HTTP[j].Host
+	})
+	return costs
+}
+
+func (c *RunCosts) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("RunCosts#Scan received a value of type %T", value)
+	}
+	return json.Unmarshal(bytes, c)
+}
+
+func (c RunCosts) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// recordJobCosts adds the costs of a job's run to the job's costs for the day
+// the run was created on
+func recordJobCosts(tx *gorm.DB, jobID int32, createdAt time.Time, costs *RunCosts) error {
+	for _, cost := range costs.HTTP {
+		err := tx.Exec(`
+			INSERT INTO job_costs (job_id, day, kind, target, calls, bytes_sent, bytes_received)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (job_id, day, kind, target) DO UPDATE SET
+				calls = job_costs.calls + EXCLUDED.calls,
+				bytes_sent = job_costs.bytes_sent + EXCLUDED.bytes_sent,
+				bytes_received = job_costs.bytes_received + EXCLUDED.bytes_received
+		`, jobID, createdAt.UTC().Format("2006-01-02"), cost.Type, cost.Host, cost.Calls, cost.BytesSent, cost.BytesReceived).Error
+		if err != nil {
+			return errors.Wrap(err, "could not record job costs")
+		}
+	}
+	return nil
+}
+
+type httpCallRecorderKey struct{}
+
+// httpCallRecorder collects the HTTP calls made by a task
+type httpCallRecorder struct {
+	mu    sync.Mutex
+	calls []HTTPCall
+}
+
+func withHTTPCallRecorder(ctx context.Context, recorder *httpCallRecorder) context.Context {
+	return context.WithValue(ctx, httpCallRecorderKey{}, recorder)
+}
+
+// recordHTTPCall records a call made by the task running with ctx, if its
+// calls are being recorded
+func recordHTTPCall(ctx context.Context, call HTTPCall) {
+	recorder, ok := ctx.Value(httpCallRecorderKey{}).(*httpCallRecorder)
+	if !ok {
+		return
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.calls = append(recorder.calls, call)
+}
@@ -0,0 +1,50 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestNewRunCosts(t *testing.T) {
+	t.Parallel()
+
+	g := pipeline.NewTaskDAG()
+	err := g.UnmarshalText([]byte(`
+		ds1 [type=bridge name=one];
+		ds2 [type=http method=GET url="https://example.com"];
+		ds3 [type=http method=GET url="https://example.com/other"];
+		answer [type=median];
+		ds1 -> answer;
+		ds2 -> answer;
+		ds3 -> answer;
+	`))
+	require.NoError(t, err)
+	tasks, err := g.TasksInDependencyOrder()
+	require.NoError(t, err)
+	byID := make(map[string]pipeline.Task)
+	for _, task := range tasks {
+		byID[task.DotID()] = task
+	}
+
+	trrs := pipeline.TaskRunResults{
+		{Task: byID["answer"]},
+		{Task: byID["ds3"], HTTPCalls: []pipeline.HTTPCall{{Host: "example.com", BytesSent: 1, BytesReceived: 10}}},
+		{Task: byID["ds2"], HTTPCalls: []pipeline.HTTPCall{{Host: "example.com", BytesSent: 2, BytesReceived: 20}}},
+		{Task: byID["ds1"], HTTPCalls: []pipeline.HTTPCall{
+			{Host: "bridge.example.com", BytesSent: 3, BytesReceived: 30},
+			{Host: "bridge.example.com", BytesSent: 3, BytesReceived: 30},
+		}},
+	}
+
+	costs := pipeline.NewRunCosts(trrs)
+	assert.Equal(t, []pipeline.HTTPCost{
+		{Type: pipeline.TaskTypeBridge, Host: "bridge.example.com", Calls: 2, BytesSent: 6, BytesReceived: 60},
+		{Type: pipeline.TaskTypeHTTP, Host: "example.com", Calls: 2, BytesSent: 3, BytesReceived: 30},
+	}, costs.HTTP)
+
+	assert.Equal(t, []pipeline.HTTPCost{}, pipeline.NewRunCosts(pipeline.TaskRunResults{{Task: byID["answer"]}}).HTTP)
+}
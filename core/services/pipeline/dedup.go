@@ -0,0 +1,15 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LogDedupKey is the dedup key of a run of a job triggered by a log. The
+// same log, whether replayed after a restart or re-broadcast after a reorg
+// back onto the same block, gets the same key, so it only triggers one run
+// of the job.
+func LogDedupKey(jobID int32, blockHash common.Hash, logIndex uint) string {
+	return fmt.Sprintf("log:%d:%s:%d", jobID, blockHash.Hex(), logIndex)
+}
@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// UpstreamMetaKey is the meta key under which a run triggered by a job's
+// dependsOn holds the upstream run that triggered it, e.g.
+//
+//	{"upstream": {"jobID": 1, "jobName": "ETH/USD", "runID": 42, "outputs": ["2000.5"]}}
+const UpstreamMetaKey = "upstream"
+
+// createDependentRuns creates a run of every job that dependsOn the job of
+// the given finished run, passing the run's outputs in as meta. Only
+// successful runs trigger their dependents, not errored or suppressed ones. A dependent job whose meta schema
+// rejects the upstream meta is skipped. The tx argument must be an already
+// started transaction.
+func createDependentRuns(tx *gorm.DB, upstream Run) error {
+	if upstream.JobID == nil || upstream.HasErrors() || upstream.Suppressed {
+		return nil
+	}
+
+	var dependents []struct {
+		ID      int32
+		JobName string
+	}
+	err := tx.Raw(`
+		SELECT dependents.id, upstream.name AS job_name
+		FROM jobs upstream
+		INNER JOIN jobs dependents ON dependents.depends_on = upstream.name
+		WHERE upstream.id = ? AND dependents.id != upstream.id AND dependents.archived_at IS NULL
+		ORDER BY dependents.id ASC
+	`, *upstream.JobID).Scan(&dependents).Error
+	if err != nil {
+		return errors.Wrap(err, "could not load dependent jobs")
+	} else if len(dependents) == 0 {
+		return nil
+	}
+
+	meta := map[string]interface{}{
+		UpstreamMetaKey: map[string]interface{}{
+			"jobID":   *upstream.JobID,
+			"jobName": dependents[0].JobName,
+			"runID":   upstream.ID,
+			"outputs": upstream.Outputs.Val,
+		},
+	}
+	for _, dependent := range dependents {
+		err := validateRunMeta(tx, dependent.ID, meta)
+		var metaErr *MetaValidationError
+		if errors.As(err, &metaErr) {
+			logger.Warnw("Skipping run of dependent job, upstream meta does not match its meta schema",
+				"jobID", dependent.ID, "upstreamRunID", upstream.ID, "error", err)
+			continue
+		} else if err != nil {
+			return err
+		}
+		runID, err := insertRun(tx, dependent.ID, meta)
+		if err != nil {
+			return errors.Wrapf(err, "could not create run of dependent job %v", dependent.ID)
+		}
+		logger.Debugw("Triggered run of dependent job", "jobID", dependent.ID, "runID", runID, "upstreamRunID", upstream.ID)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -123,6 +124,34 @@ func (g TaskDAG) outputs() []*taskDAGNode {
 	return outputs
 }
 
+// DOTTask is a task of a TaskDAG as it is declared in DOT
+type DOTTask struct {
+	ID      string            `json:"id"`
+	Attrs   map[string]string `json:"attrs"`
+	Outputs []string          `json:"outputs,omitempty"`
+}
+
+// DOTTasks returns the DAG's tasks sorted by DOT ID, so that DAGs with the same
+// tasks and edges return the same tasks however their DOT was written
+func (g TaskDAG) DOTTasks() []DOTTask {
+	if g.DirectedGraph == nil {
+		return nil
+	}
+	var tasks []DOTTask
+	iter := g.Nodes()
+	for iter.Next() {
+		node := iter.Node().(*taskDAGNode)
+		task := DOTTask{ID: node.dotID, Attrs: node.attrs}
+		for _, output := range node.outputs() {
+			task.Outputs = append(task.Outputs, output.dotID)
+		}
+		sort.Strings(task.Outputs)
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks
+}
+
 type taskDAGNode struct {
 	graph.Node
 	g     *TaskDAG
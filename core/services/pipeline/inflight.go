@@ -0,0 +1,81 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// inFlightRuns tracks the runs that are being executed for each job, so that
+// they can be cancelled or waited for when the job is deleted
+type inFlightRuns struct {
+	mu   sync.Mutex
+	runs map[int32]map[*inFlightRun]struct{}
+}
+
+type inFlightRun struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newInFlightRuns() *inFlightRuns {
+	return &inFlightRuns{runs: make(map[int32]map[*inFlightRun]struct{})}
+}
+
+// track returns a context for a run of the job that is cancelled by cancel,
+// and a func to call once the run has finished. Runs without a job are not
+// tracked.
+func (f *inFlightRuns) track(ctx context.Context, jobID int32) (context.Context, func()) {
+	if jobID == 0 {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	run := &inFlightRun{cancel: cancel, done: make(chan struct{})}
+
+	f.mu.Lock()
+	if f.runs[jobID] == nil {
+		f.runs[jobID] = make(map[*inFlightRun]struct{})
+	}
+	f.runs[jobID][run] = struct{}{}
+	f.mu.Unlock()
+
+	return ctx, func() {
+		f.mu.Lock()
+		delete(f.runs[jobID], run)
+		if len(f.runs[jobID]) == 0 {
+			delete(f.runs, jobID)
+		}
+		f.mu.Unlock()
+		cancel()
+		close(run.done)
+	}
+}
+
+// cancel cancels the job's runs, returning how many there were
+func (f *inFlightRuns) cancel(jobID int32) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for run := range f.runs[jobID] {
+		run.cancel()
+	}
+	return len(f.runs[jobID])
+}
+
+// await waits for the job's runs that are in flight to finish. Runs started
+// while waiting are not waited for.
+func (f *inFlightRuns) await(ctx context.Context, jobID int32) error {
+	f.mu.Lock()
+	var done []chan struct{}
+	for run := range f.runs[jobID] {
+		done = append(done, run.done)
+	}
+	f.mu.Unlock()
+
+	for _, ch := range done {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
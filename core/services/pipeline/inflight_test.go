@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlightRuns(t *testing.T) {
+	f := newInFlightRuns()
+
+	ctx1, finished1 := f.track(context.Background(), 1)
+	ctx2, finished2 := f.track(context.Background(), 2)
+	defer finished2()
+	untrackedCtx, _ := f.track(context.Background(), 0)
+
+	assert.Equal(t, 0, f.cancel(3))
+	assert.Equal(t, 1, f.cancel(1))
+	assert.Error(t, ctx1.Err())
+	assert.NoError(t, ctx2.Err())
+	assert.NoError(t, untrackedCtx.Err())
+
+	t.Run("await times out while runs are in flight", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, f.await(ctx, 1))
+	})
+
+	t.Run("await returns once runs have finished", func(t *testing.T) {
+		go finished1()
+		require.NoError(t, f.await(context.Background(), 1))
+		assert.Equal(t, 0, f.cancel(1))
+		require.NoError(t, f.await(context.Background(), 3))
+	})
+}
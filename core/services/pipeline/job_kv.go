@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrKeyNotFound is returned by kvget tasks for keys that have not been
+	// set by their job and have no default
+	ErrKeyNotFound = errors.New("key not found in the job's key/value store")
+	// ErrNoJobKVStore is returned by kvget and kvset tasks in runs that do
+	// not belong to a job, and so have no key/value store
+	ErrNoJobKVStore = errors.New("only runs of a job have a key/value store")
+)
+
+// loadJobKV returns the value stored under key by the given job
+func loadJobKV(tx SafeTx, jobID int32, key string) (JSONSerializable, error) {
+	if tx.txMu != nil {
+		tx.txMu.Lock()
+		defer tx.txMu.Unlock()
+	}
+	var value JSONSerializable
+	err := tx.tx.Raw(`SELECT value FROM job_kv_store WHERE job_id = ? AND key = ?`, jobID, key).Row().Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return value, errors.Wrapf(ErrKeyNotFound, "key %q", key)
+	}
+	return value, errors.Wrapf(err, "could not load key %q", key)
+}
+
+// saveJobKV stores value under key for the given job, replacing any value it
+// had
+func saveJobKV(tx SafeTx, jobID int32, key string, value JSONSerializable) error {
+	if tx.txMu != nil {
+		tx.txMu.Lock()
+		defer tx.txMu.Unlock()
+	}
+	err := tx.tx.Exec(`
+		INSERT INTO job_kv_store (job_id, key, value, updated_at) VALUES (?, ?, ?, NOW())
+		ON CONFLICT (job_id, key) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	`, jobID, key, value).Error
+	return errors.Wrapf(err, "could not save key %q", key)
+}
+
+// deferredJobKV holds writes to a job's key/value store until its run has
+// finished, so that they are only made if the whole run succeeds
+type deferredJobKV struct {
+	mu     sync.Mutex
+	keys   []string
+	values map[string]JSONSerializable
+}
+
+func newDeferredJobKV() *deferredJobKV {
+	return &deferredJobKV{values: make(map[string]JSONSerializable)}
+}
+
+// set stores value under key once the run is committed
+func (d *deferredJobKV) set(key string, value JSONSerializable) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, exists := d.values[key]; !exists {
+		d.keys = append(d.keys, key)
+	}
+	d.values[key] = value
+}
+
+// commit saves the deferred writes for the given job
+func (d *deferredJobKV) commit(tx SafeTx, jobID int32) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, key := range d.keys {
+		if err := saveJobKV(tx, jobID, key, d.values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+func Test_PipelineRunner_JobKVStore(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	orm := new(mocks.ORM)
+	orm.On("DB").Return(store.DB)
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
+
+	jb := cltest.MustInsertSampleDirectRequestJob(t, store.DB)
+	spec := pipeline.Spec{JobID: jb.ID, DotDagSource: `
+previous [type=kvget key="total" default="1"];
+double   [type=multiply times=2];
+save     [type=kvset key="total"];
+previous -> double -> save;
+`}
+
+	// Each run doubles the total saved by the one before
+	for _, expected := range []string{"2", "4"} {
+		trrs, err := r.ExecuteRun(context.Background(), spec, pipeline.JSONSerializable{}, *logger.Default)
+		require.NoError(t, err)
+		result := trrs.FinalResult()
+		require.NoError(t, result.Errors[0])
+		assert.Equal(t, decimal.RequireFromString(expected).String(), result.Values[0].(decimal.Decimal).String())
+	}
+
+	t.Run("keys that were never set and have no default", func(t *testing.T) {
+		spec := pipeline.Spec{JobID: jb.ID, DotDagSource: `previous [type=kvget key="missing"];`}
+		trrs, err := r.ExecuteRun(context.Background(), spec, pipeline.JSONSerializable{}, *logger.Default)
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.ErrKeyNotFound, errors.Cause(trrs.FinalResult().Errors[0]))
+	})
+
+	t.Run("runs without a job", func(t *testing.T) {
+		spec := pipeline.Spec{DotDagSource: `previous [type=kvget key="total" default="1"];`}
+		trrs, err := r.ExecuteRun(context.Background(), spec, pipeline.JSONSerializable{}, *logger.Default)
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.ErrNoJobKVStore, trrs.FinalResult().Errors[0])
+	})
+}
@@ -0,0 +1,312 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JSONSchema is a JSON schema that values such as run meta and task responses
+// can be validated against. The following subset of JSON Schema is supported:
+//   - type, as a single type name or a list of them
+//   - properties, required and additionalProperties (as a boolean)
+//   - items
+//   - enum
+//   - minimum and maximum
+//   - minLength, maxLength and pattern
+//
+// Any other keyword is rejected, rather than ignored, so that a schema never
+// appears to enforce more than it does. Only the annotations $schema, $id,
+// $comment, title and description, which don't constrain values, are
+// allowed as well.
+type JSONSchema struct {
+	Type                 jsonSchemaTypes        `json:"type"`
+	Properties           map[string]*JSONSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *JSONSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	Pattern              string                 `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// jsonSchemaTypes is the type keyword, which may be a single type or a list
+type jsonSchemaTypes []string
+
+func (t *jsonSchemaTypes) UnmarshalJSON(input []byte) error {
+	var single string
+	if err := json.Unmarshal(input, &single); err == nil {
+		*t = jsonSchemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(input, &list); err != nil {
+		return errors.New("type must be a string or a list of strings")
+	}
+	*t = list
+	return nil
+}
+
+var jsonSchemaTypeNames = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+// jsonSchemaKeywords are the keywords of JSONSchema, and the annotations
+// that are allowed alongside them
+var jsonSchemaKeywords = map[string]bool{
+	"type": true, "properties": true, "required": true, "additionalProperties": true,
+	"items": true, "enum": true, "minimum": true, "maximum": true,
+	"minLength": true, "maxLength": true, "pattern": true,
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true,
+}
+
+// ParseJSONSchema parses and checks a JSON schema. It returns an error for
+// keywords that JSONSchema doesn't implement.
+func ParseJSONSchema(schema string) (*JSONSchema, error) {
+	var raw interface{}
+	if err := json.Unmarshal([]byte(schema), &raw); err != nil {
+		return nil, errors.Wrap(err, "schema is not valid JSON")
+	}
+	if err := checkJSONSchemaKeywords("schema", raw); err != nil {
+		return nil, err
+	}
+	var s JSONSchema
+	if err := json.Unmarshal([]byte(schema), &s); err != nil {
+		return nil, errors.Wrap(err, "schema is not valid JSON")
+	}
+	if err := s.compile("schema"); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// checkJSONSchemaKeywords returns an error for the first keyword in the
+// schema, or in its properties and items, that JSONSchema doesn't implement
+func checkJSONSchemaKeywords(path string, raw interface{}) error {
+	schema, ok := raw.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("%s: schema must be an object", path)
+	}
+	// Sorted, so that the same keyword is reported each time
+	keywords := make([]string, 0, len(schema))
+	for keyword := range schema {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	for _, keyword := range keywords {
+		if !jsonSchemaKeywords[keyword] {
+			return errors.Errorf("%s: unsupported keyword %q", path, keyword)
+		}
+	}
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := checkJSONSchemaKeywords(path+"."+name, properties[name]); err != nil {
+				return err
+			}
+		}
+	}
+	if items, exists := schema["items"]; exists {
+		return checkJSONSchemaKeywords(path+"[]", items)
+	}
+	return nil
+}
+
+func (s *JSONSchema) compile(path string) error {
+	for _, t := range s.Type {
+		if !jsonSchemaTypeNames[t] {
+			return errors.Errorf("%s: unknown type %q", path, t)
+		}
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return errors.Wrapf(err, "%s: invalid pattern", path)
+		}
+		s.pattern = pattern
+	}
+	for name, property := range s.Properties {
+		if property == nil {
+			return errors.Errorf("%s.%s: schema must be an object", path, name)
+		}
+		if err := property.compile(path + "." + name); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile(path + "[]")
+	}
+	return nil
+}
+
+// SchemaFieldError is a single mismatch between a value and a JSONSchema.
+// Field is the path to the offending value, e.g. meta.data.price or
+// response.ids[2].
+type SchemaFieldError struct {
+	Field   string
+	Message string
+}
+
+func (e SchemaFieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Validate checks value against the schema, returning every mismatch rather
+// than just the first. Paths in the mismatches start with root.
+func (s *JSONSchema) Validate(root string, value interface{}) []SchemaFieldError {
+	var fields []SchemaFieldError
+	s.validate(root, value, &fields)
+	return fields
+}
+
+// joinSchemaFieldErrors joins mismatches into a single message
+func joinSchemaFieldErrors(fields []SchemaFieldError) string {
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (s *JSONSchema) validate(path string, value interface{}, fields *[]SchemaFieldError) {
+	fail := func(format string, args ...interface{}) {
+		*fields = append(*fields, SchemaFieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	actual := jsonTypeOf(value)
+	if len(s.Type) > 0 && !s.allowsType(actual, value) {
+		fail("expected %s, got %s", strings.Join(s.Type, " or "), actual)
+		return
+	}
+	if len(s.Enum) > 0 && !jsonEnumContains(s.Enum, value) {
+		fail("must be one of %v", s.Enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, exists := v[name]; !exists {
+				*fields = append(*fields, SchemaFieldError{Field: path + "." + name, Message: "is required"})
+			}
+		}
+		// Sorted, so that errors are listed in a stable order
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, exists := s.Properties[name]; exists {
+				property.validate(path+"."+name, v[name], fields)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*fields = append(*fields, SchemaFieldError{Field: path + "." + name, Message: "is not allowed"})
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, fields)
+			}
+		}
+	case string:
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			fail("must be at least %d characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && len(v) > *s.MaxLength {
+			fail("must be at most %d characters long", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("must match pattern %q", s.Pattern)
+		}
+	default:
+		if n, ok := jsonNumber(value); ok {
+			if s.Minimum != nil && n < *s.Minimum {
+				fail("must be at least %v", *s.Minimum)
+			}
+			if s.Maximum != nil && n > *s.Maximum {
+				fail("must be at most %v", *s.Maximum)
+			}
+		}
+	}
+}
+
+func (s *JSONSchema) allowsType(actual string, value interface{}) bool {
+	for _, t := range s.Type {
+		if t == actual {
+			return true
+		}
+		if t == "integer" && actual == "number" {
+			if n, _ := jsonNumber(value); n == math.Trunc(n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if _, ok := jsonNumber(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func jsonNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func jsonEnumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if a, ok := jsonNumber(allowed); ok {
+			if n, ok := jsonNumber(value); ok && a == n {
+				return true
+			}
+		} else if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestJSONSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	schema, err := pipeline.ParseJSONSchema(`{
+		"type": "object",
+		"required": ["requestID", "data"],
+		"additionalProperties": false,
+		"properties": {
+			"requestID": {"type": "string", "pattern": "^0x[0-9a-f]+$"},
+			"data": {
+				"type": "object",
+				"properties": {
+					"coin": {"enum": ["ETH", "BTC"]},
+					"times": {"type": "integer", "minimum": 1}
+				}
+			},
+			"ids": {"type": "array", "items": {"type": ["integer", "null"]}}
+		}
+	}`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		meta   map[string]interface{}
+		errors []string
+	}{
+		{
+			"valid",
+			map[string]interface{}{
+				"requestID": "0xabc",
+				"data":      map[string]interface{}{"coin": "ETH", "times": float64(100)},
+				"ids":       []interface{}{float64(1), nil},
+			},
+			nil,
+		},
+		{
+			"missing required fields",
+			map[string]interface{}{},
+			[]string{"meta.requestID: is required", "meta.data: is required"},
+		},
+		{
+			"every mismatch is listed",
+			map[string]interface{}{
+				"requestID": "abc",
+				"data":      map[string]interface{}{"coin": "DOGE", "times": float64(0.5)},
+				"ids":       []interface{}{"one"},
+				"extra":     true,
+			},
+			[]string{
+				`meta.data.coin: must be one of [ETH BTC]`,
+				`meta.data.times: expected integer, got number`,
+				`meta.extra: is not allowed`,
+				`meta.ids[0]: expected integer or null, got string`,
+				`meta.requestID: must match pattern "^0x[0-9a-f]+$"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var messages []string
+			for _, field := range schema.Validate("meta", test.meta) {
+				messages = append(messages, field.Error())
+			}
+			assert.Equal(t, test.errors, messages)
+		})
+	}
+}
+
+func TestParseJSONSchema_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := pipeline.ParseJSONSchema(`{"type": "object"`)
+	assert.Error(t, err)
+	_, err = pipeline.ParseJSONSchema(`{"properties": {"a": {"type": "float"}}}`)
+	assert.EqualError(t, err, `schema.a: unknown type "float"`)
+	_, err = pipeline.ParseJSONSchema(`{"pattern": "("}`)
+	assert.Error(t, err)
+}
+
+func TestParseJSONSchema_UnsupportedKeywords(t *testing.T) {
+	t.Parallel()
+
+	_, err := pipeline.ParseJSONSchema(`{"oneOf": [{"type": "string"}, {"type": "number"}]}`)
+	assert.EqualError(t, err, `schema: unsupported keyword "oneOf"`)
+	_, err = pipeline.ParseJSONSchema(`{"properties": {"price": {"type": "number", "exclusiveMinimum": 0}}}`)
+	assert.EqualError(t, err, `schema.price: unsupported keyword "exclusiveMinimum"`)
+	_, err = pipeline.ParseJSONSchema(`{"items": {"format": "uri"}}`)
+	assert.EqualError(t, err, `schema[]: unsupported keyword "format"`)
+
+	_, err = pipeline.ParseJSONSchema(`{"$schema": "http://json-schema.org/draft-07/schema#", "title": "Meta", "description": "The run's meta", "type": "object"}`)
+	assert.NoError(t, err)
+}
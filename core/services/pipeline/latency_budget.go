@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// LatencyBudgetHeadroom is the fraction of a run's latency budget that is
+// held back from its tasks, leaving time to use the result after the last
+// task finishes.
+const LatencyBudgetHeadroom = 0.1
+
+// ErrLatencyBudgetExceeded is the error of a task that was pre-empted because
+// it used up its share of the run's latency budget
+var ErrLatencyBudgetExceeded = errors.New("task exceeded its share of the latency budget")
+
+var promPipelineTaskLatencyBudgetExceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "pipeline_task_latency_budget_exceeded",
+	Help: "The number of times a pipeline task was pre-empted for exceeding its share of the run's latency budget",
+},
+	[]string{"job_id", "job_name", "task_id"},
+)
+
+type latencyBudgetKey struct{}
+
+// WithLatencyBudget returns a copy of ctx that gives runs executed with it a
+// latency budget. Rather than every task racing a single deadline, the runner
+// shares the budget out between the tasks and pre-empts any task that runs
+// past its share, so that the task which blew the budget is the one that
+// fails.
+func WithLatencyBudget(ctx context.Context, budget time.Duration) context.Context {
+	return context.WithValue(ctx, latencyBudgetKey{}, budget)
+}
+
+func latencyBudgetFrom(ctx context.Context) (time.Duration, bool) {
+	budget, ok := ctx.Value(latencyBudgetKey{}).(time.Duration)
+	return budget, ok && budget > 0
+}
+
+// latencyBudgetWeight is a task's share of a latency budget relative to other
+// tasks. Tasks that wait on the network get most of the budget.
+func latencyBudgetWeight(task Task) float64 {
+	switch task.Type() {
+	case TaskTypeHTTP, TaskTypeBridge, TaskTypeGRPCBridge, TaskTypeSQL:
+		return 10
+	default:
+		return 1
+	}
+}
+
+// taskDeadlines shares a latency budget starting at start out between tasks,
+// which must be in dependency order. Each task's share is proportional to its
+// weight, and its deadline falls after the shares of the heaviest chain of
+// tasks leading to it, so the last tasks are due when the budget less its
+// headroom runs out. Time left over by a task that finishes early passes to
+// its successors.
+func taskDeadlines(tasks []Task, start time.Time, budget time.Duration) map[string]time.Time {
+	usable := float64(budget) * (1 - LatencyBudgetHeadroom)
+
+	cumulative := make(map[string]float64, len(tasks))
+	predecessors := make(map[string]float64, len(tasks))
+	var total float64
+	for _, task := range tasks {
+		weight := predecessors[task.DotID()] + latencyBudgetWeight(task)
+		cumulative[task.DotID()] = weight
+		if weight > total {
+			total = weight
+		}
+		if next := task.OutputTask(); next != nil && weight > predecessors[next.DotID()] {
+			predecessors[next.DotID()] = weight
+		}
+	}
+
+	deadlines := make(map[string]time.Time, len(tasks))
+	for dotID, weight := range cumulative {
+		deadlines[dotID] = start.Add(time.Duration(usable * weight / total))
+	}
+	return deadlines
+}
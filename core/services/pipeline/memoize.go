@@ -0,0 +1,107 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// memoizedDefinitions returns the definitions of the tasks that set
+// memoize=true, by dot ID. A definition is the task's type and attributes,
+// apart from its index, which only positions its output, so that identical
+// tasks copied into several branches of a spec have the same definition.
+func (g TaskDAG) memoizedDefinitions() map[string]string {
+	definitions := make(map[string]string)
+	iter := g.Nodes()
+	for iter.Next() {
+		node := iter.Node().(*taskDAGNode)
+		if memoize, _ := strconv.ParseBool(node.attrs["memoize"]); !memoize {
+			continue
+		}
+		keys := make([]string, 0, len(node.attrs))
+		for key := range node.attrs {
+			if key != "index" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		attrs := make([][2]string, len(keys))
+		for i, key := range keys {
+			attrs[i] = [2]string{key, node.attrs[key]}
+		}
+		definition, err := json.Marshal(attrs)
+		if err != nil {
+			continue
+		}
+		definitions[node.dotID] = string(definition)
+	}
+	return definitions
+}
+
+// runMemo shares the results of memoized tasks between the tasks of a run
+// that have the same definition and inputs. The first of them to run
+// executes, and the others wait for its result.
+type runMemo struct {
+	mu      sync.Mutex
+	entries map[string]*memoEntry
+}
+
+type memoEntry struct {
+	done   chan struct{}
+	result Result
+}
+
+func newRunMemo() *runMemo {
+	return &runMemo{entries: make(map[string]*memoEntry)}
+}
+
+// do returns the result of the task with definition and inputs, calling
+// execute if no other task has, and whether it was shared
+func (m *runMemo) do(ctx context.Context, definition string, inputs []Result, execute func() Result) (Result, bool) {
+	key, err := memoKey(definition, inputs)
+	if err != nil {
+		return execute(), false
+	}
+
+	m.mu.Lock()
+	entry, exists := m.entries[key]
+	if !exists {
+		entry = &memoEntry{done: make(chan struct{})}
+		m.entries[key] = entry
+	}
+	m.mu.Unlock()
+
+	if exists {
+		select {
+		case <-entry.done:
+			return entry.result, true
+		case <-ctx.Done():
+			return Result{Error: ctx.Err()}, false
+		}
+	}
+
+	defer close(entry.done)
+	entry.result = execute()
+	return entry.result, false
+}
+
+func memoKey(definition string, inputs []Result) (string, error) {
+	type memoInput struct {
+		Value interface{} `json:"value"`
+		Error string      `json:"error"`
+	}
+	ins := make([]memoInput, len(inputs))
+	for i, input := range inputs {
+		ins[i].Value = input.Value
+		if input.Error != nil {
+			ins[i].Error = input.Error.Error()
+		}
+	}
+	bs, err := json.Marshal(ins)
+	if err != nil {
+		return "", err
+	}
+	return definition + string(bs), nil
+}
@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+// MetaValidationError is returned when creating a run with meta that does
+// not match its job's meta schema. It lists every mismatch, not just the
+// first.
+type MetaValidationError struct {
+	Fields []SchemaFieldError
+}
+
+func (e *MetaValidationError) Error() string {
+	return "run meta does not match the job's meta schema: " + joinSchemaFieldErrors(e.Fields)
+}
+
+// validateRunMeta checks meta against the meta schema of the job, if it has
+// one
+func validateRunMeta(tx *gorm.DB, jobID int32, meta map[string]interface{}) error {
+	var metaSchema null.String
+	err := tx.Raw(`SELECT meta_schema FROM jobs WHERE id = ?`, jobID).Row().Scan(&metaSchema)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "could not load meta schema")
+	}
+	return checkRunMeta(metaSchema, meta)
+}
+
+// checkRunMeta checks meta against a job's meta schema, if it has one
+func checkRunMeta(metaSchema null.String, meta map[string]interface{}) error {
+	if !metaSchema.Valid {
+		return nil
+	}
+	schema, err := ParseJSONSchema(metaSchema.String)
+	if err != nil {
+		return err
+	}
+	var value interface{} = meta
+	if meta == nil {
+		value = map[string]interface{}{}
+	}
+	if fields := schema.Validate("meta", value); len(fields) > 0 {
+		return &MetaValidationError{Fields: fields}
+	}
+	return nil
+}
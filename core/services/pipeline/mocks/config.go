@@ -116,6 +116,62 @@ func (_m *Config) DefaultMaxHTTPAttempts() uint {
 	return r0
 }
 
+// Dev provides a mock function with given fields:
+func (_m *Config) Dev() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// FeatureShadow provides a mock function with given fields:
+func (_m *Config) FeatureShadow() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// JobPipelineAuditMode provides a mock function with given fields:
+func (_m *Config) JobPipelineAuditMode() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// JobPipelineJSONParseLimit provides a mock function with given fields:
+func (_m *Config) JobPipelineJSONParseLimit() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
 // JobPipelineMaxRunDuration provides a mock function with given fields:
 func (_m *Config) JobPipelineMaxRunDuration() time.Duration {
 	ret := _m.Called()
@@ -144,6 +200,34 @@ func (_m *Config) JobPipelineParallelism() uint8 {
 	return r0
 }
 
+// JobPipelineParallelismMax provides a mock function with given fields:
+func (_m *Config) JobPipelineParallelismMax() uint8 {
+	ret := _m.Called()
+
+	var r0 uint8
+	if rf, ok := ret.Get(0).(func() uint8); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint8)
+	}
+
+	return r0
+}
+
+// JobPipelineParallelismMin provides a mock function with given fields:
+func (_m *Config) JobPipelineParallelismMin() uint8 {
+	ret := _m.Called()
+
+	var r0 uint8
+	if rf, ok := ret.Get(0).(func() uint8); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint8)
+	}
+
+	return r0
+}
+
 // JobPipelineReaperInterval provides a mock function with given fields:
 func (_m *Config) JobPipelineReaperInterval() time.Duration {
 	ret := _m.Called()
@@ -58,20 +58,43 @@ func (_m *ORM) CreateRun(ctx context.Context, jobID int32, meta map[string]inter
 	return r0, r1
 }
 
-// CreateSpec provides a mock function with given fields: ctx, db, taskDAG, maxTaskTimeout
-func (_m *ORM) CreateSpec(ctx context.Context, db *gorm.DB, taskDAG pipeline.TaskDAG, maxTaskTimeout models.Interval) (int32, error) {
-	ret := _m.Called(ctx, db, taskDAG, maxTaskTimeout)
+// CreateRuns provides a mock function with given fields: ctx, requests
+func (_m *ORM) CreateRuns(ctx context.Context, requests []pipeline.RunRequest) ([]int64, error) {
+	ret := _m.Called(ctx, requests)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(context.Context, []pipeline.RunRequest) []int64); ok {
+		r0 = rf(ctx, requests)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []pipeline.RunRequest) error); ok {
+		r1 = rf(ctx, requests)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSpec provides a mock function with given fields: ctx, db, taskDAG, maxTaskTimeout, numericPolicy
+func (_m *ORM) CreateSpec(ctx context.Context, db *gorm.DB, taskDAG pipeline.TaskDAG, maxTaskTimeout models.Interval, numericPolicy pipeline.NumericPolicy) (int32, error) {
+	ret := _m.Called(ctx, db, taskDAG, maxTaskTimeout, numericPolicy)
 
 	var r0 int32
-	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, pipeline.TaskDAG, models.Interval) int32); ok {
-		r0 = rf(ctx, db, taskDAG, maxTaskTimeout)
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, pipeline.TaskDAG, models.Interval, pipeline.NumericPolicy) int32); ok {
+		r0 = rf(ctx, db, taskDAG, maxTaskTimeout, numericPolicy)
 	} else {
 		r0 = ret.Get(0).(int32)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, pipeline.TaskDAG, models.Interval) error); ok {
-		r1 = rf(ctx, db, taskDAG, maxTaskTimeout)
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, pipeline.TaskDAG, models.Interval, pipeline.NumericPolicy) error); ok {
+		r1 = rf(ctx, db, taskDAG, maxTaskTimeout, numericPolicy)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -151,6 +174,29 @@ func (_m *ORM) FindRun(id int64) (pipeline.Run, error) {
 	return r0, r1
 }
 
+// FindShadowSpecs provides a mock function with given fields: primaryJobID
+func (_m *ORM) FindShadowSpecs(primaryJobID int32) ([]pipeline.Spec, error) {
+	ret := _m.Called(primaryJobID)
+
+	var r0 []pipeline.Spec
+	if rf, ok := ret.Get(0).(func(int32) []pipeline.Spec); ok {
+		r0 = rf(primaryJobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.Spec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(primaryJobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InsertFinishedRunWithResults provides a mock function with given fields: ctx, run, trrs
 func (_m *ORM) InsertFinishedRunWithResults(ctx context.Context, run pipeline.Run, trrs []pipeline.TaskRunResult) (int64, error) {
 	ret := _m.Called(ctx, run, trrs)
@@ -172,6 +218,43 @@ func (_m *ORM) InsertFinishedRunWithResults(ctx context.Context, run pipeline.Ru
 	return r0, r1
 }
 
+// InsertShadowComparison provides a mock function with given fields: ctx, comparison
+func (_m *ORM) InsertShadowComparison(ctx context.Context, comparison *pipeline.ShadowComparison) error {
+	ret := _m.Called(ctx, comparison)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pipeline.ShadowComparison) error); ok {
+		r0 = rf(ctx, comparison)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListenForNewRunBatches provides a mock function with given fields:
+func (_m *ORM) ListenForNewRunBatches() (postgres.Subscription, error) {
+	ret := _m.Called()
+
+	var r0 postgres.Subscription
+	if rf, ok := ret.Get(0).(func() postgres.Subscription); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgres.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListenForNewRuns provides a mock function with given fields:
 func (_m *ORM) ListenForNewRuns() (postgres.Subscription, error) {
 	ret := _m.Called()
@@ -196,14 +279,16 @@ func (_m *ORM) ListenForNewRuns() (postgres.Subscription, error) {
 }
 
 // ProcessNextUnfinishedRun provides a mock function with given fields: ctx, fn
-func (_m *ORM) ProcessNextUnfinishedRun(ctx context.Context, fn pipeline.ProcessRunFunc) (bool, error) {
+func (_m *ORM) ProcessNextUnfinishedRun(ctx context.Context, fn pipeline.ProcessRunFunc) (*pipeline.Run, error) {
 	ret := _m.Called(ctx, fn)
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, pipeline.ProcessRunFunc) bool); ok {
+	var r0 *pipeline.Run
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.ProcessRunFunc) *pipeline.Run); ok {
 		r0 = rf(ctx, fn)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipeline.Run)
+		}
 	}
 
 	var r1 error
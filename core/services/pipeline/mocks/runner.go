@@ -30,6 +30,25 @@ func (_m *Runner) AwaitRun(ctx context.Context, runID int64) error {
 	return r0
 }
 
+// AwaitRuns provides a mock function with given fields: ctx, jobID
+func (_m *Runner) AwaitRuns(ctx context.Context, jobID int32) error {
+	ret := _m.Called(ctx, jobID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelRuns provides a mock function with given fields: jobID
+func (_m *Runner) CancelRuns(jobID int32) {
+	_m.Called(jobID)
+}
+
 // Close provides a mock function with given fields:
 func (_m *Runner) Close() error {
 	ret := _m.Called()
@@ -65,6 +84,29 @@ func (_m *Runner) CreateRun(ctx context.Context, jobID int32, meta map[string]in
 	return r0, r1
 }
 
+// CreateRuns provides a mock function with given fields: ctx, requests
+func (_m *Runner) CreateRuns(ctx context.Context, requests []pipeline.RunRequest) ([]int64, error) {
+	ret := _m.Called(ctx, requests)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(context.Context, []pipeline.RunRequest) []int64); ok {
+		r0 = rf(ctx, requests)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []pipeline.RunRequest) error); ok {
+		r1 = rf(ctx, requests)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteAndInsertNewRun provides a mock function with given fields: ctx, spec, meta, l
 func (_m *Runner) ExecuteAndInsertNewRun(ctx context.Context, spec pipeline.Spec, meta pipeline.JSONSerializable, l logger.Logger) (int64, pipeline.FinalResult, error) {
 	ret := _m.Called(ctx, spec, meta, l)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
@@ -19,6 +20,10 @@ type Spec struct {
 	DotDagSource    string          `json:"dotDagSource"`
 	CreatedAt       time.Time       `json:"-"`
 	MaxTaskDuration models.Interval `json:"-"`
+	NumericPolicy   NumericPolicy   `json:"-"`
+	// ContentHash identifies the spec by its contents, see HashSpec. It is
+	// nil for specs created before specs were content addressed.
+	ContentHash *SpecHash `json:"contentHash"`
 
 	JobID   int32  `gorm:"-" json:"-"`
 	JobName string `gorm:"-" json:"-"`
@@ -45,6 +50,7 @@ type Run struct {
 	ID             int64            `json:"-" gorm:"primary_key"`
 	PipelineSpecID int32            `json:"-"`
 	PipelineSpec   Spec             `json:"pipelineSpec"`
+	JobID          *int32           `json:"-"`
 	Meta           JSONSerializable `json:"meta"`
 	// The errors are only ever strings
 	// DB example: [null, null, "my error"]
@@ -55,6 +61,17 @@ type Run struct {
 	CreatedAt        time.Time        `json:"createdAt"`
 	FinishedAt       *time.Time       `json:"finishedAt"`
 	PipelineTaskRuns []TaskRun        `json:"taskRuns" gorm:"foreignkey:PipelineRunID;->"`
+	// Audit is only recorded in audit mode, and is served separately as it
+	// can be large
+	Audit *RunAudit `json:"-" gorm:"type:jsonb"`
+	// Costs is the resources used by the run, see RunCosts
+	Costs *RunCosts `json:"costs" gorm:"type:jsonb"`
+	// DedupKey identifies the event that triggered the run, so that the
+	// event doesn't trigger a second run when it is replayed
+	DedupKey null.String `json:"dedupKey"`
+	// Suppressed is set if a deltathreshold task suppressed any of the run's
+	// outputs, see FinalResult
+	Suppressed bool `json:"suppressed"`
 }
 
 func (Run) TableName() string {
@@ -87,6 +104,8 @@ func (r Run) HasErrors() bool {
 func (r *Run) Status() RunStatus {
 	if r.HasErrors() {
 		return RunStatusErrored
+	} else if r.Suppressed {
+		return RunStatusSuppressed
 	} else if r.FinishedAt != nil {
 		return RunStatusCompleted
 	}
@@ -131,9 +150,15 @@ type TaskRun struct {
 	Output        *JSONSerializable `json:"output" gorm:"type:jsonb"`
 	Error         null.String       `json:"error"`
 	CreatedAt     time.Time         `json:"createdAt"`
+	StartedAt     *time.Time        `json:"startedAt"`
 	FinishedAt    *time.Time        `json:"finishedAt"`
-	Index         int32             `json:"index"`
-	DotID         string            `json:"dotId"`
+	// QueueWait is how long the task waited to start after its inputs were
+	// ready. Tasks with no inputs are ready when the run is created.
+	QueueWait models.Interval `json:"queueWait"`
+	Index     int32           `json:"index"`
+	DotID     string          `json:"dotId"`
+	// StackTrace is where the task panicked, if it did
+	StackTrace null.String `json:"stackTrace"`
 }
 
 func (TaskRun) TableName() string {
@@ -179,6 +204,9 @@ const (
 	RunStatusErrored
 	// RunStatusCompleted is used for when a run has successfully completed execution.
 	RunStatusCompleted
+	// RunStatusSuppressed is used for when a deltathreshold task suppressed
+	// the run's answer, so the tasks after it were skipped.
+	RunStatusSuppressed
 )
 
 // Completed returns true if the status is RunStatusCompleted.
@@ -191,7 +219,42 @@ func (s RunStatus) Errored() bool {
 	return s == RunStatusErrored
 }
 
+// Suppressed returns true if the status is RunStatusSuppressed.
+func (s RunStatus) Suppressed() bool {
+	return s == RunStatusSuppressed
+}
+
 // Finished returns true if the status is final and can't be changed.
 func (s RunStatus) Finished() bool {
-	return s.Completed() || s.Errored()
+	return s.Completed() || s.Errored() || s.Suppressed()
+}
+
+// ShadowComparison compares the result of a shadow job's run with the run of
+// the job it shadows. Delta is the shadow's result less the primary's, and is
+// only set when both results are a single number.
+type ShadowComparison struct {
+	ID             int64               `json:"-" gorm:"primary_key"`
+	PrimaryRunID   int64               `json:"primaryRunID"`
+	ShadowRunID    int64               `json:"shadowRunID"`
+	Delta          decimal.NullDecimal `json:"delta" gorm:"type:numeric(78,18)"`
+	PrimaryLatency models.Interval     `json:"primaryLatency"`
+	ShadowLatency  models.Interval     `json:"shadowLatency"`
+	CreatedAt      time.Time           `json:"createdAt"`
+}
+
+func (ShadowComparison) TableName() string {
+	return "pipeline_shadow_comparisons"
+}
+
+func (c ShadowComparison) GetID() string {
+	return fmt.Sprintf("%v", c.ID)
+}
+
+func (c *ShadowComparison) SetID(value string) error {
+	ID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	c.ID = ID
+	return nil
 }
@@ -16,6 +16,7 @@ func TestRunStatus(t *testing.T) {
 	assert.Equal(t, pipeline.RunStatusInProgress.Finished(), false)
 	assert.Equal(t, pipeline.RunStatusCompleted.Finished(), true)
 	assert.Equal(t, pipeline.RunStatusErrored.Finished(), true)
+	assert.Equal(t, pipeline.RunStatusSuppressed.Finished(), true)
 
 	assert.Equal(t, pipeline.RunStatusUnknown.Errored(), false)
 	assert.Equal(t, pipeline.RunStatusInProgress.Errored(), false)
@@ -65,6 +66,18 @@ func TestRun_Status(t *testing.T) {
 			IsTerminal: true,
 		},
 	}
+	var suppressed = pipeline.TaskRunResults{
+		{
+			Task: &pipeline.HTTPTask{},
+			Result: pipeline.Result{
+				Value: nil,
+				Error: pipeline.ErrRunSuppressed,
+			},
+			FinishedAt: time.Now(),
+			IsTerminal: true,
+		},
+	}
+	var suppressedAndFail = append(pipeline.TaskRunResults{fail[0]}, suppressed...)
 
 	testCases := []struct {
 		name string
@@ -98,6 +111,26 @@ func TestRun_Status(t *testing.T) {
 			},
 			want: pipeline.RunStatusErrored,
 		},
+		{
+			name: "Suppressed",
+			run: &pipeline.Run{
+				Outputs:    suppressed.FinalResult().OutputsDB(),
+				Errors:     suppressed.FinalResult().ErrorsDB(),
+				Suppressed: suppressed.FinalResult().Suppressed,
+				FinishedAt: &now,
+			},
+			want: pipeline.RunStatusSuppressed,
+		},
+		{
+			name: "Suppressed with an error",
+			run: &pipeline.Run{
+				Outputs:    suppressedAndFail.FinalResult().OutputsDB(),
+				Errors:     suppressedAndFail.FinalResult().ErrorsDB(),
+				Suppressed: suppressedAndFail.FinalResult().Suppressed,
+				FinishedAt: &now,
+			},
+			want: pipeline.RunStatusErrored,
+		},
 	}
 
 	for _, tc := range testCases {
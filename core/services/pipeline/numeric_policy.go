@@ -0,0 +1,116 @@
+package pipeline
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// RoundingMode is how a NumericPolicy rounds away excess digits
+type RoundingMode string
+
+const (
+	// RoundHalfAwayFromZero rounds halves away from zero, e.g. 2.5 => 3 and
+	// -2.5 => -3. It is the default.
+	RoundHalfAwayFromZero RoundingMode = "halfAwayFromZero"
+	// RoundHalfEven rounds halves to the nearest even digit, e.g. 2.5 => 2
+	// and 3.5 => 4
+	RoundHalfEven RoundingMode = "halfEven"
+	// RoundTowardZero drops excess digits, e.g. 2.9 => 2 and -2.9 => -2
+	RoundTowardZero RoundingMode = "towardZero"
+)
+
+// OverflowBehavior is what a NumericPolicy does with a value whose integer
+// part has more digits than MaxSignificantDigits
+type OverflowBehavior string
+
+const (
+	// OverflowError fails the task. It is the default.
+	OverflowError OverflowBehavior = "error"
+	// OverflowRound rounds the integer part to MaxSignificantDigits, e.g.
+	// 12345 with 3 significant digits becomes 12300
+	OverflowRound OverflowBehavior = "round"
+)
+
+// ErrNumericOverflow is the error of a task whose result has more integer
+// digits than its job's numeric policy allows
+var ErrNumericOverflow = errors.New("numeric overflow")
+
+// NumericPolicy is the precision that a job's arithmetic tasks (multiply,
+// median and scale) apply to their results. Every node running the job rounds
+// in the same way, so that their answers agree.
+//
+// The zero NumericPolicy keeps all digits and rounds halves away from zero.
+type NumericPolicy struct {
+	// MaxSignificantDigits is the number of significant digits results are
+	// rounded to. Zero means no limit.
+	MaxSignificantDigits int32            `json:"maxSignificantDigits,omitempty" toml:"maxSignificantDigits"`
+	Rounding             RoundingMode     `json:"rounding,omitempty" toml:"rounding"`
+	Overflow             OverflowBehavior `json:"overflow,omitempty" toml:"overflow"`
+}
+
+// Validate checks that the policy's fields are known values
+func (p NumericPolicy) Validate() error {
+	if p.MaxSignificantDigits < 0 {
+		return errors.Errorf("numericPolicy maxSignificantDigits must not be negative, got %d", p.MaxSignificantDigits)
+	}
+	switch p.Rounding {
+	case "", RoundHalfAwayFromZero, RoundHalfEven, RoundTowardZero:
+	default:
+		return errors.Errorf("unknown numericPolicy rounding %q, must be one of %s, %s or %s", p.Rounding, RoundHalfAwayFromZero, RoundHalfEven, RoundTowardZero)
+	}
+	switch p.Overflow {
+	case "", OverflowError, OverflowRound:
+	default:
+		return errors.Errorf("unknown numericPolicy overflow %q, must be one of %s or %s", p.Overflow, OverflowError, OverflowRound)
+	}
+	return nil
+}
+
+// Round rounds value to the given number of decimal places, which may be
+// negative to round the integer part, using the policy's rounding mode
+func (p NumericPolicy) Round(value decimal.Decimal, places int32) decimal.Decimal {
+	shifted := value.Shift(places)
+	switch p.Rounding {
+	case RoundHalfEven:
+		shifted = shifted.RoundBank(0)
+	case RoundTowardZero:
+		shifted = shifted.Truncate(0)
+	default:
+		shifted = shifted.Round(0)
+	}
+	return shifted.Shift(-places)
+}
+
+// Apply rounds value to the policy's significant digits
+func (p NumericPolicy) Apply(value decimal.Decimal) (decimal.Decimal, error) {
+	if p.MaxSignificantDigits == 0 || value.IsZero() {
+		return value, nil
+	}
+	// The power of ten of the most significant digit
+	digits := len(new(big.Int).Abs(value.Coefficient()).String())
+	msd := int32(digits) + value.Exponent() - 1
+	places := p.MaxSignificantDigits - 1 - msd
+	if places < 0 && p.Overflow != OverflowRound {
+		return decimal.Decimal{}, errors.Wrapf(ErrNumericOverflow, "%s has more than %d significant integer digits", value, p.MaxSignificantDigits)
+	}
+	return p.Round(value, places), nil
+}
+
+func (p *NumericPolicy) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("NumericPolicy#Scan received a value of type %T", value)
+	}
+	return json.Unmarshal(b, p)
+}
+
+func (p NumericPolicy) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
@@ -0,0 +1,68 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestNumericPolicy_Apply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy pipeline.NumericPolicy
+		input  string
+		want   string
+	}{
+		{"no limit", pipeline.NumericPolicy{}, "1.23456789", "1.23456789"},
+		{"default rounding", pipeline.NumericPolicy{MaxSignificantDigits: 3}, "1.23556", "1.24"},
+		{"small value", pipeline.NumericPolicy{MaxSignificantDigits: 2}, "0.0012345", "0.0012"},
+		{"half away from zero", pipeline.NumericPolicy{MaxSignificantDigits: 1, Rounding: pipeline.RoundHalfAwayFromZero}, "-2.5", "-3"},
+		{"half even", pipeline.NumericPolicy{MaxSignificantDigits: 1, Rounding: pipeline.RoundHalfEven}, "2.5", "2"},
+		{"toward zero", pipeline.NumericPolicy{MaxSignificantDigits: 1, Rounding: pipeline.RoundTowardZero}, "-2.9", "-2"},
+		{"rounded overflow", pipeline.NumericPolicy{MaxSignificantDigits: 3, Overflow: pipeline.OverflowRound}, "12345", "12300"},
+		{"zero", pipeline.NumericPolicy{MaxSignificantDigits: 3}, "0", "0"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			value, err := test.policy.Apply(*mustDecimal(t, test.input))
+			require.NoError(t, err)
+			assert.Equal(t, test.want, value.String())
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		_, err := pipeline.NumericPolicy{MaxSignificantDigits: 3}.Apply(*mustDecimal(t, "12345"))
+		assert.True(t, errors.Is(err, pipeline.ErrNumericOverflow))
+	})
+}
+
+func TestNumericPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, pipeline.NumericPolicy{}.Validate())
+	assert.NoError(t, pipeline.NumericPolicy{MaxSignificantDigits: 18, Rounding: pipeline.RoundHalfEven, Overflow: pipeline.OverflowRound}.Validate())
+	assert.Error(t, pipeline.NumericPolicy{MaxSignificantDigits: -1}.Validate())
+	assert.Error(t, pipeline.NumericPolicy{Rounding: "ceiling"}.Validate())
+	assert.Error(t, pipeline.NumericPolicy{Overflow: "saturate"}.Validate())
+}
+
+func TestMultiplyTask_NumericPolicy(t *testing.T) {
+	t.Parallel()
+
+	task := pipeline.MultiplyTask{Times: decimal.NewFromInt(3)}
+	task.HelperSetNumericPolicy(pipeline.NumericPolicy{MaxSignificantDigits: 4, Rounding: pipeline.RoundHalfEven})
+
+	result := task.Run(context.Background(), pipeline.JSONSerializable{}, []pipeline.Result{{Value: "1.23456"}})
+	require.NoError(t, result.Error)
+	assert.Equal(t, "3.704", result.Value.(decimal.Decimal).String())
+}
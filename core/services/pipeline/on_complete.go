@@ -0,0 +1,23 @@
+package pipeline
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// enqueueOnCompleteDelivery records that the result of the given finished
+// run is to be delivered to its job's onComplete sinks, if it has any, see
+// package oncomplete. It is recorded along with the run, so that the result
+// is delivered even if the node stops before delivering it. The tx argument
+// must be an already started transaction.
+func enqueueOnCompleteDelivery(tx *gorm.DB, run Run) error {
+	if run.JobID == nil {
+		return nil
+	}
+	err := tx.Exec(`
+		INSERT INTO job_on_complete_pending_deliveries (job_id, pipeline_run_id, created_at)
+		SELECT id, ?, NOW() FROM jobs
+		WHERE id = ? AND (on_complete_webhook_url IS NOT NULL OR on_complete_queue_url IS NOT NULL)
+	`, run.ID, *run.JobID).Error
+	return errors.Wrap(err, "could not enqueue onComplete delivery")
+}
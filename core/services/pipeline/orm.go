@@ -3,6 +3,7 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,9 +13,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
+	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
 )
 
@@ -22,21 +25,40 @@ var (
 	ErrNoSuchBridge = errors.New("no such bridge exists")
 )
 
+// NoSuchBridgeError is the error of a task that calls a bridge that doesn't
+// exist, e.g. because it was deleted after the job was created. It matches
+// ErrNoSuchBridge with errors.Is.
+type NoSuchBridgeError struct {
+	Name string
+}
+
+func (e NoSuchBridgeError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrNoSuchBridge, e.Name)
+}
+
+func (e NoSuchBridgeError) Is(target error) bool {
+	return target == ErrNoSuchBridge
+}
+
 //go:generate mockery --name ORM --output ./mocks/ --case=underscore
 
 type ORM interface {
-	CreateSpec(ctx context.Context, db *gorm.DB, taskDAG TaskDAG, maxTaskTimeout models.Interval) (int32, error)
+	CreateSpec(ctx context.Context, db *gorm.DB, taskDAG TaskDAG, maxTaskTimeout models.Interval, numericPolicy NumericPolicy) (int32, error)
 	InsertFinishedRunWithResults(ctx context.Context, run Run, trrs []TaskRunResult) (runID int64, err error)
 	DeleteRunsOlderThan(threshold time.Duration) error
 	FindBridge(name models.TaskType) (models.BridgeType, error)
 	FindRun(id int64) (Run, error)
+	FindShadowSpecs(primaryJobID int32) ([]Spec, error)
+	InsertShadowComparison(ctx context.Context, comparison *ShadowComparison) error
 	DB() *gorm.DB
 
 	// Note below methods are not currently used to process runs.
 	CreateRun(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
+	CreateRuns(ctx context.Context, requests []RunRequest) ([]int64, error)
 	AwaitRun(ctx context.Context, runID int64) error
-	ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (bool, error)
+	ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (*Run, error)
 	ListenForNewRuns() (postgres.Subscription, error)
+	ListenForNewRunBatches() (postgres.Subscription, error)
 	RunFinished(runID int64) (bool, error)
 	ResultsForRun(ctx context.Context, runID int64) ([]Result, error)
 }
@@ -45,6 +67,7 @@ type orm struct {
 	db               *gorm.DB
 	config           Config
 	eventBroadcaster postgres.EventBroadcaster
+	eventBus         *eventbus.Bus
 }
 
 var _ ORM = (*orm)(nil)
@@ -70,20 +93,31 @@ var (
 	)
 )
 
-func NewORM(db *gorm.DB, config Config, eventBroadcaster postgres.EventBroadcaster) *orm {
-	return &orm{db, config, eventBroadcaster}
+func NewORM(db *gorm.DB, config Config, eventBroadcaster postgres.EventBroadcaster, eventBus *eventbus.Bus) *orm {
+	return &orm{db, config, eventBroadcaster, eventBus}
 }
 
 // The tx argument must be an already started transaction.
-func (o *orm) CreateSpec(ctx context.Context, tx *gorm.DB, taskDAG TaskDAG, maxTaskDuration models.Interval) (int32, error) {
+// CreateSpec returns the ID of the pipeline spec with the given contents,
+// creating it if no other job has the same pipeline
+func (o *orm) CreateSpec(ctx context.Context, tx *gorm.DB, taskDAG TaskDAG, maxTaskDuration models.Interval, numericPolicy NumericPolicy) (int32, error) {
+	hash, err := HashSpec(taskDAG, maxTaskDuration, numericPolicy)
+	if err != nil {
+		return 0, err
+	}
 	spec := Spec{
 		DotDagSource:    taskDAG.DOTSource,
 		MaxTaskDuration: maxTaskDuration,
+		NumericPolicy:   numericPolicy,
+		ContentHash:     &hash,
 	}
-	err := tx.Create(&spec).Error
+	err = tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "content_hash"}}, DoNothing: true}).Create(&spec).Error
 	if err != nil {
 		return 0, err
 	}
+	if spec.ID == 0 {
+		err = tx.Where("content_hash = ?", hash).First(&spec).Error
+	}
 	return spec.ID, errors.WithStack(err)
 }
 
@@ -96,53 +130,218 @@ func (o *orm) CreateRun(ctx context.Context, jobID int32, meta map[string]interf
 	defer cancel()
 
 	err = postgres.GormTransaction(ctx, o.db, func(tx *gorm.DB) (err error) {
-		// Create the job run
-		run := Run{}
+		if err = validateRunMeta(tx, jobID, meta); err != nil {
+			return err
+		}
+		runID, err = insertRun(tx, jobID, meta)
+		return err
+	})
+	return runID, errors.WithStack(err)
+}
 
-		err = tx.Raw(`
-            INSERT INTO pipeline_runs (pipeline_spec_id, meta, created_at)
-            SELECT pipeline_spec_id, ?, NOW()
-            FROM jobs WHERE id = ? 
+// insertRun creates an unfinished run of the job, along with a task run for
+// each of its tasks. The tx argument must be an already started transaction.
+func insertRun(tx *gorm.DB, jobID int32, meta map[string]interface{}) (int64, error) {
+	run := Run{}
+
+	err := tx.Raw(`
+            INSERT INTO pipeline_runs (pipeline_spec_id, job_id, meta, created_at)
+            SELECT pipeline_spec_id, id, ?, NOW()
+            FROM jobs WHERE id = ? AND archived_at IS NULL
             RETURNING *`, JSONSerializable{Val: meta}, jobID).Scan(&run).Error
-		if run.ID == 0 {
-			return errors.Errorf("no job found with id %v (most likely it was deleted)", jobID)
-		} else if err != nil {
-			return errors.Wrap(err, "could not create pipeline run")
+	if run.ID == 0 {
+		return 0, errors.Errorf("no job found with id %v (most likely it was deleted)", jobID)
+	} else if err != nil {
+		return 0, errors.Wrap(err, "could not create pipeline run")
+	}
+
+	if err = tx.Preload("PipelineSpec").First(&run).Error; err != nil {
+		return 0, err
+	}
+	d := TaskDAG{}
+	if err = d.UnmarshalText([]byte(run.PipelineSpec.DotDagSource)); err != nil {
+		return 0, err
+	}
+
+	var trs []TaskRun
+	tasks, err := d.TasksInDependencyOrder()
+	if err != nil {
+		return 0, err
+	}
+	for _, ts := range tasks {
+		trs = append(trs, TaskRun{
+			Type:          ts.Type(),
+			PipelineRunID: run.ID,
+			Index:         ts.OutputIndex(),
+			DotID:         ts.DotID(),
+		})
+	}
+	if len(trs) > 0 {
+		if err = tx.Create(&trs).Error; err != nil {
+			return 0, err
+		}
+	}
+	return run.ID, nil
+}
+
+// RunRequest asks for a run of a job with the given meta
+type RunRequest struct {
+	JobID int32
+	Meta  map[string]interface{}
+	// DedupKey, if set, identifies the event that triggered the run (see
+	// LogDedupKey). A request whose key already has a run is skipped.
+	DedupKey string
+}
+
+// CreateRuns is CreateRun for many runs at once, e.g. all of the runs
+// triggered by a single head. The runs are created in a single transaction,
+// and runners are woken with a single notification rather than one per run.
+// The IDs of the runs are returned in the order that they were requested,
+// with an ID of 0 for requests skipped as duplicates.
+func (o *orm) CreateRuns(ctx context.Context, requests []RunRequest) (runIDs []int64, err error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	ctx, cancel := utils.CombinedContext(ctx, o.config.DatabaseMaximumTxDuration())
+	defer cancel()
+
+	jobIDs := make([]int32, len(requests))
+	for i, request := range requests {
+		jobIDs[i] = request.JobID
+	}
+
+	err = postgres.GormTransaction(ctx, o.db, func(tx *gorm.DB) error {
+		var jobs []struct {
+			ID             int32
+			PipelineSpecID int32
+			DotDagSource   string
+			MetaSchema     null.String
+		}
+		err = tx.Raw(`
+			SELECT jobs.id, jobs.pipeline_spec_id, pipeline_specs.dot_dag_source, jobs.meta_schema
+			FROM jobs JOIN pipeline_specs ON pipeline_specs.id = jobs.pipeline_spec_id
+			WHERE jobs.id IN (?) AND jobs.archived_at IS NULL`, jobIDs).Scan(&jobs).Error
+		if err != nil {
+			return errors.Wrap(err, "could not load jobs")
+		}
+		specIDs := make(map[int32]int32)
+		tasksByJob := make(map[int32][]Task)
+		for _, job := range jobs {
+			d := TaskDAG{}
+			if err = d.UnmarshalText([]byte(job.DotDagSource)); err != nil {
+				return err
+			}
+			if tasksByJob[job.ID], err = d.TasksInDependencyOrder(); err != nil {
+				return err
+			}
+			specIDs[job.ID] = job.PipelineSpecID
+			for _, request := range requests {
+				if request.JobID != job.ID {
+					continue
+				}
+				if err = checkRunMeta(job.MetaSchema, request.Meta); err != nil {
+					return errors.Wrapf(err, "job %v", job.ID)
+				}
+			}
 		}
 
-		if err = tx.Preload("PipelineSpec").First(&run).Error; err != nil {
-			return err
+		now := time.Now()
+		runs := make([]Run, len(requests))
+		for i, request := range requests {
+			specID, exists := specIDs[request.JobID]
+			if !exists {
+				return errors.Errorf("no job found with id %v (most likely it was deleted)", request.JobID)
+			}
+			runs[i] = Run{
+				PipelineSpecID: specID,
+				JobID:          &requests[i].JobID,
+				Meta:           JSONSerializable{Val: request.Meta},
+				CreatedAt:      now,
+				DedupKey:       null.NewString(request.DedupKey, request.DedupKey != ""),
+			}
 		}
-		d := TaskDAG{}
-		if err = d.UnmarshalText([]byte(run.PipelineSpec.DotDagSource)); err != nil {
+
+		// The batch notifies the runners once, below, instead of once per run
+		if err = tx.Exec(`SET LOCAL chainlink.batch_run_insert = 'on'`).Error; err != nil {
+			return errors.Wrap(err, "could not suppress run notifications")
+		}
+		if err = insertRuns(tx, runs); err != nil {
 			return err
 		}
 
 		var trs []TaskRun
-		tasks, err := d.TasksInDependencyOrder()
-		if err != nil {
-			return err
-		}
-		for _, ts := range tasks {
-			trs = append(trs, TaskRun{
-				Type:          ts.Type(),
-				PipelineRunID: run.ID,
-				Index:         ts.OutputIndex(),
-				DotID:         ts.DotID(),
-			})
+		runIDs = make([]int64, len(runs))
+		for i, run := range runs {
+			runIDs[i] = run.ID
+			if run.ID == 0 {
+				continue
+			}
+			for _, task := range tasksByJob[requests[i].JobID] {
+				trs = append(trs, TaskRun{
+					Type:          task.Type(),
+					PipelineRunID: run.ID,
+					Index:         task.OutputIndex(),
+					DotID:         task.DotID(),
+				})
+			}
 		}
-		runID = run.ID
 		if len(trs) > 0 {
-			return tx.Create(&trs).Error
+			if err = tx.Create(&trs).Error; err != nil {
+				return errors.Wrap(err, "could not create pipeline task runs")
+			}
 		}
-		return nil
+
+		var created int
+		for _, runID := range runIDs {
+			if runID != 0 {
+				created++
+			}
+		}
+		if created == 0 {
+			return nil
+		}
+		return o.eventBroadcaster.NotifyInsideGormTx(tx, postgres.ChannelRunsStarted, fmt.Sprintf("%d", created))
 	})
-	return runID, errors.WithStack(err)
+	return runIDs, errors.WithStack(err)
+}
+
+// insertRuns inserts runs, setting their IDs. Runs without a dedup key are
+// inserted together. Runs with a dedup key are inserted one at a time, so
+// that a run whose key is taken is skipped, leaving its ID 0.
+func insertRuns(tx *gorm.DB, runs []Run) error {
+	var batch []Run
+	var batchIndexes []int
+	for i := range runs {
+		if !runs[i].DedupKey.Valid {
+			batch = append(batch, runs[i])
+			batchIndexes = append(batchIndexes, i)
+			continue
+		}
+		err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&runs[i]).Error
+		if err != nil {
+			return errors.Wrap(err, "could not create pipeline run")
+		}
+		if runs[i].ID == 0 {
+			logger.Debugw("Skipping duplicate pipeline run", "dedupKey", runs[i].DedupKey.String)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := tx.Omit(clause.Associations).Create(&batch).Error; err != nil {
+		return errors.Wrap(err, "could not create pipeline runs")
+	}
+	for i, run := range batch {
+		runs[batchIndexes[i]].ID = run.ID
+	}
+	return nil
 }
 
 type ProcessRunFunc func(ctx context.Context, txdb *gorm.DB, spec Spec, meta JSONSerializable, l logger.Logger) (TaskRunResults, bool, error)
 
-func (o *orm) ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (bool, error) {
+// ProcessNextUnfinishedRun finishes the next unfinished run with fn, and
+// returns it. It returns nil if there are no unfinished runs.
+func (o *orm) ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (*Run, error) {
 	// Passed in context cancels on (chStop || JobPipelineMaxTaskDuration)
 	txContext, cancel := context.WithTimeout(context.Background(), o.config.DatabaseMaximumTxDuration())
 	defer cancel()
@@ -153,6 +352,9 @@ func (o *orm) ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (
 			Preload("PipelineSpec").
 			Preload("PipelineTaskRuns").
 			Where("pipeline_runs.finished_at IS NULL").
+			// The runs of archived jobs are left unfinished, as archiving
+			// a job stops it being run
+			Where("NOT EXISTS (SELECT 1 FROM jobs WHERE jobs.id = pipeline_runs.job_id AND jobs.archived_at IS NOT NULL)").
 			Order("id ASC").
 			Clauses(clause.Locking{
 				Strength: "UPDATE",
@@ -163,6 +365,9 @@ func (o *orm) ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (
 			return errors.Wrap(err, "error finding unfinished run")
 		}
 		logger.Infow("Pipeline run started", "runID", pRun.ID)
+		if pRun.JobID != nil {
+			pRun.PipelineSpec.JobID = *pRun.JobID
+		}
 
 		trrs, _, err := fn(ctx, tx, pRun.PipelineSpec, pRun.Meta, *logger.Default)
 		if err != nil {
@@ -177,6 +382,10 @@ func (o *orm) ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (
 					trrs[i].ID = tr.ID
 				}
 			}
+			// Tasks without inputs have been waiting since the run was created
+			if trr.Task.NPreds() == 0 {
+				trrs[i].QueueWait = trr.StartedAt.Sub(pRun.CreatedAt)
+			}
 		}
 
 		if err = o.updateTaskRuns(tx, trrs); err != nil {
@@ -187,6 +396,29 @@ func (o *orm) ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (
 			return errors.Wrap(err, "could not mark pipeline_run as finished")
 		}
 
+		if err = createDependentRuns(tx, pRun); err != nil {
+			return errors.Wrap(err, "could not trigger dependent jobs")
+		}
+		if err = enqueueOnCompleteDelivery(tx, pRun); err != nil {
+			return err
+		}
+
+		if o.config.JobPipelineAuditMode() {
+			if err = tx.Exec(`UPDATE pipeline_runs SET audit = ? WHERE id = ?`, NewRunAudit(trrs), pRun.ID).Error; err != nil {
+				return errors.Wrap(err, "could not save pipeline_run audit")
+			}
+		}
+
+		pRun.Costs = NewRunCosts(trrs)
+		if err = tx.Exec(`UPDATE pipeline_runs SET costs = ? WHERE id = ?`, pRun.Costs, pRun.ID).Error; err != nil {
+			return errors.Wrap(err, "could not save pipeline_run costs")
+		}
+		if pRun.JobID != nil {
+			if err = recordJobCosts(tx, *pRun.JobID, pRun.CreatedAt, pRun.Costs); err != nil {
+				return err
+			}
+		}
+
 		err = o.eventBroadcaster.NotifyInsideGormTx(tx, postgres.ChannelRunCompleted, fmt.Sprintf("%v", pRun.ID))
 		if err != nil {
 			return errors.Wrap(err, "could not notify pipeline_run_completed")
@@ -203,12 +435,32 @@ func (o *orm) ProcessNextUnfinishedRun(ctx context.Context, fn ProcessRunFunc) (
 	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, nil
+			return nil, nil
 		}
-		return false, errors.Wrap(err, "while processing run")
+		return nil, errors.Wrap(err, "while processing run")
 	}
 	logger.Infow("Pipeline run completed", "runID", pRun.ID)
-	return true, nil
+	o.publishRunFinished(pRun)
+	return &pRun, nil
+}
+
+// publishRunFinished publishes that run has finished on the event bus
+func (o *orm) publishRunFinished(run Run) {
+	event := eventbus.RunFinished{
+		RunID:   strconv.FormatInt(run.ID, 10),
+		Errored: run.HasErrors(),
+	}
+	if run.JobID != nil {
+		event.JobID = strconv.FormatInt(int64(*run.JobID), 10)
+	}
+	var errs []string
+	for _, err := range run.Errors {
+		if !err.IsZero() {
+			errs = append(errs, err.String)
+		}
+	}
+	event.Error = strings.Join(errs, "; ")
+	o.eventBus.Publish(event)
 }
 
 // updateTaskRuns updates multiple task runs in one query
@@ -217,17 +469,20 @@ func (o *orm) updateTaskRuns(db *gorm.DB, trrs TaskRunResults) error {
 UPDATE pipeline_task_runs AS ptr SET
 output = updates.output,
 error = updates.error,
-finished_at = updates.finished_at
+started_at = updates.started_at,
+finished_at = updates.finished_at,
+queue_wait = updates.queue_wait,
+stack_trace = updates.stack_trace
 FROM (VALUES
 %s
-) AS updates(id, output, error, finished_at)
+) AS updates(id, output, error, started_at, finished_at, queue_wait, stack_trace)
 WHERE ptr.id = updates.id
 `
 	valueStrings := []string{}
 	valueArgs := []interface{}{}
 	for _, trr := range trrs {
-		valueStrings = append(valueStrings, "(?::bigint, ?::jsonb, ?::text, ?::timestamptz)")
-		valueArgs = append(valueArgs, trr.ID, trr.Result.OutputDB(), trr.Result.ErrorDB(), trr.FinishedAt)
+		valueStrings = append(valueStrings, "(?::bigint, ?::jsonb, ?::text, ?::timestamptz, ?::timestamptz, ?::bigint, ?::text)")
+		valueArgs = append(valueArgs, trr.ID, trr.Result.OutputDB(), trr.Result.ErrorDB(), null.NewTime(trr.StartedAt, !trr.StartedAt.IsZero()), trr.FinishedAt, models.Interval(trr.QueueWait), trr.Result.StackTraceDB())
 	}
 
 	/* #nosec G201 */
@@ -237,10 +492,10 @@ WHERE ptr.id = updates.id
 
 func (o *orm) UpdatePipelineRun(db *gorm.DB, run *Run, result FinalResult) error {
 	return db.Raw(`
-		UPDATE pipeline_runs SET finished_at = ?, outputs = ?, errors = ?
+		UPDATE pipeline_runs SET finished_at = ?, outputs = ?, errors = ?, suppressed = ?
 		WHERE id = ?
 		RETURNING *
-		`, time.Now(), result.OutputsDB(), result.ErrorsDB(), run.ID).
+		`, time.Now(), result.OutputsDB(), result.ErrorsDB(), result.Suppressed, run.ID).
 		Scan(run).Error
 }
 
@@ -248,6 +503,12 @@ func (o *orm) ListenForNewRuns() (postgres.Subscription, error) {
 	return o.eventBroadcaster.Subscribe(postgres.ChannelRunStarted, "")
 }
 
+// ListenForNewRunBatches notifies of batches of runs created by CreateRuns.
+// The payload of each event is the number of runs in the batch.
+func (o *orm) ListenForNewRunBatches() (postgres.Subscription, error) {
+	return o.eventBroadcaster.Subscribe(postgres.ChannelRunsStarted, "")
+}
+
 func (o *orm) InsertFinishedRunWithResults(ctx context.Context, run Run, trrs []TaskRunResult) (runID int64, err error) {
 	if run.CreatedAt.IsZero() {
 		return 0, errors.New("run.CreatedAt must be set")
@@ -259,28 +520,50 @@ func (o *orm) InsertFinishedRunWithResults(ctx context.Context, run Run, trrs []
 		return 0, errors.Errorf("run must have both Outputs and Errors, got Outputs: %#v, Errors: %#v", run.Outputs.Val, run.Errors)
 	}
 
+	if run.Costs == nil {
+		run.Costs = NewRunCosts(trrs)
+	}
+
 	err = postgres.GormTransaction(ctx, o.db, func(tx *gorm.DB) error {
-		if err = tx.Create(&run).Error; err != nil {
+		if err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&run).Error; err != nil {
 			return errors.Wrap(err, "error inserting finished pipeline_run")
 		}
+		if run.ID == 0 {
+			// A run with the same dedup key already exists
+			logger.Debugw("Skipping duplicate pipeline run", "dedupKey", run.DedupKey.String)
+			return nil
+		}
 
 		runID = run.ID
 		sql := `
-		INSERT INTO pipeline_task_runs (pipeline_run_id, type, index, output, error, dot_id, created_at, finished_at)
+		INSERT INTO pipeline_task_runs (pipeline_run_id, type, index, output, error, dot_id, created_at, started_at, finished_at, queue_wait, stack_trace)
 		VALUES %s
 		`
 		valueStrings := []string{}
 		valueArgs := []interface{}{}
 		for _, trr := range trrs {
-			valueStrings = append(valueStrings, "(?,?,?,?,?,?,?,?)")
-			valueArgs = append(valueArgs, run.ID, trr.Task.Type(), trr.Task.OutputIndex(), trr.Result.OutputDB(), trr.Result.ErrorDB(), trr.Task.DotID(), trr.CreatedAt, trr.FinishedAt)
+			valueStrings = append(valueStrings, "(?,?,?,?,?,?,?,?,?,?,?)")
+			valueArgs = append(valueArgs, run.ID, trr.Task.Type(), trr.Task.OutputIndex(), trr.Result.OutputDB(), trr.Result.ErrorDB(), trr.Task.DotID(), trr.CreatedAt, null.NewTime(trr.StartedAt, !trr.StartedAt.IsZero()), trr.FinishedAt, models.Interval(trr.QueueWait), trr.Result.StackTraceDB())
 		}
 
 		/* #nosec G201 */
 		stmt := fmt.Sprintf(sql, strings.Join(valueStrings, ","))
-		err = tx.Exec(stmt, valueArgs...).Error
-		return errors.Wrap(err, "error inserting finished pipeline_task_runs")
+		if err = tx.Exec(stmt, valueArgs...).Error; err != nil {
+			return errors.Wrap(err, "error inserting finished pipeline_task_runs")
+		}
+		if run.JobID != nil {
+			if err = recordJobCosts(tx, *run.JobID, run.CreatedAt, run.Costs); err != nil {
+				return err
+			}
+		}
+		if err = createDependentRuns(tx, run); err != nil {
+			return errors.Wrap(err, "could not trigger dependent jobs")
+		}
+		return enqueueOnCompleteDelivery(tx, run)
 	})
+	if err == nil && runID != 0 {
+		o.publishRunFinished(run)
+	}
 
 	return runID, err
 }
@@ -423,10 +706,50 @@ func (o *orm) FindRun(id int64) (Run, error) {
 	return run, err
 }
 
-// FindBridge find a bridge using the given database
+// FindShadowSpecs returns the pipeline specs of the jobs shadowing the given
+// job, with their JobID and JobName set
+func (o *orm) FindShadowSpecs(primaryJobID int32) ([]Spec, error) {
+	var shadows []struct {
+		ID             int32
+		Name           null.String
+		PipelineSpecID int32
+	}
+	err := o.db.Raw(`
+		SELECT shadows.id, shadows.name, shadows.pipeline_spec_id
+		FROM jobs shadows
+		INNER JOIN shadow_specs ON shadows.shadow_spec_id = shadow_specs.id
+		INNER JOIN jobs primaries ON shadow_specs.shadow_of_job_id = primaries.id
+		WHERE primaries.id = ? AND shadows.archived_at IS NULL
+		ORDER BY shadows.id ASC
+	`, primaryJobID).Scan(&shadows).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load shadow jobs")
+	}
+
+	specs := make([]Spec, len(shadows))
+	for i, shadow := range shadows {
+		if err := o.db.First(&specs[i], shadow.PipelineSpecID).Error; err != nil {
+			return nil, errors.Wrapf(err, "could not load pipeline spec for shadow job %v", shadow.ID)
+		}
+		specs[i].JobID = shadow.ID
+		specs[i].JobName = shadow.Name.ValueOrZero()
+	}
+	return specs, nil
+}
+
+func (o *orm) InsertShadowComparison(ctx context.Context, comparison *ShadowComparison) error {
+	return errors.Wrap(o.db.WithContext(ctx).Create(comparison).Error, "error inserting shadow comparison")
+}
+
+// FindBridge find a bridge using the given database, returning a
+// NoSuchBridgeError if there is none
 func FindBridge(db *gorm.DB, name models.TaskType) (models.BridgeType, error) {
 	var bt models.BridgeType
-	return bt, errors.Wrapf(db.First(&bt, "name = ?", name.String()).Error, "could not find bridge with name '%s'", name)
+	err := db.First(&bt, "name = ?", name.String()).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return bt, NoSuchBridgeError{Name: name.String()}
+	}
+	return bt, errors.Wrapf(err, "could not find bridge with name '%s'", name)
 }
 
 func (o *orm) DB() *gorm.DB {
@@ -6,11 +6,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/services/postgres/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,7 +23,7 @@ func Test_PipelineORM_CreateRun(t *testing.T) {
 	db := store.DB
 
 	eventBroadcaster := new(mocks.EventBroadcaster)
-	orm := pipeline.NewORM(db, store.Config, eventBroadcaster)
+	orm := pipeline.NewORM(db, store.Config, eventBroadcaster, nil)
 
 	job := cltest.MustInsertSampleDirectRequestJob(t, db)
 	meta := make(map[string]interface{})
@@ -40,6 +43,142 @@ func Test_PipelineORM_CreateRun(t *testing.T) {
 	require.Len(t, trs, 3)
 }
 
+func Test_PipelineORM_CreateRuns(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	db := store.DB
+
+	eventBroadcaster := new(mocks.EventBroadcaster)
+	eventBroadcaster.On("NotifyInsideGormTx", mock.Anything, postgres.ChannelRunsStarted, "3").Return(nil).Once()
+	orm := pipeline.NewORM(db, store.Config, eventBroadcaster, nil)
+
+	job := cltest.MustInsertSampleDirectRequestJob(t, db)
+
+	runIDs, err := orm.CreateRuns(context.Background(), []pipeline.RunRequest{
+		{JobID: job.ID, Meta: map[string]interface{}{"n": 1}},
+		{JobID: job.ID, Meta: map[string]interface{}{"n": 2}},
+		{JobID: job.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, runIDs, 3)
+	eventBroadcaster.AssertExpectations(t)
+
+	var prs []pipeline.Run
+	require.NoError(t, db.Order("id ASC").Find(&prs).Error)
+	require.Len(t, prs, 3)
+	for i, pr := range prs {
+		require.Equal(t, runIDs[i], pr.ID)
+	}
+	require.Equal(t, map[string]interface{}{"n": float64(2)}, prs[1].Meta.Val)
+
+	var trs []pipeline.TaskRun
+	require.NoError(t, db.Find(&trs).Error)
+	require.Len(t, trs, 9)
+
+	_, err = orm.CreateRuns(context.Background(), []pipeline.RunRequest{{JobID: job.ID}, {JobID: -1}})
+	require.Error(t, err)
+	require.NoError(t, db.Find(&prs).Error)
+	require.Len(t, prs, 3)
+}
+
+func Test_PipelineORM_CreateRuns_DedupKey(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	db := store.DB
+
+	eventBroadcaster := new(mocks.EventBroadcaster)
+	eventBroadcaster.On("NotifyInsideGormTx", mock.Anything, postgres.ChannelRunsStarted, "2").Return(nil).Once()
+	orm := pipeline.NewORM(db, store.Config, eventBroadcaster, nil)
+
+	job := cltest.MustInsertSampleDirectRequestJob(t, db)
+	key := pipeline.LogDedupKey(job.ID, common.HexToHash("0xabc"), 3)
+
+	runIDs, err := orm.CreateRuns(context.Background(), []pipeline.RunRequest{
+		{JobID: job.ID, DedupKey: key},
+		{JobID: job.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, runIDs, 2)
+	require.NotZero(t, runIDs[0])
+	require.NotZero(t, runIDs[1])
+	eventBroadcaster.AssertExpectations(t)
+
+	// A replay of the same log is a no-op
+	runIDs, err = orm.CreateRuns(context.Background(), []pipeline.RunRequest{
+		{JobID: job.ID, DedupKey: key},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{0}, runIDs)
+	eventBroadcaster.AssertExpectations(t)
+
+	var prs []pipeline.Run
+	require.NoError(t, db.Find(&prs).Error)
+	require.Len(t, prs, 2)
+	var trs []pipeline.TaskRun
+	require.NoError(t, db.Find(&trs).Error)
+	require.Len(t, trs, 6)
+}
+
+func Test_PipelineORM_DependentRuns(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	db := store.DB
+
+	eventBroadcaster := new(mocks.EventBroadcaster)
+	orm := pipeline.NewORM(db, store.Config, eventBroadcaster, nil)
+
+	upstream := cltest.MustInsertSampleDirectRequestJob(t, db)
+	downstream := cltest.MustInsertSampleDirectRequestJob(t, db)
+	require.NoError(t, db.Exec(`UPDATE jobs SET name = 'ETH/USD' WHERE id = ?`, upstream.ID).Error)
+	require.NoError(t, db.Exec(`UPDATE jobs SET depends_on = 'ETH/USD' WHERE id = ?`, downstream.ID).Error)
+
+	insertFinishedRun := func(output interface{}, runErr null.String) int64 {
+		now := time.Now()
+		run := pipeline.Run{
+			PipelineSpecID: upstream.PipelineSpecID,
+			JobID:          &upstream.ID,
+			Outputs:        pipeline.JSONSerializable{Val: []interface{}{output}},
+			Errors:         pipeline.RunErrors{runErr},
+			CreatedAt:      now,
+			FinishedAt:     &now,
+		}
+		trrs := pipeline.TaskRunResults{{
+			Task:       &pipeline.MultiplyTask{BaseTask: pipeline.NewBaseTask("ds1_multiply", nil, 0, 0)},
+			Result:     pipeline.Result{Value: output},
+			CreatedAt:  now,
+			FinishedAt: now,
+			IsTerminal: true,
+		}}
+		runID, err := orm.InsertFinishedRunWithResults(context.Background(), run, trrs)
+		require.NoError(t, err)
+		return runID
+	}
+
+	t.Run("triggers a run of the dependent job with the upstream outputs as meta", func(t *testing.T) {
+		upstreamRunID := insertFinishedRun("2000.5", null.String{})
+
+		var runs []pipeline.Run
+		require.NoError(t, db.Preload("PipelineTaskRuns").Where("job_id = ?", downstream.ID).Find(&runs).Error)
+		require.Len(t, runs, 1)
+		require.Nil(t, runs[0].FinishedAt)
+		require.Len(t, runs[0].PipelineTaskRuns, 3)
+		require.Equal(t, map[string]interface{}{
+			pipeline.UpstreamMetaKey: map[string]interface{}{
+				"jobID":   float64(upstream.ID),
+				"jobName": "ETH/USD",
+				"runID":   float64(upstreamRunID),
+				"outputs": []interface{}{"2000.5"},
+			},
+		}, runs[0].Meta.Val)
+	})
+
+	t.Run("does not trigger the dependent job when the upstream run fails", func(t *testing.T) {
+		insertFinishedRun(nil, null.StringFrom("connection refused"))
+
+		cltest.AssertCount(t, store, pipeline.Run{}, 3)
+	})
+}
+
 func Test_PipelineORM_UpdatePipelineRun(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -49,7 +188,7 @@ func Test_PipelineORM_UpdatePipelineRun(t *testing.T) {
 	require.NoError(t, db.Exec(`SET CONSTRAINTS pipeline_runs_pipeline_spec_id_fkey DEFERRED`).Error)
 
 	eventBroadcaster := new(mocks.EventBroadcaster)
-	orm := pipeline.NewORM(db, store.Config, eventBroadcaster)
+	orm := pipeline.NewORM(db, store.Config, eventBroadcaster, nil)
 
 	t.Run("saves errored run with string error correctly", func(t *testing.T) {
 		run := cltest.MustInsertPipelineRun(t, db)
@@ -93,7 +232,7 @@ func Test_PipelineORM_FindRun(t *testing.T) {
 	db := store.DB
 
 	eventBroadcaster := new(mocks.EventBroadcaster)
-	orm := pipeline.NewORM(db, store.Config, eventBroadcaster)
+	orm := pipeline.NewORM(db, store.Config, eventBroadcaster, nil)
 
 	require.NoError(t, db.Exec(`SET CONSTRAINTS pipeline_runs_pipeline_spec_id_fkey DEFERRED`).Error)
 	expected := cltest.MustInsertPipelineRun(t, db)
@@ -0,0 +1,88 @@
+package pipeline
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Headers set on the requests of bridge tasks with signRequest set
+const (
+	RequestSignerHeader    = "X-Chainlink-Signer"
+	RequestSignatureHeader = "X-Chainlink-Signature"
+	RequestTimestampHeader = "X-Chainlink-Timestamp"
+	RequestNonceHeader     = "X-Chainlink-Nonce"
+)
+
+// ErrRequestSigningDisabled is returned by bridge tasks with signRequest set
+// when the node has no BRIDGE_SIGNING_ADDRESS
+var ErrRequestSigningDisabled = errors.New("signRequest requires BRIDGE_SIGNING_ADDRESS to be set")
+
+// RequestSigningKeyStore is the part of the node's ETH key store that
+// requests are signed with
+type RequestSigningKeyStore interface {
+	GetAccountByAddress(common.Address) (accounts.Account, error)
+	SignHash(account accounts.Account, hash []byte) ([]byte, error)
+}
+
+// RequestSigner signs the requests that bridge tasks send to external
+// adapters with one of the node's ETH keys, so that adapters can check that
+// requests genuinely came from the node without a shared secret.
+//
+// The signature is an Ethereum signed message (as made by eth_sign) of
+// RequestSigningHash, which covers the timestamp, a random nonce and the
+// request body. Adapters should recover the signer from it, compare it with
+// the node's address, and reject stale timestamps and reused nonces.
+type RequestSigner struct {
+	keyStore RequestSigningKeyStore
+	address  common.Address
+}
+
+// NewRequestSigner returns a RequestSigner for the key with the given
+// address, or nil if address is nil
+func NewRequestSigner(keyStore RequestSigningKeyStore, address *common.Address) *RequestSigner {
+	if address == nil {
+		return nil
+	}
+	return &RequestSigner{keyStore: keyStore, address: *address}
+}
+
+// RequestSigningHash returns the hash of a request that is signed
+func RequestSigningHash(timestamp, nonce string, body []byte) common.Hash {
+	return crypto.Keccak256Hash([]byte(timestamp), []byte("."), []byte(nonce), []byte("."), body)
+}
+
+// Sign sets the signature headers on request, which has the given body
+func (s *RequestSigner) Sign(request *http.Request, body []byte) error {
+	account, err := s.keyStore.GetAccountByAddress(s.address)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the bridge signing key")
+	}
+
+	nonceBytes := make([]byte, 16)
+	if _, err = rand.Read(nonceBytes); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+	nonce := hex.EncodeToString(nonceBytes)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	hash := RequestSigningHash(timestamp, nonce, body)
+	signature, err := s.keyStore.SignHash(account, accounts.TextHash(hash.Bytes()))
+	if err != nil {
+		return errors.Wrap(err, "failed to sign request")
+	}
+
+	request.Header.Set(RequestSignerHeader, s.address.Hex())
+	request.Header.Set(RequestSignatureHeader, hexutil.Encode(signature))
+	request.Header.Set(RequestTimestampHeader, timestamp)
+	request.Header.Set(RequestNonceHeader, nonce)
+	return nil
+}
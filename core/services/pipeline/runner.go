@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -14,7 +15,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shopspring/decimal"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"gorm.io/gorm"
@@ -35,20 +38,46 @@ type Runner interface {
 
 	// Deprecated
 	CreateRun(ctx context.Context, jobID int32, meta map[string]interface{}) (runID int64, err error)
+	CreateRuns(ctx context.Context, requests []RunRequest) (runIDs []int64, err error)
 	AwaitRun(ctx context.Context, runID int64) error
 	ResultsForRun(ctx context.Context, runID int64) ([]Result, error)
+
+	// CancelRuns cancels the runs of a job that this node is executing, e.g.
+	// because the job is being deleted. Their tasks fail with
+	// context.Canceled.
+	CancelRuns(jobID int32)
+	// AwaitRuns waits for the runs of a job that this node is executing to
+	// finish, including saving their results
+	AwaitRuns(ctx context.Context, jobID int32) error
 }
 
 type runner struct {
 	orm                             ORM
 	config                          Config
+	signer                          *RequestSigner
 	processIncompleteTaskRunsWorker utils.SleeperTask
 	runReaperWorker                 utils.SleeperTask
 
 	utils.StartStopOnce
-	chStop  chan struct{}
-	chDone  chan struct{}
-	newRuns postgres.Subscription
+	chStop    chan struct{}
+	chDone    chan struct{}
+	newRuns   postgres.Subscription
+	wgShadows sync.WaitGroup
+	// newRunBatches notifies of batches created by CreateRuns, which are
+	// shared out between the workers one run at a time over chBatchedRuns
+	newRunBatches postgres.Subscription
+	chBatchedRuns chan struct{}
+	// chainContext, if set, is added to the meta of every run
+	chainContext *ChainContext
+	// ethClient is used by ethcall tasks
+	ethClient eth.Client
+	// workers process the runs, and are resized by autotune when
+	// JobPipelineParallelismMax is set
+	workers    *workerPool
+	wgAutotune sync.WaitGroup
+	// inFlight is the runs being executed, which are cancelled or awaited
+	// when their job is deleted
+	inFlight *inFlightRuns
 }
 
 var (
@@ -58,15 +87,26 @@ var (
 	},
 		[]string{"job_id", "job_name", "task_type"},
 	)
+	promPipelineTaskPanics = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_task_panics",
+		Help: "The number of times a pipeline task panicked",
+	},
+		[]string{"job_id", "job_name", "task_type"},
+	)
 	ErrRunPanicked = errors.New("pipeline run panicked")
 )
 
-func NewRunner(orm ORM, config Config) *runner {
+func NewRunner(orm ORM, config Config, signer *RequestSigner, chainContext *ChainContext, ethClient eth.Client) *runner {
 	r := &runner{
-		orm:    orm,
-		config: config,
-		chStop: make(chan struct{}),
-		chDone: make(chan struct{}),
+		orm:           orm,
+		config:        config,
+		signer:        signer,
+		chainContext:  chainContext,
+		ethClient:     ethClient,
+		chStop:        make(chan struct{}),
+		chDone:        make(chan struct{}),
+		chBatchedRuns: make(chan struct{}),
+		inFlight:      newInFlightRuns(),
 	}
 	r.processIncompleteTaskRunsWorker = utils.NewSleeperTask(
 		utils.SleeperTaskFuncWorker(r.processUnfinishedRuns),
@@ -91,20 +131,58 @@ func (r *runner) Start() error {
 	}
 	r.newRuns = newRunsSubscription
 	var newRunEvents = r.newRuns.Events()
-	for i := 0; i < int(r.config.JobPipelineParallelism()); i++ {
-		go func() {
-			for {
+	r.workers = &workerPool{work: func(stop <-chan struct{}) {
+		for {
+			select {
+			case <-newRunEvents:
+				r.processUnfinishedRuns()
+			case <-r.chBatchedRuns:
+				r.processUnfinishedRuns()
+			case <-stop:
+				return
+			case <-r.chStop:
+				return
+			}
+		}
+	}}
+	r.workers.resize(r.initialParallelism())
+	if _, _, autotune := r.parallelismBounds(); autotune {
+		r.wgAutotune.Add(1)
+		go r.autotune()
+	}
+
+	newRunBatchesSubscription, err := r.orm.ListenForNewRunBatches()
+	if err != nil {
+		logger.Error("Pipeline runner could not subscribe to new run batch events, falling back to polling")
+		return nil
+	}
+	r.newRunBatches = newRunBatchesSubscription
+	go r.dispatchRunBatches()
+
+	return nil
+}
+
+// dispatchRunBatches hands each run of a batch to a worker
+func (r *runner) dispatchRunBatches() {
+	for {
+		select {
+		case event := <-r.newRunBatches.Events():
+			n, err := strconv.Atoi(event.Payload)
+			if err != nil {
+				logger.Errorw("Pipeline runner got malformed run batch event", "payload", event.Payload, "err", err)
+				continue
+			}
+			for i := 0; i < n; i++ {
 				select {
-				case <-newRunEvents:
-					r.processUnfinishedRuns()
+				case r.chBatchedRuns <- struct{}{}:
 				case <-r.chStop:
 					return
 				}
 			}
-		}()
+		case <-r.chStop:
+			return
+		}
 	}
-
-	return nil
 }
 
 func (r *runner) Close() error {
@@ -114,9 +192,14 @@ func (r *runner) Close() error {
 
 	close(r.chStop)
 	<-r.chDone
+	r.wgShadows.Wait()
+	r.wgAutotune.Wait()
 	if r.newRuns != nil {
 		r.newRuns.Close()
 	}
+	if r.newRunBatches != nil {
+		r.newRunBatches.Close()
+	}
 
 	return nil
 }
@@ -163,6 +246,15 @@ func (r *runner) CreateRun(ctx context.Context, jobID int32, meta map[string]int
 	return runID, nil
 }
 
+func (r *runner) CreateRuns(ctx context.Context, requests []RunRequest) ([]int64, error) {
+	runIDs, err := r.orm.CreateRuns(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infow("Pipeline runs created", "count", len(runIDs), "runIDs", runIDs)
+	return runIDs, nil
+}
+
 func (r *runner) AwaitRun(ctx context.Context, runID int64) error {
 	ctx, cancel := utils.CombinedContext(r.chStop, ctx)
 	defer cancel()
@@ -175,15 +267,27 @@ func (r *runner) ResultsForRun(ctx context.Context, runID int64) ([]Result, erro
 	return r.orm.ResultsForRun(ctx, runID)
 }
 
+func (r *runner) CancelRuns(jobID int32) {
+	if n := r.inFlight.cancel(jobID); n > 0 {
+		logger.Infow("Cancelled in-flight pipeline runs", "jobID", jobID, "count", n)
+	}
+}
+
+func (r *runner) AwaitRuns(ctx context.Context, jobID int32) error {
+	return r.inFlight.await(ctx, jobID)
+}
+
 // NOTE: This could potentially run on a different machine in the cluster than
 // the one that originally added the job run.
 func (r *runner) processUnfinishedRuns() {
 	ctx, cancel := utils.CombinedContext(r.chStop, r.config.JobPipelineMaxRunDuration())
 	defer cancel()
 
-	_, err := r.orm.ProcessNextUnfinishedRun(ctx, r.executeRun)
+	run, err := r.orm.ProcessNextUnfinishedRun(ctx, r.executeRun)
 	if err != nil {
 		logger.Errorf("Error processing unfinished run: %v", err)
+	} else if run != nil {
+		r.runShadows(*run, run.Meta)
 	}
 }
 
@@ -196,6 +300,8 @@ type (
 		inputs        []input
 		predMu        sync.RWMutex
 		finishMu      sync.Mutex
+		// readyAt is when the last of the task's inputs became available
+		readyAt time.Time
 	}
 
 	input struct {
@@ -270,7 +376,11 @@ func (r *runner) panickedRunResults(spec Spec) ([]TaskRunResult, error) {
 }
 
 func (r *runner) executeRun(ctx context.Context, txdb *gorm.DB, spec Spec, meta JSONSerializable, l logger.Logger) (TaskRunResults, bool, error) {
+	ctx, finished := r.inFlight.track(ctx, spec.JobID)
+	defer finished()
+
 	l.Debugw("Initiating tasks for pipeline run of spec", "job ID", spec.JobID, "job name", spec.JobName)
+	meta = withChainContext(meta, r.chainContext)
 	var (
 		err  error
 		trrs TaskRunResults
@@ -288,20 +398,62 @@ func (r *runner) executeRun(ctx context.Context, txdb *gorm.DB, spec Spec, meta
 	if err != nil {
 		return nil, false, err
 	}
+	var deadlines map[string]time.Time
+	if budget, ok := latencyBudgetFrom(ctx); ok {
+		deadlines = taskDeadlines(tasks, startRun, budget)
+	}
+
+	memoized := d.memoizedDefinitions()
+	memo := newRunMemo()
+
 	all := make(map[string]*memoryTaskRun)
 	var graph []*memoryTaskRun
 	txMu := new(sync.Mutex)
+	deferredKV := newDeferredJobKV()
 	for _, task := range tasks {
 		if task.Type() == TaskTypeHTTP {
 			task.(*HTTPTask).config = r.config
 		}
+		if task.Type() == TaskTypeJSONParse {
+			task.(*JSONParseTask).config = r.config
+		}
 		if task.Type() == TaskTypeBridge {
 			task.(*BridgeTask).config = r.config
 			task.(*BridgeTask).safeTx = SafeTx{txdb, txMu}
+			task.(*BridgeTask).signer = r.signer
+		}
+		if task.Type() == TaskTypeSQL {
+			task.(*SQLTask).safeTx = SafeTx{txdb, txMu}
+		}
+		if task.Type() == TaskTypeEthCall {
+			task.(*EthCallTask).ethClient = r.ethClient
+		}
+		switch t := task.(type) {
+		case *KVGetTask:
+			t.safeTx = SafeTx{txdb, txMu}
+			t.jobID = spec.JobID
+		case *KVSetTask:
+			t.safeTx = SafeTx{txdb, txMu}
+			t.jobID = spec.JobID
+		case *DeltaThresholdTask:
+			t.safeTx = SafeTx{txdb, txMu}
+			t.jobID = spec.JobID
+			t.deferred = deferredKV
+		case *GRPCBridgeTask:
+			t.safeTx = SafeTx{txdb, txMu}
+		case *MultiplyTask:
+			t.numericPolicy = spec.NumericPolicy
+		case *MedianTask:
+			t.numericPolicy = spec.NumericPolicy
+		case *WeightedAggregateTask:
+			t.numericPolicy = spec.NumericPolicy
+		case *ScaleTask:
+			t.numericPolicy = spec.NumericPolicy
 		}
 		mtr := memoryTaskRun{
 			nPredecessors: task.NPreds(),
 			task:          task,
+			readyAt:       startRun,
 		}
 		if mtr.nPredecessors == 0 {
 			graph = append(graph, &mtr)
@@ -338,6 +490,7 @@ func (r *runner) executeRun(ctx context.Context, txdb *gorm.DB, spec Spec, meta
 			for m != nil {
 				m.predMu.RLock()
 				nPredecessors := m.nPredecessors
+				readyAt := m.readyAt
 				m.predMu.RUnlock()
 				if nPredecessors > 0 {
 					// This one is still waiting another chain, abandon this
@@ -359,16 +512,33 @@ func (r *runner) executeRun(ctx context.Context, txdb *gorm.DB, spec Spec, meta
 
 				startTaskRun := time.Now()
 
-				result := r.executeTaskRun(ctx, spec, m.task, meta, m.results(), l)
+				inputs := m.results()
+				var calls httpCallRecorder
+				execute := func() Result {
+					return r.executeTaskRun(withHTTPCallRecorder(ctx, &calls), spec, m.task, meta, inputs, deadlines[m.task.DotID()], l)
+				}
+				var result Result
+				if definition, ok := memoized[m.task.DotID()]; ok {
+					var shared bool
+					if result, shared = memo.do(ctx, definition, inputs, execute); shared {
+						l.Debugw("Shared the result of an identical task", "taskName", m.task.DotID())
+					}
+				} else {
+					result = execute()
+				}
 
 				finishedAt := time.Now()
 
 				trr := TaskRunResult{
 					Task:       m.task,
+					Inputs:     inputs,
 					Result:     result,
 					CreatedAt:  startTaskRun,
+					StartedAt:  startTaskRun,
 					FinishedAt: finishedAt,
+					QueueWait:  startTaskRun.Sub(readyAt),
 					IsTerminal: m.next == nil,
+					HTTPCalls:  calls.calls,
 				}
 
 				updateMu.Lock()
@@ -392,6 +562,9 @@ func (r *runner) executeRun(ctx context.Context, txdb *gorm.DB, spec Spec, meta
 				m.next.predMu.Lock()
 				m.next.inputs = append(m.next.inputs, input{result: result, index: m.task.OutputIndex()})
 				m.next.nPredecessors--
+				if m.next.nPredecessors == 0 {
+					m.next.readyAt = finishedAt
+				}
 				m.next.predMu.Unlock()
 
 				m = m.next
@@ -404,14 +577,21 @@ func (r *runner) executeRun(ctx context.Context, txdb *gorm.DB, spec Spec, meta
 	runTime := time.Since(startRun)
 	l.Debugw("Finished all tasks for pipeline run", "specID", spec.ID, "runTime", runTime)
 	promPipelineRunTotalTimeToCompletion.WithLabelValues(fmt.Sprintf("%d", spec.JobID), spec.JobName).Set(float64(runTime))
-	if retry || trrs.FinalResult().HasErrors() {
+	finalResult := trrs.FinalResult()
+	if retry || finalResult.HasErrors() {
 		promPipelineRunErrors.WithLabelValues(fmt.Sprintf("%d", spec.JobID), spec.JobName).Inc()
+	} else if !finalResult.Suppressed {
+		if err = deferredKV.commit(SafeTx{txdb, txMu}, spec.JobID); err != nil {
+			return trrs, false, err
+		}
 	}
 
 	return trrs, retry, err
 }
 
-func (r *runner) executeTaskRun(ctx context.Context, spec Spec, task Task, meta JSONSerializable, inputs []Result, l logger.Logger) Result {
+// executeTaskRun runs task. A non-zero deadline is the end of the task's
+// share of the run's latency budget, when the task is pre-empted.
+func (r *runner) executeTaskRun(ctx context.Context, spec Spec, task Task, meta JSONSerializable, inputs []Result, deadline time.Time, l logger.Logger) Result {
 	loggerFields := []interface{}{
 		"taskName", task.DotID(),
 	}
@@ -430,8 +610,33 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, task Task, meta
 		ctx, cancel = utils.CombinedContext(r.chStop, time.Duration(spec.MaxTaskDuration))
 		defer cancel()
 	}
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	// Tasks downstream of a suppressed answer have nothing to do
+	for _, input := range inputs {
+		if errors.Is(input.Error, ErrRunSuppressed) {
+			return Result{Error: input.Error}
+		}
+	}
 
-	result := task.Run(ctx, meta, inputs)
+	result := runTask(ctx, task, meta, inputs)
+	var panicErr *TaskPanicError
+	if errors.As(result.Error, &panicErr) {
+		promPipelineTaskPanics.WithLabelValues(fmt.Sprintf("%d", spec.JobID), spec.JobName, string(task.Type())).Inc()
+		l.Errorw("Pipeline task panicked", "taskName", task.DotID(), "panic", panicErr.Value, "stacktrace", string(panicErr.Stack))
+	}
+	// The task only blew the budget if its share ran out before any other
+	// timeout did
+	if taskDeadline, _ := ctx.Deadline(); !deadline.IsZero() && taskDeadline.Equal(deadline) &&
+		result.Error != nil && ctx.Err() == context.DeadlineExceeded {
+		result.Error = errors.Wrapf(ErrLatencyBudgetExceeded, "task %v was pre-empted (%v)", task.DotID(), result.Error)
+		promPipelineTaskLatencyBudgetExceeded.WithLabelValues(fmt.Sprintf("%d", spec.JobID), spec.JobName, task.DotID()).Inc()
+		l.Warnw("Pipeline task exceeded its share of the latency budget", "taskName", task.DotID(), "deadline", deadline)
+	}
 	loggerFields = append(loggerFields, "result value", result.Value)
 	loggerFields = append(loggerFields, "result error", result.Error)
 	switch v := result.Value.(type) {
@@ -444,15 +649,46 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, task Task, meta
 	return result
 }
 
+// runTask runs task, turning a panic into an error so that it only fails the
+// task, rather than the whole run
+func runTask(ctx context.Context, task Task, meta JSONSerializable, inputs []Result) (result Result) {
+	defer func() {
+		if p := recover(); p != nil {
+			result = Result{Error: &TaskPanicError{Value: p, Stack: debug.Stack()}}
+		}
+	}()
+	return task.Run(ctx, meta, inputs)
+}
+
 // ExecuteAndInsertNewRun bypasses the job pipeline entirely.
 // It executes a run in memory then inserts the finished run/task run records, returning the final result
 func (r *runner) ExecuteAndInsertNewRun(ctx context.Context, spec Spec, meta JSONSerializable, l logger.Logger) (runID int64, result FinalResult, err error) {
-	var run Run
+	// Added here rather than only when the tasks run, so that it is saved
+	// with the run and its shadows see the same block
+	meta = withChainContext(meta, r.chainContext)
+	run, result, err := r.executeAndInsertNewRun(ctx, spec, meta, l)
+	if err != nil {
+		return run.ID, result, err
+	}
+	r.runShadows(run, meta)
+	return run.ID, result, nil
+}
+
+func (r *runner) executeAndInsertNewRun(ctx context.Context, spec Spec, meta JSONSerializable, l logger.Logger) (run Run, result FinalResult, err error) {
+	// Tracked until the results are saved, so that awaiting the job's runs
+	// also waits for them
+	ctx, finished := r.inFlight.track(ctx, spec.JobID)
+	defer finished()
+
 	run.PipelineSpecID = spec.ID
+	if spec.JobID != 0 {
+		run.JobID = &spec.JobID
+	}
+	run.Meta = meta
 	run.CreatedAt = time.Now()
 	trrs, err := r.ExecuteRun(ctx, spec, meta, l)
 	if err != nil {
-		return run.ID, result, errors.Wrapf(err, "error executing run for spec ID %v", spec.ID)
+		return run, result, errors.Wrapf(err, "error executing run for spec ID %v", spec.ID)
 	}
 
 	end := time.Now()
@@ -461,18 +697,102 @@ func (r *runner) ExecuteAndInsertNewRun(ctx context.Context, spec Spec, meta JSO
 	finalResult := trrs.FinalResult()
 	run.Outputs = finalResult.OutputsDB()
 	run.Errors = finalResult.ErrorsDB()
+	run.Suppressed = finalResult.Suppressed
+	if r.config.JobPipelineAuditMode() {
+		run.Audit = NewRunAudit(trrs)
+	}
 
-	if runID, err = r.orm.InsertFinishedRunWithResults(ctx, run, trrs); err != nil {
-		return runID, result, errors.Wrapf(err, "error inserting finished results for spec ID %v", spec.ID)
+	if run.ID, err = r.orm.InsertFinishedRunWithResults(ctx, run, trrs); err != nil {
+		return run, result, errors.Wrapf(err, "error inserting finished results for spec ID %v", spec.ID)
 	}
 
-	return runID, finalResult, nil
+	return run, finalResult, nil
 }
 
 func (r *runner) InsertFinishedRunWithResults(ctx context.Context, run Run, trrs TaskRunResults) (int64, error) {
 	dbCtx, cancel := context.WithTimeout(ctx, r.config.DatabaseMaximumTxDuration())
 	defer cancel()
-	return r.orm.InsertFinishedRunWithResults(dbCtx, run, trrs)
+	runID, err := r.orm.InsertFinishedRunWithResults(dbCtx, run, trrs)
+	if err != nil {
+		return runID, err
+	}
+	run.ID = runID
+	r.runShadows(run, run.Meta)
+	return runID, nil
+}
+
+// runShadows runs the jobs shadowing the job that made the primary run, with
+// the same meta, and records how each of their results compares with it.
+// Shadows run in the background so that they never hold up the primary job.
+func (r *runner) runShadows(primary Run, meta JSONSerializable) {
+	select {
+	case <-r.chStop:
+		return
+	default:
+	}
+
+	if primary.JobID == nil || !(r.config.Dev() || r.config.FeatureShadow()) {
+		return
+	}
+	shadows, err := r.orm.FindShadowSpecs(*primary.JobID)
+	if err != nil {
+		logger.Errorw("Pipeline runner could not load shadow jobs", "jobID", *primary.JobID, "error", err)
+		return
+	}
+	for _, spec := range shadows {
+		r.wgShadows.Add(1)
+		go func(spec Spec) {
+			defer r.wgShadows.Done()
+			r.runShadow(primary, spec, meta)
+		}(spec)
+	}
+}
+
+func (r *runner) runShadow(primary Run, spec Spec, meta JSONSerializable) {
+	ctx, cancel := utils.CombinedContext(r.chStop, r.config.JobPipelineMaxRunDuration())
+	defer cancel()
+
+	l := logger.CreateLogger(logger.Default.With("shadowJobID", spec.JobID, "primaryRunID", primary.ID))
+	shadow, _, err := r.executeAndInsertNewRun(ctx, spec, meta, *l)
+	if err != nil {
+		l.Errorw("Pipeline runner failed to run shadow job", "error", err)
+		return
+	}
+
+	comparison := ShadowComparison{
+		PrimaryRunID:   primary.ID,
+		ShadowRunID:    shadow.ID,
+		Delta:          shadowDelta(primary.Outputs, shadow.Outputs),
+		PrimaryLatency: models.Interval(primary.FinishedAt.Sub(primary.CreatedAt)),
+		ShadowLatency:  models.Interval(shadow.FinishedAt.Sub(shadow.CreatedAt)),
+		CreatedAt:      time.Now(),
+	}
+	if err := r.orm.InsertShadowComparison(ctx, &comparison); err != nil {
+		l.Errorw("Pipeline runner failed to record shadow comparison", "error", err)
+	}
+}
+
+// shadowDelta returns the shadow's result less the primary's, if they both
+// have a single numeric result
+func shadowDelta(primary, shadow JSONSerializable) decimal.NullDecimal {
+	p, ok := singleDecimalOutput(primary)
+	if !ok {
+		return decimal.NullDecimal{}
+	}
+	s, ok := singleDecimalOutput(shadow)
+	if !ok {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{Decimal: s.Sub(p), Valid: true}
+}
+
+func singleDecimalOutput(outputs JSONSerializable) (decimal.Decimal, bool) {
+	values, ok := outputs.Val.([]interface{})
+	if !ok || len(values) != 1 || values[0] == nil {
+		return decimal.Decimal{}, false
+	}
+	d, err := utils.ToDecimal(values[0])
+	return d, err == nil
 }
 
 func (r *runner) runReaper() {
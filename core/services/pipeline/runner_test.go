@@ -2,13 +2,17 @@ package pipeline_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/guregu/null.v4"
 
@@ -53,7 +57,7 @@ func Test_PipelineRunner_ExecuteTaskRuns(t *testing.T) {
 	orm := new(mocks.ORM)
 	orm.On("DB").Return(store.DB)
 
-	r := pipeline.NewRunner(orm, store.Config)
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
 
 	d := pipeline.TaskDAG{}
 	s := fmt.Sprintf(`
@@ -107,6 +111,15 @@ ds5 [type=http method="GET" url="%s" index=2]
 	}
 	// There are three tasks in the erroring pipeline
 	require.Len(t, errorResults, 3)
+
+	calls := make(map[pipeline.TaskType]int64)
+	for _, cost := range pipeline.NewRunCosts(trrs).HTTP {
+		if cost.Host == "127.0.0.1" {
+			calls[cost.Type] += cost.Calls
+			assert.Greater(t, cost.BytesReceived, int64(0))
+		}
+	}
+	assert.Equal(t, map[pipeline.TaskType]int64{pipeline.TaskTypeBridge: 1, pipeline.TaskTypeHTTP: 3}, calls)
 }
 
 func Test_PipelineRunner_HandleFaults(t *testing.T) {
@@ -141,7 +154,7 @@ ds2 -> ds2_parse -> ds2_multiply -> answer1;
 answer1 [type=median                      index=0];
 `, m1.URL, m2.URL)
 
-	r := pipeline.NewRunner(orm, store.Config)
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
 
 	// If we cancel before an API is finished, we should still get a median.
 	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
@@ -158,6 +171,198 @@ answer1 [type=median                      index=0];
 	}
 }
 
+func Test_PipelineRunner_LatencyBudget(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	orm := new(mocks.ORM)
+	orm.On("DB").Return(store.DB)
+	slow := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		res.WriteHeader(http.StatusOK)
+		res.Write([]byte(`{"result":10}`))
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusOK)
+		res.Write([]byte(`{"result":11}`))
+	}))
+	defer fast.Close()
+	s := fmt.Sprintf(`
+ds1          [type=http url="%s"];
+ds1_parse    [type=jsonparse path="result"];
+ds1_multiply [type=multiply times=100];
+
+ds2          [type=http url="%s"];
+ds2_parse    [type=jsonparse path="result"];
+ds2_multiply [type=multiply times=100];
+
+ds1 -> ds1_parse -> ds1_multiply -> answer1;
+ds2 -> ds2_parse -> ds2_multiply -> answer1;
+
+answer1 [type=median                      index=0];
+`, slow.URL, fast.URL)
+
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
+
+	// The slow data source is pre-empted when its share of the budget runs
+	// out, well before the budget itself does
+	ctx := pipeline.WithLatencyBudget(context.Background(), 300*time.Millisecond)
+	start := time.Now()
+	trrs, err := r.ExecuteRun(ctx, pipeline.Spec{DotDagSource: s}, pipeline.JSONSerializable{}, *logger.Default)
+	require.NoError(t, err)
+	assert.Less(t, int64(time.Since(start)), int64(300*time.Millisecond))
+
+	for _, trr := range trrs {
+		switch trr.Task.DotID() {
+		case "ds1":
+			require.Error(t, trr.Result.Error)
+			assert.Equal(t, pipeline.ErrLatencyBudgetExceeded, errors.Cause(trr.Result.Error))
+			assert.Contains(t, trr.Result.Error.Error(), "task ds1 was pre-empted")
+		case "ds2":
+			assert.NoError(t, trr.Result.Error)
+		case "answer1":
+			require.Equal(t, decimal.RequireFromString("1100"), trr.Result.Value.(decimal.Decimal))
+		}
+	}
+}
+
+func Test_PipelineRunner_CountsEachAttempt(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	orm := new(mocks.ORM)
+	orm.On("DB").Return(store.DB)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			res.WriteHeader(http.StatusBadGateway)
+			res.Write([]byte(`{}`))
+			return
+		}
+		res.WriteHeader(http.StatusOK)
+		res.Write([]byte(`{"data":{"result":10}}`))
+	}))
+	defer server.Close()
+
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
+	s := fmt.Sprintf(`
+ds1 [type=http url="%s"];
+ds1_parse [type=jsonparse path="data,result"];
+
+ds1 -> ds1_parse;
+`, server.URL)
+	trrs, err := r.ExecuteRun(context.Background(), pipeline.Spec{DotDagSource: s}, pipeline.JSONSerializable{}, *logger.Default)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	costs := pipeline.NewRunCosts(trrs)
+	require.Len(t, costs.HTTP, 1)
+	assert.Equal(t, int64(2), costs.HTTP[0].Calls)
+	assert.Equal(t, int64(len(`{}`)+len(`{"data":{"result":10}}`)), costs.HTTP[0].BytesReceived)
+}
+
+func Test_PipelineRunner_Memoize(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	orm := new(mocks.ORM)
+	orm.On("DB").Return(store.DB)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(50 * time.Millisecond)
+		res.WriteHeader(http.StatusOK)
+		res.Write([]byte(`{"data":{"result":10}}`))
+	}))
+	defer server.Close()
+
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
+	run := func(memoize bool) pipeline.TaskRunResults {
+		s := fmt.Sprintf(`
+ds1 [type=http url="%[1]s" memoize=%[2]t index=0];
+ds1_parse [type=jsonparse path="data,result" memoize=%[2]t];
+ds2 [type=http url="%[1]s" memoize=%[2]t index=1];
+ds2_parse [type=jsonparse path="data,result" memoize=%[2]t];
+ds3 [type=http url="%[1]s?other" memoize=%[2]t];
+ds3_parse [type=jsonparse path="data,result"];
+
+ds1 -> ds1_parse -> answer1;
+ds2 -> ds2_parse -> answer1;
+ds3 -> ds3_parse -> answer1;
+
+answer1 [type=median index=0];
+`, server.URL, memoize)
+		trrs, err := r.ExecuteRun(context.Background(), pipeline.Spec{DotDagSource: s}, pipeline.JSONSerializable{}, *logger.Default)
+		require.NoError(t, err)
+		require.Len(t, trrs, 7)
+		assert.Equal(t, "10", trrs.FinalResult().Values[0].(decimal.Decimal).String())
+		return trrs
+	}
+
+	trrs := run(true)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	var calls int
+	for _, trr := range trrs {
+		calls += len(trr.HTTPCalls)
+		if trr.Task.DotID() == "ds1" || trr.Task.DotID() == "ds2" {
+			assert.Equal(t, `{"data":{"result":10}}`, trr.Result.Value)
+		}
+	}
+	assert.Equal(t, 2, calls, "shared results do not count as calls")
+
+	atomic.StoreInt32(&requests, 0)
+	run(false)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	_, err := r.ExecuteRun(context.Background(), pipeline.Spec{DotDagSource: `ds1 [type=http url="https://example.com" memoize=sometimes];`}, pipeline.JSONSerializable{}, *logger.Default)
+	assert.Error(t, err)
+}
+
+func Test_PipelineRunner_TaskTimestamps(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	orm := new(mocks.ORM)
+	orm.On("DB").Return(store.DB)
+	slow := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		res.WriteHeader(http.StatusOK)
+		res.Write([]byte(`10`))
+	}))
+	defer slow.Close()
+	s := fmt.Sprintf(`
+ds1 [type=http url="%s"];
+ds2 [type=http url="%s"];
+
+ds1 -> answer1;
+ds2 -> answer1;
+
+answer1 [type=median index=0];
+`, slow.URL, slow.URL)
+
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
+	trrs, err := r.ExecuteRun(context.Background(), pipeline.Spec{DotDagSource: s}, pipeline.JSONSerializable{}, *logger.Default)
+	require.NoError(t, err)
+	require.Len(t, trrs, 3)
+
+	var lastSourceFinishedAt time.Time
+	for _, trr := range trrs {
+		require.False(t, trr.StartedAt.IsZero())
+		assert.False(t, trr.FinishedAt.Before(trr.StartedAt))
+		assert.GreaterOrEqual(t, int64(trr.QueueWait), int64(0))
+		if trr.Task.DotID() != "answer1" && trr.FinishedAt.After(lastSourceFinishedAt) {
+			lastSourceFinishedAt = trr.FinishedAt
+		}
+	}
+	for _, trr := range trrs {
+		if trr.Task.DotID() == "answer1" {
+			// The median was ready once both sources finished
+			assert.False(t, trr.StartedAt.Before(lastSourceFinishedAt))
+			// Time spent waiting for its inputs is not queue wait
+			assert.Less(t, int64(trr.QueueWait), int64(200*time.Millisecond))
+		}
+	}
+}
+
 func TestPanicTask_Run(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -167,7 +372,7 @@ func TestPanicTask_Run(t *testing.T) {
 		res.WriteHeader(http.StatusOK)
 		res.Write([]byte(`{"result":10}`))
 	}))
-	r := pipeline.NewRunner(orm, store.Config)
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
 	trrs, err := r.ExecuteRun(context.Background(), pipeline.Spec{
 		DotDagSource: fmt.Sprintf(`
 ds1 [type=http url="%s"]
@@ -179,9 +384,58 @@ ds1->ds_parse->ds_multiply->ds_panic;`, s.URL),
 	require.NoError(t, err)
 	require.Equal(t, 4, len(trrs))
 	assert.Equal(t, []interface{}{nil}, trrs.FinalResult().Values)
-	assert.Equal(t, pipeline.ErrRunPanicked.Error(), trrs.FinalResult().Errors[0].Error())
+	assert.True(t, errors.Is(trrs.FinalResult().Errors[0], pipeline.ErrTaskPanicked))
 	for _, trr := range trrs {
-		assert.Equal(t, null.NewString("pipeline run panicked", true), trr.Result.ErrorDB())
+		if trr.Task.DotID() != "ds_panic" {
+			// Only the task that panicked fails
+			assert.NoError(t, trr.Result.Error)
+			assert.False(t, trr.Result.StackTraceDB().Valid)
+			continue
+		}
+		assert.Equal(t, null.NewString("pipeline task panicked: oh no", true), trr.Result.ErrorDB())
 		assert.Equal(t, true, trr.Result.OutputDB().Null)
+		assert.Contains(t, trr.Result.StackTraceDB().String, "task.panic.go")
 	}
 }
+
+func Test_PipelineRunner_ChainContext(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	orm := new(mocks.ORM)
+	orm.On("DB").Return(store.DB)
+
+	var meta map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Meta map[string]interface{} `json:"meta"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		meta = body.Meta
+		res.WriteHeader(http.StatusOK)
+		res.Write([]byte(`10`))
+	}))
+	defer server.Close()
+	bridgeURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+	_, bridge := cltest.NewBridgeType(t, "chain-aware-bridge")
+	bridge.URL = models.WebURL(*bridgeURL)
+	require.NoError(t, store.DB.Create(&bridge).Error)
+
+	chainContext := pipeline.NewChainContext(big.NewInt(42))
+	head := cltest.Head(1234)
+	chainContext.OnNewLongestChain(context.Background(), *head)
+	r := pipeline.NewRunner(orm, store.Config, nil, chainContext, nil)
+
+	spec := pipeline.Spec{DotDagSource: `ds1 [type=bridge name="chain-aware-bridge"];`}
+	trrs, err := r.ExecuteRun(context.Background(), spec, pipeline.JSONSerializable{Val: map[string]interface{}{"foo": "bar"}}, *logger.Default)
+	require.NoError(t, err)
+	require.NoError(t, trrs.FinalResult().Errors[0])
+
+	assert.Equal(t, "bar", meta["foo"])
+	assert.Equal(t, map[string]interface{}{
+		"chainID":        "42",
+		"blockNumber":    float64(1234),
+		"blockHash":      head.Hash.Hex(),
+		"blockTimestamp": float64(0),
+	}, meta[pipeline.ChainContextKey])
+}
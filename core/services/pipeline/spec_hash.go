@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// SpecHash is the content hash of a pipeline spec. Pipeline specs are content
+// addressed: jobs whose pipelines are the same share a single spec.
+type SpecHash [sha256.Size]byte
+
+// HashSpec returns the content hash of a pipeline spec. The parsed DAG is
+// hashed rather than its DOT source, as its tasks and edges in a canonical
+// order, so that reformatting or reordering a job's observationSource doesn't
+// change its hash while any change to a task's attributes does.
+func HashSpec(taskDAG TaskDAG, maxTaskDuration models.Interval, numericPolicy NumericPolicy) (SpecHash, error) {
+	tasks, err := json.Marshal(taskDAG.DOTTasks())
+	if err != nil {
+		return SpecHash{}, errors.Wrap(err, "could not hash pipeline tasks")
+	}
+	policy, err := json.Marshal(numericPolicy)
+	if err != nil {
+		return SpecHash{}, errors.Wrap(err, "could not hash numeric policy")
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "v2\n%s\n%d\n%s", tasks, maxTaskDuration, policy)
+	var hash SpecHash
+	copy(hash[:], h.Sum(nil))
+	return hash, nil
+}
+
+func (h SpecHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+func (h SpecHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *SpecHash) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(string(text))
+	if err != nil {
+		return errors.Wrap(err, "invalid spec hash")
+	}
+	if len(b) != len(h) {
+		return errors.Errorf("invalid spec hash, expected %d bytes, got %d", len(h), len(b))
+	}
+	copy(h[:], b)
+	return nil
+}
+
+func (h *SpecHash) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("SpecHash#Scan received a value of type %T", value)
+	}
+	if len(b) != len(h) {
+		return errors.Errorf("SpecHash#Scan expected %d bytes, got %d", len(h), len(b))
+	}
+	copy(h[:], b)
+	return nil
+}
+
+func (h SpecHash) Value() (driver.Value, error) {
+	return h[:], nil
+}
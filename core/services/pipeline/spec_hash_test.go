@@ -0,0 +1,80 @@
+package pipeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func TestHashSpec(t *testing.T) {
+	t.Parallel()
+
+	source := `
+    ds1       [type=http method=GET url="https://chain.link/voter_turnout/USA-2020"];
+    ds1_parse [type=jsonparse path="USD"];
+    ds1 -> ds1_parse;
+`
+	hash, err := pipeline.HashSpec(mustParseDAG(t, source), models.Interval(0), pipeline.NumericPolicy{})
+	require.NoError(t, err)
+
+	t.Run("ignores formatting and declaration order", func(t *testing.T) {
+		reformatted := "ds1_parse [type=jsonparse path=USD];\n\n\tds1 [ type=http   url=\"https://chain.link/voter_turnout/USA-2020\" method=GET ];\nds1 -> ds1_parse"
+		other, err := pipeline.HashSpec(mustParseDAG(t, reformatted), models.Interval(0), pipeline.NumericPolicy{})
+		require.NoError(t, err)
+		assert.Equal(t, hash, other)
+	})
+
+	t.Run("differs for different pipelines", func(t *testing.T) {
+		other, err := pipeline.HashSpec(mustParseDAG(t, source+"ds1_multiply [type=multiply times=100];"), models.Interval(0), pipeline.NumericPolicy{})
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+
+		other, err = pipeline.HashSpec(mustParseDAG(t, source+"ds1_parse -> ds1;"), models.Interval(0), pipeline.NumericPolicy{})
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+
+		other, err = pipeline.HashSpec(mustParseDAG(t, source), models.Interval(time.Second), pipeline.NumericPolicy{})
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+
+		other, err = pipeline.HashSpec(mustParseDAG(t, source), models.Interval(0), pipeline.NumericPolicy{MaxSignificantDigits: 6})
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("keeps whitespace inside quoted values", func(t *testing.T) {
+		body := func(indent string) string {
+			return `ds1 [type=http method=POST url="https://example.com" requestData="{
+` + indent + `\"a\": \"x  y\"
+}"];`
+		}
+		one, err := pipeline.HashSpec(mustParseDAG(t, body("  ")), models.Interval(0), pipeline.NumericPolicy{})
+		require.NoError(t, err)
+		other, err := pipeline.HashSpec(mustParseDAG(t, body("    ")), models.Interval(0), pipeline.NumericPolicy{})
+		require.NoError(t, err)
+		assert.NotEqual(t, one, other)
+	})
+
+	t.Run("round trips as text", func(t *testing.T) {
+		text, err := hash.MarshalText()
+		require.NoError(t, err)
+		assert.Len(t, text, 64)
+
+		var decoded pipeline.SpecHash
+		require.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, hash, decoded)
+		assert.Error(t, decoded.UnmarshalText([]byte("abc")))
+	})
+}
+
+func mustParseDAG(t *testing.T, source string) pipeline.TaskDAG {
+	t.Helper()
+	dag := pipeline.NewTaskDAG()
+	require.NoError(t, dag.UnmarshalText([]byte(source)))
+	return *dag
+}
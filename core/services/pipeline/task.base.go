@@ -8,6 +8,9 @@ type BaseTask struct {
 	nPreds     int           `mapstructure:"-"`
 	Index      int32         `mapstructure:"index" json:"-" `
 	Timeout    time.Duration `mapstructure:"timeout"`
+	// Memoize shares the task's result with the tasks of the same run that
+	// have the same definition and inputs, see TaskDAG#memoizedDefinitions
+	Memoize bool `mapstructure:"memoize"`
 }
 
 func (t BaseTask) NPreds() int {
@@ -2,7 +2,6 @@ package pipeline
 
 import (
 	"context"
-	"net/url"
 
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -12,11 +11,19 @@ import (
 type BridgeTask struct {
 	BaseTask `mapstructure:",squash"`
 
-	Name        string          `json:"name"`
-	RequestData HttpRequestData `json:"requestData"`
+	Name                   string          `json:"name"`
+	RequestData            HttpRequestData `json:"requestData"`
+	ResponseSchema         string          `json:"responseSchema"`
+	AllowedStatusCodes     string          `json:"allowedStatusCodes"`
+	ErrorResponsesAsOutput bool            `json:"errorResponsesAsOutput"`
+	CaptureHeaders         []string        `json:"captureHeaders"`
+	// SignRequest signs requests with the node's BRIDGE_SIGNING_ADDRESS key,
+	// see RequestSigner
+	SignRequest bool `json:"signRequest"`
 
 	safeTx SafeTx
 	config Config
+	signer *RequestSigner
 }
 
 var _ Task = (*BridgeTask)(nil)
@@ -34,11 +41,29 @@ func (t *BridgeTask) Run(ctx context.Context, meta JSONSerializable, inputs []Re
 		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "BridgeTask requires 0 inputs")}
 	}
 
-	url, err := t.getBridgeURLFromName()
+	var signer *RequestSigner
+	if t.SignRequest {
+		if t.signer == nil {
+			return Result{Error: ErrRequestSigningDisabled}
+		}
+		signer = t.signer
+	}
+
+	bridge, err := t.getBridgeFromName()
+	if err != nil {
+		return Result{Error: err}
+	}
+	requestURL, err := bridge.URL.WithEnv()
 	if err != nil {
 		return Result{Error: err}
 	}
 
+	release, err := bridgeLimiter.acquire(ctx, t.Name, bridge.MaxConcurrentCalls)
+	if err != nil {
+		return Result{Error: err}
+	}
+	defer release()
+
 	var metaMap map[string]interface{}
 	switch v := meta.Val.(type) {
 	case map[string]interface{}:
@@ -52,25 +77,32 @@ func (t *BridgeTask) Run(ctx context.Context, meta JSONSerializable, inputs []Re
 	}
 
 	result = (&HTTPTask{
-		URL:         models.WebURL(url),
+		URL:         bridge.URL,
 		Method:      "POST",
 		RequestData: withMeta(t.RequestData, metaMap),
 		// URL is "safe" because it comes from the node's own database
 		// Some node operators may run external adapters on their own hardware
 		AllowUnrestrictedNetworkAccess: MaybeBoolTrue,
+		ResponseSchema:                 t.ResponseSchema,
+		AllowedStatusCodes:             t.AllowedStatusCodes,
+		ErrorResponsesAsOutput:         t.ErrorResponsesAsOutput,
+		CaptureHeaders:                 t.CaptureHeaders,
 		config:                         t.config,
+		signer:                         signer,
+		requestURL:                     requestURL,
+		sizeLimit:                      responseSizeLimit(t.config, t.OutputTask()),
 	}).Run(ctx, meta, inputs)
 	if result.Error != nil {
 		return result
 	}
 	logger.Debugw("Bridge task: fetched answer",
 		"answer", result.Value,
-		"url", url.String(),
+		"url", bridge.URL.String(),
 	)
 	return result
 }
 
-func (t BridgeTask) getBridgeURLFromName() (url.URL, error) {
+func (t BridgeTask) getBridgeFromName() (models.BridgeType, error) {
 	task := models.TaskType(t.Name)
 
 	if t.safeTx.txMu != nil {
@@ -78,12 +110,7 @@ func (t BridgeTask) getBridgeURLFromName() (url.URL, error) {
 		defer t.safeTx.txMu.Unlock()
 	}
 
-	bridge, err := FindBridge(t.safeTx.tx, task)
-	if err != nil {
-		return url.URL{}, err
-	}
-	bridgeURL := url.URL(bridge.URL)
-	return bridgeURL, nil
+	return FindBridge(t.safeTx.tx, task)
 }
 
 func withMeta(request HttpRequestData, meta HttpRequestData) HttpRequestData {
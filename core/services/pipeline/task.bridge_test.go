@@ -2,6 +2,7 @@ package pipeline_test
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,9 +10,18 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v4"
 
@@ -273,7 +283,202 @@ func TestBridgeTask_ErrorIfBridgeMissing(t *testing.T) {
 	result := task.Run(context.Background(), pipeline.JSONSerializable{emptyMeta, false}, nil)
 	require.Nil(t, result.Value)
 	require.Error(t, result.Error)
-	require.Equal(t, "could not find bridge with name 'foo': record not found", result.Error.Error())
+	require.Equal(t, pipeline.NoSuchBridgeError{Name: "foo"}, result.Error)
+	require.True(t, errors.Is(result.Error, pipeline.ErrNoSuchBridge))
+	require.Equal(t, "no such bridge exists: foo", result.Error.Error())
+}
+
+func TestBridgeTask_EnvPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	var requestedPath, requestedKey string
+	s1 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		requestedKey = r.URL.Query().Get("key")
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer s1.Close()
+
+	os.Setenv("CL_BRIDGE_TASK_TEST_VERSION", "v2")
+	defer os.Unsetenv("CL_BRIDGE_TASK_TEST_VERSION")
+	os.Setenv("CL_BRIDGE_TASK_TEST_KEY", "s3cr3t")
+	defer os.Unsetenv("CL_BRIDGE_TASK_TEST_KEY")
+
+	task := pipeline.BridgeTask{Name: "envbridge"}
+	task.HelperSetConfigAndTxDB(store.Config, store.DB)
+
+	_, bridge := cltest.NewBridgeType(t, task.Name)
+	bridge.URL = cltest.WebURL(t, s1.URL+"/${CL_BRIDGE_TASK_TEST_VERSION}/price?key=${CL_BRIDGE_TASK_TEST_KEY}")
+	require.NoError(t, store.ORM.DB.Create(&bridge).Error)
+
+	result := task.Run(context.Background(), pipeline.JSONSerializable{emptyMeta, false}, nil)
+	require.Error(t, result.Error)
+	assert.Equal(t, "/v2/price", requestedPath)
+	assert.Equal(t, "s3cr3t", requestedKey)
+	// The values of the variables stay out of errors
+	assert.Contains(t, result.Error.Error(), "/$%7BCL_BRIDGE_TASK_TEST_VERSION%7D/price?key=${CL_BRIDGE_TASK_TEST_KEY}")
+	assert.NotContains(t, result.Error.Error(), "s3cr3t")
+
+	os.Unsetenv("CL_BRIDGE_TASK_TEST_KEY")
+	result = task.Run(context.Background(), pipeline.JSONSerializable{emptyMeta, false}, nil)
+	require.Error(t, result.Error)
+	assert.Contains(t, result.Error.Error(), "references environment variables that are not set: CL_BRIDGE_TASK_TEST_KEY")
+}
+
+// fakeSigningKeyStore signs hashes with a single in-memory key
+type fakeSigningKeyStore struct {
+	key *ecdsa.PrivateKey
+}
+
+func (ks fakeSigningKeyStore) GetAccountByAddress(address common.Address) (accounts.Account, error) {
+	return accounts.Account{Address: address}, nil
+}
+
+func (ks fakeSigningKeyStore) SignHash(_ accounts.Account, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, ks.key)
+}
+
+func TestBridgeTask_MaxConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	s1 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"data":{"result":1}}`))
+	}))
+	defer s1.Close()
+
+	feedURL, err := url.ParseRequestURI(s1.URL)
+	require.NoError(t, err)
+
+	_, bridge := cltest.NewBridgeType(t, "concurrencylimited")
+	bridge.URL = models.WebURL(*feedURL)
+	bridge.MaxConcurrentCalls = 1
+	require.NoError(t, store.ORM.DB.Create(&bridge).Error)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task := pipeline.BridgeTask{Name: "concurrencylimited"}
+			task.HelperSetConfigAndTxDB(store.Config, store.DB)
+			result := task.Run(context.Background(), pipeline.JSONSerializable{emptyMeta, false}, nil)
+			assert.NoError(t, result.Error)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxInFlight)
+}
+
+func TestBridgeTask_SignRequest(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	var signer common.Address
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		hash := pipeline.RequestSigningHash(r.Header.Get(pipeline.RequestTimestampHeader), r.Header.Get(pipeline.RequestNonceHeader), body)
+		signature, err := hexutil.Decode(r.Header.Get(pipeline.RequestSignatureHeader))
+		require.NoError(t, err)
+		pubKey, err := crypto.SigToPub(accounts.TextHash(hash.Bytes()), signature)
+		require.NoError(t, err)
+		signer = crypto.PubkeyToAddress(*pubKey)
+		assert.Equal(t, address.Hex(), r.Header.Get(pipeline.RequestSignerHeader))
+		_, err = w.Write([]byte(`{"data": {"result": 1}}`))
+		require.NoError(t, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	bridgeURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	_, bridge := cltest.NewBridgeType(t, "signed")
+	bridge.URL = models.WebURL(*bridgeURL)
+	require.NoError(t, store.ORM.DB.Create(&bridge).Error)
+
+	task := pipeline.BridgeTask{Name: "signed", SignRequest: true}
+	task.HelperSetConfigAndTxDB(store.Config, store.DB)
+
+	result := task.Run(context.Background(), pipeline.JSONSerializable{emptyMeta, false}, nil)
+	require.Equal(t, pipeline.ErrRequestSigningDisabled, result.Error)
+
+	task.HelperSetSigner(pipeline.NewRequestSigner(fakeSigningKeyStore{key}, &address))
+	result = task.Run(context.Background(), pipeline.JSONSerializable{emptyMeta, false}, nil)
+	require.NoError(t, result.Error)
+	assert.Equal(t, address, signer)
+}
+
+func TestBridgeTask_SignRequest_SignsEachAttempt(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	var nonces []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		nonce := r.Header.Get(pipeline.RequestNonceHeader)
+		hash := pipeline.RequestSigningHash(r.Header.Get(pipeline.RequestTimestampHeader), nonce, body)
+		signature, err := hexutil.Decode(r.Header.Get(pipeline.RequestSignatureHeader))
+		require.NoError(t, err)
+		pubKey, err := crypto.SigToPub(accounts.TextHash(hash.Bytes()), signature)
+		require.NoError(t, err)
+		assert.Equal(t, address, crypto.PubkeyToAddress(*pubKey))
+		nonces = append(nonces, nonce)
+		if len(nonces) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, err = w.Write([]byte(`{"data": {"result": 1}}`))
+		require.NoError(t, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	bridgeURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	_, bridge := cltest.NewBridgeType(t, "signed")
+	bridge.URL = models.WebURL(*bridgeURL)
+	require.NoError(t, store.ORM.DB.Create(&bridge).Error)
+
+	task := pipeline.BridgeTask{Name: "signed", SignRequest: true}
+	task.HelperSetConfigAndTxDB(store.Config, store.DB)
+	task.HelperSetSigner(pipeline.NewRequestSigner(fakeSigningKeyStore{key}, &address))
+
+	result := task.Run(context.Background(), pipeline.JSONSerializable{emptyMeta, false}, nil)
+	require.NoError(t, result.Error)
+	require.Len(t, nonces, 2)
+	assert.NotEqual(t, nonces[0], nonces[1])
 }
 
 // Sample input taken from
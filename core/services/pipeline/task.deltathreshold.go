@@ -0,0 +1,113 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// ErrRunSuppressed is returned by deltathreshold tasks whose answer has not
+// changed enough to be worth passing on. The tasks that depend on them are
+// skipped, and the run finishes as suppressed rather than errored: the error
+// only carries the suppression through the run, and isn't saved with it.
+var ErrRunSuppressed = errors.New("run suppressed")
+
+// DeltaThresholdTask passes its input on only if it has moved by at least
+// threshold percent from the last answer it passed on, or if heartbeat has
+// elapsed since then. Otherwise it suppresses the run, so that push-style
+// jobs don't do any downstream work for answers that haven't changed. The
+// last answer is kept in the job's key/value store under key, which defaults
+// to the task's name. It is only stored once the rest of the run has
+// succeeded, so that an answer that failed downstream is passed on again.
+//
+//	deviation [type=deltathreshold threshold=0.5 heartbeat="1h"];
+type DeltaThresholdTask struct {
+	BaseTask `mapstructure:",squash"`
+
+	Key       string          `json:"key"`
+	Threshold decimal.Decimal `json:"threshold"`
+	Heartbeat time.Duration   `json:"heartbeat"`
+
+	safeTx   SafeTx
+	jobID    int32
+	deferred *deferredJobKV
+}
+
+var _ Task = (*DeltaThresholdTask)(nil)
+
+func (t *DeltaThresholdTask) Type() TaskType {
+	return TaskTypeDeltaThreshold
+}
+
+func (t *DeltaThresholdTask) SetDefaults(inputValues map[string]string, g TaskDAG, self taskDAGNode) error {
+	return nil
+}
+
+func (t *DeltaThresholdTask) Run(_ context.Context, _ JSONSerializable, inputs []Result) Result {
+	if len(inputs) != 1 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "DeltaThresholdTask requires a single input")}
+	} else if inputs[0].Error != nil {
+		return Result{Error: inputs[0].Error}
+	}
+	if t.jobID == 0 {
+		return Result{Error: ErrNoJobKVStore}
+	}
+	answer, err := utils.ToDecimal(inputs[0].Value)
+	if err != nil {
+		return Result{Error: err}
+	}
+	key := t.Key
+	if key == "" {
+		key = t.DotID()
+	}
+
+	now := time.Now()
+	stored, err := loadJobKV(t.safeTx, t.jobID, key)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return Result{Error: err}
+	} else if err == nil {
+		previous, at, err := parseDeltaThresholdState(stored)
+		if err != nil {
+			return Result{Error: errors.Wrapf(err, "malformed previous answer in key %q", key)}
+		}
+		heartbeatDue := t.Heartbeat > 0 && now.Sub(at) >= t.Heartbeat
+		if !heartbeatDue && !exceedsThreshold(previous, answer, t.Threshold) {
+			return Result{Error: errors.Wrapf(ErrRunSuppressed, "%v is within %v%% of the previous answer %v", answer, t.Threshold, previous)}
+		}
+	}
+
+	state := JSONSerializable{Val: map[string]interface{}{
+		"answer": answer.String(),
+		"at":     now.UTC().Format(time.RFC3339Nano),
+	}}
+	t.deferred.set(key, state)
+	return Result{Value: inputs[0].Value}
+}
+
+func parseDeltaThresholdState(stored JSONSerializable) (answer decimal.Decimal, at time.Time, err error) {
+	state, ok := stored.Val.(map[string]interface{})
+	if !ok {
+		return answer, at, errors.Errorf("expected an object, got %T", stored.Val)
+	}
+	answer, err = utils.ToDecimal(state["answer"])
+	if err != nil {
+		return answer, at, err
+	}
+	atString, _ := state["at"].(string)
+	at, err = time.Parse(time.RFC3339Nano, atString)
+	return answer, at, err
+}
+
+// exceedsThreshold reports whether answer differs from previous by at least
+// threshold percent. Any change from zero exceeds it.
+func exceedsThreshold(previous, answer, threshold decimal.Decimal) bool {
+	if previous.IsZero() {
+		return !answer.IsZero()
+	}
+	change := answer.Sub(previous).Div(previous).Abs().Mul(decimal.NewFromInt(100))
+	return change.GreaterThanOrEqual(threshold)
+}
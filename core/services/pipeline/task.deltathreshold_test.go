@@ -0,0 +1,89 @@
+package pipeline_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+func TestDeltaThresholdTask(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	orm := new(mocks.ORM)
+	orm.On("DB").Return(store.DB)
+	r := pipeline.NewRunner(orm, store.Config, nil, nil, nil)
+
+	var answer string
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusOK)
+		res.Write([]byte(fmt.Sprintf(`{"result": %s}`, answer)))
+	}))
+	defer server.Close()
+
+	jb := cltest.MustInsertSampleDirectRequestJob(t, store.DB)
+	spec := pipeline.Spec{JobID: jb.ID, DotDagSource: fmt.Sprintf(`
+ds        [type=http method=GET url="%s"];
+ds_parse  [type=jsonparse path="result"];
+deviation [type=deltathreshold threshold=1];
+multiply  [type=multiply times=10];
+ds -> ds_parse -> deviation -> multiply;
+`, server.URL)}
+
+	tests := []struct {
+		answer     string
+		suppressed bool
+	}{
+		{"100", false},  // the first answer is always passed on
+		{"100.5", true}, // 0.5% from 100
+		{"101", false},  // 1% from 100
+		{"100", true},   // within 1% of 101
+		{"0", false},
+		{"0", true},
+		{"1", false}, // any change from zero
+	}
+	for _, test := range tests {
+		answer = test.answer
+		trrs, err := r.ExecuteRun(context.Background(), spec, pipeline.JSONSerializable{}, *logger.Default)
+		require.NoError(t, err)
+		result := trrs.FinalResult()
+		assert.Equal(t, test.suppressed, result.Suppressed, "answer %s", test.answer)
+		assert.NoError(t, result.Errors[0], "answer %s", test.answer)
+		singular, err := result.SingularResult()
+		require.NoError(t, err)
+		if test.suppressed {
+			assert.Equal(t, pipeline.ErrRunSuppressed, errors.Cause(singular.Error))
+		} else {
+			assert.NoError(t, singular.Error)
+		}
+	}
+
+	t.Run("keeps the previous answer if the run fails after it", func(t *testing.T) {
+		failing := pipeline.Spec{JobID: jb.ID, DotDagSource: fmt.Sprintf(`
+ds        [type=http method=GET url="%s"];
+ds_parse  [type=jsonparse path="result"];
+deviation [type=deltathreshold key="failing" threshold=1];
+fail      [type=jsonparse path="missing"];
+ds -> ds_parse -> deviation -> fail;
+`, server.URL)}
+
+		answer = "200"
+		for i := 0; i < 2; i++ {
+			trrs, err := r.ExecuteRun(context.Background(), failing, pipeline.JSONSerializable{}, *logger.Default)
+			require.NoError(t, err)
+			result := trrs.FinalResult()
+			assert.False(t, result.Suppressed, "the answer must be passed on again after a failed run")
+			assert.True(t, result.HasErrors())
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// EthCallTask makes an eth_call to a contract, and returns the data that the
+// call returned as a 0x prefixed hex string.
+//
+// The call is made against the latest block unless a block is given, so that
+// the result doesn't depend on when the run happens to execute. The block is
+// a block number, in decimal or 0x prefixed hex, or a block hash, in which
+// case the call fails if the block is no longer part of the canonical chain
+// (EIP-1898). It can also be taken from the run's meta by giving "meta."
+// followed by its path, e.g. meta.chain.blockNumber for the latest head when
+// the run was created, which reruns of the run see too.
+//
+//	price [type=ethcall contract="0x514910771AF9Ca656af840dff83E8264EcF986CA" data="0x50d25bcd" block="meta.chain.blockHash"];
+type EthCallTask struct {
+	BaseTask `mapstructure:",squash"`
+
+	Contract string `json:"contract"`
+	Data     string `json:"data"`
+	Block    string `json:"block"`
+
+	ethClient eth.Client
+}
+
+var _ Task = (*EthCallTask)(nil)
+
+// metaBlockPrefix is the prefix of a block given as a path in the run's meta
+const metaBlockPrefix = "meta."
+
+func (t *EthCallTask) Type() TaskType {
+	return TaskTypeEthCall
+}
+
+func (t *EthCallTask) SetDefaults(inputValues map[string]string, g TaskDAG, self taskDAGNode) error {
+	return nil
+}
+
+func (t *EthCallTask) Run(ctx context.Context, meta JSONSerializable, inputs []Result) Result {
+	if len(inputs) > 0 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "EthCallTask requires 0 inputs")}
+	}
+	if t.ethClient == nil {
+		return Result{Error: errors.New("EthCallTask requires the node to be connected to an eth node")}
+	}
+	if !common.IsHexAddress(t.Contract) {
+		return Result{Error: errors.Errorf("EthCallTask got invalid contract address %q", t.Contract)}
+	}
+	data, err := hexutil.Decode(t.Data)
+	if err != nil {
+		return Result{Error: errors.Wrapf(err, "EthCallTask got invalid data %q", t.Data)}
+	}
+	block, err := t.blockArg(meta)
+	if err != nil {
+		return Result{Error: err}
+	}
+
+	call := map[string]interface{}{
+		"to":   common.HexToAddress(t.Contract),
+		"data": hexutil.Bytes(data),
+	}
+	var result hexutil.Bytes
+	if err := t.ethClient.CallContext(ctx, &result, "eth_call", call, block); err != nil {
+		return Result{Error: errors.Wrapf(err, "eth_call to %s failed", t.Contract)}
+	}
+	return Result{Value: result.String()}
+}
+
+// blockArg returns the block parameter of the eth_call
+func (t *EthCallTask) blockArg(meta JSONSerializable) (interface{}, error) {
+	block := strings.TrimSpace(t.Block)
+	if strings.HasPrefix(block, metaBlockPrefix) {
+		path := strings.TrimPrefix(block, metaBlockPrefix)
+		value, err := metaBlock(meta, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "EthCallTask could not get the block from meta.%s", path)
+		}
+		block = value
+	}
+
+	switch {
+	case block == "" || block == "latest":
+		return "latest", nil
+	case strings.HasPrefix(block, "0x") && len(block) == 2+2*common.HashLength:
+		hash := common.HexToHash(block)
+		return map[string]interface{}{"blockHash": hash, "requireCanonical": true}, nil
+	case strings.HasPrefix(block, "0x"):
+		n, err := hexutil.DecodeBig(block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "EthCallTask got invalid block %q", block)
+		}
+		return hexutil.EncodeBig(n), nil
+	default:
+		n, ok := new(big.Int).SetString(block, 10)
+		if !ok || n.Sign() < 0 {
+			return nil, errors.Errorf("EthCallTask got invalid block %q, must be latest, a block number or a block hash", block)
+		}
+		return hexutil.EncodeBig(n), nil
+	}
+}
+
+// metaBlock returns the block number or hash at a dotted path in meta
+func metaBlock(meta JSONSerializable, path string) (string, error) {
+	value := meta.Val
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return "", errors.Errorf("meta has no %s", path)
+		}
+		if value, ok = m[key]; !ok {
+			return "", errors.Errorf("meta has no %s", path)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		if v != float64(int64(v)) {
+			return "", errors.Errorf("meta.%s is not a block number: %v", path, v)
+		}
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case int:
+		return strconv.Itoa(v), nil
+	default:
+		return "", errors.Errorf("meta.%s is not a block number or hash: %v", path, v)
+	}
+}
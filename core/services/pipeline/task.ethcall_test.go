@@ -0,0 +1,75 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestEthCallTask(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("0x514910771AF9Ca656af840dff83E8264EcF986CA")
+	blockHash := common.HexToHash("0x36bd9da1f2de8b3b56f2f7f8a1ec2b4e2bdc1f0b3bbdfdaf5ac5fd4bd7d8c1a2")
+	meta := pipeline.JSONSerializable{Val: map[string]interface{}{
+		"chain": map[string]interface{}{"blockNumber": float64(12345), "blockHash": blockHash.Hex()},
+	}}
+	call := map[string]interface{}{"to": contract, "data": hexutil.Bytes{0x50, 0xd2, 0x5b, 0xcd}}
+
+	tests := []struct {
+		name  string
+		block string
+		arg   interface{}
+	}{
+		{"latest by default", "", "latest"},
+		{"block number", "12345", "0x3039"},
+		{"hex block number", "0x3039", "0x3039"},
+		{"block hash", blockHash.Hex(), map[string]interface{}{"blockHash": blockHash, "requireCanonical": true}},
+		{"block number from meta", "meta.chain.blockNumber", "0x3039"},
+		{"block hash from meta", "meta.chain.blockHash", map[string]interface{}{"blockHash": blockHash, "requireCanonical": true}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ethClient := new(mocks.Client)
+			ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", call, test.arg).
+				Return(nil).
+				Run(func(args mock.Arguments) {
+					*args.Get(1).(*hexutil.Bytes) = hexutil.Bytes{0x01, 0x02}
+				}).
+				Once()
+
+			task := pipeline.EthCallTask{Contract: contract.Hex(), Data: "0x50d25bcd", Block: test.block}
+			task.HelperSetEthClient(ethClient)
+			result := task.Run(context.Background(), meta, nil)
+			require.NoError(t, result.Error)
+			assert.Equal(t, "0x0102", result.Value)
+
+			ethClient.AssertExpectations(t)
+		})
+	}
+
+	t.Run("errors on blocks that are not numbers or hashes", func(t *testing.T) {
+		for _, block := range []string{"yesterday", "-1", "meta.chain.timestamp", "meta.chain"} {
+			task := pipeline.EthCallTask{Contract: contract.Hex(), Data: "0x50d25bcd", Block: block}
+			task.HelperSetEthClient(new(mocks.Client))
+			result := task.Run(context.Background(), meta, nil)
+			assert.Error(t, result.Error, block)
+		}
+	})
+
+	t.Run("errors without an eth client", func(t *testing.T) {
+		task := pipeline.EthCallTask{Contract: contract.Hex(), Data: "0x50d25bcd"}
+		result := task.Run(context.Background(), meta, nil)
+		assert.Error(t, result.Error)
+	})
+}
@@ -0,0 +1,112 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eagrpc"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// GRPCBridgeTask calls an external adapter over the gRPC protocol of package
+// eagrpc, rather than with an HTTP request like BridgeTask. The bridge must
+// have a gRPC target. Unlike BridgeTask, the values of the task's inputs are
+// sent to the adapter.
+type GRPCBridgeTask struct {
+	BaseTask `mapstructure:",squash"`
+
+	Name        string          `json:"name"`
+	RequestData HttpRequestData `json:"requestData"`
+	// Stream calls the adapter's RunStream method, taking the last of the
+	// responses it streams as the answer
+	Stream bool `json:"stream"`
+
+	safeTx SafeTx
+}
+
+var _ Task = (*GRPCBridgeTask)(nil)
+
+func (t *GRPCBridgeTask) Type() TaskType {
+	return TaskTypeGRPCBridge
+}
+
+func (t *GRPCBridgeTask) SetDefaults(inputValues map[string]string, g TaskDAG, self taskDAGNode) error {
+	return nil
+}
+
+func (t *GRPCBridgeTask) Run(ctx context.Context, meta JSONSerializable, inputs []Result) Result {
+	inputValues := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		if input.Error != nil {
+			return Result{Error: input.Error}
+		}
+		inputValues[i] = input.Value
+	}
+
+	bridge, err := t.findBridge()
+	if err != nil {
+		return Result{Error: err}
+	}
+	if bridge.GRPCTarget == "" {
+		return Result{Error: errors.Errorf("bridge %s has no gRPC target", t.Name)}
+	}
+	release, err := bridgeLimiter.acquire(ctx, t.Name, bridge.MaxConcurrentCalls)
+	if err != nil {
+		return Result{Error: err}
+	}
+	defer release()
+
+	var metaMap map[string]interface{}
+	switch v := meta.Val.(type) {
+	case map[string]interface{}:
+		metaMap = v
+	case nil:
+	default:
+		logger.Warnw(`"meta" field on task run is malformed, discarding`,
+			"task", t.DotID(),
+			"meta", meta,
+		)
+	}
+
+	conn, done, err := eagrpc.Conn(t.Name, eagrpc.Target{
+		Address: bridge.GRPCTarget,
+		TLS:     bridge.GRPCTLS,
+		CACert:  bridge.GRPCTLSCACert,
+	})
+	if err != nil {
+		return Result{Error: err}
+	}
+	defer done()
+	req, err := eagrpc.NewRequest(t.DotID(), t.RequestData, metaMap, inputValues)
+	if err != nil {
+		return Result{Error: err}
+	}
+	var resp *eagrpc.Response
+	if t.Stream {
+		resp, err = eagrpc.RunStream(ctx, conn, bridge.OutgoingToken, req)
+	} else {
+		resp, err = eagrpc.Run(ctx, conn, bridge.OutgoingToken, req)
+	}
+	if err != nil {
+		return Result{Error: errors.Wrapf(err, "gRPC call to bridge %s failed", t.Name)}
+	} else if resp.Error != "" {
+		return Result{Error: errors.Errorf("bridge %s returned an error: %s", t.Name, resp.Error)}
+	}
+
+	answer := resp.GetResult().AsInterface()
+	logger.Debugw("gRPC bridge task: fetched answer",
+		"answer", answer,
+		"target", bridge.GRPCTarget,
+	)
+	return Result{Value: answer}
+}
+
+func (t GRPCBridgeTask) findBridge() (models.BridgeType, error) {
+	if t.safeTx.txMu != nil {
+		t.safeTx.txMu.Lock()
+		defer t.safeTx.txMu.Unlock()
+	}
+	return FindBridge(t.safeTx.tx, models.TaskType(t.Name))
+}
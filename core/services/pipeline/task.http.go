@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -58,8 +61,83 @@ type HTTPTask struct {
 	URL                            models.WebURL
 	RequestData                    HttpRequestData `json:"requestData"`
 	AllowUnrestrictedNetworkAccess MaybeBool
+	// ResponseSchema is an optional JSON schema that the response must match
+	ResponseSchema string
+	// AllowedStatusCodes is an optional list of the status codes and ranges of
+	// status codes that count as success, e.g. "200-299,404". Defaults to any
+	// status code below 400.
+	AllowedStatusCodes string
+	// ErrorResponsesAsOutput makes responses with other status codes the
+	// task's output instead of an error, so that later tasks can handle them
+	ErrorResponsesAsOutput bool
+	// CaptureHeaders is an optional list of response headers, e.g.
+	// "X-RateLimit-Remaining,Date", to capture alongside the response body.
+	// When it is set, the task's output is an object with the body under
+	// "body" and the captured headers under "headers".
+	CaptureHeaders []string
 
 	config Config
+	signer *RequestSigner
+	// requestURL, when set, is the URL requested instead of URL, which is
+	// what the task's logs and errors show. Bridge tasks set it to their
+	// bridge's URL with the values of its environment variables.
+	requestURL *url.URL
+	// sizeLimit, when set, is the most of the response that is read instead
+	// of responseSizeLimit. Bridge tasks set it to their own limit.
+	sizeLimit int64
+}
+
+// StatusCodes is a set of HTTP status codes, given as ranges
+type StatusCodes [][2]int
+
+// ParseStatusCodes parses a comma-separated list of status codes and
+// inclusive ranges of status codes, e.g. "200-299,404"
+func ParseStatusCodes(s string) (StatusCodes, error) {
+	var codes StatusCodes
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.SplitN(part, "-", 2)
+		from, err := parseStatusCode(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if len(bounds) == 2 {
+			to, err = parseStatusCode(bounds[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+		if to < from {
+			return nil, errors.Errorf("invalid status code range %s", part)
+		}
+		codes = append(codes, [2]int{from, to})
+	}
+	if len(codes) == 0 {
+		return nil, errors.Errorf("no status codes in %q", s)
+	}
+	return codes, nil
+}
+
+func parseStatusCode(s string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || code < 100 || code > 599 {
+		return 0, errors.Errorf("invalid status code %s", s)
+	}
+	return code, nil
+}
+
+// Contains reports whether code is one of the status codes
+func (c StatusCodes) Contains(code int) bool {
+	for _, r := range c {
+		if code >= r[0] && code <= r[1] {
+			return true
+		}
+	}
+	return false
 }
 
 type PossibleErrorResponses struct {
@@ -84,6 +162,26 @@ var (
 	)
 )
 
+func (t *HTTPTask) responseSizeLimit() int64 {
+	if t.sizeLimit > 0 {
+		return t.sizeLimit
+	}
+	return responseSizeLimit(t.config, t.OutputTask())
+}
+
+// responseSizeLimit returns the most of a response that a task with the given
+// output may read. Responses that go to a jsonparse task are cut off at its
+// limit while they are read, so that they are never held in memory in full.
+func responseSizeLimit(config Config, output Task) int64 {
+	limit := config.DefaultHTTPLimit()
+	if _, ok := output.(*JSONParseTask); ok {
+		if parseLimit := config.JobPipelineJSONParseLimit(); parseLimit > 0 && parseLimit < limit {
+			limit = parseLimit
+		}
+	}
+	return limit
+}
+
 func (t *HTTPTask) Type() TaskType {
 	return TaskTypeHTTP
 }
@@ -98,15 +196,21 @@ func (t *HTTPTask) Run(ctx context.Context, _ JSONSerializable, inputs []Result)
 	}
 
 	var bodyReader io.Reader
+	var bodyBytes []byte
 	if t.RequestData != nil {
-		bodyBytes, err := json.Marshal(t.RequestData)
+		var err error
+		bodyBytes, err = json.Marshal(t.RequestData)
 		if err != nil {
 			return Result{Error: errors.Wrap(err, "failed to encode request body as JSON")}
 		}
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	request, err := http.NewRequest(t.Method, t.URL.String(), bodyReader)
+	requestURL := t.URL.String()
+	if t.requestURL != nil {
+		requestURL = t.requestURL.String()
+	}
+	request, err := http.NewRequest(t.Method, requestURL, bodyReader)
 	if err != nil {
 		return Result{Error: errors.Wrap(err, "failed to create http.Request")}
 	}
@@ -115,28 +219,69 @@ func (t *HTTPTask) Run(ctx context.Context, _ JSONSerializable, inputs []Result)
 	config := utils.HTTPRequestConfig{
 		Timeout:                        t.config.DefaultHTTPTimeout().Duration(),
 		MaxAttempts:                    t.config.DefaultMaxHTTPAttempts(),
-		SizeLimit:                      t.config.DefaultHTTPLimit(),
+		SizeLimit:                      t.responseSizeLimit(),
 		AllowUnrestrictedNetworkAccess: t.allowUnrestrictedNetworkAccess(),
 	}
 
 	httpRequest := utils.HTTPRequest{
 		Request: request,
 		Config:  config,
+		// Every attempt is a call, so that retried requests are paid for
+		// each time they are sent
+		AttemptDone: func(responseBody []byte) {
+			recordHTTPCall(ctx, HTTPCall{
+				Host:          request.URL.Hostname(),
+				BytesSent:     int64(len(bodyBytes)),
+				BytesReceived: int64(len(responseBody)),
+			})
+		},
+	}
+	if t.signer != nil {
+		// Each attempt is signed with a fresh timestamp and nonce, so that
+		// retries aren't rejected by adapters as replays
+		httpRequest.PrepareAttempt = func(request *http.Request) error {
+			return t.signer.Sign(request, bodyBytes)
+		}
 	}
 
 	start := time.Now()
-	responseBytes, statusCode, err := httpRequest.SendRequest(ctx)
+	responseBytes, statusCode, headers, err := httpRequest.SendRequestWithHeaders(ctx)
+	var remoteErr *utils.RemoteServerError
+	if err != nil && ctx.Err() == nil && errors.As(err, &remoteErr) {
+		// The server still responded once the retries of its 5xx errors ran
+		// out, so its response is checked like any other error response
+		responseBytes, statusCode = remoteErr.ResponseBody(), remoteErr.StatusCode()
+		err = nil
+	}
 	if err != nil {
 		if ctx.Err() != nil {
 			return Result{Error: errors.New("http request timed out or interrupted")}
 		}
+		if urlErr, ok := err.(*url.Error); ok && t.requestURL != nil {
+			urlErr.URL = t.URL.String()
+		}
+		if _, ok := err.(*utils.HTTPResponseTooLargeError); ok {
+			return Result{Error: errors.Wrapf(ErrResponseTooLarge, "%v", err)}
+		}
 		return Result{Error: errors.Wrapf(err, "error making http request")}
 	}
 	elapsed := time.Since(start)
 	promHTTPFetchTime.WithLabelValues(t.DotID()).Set(float64(elapsed))
 	promHTTPResponseBodySize.WithLabelValues(t.DotID()).Set(float64(len(responseBytes)))
 
-	if statusCode >= 400 {
+	allowed, err := t.statusCodeAllowed(statusCode)
+	if err != nil {
+		return Result{Error: err}
+	} else if !allowed {
+		if t.ErrorResponsesAsOutput {
+			logger.Debugw("HTTP task got error response, returning it as output",
+				"statusCode", statusCode,
+				"response", string(responseBytes),
+				"url", t.URL.String(),
+				"dotID", t.DotID(),
+			)
+			return Result{Value: t.output(responseBytes, headers)}
+		}
 		maybeErr := bestEffortExtractError(responseBytes)
 		return Result{Error: errors.Errorf("got error from %s: (status code %v) %s", t.URL.String(), statusCode, maybeErr)}
 	}
@@ -146,11 +291,35 @@ func (t *HTTPTask) Run(ctx context.Context, _ JSONSerializable, inputs []Result)
 		"url", t.URL.String(),
 		"dotID", t.DotID(),
 	)
+	if err := validateResponse(t.ResponseSchema, responseBytes); err != nil {
+		return Result{Error: err}
+	}
+
 	// NOTE: We always stringify the response since this is required for all current jobs.
 	// If a binary response is required we might consider adding an adapter
 	// flag such as  "BinaryMode: true" which passes through raw binary as the
 	// value instead.
-	return Result{Value: string(responseBytes)}
+	return Result{Value: t.output(responseBytes, headers)}
+}
+
+// output returns the stringified response body, along with the headers named
+// in CaptureHeaders if there are any. Headers missing from the response are
+// left out.
+func (t *HTTPTask) output(responseBytes []byte, headers http.Header) interface{} {
+	if len(t.CaptureHeaders) == 0 {
+		return string(responseBytes)
+	}
+	captured := make(map[string]interface{})
+	for _, name := range t.CaptureHeaders {
+		name = strings.TrimSpace(name)
+		if values, exists := headers[http.CanonicalHeaderKey(name)]; exists && len(values) > 0 {
+			captured[name] = values[0]
+		}
+	}
+	return map[string]interface{}{
+		"body":    string(responseBytes),
+		"headers": captured,
+	}
 }
 
 func (t *HTTPTask) allowUnrestrictedNetworkAccess() bool {
@@ -161,6 +330,17 @@ func (t *HTTPTask) allowUnrestrictedNetworkAccess() bool {
 	return t.config.DefaultHTTPAllowUnrestrictedNetworkAccess()
 }
 
+func (t *HTTPTask) statusCodeAllowed(statusCode int) (bool, error) {
+	if t.AllowedStatusCodes == "" {
+		return statusCode < 400, nil
+	}
+	codes, err := ParseStatusCodes(t.AllowedStatusCodes)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid allowedStatusCodes")
+	}
+	return codes.Contains(statusCode), nil
+}
+
 func bestEffortExtractError(responseBytes []byte) string {
 	var resp PossibleErrorResponses
 	err := json.Unmarshal(responseBytes, &resp)
@@ -174,3 +354,24 @@ func bestEffortExtractError(responseBytes []byte) string {
 	}
 	return string(responseBytes)
 }
+
+// validateResponse checks a JSON response against a task's responseSchema, if
+// it has one. Adapters that change their payloads are caught here, rather than
+// by a jsonparse task picking out the wrong value.
+func validateResponse(schema string, responseBytes []byte) error {
+	if schema == "" {
+		return nil
+	}
+	s, err := ParseJSONSchema(schema)
+	if err != nil {
+		return errors.Wrap(err, "invalid responseSchema")
+	}
+	var response interface{}
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return errors.Wrap(ErrSchemaValidation, "response: is not valid JSON")
+	}
+	if fields := s.Validate("response", response); len(fields) > 0 {
+		return errors.Wrap(ErrSchemaValidation, joinSchemaFieldErrors(fields))
+	}
+	return nil
+}
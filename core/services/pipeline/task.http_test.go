@@ -3,12 +3,16 @@ package pipeline_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v4"
 
@@ -122,3 +126,202 @@ func TestHTTPTask_OnlyErrorMessage(t *testing.T) {
 	require.Contains(t, result.Error.Error(), "RequestId")
 	require.Nil(t, result.Value)
 }
+
+func TestHTTPTask_ResponseSchema(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data": {"result": "9700"}}`))
+		require.NoError(t, err)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	t.Run("matching response", func(t *testing.T) {
+		task := pipeline.HTTPTask{
+			Method:         "GET",
+			URL:            models.WebURL(*feedURL),
+			ResponseSchema: `{"type": "object", "required": ["data"]}`,
+		}
+		task.HelperSetConfig(config)
+
+		result := task.Run(context.Background(), pipeline.JSONSerializable{}, nil)
+		require.NoError(t, result.Error)
+		assert.Equal(t, `{"data": {"result": "9700"}}`, result.Value)
+	})
+
+	t.Run("mismatched response", func(t *testing.T) {
+		task := pipeline.HTTPTask{
+			Method:         "GET",
+			URL:            models.WebURL(*feedURL),
+			ResponseSchema: `{"properties": {"data": {"properties": {"result": {"type": "number"}}}}}`,
+		}
+		task.HelperSetConfig(config)
+
+		result := task.Run(context.Background(), pipeline.JSONSerializable{}, nil)
+		require.True(t, errors.Is(result.Error, pipeline.ErrSchemaValidation))
+		assert.Contains(t, result.Error.Error(), "response.data.result: expected number, got string")
+		assert.Nil(t, result.Value)
+	})
+}
+
+func TestHTTPTask_AllowedStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusNotFound
+		if code := r.URL.Query().Get("status"); code != "" {
+			status, _ = strconv.Atoi(code)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, err := w.Write([]byte(`{"error": "no such pair"}`))
+		require.NoError(t, err)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                   string
+		status                 int
+		allowedStatusCodes     string
+		errorResponsesAsOutput bool
+		expectedErr            string
+	}{
+		{"default", http.StatusNotFound, "", false, "no such pair"},
+		{"allowed", http.StatusNotFound, "200-299,404", false, ""},
+		{"not allowed", http.StatusNotFound, "200-299", false, "no such pair"},
+		{"error response as output", http.StatusNotFound, "200-299", true, ""},
+		{"invalid", http.StatusNotFound, "200-abc", false, "invalid allowedStatusCodes"},
+		{"5xx not allowed", http.StatusInternalServerError, "", false, "status code 500"},
+		{"5xx allowed", http.StatusServiceUnavailable, "200-299,503", false, ""},
+		{"5xx as output", http.StatusInternalServerError, "200-299", true, ""},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			requestURL := *feedURL
+			requestURL.RawQuery = fmt.Sprintf("status=%d", test.status)
+			task := pipeline.HTTPTask{
+				Method:                 "GET",
+				URL:                    models.WebURL(requestURL),
+				AllowedStatusCodes:     test.allowedStatusCodes,
+				ErrorResponsesAsOutput: test.errorResponsesAsOutput,
+			}
+			task.HelperSetConfig(config)
+
+			result := task.Run(context.Background(), pipeline.JSONSerializable{}, nil)
+			if test.expectedErr != "" {
+				require.Error(t, result.Error)
+				assert.Contains(t, result.Error.Error(), test.expectedErr)
+				assert.Nil(t, result.Value)
+			} else {
+				require.NoError(t, result.Error)
+				assert.Equal(t, `{"error": "no such pair"}`, result.Value)
+			}
+		})
+	}
+}
+
+func TestParseStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	codes, err := pipeline.ParseStatusCodes("200-299, 404")
+	require.NoError(t, err)
+	assert.True(t, codes.Contains(200))
+	assert.True(t, codes.Contains(299))
+	assert.True(t, codes.Contains(404))
+	assert.False(t, codes.Contains(300))
+	assert.False(t, codes.Contains(500))
+
+	for _, invalid := range []string{"", "abc", "299-200", "200-", "99", "600"} {
+		_, err := pipeline.ParseStatusCodes(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestHTTPTask_CaptureHeaders(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		_, err := w.Write([]byte(`{"data": {"result": "9700"}}`))
+		require.NoError(t, err)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	task := pipeline.HTTPTask{
+		Method:         "GET",
+		URL:            models.WebURL(*feedURL),
+		CaptureHeaders: []string{"x-ratelimit-remaining", "X-Missing"},
+	}
+	task.HelperSetConfig(config)
+
+	result := task.Run(context.Background(), pipeline.JSONSerializable{}, nil)
+	require.NoError(t, result.Error)
+	assert.Equal(t, map[string]interface{}{
+		"body":    `{"data": {"result": "9700"}}`,
+		"headers": map[string]interface{}{"x-ratelimit-remaining": "42"},
+	}, result.Value)
+}
+
+func TestHTTPTask_JSONParseLimit(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("JOB_PIPELINE_JSON_PARSE_LIMIT", 16)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data": 1, "padding": "xxxxxxxxxx"}`))
+		require.NoError(t, err)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	t.Run("cuts off responses that go to a jsonparse task", func(t *testing.T) {
+		task := pipeline.HTTPTask{Method: "GET", URL: models.WebURL(*feedURL)}
+		task.HelperSetConfig(config)
+		task.SetOutputTask(&pipeline.JSONParseTask{})
+
+		result := task.Run(context.Background(), pipeline.JSONSerializable{}, nil)
+		require.True(t, errors.Is(result.Error, pipeline.ErrResponseTooLarge), result.Error)
+		require.Nil(t, result.Value)
+	})
+
+	t.Run("reads other responses in full", func(t *testing.T) {
+		task := pipeline.HTTPTask{Method: "GET", URL: models.WebURL(*feedURL)}
+		task.HelperSetConfig(config)
+		task.SetOutputTask(&pipeline.MultiplyTask{})
+
+		result := task.Run(context.Background(), pipeline.JSONSerializable{}, nil)
+		require.NoError(t, result.Error)
+		require.Equal(t, `{"data": 1, "padding": "xxxxxxxxxx"}`, result.Value)
+	})
+}
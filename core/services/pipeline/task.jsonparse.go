@@ -1,25 +1,38 @@
 package pipeline
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
 	"encoding/json"
+	"io"
+	"math"
 	"math/big"
 	"strings"
 
 	"github.com/pkg/errors"
 )
 
+// ErrResponseTooLarge is returned when the input to a JSONParseTask exceeds
+// the configured size limit
+var ErrResponseTooLarge = errors.New("response too large")
+
 type JSONParseTask struct {
 	BaseTask `mapstructure:",squash"`
 	Path     JSONPath `json:"path"`
 	// Lax when disabled will return an error if the path does not exist
 	// Lax when enabled will return nil with no error if the path does not exist
 	Lax bool
+
+	config Config
 }
 
 var _ Task = (*JSONParseTask)(nil)
 
+// errJSONPathUnresolvable indicates that the path descends into a value that
+// is neither an object nor an array
+var errJSONPathUnresolvable = errors.New("path is unresolvable")
+
 func (t *JSONParseTask) Type() TaskType {
 	return TaskTypeJSONParse
 }
@@ -41,55 +54,157 @@ func (t *JSONParseTask) Run(_ context.Context, _ JSONSerializable, inputs []Resu
 		bs = v
 	case string:
 		bs = []byte(v)
+	case map[string]interface{}:
+		// e.g. the output of an http task that captures response headers
+		b, err := json.Marshal(v)
+		if err != nil {
+			return Result{Error: errors.Wrap(err, "JSONParseTask could not encode its input as JSON")}
+		}
+		bs = b
 	default:
 		return Result{Error: errors.Errorf("JSONParseTask does not accept inputs of type %T", inputs[0].Value)}
 	}
 
-	var decoded interface{}
-	err := json.Unmarshal(bs, &decoded)
-	if err != nil {
+	// HTTP and bridge tasks already stop reading responses at the limit, this
+	// catches inputs from any other task
+	if limit := t.sizeLimit(); limit > 0 && int64(len(bs)) > limit {
+		return Result{Error: errors.Wrapf(ErrResponseTooLarge, "JSONParseTask input is %d bytes, must be at most %d bytes", len(bs), limit)}
+	}
+
+	// The input is left out of errors, as it can be large and errors are
+	// stored with the run
+	decoded, exists, err := extractJSONPath(bytes.NewReader(bs), t.Path)
+	if errors.Is(err, errJSONPathUnresolvable) {
+		return Result{Error: errors.Errorf(`could not resolve path ["%v"] in %d byte input`, strings.Join(t.Path, `","`), len(bs))}
+	} else if err != nil {
 		return Result{Error: err}
+	} else if !exists && t.Lax {
+		return Result{Value: nil}
+	} else if !exists {
+		return Result{Error: errors.Errorf(`could not resolve path ["%v"] in %d byte input`, strings.Join(t.Path, `","`), len(bs))}
 	}
+	return Result{Value: decoded}
+}
 
-	for _, part := range t.Path {
-		switch d := decoded.(type) {
-		case map[string]interface{}:
-			var exists bool
-			decoded, exists = d[part]
-			if !exists && t.Lax {
-				return Result{Value: nil}
-			} else if !exists {
-				return Result{Error: errors.Errorf(`could not resolve path ["%v"] in %s`, strings.Join(t.Path, `","`), bs)}
+func (t *JSONParseTask) sizeLimit() int64 {
+	if t.config == nil {
+		return 0
+	}
+	return t.config.JobPipelineJSONParseLimit()
+}
+
+// extractJSONPath streams through the JSON document read from r and decodes
+// only the value found at path, skipping over everything else without
+// allocating it. exists is false if the path does not exist in the document.
+func extractJSONPath(r io.Reader, path JSONPath) (value interface{}, exists bool, err error) {
+	dec := json.NewDecoder(r)
+	for _, part := range path {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			exists, err = seekJSONObjectKey(dec, part)
+			if err != nil || !exists {
+				return nil, false, err
 			}
 
-		case []interface{}:
+		case json.Delim('['):
 			bigindex, ok := big.NewInt(0).SetString(part, 10)
 			if !ok {
-				return Result{Error: errors.Errorf("JSONParse task error: %v is not a valid array index", part)}
-			} else if !bigindex.IsInt64() {
-				if t.Lax {
-					return Result{Value: nil}
-				}
-				return Result{Error: errors.Errorf(`could not resolve path ["%v"] in %s`, strings.Join(t.Path, `","`), bs)}
+				return nil, false, errors.Errorf("JSONParse task error: %v is not a valid array index", part)
+			} else if !bigindex.IsInt64() || bigindex.Int64() == math.MinInt64 {
+				return nil, false, nil
 			}
-			index := int(bigindex.Int64())
-			if index < 0 {
-				index = len(d) + index
+			index := bigindex.Int64()
+			if index >= 0 {
+				exists, err = seekJSONArrayIndex(dec, index)
+				if err != nil || !exists {
+					return nil, false, err
+				}
+				continue
 			}
 
-			exists := index >= 0 && index < len(d)
-			if !exists && t.Lax {
-				return Result{Value: nil}
-			} else if !exists {
-				return Result{Error: errors.Errorf(`could not resolve path ["%v"] in %s`, strings.Join(t.Path, `","`), bs)}
+			// Negative indices count from the end of the array, so the
+			// trailing elements have to be buffered until it is reached
+			var window []json.RawMessage
+			for dec.More() {
+				var elem json.RawMessage
+				if err = dec.Decode(&elem); err != nil {
+					return nil, false, err
+				}
+				window = append(window, elem)
+				if int64(len(window)) > -index {
+					window = window[1:]
+				}
 			}
-			decoded = d[index]
+			if int64(len(window)) < -index {
+				return nil, false, nil
+			}
+			dec = json.NewDecoder(bytes.NewReader(window[0]))
 
 		default:
-			return Result{Error: errors.Errorf(`could not resolve path ["%v"] in %s`, strings.Join(t.Path, `","`), bs)}
+			return nil, false, errJSONPathUnresolvable
+		}
+	}
+
+	err = dec.Decode(&value)
+	return value, err == nil, err
+}
+
+// seekJSONObjectKey advances dec, which must be positioned inside an object,
+// to the value of key
+func seekJSONObjectKey(dec *json.Decoder, key string) (bool, error) {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+		if tok == key {
+			return true, nil
+		}
+		if err := skipJSONValue(dec); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// seekJSONArrayIndex advances dec, which must be positioned inside an array,
+// to the element at index
+func seekJSONArrayIndex(dec *json.Decoder, index int64) (bool, error) {
+	for i := int64(0); dec.More(); i++ {
+		if i == index {
+			return true, nil
+		}
+		if err := skipJSONValue(dec); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// skipJSONValue advances dec past the next value, including any nested
+// objects or arrays
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
 		}
 	}
-	return Result{Value: decoded}
 }
 
 type JSONPath []string
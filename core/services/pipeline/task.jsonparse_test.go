@@ -1,10 +1,14 @@
-package pipeline
+package pipeline_test
 
 import (
 	"context"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
 )
 
 func TestJSONParseTask(t *testing.T) {
@@ -147,6 +151,30 @@ func TestJSONParseTask(t *testing.T) {
 			"0.00058217",
 			false,
 		},
+		{
+			"skips over nested values before the path",
+			`{"foo": {"bar": [1, {"baz": [[], {}]}]}, "data": {"foo": "x", "value": [2, 3]}}`,
+			[]string{"data", "value", "-1"},
+			false,
+			float64(3),
+			false,
+		},
+		{
+			"path into a scalar with lax=true returns error",
+			`{"data": 1}`,
+			[]string{"data", "0"},
+			true,
+			nil,
+			true,
+		},
+		{
+			"malformed JSON returns error",
+			`{"data": tru`,
+			[]string{"data"},
+			false,
+			nil,
+			true,
+		},
 		{
 			"missing top-level key with lax=false returns error",
 			`{"foo": 1}`,
@@ -194,8 +222,8 @@ func TestJSONParseTask(t *testing.T) {
 	for _, tt := range tests {
 		test := tt
 		t.Run(test.name, func(t *testing.T) {
-			task := JSONParseTask{Path: test.path, Lax: test.lax}
-			result := task.Run(context.Background(), JSONSerializable{}, []Result{{Value: test.input}})
+			task := pipeline.JSONParseTask{Path: test.path, Lax: test.lax}
+			result := task.Run(context.Background(), pipeline.JSONSerializable{}, []pipeline.Result{{Value: test.input}})
 
 			if test.wantResultError {
 				require.Error(t, result.Error)
@@ -207,3 +235,50 @@ func TestJSONParseTask(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONParseTask_SizeLimit(t *testing.T) {
+	t.Parallel()
+
+	config := new(mocks.Config)
+	config.On("JobPipelineJSONParseLimit").Return(int64(16))
+	defer config.AssertExpectations(t)
+
+	task := pipeline.JSONParseTask{Path: []string{"data"}}
+	task.HelperSetConfig(config)
+
+	result := task.Run(context.Background(), pipeline.JSONSerializable{}, []pipeline.Result{{Value: `{"data": 1}`}})
+	require.NoError(t, result.Error)
+	require.Equal(t, float64(1), result.Value)
+
+	result = task.Run(context.Background(), pipeline.JSONSerializable{}, []pipeline.Result{{Value: `{"data": 1, "padding": "xxxxxxxxxx"}`}})
+	require.True(t, errors.Is(result.Error, pipeline.ErrResponseTooLarge))
+	require.Nil(t, result.Value)
+}
+
+func TestJSONParseTask_MapInput(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]interface{}{
+		"body":    `{"data": 1}`,
+		"headers": map[string]interface{}{"Date": "Mon, 01 Feb 2021 00:00:00 GMT"},
+	}
+
+	task := pipeline.JSONParseTask{Path: []string{"headers", "Date"}}
+	result := task.Run(context.Background(), pipeline.JSONSerializable{}, []pipeline.Result{{Value: input}})
+	require.NoError(t, result.Error)
+	require.Equal(t, "Mon, 01 Feb 2021 00:00:00 GMT", result.Value)
+
+	task = pipeline.JSONParseTask{Path: []string{"body"}}
+	result = task.Run(context.Background(), pipeline.JSONSerializable{}, []pipeline.Result{{Value: input}})
+	require.NoError(t, result.Error)
+	require.Equal(t, `{"data": 1}`, result.Value)
+}
+
+func TestJSONParseTask_ErrorsLeaveOutInput(t *testing.T) {
+	t.Parallel()
+
+	task := pipeline.JSONParseTask{Path: []string{"missing"}}
+	result := task.Run(context.Background(), pipeline.JSONSerializable{}, []pipeline.Result{{Value: `{"secret": "s3cr3t"}`}})
+	require.Error(t, result.Error)
+	require.NotContains(t, result.Error.Error(), "s3cr3t")
+}
@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// KVGetTask returns the value that a kvset task of the same job stored under
+// key in an earlier run. Keys that have never been set return the default,
+// if there is one, and an error otherwise.
+//
+//	previous [type=kvget key="lastAnswer" default="0"];
+type KVGetTask struct {
+	BaseTask `mapstructure:",squash"`
+
+	Key     string  `json:"key"`
+	Default *string `json:"default"`
+
+	safeTx SafeTx
+	jobID  int32
+}
+
+var _ Task = (*KVGetTask)(nil)
+
+func (t *KVGetTask) Type() TaskType {
+	return TaskTypeKVGet
+}
+
+func (t *KVGetTask) SetDefaults(inputValues map[string]string, g TaskDAG, self taskDAGNode) error {
+	return nil
+}
+
+func (t *KVGetTask) Run(_ context.Context, _ JSONSerializable, inputs []Result) Result {
+	if len(inputs) > 0 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "KVGetTask requires 0 inputs")}
+	}
+	if t.Key == "" {
+		return Result{Error: errors.New("KVGetTask requires a key")}
+	}
+	if t.jobID == 0 {
+		return Result{Error: ErrNoJobKVStore}
+	}
+
+	value, err := loadJobKV(t.safeTx, t.jobID, t.Key)
+	if errors.Is(err, ErrKeyNotFound) && t.Default != nil {
+		return Result{Value: *t.Default}
+	} else if err != nil {
+		return Result{Error: err}
+	}
+	return Result{Value: value.Val}
+}
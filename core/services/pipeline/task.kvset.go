@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// KVSetTask stores its input under key in its job's key/value store, where
+// kvget tasks of later runs can read it, and passes the input on unchanged.
+// Values are stored as JSON, so numbers that are not exactly representable
+// as a float should be stored as decimals or strings.
+//
+//	remember [type=kvset key="lastAnswer"];
+type KVSetTask struct {
+	BaseTask `mapstructure:",squash"`
+
+	Key string `json:"key"`
+
+	safeTx SafeTx
+	jobID  int32
+}
+
+var _ Task = (*KVSetTask)(nil)
+
+func (t *KVSetTask) Type() TaskType {
+	return TaskTypeKVSet
+}
+
+func (t *KVSetTask) SetDefaults(inputValues map[string]string, g TaskDAG, self taskDAGNode) error {
+	return nil
+}
+
+func (t *KVSetTask) Run(_ context.Context, _ JSONSerializable, inputs []Result) Result {
+	if len(inputs) != 1 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "KVSetTask requires a single input")}
+	} else if inputs[0].Error != nil {
+		return Result{Error: inputs[0].Error}
+	}
+	if t.Key == "" {
+		return Result{Error: errors.New("KVSetTask requires a key")}
+	}
+	if t.jobID == 0 {
+		return Result{Error: ErrNoJobKVStore}
+	}
+
+	if err := saveJobKV(t.safeTx, t.jobID, t.Key, JSONSerializable{Val: inputs[0].Value}); err != nil {
+		return Result{Error: err}
+	}
+	return Result{Value: inputs[0].Value}
+}
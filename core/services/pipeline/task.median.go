@@ -14,6 +14,8 @@ import (
 type MedianTask struct {
 	BaseTask      `mapstructure:",squash"`
 	AllowedFaults uint64 `json:"allowedFaults"`
+
+	numericPolicy NumericPolicy
 }
 
 var _ Task = (*MedianTask)(nil)
@@ -63,9 +65,13 @@ func (t *MedianTask) Run(_ context.Context, _ JSONSerializable, inputs []Result)
 		return answers[i].LessThan(answers[j])
 	})
 	k := len(answers) / 2
-	if len(answers)%2 == 1 {
-		return Result{Value: answers[k]}
+	median := answers[k]
+	if len(answers)%2 == 0 {
+		median = answers[k].Add(answers[k-1]).Div(decimal.NewFromInt(2))
+	}
+	median, err := t.numericPolicy.Apply(median)
+	if err != nil {
+		return Result{Error: err}
 	}
-	median := answers[k].Add(answers[k-1]).Div(decimal.NewFromInt(2))
 	return Result{Value: median}
 }
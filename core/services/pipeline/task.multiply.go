@@ -12,6 +12,8 @@ import (
 type MultiplyTask struct {
 	BaseTask `mapstructure:",squash"`
 	Times    decimal.Decimal `json:"times"`
+
+	numericPolicy NumericPolicy
 }
 
 var _ Task = (*MultiplyTask)(nil)
@@ -35,5 +37,9 @@ func (t *MultiplyTask) Run(_ context.Context, _ JSONSerializable, inputs []Resul
 	if err != nil {
 		return Result{Error: err}
 	}
-	return Result{Value: value.Mul(t.Times)}
+	product, err := t.numericPolicy.Apply(value.Mul(t.Times))
+	if err != nil {
+		return Result{Error: err}
+	}
+	return Result{Value: product}
 }
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// MaxScaleDecimals is the largest number of decimal places a value can be
+// scaled by
+const MaxScaleDecimals = 36
+
+// ScaleTask converts a decimal value to the integer used to represent it
+// on-chain with the given number of decimal places, e.g. 1.2345 with
+// decimals=2 becomes 123. Digits beyond that precision are rounded using the
+// job's numeric policy, which rounds half away from zero by default.
+type ScaleTask struct {
+	BaseTask `mapstructure:",squash"`
+	Decimals uint32 `json:"decimals"`
+
+	numericPolicy NumericPolicy
+}
+
+var _ Task = (*ScaleTask)(nil)
+
+func (t *ScaleTask) Type() TaskType {
+	return TaskTypeScale
+}
+
+func (t *ScaleTask) SetDefaults(inputValues map[string]string, g TaskDAG, self taskDAGNode) error {
+	if t.Decimals > MaxScaleDecimals {
+		return errors.Errorf("ScaleTask decimals must be at most %d, got %d", MaxScaleDecimals, t.Decimals)
+	}
+	return nil
+}
+
+func (t *ScaleTask) Run(_ context.Context, _ JSONSerializable, inputs []Result) (result Result) {
+	if len(inputs) != 1 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "ScaleTask requires a single input")}
+	} else if inputs[0].Error != nil {
+		return Result{Error: inputs[0].Error}
+	}
+
+	value, err := utils.ToDecimal(inputs[0].Value)
+	if err != nil {
+		return Result{Error: err}
+	}
+	scaled, err := t.numericPolicy.Apply(t.numericPolicy.Round(value.Shift(int32(t.Decimals)), 0))
+	if err != nil {
+		return Result{Error: err}
+	}
+	return Result{Value: scaled}
+}
@@ -0,0 +1,82 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestScaleTask_Happy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		decimals uint32
+		want     decimal.Decimal
+	}{
+		{"string, 8 decimals", "1.23", 8, *mustDecimal(t, "123000000")},
+		{"string, 0 decimals", "1.23", 0, *mustDecimal(t, "1")},
+		{"string, rounds half up", "1.235", 2, *mustDecimal(t, "124")},
+		{"string, rounds down", "1.234", 2, *mustDecimal(t, "123")},
+		{"string, negative", "-1.235", 2, *mustDecimal(t, "-124")},
+		{"string, 18 decimals", "1234.5678", 18, *mustDecimal(t, "1234567800000000000000")},
+		{"int, 8 decimals", int(2), 8, *mustDecimal(t, "200000000")},
+		{"uint64, 8 decimals", uint64(2), 8, *mustDecimal(t, "200000000")},
+		{"float64, 2 decimals", float64(1.23), 2, *mustDecimal(t, "123")},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			task := pipeline.ScaleTask{Decimals: test.decimals}
+			result := task.Run(context.Background(), pipeline.JSONSerializable{}, []pipeline.Result{{Value: test.input}})
+			require.NoError(t, result.Error)
+			require.Equal(t, test.want.String(), result.Value.(decimal.Decimal).String())
+		})
+	}
+}
+
+func TestScaleTask_Unhappy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		inputs []pipeline.Result
+	}{
+		{"map", []pipeline.Result{{Value: map[string]interface{}{"chain": "link"}}}},
+		{"no inputs", []pipeline.Result{}},
+		{"multiple inputs", []pipeline.Result{{Value: "1"}, {Value: "2"}}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := pipeline.ScaleTask{Decimals: 8}
+			result := task.Run(context.Background(), pipeline.JSONSerializable{}, test.inputs)
+			require.Error(t, result.Error)
+		})
+	}
+}
+
+func TestScaleTask_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	g := pipeline.NewTaskDAG()
+	err := g.UnmarshalText([]byte(`ds1 [type=scale decimals=8];`))
+	require.NoError(t, err)
+	tasks, err := g.TasksInDependencyOrder()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, uint32(8), tasks[0].(*pipeline.ScaleTask).Decimals)
+
+	g = pipeline.NewTaskDAG()
+	err = g.UnmarshalText([]byte(`ds1 [type=scale decimals=37];`))
+	require.NoError(t, err)
+	_, err = g.TasksInDependencyOrder()
+	require.Error(t, err)
+}
@@ -0,0 +1,167 @@
+package pipeline
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	// Registers the postgres driver used to connect to datasources
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// SQLTask runs a parameterized query against an external database that has
+// been registered as a datasource, and returns the single column of the first
+// row. Queries run in a read-only transaction, so they can't change the
+// datasource even if its credentials allow it.
+//
+// Parameters are given as a comma separated list, and are referred to in the
+// query as $1, $2 and so on:
+//
+//	price [type=sql datasource=prices query="SELECT price FROM quotes WHERE symbol = $1 ORDER BY at DESC" params="ETH"];
+type SQLTask struct {
+	BaseTask `mapstructure:",squash"`
+
+	Datasource string   `json:"datasource"`
+	Query      string   `json:"query"`
+	Params     []string `json:"params"`
+
+	safeTx SafeTx
+}
+
+var _ Task = (*SQLTask)(nil)
+
+var (
+	ErrNoSuchDatasource = errors.New("no such datasource exists")
+
+	// datasourcePools are the connection pools for each datasource, by name
+	datasourcePools   = make(map[string]*datasourcePool)
+	datasourcePoolsMu sync.Mutex
+)
+
+// datasourceMaxOpenConns limits the connections made to each datasource, so
+// that busy jobs can't overwhelm a database the node doesn't own
+const datasourceMaxOpenConns = 5
+
+type datasourcePool struct {
+	connectionString string
+	db               *sql.DB
+}
+
+func (t *SQLTask) Type() TaskType {
+	return TaskTypeSQL
+}
+
+func (t *SQLTask) SetDefaults(inputValues map[string]string, g TaskDAG, self taskDAGNode) error {
+	return nil
+}
+
+func (t *SQLTask) Run(ctx context.Context, meta JSONSerializable, inputs []Result) Result {
+	if len(inputs) > 0 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "SQLTask requires 0 inputs")}
+	}
+	if t.Query == "" {
+		return Result{Error: errors.New("SQLTask requires a query")}
+	}
+
+	ds, err := t.findDatasource()
+	if err != nil {
+		return Result{Error: err}
+	}
+	db, err := openDatasource(ds)
+	if err != nil {
+		return Result{Error: errors.Wrapf(err, "could not connect to datasource %s", ds.Name)}
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return Result{Error: errors.Wrapf(err, "could not begin transaction on datasource %s", ds.Name)}
+	}
+	// Nothing is ever committed, and the transaction is already rolled back
+	// if ctx was cancelled, so the error is of no interest
+	defer func() { _ = tx.Rollback() }()
+
+	params := make([]interface{}, len(t.Params))
+	for i, param := range t.Params {
+		params[i] = param
+	}
+	rows, err := tx.QueryContext(ctx, t.Query, params...)
+	if err != nil {
+		return Result{Error: errors.Wrapf(err, "query on datasource %s failed", ds.Name)}
+	}
+	defer logger.ErrorIfCalling(rows.Close)
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return Result{Error: err}
+	}
+	if len(columns) != 1 {
+		return Result{Error: errors.Errorf("SQLTask query must select exactly 1 column, got %d", len(columns))}
+	}
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return Result{Error: err}
+		}
+		return Result{Error: errors.New("SQLTask query returned no rows")}
+	}
+	var value interface{}
+	if err = rows.Scan(&value); err != nil {
+		return Result{Error: err}
+	}
+	// numeric and text columns are scanned as bytes
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+
+	logger.Debugw("SQL task: fetched answer",
+		"answer", value,
+		"datasource", ds.Name,
+	)
+	return Result{Value: value}
+}
+
+func (t SQLTask) findDatasource() (models.Datasource, error) {
+	if t.safeTx.txMu != nil {
+		t.safeTx.txMu.Lock()
+		defer t.safeTx.txMu.Unlock()
+	}
+	return FindDatasource(t.safeTx.tx, models.TaskType(t.Datasource))
+}
+
+// FindDatasource finds a datasource using the given database
+func FindDatasource(db *gorm.DB, name models.TaskType) (models.Datasource, error) {
+	var ds models.Datasource
+	err := db.First(&ds, "name = ?", name.String()).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ds, errors.Wrap(ErrNoSuchDatasource, name.String())
+	}
+	return ds, errors.Wrapf(err, "could not find datasource with name '%s'", name)
+}
+
+// openDatasource returns the connection pool for a datasource, replacing it
+// if the datasource has been re-registered with a new connection string
+func openDatasource(ds models.Datasource) (*sql.DB, error) {
+	datasourcePoolsMu.Lock()
+	defer datasourcePoolsMu.Unlock()
+
+	name := ds.Name.String()
+	if pool, exists := datasourcePools[name]; exists {
+		if pool.connectionString == ds.ConnectionString {
+			return pool.db, nil
+		}
+		logger.ErrorIfCalling(pool.db.Close)
+		delete(datasourcePools, name)
+	}
+
+	db, err := sql.Open("postgres", ds.ConnectionString)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(datasourceMaxOpenConns)
+	datasourcePools[name] = &datasourcePool{ds.ConnectionString, db}
+	return db, nil
+}
@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+const (
+	WeightedMethodMean   = "mean"
+	WeightedMethodMedian = "median"
+)
+
+// normalizedWeightTolerance is how far the weights of a Normalized
+// WeightedAggregateTask may sum from 1
+var normalizedWeightTolerance = decimal.New(1, -6)
+
+// WeightedAggregateTask aggregates value and weight pairs, e.g. the price and
+// 24h volume reported by each exchange, into their weighted mean (the
+// volume-weighted price) or weighted median.
+//
+// Each input must be either an object with "value" and "weight" keys, or an
+// array of [value, weight]. Weights must be positive, and are normalized by
+// their sum unless Normalized is set, in which case they must already sum to 1.
+type WeightedAggregateTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Method        string `json:"method"`
+	AllowedFaults uint64 `json:"allowedFaults"`
+	Normalized    bool   `json:"normalized"`
+
+	numericPolicy NumericPolicy
+}
+
+type weightedValue struct {
+	value  decimal.Decimal
+	weight decimal.Decimal
+}
+
+var _ Task = (*WeightedAggregateTask)(nil)
+
+func (t *WeightedAggregateTask) Type() TaskType {
+	return TaskTypeWeightedAggregate
+}
+
+func (t *WeightedAggregateTask) SetDefaults(inputValues map[string]string, g TaskDAG, self taskDAGNode) error {
+	switch t.Method {
+	case "":
+		t.Method = WeightedMethodMean
+	case WeightedMethodMean, WeightedMethodMedian:
+	default:
+		return errors.Errorf(`WeightedAggregateTask method must be "%s" or "%s", got "%s"`, WeightedMethodMean, WeightedMethodMedian, t.Method)
+	}
+	if _, exists := inputValues["allowedFaults"]; !exists {
+		if len(self.inputs()) == 0 {
+			return errors.Wrapf(ErrWrongInputCardinality, "WeightedAggregateTask requires at least 1 input")
+		}
+		t.AllowedFaults = uint64(len(self.inputs()) - 1)
+	}
+	return nil
+}
+
+func (t *WeightedAggregateTask) Run(_ context.Context, _ JSONSerializable, inputs []Result) (result Result) {
+	if len(inputs) == 0 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "WeightedAggregateTask requires at least 1 input")}
+	}
+
+	values := []weightedValue{}
+	fetchErrors := []error{}
+
+	for _, input := range inputs {
+		if input.Error != nil {
+			fetchErrors = append(fetchErrors, input.Error)
+			continue
+		}
+
+		wv, err := toWeightedValue(input.Value)
+		if err != nil {
+			fetchErrors = append(fetchErrors, err)
+			continue
+		}
+
+		values = append(values, wv)
+	}
+
+	if uint64(len(fetchErrors)) > t.AllowedFaults {
+		return Result{Error: errors.Wrapf(ErrBadInput, "Number of faulty inputs %v to weighted aggregate task > number allowed faults %v. Fetch errors: %v", len(fetchErrors), t.AllowedFaults, multierr.Combine(fetchErrors...).Error())}
+	} else if len(values) == 0 {
+		return Result{Error: errors.Wrapf(ErrBadInput, "WeightedAggregateTask has no valid inputs")}
+	}
+
+	total := decimal.Zero
+	for _, wv := range values {
+		total = total.Add(wv.weight)
+	}
+	if t.Normalized {
+		if total.Sub(decimal.NewFromInt(1)).Abs().GreaterThan(normalizedWeightTolerance) {
+			return Result{Error: errors.Wrapf(ErrBadInput, "WeightedAggregateTask weights must sum to 1, got %s", total)}
+		}
+		total = decimal.NewFromInt(1)
+	}
+
+	var aggregate decimal.Decimal
+	switch t.Method {
+	case WeightedMethodMedian:
+		aggregate = weightedMedian(values, total)
+	default:
+		aggregate = weightedMean(values, total)
+	}
+	aggregate, err := t.numericPolicy.Apply(aggregate)
+	if err != nil {
+		return Result{Error: err}
+	}
+	return Result{Value: aggregate}
+}
+
+func toWeightedValue(input interface{}) (weightedValue, error) {
+	var rawValue, rawWeight interface{}
+	switch v := input.(type) {
+	case map[string]interface{}:
+		rawValue, rawWeight = v["value"], v["weight"]
+	case []interface{}:
+		if len(v) != 2 {
+			return weightedValue{}, errors.Errorf("WeightedAggregateTask input must be a [value, weight] pair, got %d elements", len(v))
+		}
+		rawValue, rawWeight = v[0], v[1]
+	default:
+		return weightedValue{}, errors.Errorf("WeightedAggregateTask does not accept inputs of type %T", input)
+	}
+
+	value, err := utils.ToDecimal(rawValue)
+	if err != nil {
+		return weightedValue{}, errors.Wrap(err, "invalid value")
+	}
+	weight, err := utils.ToDecimal(rawWeight)
+	if err != nil {
+		return weightedValue{}, errors.Wrap(err, "invalid weight")
+	}
+	if !weight.IsPositive() {
+		return weightedValue{}, errors.Errorf("weight must be positive, got %s", weight)
+	}
+	return weightedValue{value, weight}, nil
+}
+
+func weightedMean(values []weightedValue, total decimal.Decimal) decimal.Decimal {
+	sum := decimal.Zero
+	for _, wv := range values {
+		sum = sum.Add(wv.value.Mul(wv.weight))
+	}
+	return sum.Div(total)
+}
+
+// weightedMedian returns the value at which the cumulative weight of the
+// sorted values reaches half of the total. If it lands exactly on the boundary
+// between two values, their mean is returned, as with an unweighted median.
+func weightedMedian(values []weightedValue, total decimal.Decimal) decimal.Decimal {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].value.LessThan(values[j].value)
+	})
+	half := total.Div(decimal.NewFromInt(2))
+	cumulative := decimal.Zero
+	for i, wv := range values {
+		cumulative = cumulative.Add(wv.weight)
+		if cumulative.Equal(half) && i+1 < len(values) {
+			return wv.value.Add(values[i+1].value).Div(decimal.NewFromInt(2))
+		} else if cumulative.GreaterThan(half) || cumulative.Equal(half) {
+			return wv.value
+		}
+	}
+	return values[len(values)-1].value
+}
@@ -0,0 +1,149 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestWeightedAggregateTask(t *testing.T) {
+	t.Parallel()
+
+	pair := func(value, weight interface{}) pipeline.Result {
+		return pipeline.Result{Value: map[string]interface{}{"value": value, "weight": weight}}
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		normalized    bool
+		inputs        []pipeline.Result
+		allowedFaults uint64
+		want          pipeline.Result
+	}{
+		{
+			"volume-weighted mean",
+			pipeline.WeightedMethodMean,
+			false,
+			[]pipeline.Result{pair("100", "1"), pair("110", "3")},
+			1,
+			pipeline.Result{Value: mustDecimal(t, "107.5")},
+		},
+		{
+			"array pairs",
+			pipeline.WeightedMethodMean,
+			false,
+			[]pipeline.Result{{Value: []interface{}{float64(100), float64(1)}}, {Value: []interface{}{"110", "3"}}},
+			1,
+			pipeline.Result{Value: mustDecimal(t, "107.5")},
+		},
+		{
+			"weighted median",
+			pipeline.WeightedMethodMedian,
+			false,
+			[]pipeline.Result{pair("100", "1"), pair("300", "5"), pair("200", "2")},
+			2,
+			pipeline.Result{Value: mustDecimal(t, "300")},
+		},
+		{
+			"weighted median on the boundary",
+			pipeline.WeightedMethodMedian,
+			false,
+			[]pipeline.Result{pair("100", "1"), pair("200", "1")},
+			1,
+			pipeline.Result{Value: mustDecimal(t, "150")},
+		},
+		{
+			"normalized weights",
+			pipeline.WeightedMethodMean,
+			true,
+			[]pipeline.Result{pair("100", "0.25"), pair("110", "0.75")},
+			1,
+			pipeline.Result{Value: mustDecimal(t, "107.5")},
+		},
+		{
+			"weights that are not normalized",
+			pipeline.WeightedMethodMean,
+			true,
+			[]pipeline.Result{pair("100", "1"), pair("110", "3")},
+			1,
+			pipeline.Result{Error: pipeline.ErrBadInput},
+		},
+		{
+			"non-positive weight counts as a fault",
+			pipeline.WeightedMethodMean,
+			false,
+			[]pipeline.Result{pair("100", "0"), pair("110", "3")},
+			1,
+			pipeline.Result{Value: mustDecimal(t, "110")},
+		},
+		{
+			"more faults than allowed",
+			pipeline.WeightedMethodMean,
+			false,
+			[]pipeline.Result{pair("100", "-1"), {Error: errors.New("")}, pair("110", "3")},
+			1,
+			pipeline.Result{Error: pipeline.ErrBadInput},
+		},
+		{
+			"zero inputs",
+			pipeline.WeightedMethodMean,
+			false,
+			[]pipeline.Result{},
+			0,
+			pipeline.Result{Error: pipeline.ErrWrongInputCardinality},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			task := pipeline.WeightedAggregateTask{Method: test.method, Normalized: test.normalized, AllowedFaults: test.allowedFaults}
+			output := task.Run(context.Background(), pipeline.JSONSerializable{}, test.inputs)
+			if test.want.Error != nil {
+				require.Equal(t, test.want.Error, errors.Cause(output.Error))
+				require.Nil(t, output.Value)
+			} else {
+				require.NoError(t, output.Error)
+				require.Equal(t, test.want.Value.(*decimal.Decimal).String(), output.Value.(decimal.Decimal).String())
+			}
+		})
+	}
+}
+
+func TestWeightedAggregateTask_Defaults(t *testing.T) {
+	t.Parallel()
+
+	var taskDAG pipeline.TaskDAG
+	err := taskDAG.UnmarshalText([]byte(`
+	ds1 [type=http method=GET url="https://example.com/1"];
+	ds2 [type=http method=GET url="https://example.com/2"];
+	ds1 -> answer;
+	ds2 -> answer;
+	answer [type=weightedaggregate];
+`))
+	require.NoError(t, err)
+	tasks, err := taskDAG.TasksInDependencyOrder()
+	require.NoError(t, err)
+	for _, task := range tasks {
+		if task.Type() == pipeline.TaskTypeWeightedAggregate {
+			assert.Equal(t, pipeline.WeightedMethodMean, task.(*pipeline.WeightedAggregateTask).Method)
+			assert.Equal(t, uint64(1), task.(*pipeline.WeightedAggregateTask).AllowedFaults)
+		}
+	}
+
+	err = taskDAG.UnmarshalText([]byte(`
+	ds1 [type=http method=GET url="https://example.com/1"];
+	ds1 -> answer;
+	answer [type=weightedaggregate method=mode];
+`))
+	require.NoError(t, err)
+	_, err = taskDAG.TasksInDependencyOrder()
+	assert.Error(t, err)
+}
@@ -4,6 +4,8 @@ import (
 	"reflect"
 
 	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 )
 
 const (
@@ -35,10 +37,26 @@ func (t *BridgeTask) HelperSetConfigAndTxDB(config Config, txdb *gorm.DB) {
 	t.safeTx = SafeTx{tx: txdb}
 }
 
+func (t *BridgeTask) HelperSetSigner(signer *RequestSigner) {
+	t.signer = signer
+}
+
 func (t *HTTPTask) HelperSetConfig(config Config) {
 	t.config = config
 }
 
+func (t *JSONParseTask) HelperSetConfig(config Config) {
+	t.config = config
+}
+
+func (t *EthCallTask) HelperSetEthClient(ethClient eth.Client) {
+	t.ethClient = ethClient
+}
+
+func (t *MultiplyTask) HelperSetNumericPolicy(policy NumericPolicy) {
+	t.numericPolicy = policy
+}
+
 func (t MultiplyTask) ExportedEquals(otherTask Task) bool {
 	other, ok := otherTask.(*MultiplyTask)
 	if !ok {
@@ -22,11 +22,19 @@ const (
 	AdvisoryLockClassID_EthBroadcaster int32 = 0
 	AdvisoryLockClassID_JobSpawner     int32 = 1
 	AdvisoryLockClassID_EthConfirmer   int32 = 2
+	// The OCR locks are taken per oracle spec, by upserts into the
+	// partitioned OCR tables
+	AdvisoryLockClassID_OCRPersistentStates     int32 = 3
+	AdvisoryLockClassID_OCRPendingTransmissions int32 = 4
+	// Transmitter rotations are locked per rotation while they are advanced
+	AdvisoryLockClassID_TransmitterRotation int32 = 5
+	AdvisoryLockClassID_RunExport           int32 = 6
 
 	// ORM takes lock on 1027321974924625846 which splits into ClassID 239192036, ObjID 2840971190
 	AdvisoryLockClassID_ORM int32 = 239192036
 
 	AdvisoryLockObjectID_EthConfirmer int32 = 0
+	AdvisoryLockObjectID_RunExport    int32 = 0
 )
 
 //go:generate mockery --name AdvisoryLocker --output ../../internal/mocks/ --case=underscore
@@ -5,6 +5,9 @@ const (
 	ChannelJobDeleted   = "delete_from_jobs"
 	ChannelRunStarted   = "pipeline_run_started"
 	ChannelRunCompleted = "pipeline_run_completed"
+	// ChannelRunsStarted is notified once for a batch of runs, with the
+	// number of runs as the payload
+	ChannelRunsStarted = "pipeline_runs_started"
 
 	// Postgres channel to listen for new eth_txes
 	ChannelInsertOnEthTx = "insert_on_eth_txes"
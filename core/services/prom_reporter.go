@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,6 +27,8 @@ type (
 		SetMaxUnconfirmedBlocks(int64)
 		SetPipelineRunsQueued(n int)
 		SetPipelineTaskRunsQueued(n int)
+		SetPendingBridgeRuns(int64)
+		SetMaxPendingBridgeRunAge(time.Duration)
 	}
 
 	defaultBackend struct{}
@@ -48,6 +51,14 @@ var (
 		Name: "pipeline_task_runs_queued",
 		Help: "The total number of pipeline task runs that are awaiting execution",
 	})
+	promPendingBridgeRuns = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pending_bridge_runs",
+		Help: "Number of runs currently suspended waiting for a bridge callback",
+	})
+	promMaxPendingBridgeRunAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "max_pending_bridge_run_age_seconds",
+		Help: "How long the oldest run suspended waiting for a bridge callback has been waiting",
+	})
 )
 
 func (defaultBackend) SetUnconfirmedTransactions(n int64) {
@@ -66,6 +77,14 @@ func (defaultBackend) SetPipelineTaskRunsQueued(n int) {
 	promPipelineRunsQueued.Set(float64(n))
 }
 
+func (defaultBackend) SetPendingBridgeRuns(n int64) {
+	promPendingBridgeRuns.Set(float64(n))
+}
+
+func (defaultBackend) SetMaxPendingBridgeRunAge(age time.Duration) {
+	promMaxPendingBridgeRunAge.Set(age.Seconds())
+}
+
 func NewPromReporter(db *sql.DB, opts ...PrometheusBackend) store.HeadTrackable {
 	var backend PrometheusBackend
 	if len(opts) > 0 {
@@ -91,6 +110,7 @@ func (pr *promReporter) OnNewLongestChain(ctx context.Context, head models.Head)
 		errors.Wrap(pr.reportPendingEthTxes(ctx), "reportPendingEthTxes failed"),
 		errors.Wrap(pr.reportMaxUnconfirmedBlocks(ctx, head), "reportMaxUnconfirmedBlocks failed"),
 		errors.Wrap(pr.reportPipelineRunStats(ctx), "reportPipelineRunStats failed"),
+		errors.Wrap(pr.reportPendingBridgeRuns(ctx), "reportPendingBridgeRuns failed"),
 	)
 
 	if err != nil {
@@ -172,3 +192,30 @@ SELECT pipeline_run_id FROM pipeline_task_runs WHERE finished_at IS NULL
 
 	return nil
 }
+
+func (pr *promReporter) reportPendingBridgeRuns(ctx context.Context) (err error) {
+	rows, err := pr.db.QueryContext(ctx, `
+SELECT count(*), MIN(updated_at) FROM job_runs WHERE status = 'pending_bridge'
+`)
+	if err != nil {
+		return errors.Wrap(err, "failed to query for pending bridge runs")
+	}
+	defer func() {
+		err = multierr.Combine(err, rows.Close())
+	}()
+
+	var pending int64
+	var oldestUpdatedAt null.Time
+	for rows.Next() {
+		if err := rows.Scan(&pending, &oldestUpdatedAt); err != nil {
+			return errors.Wrap(err, "unexpected error scanning row")
+		}
+	}
+	var maxAge time.Duration
+	if oldestUpdatedAt.Valid {
+		maxAge = time.Since(oldestUpdatedAt.Time)
+	}
+	pr.backend.SetPendingBridgeRuns(pending)
+	pr.backend.SetMaxPendingBridgeRunAge(maxAge)
+	return nil
+}
@@ -3,11 +3,13 @@ package services_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,6 +26,8 @@ func Test_PromReporter_OnNewLongestChain(t *testing.T) {
 		backend.On("SetMaxUnconfirmedBlocks", int64(0)).Return()
 		backend.On("SetPipelineTaskRunsQueued", 0).Return()
 		backend.On("SetPipelineRunsQueued", 0).Return()
+		backend.On("SetPendingBridgeRuns", int64(0)).Return()
+		backend.On("SetMaxPendingBridgeRunAge", time.Duration(0)).Return()
 
 		head := models.Head{Number: 42}
 		reporter.OnNewLongestChain(context.Background(), head)
@@ -49,6 +53,8 @@ func Test_PromReporter_OnNewLongestChain(t *testing.T) {
 		backend.On("SetMaxUnconfirmedBlocks", int64(35)).Return()
 		backend.On("SetPipelineTaskRunsQueued", 0).Return()
 		backend.On("SetPipelineRunsQueued", 0).Return()
+		backend.On("SetPendingBridgeRuns", int64(0)).Return()
+		backend.On("SetMaxPendingBridgeRunAge", time.Duration(0)).Return()
 
 		head := models.Head{Number: 42}
 		reporter.OnNewLongestChain(context.Background(), head)
@@ -74,6 +80,38 @@ func Test_PromReporter_OnNewLongestChain(t *testing.T) {
 		backend.On("SetMaxUnconfirmedBlocks", int64(0)).Return()
 		backend.On("SetPipelineTaskRunsQueued", 3).Return()
 		backend.On("SetPipelineRunsQueued", 2).Return()
+		backend.On("SetPendingBridgeRuns", int64(0)).Return()
+		backend.On("SetMaxPendingBridgeRunAge", time.Duration(0)).Return()
+
+		head := models.Head{Number: 42}
+		reporter.OnNewLongestChain(context.Background(), head)
+
+		backend.AssertExpectations(t)
+	})
+	t.Run("with runs pending bridge callbacks", func(t *testing.T) {
+		store, cleanup := cltest.NewStore(t)
+		defer cleanup()
+
+		backend := new(mocks.PrometheusBackend)
+		d, _ := store.DB.DB()
+		reporter := services.NewPromReporter(d, backend)
+
+		job := cltest.NewJobWithWebInitiator()
+		require.NoError(t, store.CreateJob(&job))
+		for i := 0; i < 2; i++ {
+			run := cltest.NewJobRunPendingBridge(job)
+			require.NoError(t, store.CreateJobRun(&run))
+		}
+		require.NoError(t, store.DB.Exec(`UPDATE job_runs SET updated_at = ?`, time.Now().Add(-time.Hour)).Error)
+
+		backend.On("SetUnconfirmedTransactions", int64(0)).Return()
+		backend.On("SetMaxUnconfirmedBlocks", int64(0)).Return()
+		backend.On("SetPipelineTaskRunsQueued", 0).Return()
+		backend.On("SetPipelineRunsQueued", 0).Return()
+		backend.On("SetPendingBridgeRuns", int64(2)).Return()
+		backend.On("SetMaxPendingBridgeRunAge", mock.MatchedBy(func(age time.Duration) bool {
+			return age >= time.Hour
+		})).Return()
 
 		head := models.Head{Number: 42}
 		reporter.OnNewLongestChain(context.Background(), head)
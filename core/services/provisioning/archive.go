@@ -0,0 +1,356 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/static"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/migrations"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// ArchiveFormatVersion is the version of the archive format. It is increased
+// whenever archives change in a way that older nodes can't import.
+const ArchiveFormatVersion = 1
+
+type (
+	// Archive is a snapshot of the node's state from which a fresh node can
+	// be rebuilt: its jobs, bridges, external initiators and config
+	// overrides. Keys are not included and must be exported separately, but
+	// bridge and external initiator credentials are, so that adapters and
+	// initiators keep working with the rebuilt node.
+	Archive struct {
+		FormatVersion int `json:"formatVersion"`
+		// SchemaVersion is the most recent database migration applied to the
+		// node the archive was exported from
+		SchemaVersion      string                      `json:"schemaVersion"`
+		NodeVersion        string                      `json:"nodeVersion"`
+		CreatedAt          time.Time                   `json:"createdAt"`
+		ConfigOverrides    map[string]string           `json:"configOverrides"`
+		Bridges            []ArchivedBridge            `json:"bridges"`
+		ExternalInitiators []ArchivedExternalInitiator `json:"externalInitiators"`
+		JobSpecs           []models.JobSpec            `json:"jobSpecs"`
+		Jobs               []ArchivedJob               `json:"jobs"`
+	}
+
+	// ArchivedBridge is a bridge, including the hash of its incoming token
+	ArchivedBridge struct {
+		Name                   models.TaskType `json:"name"`
+		URL                    models.WebURL   `json:"url"`
+		Confirmations          uint32          `json:"confirmations"`
+		IncomingTokenHash      string          `json:"incomingTokenHash"`
+		Salt                   string          `json:"salt"`
+		OutgoingToken          string          `json:"outgoingToken"`
+		MinimumContractPayment *assets.Link    `json:"minimumContractPayment"`
+		GRPCTarget             string          `json:"grpcTarget,omitempty"`
+		GRPCTLS                bool            `json:"grpcTLS,omitempty"`
+		GRPCTLSCACert          string          `json:"grpcTLSCACert,omitempty"`
+		MaxConcurrentCalls     uint32          `json:"maxConcurrentCalls,omitempty"`
+	}
+
+	// ArchivedExternalInitiator is an external initiator registration,
+	// including the hash of its secret
+	ArchivedExternalInitiator struct {
+		Name           string         `json:"name"`
+		URL            *models.WebURL `json:"url,omitempty"`
+		AccessKey      string         `json:"accessKey"`
+		Salt           string         `json:"salt"`
+		HashedSecret   string         `json:"hashedSecret"`
+		OutgoingSecret string         `json:"outgoingSecret"`
+		OutgoingToken  string         `json:"outgoingToken"`
+	}
+
+	// ArchivedJob is a v2 job, as the TOML spec it can be recreated from
+	ArchivedJob struct {
+		Name         string `json:"name,omitempty"`
+		TOML         string `json:"toml"`
+		SpecChecksum string `json:"specChecksum,omitempty"`
+		// OnChainJobSpecID is kept for direct request jobs, as it's derived
+		// from the original TOML, which the archived TOML may differ from
+		OnChainJobSpecID *common.Hash `json:"onChainJobSpecID,omitempty"`
+	}
+
+	// JobAdder creates and starts jobs
+	JobAdder interface {
+		AddJob(job models.JobSpec) error
+		AddJobV2(ctx context.Context, job job.Job, name null.String) (int32, error)
+	}
+)
+
+// ExportArchive returns an archive of the node's state
+func ExportArchive(store *store.Store, jobORM job.ORM) (Archive, error) {
+	schemaVersion, err := migrations.Current(store.DB)
+	if err != nil {
+		return Archive{}, errors.Wrap(err, "failed to load schema version")
+	}
+	archive := Archive{
+		FormatVersion:      ArchiveFormatVersion,
+		SchemaVersion:      schemaVersion,
+		NodeVersion:        static.Version,
+		CreatedAt:          time.Now(),
+		ConfigOverrides:    make(map[string]string),
+		Bridges:            []ArchivedBridge{},
+		ExternalInitiators: []ArchivedExternalInitiator{},
+		JobSpecs:           []models.JobSpec{},
+		Jobs:               []ArchivedJob{},
+	}
+
+	var configs []models.Configuration
+	if err = store.DB.Find(&configs).Error; err != nil {
+		return Archive{}, errors.Wrap(err, "failed to load config overrides")
+	}
+	for _, config := range configs {
+		archive.ConfigOverrides[config.Name] = config.Value
+	}
+
+	var bridges []models.BridgeType
+	if err = store.DB.Order("name asc").Find(&bridges).Error; err != nil {
+		return Archive{}, errors.Wrap(err, "failed to load bridges")
+	}
+	for _, bt := range bridges {
+		archive.Bridges = append(archive.Bridges, ArchivedBridge{
+			Name:                   bt.Name,
+			URL:                    bt.URL,
+			Confirmations:          bt.Confirmations,
+			IncomingTokenHash:      bt.IncomingTokenHash,
+			Salt:                   bt.Salt,
+			OutgoingToken:          bt.OutgoingToken,
+			MinimumContractPayment: bt.MinimumContractPayment,
+			GRPCTarget:             bt.GRPCTarget,
+			GRPCTLS:                bt.GRPCTLS,
+			GRPCTLSCACert:          bt.GRPCTLSCACert,
+			MaxConcurrentCalls:     bt.MaxConcurrentCalls,
+		})
+	}
+
+	var eis []models.ExternalInitiator
+	if err = store.DB.Order("name asc").Find(&eis).Error; err != nil {
+		return Archive{}, errors.Wrap(err, "failed to load external initiators")
+	}
+	for _, ei := range eis {
+		archive.ExternalInitiators = append(archive.ExternalInitiators, ArchivedExternalInitiator{
+			Name:           ei.Name,
+			URL:            ei.URL,
+			AccessKey:      ei.AccessKey,
+			Salt:           ei.Salt,
+			HashedSecret:   ei.HashedSecret,
+			OutgoingSecret: ei.OutgoingSecret,
+			OutgoingToken:  ei.OutgoingToken,
+		})
+	}
+
+	err = store.Jobs(func(js *models.JobSpec) bool {
+		archive.JobSpecs = append(archive.JobSpecs, *js)
+		return true
+	})
+	if err != nil {
+		return Archive{}, errors.Wrap(err, "failed to load v1 jobs")
+	}
+
+	jobs, err := jobORM.JobsV2()
+	if err != nil {
+		return Archive{}, errors.Wrap(err, "failed to load v2 jobs")
+	}
+	for _, jb := range jobs {
+		aj, err := archiveJob(jb)
+		if err != nil {
+			return Archive{}, errors.Wrapf(err, "failed to archive job %v", jb.ID)
+		}
+		archive.Jobs = append(archive.Jobs, aj)
+	}
+	return archive, nil
+}
+
+func archiveJob(jb job.Job) (ArchivedJob, error) {
+	specTOML, err := job.JobToSpecTOMLWithSecrets(jb)
+	if err != nil {
+		return ArchivedJob{}, err
+	}
+	aj := ArchivedJob{
+		Name:         jb.Name.ValueOrZero(),
+		TOML:         specTOML,
+		SpecChecksum: jb.SpecChecksum.ValueOrZero(),
+	}
+	if jb.DirectRequestSpec != nil {
+		id := jb.DirectRequestSpec.OnChainJobSpecID
+		aj.OnChainJobSpecID = &id
+	}
+	return aj, nil
+}
+
+// CheckArchiveCompatibility returns an error if the archive can't be
+// imported into a node whose most recent migration is schemaVersion. An
+// archive can be imported into a node running the same or a later schema.
+func CheckArchiveCompatibility(archive Archive, schemaVersion string) error {
+	if archive.FormatVersion != ArchiveFormatVersion {
+		return errors.Errorf("archive format version %d is not supported, this node supports version %d", archive.FormatVersion, ArchiveFormatVersion)
+	}
+	archived, current := migrationIndex(archive.SchemaVersion), migrationIndex(schemaVersion)
+	if archived < 0 {
+		return errors.Errorf("archive was exported at schema version %s, which this node doesn't know of; upgrade the node before importing it", archive.SchemaVersion)
+	}
+	if archived > current {
+		return errors.Errorf("archive was exported at schema version %s, which is newer than this node's database (%s); migrate the database before importing it", archive.SchemaVersion, schemaVersion)
+	}
+	return nil
+}
+
+func migrationIndex(id string) int {
+	for i, m := range migrations.Migrations {
+		if m.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ImportArchive recreates the state in the archive on the node. Config
+// overrides are always applied, while bridges, external initiators and jobs
+// that already exist on the node are skipped. Bridges and external
+// initiators are imported before jobs, so that the jobs that use them
+// validate. v1 jobs keep their IDs, so external initiators need not be
+// notified of them again. v2 job specs are validated with validators.
+func ImportArchive(ctx context.Context, store *store.Store, jobORM job.ORM, validators *job.ValidatorRegistry, adder JobAdder, archive Archive) (Result, error) {
+	result := Result{Errors: make(map[string]error)}
+	schemaVersion, err := migrations.Current(store.DB)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to load schema version")
+	}
+	if err = CheckArchiveCompatibility(archive, schemaVersion); err != nil {
+		return result, err
+	}
+
+	for name, value := range archive.ConfigOverrides {
+		err := store.DB.Where(models.Configuration{Name: name}).
+			Assign(models.Configuration{Name: name, Value: value}).
+			FirstOrCreate(&models.Configuration{}).Error
+		recordImport(&result, "config "+name, false, err)
+	}
+	for _, ab := range archive.Bridges {
+		skipped, err := importBridge(store, ab)
+		recordImport(&result, "bridge "+ab.Name.String(), skipped, err)
+	}
+	for _, aei := range archive.ExternalInitiators {
+		skipped, err := importExternalInitiator(store, aei)
+		recordImport(&result, "external initiator "+aei.Name, skipped, err)
+	}
+	for _, js := range archive.JobSpecs {
+		skipped, err := importJobSpec(store, adder, js)
+		recordImport(&result, "job spec "+js.ID.String(), skipped, err)
+	}
+
+	existing, err := jobORM.JobsV2()
+	if err != nil {
+		return result, errors.Wrap(err, "failed to load v2 jobs")
+	}
+	existingNames := make(map[string]bool)
+	existingTOML := make(map[string]bool)
+	for _, jb := range existing {
+		if jb.Name.ValueOrZero() != "" {
+			existingNames[jb.Name.String] = true
+		}
+		if specTOML, err := job.JobToSpecTOMLWithSecrets(jb); err == nil {
+			existingTOML[specTOML] = true
+		}
+	}
+	for i, aj := range archive.Jobs {
+		name := aj.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		if existingNames[aj.Name] || existingTOML[aj.TOML] {
+			recordImport(&result, "job "+name, true, nil)
+			continue
+		}
+		recordImport(&result, "job "+name, false, importJob(ctx, store, validators, adder, aj))
+	}
+	return result, nil
+}
+
+func recordImport(result *Result, name string, skipped bool, err error) {
+	switch {
+	case err != nil:
+		result.Errors[name] = err
+	case skipped:
+		result.Skipped = append(result.Skipped, name)
+	default:
+		result.Created = append(result.Created, name)
+	}
+}
+
+func importBridge(store *store.Store, ab ArchivedBridge) (bool, error) {
+	if _, err := store.FindBridge(ab.Name); err == nil {
+		return true, nil
+	}
+	return false, store.CreateBridgeType(&models.BridgeType{
+		Name:                   ab.Name,
+		URL:                    ab.URL,
+		Confirmations:          ab.Confirmations,
+		IncomingTokenHash:      ab.IncomingTokenHash,
+		Salt:                   ab.Salt,
+		OutgoingToken:          ab.OutgoingToken,
+		MinimumContractPayment: ab.MinimumContractPayment,
+		GRPCTarget:             ab.GRPCTarget,
+		GRPCTLS:                ab.GRPCTLS,
+		GRPCTLSCACert:          ab.GRPCTLSCACert,
+		MaxConcurrentCalls:     ab.MaxConcurrentCalls,
+	})
+}
+
+func importExternalInitiator(store *store.Store, aei ArchivedExternalInitiator) (bool, error) {
+	if _, err := store.FindExternalInitiatorByName(aei.Name); err == nil {
+		return true, nil
+	}
+	return false, store.CreateExternalInitiator(&models.ExternalInitiator{
+		Name:           aei.Name,
+		URL:            aei.URL,
+		AccessKey:      aei.AccessKey,
+		Salt:           aei.Salt,
+		HashedSecret:   aei.HashedSecret,
+		OutgoingSecret: aei.OutgoingSecret,
+		OutgoingToken:  aei.OutgoingToken,
+	})
+}
+
+func importJobSpec(store *store.Store, adder JobAdder, js models.JobSpec) (bool, error) {
+	var count int64
+	if err := store.DB.Unscoped().Model(&models.JobSpec{}).Where("id = ?", js.ID).Count(&count).Error; err != nil {
+		return false, err
+	} else if count > 0 {
+		return true, nil
+	}
+	for i := range js.Initiators {
+		js.Initiators[i].ID = 0
+	}
+	for i := range js.Tasks {
+		js.Tasks[i].ID = 0
+	}
+	if err := services.ValidateJob(js, store); err != nil {
+		return false, err
+	}
+	return false, adder.AddJob(js)
+}
+
+func importJob(ctx context.Context, store *store.Store, validators *job.ValidatorRegistry, adder JobAdder, aj ArchivedJob) error {
+	jb, err := ResolvedJobSpec(ctx, validators, store.Config, store.EthClient, aj.TOML)
+	if err != nil {
+		return err
+	}
+	if aj.SpecChecksum != "" {
+		jb.SpecChecksum = null.StringFrom(aj.SpecChecksum)
+	}
+	if aj.OnChainJobSpecID != nil && jb.DirectRequestSpec != nil {
+		jb.DirectRequestSpec.OnChainJobSpecID = *aj.OnChainJobSpecID
+	}
+	_, err = adder.AddJobV2(ctx, jb, jb.Name)
+	return err
+}
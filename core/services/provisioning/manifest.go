@@ -0,0 +1,401 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// Actions and kinds of manifest changes
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+
+	KindBridge       = "bridge"
+	KindETHKey       = "ethKey"
+	KindOCRKeyBundle = "ocrKeyBundle"
+	KindConfig       = "config"
+)
+
+type (
+	// Manifest declares the bridges, ETH keys, OCR key bundles and config
+	// overrides that a node should have. Applying it creates the bridges and
+	// keys that are missing, and updates the bridges and config overrides
+	// that differ. ETH keys are matched by their settings, and OCR key
+	// bundles by their number. Keys are only ever created, and nothing is
+	// deleted.
+	Manifest struct {
+		Bridges       []models.BridgeTypeRequest `json:"bridges"`
+		ETHKeys       []models.KeySettings       `json:"ethKeys"`
+		OCRKeyBundles int                        `json:"ocrKeyBundles"`
+		// ConfigOverrides are the settings that can be changed at runtime,
+		// by their env var names
+		ConfigOverrides map[string]string `json:"configOverrides"`
+	}
+
+	// ManifestChange is a change that applying a manifest made, or would
+	// make in a dry run. The name of a created key is its address or ID,
+	// which is only known once it has been created.
+	ManifestChange struct {
+		Action string   `json:"action"`
+		Kind   string   `json:"kind"`
+		Name   string   `json:"name"`
+		Diff   []string `json:"diff"`
+	}
+
+	plannedChange struct {
+		*ManifestChange
+		apply func() error
+	}
+
+	// configOverride reads and writes a setting that a manifest can
+	// override. Values are normalized before they are compared.
+	configOverride struct {
+		get       func(config *orm.Config) string
+		normalize func(value string) (string, error)
+		set       func(ctx context.Context, store *store.Store, value string) error
+	}
+)
+
+var configOverrides = map[string]configOverride{
+	orm.EnvVarName("EthGasPriceDefault"): {
+		get: func(config *orm.Config) string { return config.EthGasPriceDefault().String() },
+		normalize: func(value string) (string, error) {
+			price, ok := new(big.Int).SetString(value, 10)
+			if !ok || price.Sign() < 0 {
+				return "", errors.Errorf("%q is not a gas price in wei", value)
+			}
+			return price.String(), nil
+		},
+		set: func(_ context.Context, store *store.Store, value string) error {
+			price, _ := new(big.Int).SetString(value, 10)
+			return store.Config.SetEthGasPriceDefault(price)
+		},
+	},
+	orm.EnvVarName("LogLevel"): {
+		get: func(config *orm.Config) string { return config.LogLevel().String() },
+		normalize: func(value string) (string, error) {
+			var ll orm.LogLevel
+			if err := ll.Set(value); err != nil {
+				return "", err
+			}
+			return ll.String(), nil
+		},
+		set: func(ctx context.Context, store *store.Store, value string) error {
+			if err := store.Config.SetLogLevel(ctx, value); err != nil {
+				return err
+			}
+			logger.SetLogger(store.Config.CreateProductionLogger())
+			return nil
+		},
+	},
+	orm.EnvVarName("LogSQLStatements"): {
+		get: func(config *orm.Config) string { return strconv.FormatBool(config.LogSQLStatements()) },
+		normalize: func(value string) (string, error) {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return "", errors.Errorf("%q is not a boolean", value)
+			}
+			return strconv.FormatBool(enabled), nil
+		},
+		set: func(ctx context.Context, store *store.Store, value string) error {
+			enabled, _ := strconv.ParseBool(value)
+			if err := store.Config.SetLogSQLStatements(ctx, enabled); err != nil {
+				return err
+			}
+			store.SetLogging(enabled)
+			logger.SetLogger(store.Config.CreateProductionLogger())
+			return nil
+		},
+	},
+}
+
+// ApplyManifest makes the node match the manifest, returning the changes it
+// made. With dryRun, it only returns the changes it would make. An invalid
+// manifest returns a *models.JSONAPIErrors with every problem found, and
+// changes nothing. Otherwise the changes are made in order until one fails,
+// and those made before it are returned with its error. Applying the
+// manifest again then makes the rest.
+func ApplyManifest(ctx context.Context, store *store.Store, manifest Manifest, dryRun bool) ([]ManifestChange, error) {
+	if err := validateManifest(store, manifest); err != nil {
+		return nil, err
+	}
+	plan, err := planManifest(ctx, store, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []ManifestChange{}
+	for _, pc := range plan {
+		if !dryRun {
+			if err := pc.apply(); err != nil {
+				return changes, errors.Wrapf(err, "failed to %s %s %s", pc.Action, pc.Kind, pc.Name)
+			}
+			logger.Infow("Provisioning: applied manifest change", "action", pc.Action, "kind", pc.Kind, "name", pc.Name)
+		}
+		changes = append(changes, *pc.ManifestChange)
+	}
+	return changes, nil
+}
+
+func validateManifest(store *store.Store, manifest Manifest) error {
+	fe := models.NewJSONAPIErrors()
+	names := make(map[string]bool)
+	for i := range manifest.Bridges {
+		btr := &manifest.Bridges[i]
+		name := btr.Name.String()
+		if names[name] {
+			fe.Add(fmt.Sprintf("bridge %s is declared more than once", name))
+		}
+		names[name] = true
+		if err := services.ValidateBridgeType(btr, store); err != nil {
+			fe.Add(fmt.Sprintf("bridge %s: %v", name, err))
+		}
+	}
+	for i, settings := range manifest.ETHKeys {
+		if settings.MaxGasPriceWei != nil && settings.MaxGasPriceWei.ToInt().Sign() <= 0 {
+			fe.Add(fmt.Sprintf("ETH key #%d: maxGasPriceWei must be positive", i))
+		}
+		if settings.MaxInFlightTransactions != nil && *settings.MaxInFlightTransactions == 0 {
+			fe.Add(fmt.Sprintf("ETH key #%d: maxInFlightTransactions must be positive", i))
+		}
+	}
+	if manifest.OCRKeyBundles < 0 {
+		fe.Add("ocrKeyBundles must not be negative")
+	}
+	for _, name := range sortedConfigNames(manifest.ConfigOverrides) {
+		override, ok := configOverrides[name]
+		if !ok {
+			fe.Add(fmt.Sprintf("config %s can't be overridden, only %s can", name, strings.Join(overridableConfigNames(), ", ")))
+			continue
+		}
+		if _, err := override.normalize(manifest.ConfigOverrides[name]); err != nil {
+			fe.Add(fmt.Sprintf("config %s: %v", name, err))
+		}
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+// planManifest returns the changes that would make the node match a valid
+// manifest
+func planManifest(ctx context.Context, store *store.Store, manifest Manifest) ([]plannedChange, error) {
+	var plan []plannedChange
+
+	for i := range manifest.Bridges {
+		pc, err := planBridge(store, &manifest.Bridges[i])
+		if err != nil {
+			return nil, err
+		}
+		if pc != nil {
+			plan = append(plan, *pc)
+		}
+	}
+
+	keys, err := store.AllKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load ETH keys")
+	}
+	used := make(map[int32]bool)
+	for _, settings := range manifest.ETHKeys {
+		if matchKey(keys, settings, used) {
+			continue
+		}
+		plan = append(plan, planETHKey(store, settings))
+	}
+
+	bundles, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load OCR key bundles")
+	}
+	for i := len(bundles); i < manifest.OCRKeyBundles; i++ {
+		plan = append(plan, planOCRKeyBundle(store))
+	}
+
+	for _, name := range sortedConfigNames(manifest.ConfigOverrides) {
+		override := configOverrides[name]
+		current := override.get(store.Config)
+		value, _ := override.normalize(manifest.ConfigOverrides[name])
+		if value == current {
+			continue
+		}
+		plan = append(plan, plannedChange{
+			ManifestChange: &ManifestChange{Action: ActionUpdate, Kind: KindConfig, Name: name, Diff: []string{fmt.Sprintf("%s -> %s", current, value)}},
+			apply:          func() error { return override.set(ctx, store, value) },
+		})
+	}
+	return plan, nil
+}
+
+// planBridge returns the change that makes the bridge match btr, or nil if
+// it already does
+func planBridge(store *store.Store, btr *models.BridgeTypeRequest) (*plannedChange, error) {
+	name := btr.Name.String()
+	existing, err := store.FindBridge(btr.Name)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return &plannedChange{
+			ManifestChange: &ManifestChange{Action: ActionCreate, Kind: KindBridge, Name: name, Diff: []string{"url: " + btr.URL.String()}},
+			apply: func() error {
+				_, bt, err := models.NewBridgeType(btr)
+				if err != nil {
+					return err
+				}
+				return store.CreateBridgeType(bt)
+			},
+		}, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to load bridge %s", name)
+	}
+
+	updated := existing
+	changed, err := updated.UpdateFrom(btr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compare bridge %s", name)
+	}
+	if !changed {
+		return nil, nil
+	}
+	return &plannedChange{
+		ManifestChange: &ManifestChange{Action: ActionUpdate, Kind: KindBridge, Name: name, Diff: bridgeDiff(existing, updated)},
+		apply:          func() error { return store.SaveBridgeType(&updated) },
+	}, nil
+}
+
+// bridgeDiff describes how a bridge changed. Tokens and certificates are
+// only said to have changed, so that they aren't printed.
+func bridgeDiff(from, to models.BridgeType) []string {
+	var diff []string
+	add := func(field, from, to string) {
+		if from != to {
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", field, from, to))
+		}
+	}
+	addSecret := func(field, from, to string) {
+		if from != to {
+			diff = append(diff, field+": changed")
+		}
+	}
+	add("url", from.URL.String(), to.URL.String())
+	add("confirmations", fmt.Sprint(from.Confirmations), fmt.Sprint(to.Confirmations))
+	add("minimumContractPayment", linkString(from.MinimumContractPayment), linkString(to.MinimumContractPayment))
+	add("grpcTarget", from.GRPCTarget, to.GRPCTarget)
+	add("grpcTLS", strconv.FormatBool(from.GRPCTLS), strconv.FormatBool(to.GRPCTLS))
+	addSecret("grpcTLSCACert", from.GRPCTLSCACert, to.GRPCTLSCACert)
+	add("maxConcurrentCalls", fmt.Sprint(from.MaxConcurrentCalls), fmt.Sprint(to.MaxConcurrentCalls))
+	addSecret("incomingToken", from.IncomingTokenHash, to.IncomingTokenHash)
+	addSecret("outgoingToken", from.OutgoingToken, to.OutgoingToken)
+	return diff
+}
+
+// matchKey marks the first unused key with the given settings as used,
+// returning false if there is none. The funding key is never matched.
+func matchKey(keys []models.Key, settings models.KeySettings, used map[int32]bool) bool {
+	for _, key := range keys {
+		if used[key.ID] || key.IsFunding || !sameKeySettings(key.Settings(), settings) {
+			continue
+		}
+		used[key.ID] = true
+		return true
+	}
+	return false
+}
+
+func sameKeySettings(a, b models.KeySettings) bool {
+	return a.IsTransmitter == b.IsTransmitter &&
+		bigString(a.ChainID) == bigString(b.ChainID) &&
+		bigString(a.MaxGasPriceWei) == bigString(b.MaxGasPriceWei) &&
+		uint32String(a.MaxInFlightTransactions) == uint32String(b.MaxInFlightTransactions)
+}
+
+func planETHKey(store *store.Store, settings models.KeySettings) plannedChange {
+	change := &ManifestChange{Action: ActionCreate, Kind: KindETHKey, Diff: []string{
+		"isTransmitter: " + strconv.FormatBool(settings.IsTransmitter),
+		"chainID: " + bigString(settings.ChainID),
+		"maxGasPriceWei: " + bigString(settings.MaxGasPriceWei),
+		"maxInFlightTransactions: " + uint32String(settings.MaxInFlightTransactions),
+	}}
+	return plannedChange{
+		ManifestChange: change,
+		apply: func() error {
+			account, err := store.KeyStore.NewAccount()
+			if err != nil {
+				return err
+			}
+			if err = store.SyncDiskKeyStoreToDB(); err != nil {
+				return err
+			}
+			change.Name = account.Address.Hex()
+			store.Events.Publish(eventbus.KeyGenerated{Type: eventbus.KeyTypeETH, ID: change.Name})
+			_, err = store.UpdateKeySettings(account.Address, settings)
+			return err
+		},
+	}
+}
+
+func planOCRKeyBundle(store *store.Store) plannedChange {
+	change := &ManifestChange{Action: ActionCreate, Kind: KindOCRKeyBundle, Diff: []string{}}
+	return plannedChange{
+		ManifestChange: change,
+		apply: func() error {
+			_, bundle, err := store.OCRKeyStore.GenerateEncryptedOCRKeyBundle()
+			if err != nil {
+				return err
+			}
+			change.Name = bundle.ID.String()
+			store.Events.Publish(eventbus.KeyGenerated{Type: eventbus.KeyTypeOCR, ID: change.Name})
+			return nil
+		},
+	}
+}
+
+func sortedConfigNames(overrides map[string]string) []string {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func overridableConfigNames() []string {
+	names := make([]string, 0, len(configOverrides))
+	for name := range configOverrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func linkString(l *assets.Link) string {
+	if l == nil {
+		return "none"
+	}
+	return l.String()
+}
+
+func bigString(b *utils.Big) string {
+	if b == nil {
+		return "none"
+	}
+	return b.String()
+}
+
+func uint32String(n *uint32) string {
+	if n == nil {
+		return "none"
+	}
+	return strconv.FormatUint(uint64(*n), 10)
+}
@@ -0,0 +1,143 @@
+package provisioning_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestApplyManifest(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.SetRuntimeStore(store.ORM)
+	require.NoError(t, store.KeyStore.Unlock(cltest.Password))
+	require.NoError(t, store.OCRKeyStore.Unlock(cltest.Password))
+
+	_, existing := cltest.NewBridgeType(t, "existing", "http://a.example.com")
+	require.NoError(t, store.CreateBridgeType(existing))
+
+	manifest := provisioning.Manifest{
+		Bridges: []models.BridgeTypeRequest{
+			{Name: models.MustNewTaskType("existing"), URL: cltest.WebURL(t, "http://b.example.com")},
+			{Name: models.MustNewTaskType("added"), URL: cltest.WebURL(t, "http://c.example.com")},
+		},
+		ETHKeys: []models.KeySettings{
+			{IsTransmitter: true, ChainID: utils.NewBigI(1)},
+			{},
+		},
+		OCRKeyBundles:   1,
+		ConfigOverrides: map[string]string{"ETH_GAS_PRICE_DEFAULT": "31000000000"},
+	}
+	defaultGasPrice := store.Config.EthGasPriceDefault().String()
+
+	expected := []struct {
+		action, kind, name string
+	}{
+		{provisioning.ActionUpdate, provisioning.KindBridge, "existing"},
+		{provisioning.ActionCreate, provisioning.KindBridge, "added"},
+		{provisioning.ActionCreate, provisioning.KindETHKey, ""},
+		{provisioning.ActionCreate, provisioning.KindETHKey, ""},
+		{provisioning.ActionCreate, provisioning.KindOCRKeyBundle, ""},
+		{provisioning.ActionUpdate, provisioning.KindConfig, "ETH_GAS_PRICE_DEFAULT"},
+	}
+	assertChanges := func(t *testing.T, changes []provisioning.ManifestChange, dryRun bool) {
+		require.Len(t, changes, len(expected))
+		for i, e := range expected {
+			assert.Equal(t, e.action, changes[i].Action)
+			assert.Equal(t, e.kind, changes[i].Kind)
+			if e.name != "" || dryRun {
+				assert.Equal(t, e.name, changes[i].Name)
+			} else {
+				assert.NotEmpty(t, changes[i].Name)
+			}
+		}
+		assert.Equal(t, []string{"url: http://a.example.com -> http://b.example.com"}, changes[0].Diff)
+		assert.Contains(t, changes[2].Diff, "isTransmitter: true")
+		assert.Contains(t, changes[2].Diff, "chainID: 1")
+		assert.Equal(t, []string{defaultGasPrice + " -> 31000000000"}, changes[5].Diff)
+	}
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		changes, err := provisioning.ApplyManifest(context.Background(), store, manifest, true)
+		require.NoError(t, err)
+		assertChanges(t, changes, true)
+
+		cltest.AssertCount(t, store, models.BridgeType{}, 1)
+		cltest.AssertCount(t, store, models.Key{}, 0)
+		bundles, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+		require.NoError(t, err)
+		assert.Len(t, bundles, 0)
+		assert.Equal(t, defaultGasPrice, store.Config.EthGasPriceDefault().String())
+	})
+
+	t.Run("applies the changes", func(t *testing.T) {
+		changes, err := provisioning.ApplyManifest(context.Background(), store, manifest, false)
+		require.NoError(t, err)
+		assertChanges(t, changes, false)
+
+		bt, err := store.FindBridge(models.MustNewTaskType("existing"))
+		require.NoError(t, err)
+		assert.Equal(t, "http://b.example.com", bt.URL.String())
+		_, err = store.FindBridge(models.MustNewTaskType("added"))
+		require.NoError(t, err)
+
+		keys, err := store.AllKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		var transmitters int
+		for _, key := range keys {
+			if key.IsTransmitter {
+				transmitters++
+				assert.Equal(t, big.NewInt(1), key.ChainID.ToInt())
+			}
+		}
+		assert.Equal(t, 1, transmitters)
+
+		bundles, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+		require.NoError(t, err)
+		assert.Len(t, bundles, 1)
+		assert.Equal(t, big.NewInt(31000000000), store.Config.EthGasPriceDefault())
+	})
+
+	t.Run("applying again changes nothing", func(t *testing.T) {
+		changes, err := provisioning.ApplyManifest(context.Background(), store, manifest, false)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		cltest.AssertCount(t, store, models.Key{}, 2)
+	})
+}
+
+func TestApplyManifest_Invalid(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	manifest := provisioning.Manifest{
+		Bridges: []models.BridgeTypeRequest{
+			{Name: models.MustNewTaskType("nourl")},
+		},
+		OCRKeyBundles: -1,
+		ConfigOverrides: map[string]string{
+			"DATABASE_URL": "postgres://",
+			"LOG_SQL":      "maybe",
+		},
+	}
+	changes, err := provisioning.ApplyManifest(context.Background(), store, manifest, false)
+	require.Error(t, err)
+	assert.Nil(t, changes)
+
+	jae, ok := err.(*models.JSONAPIErrors)
+	require.True(t, ok)
+	require.Len(t, jae.Errors, 4)
+	assert.Contains(t, jae.Errors[0].Detail, "bridge nourl: URL must be present")
+	assert.Contains(t, jae.Errors[1].Detail, "ocrKeyBundles must not be negative")
+	assert.Contains(t, jae.Errors[2].Detail, "config DATABASE_URL can't be overridden")
+	assert.Contains(t, jae.Errors[3].Detail, `config LOG_SQL: "maybe" is not a boolean`)
+}
@@ -0,0 +1,318 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/services/eagrpc"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// Subdirectories of the provisioning directory
+const (
+	JobsDir    = "jobs"
+	BridgesDir = "bridges"
+)
+
+type (
+	// Reconciler makes the jobs and bridges on the node match a directory of
+	// job specs (JobsDir/*.toml) and bridge definitions (BridgesDir/*.json).
+	// Jobs are matched by name; a spec without a name is given the name of
+	// its file. Bridges are matched by name.
+	Reconciler struct {
+		store      *store.Store
+		jobORM     job.ORM
+		spawner    job.Spawner
+		validators *job.ValidatorRegistry
+	}
+
+	// Result records what a reconciliation or import changed, and the error
+	// encountered for each file, job or bridge that couldn't be reconciled
+	Result struct {
+		Created []string
+		Updated []string
+		Deleted []string
+		Skipped []string
+		Errors  map[string]error
+	}
+)
+
+// NewReconciler returns a Reconciler that validates job specs with
+// validators, and creates and deletes jobs through the given spawner
+func NewReconciler(store *store.Store, jobORM job.ORM, spawner job.Spawner, validators *job.ValidatorRegistry) *Reconciler {
+	return &Reconciler{store, jobORM, spawner, validators}
+}
+
+// Reconcile creates the jobs and bridges defined in dir that are missing from
+// the node, and updates bridges whose definition has changed. If prune is set
+// it also deletes the jobs and bridges that aren't defined in dir, and
+// replaces jobs whose spec has changed. A missing jobs or bridges
+// subdirectory leaves the node's jobs or bridges untouched. A failure to
+// reconcile one file doesn't prevent the others from being reconciled.
+func (r *Reconciler) Reconcile(ctx context.Context, dir string, prune bool) Result {
+	result := Result{Errors: make(map[string]error)}
+	bridgeNames := r.reconcileBridges(filepath.Join(dir, BridgesDir), &result)
+	r.reconcileJobs(ctx, filepath.Join(dir, JobsDir), prune, &result)
+	if prune && bridgeNames != nil {
+		r.pruneBridges(bridgeNames, &result)
+	}
+	return result
+}
+
+// Log logs the outcome of a reconciliation
+func (res Result) Log() {
+	for _, name := range res.Created {
+		logger.Infow("Provisioning: created", "name", name)
+	}
+	for _, name := range res.Updated {
+		logger.Infow("Provisioning: updated", "name", name)
+	}
+	for _, name := range res.Deleted {
+		logger.Infow("Provisioning: deleted", "name", name)
+	}
+	for _, name := range res.Skipped {
+		logger.Infow("Provisioning: skipped, already exists", "name", name)
+	}
+	for _, name := range sortedKeys(res.Errors) {
+		logger.Errorw("Provisioning: failed", "name", name, "error", res.Errors[name])
+	}
+}
+
+// reconcileBridges creates the missing bridges, returning the names of all
+// the bridges that are defined. It returns nil if there is no bridges
+// directory or it couldn't be read, so that no bridges are pruned.
+func (r *Reconciler) reconcileBridges(dir string, result *Result) map[string]bool {
+	paths, err := readDir(dir, ".json")
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		result.Errors[dir] = err
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for _, path := range paths {
+		btr, err := readBridge(path)
+		if err != nil {
+			result.Errors[path] = err
+			continue
+		}
+		names[btr.Name.String()] = true
+
+		if err = services.ValidateBridgeType(btr, r.store); err != nil {
+			result.Errors[path] = err
+			continue
+		}
+		if existing, err := r.store.FindBridge(btr.Name); err == nil {
+			if err = r.updateBridge(existing, btr, result); err != nil {
+				result.Errors[path] = err
+			}
+			continue
+		}
+		_, bt, err := models.NewBridgeType(btr)
+		if err != nil {
+			result.Errors[path] = err
+			continue
+		}
+		if err = r.store.CreateBridgeType(bt); err != nil {
+			result.Errors[path] = err
+			continue
+		}
+		result.Created = append(result.Created, "bridge "+bt.Name.String())
+	}
+	return names
+}
+
+func (r *Reconciler) updateBridge(bt models.BridgeType, btr *models.BridgeTypeRequest, result *Result) error {
+	changed, err := bt.UpdateFrom(btr)
+	if err != nil || !changed {
+		return err
+	}
+	if err = r.store.SaveBridgeType(&bt); err != nil {
+		return err
+	}
+	result.Updated = append(result.Updated, "bridge "+bt.Name.String())
+	return nil
+}
+
+func (r *Reconciler) pruneBridges(names map[string]bool, result *Result) {
+	var bridges []models.BridgeType
+	if err := r.store.DB.Order("name asc").Find(&bridges).Error; err != nil {
+		result.Errors[BridgesDir] = err
+		return
+	}
+	for i := range bridges {
+		name := bridges[i].Name.String()
+		if names[name] {
+			continue
+		}
+		key := "bridge " + name
+		jobIDs, err := r.jobORM.FindJobIDsWithBridge(name)
+		if err != nil {
+			result.Errors[key] = err
+			continue
+		}
+		v1JobIDs, err := r.store.FindJobIDsWithBridge(name)
+		if err != nil {
+			result.Errors[key] = err
+			continue
+		}
+		if len(jobIDs) > 0 || len(v1JobIDs) > 0 {
+			result.Errors[key] = errors.Errorf("can't remove the bridge because jobs %v %v are associated with it", jobIDs, v1JobIDs)
+			continue
+		}
+		if err = r.store.DeleteBridgeType(&bridges[i]); err != nil {
+			result.Errors[key] = err
+			continue
+		}
+		eagrpc.Evict(name)
+		result.Deleted = append(result.Deleted, key)
+	}
+}
+
+func (r *Reconciler) reconcileJobs(ctx context.Context, dir string, prune bool, result *Result) {
+	paths, err := readDir(dir, ".toml")
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		result.Errors[dir] = err
+		return
+	}
+	jobs, err := r.jobORM.JobsV2()
+	if err != nil {
+		result.Errors[dir] = err
+		return
+	}
+	jobsByName := make(map[string]job.Job)
+	for _, jb := range jobs {
+		if jb.Name.Valid {
+			jobsByName[jb.Name.String] = jb
+		}
+	}
+
+	// defined is nil if any spec couldn't be read, so that its job isn't
+	// pruned
+	defined := make(map[string]bool)
+	for _, path := range paths {
+		name, err := r.reconcileJob(ctx, path, jobsByName, prune, result)
+		if err != nil {
+			result.Errors[path] = err
+			if name == "" {
+				defined = nil
+			}
+		}
+		if defined != nil {
+			defined[name] = true
+		}
+	}
+
+	if !prune || defined == nil {
+		return
+	}
+	for _, jb := range jobs {
+		if jb.Name.Valid && defined[jb.Name.String] {
+			continue
+		}
+		key := fmt.Sprintf("job %v", jb.ID)
+		if jb.Name.Valid {
+			key = "job " + jb.Name.String
+		}
+		if err := r.spawner.DeleteJob(ctx, jb.ID, true); err != nil {
+			result.Errors[key] = err
+			continue
+		}
+		result.Deleted = append(result.Deleted, key)
+	}
+}
+
+// reconcileJob creates the job defined in the file at path if it doesn't
+// exist, returning its name. The name is empty if the spec couldn't be read.
+func (r *Reconciler) reconcileJob(ctx context.Context, path string, jobsByName map[string]job.Job, prune bool, result *Result) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	specTOML := string(b)
+	jb, err := ResolvedJobSpec(ctx, r.validators, r.store.Config, r.store.EthClient, specTOML)
+	if err != nil {
+		return "", err
+	}
+	if !jb.Name.Valid || jb.Name.String == "" {
+		jb.Name = null.StringFrom(strings.TrimSuffix(filepath.Base(path), ".toml"))
+	}
+	name := jb.Name.String
+	checksum, err := job.SpecChecksum(specTOML)
+	if err != nil {
+		return name, err
+	}
+	jb.SpecChecksum = null.StringFrom(checksum)
+
+	if existing, exists := jobsByName[name]; exists {
+		if existing.SpecChecksum.ValueOrZero() == checksum {
+			return name, nil
+		}
+		if !prune {
+			return name, errors.Errorf("spec differs from that of job %v on the node", existing.ID)
+		}
+		if err = r.spawner.DeleteJob(ctx, existing.ID, true); err != nil {
+			return name, err
+		}
+		result.Deleted = append(result.Deleted, "job "+name)
+	}
+
+	if _, err = r.spawner.CreateJob(ctx, jb, jb.Name); err != nil {
+		return name, err
+	}
+	result.Created = append(result.Created, "job "+name)
+	return name, nil
+}
+
+func readBridge(path string) (*models.BridgeTypeRequest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	btr := &models.BridgeTypeRequest{}
+	if err = json.Unmarshal(b, btr); err != nil {
+		return nil, errors.Wrap(err, "invalid bridge definition")
+	}
+	return btr, nil
+}
+
+// readDir returns the paths of the files in dir with the given extension, in
+// lexical order
+func readDir(dir, ext string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ext {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+func sortedKeys(m map[string]error) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
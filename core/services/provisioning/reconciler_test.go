@@ -0,0 +1,111 @@
+package provisioning_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/job/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+const keeperSpec = `
+type            = "keeper"
+schemaVersion   = 1
+contractAddress = "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba"
+fromAddress     = "0xa8037A20989AFcBC51798de9762b351D63ff462e"
+`
+
+func writeFile(t *testing.T, dir, name, contents string) string {
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	dir, err := ioutil.TempDir("", "provisioning")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	jobsDir := filepath.Join(dir, provisioning.JobsDir)
+	bridgesDir := filepath.Join(dir, provisioning.BridgesDir)
+
+	writeFile(t, jobsDir, "keeper.toml", keeperSpec)
+	brokenPath := writeFile(t, jobsDir, "broken.toml", `type = "keeper"`)
+	writeFile(t, bridgesDir, "voter_turnout.json", `{"name": "voter_turnout", "url": "http://example.com"}`)
+	writeFile(t, jobsDir, "README.md", "not a spec")
+
+	checksum, err := job.SpecChecksum(keeperSpec)
+	require.NoError(t, err)
+
+	t.Run("creates missing jobs and bridges", func(t *testing.T) {
+		jobORM := new(mocks.ORM)
+		spawner := new(mocks.Spawner)
+		jobORM.On("JobsV2").Return([]job.Job{}, nil)
+		spawner.On("CreateJob", mock.Anything, mock.MatchedBy(func(jb job.Job) bool {
+			return jb.Name.String == "keeper" && jb.SpecChecksum.String == checksum
+		}), null.StringFrom("keeper")).Return(int32(1), nil)
+
+		result := provisioning.NewReconciler(store, jobORM, spawner, provisioning.NewValidators()).Reconcile(context.Background(), dir, false)
+
+		assert.ElementsMatch(t, []string{"bridge voter_turnout", "job keeper"}, result.Created)
+		assert.Empty(t, result.Deleted)
+		require.Len(t, result.Errors, 1)
+		assert.Error(t, result.Errors[brokenPath])
+		_, err := store.FindBridge(models.MustNewTaskType("voter_turnout"))
+		require.NoError(t, err)
+		jobORM.AssertExpectations(t)
+		spawner.AssertExpectations(t)
+	})
+
+	t.Run("reports drifted jobs without pruning", func(t *testing.T) {
+		jobORM := new(mocks.ORM)
+		spawner := new(mocks.Spawner)
+		jobORM.On("JobsV2").Return([]job.Job{
+			{IDEmbed: job.IDEmbed{ID: 1}, Name: null.StringFrom("keeper"), SpecChecksum: null.StringFrom("changed")},
+			{IDEmbed: job.IDEmbed{ID: 2}, Name: null.StringFrom("extra")},
+		}, nil)
+
+		result := provisioning.NewReconciler(store, jobORM, spawner, provisioning.NewValidators()).Reconcile(context.Background(), dir, false)
+
+		assert.Empty(t, result.Created)
+		assert.Empty(t, result.Deleted)
+		assert.Error(t, result.Errors[filepath.Join(jobsDir, "keeper.toml")])
+		spawner.AssertExpectations(t)
+	})
+
+	require.NoError(t, os.Remove(brokenPath))
+
+	t.Run("prunes extra jobs and replaces drifted ones", func(t *testing.T) {
+		jobORM := new(mocks.ORM)
+		spawner := new(mocks.Spawner)
+		jobORM.On("JobsV2").Return([]job.Job{
+			{IDEmbed: job.IDEmbed{ID: 1}, Name: null.StringFrom("keeper"), SpecChecksum: null.StringFrom("changed")},
+			{IDEmbed: job.IDEmbed{ID: 2}, Name: null.StringFrom("extra")},
+		}, nil)
+		spawner.On("DeleteJob", mock.Anything, int32(1), true).Return(nil)
+		spawner.On("DeleteJob", mock.Anything, int32(2), true).Return(nil)
+		spawner.On("CreateJob", mock.Anything, mock.Anything, null.StringFrom("keeper")).Return(int32(3), nil)
+
+		result := provisioning.NewReconciler(store, jobORM, spawner, provisioning.NewValidators()).Reconcile(context.Background(), dir, true)
+
+		assert.Equal(t, []string{"job keeper"}, result.Created)
+		assert.ElementsMatch(t, []string{"job keeper", "job extra"}, result.Deleted)
+		assert.Empty(t, result.Errors)
+		jobORM.AssertExpectations(t)
+		spawner.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,127 @@
+package provisioning
+
+import (
+	"context"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/directrequest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitorv2"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/keeper"
+	"github.com/smartcontractkit/chainlink/core/services/messagequeue"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/shadow"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// NewValidators returns a registry with the validators of the node's built-in
+// job types
+func NewValidators() *job.ValidatorRegistry {
+	r := job.NewValidatorRegistry()
+	for t, validator := range map[job.Type]job.Validator{
+		job.OffchainReporting: offchainreporting.ValidatedOracleSpecToml,
+		job.DirectRequest:     directrequest.ValidatedDirectRequestSpec,
+		job.FluxMonitor:       fluxmonitorv2.ValidatedFluxMonitorSpec,
+		job.Keeper:            keeper.ValidatedKeeperSpec,
+		job.Shadow:            shadow.ValidatedShadowSpec,
+		job.MessageQueue:      messagequeue.ValidatedMessageQueueSpec,
+	} {
+		if err := r.Register(t, validator); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// CanonicalSpec is a validated job spec in a canonical form, which is the same
+// however the spec's TOML was written. Its values are parsed and defaulted as
+// the node would create the job, and its pipeline's tasks are sorted by DOT ID.
+// Marshalled to JSON, equivalent specs are byte for byte identical.
+type CanonicalSpec struct {
+	job.Job
+	Tasks []pipeline.DOTTask `json:"tasks"`
+}
+
+// ValidateSpecString validates a TOML job spec of the given type with the
+// node's validators, with the node's configuration read from the environment,
+// and returns it in canonical form. It is meant for tooling outside the node,
+// e.g. linting job specs in CI before they are deployed. Checks that need the
+// node's database, e.g. that bridges, keys, peer IDs and transmitter addresses
+// exist, or that the jobs named by shadowOf and dependsOn exist, are only made
+// by CreateJob.
+func ValidateSpecString(jobType job.Type, specTOML string) (CanonicalSpec, error) {
+	t, err := job.SpecType(specTOML)
+	if err != nil {
+		return CanonicalSpec{}, err
+	}
+	if t != jobType {
+		return CanonicalSpec{}, errors.Errorf("expected a spec of type %s, got %s", jobType, t)
+	}
+	jb, err := NewValidators().Validate(orm.NewConfig(), specTOML)
+	if err != nil {
+		return CanonicalSpec{}, err
+	}
+	return CanonicalSpec{Job: jb, Tasks: jb.Pipeline.DOTTasks()}, nil
+}
+
+// ResolvedJobSpec validates a TOML job spec with validators, resolving its
+// contractAddress first if it is given as an ENS name, e.g. contractAddress =
+// "eth-usd.data.eth". The name is resolved to the address that the job will
+// use, and is kept in the job's ContractENSName so that it can be re-resolved
+// later. ENS names aren't resolved anywhere else in the spec, so a spec with
+// an ethcall task whose contract is an ENS name is rejected.
+func ResolvedJobSpec(ctx context.Context, validators *job.ValidatorRegistry, config *orm.Config, ethClient eth.GethClient, specTOML string) (job.Job, error) {
+	jb, err := resolvedJobSpec(ctx, validators, config, ethClient, specTOML)
+	if err != nil {
+		return jb, err
+	}
+	return jb, checkTaskContracts(jb)
+}
+
+func resolvedJobSpec(ctx context.Context, validators *job.ValidatorRegistry, config *orm.Config, ethClient eth.GethClient, specTOML string) (job.Job, error) {
+	tree, err := toml.Load(specTOML)
+	if err != nil {
+		return job.Job{}, errors.Wrap(err, "failed to parse V2 job TOML")
+	}
+	name, ok := tree.Get("contractAddress").(string)
+	if !ok || !eth.IsENSName(name) {
+		return validators.Validate(config, specTOML)
+	}
+
+	address, err := eth.ResolveENS(ctx, ethClient, name)
+	if err != nil {
+		return job.Job{}, errors.Wrap(err, "failed to resolve contractAddress")
+	}
+	tree.Set("contractAddress", address.Hex())
+	resolvedTOML, err := tree.ToTomlString()
+	if err != nil {
+		return job.Job{}, err
+	}
+
+	jb, err := validators.Validate(config, resolvedTOML)
+	if err != nil {
+		return jb, err
+	}
+	jb.ContractENSName = null.StringFrom(name)
+	return jb, nil
+}
+
+// checkTaskContracts returns an error if any ethcall task of the job is given
+// an ENS name as its contract, which would otherwise fail every run
+func checkTaskContracts(jb job.Job) error {
+	tasks, err := jb.Pipeline.TasksInDependencyOrder()
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if t, ok := task.(*pipeline.EthCallTask); ok && eth.IsENSName(t.Contract) {
+			return errors.Errorf("task %s: ENS names are only resolved in contractAddress, so the contract of an ethcall task must be an address, got %q", task.DotID(), t.Contract)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package provisioning_test
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata/validate")
+
+// TestValidateSpecString compares the canonical form of each spec, or the error
+// validating it, with its golden file. Run with -update to regenerate them.
+func TestValidateSpecString(t *testing.T) {
+	// The node's configuration is read from the environment. Timestamps are only
+	// set when a job is saved, so the canonical specs don't depend on the clock.
+	for _, feature := range []string{"FEATURE_OFFCHAIN_REPORTING", "FEATURE_FLUX_MONITOR_V2", "FEATURE_MESSAGE_QUEUE", "FEATURE_SHADOW"} {
+		require.NoError(t, os.Setenv(feature, "true"))
+		defer os.Unsetenv(feature)
+	}
+
+	tests := []struct {
+		spec    string
+		jobType job.Type
+		golden  string
+	}{
+		{"ocr.toml", job.OffchainReporting, "ocr.golden"},
+		{"directrequest.toml", job.DirectRequest, "directrequest.golden"},
+		{"fluxmonitor.toml", job.FluxMonitor, "fluxmonitor.golden"},
+		{"keeper.toml", job.Keeper, "keeper.golden"},
+		{"messagequeue.toml", job.MessageQueue, "messagequeue.golden"},
+		{"shadow.toml", job.Shadow, "shadow.golden"},
+		{"shadow_reformatted.toml", job.Shadow, "shadow.golden"},
+		{"shadow.toml", job.Keeper, "shadow_as_keeper.golden"},
+		{"shadow_without_pipeline.toml", job.Shadow, "shadow_without_pipeline.golden"},
+		{"shadow_unknown_key.toml", job.Shadow, "shadow_unknown_key.golden"},
+		{"unknown_type.toml", job.Type("bogus"), "unknown_type.golden"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.spec+" as "+string(test.jobType), func(t *testing.T) {
+			specTOML, err := ioutil.ReadFile(filepath.Join("testdata", "validate", test.spec))
+			require.NoError(t, err)
+
+			var actual []byte
+			spec, err := provisioning.ValidateSpecString(test.jobType, string(specTOML))
+			if err != nil {
+				actual = []byte(err.Error())
+			} else {
+				actual, err = json.MarshalIndent(spec, "", "  ")
+				require.NoError(t, err)
+			}
+			actual = append(actual, '\n')
+
+			golden := filepath.Join("testdata", "validate", test.golden)
+			if *updateGolden {
+				require.NoError(t, ioutil.WriteFile(golden, actual, 0644))
+			}
+			expected, err := ioutil.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestResolvedJobSpec_RejectsENSNamesInTasks(t *testing.T) {
+	t.Parallel()
+
+	spec := `
+type = "directrequest"
+schemaVersion = 1
+name = "eth-usd"
+contractAddress = "0x613a38AC1659769640aaE063C651F48E0250454C"
+observationSource = """
+price [type=ethcall contract="%s" data="0x50d25bcd"];
+"""
+`
+	config := orm.NewConfig()
+	config.Set("FEATURE_EXPERIMENTAL_TASKS", true)
+
+	_, err := provisioning.ResolvedJobSpec(context.Background(), provisioning.NewValidators(), config, nil, fmt.Sprintf(spec, "0x514910771AF9Ca656af840dff83E8264EcF986CA"))
+	require.NoError(t, err)
+
+	_, err = provisioning.ResolvedJobSpec(context.Background(), provisioning.NewValidators(), config, nil, fmt.Sprintf(spec, "eth-usd.data.eth"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `task price: ENS names are only resolved in contractAddress`)
+}
@@ -14,7 +14,8 @@ type storeReaper struct {
 	config orm.ConfigReader
 }
 
-// NewStoreReaper creates a reaper that cleans stale objects from the store.
+// NewStoreReaper creates a reaper that cleans stale objects from the store,
+// such as expired sessions and keys archived beyond the recovery window.
 func NewStoreReaper(store *store.Store) utils.SleeperTask {
 	return utils.NewSleeperTask(&storeReaper{
 		store:  store,
@@ -29,4 +30,14 @@ func (sr *storeReaper) Work() {
 	if err != nil {
 		logger.Error("unable to reap stale sessions: ", err)
 	}
+
+	if window := sr.config.KeyRecoveryWindow(); window > 0 {
+		archivedBefore := time.Now().Add(-window)
+		if err := sr.store.PurgeArchivedKeys(archivedBefore); err != nil {
+			logger.Error("unable to purge archived ETH keys: ", err)
+		}
+		if err := sr.store.OCRKeyStore.PurgeArchivedKeys(archivedBefore); err != nil {
+			logger.Error("unable to purge archived OCR keys: ", err)
+		}
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
 
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
@@ -63,3 +64,36 @@ func TestStoreReaper_ReapSessions(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreReaper_PurgeArchivedKeys(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("KEY_RECOVERY_WINDOW", "1h")
+	require.NoError(t, store.OCRKeyStore.Unlock(cltest.Password))
+
+	r := services.NewStoreReaper(store)
+	defer r.Stop()
+
+	_, recent, err := store.OCRKeyStore.GenerateEncryptedOCRKeyBundle()
+	require.NoError(t, err)
+	require.NoError(t, store.OCRKeyStore.ArchiveEncryptedOCRKeyBundle(&recent))
+
+	_, expired, err := store.OCRKeyStore.GenerateEncryptedOCRKeyBundle()
+	require.NoError(t, err)
+	require.NoError(t, store.OCRKeyStore.ArchiveEncryptedOCRKeyBundle(&expired))
+	require.NoError(t, store.DB.Exec(`UPDATE encrypted_ocr_key_bundles SET deleted_at = ? WHERE id = ?`, time.Now().Add(-2*time.Hour), expired.ID).Error)
+
+	r.WakeUp()
+
+	gomega.NewGomegaWithT(t).Eventually(func() []ocrkey.EncryptedKeyBundle {
+		var archived []ocrkey.EncryptedKeyBundle
+		require.NoError(t, store.DB.Unscoped().Where("deleted_at IS NOT NULL").Find(&archived).Error)
+		return archived
+	}).Should(gomega.HaveLen(1))
+
+	var archived ocrkey.EncryptedKeyBundle
+	require.NoError(t, store.DB.Unscoped().Where("deleted_at IS NOT NULL").First(&archived).Error)
+	assert.Equal(t, recent.ID, archived.ID)
+}
@@ -0,0 +1,252 @@
+package rpcusage
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"gorm.io/gorm"
+)
+
+// checkInterval is how often the calls counted by the eth client are saved
+const checkInterval = time.Minute
+
+const (
+	dayFormat   = "2006-01-02"
+	monthFormat = "2006-01"
+)
+
+// Usage is the number of calls made with one eth RPC method to one endpoint
+// on one day, in UTC
+type Usage struct {
+	Day      time.Time `json:"day"`
+	Method   string    `json:"method"`
+	Endpoint string    `json:"endpoint"`
+	Calls    int64     `json:"calls"`
+}
+
+func (Usage) TableName() string {
+	return "eth_rpc_usage"
+}
+
+// Save adds counts to the usage of the day of t
+func Save(db *gorm.DB, t time.Time, counts map[eth.RPCCallKey]uint64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	day := t.UTC().Format(dayFormat)
+	return db.Transaction(func(tx *gorm.DB) error {
+		for key, calls := range counts {
+			err := tx.Exec(`
+				INSERT INTO eth_rpc_usage (day, method, endpoint, calls) VALUES (?, ?, ?, ?)
+				ON CONFLICT (day, method, endpoint) DO UPDATE SET calls = eth_rpc_usage.calls + EXCLUDED.calls
+			`, day, key.Method, key.Endpoint, calls).Error
+			if err != nil {
+				return errors.Wrap(err, "failed to save eth RPC usage")
+			}
+		}
+		return nil
+	})
+}
+
+// FindUsage returns the usage of the days from from up to, but not including,
+// to
+func FindUsage(db *gorm.DB, from, to time.Time) ([]Usage, error) {
+	var usage []Usage
+	err := db.
+		Where("day >= ? AND day < ?", from.UTC().Format(dayFormat), to.UTC().Format(dayFormat)).
+		Order("day ASC, method ASC, endpoint ASC").
+		Find(&usage).Error
+	return usage, errors.Wrap(err, "failed to find eth RPC usage")
+}
+
+// Report is the eth RPC usage of the month up to now, and how many calls will
+// have been made by the end of the month at the same rate
+type Report struct {
+	Month       string
+	Usage       []Usage
+	MonthToDate int64
+	Projected   int64
+	Quota       uint64
+}
+
+// MakeReport reports the usage of the month of now
+func MakeReport(db *gorm.DB, now time.Time, quota uint64) (Report, error) {
+	start, end := monthOf(now)
+	usage, err := FindUsage(db, start, end)
+	if err != nil {
+		return Report{}, err
+	}
+	report := Report{Month: start.Format(monthFormat), Usage: usage, Quota: quota}
+	for _, u := range usage {
+		report.MonthToDate += u.Calls
+	}
+	report.Projected = Project(report.MonthToDate, now)
+	return report, nil
+}
+
+// Exceeded returns true if the calls projected for the month exceed a quota
+func (r Report) Exceeded() bool {
+	return r.Quota > 0 && r.Projected > int64(r.Quota)
+}
+
+// Project extrapolates the calls made so far in the month of now to the whole
+// month. Until a day of the month has passed, the calls are extrapolated as if
+// it had, so that the first calls of a month don't project huge usage.
+func Project(monthToDate int64, now time.Time) int64 {
+	start, end := monthOf(now)
+	elapsed := now.UTC().Sub(start)
+	if elapsed < 24*time.Hour {
+		elapsed = 24 * time.Hour
+	}
+	return int64(float64(monthToDate) * float64(end.Sub(start)) / float64(elapsed))
+}
+
+func monthOf(t time.Time) (start, end time.Time) {
+	t = t.UTC()
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// Monitor saves the calls counted by the eth client every minute, logs a
+// report of each day's usage when it is over, and warns when the usage of the
+// month is projected to exceed ETH_RPC_MONTHLY_QUOTA, publishing an
+// RPCQuotaProjected event at most once a day.
+type Monitor struct {
+	utils.StartStopOnce
+
+	db      *gorm.DB
+	events  *eventbus.Bus
+	config  orm.ConfigReader
+	pending map[eth.RPCCallKey]uint64
+	lastDay time.Time
+	warned  time.Time
+	chStop  chan struct{}
+	chDone  chan struct{}
+}
+
+// NewMonitor returns a Monitor of the eth client's RPC usage
+func NewMonitor(db *gorm.DB, events *eventbus.Bus, config orm.ConfigReader) *Monitor {
+	return &Monitor{
+		db:      db,
+		events:  events,
+		config:  config,
+		pending: make(map[eth.RPCCallKey]uint64),
+		chStop:  make(chan struct{}),
+		chDone:  make(chan struct{}),
+	}
+}
+
+func (m *Monitor) Start() error {
+	if !m.OkayToStart() {
+		return errors.New("RPCUsageMonitor has already been started")
+	}
+	go m.runLoop()
+	return nil
+}
+
+func (m *Monitor) Close() error {
+	if !m.OkayToStop() {
+		return errors.New("RPCUsageMonitor has already been stopped")
+	}
+	close(m.chStop)
+	<-m.chDone
+	return nil
+}
+
+func (m *Monitor) runLoop() {
+	defer close(m.chDone)
+
+	ticker := time.NewTicker(utils.WithJitter(checkInterval))
+	defer ticker.Stop()
+
+	ctx, cancel := utils.CombinedContext(m.chStop)
+	defer cancel()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.Check(ctx, time.Now()); err != nil {
+				logger.Errorw("RPCUsageMonitor: failed to check eth RPC usage", "error", err)
+			}
+		case <-m.chStop:
+			// Save the calls made since the last check, so that they aren't
+			// lost on shutdown
+			if err := Save(m.db, time.Now(), m.take()); err != nil {
+				logger.Errorw("RPCUsageMonitor: failed to save eth RPC usage", "error", err)
+			}
+			return
+		}
+	}
+}
+
+// take returns the calls counted by the eth client that haven't been saved
+// yet
+func (m *Monitor) take() map[eth.RPCCallKey]uint64 {
+	for key, calls := range eth.TakeRPCCalls() {
+		m.pending[key] += calls
+	}
+	return m.pending
+}
+
+// Check saves the calls counted by the eth client since the last check as
+// calls made on the day of now, logs the usage of the previous day if it has
+// ended since the last check, and warns if the usage of the month is projected
+// to exceed the quota
+func (m *Monitor) Check(ctx context.Context, now time.Time) error {
+	db := m.db.WithContext(ctx)
+	if err := Save(db, now, m.take()); err != nil {
+		return err
+	}
+	m.pending = make(map[eth.RPCCallKey]uint64)
+
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !m.lastDay.IsZero() && day.After(m.lastDay) {
+		if err := m.logDailyReport(db, m.lastDay); err != nil {
+			return err
+		}
+	}
+	m.lastDay = day
+
+	quota := m.config.EthRPCMonthlyQuota()
+	if quota == 0 || !m.warned.Before(day) {
+		return nil
+	}
+	report, err := MakeReport(db, now, quota)
+	if err != nil {
+		return err
+	}
+	if !report.Exceeded() {
+		return nil
+	}
+	logger.Warnw("RPCUsageMonitor: eth RPC usage is projected to exceed the monthly quota of ETH_RPC_MONTHLY_QUOTA",
+		"month", report.Month, "monthToDate", report.MonthToDate, "projected", report.Projected, "quota", quota)
+	m.events.Publish(eventbus.RPCQuotaProjected{
+		Month:       report.Month,
+		MonthToDate: report.MonthToDate,
+		Projected:   report.Projected,
+		Quota:       quota,
+	})
+	m.warned = day
+	return nil
+}
+
+func (m *Monitor) logDailyReport(db *gorm.DB, day time.Time) error {
+	usage, err := FindUsage(db, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	var total int64
+	calls := make(map[string]int64)
+	for _, u := range usage {
+		total += u.Calls
+		calls[u.Method+"@"+u.Endpoint] += u.Calls
+	}
+	logger.Infow("RPCUsageMonitor: eth RPC usage for "+day.Format(dayFormat), "total", total, "calls", calls)
+	return nil
+}
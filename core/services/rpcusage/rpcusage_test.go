@@ -0,0 +1,86 @@
+package rpcusage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/services/rpcusage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Project(t *testing.T) {
+	t.Parallel()
+
+	// April has 30 days
+	assert.Equal(t, int64(300), rpcusage.Project(100, time.Date(2021, 4, 11, 0, 0, 0, 0, time.UTC)))
+	// Less than a day of the month is extrapolated as a whole day
+	assert.Equal(t, int64(3000), rpcusage.Project(100, time.Date(2021, 4, 1, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, int64(0), rpcusage.Project(0, time.Date(2021, 4, 20, 0, 0, 0, 0, time.UTC)))
+}
+
+func Test_MakeReport(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	mar31 := time.Date(2021, 3, 31, 12, 0, 0, 0, time.UTC)
+	apr1 := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+	apr2 := time.Date(2021, 4, 2, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, rpcusage.Save(store.DB, mar31, map[eth.RPCCallKey]uint64{{Method: "eth_call", Endpoint: "a"}: 50}))
+	require.NoError(t, rpcusage.Save(store.DB, apr1, map[eth.RPCCallKey]uint64{{Method: "eth_call", Endpoint: "a"}: 10}))
+	require.NoError(t, rpcusage.Save(store.DB, apr1, map[eth.RPCCallKey]uint64{
+		{Method: "eth_call", Endpoint: "a"}:    5,
+		{Method: "eth_getLogs", Endpoint: "a"}: 5,
+	}))
+	require.NoError(t, rpcusage.Save(store.DB, apr2, map[eth.RPCCallKey]uint64{{Method: "eth_call", Endpoint: "b"}: 10}))
+
+	report, err := rpcusage.MakeReport(store.DB, time.Date(2021, 4, 3, 0, 0, 0, 0, time.UTC), 500)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2021-04", report.Month)
+	assert.Equal(t, int64(30), report.MonthToDate)
+	assert.Equal(t, int64(450), report.Projected)
+	assert.False(t, report.Exceeded())
+	require.Len(t, report.Usage, 3)
+	assert.Equal(t, "eth_call", report.Usage[0].Method)
+	assert.Equal(t, int64(15), report.Usage[0].Calls)
+	assert.Equal(t, "2021-04-01", report.Usage[0].Day.Format("2006-01-02"))
+	assert.Equal(t, "b", report.Usage[2].Endpoint)
+}
+
+func Test_Monitor_Check(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("ETH_RPC_MONTHLY_QUOTA", 1000)
+	sub := store.Events.Subscribe(eventbus.TopicRPCQuota)
+	defer sub.Close()
+
+	now := time.Date(2021, 4, 11, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, rpcusage.Save(store.DB, now, map[eth.RPCCallKey]uint64{{Method: "eth_call", Endpoint: "a"}: 400}))
+
+	monitor := rpcusage.NewMonitor(store.DB, store.Events, store.Config)
+	require.NoError(t, monitor.Check(context.Background(), now))
+
+	select {
+	case event := <-sub.Events():
+		assert.Equal(t, eventbus.RPCQuotaProjected{Month: "2021-04", MonthToDate: 400, Projected: 1142, Quota: 1000}, event)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for RPCQuotaProjected event")
+	}
+
+	t.Run("warns at most once a day", func(t *testing.T) {
+		require.NoError(t, monitor.Check(context.Background(), now.Add(time.Hour)))
+		assert.Len(t, sub.Events(), 0)
+
+		require.NoError(t, monitor.Check(context.Background(), now.Add(24*time.Hour)))
+		assert.Equal(t, eventbus.TopicRPCQuota, (<-sub.Events()).Topic())
+	})
+}
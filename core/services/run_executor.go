@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/services/synchronization"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -55,6 +56,7 @@ func (re *runExecutor) Execute(runID uuid.UUID) error {
 	if err != nil {
 		return errors.Wrapf(err, "error finding run %s", runID)
 	}
+	alreadyFinished := run.GetStatus().Finished()
 
 	validated := false
 	for taskIndex := range run.TaskRuns {
@@ -116,6 +118,14 @@ func (re *runExecutor) Execute(runID uuid.UUID) error {
 			logger.Debugw("All tasks complete for run", run.ForLogger()...)
 		}
 	}
+	if run.GetStatus().Finished() && !alreadyFinished {
+		re.store.Events.Publish(eventbus.RunFinished{
+			JobID:   run.JobSpecID.String(),
+			RunID:   run.ID.String(),
+			Errored: run.GetStatus().Errored(),
+			Error:   run.ErrorString(),
+		})
+	}
 	return nil
 }
 
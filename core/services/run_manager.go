@@ -5,6 +5,8 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	uuid "github.com/satori/go.uuid"
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/assets"
@@ -29,6 +31,13 @@ func (err RecurringScheduleJobError) Error() string {
 	return err.msg
 }
 
+var (
+	promPendingBridgeRunsExpired = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pending_bridge_runs_expired_total",
+		Help: "The total number of runs errored after waiting too long for a bridge callback",
+	})
+)
+
 //go:generate mockery --name RunManager --output ../internal/mocks/ --case=underscore
 
 // RunManager supplies methods for queueing, resuming and cancelling jobs in
@@ -47,6 +56,7 @@ type RunManager interface {
 		runID uuid.UUID,
 		input models.BridgeRunResult) error
 	Cancel(runID uuid.UUID) (*models.JobRun, error)
+	ExpireAllPendingBridge() error
 
 	ResumeAllInProgress() error
 	ResumeAllPendingNextBlock(currentBlockHeight *big.Int) error
@@ -356,6 +366,46 @@ func (rm *runManager) ResumePendingBridge(
 	return rm.saveAndResumeIfInProgress(&run)
 }
 
+// ExpireAllPendingBridge errors the runs that have been waiting for a bridge
+// callback for longer than PENDING_BRIDGE_RUN_TIMEOUT, so that they no longer
+// count as in flight and can be reaped like any other finished run.
+func (rm *runManager) ExpireAllPendingBridge() error {
+	timeout := rm.config.PendingBridgeRunTimeout()
+	if timeout == 0 {
+		return nil
+	}
+	runs, err := rm.orm.UnscopedPendingBridgeRunsUpdatedBefore(rm.clock.Now().Add(-timeout))
+	if err != nil {
+		return err
+	}
+
+	expired := 0
+	for i := range runs {
+		run := &runs[i]
+		if currentTaskRun := run.NextTaskRun(); currentTaskRun != nil {
+			currentTaskRun.SetError(models.ErrAsyncTimeout)
+		}
+		run.SetError(models.ErrAsyncTimeout)
+
+		err := rm.orm.SaveJobRun(run)
+		if errors.Cause(err) == orm.ErrOptimisticUpdateConflict {
+			// The callback arrived while the run was being expired
+			logger.Debugw("Pending bridge run was resumed before it could be expired", run.ForLogger()...)
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to expire pending bridge run %s", run.ID)
+		}
+		logger.Warnw("Run timed out waiting for bridge callback", run.ForLogger("timeout", timeout)...)
+		expired++
+	}
+
+	if expired > 0 {
+		promPendingBridgeRunsExpired.Add(float64(expired))
+		rm.statsPusher.PushNow()
+	}
+	return nil
+}
+
 // ResumeAllInProgress queries the db for job runs that should be resumed
 // since a previous node shutdown.
 //
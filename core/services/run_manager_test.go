@@ -127,6 +127,54 @@ func TestRunManager_ResumePendingBridge(t *testing.T) {
 	runQueue.AssertExpectations(t)
 }
 
+func TestRunManager_ExpireAllPendingBridge(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+	runQueue := new(mocks.RunQueue)
+
+	runManager := services.NewRunManager(runQueue, store.Config, store.ORM, pusher, store.Clock)
+
+	stale := makeJobRunWithInitiator(t, store, cltest.NewJob())
+	stale.SetStatus(models.RunStatusPendingBridge)
+	stale.TaskRuns[0].Status = models.RunStatusPendingBridge
+	require.NoError(t, store.CreateJobRun(&stale))
+	require.NoError(t, store.DB.Exec(`UPDATE job_runs SET updated_at = ? WHERE id = ?`, time.Now().Add(-2*time.Hour), stale.ID).Error)
+
+	fresh := makeJobRunWithInitiator(t, store, cltest.NewJob())
+	fresh.SetStatus(models.RunStatusPendingBridge)
+	require.NoError(t, store.CreateJobRun(&fresh))
+
+	t.Run("does nothing when disabled", func(t *testing.T) {
+		require.NoError(t, runManager.ExpireAllPendingBridge())
+
+		run, err := store.FindJobRun(stale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RunStatusPendingBridge, run.GetStatus())
+	})
+
+	t.Run("errors runs pending for longer than the timeout", func(t *testing.T) {
+		store.Config.Set("PENDING_BRIDGE_RUN_TIMEOUT", "1h")
+		require.NoError(t, runManager.ExpireAllPendingBridge())
+
+		run, err := store.FindJobRun(stale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RunStatusErrored, run.GetStatus())
+		assert.True(t, run.FinishedAt.Valid)
+		assert.Equal(t, models.ErrAsyncTimeout.Error(), run.ErrorString())
+		require.Len(t, run.TaskRuns, 1)
+		assert.Equal(t, models.RunStatusErrored, run.TaskRuns[0].Status)
+
+		run, err = store.FindJobRun(fresh.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RunStatusPendingBridge, run.GetStatus())
+	})
+
+	runQueue.AssertExpectations(t)
+}
+
 func TestRunManager_ResumeAllPendingNextBlock(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -6,6 +6,7 @@ import (
 
 	uuid "github.com/satori/go.uuid"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/maintenance"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -125,3 +126,24 @@ func (rq *runQueue) WorkerCount() int {
 
 	return len(rq.workers)
 }
+
+type maintenanceRunQueue struct {
+	RunQueue
+	maintenance *maintenance.Switch
+}
+
+// NewMaintenanceRunQueue wraps a RunQueue so that runs are held, rather than
+// executed, while the node is in maintenance mode. Held runs stay in progress
+// in the database, and are queued again by RunManager.ResumeAllInProgress
+// when the node is resumed.
+func NewMaintenanceRunQueue(runQueue RunQueue, maintenance *maintenance.Switch) RunQueue {
+	return &maintenanceRunQueue{RunQueue: runQueue, maintenance: maintenance}
+}
+
+func (rq *maintenanceRunQueue) Run(runID uuid.UUID) {
+	if rq.maintenance.RunsPaused() {
+		logger.Debugw("Holding run while the node is in maintenance mode", "runID", runID.String())
+		return
+	}
+	rq.RunQueue.Run(runID)
+}
@@ -0,0 +1,353 @@
+// Package runexport periodically exports the pipeline runs that have finished
+// since its last export, with their final values, to an S3 or GCS bucket as
+// newline-delimited JSON, so that analytics don't have to query the node's
+// database. Parquet is not supported; the objects can be converted to it by
+// the analytics pipeline.
+//
+// Only one node sharing the database exports at a time, the others skip the
+// export while its advisory lock is held.
+//
+// Requests are signed with the keys in AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and, optionally, AWS_SESSION_TOKEN. GCS buckets are
+// written through its S3-compatible XML API, with the HMAC keys of a service
+// account in those variables.
+package runexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// Format is the format of the exported objects
+type Format string
+
+const (
+	FormatNDJSON     Format = "ndjson"
+	FormatNDJSONGzip Format = "ndjson.gz"
+)
+
+const (
+	// cursorConfigurationName is the name of the configurations row that the
+	// position of the last export is saved in
+	cursorConfigurationName = "RUN_EXPORT_CURSOR"
+	// settleTime is how long ago a run must have finished to be exported, so
+	// that a run committed just after a later one isn't skipped
+	settleTime   = time.Minute
+	batchSize    = 10000
+	minFrequency = time.Minute
+)
+
+var promRunsExported = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "run_export_runs_exported",
+	Help: "The number of pipeline runs exported to the object store",
+})
+
+type Config interface {
+	RunExportURL() *url.URL
+	RunExportFrequency() time.Duration
+	RunExportFormat() string
+	RunExportPartitioning() string
+	RunExportEndpoint() string
+}
+
+// Record is a line of an exported object
+type Record struct {
+	RunID          int64                     `json:"runID"`
+	JobID          *int32                    `json:"jobID"`
+	JobName        null.String               `json:"jobName"`
+	JobType        null.String               `json:"jobType"`
+	PipelineSpecID int32                     `json:"pipelineSpecID"`
+	CreatedAt      time.Time                 `json:"createdAt"`
+	FinishedAt     time.Time                 `json:"finishedAt"`
+	Errored        bool                      `json:"errored" gorm:"-"`
+	Errors         pipeline.RunErrors        `json:"errors"`
+	Outputs        pipeline.JSONSerializable `json:"outputs"`
+}
+
+// cursor is the position of the last exported run in the order runs are
+// exported in
+type cursor struct {
+	FinishedAt time.Time `json:"finishedAt"`
+	RunID      int64     `json:"runID"`
+}
+
+// Exporter exports finished runs every RUN_EXPORT_FREQUENCY. Each export
+// writes the runs in batches of up to batchSize, one object per batch and
+// partition, named after the first run in it. The position of the last
+// exported run is saved after each batch, so a batch is written again after
+// a failure, replacing the object written before.
+type Exporter struct {
+	utils.StartStopOnce
+	db             *gorm.DB
+	advisoryLocker postgres.AdvisoryLocker
+	store          *objectStore
+	prefix         string
+	format         Format
+	partitioning   string
+	frequency      time.Duration
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewExporter returns an Exporter of the runs in db to RUN_EXPORT_URL
+func NewExporter(db *gorm.DB, advisoryLocker postgres.AdvisoryLocker, config Config) (*Exporter, error) {
+	creds, err := credentialsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return newExporter(db, advisoryLocker, config, creds)
+}
+
+func newExporter(db *gorm.DB, advisoryLocker postgres.AdvisoryLocker, config Config, creds credentials) (*Exporter, error) {
+	bucketURL := config.RunExportURL()
+	if bucketURL == nil {
+		return nil, errors.New("RUN_EXPORT_URL is not set")
+	}
+	store, err := newObjectStore(utils.UnrestrictedClient, creds, bucketURL, config.RunExportEndpoint())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid RUN_EXPORT_URL")
+	}
+	format := Format(config.RunExportFormat())
+	if format != FormatNDJSON && format != FormatNDJSONGzip {
+		return nil, errors.Errorf("unsupported RUN_EXPORT_FORMAT %q, must be ndjson or ndjson.gz", format)
+	}
+	frequency := config.RunExportFrequency()
+	if frequency < minFrequency {
+		return nil, errors.Errorf("RUN_EXPORT_FREQUENCY (%v) is too small. Please set it to at least %s", frequency, minFrequency)
+	}
+	return &Exporter{
+		db:             db,
+		advisoryLocker: advisoryLocker,
+		store:          store,
+		prefix:         strings.Trim(bucketURL.Path, "/"),
+		format:         format,
+		partitioning:   config.RunExportPartitioning(),
+		frequency:      frequency,
+		chStop:         make(chan struct{}),
+	}, nil
+}
+
+func (e *Exporter) Start() error {
+	return e.StartOnce("RunExporter", func() error {
+		e.wg.Add(1)
+		go e.run()
+		logger.Infow("RunExporter: exporting finished runs", "bucket", e.store.bucket, "prefix", e.prefix, "frequency", e.frequency)
+		return nil
+	})
+}
+
+func (e *Exporter) Close() error {
+	if !e.OkayToStop() {
+		return errors.New("RunExporter has already been stopped")
+	}
+	close(e.chStop)
+	e.wg.Wait()
+	return nil
+}
+
+func (e *Exporter) run() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.frequency)
+	defer ticker.Stop()
+	for {
+		e.exportGracefully()
+		select {
+		case <-ticker.C:
+		case <-e.chStop:
+			return
+		}
+	}
+}
+
+func (e *Exporter) exportGracefully() {
+	ctx, cancel := utils.CombinedContext(e.chStop, e.frequency)
+	defer cancel()
+	startAt := time.Now()
+	count, err := e.Export(ctx)
+	if errors.Is(err, errExportLocked) {
+		logger.Debugw("RunExporter: skipping export, could not take the export lock", "err", err)
+		return
+	} else if err != nil {
+		logger.Errorw("RunExporter: export failed", "exported", count, "duration", time.Since(startAt), "err", err)
+		return
+	}
+	logger.Debugw("RunExporter: export finished", "exported", count, "duration", time.Since(startAt))
+}
+
+// errExportLocked is returned by Export when it can't take the export's
+// advisory lock, usually because another node is exporting
+var errExportLocked = errors.New("run export is locked")
+
+// Export writes the runs that have finished since the last export to the
+// bucket, and returns how many it wrote. It holds an advisory lock while it
+// runs, so that nodes sharing the database don't write the same runs.
+func (e *Exporter) Export(ctx context.Context) (exported int, err error) {
+	locked := false
+	lockErr := e.advisoryLocker.WithAdvisoryLock(ctx, postgres.AdvisoryLockClassID_RunExport, postgres.AdvisoryLockObjectID_RunExport, func() error {
+		locked = true
+		exported, err = e.export(ctx)
+		return err
+	})
+	if !locked {
+		return 0, errors.Wrap(errExportLocked, lockErr.Error())
+	}
+	return exported, err
+}
+
+func (e *Exporter) export(ctx context.Context) (int, error) {
+	cur, err := e.loadCursor(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var exported int
+	for {
+		records, err := e.finishedRuns(ctx, cur, time.Now().Add(-settleTime))
+		if err != nil {
+			return exported, err
+		}
+		if len(records) == 0 {
+			return exported, nil
+		}
+		if err := e.write(ctx, records); err != nil {
+			return exported, err
+		}
+		last := records[len(records)-1]
+		cur = cursor{FinishedAt: last.FinishedAt, RunID: last.RunID}
+		if err := e.saveCursor(ctx, cur); err != nil {
+			return exported, err
+		}
+		exported += len(records)
+		promRunsExported.Add(float64(len(records)))
+		if len(records) < batchSize {
+			return exported, nil
+		}
+	}
+}
+
+// finishedRuns returns the next batch of runs after cur that finished
+// before the given time
+func (e *Exporter) finishedRuns(ctx context.Context, cur cursor, before time.Time) ([]Record, error) {
+	var records []Record
+	err := e.db.WithContext(ctx).Raw(`
+		SELECT pipeline_runs.id AS run_id, pipeline_runs.job_id, jobs.name AS job_name, jobs.type AS job_type,
+			pipeline_runs.pipeline_spec_id, pipeline_runs.created_at, pipeline_runs.finished_at,
+			pipeline_runs.errors, pipeline_runs.outputs
+		FROM pipeline_runs
+		LEFT JOIN jobs ON jobs.id = pipeline_runs.job_id
+		WHERE pipeline_runs.finished_at IS NOT NULL
+		AND (pipeline_runs.finished_at, pipeline_runs.id) > (?, ?)
+		AND pipeline_runs.finished_at < ?
+		ORDER BY pipeline_runs.finished_at, pipeline_runs.id
+		LIMIT ?`, cur.FinishedAt, cur.RunID, before, batchSize,
+	).Scan(&records).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load finished runs")
+	}
+	for i := range records {
+		records[i].Errored = records[i].Errors.HasError()
+	}
+	return records, nil
+}
+
+// write puts records, in the order they finished, into one object per
+// partition
+func (e *Exporter) write(ctx context.Context, records []Record) error {
+	start := 0
+	for i := range records {
+		if i+1 < len(records) && e.partition(records[i+1]) == e.partition(records[start]) {
+			continue
+		}
+		if err := e.writeObject(ctx, records[start:i+1]); err != nil {
+			return err
+		}
+		start = i + 1
+	}
+	return nil
+}
+
+func (e *Exporter) partition(r Record) string {
+	if e.partitioning == "" {
+		return ""
+	}
+	return r.FinishedAt.UTC().Format(e.partitioning)
+}
+
+func (e *Exporter) writeObject(ctx context.Context, records []Record) error {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gz *gzip.Writer
+	if e.format == FormatNDJSONGzip {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return errors.Wrapf(err, "could not encode run %d", record.RunID)
+		}
+	}
+	contentType := "application/x-ndjson"
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+		contentType = "application/gzip"
+	}
+
+	first := records[0]
+	name := fmt.Sprintf("runs-%s-%d.%s", first.FinishedAt.UTC().Format("20060102T150405.000000Z"), first.RunID, e.format)
+	key := path.Join(e.prefix, e.partition(first), name)
+	if err := e.store.put(ctx, key, contentType, buf.Bytes()); err != nil {
+		return err
+	}
+	logger.Debugw("RunExporter: wrote runs", "key", key, "runs", len(records))
+	return nil
+}
+
+func (e *Exporter) loadCursor(ctx context.Context) (cursor, error) {
+	var config models.Configuration
+	err := e.db.WithContext(ctx).First(&config, "name = ?", cursorConfigurationName).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return cursor{}, nil
+	} else if err != nil {
+		return cursor{}, errors.Wrap(err, "could not load run export cursor")
+	}
+	var cur cursor
+	if err := json.Unmarshal([]byte(config.Value), &cur); err != nil {
+		return cursor{}, errors.Wrap(err, "could not parse run export cursor")
+	}
+	return cur, nil
+}
+
+func (e *Exporter) saveCursor(ctx context.Context, cur cursor) error {
+	value, err := json.Marshal(cur)
+	if err != nil {
+		return err
+	}
+	err = e.db.WithContext(ctx).Where(models.Configuration{Name: cursorConfigurationName}).
+		Assign(models.Configuration{Name: cursorConfigurationName, Value: string(value)}).
+		FirstOrCreate(&models.Configuration{}).Error
+	return errors.Wrap(err, "could not save run export cursor")
+}
@@ -0,0 +1,237 @@
+package runexport_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/services/runexport"
+)
+
+type config struct {
+	url          string
+	endpoint     string
+	format       string
+	partitioning string
+	frequency    time.Duration
+}
+
+func (c config) RunExportURL() *url.URL {
+	u, _ := url.Parse(c.url)
+	return u
+}
+func (c config) RunExportFrequency() time.Duration { return c.frequency }
+func (c config) RunExportFormat() string           { return c.format }
+func (c config) RunExportPartitioning() string     { return c.partitioning }
+func (c config) RunExportEndpoint() string         { return c.endpoint }
+
+type object struct {
+	path    string
+	header  http.Header
+	records []runexport.Record
+}
+
+func newBucket(t *testing.T) (*httptest.Server, chan object) {
+	objects := make(chan object, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		if strings.HasSuffix(r.URL.Path, ".gz") {
+			gz, err := gzip.NewReader(bytes.NewReader(b))
+			require.NoError(t, err)
+			b, err = ioutil.ReadAll(gz)
+			require.NoError(t, err)
+		}
+		var records []runexport.Record
+		scanner := bufio.NewScanner(bytes.NewReader(b))
+		for scanner.Scan() {
+			var record runexport.Record
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, record)
+		}
+		objects <- object{r.URL.Path, r.Header, records}
+	}))
+	return server, objects
+}
+
+func mustInsertFinishedRun(t *testing.T, db *gorm.DB, jb job.Job, finishedAt time.Time, errored bool) pipeline.Run {
+	run := pipeline.Run{
+		PipelineSpecID: jb.PipelineSpecID,
+		JobID:          &jb.ID,
+		Outputs:        pipeline.JSONSerializable{Val: []interface{}{"42"}},
+		Errors:         pipeline.RunErrors{null.String{}},
+		CreatedAt:      finishedAt.Add(-time.Second),
+		FinishedAt:     &finishedAt,
+	}
+	if errored {
+		run.Outputs = pipeline.JSONSerializable{Val: []interface{}{nil}}
+		run.Errors = pipeline.RunErrors{null.StringFrom("boom")}
+	}
+	require.NoError(t, db.Create(&run).Error)
+	return run
+}
+
+func TestExporter_Export(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	bucket, objects := newBucket(t)
+	defer bucket.Close()
+
+	exporter, err := runexport.NewExporterWithCredentials(store.DB, config{
+		url:          "s3://bucket/runs/",
+		endpoint:     bucket.URL,
+		format:       "ndjson",
+		partitioning: "dt=2006-01-02",
+		frequency:    time.Hour,
+	}, "key", "secret")
+	require.NoError(t, err)
+
+	jb := cltest.MustInsertV2JobSpec(t, store, cltest.NewAddress())
+	day := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	run1 := mustInsertFinishedRun(t, store.DB, jb, day, false)
+	run2 := mustInsertFinishedRun(t, store.DB, jb, day.Add(time.Minute), true)
+	run3 := mustInsertFinishedRun(t, store.DB, jb, day.Add(24*time.Hour), false)
+	cltest.MustInsertPipelineRun(t, store.DB)
+	// Finished too recently to be exported yet
+	run4 := mustInsertFinishedRun(t, store.DB, jb, time.Now(), false)
+
+	exported, err := exporter.Export(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, exported)
+	require.Len(t, objects, 2)
+
+	o := <-objects
+	assert.Equal(t, "/bucket/runs/dt=2021-01-02/runs-20210102T030405.000000Z-"+itoa(run1.ID)+".ndjson", o.path)
+	assert.Equal(t, "application/x-ndjson", o.header.Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(o.header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/"))
+	require.Len(t, o.records, 2)
+	assert.Equal(t, run1.ID, o.records[0].RunID)
+	assert.Equal(t, jb.ID, *o.records[0].JobID)
+	assert.Equal(t, null.StringFrom(string(job.OffchainReporting)), o.records[0].JobType)
+	assert.False(t, o.records[0].Errored)
+	assert.Equal(t, []interface{}{"42"}, o.records[0].Outputs.Val)
+	assert.Equal(t, run2.ID, o.records[1].RunID)
+	assert.True(t, o.records[1].Errored)
+	assert.Equal(t, pipeline.RunErrors{null.StringFrom("boom")}, o.records[1].Errors)
+
+	o = <-objects
+	assert.Equal(t, "/bucket/runs/dt=2021-01-03/runs-20210103T030405.000000Z-"+itoa(run3.ID)+".ndjson", o.path)
+	require.Len(t, o.records, 1)
+	assert.Equal(t, run3.ID, o.records[0].RunID)
+
+	// Nothing has finished since
+	exported, err = exporter.Export(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, exported)
+	assert.Len(t, objects, 0)
+
+	finishedAt := time.Now().Add(-time.Hour)
+	require.NoError(t, store.DB.Model(&run4).Update("finished_at", finishedAt).Error)
+	exported, err = exporter.Export(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, exported)
+	require.Len(t, objects, 1)
+	o = <-objects
+	require.Len(t, o.records, 1)
+	assert.Equal(t, run4.ID, o.records[0].RunID)
+}
+
+func TestExporter_Export_Gzip(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	bucket, objects := newBucket(t)
+	defer bucket.Close()
+
+	exporter, err := runexport.NewExporterWithCredentials(store.DB, config{
+		url:       "gs://bucket",
+		endpoint:  bucket.URL,
+		format:    "ndjson.gz",
+		frequency: time.Hour,
+	}, "key", "secret")
+	require.NoError(t, err)
+
+	jb := cltest.MustInsertV2JobSpec(t, store, cltest.NewAddress())
+	run := mustInsertFinishedRun(t, store.DB, jb, time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC), false)
+
+	exported, err := exporter.Export(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, exported)
+	require.Len(t, objects, 1)
+	o := <-objects
+	assert.Equal(t, "/bucket/runs-20210102T030405.000000Z-"+itoa(run.ID)+".ndjson.gz", o.path)
+	assert.Equal(t, "application/gzip", o.header.Get("Content-Type"))
+	assert.Contains(t, o.header.Get("Authorization"), "/auto/s3/aws4_request")
+	require.Len(t, o.records, 1)
+	assert.Equal(t, run.ID, o.records[0].RunID)
+}
+
+func TestExporter_SkipsExportWhileLocked(t *testing.T) {
+	t.Parallel()
+
+	locker := new(mocks.AdvisoryLocker)
+	locker.On("WithAdvisoryLock", mock.Anything, postgres.AdvisoryLockClassID_RunExport, postgres.AdvisoryLockObjectID_RunExport, mock.Anything).
+		Return(errors.New("could not get advisory lock"))
+	exporter, err := runexport.NewExporterWithLocker(nil, locker, config{url: "s3://bucket", format: "ndjson", frequency: time.Hour}, "key", "secret")
+	require.NoError(t, err)
+
+	exported, err := exporter.Export(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "run export is locked")
+	assert.Equal(t, 0, exported)
+	locker.AssertExpectations(t)
+}
+
+func TestNewExporter_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config config
+		errStr string
+	}{
+		{"no bucket", config{url: "s3:///prefix", format: "ndjson", frequency: time.Hour}, "no bucket"},
+		{"unknown scheme", config{url: "azure://bucket", format: "ndjson", frequency: time.Hour}, "unsupported scheme"},
+		{"unknown format", config{url: "s3://bucket", format: "parquet", frequency: time.Hour}, "unsupported RUN_EXPORT_FORMAT"},
+		{"frequency too small", config{url: "s3://bucket", format: "ndjson", frequency: time.Second}, "too small"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := runexport.NewExporterWithCredentials(nil, test.config, "key", "secret")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.errStr)
+		})
+	}
+}
+
+func itoa(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
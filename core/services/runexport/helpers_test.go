@@ -0,0 +1,15 @@
+package runexport
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+func NewExporterWithCredentials(db *gorm.DB, config Config, accessKeyID, secretAccessKey string) (*Exporter, error) {
+	return NewExporterWithLocker(db, &postgres.NullAdvisoryLocker{}, config, accessKeyID, secretAccessKey)
+}
+
+func NewExporterWithLocker(db *gorm.DB, advisoryLocker postgres.AdvisoryLocker, config Config, accessKeyID, secretAccessKey string) (*Exporter, error) {
+	return newExporter(db, advisoryLocker, config, credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey})
+}
@@ -0,0 +1,219 @@
+package runexport
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+const (
+	amzDateFormat   = "20060102T150405Z"
+	defaultRegion   = "us-east-1"
+	gcsEndpoint     = "https://storage.googleapis.com"
+	gcsSigningScope = "auto"
+)
+
+// credentials are the access keys that requests to the object store are
+// signed with. GCS accepts the HMAC keys of a service account in their place.
+type credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// credentialsFromEnv reads the credentials from the standard AWS environment
+// variables
+func credentialsFromEnv() (credentials, error) {
+	creds := credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return credentials{}, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set to export runs")
+	}
+	return creds, nil
+}
+
+// objectStore puts objects into a bucket of S3, GCS through its
+// S3-compatible XML API, or another S3-compatible store
+type objectStore struct {
+	client *http.Client
+	creds  credentials
+	region string
+	bucket string
+	// endpoint is the URL objects are put under with path-style addressing.
+	// Without it, S3 objects are put with virtual-hosted-style addressing.
+	endpoint *url.URL
+}
+
+func newObjectStore(client *http.Client, creds credentials, bucketURL *url.URL, endpoint string) (*objectStore, error) {
+	s := &objectStore{
+		client: client,
+		creds:  creds,
+		region: os.Getenv("AWS_REGION"),
+		bucket: bucketURL.Host,
+	}
+	if s.bucket == "" {
+		return nil, errors.Errorf("no bucket in %s", bucketURL)
+	}
+	switch bucketURL.Scheme {
+	case "s3":
+		if s.region == "" {
+			s.region = defaultRegion
+		}
+	case "gs":
+		s.region = gcsSigningScope
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+	default:
+		return nil, errors.Errorf("unsupported scheme %q, must be s3 or gs", bucketURL.Scheme)
+	}
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, errors.Errorf("invalid endpoint %q", endpoint)
+		}
+		s.endpoint = u
+	}
+	return s, nil
+}
+
+// objectURL returns the URL of the object with the given key
+func (s *objectStore) objectURL(key string) *url.URL {
+	var u url.URL
+	if s.endpoint == nil {
+		u = url.URL{
+			Scheme: "https",
+			Host:   fmt.Sprintf("%s.s3.%s.amazonaws.com", s.bucket, s.region),
+			Path:   "/" + key,
+		}
+	} else {
+		u = *s.endpoint
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	}
+	// Send the path encoded exactly as it is signed
+	u.RawPath = uriEncodePath(u.Path)
+	return &u
+}
+
+// put uploads body as the object with the given key, replacing any object
+// already there
+func (s *objectStore) put(ctx context.Context, key, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, body, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer logger.ErrorIfCalling(resp.Body.Close)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("could not put %s: got status %d: %s", key, resp.StatusCode, b)
+	}
+	return nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to req, signing
+// all of its headers, see
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *objectStore) sign(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncodePath(req.URL.Path),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	date := now.Format("20060102")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(amzDateFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.creds.SecretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.creds.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// uriEncodePath percent-encodes every byte of path but the unreserved
+// characters and slashes, as signatures require
+func uriEncodePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
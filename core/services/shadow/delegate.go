@@ -0,0 +1,22 @@
+package shadow
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// Delegate starts nothing for shadow jobs. They are run by the pipeline
+// runner each time the job they shadow runs, so they have no services of
+// their own.
+type Delegate struct{}
+
+func NewDelegate() *Delegate {
+	return &Delegate{}
+}
+
+func (d *Delegate) JobType() job.Type {
+	return job.Shadow
+}
+
+func (d *Delegate) ServicesForSpec(spec job.Job) ([]job.Service, error) {
+	return nil, nil
+}
@@ -0,0 +1,77 @@
+package shadow
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// sideEffectTaskTypes are the task types whose runs change something outside
+// of the run: they write to the job's key/value store, run queries, or call
+// external adapters, which may do anything with a request
+var sideEffectTaskTypes = map[pipeline.TaskType]bool{
+	pipeline.TaskTypeBridge:         true,
+	pipeline.TaskTypeGRPCBridge:     true,
+	pipeline.TaskTypeSQL:            true,
+	pipeline.TaskTypeKVSet:          true,
+	pipeline.TaskTypeDeltaThreshold: true,
+}
+
+// ValidatedShadowSpec validates a shadow spec that came from TOML. Shadows
+// run with every run of the job they shadow, so their pipelines may only use
+// tasks without side effects, and http tasks may only GET.
+func ValidatedShadowSpec(config *orm.Config, tomlString string) (job.Job, error) {
+	var j = job.Job{
+		Pipeline: *pipeline.NewTaskDAG(),
+	}
+	var spec job.ShadowSpec
+	tree, err := toml.Load(tomlString)
+	if err != nil {
+		return j, err
+	}
+	if config.JobSpecStrictTOML() {
+		if err = job.CheckUnknownKeys(tree, job.Shadow); err != nil {
+			return j, err
+		}
+	}
+	err = tree.Unmarshal(&j)
+	if err != nil {
+		return j, err
+	}
+	err = tree.Unmarshal(&spec)
+	if err != nil {
+		return j, err
+	}
+	j.ShadowSpec = &spec
+
+	if j.Type != job.Shadow {
+		return j, errors.Errorf("unsupported type %s", j.Type)
+	}
+	if j.SchemaVersion != uint32(1) {
+		return j, errors.Errorf("the only supported schema version is currently 1, got %d", j.SchemaVersion)
+	}
+	if spec.ShadowOfJobID <= 0 {
+		return j, errors.New("shadowOf must be the ID of the job to shadow")
+	}
+	tasks, err := j.Pipeline.TasksInDependencyOrder()
+	if err != nil {
+		return j, err
+	}
+	if len(tasks) == 0 {
+		return j, errors.New("shadow jobs must have an observationSource")
+	}
+	for _, task := range tasks {
+		if sideEffectTaskTypes[task.Type()] {
+			return j, errors.Errorf("task %s: shadow jobs cannot use %s tasks, which have side effects", task.DotID(), task.Type())
+		}
+		if httpTask, ok := task.(*pipeline.HTTPTask); ok && httpTask.Method != "" && !strings.EqualFold(httpTask.Method, http.MethodGet) {
+			return j, errors.Errorf("task %s: shadow jobs can only use http tasks with method GET, got %s", task.DotID(), httpTask.Method)
+		}
+	}
+	return j, nil
+}
@@ -0,0 +1,71 @@
+package shadow
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatedShadowSpec(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		s, err := ValidatedShadowSpec(orm.NewConfig(), `
+type              = "shadow"
+schemaVersion     = 1
+name              = "new data source mix"
+shadowOf          = 42
+observationSource = """
+    ds1          [type=http method=GET url="example.com" allowunrestrictednetworkaccess="true"];
+    ds1_parse    [type=jsonparse path="USD"];
+    ds1_multiply [type=multiply times=100];
+    ds1 -> ds1_parse -> ds1_multiply;
+"""
+`)
+		require.NoError(t, err)
+		require.NotNil(t, s.ShadowSpec)
+		assert.Equal(t, int32(42), s.ShadowSpec.ShadowOfJobID)
+		assert.Equal(t, "new data source mix", s.Name.ValueOrZero())
+	})
+
+	t.Run("missing shadowOf", func(t *testing.T) {
+		_, err := ValidatedShadowSpec(orm.NewConfig(), `
+type              = "shadow"
+schemaVersion     = 1
+observationSource = """
+    ds1 [type=http method=GET url="example.com"];
+"""
+`)
+		assert.EqualError(t, err, "shadowOf must be the ID of the job to shadow")
+	})
+
+	t.Run("missing observationSource", func(t *testing.T) {
+		_, err := ValidatedShadowSpec(orm.NewConfig(), `
+type          = "shadow"
+schemaVersion = 1
+shadowOf      = 42
+`)
+		assert.EqualError(t, err, "shadow jobs must have an observationSource")
+	})
+	t.Run("side effects", func(t *testing.T) {
+		for _, task := range []string{
+			`ds1 [type=bridge name="adapter"];`,
+			`ds1 [type=grpcbridge name="adapter"];`,
+			`ds1 [type=sql datasource="ro" query="SELECT 1"];`,
+			`ds1 [type=kvset key="answer"];`,
+			`ds1 [type=deltathreshold threshold=0.5];`,
+			`ds1 [type=http method=POST url="example.com"];`,
+		} {
+			_, err := ValidatedShadowSpec(orm.NewConfig(), `
+type              = "shadow"
+schemaVersion     = 1
+shadowOf          = 42
+observationSource = """
+    `+task+`
+"""
+`)
+			assert.Error(t, err, task)
+			assert.Contains(t, err.Error(), "task ds1: shadow jobs can", task)
+		}
+	})
+}
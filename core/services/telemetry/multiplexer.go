@@ -0,0 +1,141 @@
+package telemetry
+
+import (
+	"net/url"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/synchronization"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+)
+
+// MonitoringEndpointGenerator hands out the MonitoringEndpoint an OCR feed
+// sends its telemetry to
+type MonitoringEndpointGenerator interface {
+	// GenMonitoringEndpoint returns the endpoint for the feed at
+	// contractAddress, sending to ingressURL, or to the node's default
+	// endpoint if ingressURL is empty
+	GenMonitoringEndpoint(contractAddress models.EIP55Address, ingressURL string) (ocrtypes.MonitoringEndpoint, error)
+}
+
+// Ingress is a MonitoringEndpoint that must be started before it sends
+// anything
+type Ingress interface {
+	ocrtypes.MonitoringEndpoint
+	Start() error
+	Close() error
+}
+
+// IngressFactory makes the Ingress sending telemetry to ingressURL
+type IngressFactory func(ingressURL *url.URL) (Ingress, error)
+
+// Multiplexer is a MonitoringEndpointGenerator that lets each feed report to
+// its own telemetry ingress URL, e.g. to separate collectors for mainnet and
+// testnet feeds. An Ingress is made for each distinct URL the first time a
+// feed asks for it, and is shared by every feed using that URL until the
+// Multiplexer is closed.
+type Multiplexer struct {
+	utils.StartStopOnce
+	defaultEndpoint ocrtypes.MonitoringEndpoint
+	newIngress      IngressFactory
+
+	mu        sync.Mutex
+	ingresses map[string]Ingress
+}
+
+var _ MonitoringEndpointGenerator = &Multiplexer{}
+
+// NewMultiplexer returns a Multiplexer that sends telemetry from feeds
+// without their own ingress URL to defaultEndpoint
+func NewMultiplexer(defaultEndpoint ocrtypes.MonitoringEndpoint, newIngress IngressFactory) *Multiplexer {
+	return &Multiplexer{
+		defaultEndpoint: defaultEndpoint,
+		newIngress:      newIngress,
+		ingresses:       make(map[string]Ingress),
+	}
+}
+
+func (m *Multiplexer) Start() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.OkayToStart() {
+		return errors.New("cannot start already started telemetry multiplexer")
+	}
+	for _, ingress := range m.ingresses {
+		if err := ingress.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Multiplexer) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.OkayToStop() {
+		return errors.New("cannot close unstarted telemetry multiplexer")
+	}
+	var merr error
+	for _, ingress := range m.ingresses {
+		merr = multierr.Append(merr, ingress.Close())
+	}
+	return merr
+}
+
+func (m *Multiplexer) GenMonitoringEndpoint(contractAddress models.EIP55Address, ingressURL string) (ocrtypes.MonitoringEndpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ingressURL == "" {
+		return m.defaultEndpoint, nil
+	}
+	if ingress, exists := m.ingresses[ingressURL]; exists {
+		return ingress, nil
+	}
+
+	u, err := url.Parse(ingressURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid monitoring endpoint %s", ingressURL)
+	}
+	ingress, err := m.newIngress(u)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to make telemetry ingress for %s", ingressURL)
+	}
+	if m.State() == utils.StartStopOnce_Started {
+		if err := ingress.Start(); err != nil {
+			return nil, errors.Wrapf(err, "failed to start telemetry ingress for %s", ingressURL)
+		}
+	}
+	logger.Infow("Telemetry: sending OCR telemetry to a separate ingress", "url", ingressURL, "contractAddress", contractAddress)
+	m.ingresses[ingressURL] = ingress
+	return ingress, nil
+}
+
+// explorerIngress is an Ingress sending telemetry over its own explorer
+// client
+type explorerIngress struct {
+	*Agent
+	explorerClient synchronization.ExplorerClient
+}
+
+// NewExplorerIngress returns an Ingress that sends telemetry with agent,
+// starting and closing explorerClient along with it
+func NewExplorerIngress(explorerClient synchronization.ExplorerClient, agent *Agent) Ingress {
+	return &explorerIngress{agent, explorerClient}
+}
+
+func (i *explorerIngress) Start() error {
+	if err := i.explorerClient.Start(); err != nil {
+		return err
+	}
+	return i.Agent.Start()
+}
+
+func (i *explorerIngress) Close() error {
+	return multierr.Combine(i.Agent.Close(), i.explorerClient.Close())
+}
@@ -0,0 +1,68 @@
+package telemetry_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIngress struct {
+	telemetry.NoopAgent
+	url     string
+	started bool
+	closed  bool
+}
+
+func (i *fakeIngress) Start() error { i.started = true; return nil }
+func (i *fakeIngress) Close() error { i.closed = true; return nil }
+
+func TestMultiplexer(t *testing.T) {
+	t.Parallel()
+
+	defaultEndpoint := &telemetry.NoopAgent{}
+	var ingresses []*fakeIngress
+	multiplexer := telemetry.NewMultiplexer(defaultEndpoint, func(ingressURL *url.URL) (telemetry.Ingress, error) {
+		ingress := &fakeIngress{url: ingressURL.String()}
+		ingresses = append(ingresses, ingress)
+		return ingress, nil
+	})
+
+	feed1 := cltest.NewEIP55Address()
+	feed2 := cltest.NewEIP55Address()
+	feed3 := cltest.NewEIP55Address()
+
+	endpoint, err := multiplexer.GenMonitoringEndpoint(feed1, "")
+	require.NoError(t, err)
+	assert.Equal(t, defaultEndpoint, endpoint)
+
+	// Ingresses made before starting are started with the multiplexer
+	mainnet, err := multiplexer.GenMonitoringEndpoint(feed1, "wss://mainnet.example.com")
+	require.NoError(t, err)
+	require.Len(t, ingresses, 1)
+	assert.Equal(t, ingresses[0], mainnet)
+	assert.False(t, ingresses[0].started)
+
+	require.NoError(t, multiplexer.Start())
+	assert.True(t, ingresses[0].started)
+
+	// Feeds with the same URL share an ingress
+	endpoint, err = multiplexer.GenMonitoringEndpoint(feed2, "wss://mainnet.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, mainnet, endpoint)
+	require.Len(t, ingresses, 1)
+
+	testnet, err := multiplexer.GenMonitoringEndpoint(feed3, "wss://testnet.example.com")
+	require.NoError(t, err)
+	require.Len(t, ingresses, 2)
+	assert.Equal(t, ingresses[1], testnet)
+	assert.Equal(t, "wss://testnet.example.com", ingresses[1].url)
+	assert.True(t, ingresses[1].started)
+
+	require.NoError(t, multiplexer.Close())
+	assert.True(t, ingresses[0].closed)
+	assert.True(t, ingresses[1].closed)
+}
@@ -1,8 +1,20 @@
 package telemetry
 
+import (
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+)
+
 type NoopAgent struct {
 }
 
+var _ MonitoringEndpointGenerator = &NoopAgent{}
+
 // SendLog sends a telemetry log to the explorer
 func (t *NoopAgent) SendLog(log []byte) {
 }
+
+// GenMonitoringEndpoint returns the NoopAgent for every feed
+func (t *NoopAgent) GenMonitoringEndpoint(models.EIP55Address, string) (ocrtypes.MonitoringEndpoint, error) {
+	return t, nil
+}
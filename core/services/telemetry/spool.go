@@ -0,0 +1,207 @@
+package telemetry
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+const spoolFileName = "telemetry.spool"
+
+// ErrSpoolFull is returned when appending to a spool would take it over its
+// maximum size
+var ErrSpoolFull = errors.New("telemetry spool is full")
+
+// Spool is a bounded, append-only file of telemetry messages that could not
+// be sent. Each message is stored as a 4 byte big-endian length followed by
+// the message itself.
+//
+// Messages identical to one already in the spool are skipped, so that
+// telemetry which is retried while the explorer is unreachable is only
+// replayed once.
+type Spool struct {
+	path    string
+	maxSize int64
+
+	mu   sync.Mutex
+	size int64
+	seen map[[sha256.Size]byte]struct{}
+}
+
+// NewSpool opens the spool in dir, creating it if necessary. Any messages
+// left in it by a previous run are kept to be replayed.
+func NewSpool(dir string, maxSize uint64) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create telemetry spool directory")
+	}
+	s := &Spool{
+		path:    filepath.Join(dir, spoolFileName),
+		maxSize: int64(maxSize),
+		seen:    make(map[[sha256.Size]byte]struct{}),
+	}
+	msgs, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+	// Drops any incomplete message from an interrupted write, which would
+	// otherwise corrupt the messages appended after it
+	if err := s.rewrite(msgs); err != nil {
+		return nil, err
+	}
+	for _, msg := range msgs {
+		s.seen[sha256.Sum256(msg)] = struct{}{}
+		s.size += recordSize(msg)
+	}
+	return s, nil
+}
+
+// Append adds msg to the end of the spool. It returns false if msg was
+// skipped because it is already in the spool.
+func (s *Spool) Append(msg []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash := sha256.Sum256(msg)
+	if _, exists := s.seen[hash]; exists {
+		return false, nil
+	}
+	if s.size+recordSize(msg) > s.maxSize {
+		return false, ErrSpoolFull
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to open telemetry spool")
+	}
+	defer f.Close()
+	if err := writeRecord(f, msg); err != nil {
+		return false, errors.Wrap(err, "failed to write to telemetry spool")
+	}
+	s.seen[hash] = struct{}{}
+	s.size += recordSize(msg)
+	return true, nil
+}
+
+// Size returns the number of bytes in the spool
+func (s *Spool) Size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// Replay passes up to max of the oldest messages in the spool to send, in
+// the order they were appended, and removes them from the spool. It stops
+// early if send returns false, leaving that message in the spool. It returns
+// the number of messages removed.
+func (s *Spool) Replay(max int, send func(msg []byte) bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size == 0 {
+		return 0, nil
+	}
+	msgs, err := s.readAll()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for sent < len(msgs) && sent < max && send(msgs[sent]) {
+		sent++
+	}
+	if sent == 0 {
+		return 0, nil
+	}
+	if err := s.rewrite(msgs[sent:]); err != nil {
+		return 0, err
+	}
+	for _, msg := range msgs[:sent] {
+		delete(s.seen, sha256.Sum256(msg))
+		s.size -= recordSize(msg)
+	}
+	return sent, nil
+}
+
+func (s *Spool) readAll() ([][]byte, error) {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to open telemetry spool")
+	}
+	defer f.Close()
+
+	var msgs [][]byte
+	r := bufio.NewReader(f)
+	for {
+		var n uint32
+		err := binary.Read(r, binary.BigEndian, &n)
+		var msg []byte
+		if err == nil {
+			// No message longer than the spool's maximum size is ever
+			// appended, so a longer length can only be corruption
+			if int64(n) > s.maxSize-4 {
+				logger.Warnw("Telemetry: spool is corrupt, dropping the messages after the last valid one", "length", n, "kept", len(msgs))
+				return msgs, nil
+			}
+			msg = make([]byte, n)
+			_, err = io.ReadFull(r, msg)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			// Anything after the last complete message is from an
+			// interrupted write
+			return msgs, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to read telemetry spool")
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+// rewrite atomically replaces the contents of the spool with msgs, so that a
+// crash part way through replaying loses no messages. At worst, the messages
+// sent in that replay are sent again after restarting.
+func (s *Spool) rewrite(msgs [][]byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(s.path), spoolFileName+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create telemetry spool")
+	}
+	defer os.Remove(f.Name())
+
+	w := bufio.NewWriter(f)
+	for _, msg := range msgs {
+		if err := writeRecord(w, msg); err != nil {
+			f.Close()
+			return errors.Wrap(err, "failed to write telemetry spool")
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to write telemetry spool")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to write telemetry spool")
+	}
+	return errors.Wrap(os.Rename(f.Name(), s.path), "failed to replace telemetry spool")
+}
+
+func writeRecord(w io.Writer, msg []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(msg))); err != nil {
+		return err
+	}
+	_, err := w.Write(msg)
+	return err
+}
+
+func recordSize(msg []byte) int64 {
+	return int64(4 + len(msg))
+}
@@ -2,21 +2,143 @@ package telemetry
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/synchronization"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// replayBatchSize is the most spooled messages replayed at once. It is well
+// under the explorer client's send buffer, so that replaying doesn't crowd
+// out live telemetry.
+const replayBatchSize = synchronization.SendBufferSize / 4
+
+var (
+	promTelemetrySpooled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "telemetry_spooled_total",
+		Help: "The total number of telemetry messages spooled to disk while the explorer was unreachable",
+	})
+	promTelemetryReplayed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "telemetry_replayed_total",
+		Help: "The total number of spooled telemetry messages replayed to the explorer",
+	})
+	promTelemetryDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "telemetry_dropped_total",
+		Help: "The total number of telemetry messages dropped because the spool was full or could not be written",
+	})
+	promTelemetrySpoolSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "telemetry_spool_size_bytes",
+		Help: "The size of the telemetry spool",
+	})
 )
 
 type Agent struct {
+	utils.StartStopOnce
 	explorerClient synchronization.ExplorerClient
+	spool          *Spool
+	replayInterval time.Duration
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
 }
 
 // NewAgent returns a Agent which is just a thin wrapper over
 // the explorerClient for now
 func NewAgent(explorerClient synchronization.ExplorerClient) *Agent {
-	return &Agent{explorerClient}
+	return &Agent{explorerClient: explorerClient, chStop: make(chan struct{})}
+}
+
+// NewSpoolingAgent returns an Agent which, while the explorer is
+// unreachable, writes telemetry to spool instead of dropping it, and replays
+// it every replayInterval once the explorer is reachable again.
+func NewSpoolingAgent(explorerClient synchronization.ExplorerClient, spool *Spool, replayInterval time.Duration) *Agent {
+	return &Agent{
+		explorerClient: explorerClient,
+		spool:          spool,
+		replayInterval: replayInterval,
+		chStop:         make(chan struct{}),
+	}
+}
+
+func (t *Agent) Start() error {
+	if !t.OkayToStart() {
+		return errors.New("cannot start already started telemetry agent")
+	}
+	if t.spool != nil {
+		promTelemetrySpoolSize.Set(float64(t.spool.Size()))
+		t.wg.Add(1)
+		go t.replayLoop()
+	}
+	return nil
+}
+
+func (t *Agent) Close() error {
+	if !t.OkayToStop() {
+		return errors.New("cannot close unstarted telemetry agent")
+	}
+	close(t.chStop)
+	t.wg.Wait()
+	return nil
 }
 
 // SendLog sends a telemetry log to the explorer
 func (t *Agent) SendLog(log []byte) {
+	if t.spool != nil && t.explorerClient.Status() != synchronization.ConnectionStatusConnected {
+		t.spoolLog(log)
+		return
+	}
 	t.explorerClient.Send(context.Background(), log, synchronization.ExplorerBinaryMessage)
 }
+
+func (t *Agent) spoolLog(log []byte) {
+	spooled, err := t.spool.Append(log)
+	if err != nil {
+		promTelemetryDropped.Inc()
+		logger.Warnw("Telemetry: explorer is unreachable and telemetry could not be spooled, dropping it", "err", err)
+		return
+	}
+	if spooled {
+		promTelemetrySpooled.Inc()
+		promTelemetrySpoolSize.Set(float64(t.spool.Size()))
+	}
+}
+
+func (t *Agent) replayLoop() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.replayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.replay()
+		case <-t.chStop:
+			return
+		}
+	}
+}
+
+// replay sends the next batch of spooled telemetry, if the explorer is
+// reachable
+func (t *Agent) replay() {
+	if t.spool.Size() == 0 || t.explorerClient.Status() != synchronization.ConnectionStatusConnected {
+		return
+	}
+	n, err := t.spool.Replay(replayBatchSize, func(msg []byte) bool {
+		if t.explorerClient.Status() != synchronization.ConnectionStatusConnected {
+			return false
+		}
+		t.explorerClient.Send(context.Background(), msg, synchronization.ExplorerBinaryMessage)
+		return true
+	})
+	if err != nil {
+		logger.Errorw("Telemetry: failed to replay spooled telemetry", "err", err)
+	}
+	promTelemetryReplayed.Add(float64(n))
+	promTelemetrySpoolSize.Set(float64(t.spool.Size()))
+}
@@ -0,0 +1,161 @@
+package telemetry_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/smartcontractkit/chainlink/core/services/synchronization"
+	"github.com/smartcontractkit/chainlink/core/services/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExplorerClient struct {
+	synchronization.NoopExplorerClient
+	mu        sync.Mutex
+	connected bool
+	sent      []string
+}
+
+func (c *fakeExplorerClient) Status() synchronization.ConnectionStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connected {
+		return synchronization.ConnectionStatusConnected
+	}
+	return synchronization.ConnectionStatusDisconnected
+}
+
+func (c *fakeExplorerClient) Send(_ context.Context, data []byte, _ ...int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, string(data))
+}
+
+func (c *fakeExplorerClient) setConnected(connected bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.connected = connected
+}
+
+func (c *fakeExplorerClient) sentMessages() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.sent...)
+}
+
+func newSpoolDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "telemetry-spool")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestSpool(t *testing.T) {
+	t.Parallel()
+
+	dir := newSpoolDir(t)
+	spool, err := telemetry.NewSpool(dir, 24)
+	require.NoError(t, err)
+
+	spooled, err := spool.Append([]byte("round 1"))
+	require.NoError(t, err)
+	assert.True(t, spooled)
+
+	// Duplicates are skipped
+	spooled, err = spool.Append([]byte("round 1"))
+	require.NoError(t, err)
+	assert.False(t, spooled)
+
+	spooled, err = spool.Append([]byte("round 2"))
+	require.NoError(t, err)
+	assert.True(t, spooled)
+	assert.Equal(t, int64(22), spool.Size())
+
+	_, err = spool.Append([]byte("round 3"))
+	assert.Equal(t, telemetry.ErrSpoolFull, err)
+
+	// Spooled messages survive a restart
+	spool, err = telemetry.NewSpool(dir, 24)
+	require.NoError(t, err)
+	assert.Equal(t, int64(22), spool.Size())
+
+	var replayed []string
+	n, err := spool.Replay(1, func(msg []byte) bool {
+		replayed = append(replayed, string(msg))
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"round 1"}, replayed)
+
+	n, err = spool.Replay(10, func(msg []byte) bool { return false })
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = spool.Replay(10, func(msg []byte) bool {
+		replayed = append(replayed, string(msg))
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"round 1", "round 2"}, replayed)
+	assert.Equal(t, int64(0), spool.Size())
+}
+
+func TestSpool_Corrupt(t *testing.T) {
+	t.Parallel()
+
+	dir := newSpoolDir(t)
+	spool, err := telemetry.NewSpool(dir, 1024)
+	require.NoError(t, err)
+	_, err = spool.Append([]byte("round 1"))
+	require.NoError(t, err)
+
+	// A length far longer than the spool could hold is followed by garbage
+	f, err := os.OpenFile(filepath.Join(dir, "telemetry.spool"), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0xff, 0xff, 0xff, 0xff, 'x'})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	spool, err = telemetry.NewSpool(dir, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), spool.Size())
+
+	var replayed []string
+	_, err = spool.Replay(10, func(msg []byte) bool {
+		replayed = append(replayed, string(msg))
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"round 1"}, replayed)
+}
+
+func TestAgent_SpoolsWhileDisconnected(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	explorerClient := &fakeExplorerClient{}
+	spool, err := telemetry.NewSpool(newSpoolDir(t), 1024)
+	require.NoError(t, err)
+	agent := telemetry.NewSpoolingAgent(explorerClient, spool, 10*time.Millisecond)
+	require.NoError(t, agent.Start())
+	defer agent.Close()
+
+	agent.SendLog([]byte("round 1"))
+	agent.SendLog([]byte("round 2"))
+	assert.Empty(t, explorerClient.sentMessages())
+
+	explorerClient.setConnected(true)
+	agent.SendLog([]byte("round 3"))
+
+	g.Eventually(explorerClient.sentMessages).Should(gomega.Equal([]string{"round 3", "round 1", "round 2"}))
+	assert.Equal(t, int64(0), spool.Size())
+}
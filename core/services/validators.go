@@ -1,9 +1,11 @@
 package services
 
 import (
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -56,7 +58,9 @@ func ValidateBridgeTypeNotExist(bt *models.BridgeTypeRequest, store *store.Store
 }
 
 // ValidateBridgeType checks that the bridge type doesn't have a duplicate
-// or invalid name or invalid url
+// or invalid name or invalid url. Bridges with a gRPC target need no url.
+// The environment variables referenced by the url's ${NAME} placeholders
+// must be set, and start with models.BridgeURLEnvPrefix.
 func ValidateBridgeType(bt *models.BridgeTypeRequest, store *store.Store) error {
 	fe := models.NewJSONAPIErrors()
 	if len(bt.Name.String()) < 1 {
@@ -66,9 +70,23 @@ func ValidateBridgeType(bt *models.BridgeTypeRequest, store *store.Store) error
 		fe.Merge(err)
 	}
 	u := bt.URL.String()
-	if len(strings.TrimSpace(u)) == 0 {
+	if len(strings.TrimSpace(u)) == 0 && len(strings.TrimSpace(bt.GRPCTarget)) == 0 {
 		fe.Add("URL must be present")
 	}
+	for _, name := range bt.URL.EnvVars() {
+		if !strings.HasPrefix(name, models.BridgeURLEnvPrefix) {
+			fe.Add(fmt.Sprintf("URL references environment variable %s, which does not start with %s", name, models.BridgeURLEnvPrefix))
+		} else if _, ok := os.LookupEnv(name); !ok {
+			fe.Add(fmt.Sprintf("URL references environment variable %s, which is not set", name))
+		}
+	}
+	if bt.GRPCTLSCACert != "" {
+		if !bt.GRPCTLS {
+			fe.Add("GRPCTLSCACert requires GRPCTLS")
+		} else if !x509.NewCertPool().AppendCertsFromPEM([]byte(bt.GRPCTLSCACert)) {
+			fe.Add("GRPCTLSCACert must be a PEM encoded certificate")
+		}
+	}
 	if bt.MinimumContractPayment != nil &&
 		bt.MinimumContractPayment.Cmp(assets.NewLink(0)) < 0 {
 		fe.Add("MinimumContractPayment must be positive")
@@ -300,10 +318,16 @@ func validateRandomnessLogInitiator(i models.Initiator, j models.JobSpec) error
 }
 
 func validateTask(task models.TaskSpec, store *store.Store) error {
+	if !store.Config.TaskTypeAllowed(task.Type.String()) {
+		return errors.Errorf("task type %s is not allowed on this node", task.Type)
+	}
 	adapter, err := adapters.For(task, store.Config, store.ORM)
 	if err != nil {
 		return err
 	}
+	if _, ok := adapter.BaseAdapter.(*adapters.Bridge); ok && !store.Config.TaskTypeAllowed("bridge") {
+		return errors.Errorf("task type %s is a bridge, and bridges are not allowed on this node", task.Type)
+	}
 	if !store.Config.EnableExperimentalAdapters() {
 		if _, ok := adapter.BaseAdapter.(*adapters.Sleep); ok {
 			return errors.New("Sleep Adapter is not implemented yet")
@@ -3,6 +3,7 @@ package services_test
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -123,6 +124,25 @@ func TestValidateJob_RejectsSleepAdapterWhenExperimentalAdaptersAreDisabled(t *t
 	assert.Error(t, services.ValidateJob(sleepingJob, store))
 }
 
+func TestValidateJob_RejectsDisallowedTaskTypes(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = append(job.Tasks, models.TaskSpec{Type: adapters.TaskTypeEthTx})
+	require.NoError(t, services.ValidateJob(job, store))
+
+	store.Config.Set("DISALLOWED_TASK_TYPES", "sql,EthTx")
+	assert.Equal(t, models.NewJSONAPIErrorsWith("task type ethtx is not allowed on this node"), services.ValidateJob(job, store))
+
+	store.Config.Set("DISALLOWED_TASK_TYPES", "")
+	store.Config.Set("ALLOWED_TASK_TYPES", "noop")
+	assert.Equal(t, models.NewJSONAPIErrorsWith("task type ethtx is not allowed on this node"), services.ValidateJob(job, store))
+
+	store.Config.Set("ALLOWED_TASK_TYPES", "noop,ethtx")
+	assert.NoError(t, services.ValidateJob(job, store))
+}
+
 func TestValidateBridgeType(t *testing.T) {
 	t.Parallel()
 
@@ -157,6 +177,25 @@ func TestValidateBridgeType(t *testing.T) {
 			},
 			models.NewJSONAPIErrorsWith("URL must be present"),
 		},
+		{
+			"valid with grpc target and blank url",
+			models.BridgeTypeRequest{
+				Name:       "grpcadapter",
+				URL:        cltest.WebURL(t, ""),
+				GRPCTarget: "localhost:8081",
+			},
+			nil,
+		},
+		{
+			"invalid grpc CA cert",
+			models.BridgeTypeRequest{
+				Name:          "grpcadapter",
+				GRPCTarget:    "localhost:8081",
+				GRPCTLS:       true,
+				GRPCTLSCACert: "not a certificate",
+			},
+			models.NewJSONAPIErrorsWith("GRPCTLSCACert must be a PEM encoded certificate"),
+		},
 		{
 			"valid url",
 			models.BridgeTypeRequest{
@@ -165,6 +204,14 @@ func TestValidateBridgeType(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"valid unix socket url",
+			models.BridgeTypeRequest{
+				Name: "adapterwithunixsocket",
+				URL:  cltest.WebURL(t, "unix:///var/run/adapter.sock"),
+			},
+			nil,
+		},
 		{
 			"valid docker url",
 			models.BridgeTypeRequest{
@@ -206,8 +253,35 @@ func TestValidateBridgeType(t *testing.T) {
 				URL:  cltest.WebURL(t, "https://denergy.eth"),
 			},
 			nil,
+		},
+		{
+			"url referencing a set environment variable",
+			models.BridgeTypeRequest{
+				Name: "adapterwithenv",
+				URL:  cltest.WebURL(t, "https://denergy.eth/?key=${CL_BRIDGE_VALIDATE_KEY}"),
+			},
+			nil,
+		},
+		{
+			"url referencing a node secret",
+			models.BridgeTypeRequest{
+				Name: "adapterwithenv",
+				URL:  cltest.WebURL(t, "https://denergy.eth/?key=${DATABASE_URL}"),
+			},
+			models.NewJSONAPIErrorsWith("URL references environment variable DATABASE_URL, which does not start with CL_BRIDGE_"),
+		},
+		{
+			"url referencing an unset environment variable",
+			models.BridgeTypeRequest{
+				Name: "adapterwithenv",
+				URL:  cltest.WebURL(t, "https://denergy.eth/${CL_BRIDGE_VALIDATE_UNSET}/?key=${CL_BRIDGE_VALIDATE_KEY}"),
+			},
+			models.NewJSONAPIErrorsWith("URL references environment variable CL_BRIDGE_VALIDATE_UNSET, which is not set"),
 		}}
 
+	os.Setenv("CL_BRIDGE_VALIDATE_KEY", "key")
+	defer os.Unsetenv("CL_BRIDGE_VALIDATE_KEY")
+
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
 			result := services.ValidateBridgeType(&test.request, store)
@@ -31,12 +31,14 @@ type KeyStoreInterface interface {
 	HasAccountWithAddress(common.Address) bool
 	NewAccount() (accounts.Account, error)
 	Import(keyJSON []byte, oldPassword string) (accounts.Account, error)
+	Restore(keyJSON []byte) (accounts.Account, error)
 	Export(address common.Address, newPassword string) ([]byte, error)
 	Delete(address common.Address) error
 	GetAccounts() []accounts.Account
 	GetAccountByAddress(common.Address) (accounts.Account, error)
 
 	SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignHash(account accounts.Account, hash []byte) ([]byte, error)
 }
 
 // KeyStore manages a key storage directory on disk.
@@ -134,6 +136,15 @@ func (ks *KeyStore) Import(keyJSON []byte, oldPassword string) (accounts.Account
 	return acct, err
 }
 
+// Restore imports a key file that was encrypted by this keystore, such as
+// one that was archived when the key was deleted
+func (ks *KeyStore) Restore(keyJSON []byte) (accounts.Account, error) {
+	if ks.password == "" {
+		return accounts.Account{}, ErrKeyStoreLocked
+	}
+	return ks.Import(keyJSON, ks.password)
+}
+
 func (ks *KeyStore) Export(address common.Address, newPassword string) ([]byte, error) {
 	if ks.password == "" {
 		return nil, ErrKeyStoreLocked
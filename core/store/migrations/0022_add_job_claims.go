@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up22 = `
+		CREATE TABLE job_claims (
+			job_id integer PRIMARY KEY REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE,
+			node_id text NOT NULL,
+			claimed_at timestamptz NOT NULL,
+			last_heartbeat_at timestamptz NOT NULL
+		);
+		CREATE INDEX idx_job_claims_node_id ON job_claims (node_id);
+	`
+
+	down22 = `DROP TABLE job_claims;`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0022_add_job_claims",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up22).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down22).Error
+		},
+	})
+}
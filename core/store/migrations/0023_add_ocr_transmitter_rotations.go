@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up23 = `
+		CREATE TABLE ocr_transmitter_rotations (
+			id BIGSERIAL PRIMARY KEY,
+			job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			old_address bytea NOT NULL,
+			new_address bytea NOT NULL,
+			funding_amount numeric(78, 0) NOT NULL DEFAULT 0,
+			funding_eth_tx_id bigint REFERENCES eth_txes (id) ON DELETE SET NULL,
+			state text NOT NULL,
+			error text,
+			created_at timestamptz NOT NULL,
+			updated_at timestamptz NOT NULL,
+			CONSTRAINT chk_old_address_length CHECK (octet_length(old_address) = 20),
+			CONSTRAINT chk_new_address_length CHECK (octet_length(new_address) = 20),
+			CONSTRAINT chk_addresses_differ CHECK (old_address != new_address)
+		);
+		CREATE UNIQUE INDEX idx_ocr_transmitter_rotations_active_job_id ON ocr_transmitter_rotations (job_id) WHERE state NOT IN ('complete', 'failed');
+	`
+
+	down23 = `DROP TABLE ocr_transmitter_rotations;`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0023_add_ocr_transmitter_rotations",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up23).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down23).Error
+		},
+	})
+}
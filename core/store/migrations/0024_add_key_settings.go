@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up24 = `
+		ALTER TABLE keys
+			ADD COLUMN chain_id numeric(78, 0),
+			ADD COLUMN max_gas_price_wei numeric(78, 0),
+			ADD COLUMN max_in_flight_transactions integer,
+			ADD COLUMN is_transmitter boolean NOT NULL DEFAULT FALSE,
+			ADD CONSTRAINT chk_max_gas_price_wei CHECK (max_gas_price_wei > 0),
+			ADD CONSTRAINT chk_max_in_flight_transactions CHECK (max_in_flight_transactions > 0),
+			ADD CONSTRAINT chk_single_role CHECK (NOT (is_funding AND is_transmitter));
+	`
+
+	down24 = `
+		ALTER TABLE keys
+			DROP COLUMN chain_id,
+			DROP COLUMN max_gas_price_wei,
+			DROP COLUMN max_in_flight_transactions,
+			DROP COLUMN is_transmitter;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0024_add_key_settings",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up24).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down24).Error
+		},
+	})
+}
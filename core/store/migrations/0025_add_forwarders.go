@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up25 = `
+		CREATE TABLE forwarders (
+			id BIGSERIAL PRIMARY KEY,
+			address bytea NOT NULL UNIQUE,
+			created_at timestamptz NOT NULL,
+			updated_at timestamptz NOT NULL,
+			CONSTRAINT chk_address_length CHECK (octet_length(address) = 20)
+		);
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN forwarder_address bytea REFERENCES forwarders (address) ON DELETE RESTRICT;
+	`
+
+	down25 = `
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN forwarder_address;
+		DROP TABLE forwarders;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0025_add_forwarders",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up25).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down25).Error
+		},
+	})
+}
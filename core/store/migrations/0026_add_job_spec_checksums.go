@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up26 = `
+		ALTER TABLE jobs ADD COLUMN spec_checksum text;
+	`
+
+	down26 = `
+		ALTER TABLE jobs DROP COLUMN spec_checksum;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0026_add_job_spec_checksums",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up26).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down26).Error
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up27 = `
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN decimals smallint CHECK (decimals >= 0);
+	`
+
+	down27 = `
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN decimals;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0027_add_ocr_decimals",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up27).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down27).Error
+		},
+	})
+}
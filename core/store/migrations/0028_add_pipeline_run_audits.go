@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up28 = `
+		ALTER TABLE pipeline_runs ADD COLUMN audit jsonb;
+	`
+
+	down28 = `
+		ALTER TABLE pipeline_runs DROP COLUMN audit;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0028_add_pipeline_run_audits",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up28).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down28).Error
+		},
+	})
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up29 = `
+		ALTER TABLE users
+			ADD COLUMN totp_secret text NOT NULL DEFAULT '',
+			ADD COLUMN totp_enabled boolean NOT NULL DEFAULT false,
+			ADD COLUMN totp_last_counter bigint NOT NULL DEFAULT 0,
+			ADD COLUMN recovery_code_hashes text[];
+	`
+
+	down29 = `
+		ALTER TABLE users
+			DROP COLUMN totp_secret,
+			DROP COLUMN totp_enabled,
+			DROP COLUMN totp_last_counter,
+			DROP COLUMN recovery_code_hashes;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0029_add_user_totp",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up29).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down29).Error
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up30 = `
+		CREATE TABLE column_encryption (
+			id integer PRIMARY KEY CHECK (id = 1),
+			salt bytea NOT NULL,
+			key_check text NOT NULL,
+			created_at timestamptz NOT NULL
+		);
+	`
+
+	down30 = `
+		DROP TABLE column_encryption;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0030_add_column_encryption",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up30).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down30).Error
+		},
+	})
+}
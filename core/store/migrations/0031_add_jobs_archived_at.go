@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up31 = `
+		ALTER TABLE jobs ADD COLUMN archived_at timestamptz;
+		CREATE INDEX idx_jobs_archived_at ON jobs (archived_at) WHERE archived_at IS NOT NULL;
+
+		CREATE TRIGGER notify_job_archived
+			AFTER UPDATE OF archived_at ON jobs
+			FOR EACH ROW WHEN (OLD.archived_at IS NULL AND NEW.archived_at IS NOT NULL)
+			EXECUTE PROCEDURE notifyjobdeleted();
+	`
+
+	down31 = `
+		DROP TRIGGER notify_job_archived ON jobs;
+		DROP INDEX idx_jobs_archived_at;
+		ALTER TABLE jobs DROP COLUMN archived_at;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0031_add_jobs_archived_at",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up31).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down31).Error
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up32 = `
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN p2p_peers text[];
+	`
+
+	down32 = `
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN p2p_peers;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0032_add_ocr_p2p_peers",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up32).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down32).Error
+		},
+	})
+}
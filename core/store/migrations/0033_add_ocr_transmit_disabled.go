@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up33 = `
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN transmit_disabled boolean NOT NULL DEFAULT false;
+	`
+
+	down33 = `
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN transmit_disabled;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0033_add_ocr_transmit_disabled",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up33).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down33).Error
+		},
+	})
+}
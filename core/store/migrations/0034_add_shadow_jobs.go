@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up34 = `
+		CREATE TABLE shadow_specs (
+			id BIGSERIAL PRIMARY KEY,
+			shadow_of_job_id INT NOT NULL REFERENCES jobs(id),
+			created_at timestamp with time zone NOT NULL,
+			updated_at timestamp with time zone NOT NULL
+		);
+
+		CREATE INDEX idx_shadow_specs_shadow_of_job_id ON shadow_specs(shadow_of_job_id);
+
+		ALTER TABLE jobs ADD COLUMN shadow_spec_id INT REFERENCES shadow_specs(id),
+		DROP CONSTRAINT chk_only_one_spec,
+		ADD CONSTRAINT chk_only_one_spec CHECK (
+			num_nonnulls(offchainreporting_oracle_spec_id, direct_request_spec_id, flux_monitor_spec_id, keeper_spec_id, shadow_spec_id) = 1
+		);
+
+		CREATE UNIQUE INDEX idx_jobs_unique_shadow_spec_id ON jobs(shadow_spec_id);
+
+		CREATE TABLE pipeline_shadow_comparisons (
+			id BIGSERIAL PRIMARY KEY,
+			primary_run_id BIGINT NOT NULL REFERENCES pipeline_runs(id) ON DELETE CASCADE,
+			shadow_run_id BIGINT NOT NULL REFERENCES pipeline_runs(id) ON DELETE CASCADE,
+			delta numeric(78,18),
+			primary_latency BIGINT NOT NULL,
+			shadow_latency BIGINT NOT NULL,
+			created_at timestamp with time zone NOT NULL
+		);
+
+		CREATE INDEX idx_pipeline_shadow_comparisons_primary_run_id ON pipeline_shadow_comparisons(primary_run_id);
+		CREATE INDEX idx_pipeline_shadow_comparisons_shadow_run_id ON pipeline_shadow_comparisons(shadow_run_id);
+	`
+
+	down34 = `
+		DROP TABLE pipeline_shadow_comparisons;
+		DELETE FROM jobs WHERE shadow_spec_id IS NOT NULL;
+		ALTER TABLE jobs DROP CONSTRAINT chk_only_one_spec,
+		ADD CONSTRAINT chk_only_one_spec CHECK (
+			num_nonnulls(offchainreporting_oracle_spec_id, direct_request_spec_id, flux_monitor_spec_id, keeper_spec_id) = 1
+		);
+		ALTER TABLE jobs DROP COLUMN shadow_spec_id;
+		DROP TABLE shadow_specs;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0034_add_shadow_jobs",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up34).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down34).Error
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up35 = `
+		CREATE TABLE datasources (
+			id BIGSERIAL PRIMARY KEY,
+			name text NOT NULL UNIQUE,
+			connection_string text NOT NULL,
+			created_at timestamp with time zone NOT NULL,
+			updated_at timestamp with time zone NOT NULL
+		);
+	`
+
+	down35 = `
+		DROP TABLE datasources;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0035_add_datasources",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up35).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down35).Error
+		},
+	})
+}
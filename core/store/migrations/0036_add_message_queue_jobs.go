@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up36 = `
+		CREATE TABLE message_queue_specs (
+			id BIGSERIAL PRIMARY KEY,
+			url text NOT NULL,
+			topic text NOT NULL,
+			consumer_group text NOT NULL DEFAULT '',
+			created_at timestamp with time zone NOT NULL,
+			updated_at timestamp with time zone NOT NULL
+		);
+
+		ALTER TABLE jobs ADD COLUMN message_queue_spec_id INT REFERENCES message_queue_specs(id),
+		DROP CONSTRAINT chk_only_one_spec,
+		ADD CONSTRAINT chk_only_one_spec CHECK (
+			num_nonnulls(offchainreporting_oracle_spec_id, direct_request_spec_id, flux_monitor_spec_id, keeper_spec_id, shadow_spec_id, message_queue_spec_id) = 1
+		);
+
+		CREATE UNIQUE INDEX idx_jobs_unique_message_queue_spec_id ON jobs(message_queue_spec_id);
+	`
+
+	down36 = `
+		DELETE FROM jobs WHERE message_queue_spec_id IS NOT NULL;
+		ALTER TABLE jobs DROP CONSTRAINT chk_only_one_spec,
+		ADD CONSTRAINT chk_only_one_spec CHECK (
+			num_nonnulls(offchainreporting_oracle_spec_id, direct_request_spec_id, flux_monitor_spec_id, keeper_spec_id, shadow_spec_id) = 1
+		);
+		ALTER TABLE jobs DROP COLUMN message_queue_spec_id;
+		DROP TABLE message_queue_specs;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0036_add_message_queue_jobs",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up36).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down36).Error
+		},
+	})
+}
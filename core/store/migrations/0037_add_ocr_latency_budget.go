@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up37 = `
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN latency_budget boolean NOT NULL DEFAULT false;
+	`
+
+	down37 = `
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN latency_budget;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0037_add_ocr_latency_budget",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up37).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down37).Error
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up38 = `
+		ALTER TABLE jobs ADD COLUMN meta_schema text;
+	`
+
+	down38 = `
+		ALTER TABLE jobs DROP COLUMN meta_schema;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0038_add_job_meta_schema",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up38).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down38).Error
+		},
+	})
+}
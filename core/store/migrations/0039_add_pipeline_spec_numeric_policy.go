@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up39 = `
+		ALTER TABLE pipeline_specs ADD COLUMN numeric_policy jsonb NOT NULL DEFAULT '{}';
+	`
+
+	down39 = `
+		ALTER TABLE pipeline_specs DROP COLUMN numeric_policy;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0039_add_pipeline_spec_numeric_policy",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up39).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down39).Error
+		},
+	})
+}
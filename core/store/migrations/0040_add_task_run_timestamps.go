@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up40 = `
+		ALTER TABLE pipeline_task_runs ADD COLUMN started_at timestamptz, ADD COLUMN queue_wait bigint NOT NULL DEFAULT 0;
+	`
+
+	down40 = `
+		ALTER TABLE pipeline_task_runs DROP COLUMN started_at, DROP COLUMN queue_wait;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0040_add_task_run_timestamps",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up40).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down40).Error
+		},
+	})
+}
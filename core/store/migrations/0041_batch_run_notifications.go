@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// Runs created in a batch notify once for the whole batch, so the
+	// per-run notification is skipped while chainlink.batch_run_insert is on
+	up41 = `
+		CREATE OR REPLACE FUNCTION public.notifypipelinerunstarted() RETURNS trigger
+			LANGUAGE plpgsql
+			AS $$
+			BEGIN
+				IF NEW.finished_at IS NULL AND current_setting('chainlink.batch_run_insert', true) IS DISTINCT FROM 'on' THEN
+					PERFORM pg_notify('pipeline_run_started', NEW.id::text);
+				END IF;
+				RETURN NEW;
+			END
+			$$;
+	`
+
+	down41 = `
+		CREATE OR REPLACE FUNCTION public.notifypipelinerunstarted() RETURNS trigger
+			LANGUAGE plpgsql
+			AS $$
+			BEGIN
+				IF NEW.finished_at IS NULL THEN
+					PERFORM pg_notify('pipeline_run_started', NEW.id::text);
+				END IF;
+				RETURN NEW;
+			END
+			$$;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0041_batch_run_notifications",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up41).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down41).Error
+		},
+	})
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up42 = `
+		ALTER TABLE pipeline_runs ADD COLUMN dedup_key text;
+		CREATE UNIQUE INDEX idx_pipeline_runs_unique_dedup_key ON pipeline_runs (dedup_key) WHERE dedup_key IS NOT NULL;
+	`
+
+	down42 = `
+		DROP INDEX idx_pipeline_runs_unique_dedup_key;
+		ALTER TABLE pipeline_runs DROP COLUMN dedup_key;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0042_add_pipeline_run_dedup_key",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up42).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down42).Error
+		},
+	})
+}
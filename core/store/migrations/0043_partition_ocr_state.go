@@ -0,0 +1,158 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up43 = `
+		CREATE SCHEMA IF NOT EXISTS ocr_archive;
+
+		-- Creates the monthly partition of parent that holds ts, moving any of
+		-- its rows out of the default partition
+		CREATE OR REPLACE FUNCTION ocr_create_monthly_partition(parent text, partition_key text, ts timestamptz) RETURNS void AS $$
+		DECLARE
+			month_start timestamptz := date_trunc('month', ts AT TIME ZONE 'UTC') AT TIME ZONE 'UTC';
+			month_end timestamptz := (date_trunc('month', ts AT TIME ZONE 'UTC') + interval '1 month') AT TIME ZONE 'UTC';
+			partition text := parent || to_char(month_start AT TIME ZONE 'UTC', '"_y"YYYY"m"MM');
+		BEGIN
+			IF to_regclass(partition) IS NOT NULL THEN
+				RETURN;
+			END IF;
+			EXECUTE format('CREATE TABLE %I (LIKE %I INCLUDING DEFAULTS INCLUDING CONSTRAINTS)', partition, parent);
+			EXECUTE format('WITH moved AS (DELETE FROM %I WHERE %I >= %L AND %I < %L RETURNING *) INSERT INTO %I SELECT * FROM moved',
+				parent || '_default', partition_key, month_start, partition_key, month_end, partition);
+			EXECUTE format('ALTER TABLE %I ATTACH PARTITION %I FOR VALUES FROM (%L) TO (%L)', parent, partition, month_start, month_end);
+		END
+		$$ LANGUAGE plpgsql;
+
+		ALTER TABLE offchainreporting_persistent_states RENAME TO old_persistent_states;
+		ALTER INDEX offchainreporting_persistent_states_pkey RENAME TO old_persistent_states_pkey;
+		ALTER TABLE offchainreporting_pending_transmissions RENAME TO old_pending_transmissions;
+		ALTER INDEX offchainreporting_pending_transmissions_pkey RENAME TO old_pending_transmissions_pkey;
+		DROP INDEX idx_offchainreporting_pending_transmissions_time;
+
+		-- Rows move to the current month's partition whenever they are written,
+		-- so old partitions only hold the state of configs that are no longer used
+		CREATE TABLE offchainreporting_persistent_states (
+			offchainreporting_oracle_spec_id integer NOT NULL REFERENCES offchainreporting_oracle_specs (id) ON DELETE CASCADE,
+			config_digest bytea NOT NULL,
+			epoch bigint NOT NULL,
+			highest_sent_epoch bigint NOT NULL,
+			highest_received_epoch bigint[] NOT NULL,
+			created_at timestamptz NOT NULL,
+			updated_at timestamptz NOT NULL,
+			CONSTRAINT offchainreporting_persistent_states_config_digest_check CHECK (octet_length(config_digest) = 16),
+			PRIMARY KEY (offchainreporting_oracle_spec_id, config_digest, updated_at)
+		) PARTITION BY RANGE (updated_at);
+		CREATE TABLE offchainreporting_persistent_states_default PARTITION OF offchainreporting_persistent_states DEFAULT;
+
+		CREATE TABLE offchainreporting_pending_transmissions (
+			offchainreporting_oracle_spec_id integer NOT NULL REFERENCES offchainreporting_oracle_specs (id) ON DELETE CASCADE,
+			config_digest bytea NOT NULL,
+			epoch bigint NOT NULL,
+			round bigint NOT NULL,
+			"time" timestamptz NOT NULL,
+			median numeric(78,0) NOT NULL,
+			serialized_report bytea NOT NULL,
+			rs bytea[] NOT NULL,
+			ss bytea[] NOT NULL,
+			vs bytea NOT NULL,
+			created_at timestamptz NOT NULL,
+			updated_at timestamptz NOT NULL,
+			CONSTRAINT offchainreporting_pending_transmissions_config_digest_check CHECK (octet_length(config_digest) = 16),
+			PRIMARY KEY (offchainreporting_oracle_spec_id, config_digest, epoch, round, "time")
+		) PARTITION BY RANGE ("time");
+		CREATE TABLE offchainreporting_pending_transmissions_default PARTITION OF offchainreporting_pending_transmissions DEFAULT;
+		CREATE INDEX idx_offchainreporting_pending_transmissions_time ON offchainreporting_pending_transmissions ("time");
+
+		DO $$
+		DECLARE
+			m timestamp;
+		BEGIN
+			FOR m IN SELECT generate_series(
+				date_trunc('month', COALESCE((SELECT MIN(updated_at) FROM old_persistent_states), NOW()) AT TIME ZONE 'UTC'),
+				(NOW() AT TIME ZONE 'UTC') + interval '1 month',
+				interval '1 month'
+			) LOOP
+				PERFORM ocr_create_monthly_partition('offchainreporting_persistent_states', 'updated_at', m AT TIME ZONE 'UTC');
+			END LOOP;
+			FOR m IN SELECT generate_series(
+				date_trunc('month', COALESCE((SELECT MIN("time") FROM old_pending_transmissions), NOW()) AT TIME ZONE 'UTC'),
+				(NOW() AT TIME ZONE 'UTC') + interval '1 month',
+				interval '1 month'
+			) LOOP
+				PERFORM ocr_create_monthly_partition('offchainreporting_pending_transmissions', 'time', m AT TIME ZONE 'UTC');
+			END LOOP;
+		END
+		$$;
+
+		INSERT INTO offchainreporting_persistent_states SELECT * FROM old_persistent_states;
+		INSERT INTO offchainreporting_pending_transmissions SELECT * FROM old_pending_transmissions;
+		DROP TABLE old_persistent_states;
+		DROP TABLE old_pending_transmissions;
+	`
+
+	down43 = `
+		ALTER TABLE offchainreporting_persistent_states RENAME TO new_persistent_states;
+		ALTER INDEX offchainreporting_persistent_states_pkey RENAME TO new_persistent_states_pkey;
+		ALTER TABLE offchainreporting_pending_transmissions RENAME TO new_pending_transmissions;
+		ALTER INDEX offchainreporting_pending_transmissions_pkey RENAME TO new_pending_transmissions_pkey;
+		DROP INDEX idx_offchainreporting_pending_transmissions_time;
+
+		CREATE TABLE offchainreporting_persistent_states (
+			offchainreporting_oracle_spec_id integer NOT NULL REFERENCES offchainreporting_oracle_specs (id) ON DELETE CASCADE,
+			config_digest bytea NOT NULL,
+			epoch bigint NOT NULL,
+			highest_sent_epoch bigint NOT NULL,
+			highest_received_epoch bigint[] NOT NULL,
+			created_at timestamptz NOT NULL,
+			updated_at timestamptz NOT NULL,
+			CONSTRAINT offchainreporting_persistent_states_config_digest_check CHECK (octet_length(config_digest) = 16),
+			PRIMARY KEY (offchainreporting_oracle_spec_id, config_digest)
+		);
+
+		CREATE TABLE offchainreporting_pending_transmissions (
+			offchainreporting_oracle_spec_id integer NOT NULL REFERENCES offchainreporting_oracle_specs (id) ON DELETE CASCADE,
+			config_digest bytea NOT NULL,
+			epoch bigint NOT NULL,
+			round bigint NOT NULL,
+			"time" timestamptz NOT NULL,
+			median numeric(78,0) NOT NULL,
+			serialized_report bytea NOT NULL,
+			rs bytea[] NOT NULL,
+			ss bytea[] NOT NULL,
+			vs bytea NOT NULL,
+			created_at timestamptz NOT NULL,
+			updated_at timestamptz NOT NULL,
+			CONSTRAINT offchainreporting_pending_transmissions_config_digest_check CHECK (octet_length(config_digest) = 16),
+			PRIMARY KEY (offchainreporting_oracle_spec_id, config_digest, epoch, round)
+		);
+		CREATE INDEX idx_offchainreporting_pending_transmissions_time ON offchainreporting_pending_transmissions ("time");
+
+		-- The partitioned tables can't enforce the old primary keys, so keep
+		-- only the latest row of any duplicates
+		INSERT INTO offchainreporting_persistent_states
+		SELECT DISTINCT ON (offchainreporting_oracle_spec_id, config_digest) * FROM new_persistent_states
+		ORDER BY offchainreporting_oracle_spec_id, config_digest, updated_at DESC;
+		INSERT INTO offchainreporting_pending_transmissions
+		SELECT DISTINCT ON (offchainreporting_oracle_spec_id, config_digest, epoch, round) * FROM new_pending_transmissions
+		ORDER BY offchainreporting_oracle_spec_id, config_digest, epoch, round, updated_at DESC;
+		DROP TABLE new_persistent_states;
+		DROP TABLE new_pending_transmissions;
+		DROP FUNCTION ocr_create_monthly_partition;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0043_partition_ocr_state",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up43).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down43).Error
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up44 = `
+		ALTER TABLE pipeline_task_runs ADD COLUMN stack_trace text;
+	`
+
+	down44 = `
+		ALTER TABLE pipeline_task_runs DROP COLUMN stack_trace;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0044_add_task_run_stack_trace",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up44).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down44).Error
+		},
+	})
+}
@@ -0,0 +1,119 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+const (
+	up45 = `
+		ALTER TABLE pipeline_specs ADD COLUMN content_hash bytea CHECK (octet_length(content_hash) = 32);
+
+		-- Runs can no longer be attributed to a job by their pipeline spec, as
+		-- jobs with the same pipeline share a spec
+		ALTER TABLE pipeline_runs ADD COLUMN job_id integer REFERENCES jobs (id) ON DELETE CASCADE;
+		UPDATE pipeline_runs SET job_id = jobs.id FROM jobs WHERE jobs.pipeline_spec_id = pipeline_runs.pipeline_spec_id;
+		CREATE INDEX idx_pipeline_runs_job_id ON pipeline_runs (job_id);
+	`
+
+	// The unique index is created once existing specs have been hashed and
+	// merged
+	up45Index = `
+		CREATE UNIQUE INDEX idx_pipeline_specs_unique_content_hash ON pipeline_specs (content_hash);
+	`
+
+	down45 = `
+		DROP INDEX idx_pipeline_runs_job_id;
+		ALTER TABLE pipeline_runs DROP COLUMN job_id;
+		DROP INDEX idx_pipeline_specs_unique_content_hash;
+		ALTER TABLE pipeline_specs DROP COLUMN content_hash;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0045_content_addressed_pipeline_specs",
+		Migrate: func(db *gorm.DB) error {
+			return db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(up45).Error; err != nil {
+					return err
+				}
+				if err := hashPipelineSpecs45(tx); err != nil {
+					return err
+				}
+				return tx.Exec(up45Index).Error
+			})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down45).Error
+		},
+	})
+}
+
+// hashPipelineSpecs45 sets the content hash of the existing pipeline specs,
+// and merges those with the same hash into the oldest of them, moving their
+// jobs and runs to it. Specs whose DAG no longer parses are left without a
+// hash, and so are never shared.
+//
+// The specs can't be loaded in a dry run, so a note is recorded in place of
+// the statements this would execute.
+func hashPipelineSpecs45(tx *gorm.DB) error {
+	if tx.DryRun {
+		return tx.Exec(`-- Existing pipeline specs are hashed, and those with the same hash merged, here. These statements depend on the specs' contents and can't be shown in a dry run.`).Error
+	}
+	type spec struct {
+		id              int32
+		dotDagSource    string
+		maxTaskDuration models.Interval
+		numericPolicy   pipeline.NumericPolicy
+	}
+	rows, err := tx.Raw(`SELECT id, dot_dag_source, max_task_duration, numeric_policy FROM pipeline_specs ORDER BY id`).Rows()
+	if err != nil {
+		return errors.Wrap(err, "could not load pipeline specs")
+	}
+	var specs []spec
+	for rows.Next() {
+		var s spec
+		if err = rows.Scan(&s.id, &s.dotDagSource, &s.maxTaskDuration, &s.numericPolicy); err != nil {
+			rows.Close()
+			return errors.Wrap(err, "could not load pipeline specs")
+		}
+		specs = append(specs, s)
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+
+	hashed := make(map[pipeline.SpecHash]int32)
+	for _, s := range specs {
+		taskDAG := pipeline.NewTaskDAG()
+		if err := taskDAG.UnmarshalText([]byte(s.dotDagSource)); err != nil {
+			continue
+		}
+		hash, err := pipeline.HashSpec(*taskDAG, s.maxTaskDuration, s.numericPolicy)
+		if err != nil {
+			continue
+		}
+		if id, exists := hashed[hash]; exists {
+			if err := tx.Exec(`UPDATE jobs SET pipeline_spec_id = ? WHERE pipeline_spec_id = ?`, id, s.id).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`UPDATE pipeline_runs SET pipeline_spec_id = ? WHERE pipeline_spec_id = ?`, id, s.id).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`DELETE FROM pipeline_specs WHERE id = ?`, s.id).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tx.Exec(`UPDATE pipeline_specs SET content_hash = ? WHERE id = ?`, hash[:], s.id).Error; err != nil {
+			return err
+		}
+		hashed[hash] = s.id
+	}
+	return nil
+}
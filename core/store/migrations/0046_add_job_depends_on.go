@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up46 = `
+		ALTER TABLE jobs ADD COLUMN depends_on text CHECK (depends_on <> '');
+		CREATE INDEX idx_jobs_depends_on ON jobs (depends_on) WHERE depends_on IS NOT NULL;
+	`
+
+	down46 = `
+		ALTER TABLE jobs DROP COLUMN depends_on;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0046_add_job_depends_on",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up46).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down46).Error
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up47 = `
+		ALTER TABLE jobs ADD COLUMN contract_ens_name text CHECK (contract_ens_name <> '');
+	`
+
+	down47 = `
+		ALTER TABLE jobs DROP COLUMN contract_ens_name;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0047_add_job_contract_ens_name",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up47).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down47).Error
+		},
+	})
+}
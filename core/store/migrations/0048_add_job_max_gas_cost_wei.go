@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up48 = `
+		ALTER TABLE jobs ADD COLUMN max_gas_cost_wei numeric(78,0) CHECK (max_gas_cost_wei > 0);
+	`
+
+	down48 = `
+		ALTER TABLE jobs DROP COLUMN max_gas_cost_wei;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0048_add_job_max_gas_cost_wei",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up48).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down48).Error
+		},
+	})
+}
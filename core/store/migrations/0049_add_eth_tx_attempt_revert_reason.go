@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up49 = `
+		ALTER TABLE eth_tx_attempts ADD COLUMN revert_reason text;
+	`
+
+	down49 = `
+		ALTER TABLE eth_tx_attempts DROP COLUMN revert_reason;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0049_add_eth_tx_attempt_revert_reason",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up49).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down49).Error
+		},
+	})
+}
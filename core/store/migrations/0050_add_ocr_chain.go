@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up50 = `
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN chain text NOT NULL DEFAULT 'evm';
+	`
+
+	down50 = `
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN chain;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0050_add_ocr_chain",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up50).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down50).Error
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up51 = `
+		CREATE TABLE job_kv_store (
+			job_id int NOT NULL REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+			key text NOT NULL,
+			value jsonb NOT NULL,
+			updated_at timestamptz NOT NULL,
+			PRIMARY KEY (job_id, key)
+		);
+	`
+
+	down51 = `
+		DROP TABLE job_kv_store;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0051_create_job_kv_store",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up51).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down51).Error
+		},
+	})
+}
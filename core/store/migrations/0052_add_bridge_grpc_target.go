@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up52 = `
+		ALTER TABLE bridge_types
+			ADD COLUMN grpc_target text,
+			ADD COLUMN grpc_tls boolean NOT NULL DEFAULT false,
+			ADD COLUMN grpc_tls_ca_cert text;
+	`
+
+	down52 = `
+		ALTER TABLE bridge_types
+			DROP COLUMN grpc_target,
+			DROP COLUMN grpc_tls,
+			DROP COLUMN grpc_tls_ca_cert;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0052_add_bridge_grpc_target",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up52).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down52).Error
+		},
+	})
+}
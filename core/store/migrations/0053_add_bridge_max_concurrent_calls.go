@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up53 = `
+		ALTER TABLE bridge_types ADD COLUMN max_concurrent_calls integer NOT NULL DEFAULT 0 CHECK (max_concurrent_calls >= 0);
+	`
+
+	down53 = `
+		ALTER TABLE bridge_types DROP COLUMN max_concurrent_calls;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0053_add_bridge_max_concurrent_calls",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up53).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down53).Error
+		},
+	})
+}
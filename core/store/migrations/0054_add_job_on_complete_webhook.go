@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up54 = `
+		ALTER TABLE jobs ADD COLUMN on_complete_webhook_url text CHECK (on_complete_webhook_url <> '');
+		ALTER TABLE jobs ADD COLUMN on_complete_webhook_secret text CHECK (on_complete_webhook_secret <> '');
+
+		CREATE TABLE job_on_complete_dead_letters (
+			id BIGSERIAL PRIMARY KEY,
+			job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			pipeline_run_id bigint NOT NULL REFERENCES pipeline_runs (id) ON DELETE CASCADE,
+			sink text NOT NULL,
+			payload jsonb NOT NULL,
+			error text NOT NULL,
+			attempts integer NOT NULL,
+			created_at timestamptz NOT NULL
+		);
+		CREATE INDEX idx_job_on_complete_dead_letters_job_id ON job_on_complete_dead_letters (job_id);
+	`
+
+	down54 = `
+		DROP TABLE job_on_complete_dead_letters;
+		ALTER TABLE jobs DROP COLUMN on_complete_webhook_url;
+		ALTER TABLE jobs DROP COLUMN on_complete_webhook_secret;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0054_add_job_on_complete_webhook",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up54).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down54).Error
+		},
+	})
+}
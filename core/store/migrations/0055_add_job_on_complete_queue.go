@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up55 = `
+		ALTER TABLE jobs ADD COLUMN on_complete_queue_url text CHECK (on_complete_queue_url <> '');
+		ALTER TABLE jobs ADD COLUMN on_complete_queue_topic text CHECK (on_complete_queue_topic <> '');
+		ALTER TABLE jobs ADD COLUMN on_complete_queue_format text CHECK (on_complete_queue_format IN ('json', 'avro'));
+	`
+
+	down55 = `
+		ALTER TABLE jobs DROP COLUMN on_complete_queue_url;
+		ALTER TABLE jobs DROP COLUMN on_complete_queue_topic;
+		ALTER TABLE jobs DROP COLUMN on_complete_queue_format;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0055_add_job_on_complete_queue",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up55).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down55).Error
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up56 = `
+		ALTER TABLE pipeline_runs ADD COLUMN costs jsonb;
+
+		CREATE TABLE job_costs (
+			job_id int NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			day date NOT NULL,
+			kind text NOT NULL CHECK (kind IN ('http', 'bridge', 'transaction')),
+			target text NOT NULL,
+			calls bigint NOT NULL DEFAULT 0,
+			bytes_sent bigint NOT NULL DEFAULT 0,
+			bytes_received bigint NOT NULL DEFAULT 0,
+			gas_used numeric(78,0) NOT NULL DEFAULT 0,
+			fee_wei numeric(78,0) NOT NULL DEFAULT 0,
+			PRIMARY KEY (job_id, day, kind, target)
+		);
+
+		ALTER TABLE eth_txes ADD COLUMN job_id int REFERENCES jobs (id) ON DELETE SET NULL;
+		ALTER TABLE eth_txes ADD COLUMN job_cost_recorded bool NOT NULL DEFAULT false;
+		CREATE INDEX idx_eth_txes_job_id ON eth_txes (job_id) WHERE job_id IS NOT NULL;
+	`
+
+	down56 = `
+		DROP INDEX idx_eth_txes_job_id;
+		ALTER TABLE eth_txes DROP COLUMN job_cost_recorded;
+		ALTER TABLE eth_txes DROP COLUMN job_id;
+
+		DROP TABLE job_costs;
+
+		ALTER TABLE pipeline_runs DROP COLUMN costs;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0056_add_job_costs",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up56).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down56).Error
+		},
+	})
+}
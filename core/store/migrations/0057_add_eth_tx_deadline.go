@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up57 = `
+		ALTER TABLE eth_txes ADD COLUMN deadline timestamptz;
+	`
+
+	down57 = `
+		ALTER TABLE eth_txes DROP COLUMN deadline;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0057_add_eth_tx_deadline",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up57).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down57).Error
+		},
+	})
+}
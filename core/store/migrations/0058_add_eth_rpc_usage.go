@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up58 = `
+		CREATE TABLE eth_rpc_usage (
+			day date NOT NULL,
+			method text NOT NULL,
+			endpoint text NOT NULL,
+			calls bigint NOT NULL DEFAULT 0,
+			PRIMARY KEY (day, method, endpoint)
+		);
+	`
+
+	down58 = `
+		DROP TABLE eth_rpc_usage;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0058_add_eth_rpc_usage",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up58).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down58).Error
+		},
+	})
+}
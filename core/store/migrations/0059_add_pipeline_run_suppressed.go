@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up59 = `
+		ALTER TABLE pipeline_runs ADD COLUMN suppressed boolean NOT NULL DEFAULT false;
+	`
+
+	down59 = `
+		ALTER TABLE pipeline_runs DROP COLUMN suppressed;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0059_add_pipeline_run_suppressed",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up59).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down59).Error
+		},
+	})
+}
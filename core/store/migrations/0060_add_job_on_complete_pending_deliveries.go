@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up60 = `
+		CREATE TABLE job_on_complete_pending_deliveries (
+			id BIGSERIAL PRIMARY KEY,
+			job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			pipeline_run_id bigint NOT NULL UNIQUE REFERENCES pipeline_runs (id) ON DELETE CASCADE,
+			locked_until timestamptz,
+			created_at timestamptz NOT NULL
+		);
+		CREATE INDEX idx_job_on_complete_pending_deliveries_job_id ON job_on_complete_pending_deliveries (job_id);
+	`
+
+	down60 = `
+		DROP TABLE job_on_complete_pending_deliveries;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0060_add_job_on_complete_pending_deliveries",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up60).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down60).Error
+		},
+	})
+}
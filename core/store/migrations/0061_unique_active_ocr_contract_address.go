@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// The contract addresses of OCR specs only need to be unique among the
+	// jobs that haven't been archived, so that a contract's job can be
+	// replaced before the archived one is purged. The job's archived_at is
+	// copied to its spec, as a partial index can't join the jobs table.
+	up61 = `
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN archived_at timestamptz;
+		UPDATE offchainreporting_oracle_specs SET archived_at = jobs.archived_at
+			FROM jobs WHERE jobs.offchainreporting_oracle_spec_id = offchainreporting_oracle_specs.id;
+
+		ALTER TABLE offchainreporting_oracle_specs DROP CONSTRAINT unique_contract_addr;
+		CREATE UNIQUE INDEX unique_contract_addr ON offchainreporting_oracle_specs (contract_address) WHERE archived_at IS NULL;
+	`
+
+	down61 = `
+		DROP INDEX unique_contract_addr;
+		ALTER TABLE offchainreporting_oracle_specs ADD CONSTRAINT unique_contract_addr UNIQUE (contract_address);
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN archived_at;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0061_unique_active_ocr_contract_address",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up61).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down61).Error
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up62 = `
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN chain;
+	`
+
+	down62 = `
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN chain text NOT NULL DEFAULT 'evm';
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0062_remove_ocr_chain",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up62).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down62).Error
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	up63 = `
+		ALTER TABLE offchainreporting_oracle_specs ADD COLUMN transmission_deadline bigint;
+	`
+
+	down63 = `
+		ALTER TABLE offchainreporting_oracle_specs DROP COLUMN transmission_deadline;
+	`
+)
+
+func init() {
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "0063_add_ocr_transmission_deadline",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec(up63).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Exec(down63).Error
+		},
+	})
+}
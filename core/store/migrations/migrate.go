@@ -1,8 +1,14 @@
 package migrations
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 var Migrations []*gormigrate.Migration
@@ -66,3 +72,142 @@ func Rollback(db *gorm.DB, m *gormigrate.Migration) error {
 
 	return g.RollbackMigration(m)
 }
+
+// MigrateDownTo rolls back the migrations applied after the one with the
+// given ID, most recent first
+func MigrateDownTo(db *gorm.DB, to string) error {
+	if _, err := indexOf(to); err != nil {
+		return err
+	}
+	g := gormigrate.New(db, &gormigrate.Options{
+		UseTransaction:            false,
+		ValidateUnknownMigrations: false,
+	}, Migrations)
+
+	return g.RollbackTo(to)
+}
+
+// Current returns the ID of the most recent migration that has been applied,
+// or an empty string if none have been
+func Current(db *gorm.DB) (string, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return "", err
+	}
+	for i := len(Migrations) - 1; i >= 0; i-- {
+		if applied[Migrations[i].ID] {
+			return Migrations[i].ID, nil
+		}
+	}
+	return "", nil
+}
+
+// Pending returns the migrations that MigrateUp(db, to) would apply, in order
+func Pending(db *gorm.DB, to string) ([]*gormigrate.Migration, error) {
+	last := len(Migrations) - 1
+	if to != "" {
+		var err error
+		if last, err = indexOf(to); err != nil {
+			return nil, err
+		}
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	var pending []*gormigrate.Migration
+	for _, m := range Migrations[:last+1] {
+		if !applied[m.ID] {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Applied returns the migrations that MigrateDownTo(db, to) would roll back,
+// most recent first
+func Applied(db *gorm.DB, to string) ([]*gormigrate.Migration, error) {
+	first, err := indexOf(to)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	var rollback []*gormigrate.Migration
+	for i := len(Migrations) - 1; i > first; i-- {
+		if applied[Migrations[i].ID] {
+			rollback = append(rollback, Migrations[i])
+		}
+	}
+	return rollback, nil
+}
+
+// MigrationSQL is the SQL a migration executes
+type MigrationSQL struct {
+	ID         string
+	Statements []string
+}
+
+// DryRun returns the SQL that each of the given migrations would execute,
+// without executing it. Rollback SQL is returned if down is set.
+func DryRun(db *gorm.DB, ms []*gormigrate.Migration, down bool) ([]MigrationSQL, error) {
+	var results []MigrationSQL
+	for _, m := range ms {
+		recorder := &sqlRecorder{Interface: db.Logger}
+		tx := db.Session(&gorm.Session{DryRun: true, Logger: recorder})
+		fn := m.Migrate
+		if down {
+			if m.Rollback == nil {
+				return nil, fmt.Errorf("migration %s cannot be rolled back", m.ID)
+			}
+			fn = gormigrate.MigrateFunc(m.Rollback)
+		}
+		if err := fn(tx); err != nil {
+			return nil, errors.Wrapf(err, "failed to dry run migration %s", m.ID)
+		}
+		results = append(results, MigrationSQL{ID: m.ID, Statements: recorder.statements})
+	}
+	return results, nil
+}
+
+// sqlRecorder is a gorm logger that records the SQL of each statement
+type sqlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface {
+	return r
+}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+func appliedMigrations(db *gorm.DB) (map[string]bool, error) {
+	applied := make(map[string]bool)
+	if !db.Migrator().HasTable(gormigrate.DefaultOptions.TableName) {
+		return applied, nil
+	}
+	var ids []string
+	err := db.Table(gormigrate.DefaultOptions.TableName).Pluck(gormigrate.DefaultOptions.IDColumnName, &ids).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load applied migrations")
+	}
+	for _, id := range ids {
+		applied[id] = true
+	}
+	return applied, nil
+}
+
+func indexOf(id string) (int, error) {
+	for i, m := range Migrations {
+		if m.ID == id {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown migration %s", id)
+}
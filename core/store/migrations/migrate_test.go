@@ -301,3 +301,113 @@ func TestMigrate_RemoveResultTask(t *testing.T) {
 
 	require.NoError(t, migrations.MigrateDownFrom(orm.DB, "0020_remove_result_task"))
 }
+
+func TestMigrate_ContentAddressedPipelineSpecs(t *testing.T) {
+	_, orm, cleanup := cltest.BootstrapThrowawayORM(t, "migrations_content_addressed_pipeline_specs", false)
+	defer cleanup()
+
+	require.NoError(t, migrations.MigrateUp(orm.DB, "0044_add_task_run_stack_trace"))
+
+	// Two jobs whose pipelines only differ in formatting, and a third
+	insertSpec := func(dot string) (id int32) {
+		require.NoError(t, orm.DB.Raw(`INSERT INTO pipeline_specs (dot_dag_source, created_at) VALUES (?, NOW()) RETURNING id`, dot).Row().Scan(&id))
+		return id
+	}
+	insertJob := func(specID int32) (id int32) {
+		var drID int32
+		require.NoError(t, orm.DB.Raw(`INSERT INTO direct_request_specs (contract_address, on_chain_job_spec_id, created_at, updated_at) VALUES (?, ?, NOW(), NOW()) RETURNING id`,
+			cltest.NewAddress().Bytes(), cltest.NewHash().Bytes()).Row().Scan(&drID))
+		require.NoError(t, orm.DB.Raw(`INSERT INTO jobs (pipeline_spec_id, direct_request_spec_id, schema_version, type) VALUES (?, ?, 1, 'directrequest') RETURNING id`,
+			specID, drID).Row().Scan(&id))
+		require.NoError(t, orm.DB.Exec(`INSERT INTO pipeline_runs (pipeline_spec_id, created_at) VALUES (?, NOW())`, specID).Error)
+		return id
+	}
+	spec1 := insertSpec(`ds1 [type=http url="https://example.com"]; ds1_parse [type=jsonparse path="USD"]; ds1 -> ds1_parse;`)
+	spec2 := insertSpec(`
+		ds1_parse [type=jsonparse path="USD"];
+		ds1       [type=http url="https://example.com"];
+		ds1 -> ds1_parse;
+	`)
+	spec3 := insertSpec(`ds1 [type=http url="https://example.org"];`)
+	job1, job2, job3 := insertJob(spec1), insertJob(spec2), insertJob(spec3)
+
+	require.NoError(t, migrations.MigrateUp(orm.DB, "0045_content_addressed_pipeline_specs"))
+
+	specOf := func(jobID int32) (specID int32) {
+		require.NoError(t, orm.DB.Raw(`SELECT pipeline_spec_id FROM jobs WHERE id = ?`, jobID).Row().Scan(&specID))
+		return specID
+	}
+	assert.Equal(t, spec1, specOf(job1))
+	assert.Equal(t, spec1, specOf(job2))
+	assert.Equal(t, spec3, specOf(job3))
+
+	var count int64
+	require.NoError(t, orm.DB.Raw(`SELECT count(*) FROM pipeline_specs WHERE content_hash IS NOT NULL`).Row().Scan(&count))
+	assert.Equal(t, int64(2), count)
+	require.NoError(t, orm.DB.Raw(`SELECT count(*) FROM pipeline_specs WHERE id = ?`, spec2).Row().Scan(&count))
+	assert.Equal(t, int64(0), count)
+
+	// Runs keep their job, and move to the merged spec
+	require.NoError(t, orm.DB.Raw(`SELECT count(*) FROM pipeline_runs WHERE pipeline_spec_id = ? AND job_id = ?`, spec1, job2).Row().Scan(&count))
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, migrations.MigrateDownFrom(orm.DB, "0045_content_addressed_pipeline_specs"))
+}
+
+func TestMigrate_DryRunAndRollback(t *testing.T) {
+	_, orm, cleanup := cltest.BootstrapThrowawayORM(t, "migrations_dry_run", false)
+	defer cleanup()
+
+	require.NoError(t, migrations.MigrateUp(orm.DB, "0026_add_job_spec_checksums"))
+	current, err := migrations.Current(orm.DB)
+	require.NoError(t, err)
+	assert.Equal(t, "0026_add_job_spec_checksums", current)
+
+	pending, err := migrations.Pending(orm.DB, "0028_add_pipeline_run_audits")
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "0027_add_ocr_decimals", pending[0].ID)
+	assert.Equal(t, "0028_add_pipeline_run_audits", pending[1].ID)
+
+	results, err := migrations.DryRun(orm.DB, pending, false)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "0027_add_ocr_decimals", results[0].ID)
+	require.Len(t, results[0].Statements, 1)
+	assert.Contains(t, results[0].Statements[0], "ADD COLUMN decimals")
+	assert.False(t, orm.DB.Migrator().HasColumn("offchainreporting_oracle_specs", "decimals"), "a dry run must not change the database")
+	current, err = migrations.Current(orm.DB)
+	require.NoError(t, err)
+	assert.Equal(t, "0026_add_job_spec_checksums", current)
+
+	_, err = migrations.Pending(orm.DB, "9999_unknown")
+	assert.Error(t, err)
+
+	// Rolling back the recent job and pipeline migrations and reapplying them
+	// must leave the database as it was
+	require.NoError(t, migrations.Migrate(orm.DB))
+	applied, err := migrations.Applied(orm.DB, "0019_last_run_height_column_to_keeper_table")
+	require.NoError(t, err)
+	require.Equal(t, len(migrations.Migrations)-19, len(applied))
+	assert.Equal(t, migrations.Migrations[len(migrations.Migrations)-1].ID, applied[0].ID)
+
+	results, err = migrations.DryRun(orm.DB, applied, true)
+	require.NoError(t, err)
+	assert.Len(t, results, len(applied))
+
+	require.NoError(t, migrations.MigrateDownTo(orm.DB, "0019_last_run_height_column_to_keeper_table"))
+	current, err = migrations.Current(orm.DB)
+	require.NoError(t, err)
+	assert.Equal(t, "0019_last_run_height_column_to_keeper_table", current)
+	assert.False(t, orm.DB.Migrator().HasTable("job_claims"))
+
+	// Migrations with data steps, such as 0045, must dry run too
+	pending, err = migrations.Pending(orm.DB, migrations.Migrations[len(migrations.Migrations)-1].ID)
+	require.NoError(t, err)
+	results, err = migrations.DryRun(orm.DB, pending, false)
+	require.NoError(t, err)
+	assert.Len(t, results, len(pending))
+
+	require.NoError(t, migrations.Migrate(orm.DB))
+	assert.True(t, orm.DB.Migrator().HasTable("job_claims"))
+}
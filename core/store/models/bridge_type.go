@@ -11,12 +11,20 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
-// BridgeTypeRequest is the incoming record used to create a BridgeType
+// BridgeTypeRequest is the incoming record used to create a BridgeType.
+// IncomingToken and OutgoingToken are optional; random tokens are generated
+// for a new bridge when they are omitted.
 type BridgeTypeRequest struct {
 	Name                   TaskType     `json:"name"`
 	URL                    WebURL       `json:"url"`
 	Confirmations          uint32       `json:"confirmations"`
 	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
+	IncomingToken          string       `json:"incomingToken,omitempty"`
+	OutgoingToken          string       `json:"outgoingToken,omitempty"`
+	GRPCTarget             string       `json:"grpcTarget,omitempty"`
+	GRPCTLS                bool         `json:"grpcTLS,omitempty"`
+	GRPCTLSCACert          string       `json:"grpcTLSCACert,omitempty"`
+	MaxConcurrentCalls     uint32       `json:"maxConcurrentCalls"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -44,6 +52,10 @@ type BridgeTypeAuthentication struct {
 	IncomingToken          string       `json:"incomingToken"`
 	OutgoingToken          string       `json:"outgoingToken"`
 	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
+	GRPCTarget             string       `json:"grpcTarget,omitempty"`
+	GRPCTLS                bool         `json:"grpcTLS,omitempty"`
+	GRPCTLSCACert          string       `json:"grpcTLSCACert,omitempty"`
+	MaxConcurrentCalls     uint32       `json:"maxConcurrentCalls"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -64,15 +76,23 @@ func (bt *BridgeTypeAuthentication) SetID(value string) error {
 }
 
 // BridgeType is used for external adapters and has fields for
-// the name of the adapter and its URL.
+// the name of the adapter and its URL. Adapters that serve the gRPC protocol
+// of package eagrpc also have a gRPC target, which grpcbridge tasks call.
+// MaxConcurrentCalls limits the calls made to the adapter at once, for
+// adapters that can't take the load of every job calling them at the same
+// time; zero means no limit.
 type BridgeType struct {
 	Name                   TaskType     `json:"name" gorm:"primary_key"`
 	URL                    WebURL       `json:"url"`
 	Confirmations          uint32       `json:"confirmations"`
 	IncomingTokenHash      string       `json:"-"`
 	Salt                   string       `json:"-"`
-	OutgoingToken          string       `json:"outgoingToken"`
+	OutgoingToken          string       `json:"outgoingToken" gorm:"encrypted"`
 	MinimumContractPayment *assets.Link `json:"minimumContractPayment" gorm:"type:varchar(255)"`
+	GRPCTarget             string       `json:"grpcTarget,omitempty" gorm:"column:grpc_target"`
+	GRPCTLS                bool         `json:"grpcTLS,omitempty" gorm:"column:grpc_tls"`
+	GRPCTLSCACert          string       `json:"grpcTLSCACert,omitempty" gorm:"column:grpc_tls_ca_cert"`
+	MaxConcurrentCalls     uint32       `json:"maxConcurrentCalls"`
 	CreatedAt              time.Time    `json:"-"`
 	UpdatedAt              time.Time    `json:"-"`
 }
@@ -98,8 +118,14 @@ func (bt *BridgeType) SetID(value string) error {
 // password) and a bridge type (with hashed password, for persisting)
 func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 	*BridgeType, error) {
-	incomingToken := utils.NewSecret(24)
-	outgoingToken := utils.NewSecret(24)
+	incomingToken := btr.IncomingToken
+	if incomingToken == "" {
+		incomingToken = utils.NewSecret(24)
+	}
+	outgoingToken := btr.OutgoingToken
+	if outgoingToken == "" {
+		outgoingToken = utils.NewSecret(24)
+	}
 	salt := utils.NewSecret(24)
 
 	hash, err := incomingTokenHash(incomingToken, salt)
@@ -114,6 +140,10 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 			IncomingToken:          incomingToken,
 			OutgoingToken:          outgoingToken,
 			MinimumContractPayment: btr.MinimumContractPayment,
+			GRPCTarget:             btr.GRPCTarget,
+			GRPCTLS:                btr.GRPCTLS,
+			GRPCTLSCACert:          btr.GRPCTLSCACert,
+			MaxConcurrentCalls:     btr.MaxConcurrentCalls,
 		}, &BridgeType{
 			Name:                   btr.Name,
 			URL:                    btr.URL,
@@ -122,9 +152,62 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 			Salt:                   salt,
 			OutgoingToken:          outgoingToken,
 			MinimumContractPayment: btr.MinimumContractPayment,
+			GRPCTarget:             btr.GRPCTarget,
+			GRPCTLS:                btr.GRPCTLS,
+			GRPCTLSCACert:          btr.GRPCTLSCACert,
+			MaxConcurrentCalls:     btr.MaxConcurrentCalls,
 		}, nil
 }
 
+// UpdateFrom sets the attributes of the bridge to those in the request,
+// returning whether any of them changed. The bridge's tokens are only changed
+// if the request includes them.
+func (bt *BridgeType) UpdateFrom(btr *BridgeTypeRequest) (bool, error) {
+	changed := bt.URL.String() != btr.URL.String() ||
+		bt.Confirmations != btr.Confirmations ||
+		!sameLink(bt.MinimumContractPayment, btr.MinimumContractPayment) ||
+		bt.GRPCTarget != btr.GRPCTarget ||
+		bt.GRPCTLS != btr.GRPCTLS ||
+		bt.GRPCTLSCACert != btr.GRPCTLSCACert ||
+		bt.MaxConcurrentCalls != btr.MaxConcurrentCalls
+	bt.URL = btr.URL
+	bt.Confirmations = btr.Confirmations
+	bt.MinimumContractPayment = btr.MinimumContractPayment
+	bt.GRPCTarget = btr.GRPCTarget
+	bt.GRPCTLS = btr.GRPCTLS
+	bt.GRPCTLSCACert = btr.GRPCTLSCACert
+	bt.MaxConcurrentCalls = btr.MaxConcurrentCalls
+
+	if btr.OutgoingToken != "" && btr.OutgoingToken != bt.OutgoingToken {
+		bt.OutgoingToken = btr.OutgoingToken
+		changed = true
+	}
+	if btr.IncomingToken != "" {
+		ok, err := AuthenticateBridgeType(bt, btr.IncomingToken)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			salt := utils.NewSecret(24)
+			hash, err := incomingTokenHash(btr.IncomingToken, salt)
+			if err != nil {
+				return false, err
+			}
+			bt.IncomingTokenHash = hash
+			bt.Salt = salt
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+func sameLink(a, b *assets.Link) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
 // AuthenticateBridgeType returns true if the passed token matches its
 // IncomingToken, or returns false with an error.
 func AuthenticateBridgeType(bt *BridgeType, token string) (bool, error) {
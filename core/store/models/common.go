@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -371,6 +373,66 @@ func (w WebURL) String() string {
 	return url.String()
 }
 
+// BridgeURLEnvPrefix is the prefix of the environment variables that bridge
+// URLs may reference, so that a bridge can't be made to send the node's other
+// secrets, such as DATABASE_URL, to its adapter
+const BridgeURLEnvPrefix = "CL_BRIDGE_"
+
+// envPlaceholder matches the ${NAME} placeholders of environment variables
+// that bridge URLs may contain in their path and query, including those in
+// the path that url.URL escapes
+var envPlaceholder = regexp.MustCompile(`\$(?:\{|%7[Bb])([A-Za-z_][A-Za-z0-9_]*)(?:\}|%7[Dd])`)
+
+// EnvVars returns the names of the environment variables referenced by the
+// URL's ${NAME} placeholders
+func (w WebURL) EnvVars() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range envPlaceholder.FindAllStringSubmatch(w.String(), -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// WithEnv returns the URL with its ${NAME} placeholders replaced by the
+// escaped values of the environment variables, which must all be set and
+// start with BridgeURLEnvPrefix. The result should not be logged, since the
+// variables usually hold credentials.
+func (w WebURL) WithEnv() (*url.URL, error) {
+	var missing, disallowed []string
+	replacer := func(escape func(string) string) func(string) string {
+		return func(placeholder string) string {
+			name := envPlaceholder.FindStringSubmatch(placeholder)[1]
+			if !strings.HasPrefix(name, BridgeURLEnvPrefix) {
+				disallowed = append(disallowed, name)
+				return ""
+			}
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				missing = append(missing, name)
+			}
+			return escape(value)
+		}
+	}
+
+	s := w.String()
+	path, query := s, ""
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		path, query = s[:i], s[i:]
+	}
+	s = envPlaceholder.ReplaceAllStringFunc(path, replacer(url.PathEscape)) +
+		envPlaceholder.ReplaceAllStringFunc(query, replacer(url.QueryEscape))
+	if len(disallowed) > 0 {
+		return nil, errors.Errorf("URL %s references environment variables without the %s prefix: %s", w.String(), BridgeURLEnvPrefix, strings.Join(disallowed, ", "))
+	} else if len(missing) > 0 {
+		return nil, errors.Errorf("URL %s references environment variables that are not set: %s", w.String(), strings.Join(missing, ", "))
+	}
+	return url.Parse(s)
+}
+
 // Value returns this instance serialized for database storage.
 func (w WebURL) Value() (driver.Value, error) {
 	return w.String(), nil
@@ -646,8 +708,10 @@ type SendEtherRequest struct {
 }
 
 // CreateJobSpecRequest represents a request to create and start and OCR job spec.
+// The spec is given either as TOML or as a JSON object with the same keys.
 type CreateJobSpecRequest struct {
-	TOML string `json:"toml"`
+	TOML string          `json:"toml"`
+	JSON json.RawMessage `json:"json,omitempty"`
 }
 
 // AddressCollection is an array of common.Address
@@ -3,6 +3,7 @@ package models_test
 import (
 	"encoding/json"
 	"net/url"
+	"os"
 	"testing"
 	"time"
 
@@ -237,6 +238,38 @@ func TestWebURL_String_HasNilURL(t *testing.T) {
 	assert.Equal(t, "", w.String())
 }
 
+func TestWebURL_EnvPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("CL_BRIDGE_WEBURL_TEST_PATH", "v1")
+	defer os.Unsetenv("CL_BRIDGE_WEBURL_TEST_PATH")
+	os.Setenv("CL_BRIDGE_WEBURL_TEST_KEY", "s3cr&t=/")
+	defer os.Unsetenv("CL_BRIDGE_WEBURL_TEST_KEY")
+
+	var w models.WebURL
+	require.NoError(t, json.Unmarshal([]byte(`"https://adapter.example.com/${CL_BRIDGE_WEBURL_TEST_PATH}/price?key=${CL_BRIDGE_WEBURL_TEST_KEY}&path=${CL_BRIDGE_WEBURL_TEST_PATH}"`), &w))
+
+	assert.Equal(t, "https://adapter.example.com/$%7BCL_BRIDGE_WEBURL_TEST_PATH%7D/price?key=${CL_BRIDGE_WEBURL_TEST_KEY}&path=${CL_BRIDGE_WEBURL_TEST_PATH}", w.String())
+	assert.Equal(t, []string{"CL_BRIDGE_WEBURL_TEST_PATH", "CL_BRIDGE_WEBURL_TEST_KEY"}, w.EnvVars())
+
+	resolved, err := w.WithEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "https://adapter.example.com/v1/price?key=s3cr%26t%3D%2F&path=v1", resolved.String())
+	assert.Equal(t, "s3cr&t=/", resolved.Query().Get("key"))
+
+	var scanned models.WebURL
+	require.NoError(t, scanned.Scan(w.String()))
+	assert.Equal(t, w.String(), scanned.String())
+
+	require.NoError(t, json.Unmarshal([]byte(`"https://adapter.example.com/?key=${CL_BRIDGE_WEBURL_TEST_MISSING}"`), &w))
+	_, err = w.WithEnv()
+	assert.EqualError(t, err, "URL https://adapter.example.com/?key=${CL_BRIDGE_WEBURL_TEST_MISSING} references environment variables that are not set: CL_BRIDGE_WEBURL_TEST_MISSING")
+
+	require.NoError(t, json.Unmarshal([]byte(`"https://adapter.example.com/?key=${DATABASE_URL}"`), &w))
+	_, err = w.WithEnv()
+	assert.EqualError(t, err, "URL https://adapter.example.com/?key=${DATABASE_URL} references environment variables without the CL_BRIDGE_ prefix: DATABASE_URL")
+}
+
 func TestAnyTime_UnmarshalJSON_Valid(t *testing.T) {
 	tests := []struct {
 		name  string
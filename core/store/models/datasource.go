@@ -0,0 +1,58 @@
+package models
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DatasourceRequest is the incoming record used to register a Datasource
+type DatasourceRequest struct {
+	Name             TaskType `json:"name"`
+	ConnectionString string   `json:"connectionString"`
+}
+
+// Datasource is an external database that the sql pipeline task may run
+// read-only queries against. Its connection string holds credentials, so it
+// is encrypted at rest and never returned by the API.
+type Datasource struct {
+	ID               int64     `json:"-"`
+	Name             TaskType  `json:"name" gorm:"not null;unique"`
+	ConnectionString string    `json:"-" gorm:"not null;encrypted"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// NewDatasource returns the Datasource registered by a DatasourceRequest.
+// Only PostgreSQL databases are supported.
+func NewDatasource(dsr DatasourceRequest) (Datasource, error) {
+	if dsr.Name == "" {
+		return Datasource{}, errors.New("name is required")
+	}
+	u, err := url.Parse(dsr.ConnectionString)
+	if err != nil {
+		return Datasource{}, errors.New("connectionString must be a URL")
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return Datasource{}, errors.Errorf("unsupported datasource scheme %q, only postgres datasources are supported", u.Scheme)
+	}
+	return Datasource{Name: dsr.Name, ConnectionString: dsr.ConnectionString}, nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (d Datasource) GetID() string {
+	return d.Name.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (d Datasource) GetName() string {
+	return "datasources"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (d *Datasource) SetID(value string) error {
+	name, err := NewTaskType(value)
+	d.Name = name
+	return err
+}
@@ -37,6 +37,29 @@ type JSONAPIErrors struct {
 // JSONAPIError is an individual JSONAPI Error.
 type JSONAPIError struct {
 	Detail string `json:"detail"`
+	// Meta is the position in a job spec's TOML that the error is at, if it
+	// is known
+	Meta *JSONAPIErrorMeta `json:"meta,omitempty"`
+}
+
+// JSONAPIErrorMeta is a position in a TOML job spec. Column is 0 when only
+// the line is known.
+type JSONAPIErrorMeta struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+// String returns the detail of the error, prefixed with its position if it is
+// known.
+func (e JSONAPIError) String() string {
+	switch {
+	case e.Meta == nil:
+		return e.Detail
+	case e.Meta.Column == 0:
+		return fmt.Sprintf("line %d: %s", e.Meta.Line, e.Detail)
+	default:
+		return fmt.Sprintf("line %d, column %d: %s", e.Meta.Line, e.Meta.Column, e.Detail)
+	}
 }
 
 // NewJSONAPIErrors creates an instance of JSONAPIErrors, with the intention
@@ -58,6 +58,10 @@ type EthTx struct {
 	CreatedAt     time.Time
 	State         EthTxState
 	EthTxAttempts []EthTxAttempt `gorm:"->"`
+	// Deadline is when the transaction stops being useful if it hasn't been
+	// mined, e.g. the end of the OCR round it transmits. As it approaches, the
+	// EthConfirmer bumps its gas more aggressively.
+	Deadline *time.Time
 }
 
 func (e EthTx) GetError() error {
@@ -82,7 +86,10 @@ type EthTxAttempt struct {
 	CreatedAt               time.Time
 	BroadcastBeforeBlockNum *int64
 	State                   EthTxAttemptState
-	EthReceipts             []EthReceipt `gorm:"foreignKey:TxHash;references:Hash;association_foreignkey:Hash;->"`
+	// RevertReason is set on attempts that were mined but reverted, if the
+	// reason could be recovered by replaying the transaction
+	RevertReason *string
+	EthReceipts  []EthReceipt `gorm:"foreignKey:TxHash;references:Hash;association_foreignkey:Hash;->"`
 }
 
 type EthReceipt struct {
@@ -25,8 +25,8 @@ type ExternalInitiator struct {
 	AccessKey      string  `gorm:"not null"`
 	Salt           string  `gorm:"not null"`
 	HashedSecret   string  `gorm:"not null"`
-	OutgoingSecret string  `gorm:"not null"`
-	OutgoingToken  string  `gorm:"not null"`
+	OutgoingSecret string  `gorm:"not null;encrypted"`
+	OutgoingToken  string  `gorm:"not null;encrypted"`
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
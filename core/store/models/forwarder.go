@@ -0,0 +1,37 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// Forwarder is an operator forwarder contract that the node may send
+// transactions through, so that the address seen on-chain stays the same when
+// the node's keys are rotated. The forwarder must authorize the keys sending
+// through it.
+type Forwarder struct {
+	ID        int64        `json:"-"`
+	Address   EIP55Address `json:"address"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (f Forwarder) GetID() string {
+	return strconv.FormatInt(f.ID, 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (f Forwarder) GetName() string {
+	return "forwarders"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (f *Forwarder) SetID(value string) error {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	f.ID = id
+	return nil
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -25,6 +26,10 @@ var (
 	},
 		[]string{"job_spec_id", "from_status", "status"},
 	)
+
+	// ErrAsyncTimeout is the error set on runs that waited longer than
+	// PENDING_BRIDGE_RUN_TIMEOUT for an asynchronous bridge callback
+	ErrAsyncTimeout = errors.New("timed out waiting for asynchronous bridge callback")
 )
 
 // JobRun tracks the status of a job by holding its TaskRuns and the
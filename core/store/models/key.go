@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 	"io/ioutil"
+	"math/big"
 	"time"
 
 	"gorm.io/gorm"
@@ -33,6 +34,52 @@ type Key struct {
 	// IsFunding marks the address as being used for rescuing the  node and the pending transactions
 	// Only one key can be IsFunding=true at a time.
 	IsFunding bool
+	// IsTransmitter reserves the address for OCR transmissions. The tx manager
+	// refuses to send transactions from it to anything other than an OCR
+	// contract, and it is never picked for other jobs.
+	IsTransmitter bool
+	// ChainID, if set, is the only chain that transactions will be sent from
+	// this address on
+	ChainID *utils.Big
+	// MaxGasPriceWei, if set, caps the gas price of transactions sent from
+	// this address below ETH_MAX_GAS_PRICE_WEI
+	MaxGasPriceWei *utils.Big
+	// MaxInFlightTransactions, if set, limits the number of transactions from
+	// this address that may be broadcast but unconfirmed at any one time
+	MaxInFlightTransactions *uint32
+}
+
+// KeySettings are the per-key limits and roles that node operators may change
+type KeySettings struct {
+	IsTransmitter           bool       `json:"isTransmitter"`
+	ChainID                 *utils.Big `json:"chainID"`
+	MaxGasPriceWei          *utils.Big `json:"maxGasPriceWei"`
+	MaxInFlightTransactions *uint32    `json:"maxInFlightTransactions"`
+}
+
+// Settings returns the key's per-key limits and role
+func (k Key) Settings() KeySettings {
+	return KeySettings{
+		IsTransmitter:           k.IsTransmitter,
+		ChainID:                 k.ChainID,
+		MaxGasPriceWei:          k.MaxGasPriceWei,
+		MaxInFlightTransactions: k.MaxInFlightTransactions,
+	}
+}
+
+// SupportsChain returns whether transactions may be sent from this key on
+// the given chain
+func (k Key) SupportsChain(chainID *big.Int) bool {
+	return k.ChainID == nil || k.ChainID.ToInt().Cmp(chainID) == 0
+}
+
+// GasPriceCap returns the highest gas price that may be paid by this key,
+// given the node-wide maximum
+func (k Key) GasPriceCap(nodeMax *big.Int) *big.Int {
+	if k.MaxGasPriceWei != nil && k.MaxGasPriceWei.ToInt().Cmp(nodeMax) < 0 {
+		return k.MaxGasPriceWei.ToInt()
+	}
+	return nodeMax
 }
 
 // NewKeyFromFile creates an instance in memory from a key file on disk.
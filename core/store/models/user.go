@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
@@ -21,6 +22,12 @@ type User struct {
 	TokenSalt         string    `json:"-"`
 	TokenHashedSecret string    `json:"-"`
 	UpdatedAt         time.Time `json:"-"`
+	// TOTPSecret is set once two-factor enrollment has begun, but is only
+	// required to log in once TOTPEnabled is set
+	TOTPSecret         string         `json:"-" gorm:"column:totp_secret;encrypted"`
+	TOTPEnabled        bool           `json:"-" gorm:"column:totp_enabled"`
+	TOTPLastCounter    int64          `json:"-" gorm:"column:totp_last_counter"`
+	RecoveryCodeHashes pq.StringArray `json:"-" gorm:"type:text[]"`
 }
 
 // https://davidcel.is/posts/stop-validating-email-addresses-with-regex/
@@ -57,10 +64,13 @@ func NewUser(email, plainPwd string) (User, error) {
 }
 
 // SessionRequest encapsulates the fields needed to generate a new SessionID,
-// including the hashed password.
+// including the hashed password. If the user has enabled two-factor
+// authentication, either a TOTP code or a recovery code is also needed.
 type SessionRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	TOTPCode     string `json:"totpCode,omitempty"`
+	RecoveryCode string `json:"recoveryCode,omitempty"`
 }
 
 // Session holds the unique id for the authenticated session.
@@ -79,12 +89,121 @@ func NewSession() Session {
 	}
 }
 
+// RecoveryCodeCount is the number of recovery codes issued when two-factor
+// authentication is enabled
+const RecoveryCodeCount = 10
+
+var (
+	// ErrSecondFactorRequired is returned when logging in without a TOTP or
+	// recovery code when two-factor authentication is enabled
+	ErrSecondFactorRequired = errors.New("a two-factor authentication code is required")
+	// ErrInvalidSecondFactor is returned for an incorrect, expired or reused
+	// TOTP or recovery code
+	ErrInvalidSecondFactor = errors.New("invalid two-factor authentication code")
+)
+
+// BeginTOTPEnrollment generates a new TOTP secret for the user. It has no
+// effect on logging in until it is confirmed with ConfirmTOTPEnrollment.
+func (u *User) BeginTOTPEnrollment() (string, error) {
+	if u.TOTPEnabled {
+		return "", errors.New("two-factor authentication is already enabled")
+	}
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	u.TOTPSecret = secret
+	u.TOTPLastCounter = 0
+	return secret, nil
+}
+
+// ConfirmTOTPEnrollment enables two-factor authentication if the code is
+// valid for the secret from BeginTOTPEnrollment, returning the user's
+// recovery codes
+func (u *User) ConfirmTOTPEnrollment(code string, at time.Time) ([]string, error) {
+	if u.TOTPEnabled {
+		return nil, errors.New("two-factor authentication is already enabled")
+	} else if u.TOTPSecret == "" {
+		return nil, errors.New("two-factor enrollment has not been started")
+	}
+	counter, ok := auth.ValidateTOTP(u.TOTPSecret, code, at, u.TOTPLastCounter)
+	if !ok {
+		return nil, ErrInvalidSecondFactor
+	}
+	codes, err := u.RegenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	u.TOTPEnabled = true
+	u.TOTPLastCounter = counter
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes with new ones
+func (u *User) RegenerateRecoveryCodes() ([]string, error) {
+	codes, err := auth.NewRecoveryCodes(RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(pq.StringArray, len(codes))
+	for i, code := range codes {
+		hashes[i] = auth.HashRecoveryCode(code)
+	}
+	u.RecoveryCodeHashes = hashes
+	return codes, nil
+}
+
+// DisableTOTP turns off two-factor authentication and forgets the secret
+// and recovery codes
+func (u *User) DisableTOTP() {
+	u.TOTPSecret = ""
+	u.TOTPEnabled = false
+	u.TOTPLastCounter = 0
+	u.RecoveryCodeHashes = nil
+}
+
+// VerifySecondFactor checks a TOTP code or, failing that, a recovery code.
+// Both are single use, so the user must be saved after a successful
+// verification. It always succeeds if two-factor authentication is disabled.
+func (u *User) VerifySecondFactor(totpCode, recoveryCode string, at time.Time) error {
+	if !u.TOTPEnabled {
+		return nil
+	}
+	if totpCode == "" && recoveryCode == "" {
+		return ErrSecondFactorRequired
+	}
+	if totpCode != "" {
+		if counter, ok := auth.ValidateTOTP(u.TOTPSecret, totpCode, at, u.TOTPLastCounter); ok {
+			u.TOTPLastCounter = counter
+			return nil
+		}
+	}
+	if recoveryCode != "" {
+		hash := auth.HashRecoveryCode(recoveryCode)
+		for i, h := range u.RecoveryCodeHashes {
+			if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+				u.RecoveryCodeHashes = append(u.RecoveryCodeHashes[:i:i], u.RecoveryCodeHashes[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return ErrInvalidSecondFactor
+}
+
 // ChangePasswordRequest sets a new password for the current Session's User.
 type ChangePasswordRequest struct {
 	OldPassword string `json:"oldPassword"`
 	NewPassword string `json:"newPassword"`
 }
 
+// TwoFactorRequest authorizes changes to the user's two-factor
+// authentication settings. Which fields are needed depends on the change.
+type TwoFactorRequest struct {
+	Password     string `json:"password"`
+	TOTPCode     string `json:"totpCode"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
 // Changeauth.TokenRequest is sent when updating a User's authentication token.
 type ChangeAuthTokenRequest struct {
 	Password string `json:"password"`
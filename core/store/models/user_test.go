@@ -2,7 +2,9 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
@@ -66,3 +68,65 @@ func TestAuthenticateUserByToken(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, ok, "authentication must fail with past token")
 }
+
+func TestUser_TOTPEnrollment(t *testing.T) {
+	t.Parallel()
+
+	var user models.User
+	now := time.Now()
+
+	_, err := user.ConfirmTOTPEnrollment("123456", now)
+	require.Error(t, err, "enrollment must be started first")
+
+	secret, err := user.BeginTOTPEnrollment()
+	require.NoError(t, err)
+	assert.Equal(t, secret, user.TOTPSecret)
+	assert.False(t, user.TOTPEnabled)
+	assert.NoError(t, user.VerifySecondFactor("", "", now), "not required until confirmed")
+
+	_, err = user.ConfirmTOTPEnrollment("000000", now)
+	require.Equal(t, models.ErrInvalidSecondFactor, err)
+
+	code, err := auth.TOTPCode(secret, auth.TOTPCounter(now))
+	require.NoError(t, err)
+	codes, err := user.ConfirmTOTPEnrollment(code, now)
+	require.NoError(t, err)
+	assert.True(t, user.TOTPEnabled)
+	assert.Len(t, codes, models.RecoveryCodeCount)
+	assert.Len(t, user.RecoveryCodeHashes, models.RecoveryCodeCount)
+
+	_, err = user.BeginTOTPEnrollment()
+	assert.Error(t, err, "cannot restart enrollment while enabled")
+
+	user.DisableTOTP()
+	assert.False(t, user.TOTPEnabled)
+	assert.Empty(t, user.TOTPSecret)
+	assert.Empty(t, user.RecoveryCodeHashes)
+}
+
+func TestUser_VerifySecondFactor(t *testing.T) {
+	t.Parallel()
+
+	var user models.User
+	now := time.Now()
+	secret, err := user.BeginTOTPEnrollment()
+	require.NoError(t, err)
+	code, err := auth.TOTPCode(secret, auth.TOTPCounter(now)-1)
+	require.NoError(t, err)
+	recoveryCodes, err := user.ConfirmTOTPEnrollment(code, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.ErrSecondFactorRequired, user.VerifySecondFactor("", "", now))
+	assert.Equal(t, models.ErrInvalidSecondFactor, user.VerifySecondFactor(code, "", now), "TOTP codes are single use")
+
+	code, err = auth.TOTPCode(secret, auth.TOTPCounter(now))
+	require.NoError(t, err)
+	assert.NoError(t, user.VerifySecondFactor(code, "", now))
+	assert.Equal(t, models.ErrInvalidSecondFactor, user.VerifySecondFactor(code, "", now))
+
+	assert.NoError(t, user.VerifySecondFactor("", recoveryCodes[3], now))
+	assert.Len(t, user.RecoveryCodeHashes, models.RecoveryCodeCount-1)
+	assert.Equal(t, models.ErrInvalidSecondFactor, user.VerifySecondFactor("", recoveryCodes[3], now), "recovery codes are single use")
+	assert.NoError(t, user.VerifySecondFactor("", recoveryCodes[4], now))
+	assert.Equal(t, models.ErrInvalidSecondFactor, user.VerifySecondFactor("", "not-a-code", now))
+}
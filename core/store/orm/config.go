@@ -14,7 +14,9 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/smartcontractkit/chainlink/core/static"
 	"github.com/smartcontractkit/chainlink/core/store/dialects"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
@@ -97,9 +100,24 @@ func newConfigWithViper(v *viper.Viper) *Config {
 		logger.Warnf("Unable to load config file: %v\n", err)
 	}
 
+	config.applyChainProfile()
+
 	return config
 }
 
+// applyChainProfile replaces the defaults of config values with those of the
+// profile of ETH_CHAIN_ID, if it is a known chain that needs them, e.g. a
+// rollup. Values that are set explicitly still take precedence.
+func (c *Config) applyChainProfile() {
+	profile, exists := eth.ChainProfileFor(c.ChainID())
+	if !exists {
+		return
+	}
+	for name, value := range profile.ConfigDefaults {
+		c.viper.SetDefault(name, value)
+	}
+}
+
 // Validate performs basic sanity checks on config and returns error if any
 // misconfiguration would be fatal to the application
 func (c *Config) Validate() error {
@@ -154,6 +172,18 @@ func (c *Config) Validate() error {
 			}
 		}
 	}
+	if peers, err := c.P2PPeers(nil); err == nil {
+		for i := range peers {
+			if err := ValidateP2PPeer(peers[i]); err != nil {
+				return errors.Errorf("p2p peer %d is invalid: err %v", i, err)
+			}
+		}
+	} else if c.P2PDiscoveryMode() == P2PDiscoveryModeExplicit && c.FeatureOffchainReporting() {
+		logger.Warn("P2P_DISCOVERY_MODE is explicit but P2P_PEERS is unset, so every OCR job must specify p2pPeers")
+	}
+	if _, err := c.APIAllowedIPs(); err != nil {
+		return err
+	}
 	if me := c.OCRMonitoringEndpoint(""); me != "" {
 		if _, err := url.Parse(me); err != nil {
 			return errors.Wrapf(err, "invalid monitoring url: %s", me)
@@ -203,6 +233,79 @@ func (c Config) AllowOrigins() string {
 	return c.viper.GetString(EnvVarName("AllowOrigins"))
 }
 
+// AllowedTaskTypes returns the only task types that jobs may use, given as a
+// comma-separated list. Jobs may use every task type if it is unset.
+func (c Config) AllowedTaskTypes() []string {
+	return taskTypeList(c.viper.GetString(EnvVarName("AllowedTaskTypes")))
+}
+
+// DisallowedTaskTypes returns the task types that jobs may not use, given as
+// a comma-separated list. It takes precedence over ALLOWED_TASK_TYPES.
+func (c Config) DisallowedTaskTypes() []string {
+	return taskTypeList(c.viper.GetString(EnvVarName("DisallowedTaskTypes")))
+}
+
+// TaskTypeAllowed returns whether jobs on this node may use tasks of type t,
+// such as ethtx or sql. Task types are compared case-insensitively.
+func (c Config) TaskTypeAllowed(t string) bool {
+	t = strings.ToLower(t)
+	for _, disallowed := range c.DisallowedTaskTypes() {
+		if t == disallowed {
+			return false
+		}
+	}
+	allowed := c.AllowedTaskTypes()
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if t == a {
+			return true
+		}
+	}
+	return false
+}
+
+func taskTypeList(s string) []string {
+	var types []string
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}) {
+		types = append(types, strings.ToLower(field))
+	}
+	return types
+}
+
+// APIAllowedIPs returns the networks that may connect to the node's API,
+// given as a comma-separated list of IP addresses and CIDR ranges. All
+// addresses are allowed if it is unset.
+func (c Config) APIAllowedIPs() ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	fields := strings.FieldsFunc(c.viper.GetString(EnvVarName("APIAllowedIPs")), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for _, field := range fields {
+		if !strings.Contains(field, "/") {
+			ip := net.ParseIP(field)
+			if ip == nil {
+				return nil, errors.Errorf("invalid IP address %q in API_ALLOWED_IPS", field)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(field)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid CIDR range in API_ALLOWED_IPS")
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
 // AdminCredentialsFile points to text file containing admnn credentials for logging in
 func (c Config) AdminCredentialsFile() string {
 	fieldName := "AdminCredentialsFile"
@@ -240,11 +343,39 @@ func (c Config) BridgeResponseURL() *url.URL {
 	return c.getWithFallback("BridgeResponseURL", parseURL).(*url.URL)
 }
 
+// BridgeSigningAddress is the address of the ETH key that bridge tasks with
+// signRequest set sign their requests with. Request signing is disabled if it
+// is not set.
+func (c Config) BridgeSigningAddress() *common.Address {
+	if c.viper.GetString(EnvVarName("BridgeSigningAddress")) == "" {
+		return nil
+	}
+	address, ok := c.getWithFallback("BridgeSigningAddress", parseAddress).(*common.Address)
+	if !ok {
+		return nil
+	}
+	return address
+}
+
+// BridgeCheckInterval is how often jobs are checked for calls to bridges that
+// don't exist, e.g. because they were deleted after the job was created. Set to
+// 0 to never check.
+func (c Config) BridgeCheckInterval() time.Duration {
+	return c.getWithFallback("BridgeCheckInterval", parseDuration).(time.Duration)
+}
+
 // ChainID represents the chain ID to use for transactions.
 func (c Config) ChainID() *big.Int {
 	return c.getWithFallback("ChainID", parseBigInt).(*big.Int)
 }
 
+// ChainType is the type of the chain with ChainID, which determines how the
+// cost of transactions is estimated
+func (c Config) ChainType() eth.ChainType {
+	profile, _ := eth.ChainProfileFor(c.ChainID())
+	return profile.Type
+}
+
 // ClientNodeURL is the URL of the Ethereum node this Chainlink node should connect to.
 func (c Config) ClientNodeURL() string {
 	return c.viper.GetString(EnvVarName("ClientNodeURL"))
@@ -287,6 +418,14 @@ func (c Config) DatabaseBackupURL() *url.URL {
 	return uri
 }
 
+// DatabaseEncryptionKeyFile is the path to a file holding the hex encoded
+// 256-bit key used to encrypt sensitive database columns, such as one written
+// by a KMS or secrets manager. When unset, the key is derived from the keystore
+// password.
+func (c Config) DatabaseEncryptionKeyFile() string {
+	return c.viper.GetString(EnvVarName("DatabaseEncryptionKeyFile"))
+}
+
 // DatabaseTimeout represents how long to tolerate non response from the DB.
 func (c Config) DatabaseTimeout() models.Duration {
 	return models.MustMakeDuration(c.getWithFallback("DatabaseTimeout", parseDuration).(time.Duration))
@@ -352,22 +491,39 @@ func (c Config) EnableExperimentalAdapters() bool {
 
 // FeatureExternalInitiators enables the External Initiator feature.
 func (c Config) FeatureExternalInitiators() bool {
-	return c.viper.GetBool(EnvVarName("FeatureExternalInitiators"))
+	return c.FeatureEnabled(FeatureFlagExternalInitiators)
+}
+
+// FeatureExperimentalTasks enables the pipeline tasks that are still
+// experimental in v2 jobs
+func (c Config) FeatureExperimentalTasks() bool {
+	return c.FeatureEnabled(FeatureFlagExperimentalTasks)
 }
 
 // FeatureFluxMonitor enables the Flux Monitor feature.
 func (c Config) FeatureFluxMonitor() bool {
-	return c.viper.GetBool(EnvVarName("FeatureFluxMonitor"))
+	return c.FeatureEnabled(FeatureFlagFluxMonitor)
 }
 
 // FeatureFluxMonitorV2 enables the Flux Monitor v2 feature.
 func (c Config) FeatureFluxMonitorV2() bool {
-	return c.getWithFallback("FeatureFluxMonitorV2", parseBool).(bool)
+	return c.FeatureEnabled(FeatureFlagFluxMonitorV2)
 }
 
-// FeatureOffchainReporting enables the Flux Monitor feature.
+// FeatureMessageQueue enables messagequeue jobs.
+func (c Config) FeatureMessageQueue() bool {
+	return c.FeatureEnabled(FeatureFlagMessageQueue)
+}
+
+// FeatureOffchainReporting enables the Offchain Reporting feature.
 func (c Config) FeatureOffchainReporting() bool {
-	return c.viper.GetBool(EnvVarName("FeatureOffchainReporting"))
+	return c.FeatureEnabled(FeatureFlagOffchainReporting)
+}
+
+// FeatureShadow enables shadow jobs, and the shadow runs of their primary
+// jobs.
+func (c Config) FeatureShadow() bool {
+	return c.FeatureEnabled(FeatureFlagShadow)
 }
 
 // MaximumServiceDuration is the maximum time that a service agreement can run
@@ -408,6 +564,15 @@ func (c Config) EthGasBumpTxDepth() uint16 {
 	return c.getWithFallback("EthGasBumpTxDepth", parseUint16).(uint16)
 }
 
+// EthGasBumpDeadlineWindow is how long before its deadline a transaction starts
+// having its gas bumped every block, by twice ETH_GAS_BUMP_PERCENT or
+// ETH_GAS_BUMP_WEI, rather than every ETH_GAS_BUMP_THRESHOLD blocks. Only
+// transactions with a deadline, such as OCR transmissions, are affected. It is
+// 0, disabling priority bumping, by default.
+func (c Config) EthGasBumpDeadlineWindow() time.Duration {
+	return c.getWithFallback("EthGasBumpDeadlineWindow", parseDuration).(time.Duration)
+}
+
 // EthGasBumpPercent is the minimum percentage by which gas is bumped on each transaction attempt
 // Change with care since values below geth's default will fail with "underpriced replacement transaction"
 func (c Config) EthGasBumpPercent() uint16 {
@@ -433,6 +598,43 @@ func (c Config) EthMaxUnconfirmedTransactions() uint64 {
 	return c.getWithFallback("EthMaxUnconfirmedTransactions", parseUint64).(uint64)
 }
 
+// EthExternalTxDetectionEnabled scans each new block for transactions from the
+// sending keys that the node did not send, reconciling the keys' nonces with them
+// and publishing an alert.
+func (c Config) EthExternalTxDetectionEnabled() bool {
+	return c.viper.GetBool(EnvVarName("EthExternalTxDetectionEnabled"))
+}
+
+// EthNonceGapCheckInterval is how often the nonces of the sending keys are checked
+// for gaps, which leave every transaction sent after them stuck. Set to 0 to
+// disable the checks.
+func (c Config) EthNonceGapCheckInterval() time.Duration {
+	return c.getWithFallback("EthNonceGapCheckInterval", parseDuration).(time.Duration)
+}
+
+// EthNonceGapAutoFill fills the nonce gaps found by consecutive checks with
+// empty transactions. When disabled, gaps are only reported, and the operator
+// fills them through the API.
+func (c Config) EthNonceGapAutoFill() bool {
+	return c.viper.GetBool(EnvVarName("EthNonceGapAutoFill"))
+}
+
+// EthRPCMonthlyQuota is the number of eth RPC calls a month that the node's
+// provider plan allows. A warning is logged and an rpc_quota event is published
+// when the calls made so far this month are projected to exceed it. 0 disables
+// the warning.
+func (c Config) EthRPCMonthlyQuota() uint64 {
+	return c.getWithFallback("EthRPCMonthlyQuota", parseUint64).(uint64)
+}
+
+// EthReadCacheTTL is the longest that the eth client caches the results of
+// the idempotent reads made while the chain's head is a given block, e.g. the
+// chain ID, blocks by number and the code of contracts. Cached results are
+// dropped as soon as the next head arrives. 0 disables the cache.
+func (c Config) EthReadCacheTTL() time.Duration {
+	return c.getWithFallback("EthReadCacheTTL", parseDuration).(time.Duration)
+}
+
 // EthGasLimitDefault sets the default gas limit for outgoing transactions.
 func (c Config) EthGasLimitDefault() uint64 {
 	return c.getWithFallback("EthGasLimitDefault", parseUint64).(uint64)
@@ -499,6 +701,13 @@ func (c Config) EthereumURL() string {
 	return c.viper.GetString(EnvVarName("EthereumURL"))
 }
 
+// ENSResolveInterval is how often the ENS names that jobs' contract addresses
+// were given as are re-resolved, to detect when they change. Set to 0 to never
+// re-resolve them.
+func (c Config) ENSResolveInterval() time.Duration {
+	return c.getWithFallback("ENSResolveInterval", parseDuration).(time.Duration)
+}
+
 // EthereumSecondaryURLs is an optional backup RPC URL
 // Must be http(s) format
 // If specified, transactions will also be broadcast to this ethereum node
@@ -569,12 +778,25 @@ func (c Config) GasUpdaterEnabled() bool {
 	return c.viper.GetBool(EnvVarName("GasUpdaterEnabled"))
 }
 
+// GRPCPort is the port the gRPC operator API listens on. It is disabled when
+// set to 0.
+func (c Config) GRPCPort() uint16 {
+	return c.getWithFallback("GRPCPort", parseUint16).(uint16)
+}
+
 // InsecureFastScrypt causes all key stores to encrypt using "fast" scrypt params instead
 // This is insecure and only useful for local testing. DO NOT SET THIS IN PRODUCTION
 func (c Config) InsecureFastScrypt() bool {
 	return c.viper.GetBool(EnvVarName("InsecureFastScrypt"))
 }
 
+// JobArchiveRetention is how long deleted (archived) v2 jobs are kept, along
+// with their run history, before they are permanently deleted. Set to 0 to
+// keep archived jobs forever.
+func (c Config) JobArchiveRetention() time.Duration {
+	return c.getWithFallback("JobArchiveRetention", parseDuration).(time.Duration)
+}
+
 func (c Config) TriggerFallbackDBPollInterval() time.Duration {
 	return c.getWithFallback("TriggerFallbackDBPollInterval", parseDuration).(time.Duration)
 }
@@ -594,6 +816,121 @@ func (c Config) JobPipelineParallelism() uint8 {
 	return c.getWithFallback("JobPipelineParallelism", parseUint8).(uint8)
 }
 
+// JobPipelineParallelismMin is the fewest workers the pipeline.Runner
+// autotunes its parallelism down to, see JobPipelineParallelismMax
+func (c Config) JobPipelineParallelismMin() uint8 {
+	return c.getWithFallback("JobPipelineParallelismMin", parseUint8).(uint8)
+}
+
+// JobPipelineParallelismMax, when set, makes the pipeline.Runner autotune
+// its parallelism between JobPipelineParallelismMin and this, starting from
+// JobPipelineParallelism. Workers are added while runs are queued up and taken
+// away while the database is slow or the CPU is busy. 0 disables autotuning.
+func (c Config) JobPipelineParallelismMax() uint8 {
+	return c.getWithFallback("JobPipelineParallelismMax", parseUint8).(uint8)
+}
+
+// JobPipelineJSONParseLimit is the maximum size in bytes of the input that a
+// jsonparse pipeline task will accept. 0 means no limit.
+func (c Config) JobPipelineJSONParseLimit() int64 {
+	return c.viper.GetInt64(EnvVarName("JobPipelineJSONParseLimit"))
+}
+
+// JobPipelineAuditMode enables recording an audit of each pipeline run,
+// capturing the raw value from each source, every aggregation step and any
+// rounding applied to the result
+func (c Config) JobPipelineAuditMode() bool {
+	return c.viper.GetBool(EnvVarName("JobPipelineAuditMode"))
+}
+
+// JobPipelineWarmupTimeout, when set, makes OCR and flux monitor jobs execute one
+// throwaway pipeline run as they start, to warm DNS caches, TLS sessions and
+// provider-side caches. The job waits at most this long for the run before it
+// starts. 0 disables warmup runs.
+func (c Config) JobPipelineWarmupTimeout() time.Duration {
+	return c.getWithFallback("JobPipelineWarmupTimeout", parseDuration).(time.Duration)
+}
+
+// JobSpecStrictTOML rejects job specs containing keys that are not recognised
+// for their job type. Disable it to accept specs written for newer node versions.
+func (c Config) JobSpecStrictTOML() bool {
+	return c.viper.GetBool(EnvVarName("JobSpecStrictTOML"))
+}
+
+// ProvisioningDir is a directory of job specs (jobs/*.toml) and bridges
+// (bridges/*.json) that the node creates on startup if they are missing. Empty
+// disables provisioning.
+func (c Config) ProvisioningDir() string {
+	return c.viper.GetString(EnvVarName("ProvisioningDir"))
+}
+
+// ProvisioningPrune deletes the jobs and bridges that are not defined in
+// ProvisioningDir on startup, and replaces jobs whose spec has changed.
+func (c Config) ProvisioningPrune() bool {
+	return c.viper.GetBool(EnvVarName("ProvisioningPrune"))
+}
+
+// NotifierConfigFile is a TOML file of notification routes, which send
+// notifications of job errors, low balances, stalled feeds and failed
+// transactions to Slack, PagerDuty or generic webhooks. See package notifier.
+func (c Config) NotifierConfigFile() string {
+	return c.viper.GetString(EnvVarName("NotifierConfigFile"))
+}
+
+// RunExportURL is the bucket and prefix that finished pipeline runs are
+// exported to for analytics, e.g. s3://bucket/prefix or gs://bucket/prefix.
+// Runs are not exported if it is unset. See package runexport.
+func (c Config) RunExportURL() *url.URL {
+	s := c.viper.GetString(EnvVarName("RunExportURL"))
+	if s == "" {
+		return nil
+	}
+	uri, err := url.Parse(s)
+	if err != nil {
+		logger.Errorf("invalid run export url %s", s)
+		return nil
+	}
+	return uri
+}
+
+// RunExportFrequency is how often the runs finished since the last export
+// are exported
+func (c Config) RunExportFrequency() time.Duration {
+	return c.getWithFallback("RunExportFrequency", parseDuration).(time.Duration)
+}
+
+// RunExportFormat is the format of the exported objects, ndjson or
+// ndjson.gz
+func (c Config) RunExportFormat() string {
+	return c.viper.GetString(EnvVarName("RunExportFormat"))
+}
+
+// RunExportPartitioning is a Go time layout, applied to the UTC time runs
+// finished at, that places the exported objects under partition prefixes,
+// e.g. dt=2006-01-02/hour=15. Empty puts them all directly under the prefix.
+func (c Config) RunExportPartitioning() string {
+	return c.viper.GetString(EnvVarName("RunExportPartitioning"))
+}
+
+// RunExportEndpoint overrides the endpoint of the object store, for
+// S3-compatible stores such as MinIO
+func (c Config) RunExportEndpoint() string {
+	return c.viper.GetString(EnvVarName("RunExportEndpoint"))
+}
+
+// JobOnCompleteMaxAttempts is how many times the result of a run is
+// attempted to be delivered to each of its job's onComplete webhook and
+// message queue before the delivery is recorded as a dead letter
+func (c Config) JobOnCompleteMaxAttempts() uint32 {
+	return c.getWithFallback("JobOnCompleteMaxAttempts", parseUint32).(uint32)
+}
+
+// JobOnCompleteRetryDelay is how long a failed onComplete delivery waits
+// before its first retry. The delay doubles with each retry.
+func (c Config) JobOnCompleteRetryDelay() time.Duration {
+	return c.getWithFallback("JobOnCompleteRetryDelay", parseDuration).(time.Duration)
+}
+
 func (c Config) JobPipelineReaperInterval() time.Duration {
 	return c.getWithFallback("JobPipelineReaperInterval", parseDuration).(time.Duration)
 }
@@ -602,6 +939,21 @@ func (c Config) JobPipelineReaperThreshold() time.Duration {
 	return c.getWithFallback("JobPipelineReaperThreshold", parseDuration).(time.Duration)
 }
 
+// JobSpawnerClaimBatchSize is the maximum number of unclaimed jobs that this
+// node will attempt to claim in a single reconciliation round. Claiming jobs
+// incrementally allows several nodes sharing a database to distribute jobs
+// between them. Set to 0 to claim every unclaimed job at once.
+func (c Config) JobSpawnerClaimBatchSize() uint32 {
+	return c.getWithFallback("JobSpawnerClaimBatchSize", parseUint32).(uint32)
+}
+
+// JobSpawnerMaxClaimedJobs is the maximum number of jobs that this node will
+// hold claims on at any one time. Claims in excess of this limit are released
+// so that other nodes can pick them up. 0 means no limit.
+func (c Config) JobSpawnerMaxClaimedJobs() uint32 {
+	return c.getWithFallback("JobSpawnerMaxClaimedJobs", parseUint32).(uint32)
+}
+
 func (c Config) KeeperRegistrySyncInterval() time.Duration {
 	return c.getWithFallback("KeeperRegistrySyncInterval", parseDuration).(time.Duration)
 }
@@ -614,6 +966,13 @@ func (c Config) KeeperMaximumGracePeriod() int64 {
 	return c.viper.GetInt64(EnvVarName("KeeperMaximumGracePeriod"))
 }
 
+// KeyRecoveryWindow is how long deleted (archived) keys are kept around so
+// that they can be restored. Archived keys older than this are permanently
+// deleted. It is 0 by default, which keeps archived keys forever.
+func (c Config) KeyRecoveryWindow() time.Duration {
+	return c.getWithFallback("KeyRecoveryWindow", parseDuration).(time.Duration)
+}
+
 // JSONConsole enables the JSON console.
 func (c Config) JSONConsole() bool {
 	return c.viper.GetBool(EnvVarName("JSONConsole"))
@@ -648,6 +1007,19 @@ func (c Config) ExplorerSecret() string {
 	return c.viper.GetString(EnvVarName("ExplorerSecret"))
 }
 
+// TelemetrySpoolMaxSize is the maximum size in bytes of the on-disk spool
+// that buffers telemetry while the explorer is unreachable. Telemetry beyond
+// this is dropped. Set to 0 to disable the spool.
+func (c Config) TelemetrySpoolMaxSize() uint64 {
+	return c.getWithFallback("TelemetrySpoolMaxSize", parseUint64).(uint64)
+}
+
+// TelemetrySpoolReplayInterval is how often spooled telemetry is replayed
+// to the explorer once it is reachable again
+func (c Config) TelemetrySpoolReplayInterval() time.Duration {
+	return c.getWithFallback("TelemetrySpoolReplayInterval", parseDuration).(time.Duration)
+}
+
 // FIXME: Add comments to all of these
 func (c Config) OCRBootstrapCheckInterval() time.Duration {
 	return c.getWithFallback("OCRBootstrapCheckInterval", parseDuration).(time.Duration)
@@ -691,10 +1063,36 @@ func (c Config) OCRContractConfirmations(override uint16) uint16 {
 	return c.getWithFallback("OCRContractConfirmations", parseUint16).(uint16)
 }
 
+// OCRContractLogLookbackBlocks is how many blocks back from the latest block
+// an OCR job looks for the contract's latest RoundRequested log when it has
+// none saved, e.g. when a feed is first bootstrapped. 0 disables the lookup.
+func (c Config) OCRContractLogLookbackBlocks() uint64 {
+	return c.getWithFallback("OCRContractLogLookbackBlocks", parseUint64).(uint64)
+}
+
 func (c Config) OCRDatabaseTimeout() time.Duration {
 	return c.getWithFallback("OCRDatabaseTimeout", parseDuration).(time.Duration)
 }
 
+// OCRDatabaseWriteInterval is how often the OCR writes that are safe to lose,
+// the contract config and deletes of pending transmissions, are flushed to the
+// database. They are buffered in memory between flushes, which saves a few
+// database round trips per round on nodes running many OCR jobs. The protocol
+// state and pending transmissions, most of the protocol's writes, are always
+// written through. Set to 0 to write everything
+// through to the database immediately.
+func (c Config) OCRDatabaseWriteInterval() time.Duration {
+	return c.getWithFallback("OCRDatabaseWriteInterval", parseDuration).(time.Duration)
+}
+
+// OCRPartitionRetention is how long monthly partitions of the OCR state
+// tables are kept before they are detached into the ocr_archive schema, where
+// they no longer slow down vacuums and lite backups. Set to 0 to never archive
+// partitions.
+func (c Config) OCRPartitionRetention() time.Duration {
+	return c.getWithFallback("OCRPartitionRetention", parseDuration).(time.Duration)
+}
+
 func (c Config) OCRDHTLookupInterval() int {
 	return int(c.getWithFallback("OCRDHTLookupInterval", parseUint16).(uint16))
 }
@@ -775,6 +1173,12 @@ func (c Config) OperatorContractAddress() common.Address {
 	return *address
 }
 
+// PendingBridgeRunTimeout is how long a run may wait for an asynchronous
+// bridge callback before it is marked as errored. Set to 0 to wait forever.
+func (c Config) PendingBridgeRunTimeout() time.Duration {
+	return c.getWithFallback("PendingBridgeRunTimeout", parseDuration).(time.Duration)
+}
+
 // LogLevel represents the maximum level of log messages to output.
 func (c Config) LogLevel() LogLevel {
 	if c.runtimeStore != nil {
@@ -858,6 +1262,13 @@ func (c Config) MinimumRequestExpiration() uint64 {
 	return c.getWithFallback("MinimumRequestExpiration", parseUint64).(uint64)
 }
 
+// NodeID is a stable identifier for this node instance, used to tell apart
+// nodes that share a database. If NODE_ID is not set, a random ID is generated
+// on first boot and persisted in the root directory.
+func (c Config) NodeID() string {
+	return c.viper.GetString(EnvVarName("NodeID"))
+}
+
 // P2PListenIP is the ip that libp2p willl bind to and listen on
 func (c Config) P2PListenIP() net.IP {
 	return c.getWithFallback("P2PListenIP", parseIP).(net.IP)
@@ -928,6 +1339,41 @@ func (c Config) P2PBootstrapPeers(override []string) ([]string, error) {
 	return nil, errors.Wrap(ErrUnset, "P2P_BOOTSTRAP_PEERS")
 }
 
+// P2PDiscoveryMode is how this node finds the addresses of the other peers
+// in its OCR networks. In "dht" mode they are looked up in the DHT. In
+// "explicit" mode they are taken from the peer table given by P2P_PEERS and
+// the p2pPeers of each job, and nothing is announced to a public DHT, for
+// private networks where DHT traffic is undesirable.
+func (c Config) P2PDiscoveryMode() P2PDiscoveryMode {
+	return c.getWithFallback("P2PDiscoveryMode", parseP2PDiscoveryMode).(P2PDiscoveryMode)
+}
+
+// P2PBandwidthLimit caps the OCR protocol traffic received from all peers
+// together, in bytes per second. Set to 0 for no limit.
+func (c Config) P2PBandwidthLimit() uint64 {
+	return c.getWithFallback("P2PBandwidthLimit", parseUint64).(uint64)
+}
+
+// P2PPeerBandwidthLimit caps the OCR protocol traffic received from any
+// one peer, in bytes per second. Set to 0 for no limit.
+func (c Config) P2PPeerBandwidthLimit() uint64 {
+	return c.getWithFallback("P2PPeerBandwidthLimit", parseUint64).(uint64)
+}
+
+// P2PPeers is the peer table used in the explicit P2P discovery mode, as a
+// list of multiaddrs that each end in the /p2p/ ID of the peer. The p2pPeers
+// of a job spec override it.
+func (c Config) P2PPeers(override []string) ([]string, error) {
+	if override != nil {
+		return override, nil
+	}
+	peers := c.viper.GetStringSlice(EnvVarName("P2PPeers"))
+	if peers != nil {
+		return peers, nil
+	}
+	return nil, errors.Wrap(ErrUnset, "P2P_PEERS")
+}
+
 // Port represents the port Chainlink should listen on for client requests.
 func (c Config) Port() uint16 {
 	return c.getWithFallback("Port", parseUint16).(uint16)
@@ -995,6 +1441,20 @@ func (c Config) TLSRedirect() bool {
 	return c.viper.GetBool(EnvVarName("TLSRedirect"))
 }
 
+// TLSCertReloadInterval is how often the TLS certificate and key files are
+// checked for changes. They are reloaded when changed, or when the node
+// receives SIGHUP. Set to 0 to only reload on SIGHUP.
+func (c Config) TLSCertReloadInterval() time.Duration {
+	return c.getWithFallback("TLSCertReloadInterval", parseDuration).(time.Duration)
+}
+
+// TLSClientCAPath is the file system location of the CA certificates used to
+// verify client certificates. When set, external initiators and bridge
+// callbacks must present a certificate signed by one of them.
+func (c Config) TLSClientCAPath() string {
+	return c.viper.GetString(EnvVarName("TLSClientCAPath"))
+}
+
 // UnAuthenticatedRateLimit defines the threshold to which requests unauthenticated requests get limited
 func (c Config) UnAuthenticatedRateLimit() int64 {
 	return c.viper.GetInt64(EnvVarName("UnAuthenticatedRateLimit"))
@@ -1005,11 +1465,28 @@ func (c Config) UnAuthenticatedRateLimitPeriod() models.Duration {
 	return models.MustMakeDuration(c.getWithFallback("UnAuthenticatedRateLimitPeriod", parseDuration).(time.Duration))
 }
 
+// RunTriggerRateLimit is the number of runs each client may trigger through
+// the API per RunTriggerRateLimitPeriod. Set to 0 to disable the limit.
+func (c Config) RunTriggerRateLimit() int64 {
+	return c.viper.GetInt64(EnvVarName("RunTriggerRateLimit"))
+}
+
+// RunTriggerRateLimitPeriod is the period over which RunTriggerRateLimit applies
+func (c Config) RunTriggerRateLimitPeriod() models.Duration {
+	return models.MustMakeDuration(c.getWithFallback("RunTriggerRateLimitPeriod", parseDuration).(time.Duration))
+}
+
 // KeysDir returns the path of the keys directory (used for keystore files).
 func (c Config) KeysDir() string {
 	return filepath.Join(c.RootDir(), "tempkeys")
 }
 
+// TelemetrySpoolDir is the directory holding telemetry that is waiting to be
+// sent to the explorer
+func (c Config) TelemetrySpoolDir() string {
+	return filepath.Join(c.RootDir(), "telemetry")
+}
+
 func (c Config) tlsDir() string {
 	return filepath.Join(c.RootDir(), "tls")
 }
@@ -1217,3 +1694,32 @@ func parseDatabaseBackupMode(s string) (interface{}, error) {
 		return "", fmt.Errorf("unable to parse %v into DatabaseBackupMode. Must be one of values: \"%s\", \"%s\", \"%s\"", s, DatabaseBackupModeNone, DatabaseBackupModeLite, DatabaseBackupModeFull)
 	}
 }
+
+type P2PDiscoveryMode string
+
+var (
+	P2PDiscoveryModeDHT      P2PDiscoveryMode = "dht"
+	P2PDiscoveryModeExplicit P2PDiscoveryMode = "explicit"
+)
+
+func parseP2PDiscoveryMode(s string) (interface{}, error) {
+	switch P2PDiscoveryMode(s) {
+	case P2PDiscoveryModeDHT, P2PDiscoveryModeExplicit:
+		return P2PDiscoveryMode(s), nil
+	default:
+		return "", fmt.Errorf("unable to parse %v into P2PDiscoveryMode. Must be one of values: \"%s\", \"%s\"", s, P2PDiscoveryModeDHT, P2PDiscoveryModeExplicit)
+	}
+}
+
+// ValidateP2PPeer checks that addr is a multiaddr ending in the /p2p/ ID of
+// a peer, as required for the entries of an explicit peer table
+func ValidateP2PPeer(addr string) error {
+	m, err := multiaddr.NewMultiaddr(addr)
+	if err != nil {
+		return err
+	}
+	if _, err := m.ValueForProtocol(multiaddr.P_P2P); err != nil {
+		return errors.Errorf("%s does not include a /p2p/ peer ID", addr)
+	}
+	return nil
+}
@@ -35,13 +35,18 @@ type ConfigReader interface {
 	MinimumServiceDuration() models.Duration
 	EnableExperimentalAdapters() bool
 	EthBalanceMonitorBlockDelay() uint16
+	EthExternalTxDetectionEnabled() bool
 	EthGasBumpPercent() uint16
 	EthGasBumpThreshold() uint64
 	EthGasBumpTxDepth() uint16
+	EthGasBumpDeadlineWindow() time.Duration
 	EthGasBumpWei() *big.Int
 	EthGasLimitDefault() uint64
 	EthGasPriceDefault() *big.Int
 	EthMaxGasPriceWei() *big.Int
+	EthNonceGapAutoFill() bool
+	EthNonceGapCheckInterval() time.Duration
+	EthRPCMonthlyQuota() uint64
 	EthFinalityDepth() uint
 	EthReceiptFetchBatchSize() uint32
 	EthHeadTrackerHistoryDepth() uint
@@ -53,12 +58,14 @@ type ConfigReader interface {
 	GasUpdaterBlockDelay() uint16
 	GasUpdaterBlockHistorySize() uint16
 	GasUpdaterTransactionPercentile() uint16
+	GRPCPort() uint16
 	JSONConsole() bool
 	LinkContractAddress() string
 	ExplorerURL() *url.URL
 	ExplorerAccessKey() string
 	ExplorerSecret() string
 	OperatorContractAddress() common.Address
+	PendingBridgeRunTimeout() time.Duration
 	LogLevel() LogLevel
 	LogToDisk() bool
 	LogSQLStatements() bool
@@ -79,6 +86,7 @@ type ConfigReader interface {
 	TLSPort() uint16
 	TLSRedirect() bool
 	KeysDir() string
+	KeyRecoveryWindow() time.Duration
 	tlsDir() string
 	KeyFile() string
 	CertFile() string
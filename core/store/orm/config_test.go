@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/viper"
@@ -93,6 +94,77 @@ func TestConfig_EthereumSecondaryURLs(t *testing.T) {
 	}
 }
 
+func TestConfig_APIAllowedIPs(t *testing.T) {
+	t.Parallel()
+	config := NewConfig()
+
+	nets, err := config.APIAllowedIPs()
+	require.NoError(t, err)
+	assert.Empty(t, nets)
+
+	config.Set("API_ALLOWED_IPS", "10.0.0.0/8, 127.0.0.1,::1")
+	nets, err = config.APIAllowedIPs()
+	require.NoError(t, err)
+	require.Len(t, nets, 3)
+	assert.Equal(t, "10.0.0.0/8", nets[0].String())
+	assert.Equal(t, "127.0.0.1/32", nets[1].String())
+	assert.Equal(t, "::1/128", nets[2].String())
+
+	config.Set("API_ALLOWED_IPS", "10.0.0.0/33")
+	_, err = config.APIAllowedIPs()
+	assert.Error(t, err)
+	assert.Error(t, config.Validate())
+
+	config.Set("API_ALLOWED_IPS", "localhost")
+	_, err = config.APIAllowedIPs()
+	assert.Error(t, err)
+}
+
+func TestConfig_TaskTypeAllowed(t *testing.T) {
+	t.Parallel()
+	config := NewConfig()
+
+	assert.True(t, config.TaskTypeAllowed("ethtx"))
+
+	config.Set("DISALLOWED_TASK_TYPES", "ethtx, SQL")
+	assert.Equal(t, []string{"ethtx", "sql"}, config.DisallowedTaskTypes())
+	assert.False(t, config.TaskTypeAllowed("EthTx"))
+	assert.False(t, config.TaskTypeAllowed("sql"))
+	assert.True(t, config.TaskTypeAllowed("http"))
+
+	config.Set("ALLOWED_TASK_TYPES", "http,sql")
+	assert.True(t, config.TaskTypeAllowed("http"))
+	assert.False(t, config.TaskTypeAllowed("sql"))
+	assert.False(t, config.TaskTypeAllowed("jsonparse"))
+}
+
+func TestConfig_P2PPeers(t *testing.T) {
+	t.Parallel()
+	config := NewConfig()
+
+	assert.Equal(t, P2PDiscoveryModeDHT, config.P2PDiscoveryMode())
+	_, err := config.P2PPeers(nil)
+	assert.Error(t, err)
+
+	config.Set("P2P_DISCOVERY_MODE", "explicit")
+	config.Set("P2P_PEERS", "/ip4/10.0.0.1/tcp/1234/p2p/16Uiu2HAm58SP7UL8zsnpeuwHfytLocaqgnyaYKP8wu7qRdrixLju")
+	assert.Equal(t, P2PDiscoveryModeExplicit, config.P2PDiscoveryMode())
+	peers, err := config.P2PPeers(nil)
+	require.NoError(t, err)
+	assert.Len(t, peers, 1)
+	assert.NoError(t, config.Validate())
+
+	override, err := config.P2PPeers([]string{})
+	require.NoError(t, err)
+	assert.Empty(t, override)
+
+	config.Set("P2P_PEERS", "/ip4/10.0.0.1/tcp/1234")
+	assert.Error(t, config.Validate())
+
+	_, err = parseP2PDiscoveryMode("gossip")
+	assert.Error(t, err)
+}
+
 func TestConfig_readFromFile(t *testing.T) {
 	v := viper.New()
 	v.Set("ROOT", "../../../tools/clroot/")
@@ -105,6 +177,19 @@ func TestConfig_readFromFile(t *testing.T) {
 	assert.Equal(t, config.TLSPort(), uint16(0))
 }
 
+func TestConfig_chainProfile(t *testing.T) {
+	v := viper.New()
+	v.Set("ETH_CHAIN_ID", "42161")
+	v.Set("ETH_GAS_BUMP_THRESHOLD", "5")
+
+	config := newConfigWithViper(v)
+	assert.Equal(t, eth.ChainTypeArbitrum, config.ChainType())
+	assert.False(t, config.GasUpdaterEnabled())
+	assert.Equal(t, big.NewInt(1000000000), config.EthGasPriceDefault())
+	// Explicitly set values take precedence over the profile
+	assert.Equal(t, uint64(5), config.EthGasBumpThreshold())
+}
+
 func TestStore_addressParser(t *testing.T) {
 	zero := &common.Address{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 	fifteen := &common.Address{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 15}
@@ -0,0 +1,347 @@
+package orm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+	null "gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// Sensitive columns are marked with the `encrypted` gorm tag setting, e.g.
+// `gorm:"encrypted"`, on string or null.String fields. Once
+// EnableColumnEncryption is called, their values are encrypted before being
+// written and decrypted after being read, so the rest of the node only ever
+// sees plaintext.
+const (
+	encryptedTagSetting = "ENCRYPTED"
+	// encryptedPrefix marks a column value as ciphertext, so that values
+	// written before encryption was enabled can still be read
+	encryptedPrefix = "enc:v1:"
+	// columnKeySize is the size of the AES-256 key
+	columnKeySize = 32
+	// columnKeyCheck is encrypted and stored alongside the salt, so that a
+	// wrong key is detected at startup rather than when a column is read
+	columnKeyCheck = "chainlink column encryption"
+)
+
+// encryptedModels are the models with sensitive columns. Plaintext values
+// left in them from before encryption was enabled are encrypted on startup.
+var encryptedModels = []interface{}{
+	&models.BridgeType{},
+	&models.Datasource{},
+	&models.ExternalInitiator{},
+	&models.User{},
+}
+
+// RegisterEncryptedModel adds a model with sensitive columns to those whose
+// plaintext values are encrypted on startup, for models such as job specs
+// that are defined in packages this one can't import. It must be called
+// before EnableColumnEncryption, typically from an init function.
+func RegisterEncryptedModel(model interface{}) {
+	encryptedModels = append(encryptedModels, model)
+}
+
+// ColumnCipher encrypts the values of sensitive columns with AES-GCM
+type ColumnCipher struct {
+	aead cipher.AEAD
+}
+
+// NewColumnCipher returns a ColumnCipher using the given 256-bit key
+func NewColumnCipher(key []byte) (*ColumnCipher, error) {
+	if len(key) != columnKeySize {
+		return nil, fmt.Errorf("column encryption key must be %d bytes, got %d", columnKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &ColumnCipher{aead: aead}, nil
+}
+
+// DeriveColumnEncryptionKey derives the column encryption key from the
+// keystore password
+func DeriveColumnEncryptionKey(password string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(password), salt, 1<<15, 8, 1, columnKeySize)
+}
+
+// ReadColumnEncryptionKey reads a hex encoded column encryption key from a
+// file
+func ReadColumnEncryptionKey(path string) ([]byte, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read database encryption key file")
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(contents)), "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "database encryption key file must contain a hex encoded key")
+	}
+	return key, nil
+}
+
+// Encrypt returns the ciphertext for a column value
+func (c *ColumnCipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of a column value. Values that are not
+// encrypted are returned as is.
+func (c *ColumnCipher) Decrypt(value string) (string, error) {
+	if !IsEncryptedColumnValue(value) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", errors.Wrap(err, "malformed encrypted column value")
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("malformed encrypted column value")
+	}
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt column value, the database encryption key may have changed")
+	}
+	return string(plaintext), nil
+}
+
+// encryptPlaintext encrypts value unless it is already encrypted, which is
+// the case when a model read before encryption was enabled is saved again
+func (c *ColumnCipher) encryptPlaintext(value string) (string, error) {
+	if IsEncryptedColumnValue(value) {
+		return value, nil
+	}
+	return c.Encrypt(value)
+}
+
+// IsEncryptedColumnValue returns whether a column value was written by a
+// ColumnCipher
+func IsEncryptedColumnValue(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// ColumnEncryptionSalt returns the salt used to derive the column encryption
+// key from the keystore password, generating it the first time it's needed
+func (orm *ORM) ColumnEncryptionSalt() ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+	err := orm.DB.Exec(`
+		INSERT INTO column_encryption (id, salt, key_check, created_at)
+		VALUES (1, ?, '', ?)
+		ON CONFLICT (id) DO NOTHING
+	`, salt, time.Now()).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to store column encryption salt")
+	}
+	err = orm.DB.Raw(`SELECT salt FROM column_encryption WHERE id = 1`).Row().Scan(&salt)
+	return salt, errors.Wrap(err, "failed to load column encryption salt")
+}
+
+// EnableColumnEncryption encrypts sensitive columns using c from now on. It
+// errors if c doesn't use the same key as the last time encryption was
+// enabled, and encrypts any values that were written in plaintext.
+func (orm *ORM) EnableColumnEncryption(c *ColumnCipher) error {
+	if err := orm.checkColumnEncryptionKey(c); err != nil {
+		return err
+	}
+
+	callbacks := orm.DB.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("chainlink:encrypt_columns", c.transformColumns(c.encryptPlaintext)),
+		callbacks.Create().After("gorm:create").Register("chainlink:decrypt_created_columns", c.transformColumns(c.Decrypt)),
+		callbacks.Update().Before("gorm:update").Register("chainlink:encrypt_columns", c.transformColumns(c.encryptPlaintext)),
+		callbacks.Update().After("gorm:update").Register("chainlink:decrypt_updated_columns", c.transformColumns(c.Decrypt)),
+		callbacks.Query().After("gorm:query").Register("chainlink:decrypt_columns", c.transformColumns(c.Decrypt)),
+	} {
+		if err != nil {
+			return errors.Wrap(err, "failed to register column encryption callbacks")
+		}
+	}
+
+	return orm.encryptPlaintextColumns(c)
+}
+
+func (orm *ORM) checkColumnEncryptionKey(c *ColumnCipher) error {
+	var check string
+	err := orm.DB.Raw(`SELECT key_check FROM column_encryption WHERE id = 1`).Row().Scan(&check)
+	if err == sql.ErrNoRows || (err == nil && check == "") {
+		if check, err = c.Encrypt(columnKeyCheck); err != nil {
+			return err
+		}
+		return orm.DB.Exec(`
+			INSERT INTO column_encryption (id, salt, key_check, created_at)
+			VALUES (1, '', ?, ?)
+			ON CONFLICT (id) DO UPDATE SET key_check = EXCLUDED.key_check
+		`, check, time.Now()).Error
+	} else if err != nil {
+		return errors.Wrap(err, "failed to load column encryption key check")
+	}
+	if plaintext, err := c.Decrypt(check); err != nil || plaintext != columnKeyCheck {
+		return errors.New("the database encryption key does not match the one the database was encrypted with")
+	}
+	return nil
+}
+
+// encryptPlaintextColumns encrypts the values of sensitive columns that were
+// written before encryption was enabled
+func (orm *ORM) encryptPlaintextColumns(c *ColumnCipher) error {
+	for _, model := range encryptedModels {
+		stmt := &gorm.Statement{DB: orm.DB}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		pk := stmt.Schema.PrioritizedPrimaryField
+		for _, field := range encryptedFields(stmt.Schema) {
+			var count int
+			err := orm.Transaction(func(tx *gorm.DB) error {
+				rows, err := tx.Raw(fmt.Sprintf(
+					`SELECT %s, %s FROM %s WHERE %[2]s <> '' AND %[2]s NOT LIKE '%s%%' FOR UPDATE`,
+					pk.DBName, field.DBName, stmt.Schema.Table, encryptedPrefix,
+				)).Rows()
+				if err != nil {
+					return err
+				}
+				defer logger.ErrorIfCalling(rows.Close)
+
+				type update struct {
+					id         interface{}
+					ciphertext string
+				}
+				var updates []update
+				for rows.Next() {
+					var u update
+					var plaintext string
+					if err = rows.Scan(&u.id, &plaintext); err != nil {
+						return err
+					}
+					if u.ciphertext, err = c.Encrypt(plaintext); err != nil {
+						return err
+					}
+					updates = append(updates, u)
+				}
+				if err = rows.Err(); err != nil {
+					return err
+				}
+				for _, u := range updates {
+					err = tx.Exec(fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`, stmt.Schema.Table, field.DBName, pk.DBName), u.ciphertext, u.id).Error
+					if err != nil {
+						return err
+					}
+				}
+				count = len(updates)
+				return nil
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to encrypt %s.%s", stmt.Schema.Table, field.DBName)
+			}
+			if count > 0 {
+				logger.Infow("Encrypted existing column values", "table", stmt.Schema.Table, "column", field.DBName, "count", count)
+			}
+		}
+	}
+	return nil
+}
+
+// transformColumns returns a gorm callback that applies transform to the
+// sensitive columns of the model being written or read. It also runs after
+// failed statements, so that models are never left holding ciphertext.
+func (c *ColumnCipher) transformColumns(transform func(string) (string, error)) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Schema == nil {
+			return
+		}
+		fields := encryptedFields(db.Statement.Schema)
+		if len(fields) == 0 {
+			return
+		}
+
+		apply := func(rv reflect.Value) {
+			// The destination may be a different type to the model being
+			// queried, in which case its fields can't be set
+			if rv.Type() != db.Statement.Schema.ModelType {
+				return
+			}
+			for _, field := range fields {
+				value, zero := field.ValueOf(rv)
+				if zero {
+					continue
+				}
+				var transformed interface{}
+				var err error
+				switch v := value.(type) {
+				case string:
+					transformed, err = transform(v)
+				case null.String:
+					if !v.Valid {
+						continue
+					}
+					var s string
+					s, err = transform(v.String)
+					transformed = null.StringFrom(s)
+				default:
+					continue
+				}
+				if err != nil {
+					_ = db.AddError(err)
+					return
+				}
+				if err := field.Set(rv, transformed); err != nil {
+					_ = db.AddError(err)
+					return
+				}
+			}
+		}
+
+		switch rv := db.Statement.ReflectValue; rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+					apply(elem)
+				}
+			}
+		case reflect.Struct:
+			apply(rv)
+		}
+	}
+}
+
+var nullStringType = reflect.TypeOf(null.String{})
+
+func encryptedFields(s *schema.Schema) []*schema.Field {
+	var fields []*schema.Field
+	for _, field := range s.Fields {
+		if _, ok := field.TagSettings[encryptedTagSetting]; !ok {
+			continue
+		}
+		if field.FieldType.Kind() == reflect.String || field.FieldType == nullStringType {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,87 @@
+package orm
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// Feature is a flag gating a job type or pipeline task that is still
+// experimental. Each is set with its FEATURE_* env var, and may be overridden
+// at runtime in the database, which takes precedence over the env var.
+type Feature string
+
+const (
+	FeatureFlagExternalInitiators Feature = "FeatureExternalInitiators"
+	FeatureFlagExperimentalTasks  Feature = "FeatureExperimentalTasks"
+	FeatureFlagFluxMonitor        Feature = "FeatureFluxMonitor"
+	FeatureFlagFluxMonitorV2      Feature = "FeatureFluxMonitorV2"
+	FeatureFlagMessageQueue       Feature = "FeatureMessageQueue"
+	FeatureFlagOffchainReporting  Feature = "FeatureOffchainReporting"
+	FeatureFlagShadow             Feature = "FeatureShadow"
+)
+
+// Features are all the feature flags
+var Features = []Feature{
+	FeatureFlagExternalInitiators,
+	FeatureFlagExperimentalTasks,
+	FeatureFlagFluxMonitor,
+	FeatureFlagFluxMonitorV2,
+	FeatureFlagMessageQueue,
+	FeatureFlagOffchainReporting,
+	FeatureFlagShadow,
+}
+
+// EnvVarName returns the env var that sets the feature flag, such as
+// FEATURE_OFFCHAIN_REPORTING
+func (f Feature) EnvVarName() string {
+	return EnvVarName(string(f))
+}
+
+// ParseFeature returns the feature flag set by the env var name
+func ParseFeature(name string) (Feature, error) {
+	for _, f := range Features {
+		if strings.EqualFold(f.EnvVarName(), name) {
+			return f, nil
+		}
+	}
+	return "", errors.Errorf("unknown feature flag %s", name)
+}
+
+// FeatureEnabled reports whether the feature flag is enabled, by its runtime
+// override if it has one and otherwise by its env var
+func (c Config) FeatureEnabled(f Feature) bool {
+	if override := c.FeatureOverride(f); override != nil {
+		return *override
+	}
+	return c.getWithFallback(string(f), parseBool).(bool)
+}
+
+// FeatureOverride returns the runtime override of the feature flag, or nil
+// if it doesn't have one
+func (c Config) FeatureOverride(f Feature) *bool {
+	if c.runtimeStore == nil {
+		return nil
+	}
+	enabled, err := c.runtimeStore.GetConfigBoolValue(string(f))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Warnw("Error while trying to fetch feature flag override", "feature", f.EnvVarName(), "error", err)
+		return nil
+	}
+	return enabled
+}
+
+// SetFeatureEnabled saves a runtime override of the feature flag. Flags
+// gating job types take effect for running jobs on the next restart, and for
+// new jobs immediately.
+func (c Config) SetFeatureEnabled(ctx context.Context, f Feature, enabled bool) error {
+	if c.runtimeStore == nil {
+		return errors.New("No runtime store installed")
+	}
+	return c.runtimeStore.SetConfigStrValue(ctx, string(f), strconv.FormatBool(enabled))
+}
@@ -0,0 +1,48 @@
+package orm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_FeatureEnabled(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	config := store.Config
+
+	config.Set("FEATURE_OFFCHAIN_REPORTING", true)
+	assert.True(t, config.FeatureEnabled(orm.FeatureFlagOffchainReporting))
+	assert.False(t, config.FeatureEnabled(orm.FeatureFlagExperimentalTasks))
+
+	// No orm installed
+	require.Error(t, config.SetFeatureEnabled(context.Background(), orm.FeatureFlagOffchainReporting, false))
+
+	config.SetRuntimeStore(store.ORM)
+	assert.Nil(t, config.FeatureOverride(orm.FeatureFlagOffchainReporting))
+
+	// The override takes precedence over the env var
+	require.NoError(t, config.SetFeatureEnabled(context.Background(), orm.FeatureFlagOffchainReporting, false))
+	assert.False(t, config.FeatureEnabled(orm.FeatureFlagOffchainReporting))
+	assert.False(t, config.FeatureOffchainReporting())
+	require.NotNil(t, config.FeatureOverride(orm.FeatureFlagOffchainReporting))
+
+	require.NoError(t, config.SetFeatureEnabled(context.Background(), orm.FeatureFlagExperimentalTasks, true))
+	assert.True(t, config.FeatureExperimentalTasks())
+}
+
+func TestParseFeature(t *testing.T) {
+	t.Parallel()
+
+	f, err := orm.ParseFeature("FEATURE_FLUX_MONITOR_V2")
+	require.NoError(t, err)
+	assert.Equal(t, orm.FeatureFlagFluxMonitorV2, f)
+
+	_, err = orm.ParseFeature("FEATURE_TIME_TRAVEL")
+	assert.EqualError(t, err, "unknown feature flag FEATURE_TIME_TRAVEL")
+}
@@ -541,6 +541,82 @@ func (orm *ORM) DeleteExternalInitiator(name string) error {
 	return err
 }
 
+// ErrForwarderInUse is returned when deleting a forwarder that jobs still
+// transmit through
+var ErrForwarderInUse = errors.New("forwarder is in use by one or more jobs")
+
+// CreateForwarder registers a forwarder contract that jobs may transmit through
+func (orm *ORM) CreateForwarder(address common.Address) (fwd models.Forwarder, err error) {
+	if err = orm.MustEnsureAdvisoryLock(); err != nil {
+		return fwd, err
+	}
+	fwd.Address = models.EIP55Address(address.Hex())
+	return fwd, orm.DB.Create(&fwd).Error
+}
+
+// Forwarders returns all registered forwarder contracts
+func (orm *ORM) Forwarders() (fwds []models.Forwarder, err error) {
+	if err = orm.MustEnsureAdvisoryLock(); err != nil {
+		return nil, err
+	}
+	return fwds, orm.DB.Order("id ASC").Find(&fwds).Error
+}
+
+// DeleteForwarder removes a forwarder contract, unless a job still uses it
+func (orm *ORM) DeleteForwarder(id int64) error {
+	if err := orm.MustEnsureAdvisoryLock(); err != nil {
+		return err
+	}
+	result := orm.DB.Delete(&models.Forwarder{}, id)
+	var pqErr *pgconn.PgError
+	if errors.As(result.Error, &pqErr) && pqErr.Code == "23503" {
+		return ErrForwarderInUse
+	} else if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateDatasource registers an external database for the sql task
+func (orm *ORM) CreateDatasource(ds *models.Datasource) error {
+	if err := orm.MustEnsureAdvisoryLock(); err != nil {
+		return err
+	}
+	return orm.DB.Create(ds).Error
+}
+
+// Datasources returns all registered external databases, ordered by name
+func (orm *ORM) Datasources() (dss []models.Datasource, err error) {
+	if err = orm.MustEnsureAdvisoryLock(); err != nil {
+		return nil, err
+	}
+	return dss, orm.DB.Order("name ASC").Find(&dss).Error
+}
+
+// FindDatasource looks up a registered external database by name
+func (orm *ORM) FindDatasource(name models.TaskType) (ds models.Datasource, err error) {
+	if err = orm.MustEnsureAdvisoryLock(); err != nil {
+		return ds, err
+	}
+	return ds, orm.DB.First(&ds, "name = ?", name.String()).Error
+}
+
+// DeleteDatasource removes a registered external database
+func (orm *ORM) DeleteDatasource(name models.TaskType) error {
+	if err := orm.MustEnsureAdvisoryLock(); err != nil {
+		return err
+	}
+	result := orm.DB.Exec("DELETE FROM datasources WHERE name = ?", name.String())
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // FindExternalInitiator finds an external initiator given an authentication request
 func (orm *ORM) FindExternalInitiator(
 	eia *auth.Token,
@@ -778,6 +854,22 @@ func (orm *ORM) UnscopedJobRunsWithStatus(cb func(*models.JobRun), statuses ...m
 	})
 }
 
+// UnscopedPendingBridgeRunsUpdatedBefore returns the JobRuns, including those
+// that were soft deleted, that have been waiting for a bridge callback since
+// before the given time.
+func (orm *ORM) UnscopedPendingBridgeRunsUpdatedBefore(before time.Time) ([]models.JobRun, error) {
+	if err := orm.MustEnsureAdvisoryLock(); err != nil {
+		return nil, err
+	}
+	var runs []models.JobRun
+	err := orm.Unscoped().
+		preloadJobRuns().
+		Where("job_runs.status = ? AND job_runs.updated_at < ?", models.RunStatusPendingBridge, before).
+		Order("job_runs.created_at asc").
+		Find(&runs).Error
+	return runs, errors.Wrap(err, "error fetching pending bridge runs")
+}
+
 // AnyJobWithType returns true if there is at least one job associated with
 // the type name specified and false otherwise
 func (orm *ORM) AnyJobWithType(taskTypeName string) (bool, error) {
@@ -820,6 +912,31 @@ func (orm *ORM) FindJobIDsWithBridge(bridgeName string) ([]models.JobID, error)
 	return bridgeJobIDs, nil
 }
 
+// FindTaskTypesWithoutBridges returns the types of each job's tasks that
+// don't name a bridge. These are either native adapters or bridges that
+// don't exist.
+func (orm *ORM) FindTaskTypesWithoutBridges() (map[models.JobID][]models.TaskType, error) {
+	var rows []struct {
+		JobSpecID models.JobID
+		Type      models.TaskType
+	}
+	err := orm.DB.Raw(`
+		SELECT DISTINCT task_specs.job_spec_id, task_specs.type FROM task_specs
+		JOIN job_specs ON job_specs.id = task_specs.job_spec_id
+		WHERE task_specs.deleted_at IS NULL AND job_specs.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM bridge_types WHERE bridge_types.name = task_specs.type)
+		ORDER BY task_specs.job_spec_id, task_specs.type
+	`).Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find task types without bridges")
+	}
+	types := make(map[models.JobID][]models.TaskType)
+	for _, row := range rows {
+		types[row.JobSpecID] = append(types[row.JobSpecID], row.Type)
+	}
+	return types, nil
+}
+
 // IdempotentInsertEthTaskRunTx creates both eth_task_run_transaction and eth_tx in one hit
 // It can be called multiple times without error as long as the outcome would have resulted in the same database state
 func (orm *ORM) IdempotentInsertEthTaskRunTx(taskRunID uuid.UUID, fromAddress common.Address, toAddress common.Address, encodedPayload []byte, gasLimit uint64) error {
@@ -1044,11 +1161,34 @@ func (orm *ORM) CreateSession(sr models.SessionRequest) (string, error) {
 		return "", errors.New("Invalid email")
 	}
 
-	if utils.CheckPasswordHash(sr.Password, user.HashedPassword) {
-		session := models.NewSession()
+	if !utils.CheckPasswordHash(sr.Password, user.HashedPassword) {
+		return "", errors.New("Invalid password")
+	}
+
+	session := models.NewSession()
+	if !user.TOTPEnabled {
 		return session.ID, orm.DB.Save(&session).Error
 	}
-	return "", errors.New("Invalid password")
+
+	// Codes are single use, so the user is locked while its code is checked
+	// and spent, so that concurrent logins cannot both use the same one
+	err = orm.convenientTransaction(func(tx *gorm.DB) error {
+		lockedUser, err := findUser(tx.Clauses(clause.Locking{Strength: "UPDATE"}))
+		if err != nil {
+			return err
+		}
+		if err = lockedUser.VerifySecondFactor(sr.TOTPCode, sr.RecoveryCode, time.Now()); err != nil {
+			return err
+		}
+		if err = tx.Save(&lockedUser).Error; err != nil {
+			return err
+		}
+		return tx.Save(&session).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return session.ID, nil
 }
 
 const constantTimeEmailLength = 256
@@ -1126,20 +1266,41 @@ func (orm *ORM) JobRunsSortedFor(id models.JobID, order SortType, offset int, li
 	return runs, count, err
 }
 
-// BridgeTypes returns bridge types ordered by name filtered limited by the
-// passed params.
-func (orm *ORM) BridgeTypes(offset int, limit int) ([]models.BridgeType, int, error) {
+// bridgeTypesListing lists the fields bridge types can be sorted and filtered
+// by
+var bridgeTypesListing = Listing{
+	Columns: map[string]string{
+		"name":          "name",
+		"url":           "url",
+		"confirmations": "confirmations",
+		"createdAt":     "created_at",
+	},
+	Key:         "name",
+	DefaultSort: "name",
+}
+
+// BridgeTypes returns the page of bridge types selected by p, ordered by name
+// by default, along with the number of bridge types matching its filters.
+func (orm *ORM) BridgeTypes(p Pagination) ([]models.BridgeType, int, error) {
 	if err := orm.MustEnsureAdvisoryLock(); err != nil {
 		return nil, 0, err
 	}
-	count, err := orm.CountOf(&models.BridgeType{})
+	filtered, err := bridgeTypesListing.Filter(orm.DB.Model(&models.BridgeType{}), p)
 	if err != nil {
 		return nil, 0, err
 	}
+	var count int64
+	if err = filtered.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
 
+	page, err := bridgeTypesListing.Page(orm.DB.Preload(clause.Associations), p)
+	if err != nil {
+		return nil, 0, err
+	}
 	var bridges []models.BridgeType
-	err = orm.getRecords(&bridges, "name asc", offset, limit)
-	return bridges, count, err
+	err = page.Find(&bridges).Error
+	return bridges, int(count), err
 }
 
 // SaveUser saves the user.
@@ -1174,6 +1335,14 @@ func (orm *ORM) UpdateBridgeType(bt *models.BridgeType, btr *models.BridgeTypeRe
 	return orm.DB.Save(bt).Error
 }
 
+// SaveBridgeType saves the changes made to a bridge type.
+func (orm *ORM) SaveBridgeType(bt *models.BridgeType) error {
+	if err := orm.MustEnsureAdvisoryLock(); err != nil {
+		return err
+	}
+	return orm.DB.Save(bt).Error
+}
+
 // CreateInitiator saves the initiator.
 func (orm *ORM) CreateInitiator(initr *models.Initiator) error {
 	if err := orm.MustEnsureAdvisoryLock(); err != nil {
@@ -1307,6 +1476,40 @@ func (orm *ORM) AllKeys() ([]models.Key, error) {
 	return keys, orm.DB.Order("created_at ASC, address ASC").Find(&keys).Error
 }
 
+// keysListing lists the fields keys can be sorted and filtered by
+var keysListing = Listing{
+	Columns: map[string]string{
+		"id":            "id",
+		"isFunding":     "is_funding",
+		"isTransmitter": "is_transmitter",
+		"createdAt":     "created_at",
+	},
+	Key:         "id",
+	DefaultSort: "createdAt",
+}
+
+// Keys returns the page of keys, including the funding key, selected by p,
+// oldest first by default, along with the number of keys matching its
+// filters.
+func (orm *ORM) Keys(p Pagination) ([]models.Key, int, error) {
+	filtered, err := keysListing.Filter(orm.DB.Model(&models.Key{}), p)
+	if err != nil {
+		return nil, 0, err
+	}
+	var count int64
+	if err = filtered.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page, err := keysListing.Page(orm.DB, p)
+	if err != nil {
+		return nil, 0, err
+	}
+	var keys []models.Key
+	err = page.Find(&keys).Error
+	return keys, int(count), err
+}
+
 // SendKeys will return only the keys that are not is_funding=true.
 func (orm *ORM) SendKeys() ([]models.Key, error) {
 	var keys []models.Key
@@ -1321,6 +1524,24 @@ func (orm *ORM) KeyByAddress(address common.Address) (models.Key, error) {
 	return key, err
 }
 
+// UpdateKeySettings replaces the per-key limits and role of the key with the
+// given address
+func (orm *ORM) UpdateKeySettings(address common.Address, settings models.KeySettings) (models.Key, error) {
+	key, err := orm.KeyByAddress(address)
+	if err != nil {
+		return key, err
+	}
+	if settings.IsTransmitter && key.IsFunding {
+		return key, errors.New("the funding key cannot be used as a transmitter")
+	}
+	key.IsTransmitter = settings.IsTransmitter
+	key.ChainID = settings.ChainID
+	key.MaxGasPriceWei = settings.MaxGasPriceWei
+	key.MaxInFlightTransactions = settings.MaxInFlightTransactions
+	err = orm.DB.Model(&key).Select("is_transmitter", "chain_id", "max_gas_price_wei", "max_in_flight_transactions").Updates(&key).Error
+	return key, err
+}
+
 // KeyExists returns true if a key exists in the database for this address
 func (orm *ORM) KeyExists(address common.Address) (bool, error) {
 	var key models.Key
@@ -1384,6 +1605,7 @@ func (orm *ORM) FindEncryptedSecretVRFKeys(where ...vrfkey.EncryptedVRFKey) (
 // GetRoundRobinAddress queries the database for the address of a random ethereum key derived from the id.
 // This takes an optional param for a slice of addresses it should pick from. Leave empty to pick from all
 // addresses in the database.
+// Keys reserved for OCR transmissions are never picked.
 // NOTE: We can add more advanced logic here later such as sorting by priority
 // etc
 func (orm *ORM) GetRoundRobinAddress(addresses ...common.Address) (address common.Address, err error) {
@@ -1391,7 +1613,7 @@ func (orm *ORM) GetRoundRobinAddress(addresses ...common.Address) (address commo
 		q := tx.
 			Clauses(clause.Locking{Strength: "UPDATE"}).
 			Order("last_used ASC NULLS FIRST, id ASC")
-		q = q.Where("is_funding = FALSE")
+		q = q.Where("is_funding = FALSE AND is_transmitter = FALSE")
 		if len(addresses) > 0 {
 			q = q.Where("address in (?)", addresses)
 		}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -1049,6 +1050,51 @@ func TestORM_CreateSession(t *testing.T) {
 	}
 }
 
+func TestORM_CreateSession_SingleUseSecondFactor(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	user := cltest.MustRandomUser()
+	now := time.Now()
+	secret, err := user.BeginTOTPEnrollment()
+	require.NoError(t, err)
+	code, err := auth.TOTPCode(secret, auth.TOTPCounter(now))
+	require.NoError(t, err)
+	recoveryCodes, err := user.ConfirmTOTPEnrollment(code, now)
+	require.NoError(t, err)
+	require.NoError(t, store.SaveUser(&user))
+
+	// Concurrent logins with the same recovery code must not both succeed
+	const logins = 5
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var succeeded int
+	for i := 0; i < logins; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.CreateSession(models.SessionRequest{
+				Email:        user.Email,
+				Password:     cltest.Password,
+				RecoveryCode: recoveryCodes[0],
+			})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, succeeded)
+
+	saved, err := store.FindUser()
+	require.NoError(t, err)
+	assert.Len(t, saved.RecoveryCodeHashes, models.RecoveryCodeCount-1)
+}
+
 func TestORM_AllSyncEvents(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
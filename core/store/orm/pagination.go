@@ -0,0 +1,114 @@
+package orm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPagination is returned when a Pagination sorts or filters by a
+// field that the listing does not support
+var ErrInvalidPagination = errors.New("invalid pagination")
+
+// Pagination selects one page of a listing, in a given order and optionally
+// filtered by field values.
+type Pagination struct {
+	Offset int
+	// Limit is the maximum number of records returned. Zero means no limit.
+	Limit int
+	// Sort is the field to order by, prefixed with - for descending order.
+	// Empty sorts in the listing's default order.
+	Sort string
+	// Filters restricts the listing to records whose fields equal the given
+	// values
+	Filters map[string]string
+	// Cursor, when set, is used instead of Offset: the page starts after the
+	// record whose key is Cursor. Unlike offsets, cursors are not shifted by
+	// records created while paging. Cursors require sorting by the key.
+	Cursor string
+}
+
+// Listing describes the fields a list query can be sorted and filtered by
+type Listing struct {
+	// Columns maps each field to its column
+	Columns map[string]string
+	// Key is the field that uniquely identifies a record. It breaks ties
+	// when sorting, and is the field that cursors refer to.
+	Key string
+	// DefaultSort is used when a Pagination does not specify Sort
+	DefaultSort string
+}
+
+// Filter restricts db to the records matching p's filters
+func (l Listing) Filter(db *gorm.DB, p Pagination) (*gorm.DB, error) {
+	// Sorted, so that the same filters always build the same query
+	fields := make([]string, 0, len(p.Filters))
+	for field := range p.Filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		column, exists := l.Columns[field]
+		if !exists {
+			return nil, errors.Wrapf(ErrInvalidPagination, "cannot filter by %q", field)
+		}
+		db = db.Where(fmt.Sprintf("%s = ?", column), p.Filters[field])
+	}
+	return db, nil
+}
+
+// Page restricts db to the page of records selected by p, in p's order
+func (l Listing) Page(db *gorm.DB, p Pagination) (*gorm.DB, error) {
+	db, err := l.Filter(db, p)
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy := p.Sort
+	if sortBy == "" {
+		sortBy = l.DefaultSort
+		// A cursor keeps the default direction, but sorts by the key
+		if p.Cursor != "" && strings.HasPrefix(sortBy, "-") {
+			sortBy = "-" + l.Key
+		} else if p.Cursor != "" {
+			sortBy = l.Key
+		}
+	}
+	field := strings.TrimPrefix(sortBy, "-")
+	direction := Ascending
+	if field != sortBy {
+		direction = Descending
+	}
+	column, exists := l.Columns[field]
+	if !exists {
+		return nil, errors.Wrapf(ErrInvalidPagination, "cannot sort by %q", field)
+	}
+
+	keyColumn := l.Columns[l.Key]
+	order := fmt.Sprintf("%s %s", column, direction)
+	if field != l.Key {
+		order += fmt.Sprintf(", %s %s", keyColumn, direction)
+	}
+	db = db.Order(order)
+
+	if p.Cursor != "" {
+		if field != l.Key {
+			return nil, errors.Wrapf(ErrInvalidPagination, "cursors require sorting by %q", l.Key)
+		}
+		if direction == Descending {
+			db = db.Where(fmt.Sprintf("%s < ?", keyColumn), p.Cursor)
+		} else {
+			db = db.Where(fmt.Sprintf("%s > ?", keyColumn), p.Cursor)
+		}
+	} else if p.Offset > 0 {
+		db = db.Offset(p.Offset)
+	}
+	if p.Limit > 0 {
+		db = db.Limit(p.Limit)
+	}
+	return db, nil
+}
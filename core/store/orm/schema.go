@@ -15,13 +15,17 @@ import (
 
 // ConfigSchema records the schema of configuration at the type level
 type ConfigSchema struct {
+	APIAllowedIPs                             string          `env:"API_ALLOWED_IPS"`
 	AdminCredentialsFile                      string          `env:"ADMIN_CREDENTIALS_FILE" default:"$ROOT/apicredentials"`
+	AllowedTaskTypes                          string          `env:"ALLOWED_TASK_TYPES"`
 	AllowOrigins                              string          `env:"ALLOW_ORIGINS" default:"http://localhost:3000,http://localhost:6688"`
 	AuthenticatedRateLimit                    int64           `env:"AUTHENTICATED_RATE_LIMIT" default:"1000"`
 	AuthenticatedRateLimitPeriod              time.Duration   `env:"AUTHENTICATED_RATE_LIMIT_PERIOD" default:"1m"`
 	BalanceMonitorEnabled                     bool            `env:"BALANCE_MONITOR_ENABLED" default:"true"`
 	BlockBackfillDepth                        string          `env:"BLOCK_BACKFILL_DEPTH" default:"10"`
 	BridgeResponseURL                         url.URL         `env:"BRIDGE_RESPONSE_URL"`
+	BridgeSigningAddress                      common.Address  `env:"BRIDGE_SIGNING_ADDRESS"`
+	BridgeCheckInterval                       time.Duration   `env:"BRIDGE_CHECK_INTERVAL" default:"10m"`
 	ChainID                                   big.Int         `env:"ETH_CHAIN_ID" default:"1"`
 	ClientNodeURL                             string          `env:"CLIENT_NODE_URL" default:"http://localhost:6688"`
 	DatabaseTimeout                           models.Duration `env:"DATABASE_TIMEOUT" default:"0"`
@@ -32,15 +36,20 @@ type ConfigSchema struct {
 	DatabaseBackupMode                        string          `env:"DATABASE_BACKUP_MODE" default:"none"`
 	DatabaseBackupFrequency                   time.Duration   `env:"DATABASE_BACKUP_FREQUENCY" default:"0m"`
 	DatabaseBackupURL                         *url.URL        `env:"DATABASE_BACKUP_URL" default:""`
+	DatabaseEncryptionKeyFile                 string          `env:"DATABASE_ENCRYPTION_KEY_FILE"`
 	DefaultHTTPLimit                          int64           `env:"DEFAULT_HTTP_LIMIT" default:"32768"`
 	DefaultHTTPTimeout                        models.Duration `env:"DEFAULT_HTTP_TIMEOUT" default:"15s"`
 	DefaultHTTPAllowUnrestrictedNetworkAccess bool            `env:"DEFAULT_HTTP_ALLOW_UNRESTRICTED_NETWORK_ACCESS" default:"false"`
 	Dev                                       bool            `env:"CHAINLINK_DEV" default:"false"`
+	DisallowedTaskTypes                       string          `env:"DISALLOWED_TASK_TYPES"`
 	EnableExperimentalAdapters                bool            `env:"ENABLE_EXPERIMENTAL_ADAPTERS" default:"false"`
 	FeatureExternalInitiators                 bool            `env:"FEATURE_EXTERNAL_INITIATORS" default:"false"`
+	FeatureExperimentalTasks                  bool            `env:"FEATURE_EXPERIMENTAL_TASKS" default:"false"`
 	FeatureFluxMonitor                        bool            `env:"FEATURE_FLUX_MONITOR" default:"true"`
 	FeatureFluxMonitorV2                      bool            `env:"FEATURE_FLUX_MONITOR_V2" default:"false"`
+	FeatureMessageQueue                       bool            `env:"FEATURE_MESSAGE_QUEUE" default:"false"`
 	FeatureOffchainReporting                  bool            `env:"FEATURE_OFFCHAIN_REPORTING" default:"false"`
+	FeatureShadow                             bool            `env:"FEATURE_SHADOW" default:"false"`
 	GlobalLockRetryInterval                   models.Duration `env:"GLOBAL_LOCK_RETRY_INTERVAL" default:"1s"`
 	MaximumServiceDuration                    models.Duration `env:"MAXIMUM_SERVICE_DURATION" default:"8760h" `
 	MinimumServiceDuration                    models.Duration `env:"MINIMUM_SERVICE_DURATION" default:"0s" `
@@ -48,10 +57,16 @@ type ConfigSchema struct {
 	EthGasBumpWei                             big.Int         `env:"ETH_GAS_BUMP_WEI" default:"5000000000"`
 	EthGasBumpPercent                         uint16          `env:"ETH_GAS_BUMP_PERCENT" default:"20"`
 	EthGasBumpTxDepth                         uint16          `env:"ETH_GAS_BUMP_TX_DEPTH" default:"10"`
+	EthGasBumpDeadlineWindow                  time.Duration   `env:"ETH_GAS_BUMP_DEADLINE_WINDOW" default:"0s"`
 	EthGasLimitDefault                        uint64          `env:"ETH_GAS_LIMIT_DEFAULT" default:"500000"`
 	EthGasPriceDefault                        big.Int         `env:"ETH_GAS_PRICE_DEFAULT" default:"20000000000"`
 	EthMaxGasPriceWei                         uint64          `env:"ETH_MAX_GAS_PRICE_WEI" default:"1500000000000"`
 	EthMaxUnconfirmedTransactions             uint64          `env:"ETH_MAX_UNCONFIRMED_TRANSACTIONS" default:"500"`
+	EthExternalTxDetectionEnabled             bool            `env:"ETH_EXTERNAL_TX_DETECTION_ENABLED" default:"true"`
+	EthNonceGapCheckInterval                  time.Duration   `env:"ETH_NONCE_GAP_CHECK_INTERVAL" default:"1m"`
+	EthNonceGapAutoFill                       bool            `env:"ETH_NONCE_GAP_AUTO_FILL" default:"false"`
+	EthRPCMonthlyQuota                        uint64          `env:"ETH_RPC_MONTHLY_QUOTA" default:"0"`
+	EthReadCacheTTL                           time.Duration   `env:"ETH_READ_CACHE_TTL" default:"10s"`
 	EthFinalityDepth                          uint            `env:"ETH_FINALITY_DEPTH" default:"50"`
 	EthHeadTrackerHistoryDepth                uint            `env:"ETH_HEAD_TRACKER_HISTORY_DEPTH" default:"100"`
 	EthHeadTrackerMaxBufferSize               uint            `env:"ETH_HEAD_TRACKER_MAX_BUFFER_SIZE" default:"3"`
@@ -59,6 +74,7 @@ type ConfigSchema struct {
 	EthReceiptFetchBatchSize                  uint32          `env:"ETH_RECEIPT_FETCH_BATCH_SIZE" default:"100"`
 	EthTxResendAfterThreshold                 time.Duration   `env:"ETH_TX_RESEND_AFTER_THRESHOLD" default:"30s"`
 	EthereumURL                               string          `env:"ETH_URL" default:"ws://localhost:8546"`
+	ENSResolveInterval                        time.Duration   `env:"ENS_RESOLVE_INTERVAL" default:"1h"`
 	EthereumSecondaryURL                      string          `env:"ETH_SECONDARY_URL" default:""`
 	EthereumSecondaryURLs                     string          `env:"ETH_SECONDARY_URLS" default:""`
 	EthereumDisabled                          bool            `env:"ETH_DISABLED" default:"false"`
@@ -67,21 +83,44 @@ type ConfigSchema struct {
 	GasUpdaterBlockHistorySize                uint16          `env:"GAS_UPDATER_BLOCK_HISTORY_SIZE" default:"24"`
 	GasUpdaterTransactionPercentile           uint16          `env:"GAS_UPDATER_TRANSACTION_PERCENTILE" default:"60"`
 	GasUpdaterEnabled                         bool            `env:"GAS_UPDATER_ENABLED" default:"true"`
+	GRPCPort                                  uint16          `env:"CHAINLINK_GRPC_PORT" default:"0"`
 	HeadTimeBudget                            time.Duration   `env:"HEAD_TIME_BUDGET" default:"8s"`
 	InsecureFastScrypt                        bool            `env:"INSECURE_FAST_SCRYPT" default:"false"`
+	JobArchiveRetention                       time.Duration   `env:"JOB_ARCHIVE_RETENTION" default:"720h"`
 	JobPipelineMaxRunDuration                 time.Duration   `env:"JOB_PIPELINE_MAX_RUN_DURATION" default:"10m"`
 	JobPipelineResultWriteQueueDepth          uint64          `env:"JOB_PIPELINE_RESULT_WRITE_QUEUE_DEPTH" default:"100"`
 	JobPipelineParallelism                    uint8           `env:"JOB_PIPELINE_PARALLELISM" default:"4"`
+	JobPipelineParallelismMin                 uint8           `env:"JOB_PIPELINE_PARALLELISM_MIN" default:"1"`
+	JobPipelineParallelismMax                 uint8           `env:"JOB_PIPELINE_PARALLELISM_MAX" default:"0"`
+	JobPipelineJSONParseLimit                 int64           `env:"JOB_PIPELINE_JSON_PARSE_LIMIT" default:"32768"`
+	JobPipelineAuditMode                      bool            `env:"JOB_PIPELINE_AUDIT_MODE" default:"false"`
+	JobPipelineWarmupTimeout                  time.Duration   `env:"JOB_PIPELINE_WARMUP_TIMEOUT" default:"0s"`
+	JobSpecStrictTOML                         bool            `env:"JOB_SPEC_STRICT_TOML" default:"true"`
+	ProvisioningDir                           string          `env:"PROVISIONING_DIR"`
+	ProvisioningPrune                         bool            `env:"PROVISIONING_PRUNE" default:"false"`
+	NotifierConfigFile                        string          `env:"NOTIFIER_CONFIG_FILE"`
+	RunExportURL                              *url.URL        `env:"RUN_EXPORT_URL"`
+	RunExportFrequency                        time.Duration   `env:"RUN_EXPORT_FREQUENCY" default:"1h"`
+	RunExportFormat                           string          `env:"RUN_EXPORT_FORMAT" default:"ndjson"`
+	RunExportPartitioning                     string          `env:"RUN_EXPORT_PARTITIONING" default:"dt=2006-01-02"`
+	RunExportEndpoint                         string          `env:"RUN_EXPORT_ENDPOINT"`
+	JobOnCompleteMaxAttempts                  uint32          `env:"JOB_ON_COMPLETE_MAX_ATTEMPTS" default:"5"`
+	JobOnCompleteRetryDelay                   time.Duration   `env:"JOB_ON_COMPLETE_RETRY_DELAY" default:"5s"`
 	JobPipelineReaperInterval                 time.Duration   `env:"JOB_PIPELINE_REAPER_INTERVAL" default:"1h"`
 	JobPipelineReaperThreshold                time.Duration   `env:"JOB_PIPELINE_REAPER_THRESHOLD" default:"168h"`
+	JobSpawnerClaimBatchSize                  uint32          `env:"JOB_SPAWNER_CLAIM_BATCH_SIZE" default:"10"`
+	JobSpawnerMaxClaimedJobs                  uint32          `env:"JOB_SPAWNER_MAX_CLAIMED_JOBS" default:"0"`
 	JSONConsole                               bool            `env:"JSON_CONSOLE" default:"false"`
 	KeeperRegistrySyncInterval                time.Duration   `env:"KEEPER_REGISTRY_SYNC_INTERVAL" default:"30m"`
 	KeeperMinimumRequiredConfirmations        uint64          `env:"KEEPER_MINIMUM_REQUIRED_CONFIRMATIONS" default:"12"`
 	KeeperMaximumGracePeriod                  int64           `env:"KEEPER_MAXIMUM_GRACE_PERIOD" default:"100"`
+	KeyRecoveryWindow                         time.Duration   `env:"KEY_RECOVERY_WINDOW" default:"0"`
 	LinkContractAddress                       string          `env:"LINK_CONTRACT_ADDRESS" default:"0x514910771AF9Ca656af840dff83E8264EcF986CA"`
 	ExplorerURL                               *url.URL        `env:"EXPLORER_URL"`
 	ExplorerAccessKey                         string          `env:"EXPLORER_ACCESS_KEY"`
 	ExplorerSecret                            string          `env:"EXPLORER_SECRET"`
+	TelemetrySpoolMaxSize                     uint64          `env:"TELEMETRY_SPOOL_MAX_SIZE" default:"10485760"`
+	TelemetrySpoolReplayInterval              time.Duration   `env:"TELEMETRY_SPOOL_REPLAY_INTERVAL" default:"1s"`
 	LogLevel                                  LogLevel        `env:"LOG_LEVEL" default:"info"`
 	LogToDisk                                 bool            `env:"LOG_TO_DISK" default:"true"`
 	LogSQLStatements                          bool            `env:"LOG_SQL" default:"false"`
@@ -92,17 +131,21 @@ type ConfigSchema struct {
 	MinRequiredOutgoingConfirmations          uint64          `env:"MIN_OUTGOING_CONFIRMATIONS" default:"12"`
 	MinimumContractPayment                    assets.Link     `env:"MINIMUM_CONTRACT_PAYMENT" default:"1000000000000000000"`
 	MinimumRequestExpiration                  uint64          `env:"MINIMUM_REQUEST_EXPIRATION" default:"300"`
+	NodeID                                    string          `env:"NODE_ID"`
 	OCRObservationTimeout                     time.Duration   `env:"OCR_OBSERVATION_TIMEOUT" default:"12s"`
 	OCRObservationGracePeriod                 time.Duration   `env:"OCR_OBSERVATION_GRACE_PERIOD" default:"1s"`
 	OCRBlockchainTimeout                      time.Duration   `env:"OCR_BLOCKCHAIN_TIMEOUT" default:"20s"`
 	OCRContractSubscribeInterval              time.Duration   `env:"OCR_CONTRACT_SUBSCRIBE_INTERVAL" default:"2m"`
 	OCRContractPollInterval                   time.Duration   `env:"OCR_CONTRACT_POLL_INTERVAL" default:"1m"`
 	OCRContractConfirmations                  uint            `env:"OCR_CONTRACT_CONFIRMATIONS" default:"3"`
+	OCRContractLogLookbackBlocks              uint64          `env:"OCR_CONTRACT_LOG_LOOKBACK_BLOCKS" default:"10000"`
 	OCRBootstrapCheckInterval                 time.Duration   `env:"OCR_BOOTSTRAP_CHECK_INTERVAL" default:"20s"`
 	OCRContractTransmitterTransmitTimeout     time.Duration   `env:"OCR_CONTRACT_TRANSMITTER_TRANSMIT_TIMEOUT" default:"10s"`
 	OCRTransmitterAddress                     string          `env:"OCR_TRANSMITTER_ADDRESS"`
 	OCRKeyBundleID                            string          `env:"OCR_KEY_BUNDLE_ID"`
 	OCRDatabaseTimeout                        time.Duration   `env:"OCR_DATABASE_TIMEOUT" default:"10s"`
+	OCRDatabaseWriteInterval                  time.Duration   `env:"OCR_DATABASE_WRITE_INTERVAL" default:"0s"`
+	OCRPartitionRetention                     time.Duration   `env:"OCR_PARTITION_RETENTION" default:"0s"`
 	OCRIncomingMessageBufferSize              int             `env:"OCR_INCOMING_MESSAGE_BUFFER_SIZE" default:"10"`
 	OCROutgoingMessageBufferSize              int             `env:"OCR_OUTGOING_MESSAGE_BUFFER_SIZE" default:"10"`
 	OCRNewStreamTimeout                       time.Duration   `env:"OCR_NEW_STREAM_TIMEOUT" default:"10s"`
@@ -120,6 +163,11 @@ type ConfigSchema struct {
 	P2PPeerstoreWriteInterval                 time.Duration   `env:"P2P_PEERSTORE_WRITE_INTERVAL" default:"5m"`
 	P2PPeerID                                 models.PeerID   `env:"P2P_PEER_ID"`
 	P2PBootstrapPeers                         []string        `env:"P2P_BOOTSTRAP_PEERS"`
+	P2PDiscoveryMode                          string          `env:"P2P_DISCOVERY_MODE" default:"dht"`
+	P2PBandwidthLimit                         uint64          `env:"P2P_BANDWIDTH_LIMIT" default:"0"`
+	P2PPeerBandwidthLimit                     uint64          `env:"P2P_PEER_BANDWIDTH_LIMIT" default:"0"`
+	P2PPeers                                  []string        `env:"P2P_PEERS"`
+	PendingBridgeRunTimeout                   time.Duration   `env:"PENDING_BRIDGE_RUN_TIMEOUT" default:"0s"`
 	Port                                      uint16          `env:"CHAINLINK_PORT" default:"6688"`
 	ReaperExpiration                          models.Duration `env:"REAPER_EXPIRATION" default:"240h"`
 	ReplayFromBlock                           int64           `env:"REPLAY_FROM_BLOCK" default:"-1"`
@@ -133,9 +181,13 @@ type ConfigSchema struct {
 	TLSKeyPath                                string          `env:"TLS_KEY_PATH" `
 	TLSPort                                   uint16          `env:"CHAINLINK_TLS_PORT" default:"6689"`
 	TLSRedirect                               bool            `env:"CHAINLINK_TLS_REDIRECT" default:"false"`
+	TLSCertReloadInterval                     time.Duration   `env:"TLS_CERT_RELOAD_INTERVAL" default:"1m"`
+	TLSClientCAPath                           string          `env:"TLS_CLIENT_CA_PATH"`
 	HTTPServerWriteTimeout                    time.Duration   `env:"HTTP_SERVER_WRITE_TIMEOUT" default:"10s"`
 	UnAuthenticatedRateLimit                  int64           `env:"UNAUTHENTICATED_RATE_LIMIT" default:"5"`
 	UnAuthenticatedRateLimitPeriod            time.Duration   `env:"UNAUTHENTICATED_RATE_LIMIT_PERIOD" default:"20s"`
+	RunTriggerRateLimit                       int64           `env:"RUN_TRIGGER_RATE_LIMIT" default:"0"`
+	RunTriggerRateLimitPeriod                 time.Duration   `env:"RUN_TRIGGER_RATE_LIMIT_PERIOD" default:"1m"`
 }
 
 // EnvVarName gets the environment variable name for a config schema field
@@ -41,6 +41,7 @@ type ETHKey struct {
 	CreatedAt   time.Time      `json:"createdAt"`
 	UpdatedAt   time.Time      `json:"updatedAt"`
 	DeletedAt   gorm.DeletedAt `json:"deletedAt"`
+	models.KeySettings
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -64,10 +65,13 @@ type ConfigPrinter struct {
 
 // EnvPrinter contains the supported environment variables
 type EnvPrinter struct {
+	AllowedTaskTypes                      string          `json:"allowedTaskTypes"`
 	AllowOrigins                          string          `json:"allowOrigins"`
 	BalanceMonitorEnabled                 bool            `json:"balanceMonitorEnabled"`
 	BlockBackfillDepth                    uint64          `json:"blockBackfillDepth"`
 	BridgeResponseURL                     string          `json:"bridgeResponseURL,omitempty"`
+	BridgeSigningAddress                  *common.Address `json:"bridgeSigningAddress"`
+	BridgeCheckInterval                   time.Duration   `json:"bridgeCheckInterval"`
 	ChainID                               *big.Int        `json:"ethChainId"`
 	ClientNodeURL                         string          `json:"clientNodeUrl"`
 	DatabaseTimeout                       models.Duration `json:"databaseTimeout"`
@@ -75,19 +79,27 @@ type EnvPrinter struct {
 	DefaultHTTPLimit                      int64           `json:"defaultHttpLimit"`
 	DefaultHTTPTimeout                    models.Duration `json:"defaultHttpTimeout"`
 	Dev                                   bool            `json:"chainlinkDev"`
+	DisallowedTaskTypes                   string          `json:"disallowedTaskTypes"`
 	EnableExperimentalAdapters            bool            `json:"enableExperimentalAdapters"`
 	EthBalanceMonitorBlockDelay           uint16          `json:"ethBalanceMonitorBlockDelay"`
 	EthereumDisabled                      bool            `json:"ethereumDisabled"`
 	EthFinalityDepth                      uint            `json:"ethFinalityDepth"`
 	EthGasBumpThreshold                   uint64          `json:"ethGasBumpThreshold"`
 	EthGasBumpTxDepth                     uint16          `json:"ethGasBumpTxDepth"`
+	EthExternalTxDetectionEnabled         bool            `json:"ethExternalTxDetectionEnabled"`
+	EthGasBumpDeadlineWindow              time.Duration   `json:"ethGasBumpDeadlineWindow"`
 	EthGasBumpWei                         *big.Int        `json:"ethGasBumpWei"`
 	EthGasLimitDefault                    uint64          `json:"ethGasLimitDefault"`
 	EthGasPriceDefault                    *big.Int        `json:"ethGasPriceDefault"`
 	EthHeadTrackerHistoryDepth            uint            `json:"ethHeadTrackerHistoryDepth"`
 	EthHeadTrackerMaxBufferSize           uint            `json:"ethHeadTrackerMaxBufferSize"`
 	EthMaxGasPriceWei                     *big.Int        `json:"ethMaxGasPriceWei"`
+	EthNonceGapAutoFill                   bool            `json:"ethNonceGapAutoFill"`
+	EthNonceGapCheckInterval              time.Duration   `json:"ethNonceGapCheckInterval"`
+	EthRPCMonthlyQuota                    uint64          `json:"ethRPCMonthlyQuota"`
+	EthReadCacheTTL                       time.Duration   `json:"ethReadCacheTTL"`
 	EthereumURL                           string          `json:"ethUrl"`
+	ENSResolveInterval                    time.Duration   `json:"ensResolveInterval"`
 	EthereumSecondaryURLs                 []string        `json:"ethSecondaryUrls"`
 	ExplorerURL                           string          `json:"explorerUrl"`
 	FeatureExternalInitiators             bool            `json:"featureExternalInitiators"`
@@ -98,11 +110,15 @@ type EnvPrinter struct {
 	GasUpdaterBlockHistorySize            uint16          `json:"gasUpdaterBlockHistorySize"`
 	GasUpdaterEnabled                     bool            `json:"gasUpdaterEnabled"`
 	GasUpdaterTransactionPercentile       uint16          `json:"gasUpdaterTransactionPercentile"`
+	GRPCPort                              uint16          `json:"chainlinkGRPCPort"`
 	InsecureFastScrypt                    bool            `json:"insecureFastScrypt"`
 	TriggerFallbackDBPollInterval         time.Duration   `json:"jobPipelineDBPollInterval"`
 	JobPipelineParallelism                uint8           `json:"jobPipelineParallelism"`
+	JobPipelineParallelismMin             uint8           `json:"jobPipelineParallelismMin"`
+	JobPipelineParallelismMax             uint8           `json:"jobPipelineParallelismMax"`
 	JobPipelineReaperInterval             time.Duration   `json:"jobPipelineReaperInterval"`
 	JobPipelineReaperThreshold            time.Duration   `json:"jobPipelineReaperThreshold"`
+	JobPipelineWarmupTimeout              time.Duration   `json:"jobPipelineWarmupTimeout"`
 	JSONConsole                           bool            `json:"jsonConsole"`
 	LinkContractAddress                   string          `json:"linkContractAddress"`
 	LogLevel                              orm.LogLevel    `json:"logLevel"`
@@ -117,7 +133,10 @@ type EnvPrinter struct {
 	MinimumRequestExpiration              uint64          `json:"minimumRequestExpiration"`
 	OCRBootstrapCheckInterval             time.Duration   `json:"ocrBootstrapCheckInterval"`
 	OCRContractTransmitterTransmitTimeout time.Duration   `json:"ocrContractTransmitterTransmitTimeout"`
+	OCRContractLogLookbackBlocks          uint64          `json:"ocrContractLogLookbackBlocks"`
 	OCRDatabaseTimeout                    time.Duration   `json:"ocrDatabaseTimeout"`
+	OCRDatabaseWriteInterval              time.Duration   `json:"ocrDatabaseWriteInterval"`
+	OCRPartitionRetention                 time.Duration   `json:"ocrPartitionRetention"`
 	P2PListenIP                           string          `json:"ocrListenIP"`
 	P2PListenPort                         uint16          `json:"ocrListenPort"`
 	OCRIncomingMessageBufferSize          int             `json:"ocrIncomingMessageBufferSize"`
@@ -147,10 +166,13 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 	}
 	return ConfigPrinter{
 		EnvPrinter: EnvPrinter{
+			AllowedTaskTypes:                      strings.Join(config.AllowedTaskTypes(), ","),
 			AllowOrigins:                          config.AllowOrigins(),
 			BalanceMonitorEnabled:                 config.BalanceMonitorEnabled(),
 			BlockBackfillDepth:                    config.BlockBackfillDepth(),
 			BridgeResponseURL:                     config.BridgeResponseURL().String(),
+			BridgeSigningAddress:                  config.BridgeSigningAddress(),
+			BridgeCheckInterval:                   config.BridgeCheckInterval(),
 			ChainID:                               config.ChainID(),
 			ClientNodeURL:                         config.ClientNodeURL(),
 			DatabaseTimeout:                       config.DatabaseTimeout(),
@@ -158,19 +180,27 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			DefaultHTTPTimeout:                    config.DefaultHTTPTimeout(),
 			DatabaseMaximumTxDuration:             config.DatabaseMaximumTxDuration(),
 			Dev:                                   config.Dev(),
+			DisallowedTaskTypes:                   strings.Join(config.DisallowedTaskTypes(), ","),
 			EnableExperimentalAdapters:            config.EnableExperimentalAdapters(),
 			EthBalanceMonitorBlockDelay:           config.EthBalanceMonitorBlockDelay(),
 			EthereumDisabled:                      config.EthereumDisabled(),
 			EthFinalityDepth:                      config.EthFinalityDepth(),
 			EthGasBumpThreshold:                   config.EthGasBumpThreshold(),
 			EthGasBumpTxDepth:                     config.EthGasBumpTxDepth(),
+			EthExternalTxDetectionEnabled:         config.EthExternalTxDetectionEnabled(),
+			EthGasBumpDeadlineWindow:              config.EthGasBumpDeadlineWindow(),
 			EthGasBumpWei:                         config.EthGasBumpWei(),
 			EthGasLimitDefault:                    config.EthGasLimitDefault(),
 			EthGasPriceDefault:                    config.EthGasPriceDefault(),
 			EthHeadTrackerHistoryDepth:            config.EthHeadTrackerHistoryDepth(),
 			EthHeadTrackerMaxBufferSize:           config.EthHeadTrackerMaxBufferSize(),
 			EthMaxGasPriceWei:                     config.EthMaxGasPriceWei(),
+			EthNonceGapAutoFill:                   config.EthNonceGapAutoFill(),
+			EthNonceGapCheckInterval:              config.EthNonceGapCheckInterval(),
+			EthRPCMonthlyQuota:                    config.EthRPCMonthlyQuota(),
+			EthReadCacheTTL:                       config.EthReadCacheTTL(),
 			EthereumURL:                           config.EthereumURL(),
+			ENSResolveInterval:                    config.ENSResolveInterval(),
 			EthereumSecondaryURLs:                 mapToStringA(config.EthereumSecondaryURLs()),
 			ExplorerURL:                           explorerURL,
 			FeatureExternalInitiators:             config.FeatureExternalInitiators(),
@@ -181,11 +211,15 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			GasUpdaterBlockHistorySize:            config.GasUpdaterBlockHistorySize(),
 			GasUpdaterEnabled:                     config.GasUpdaterEnabled(),
 			GasUpdaterTransactionPercentile:       config.GasUpdaterTransactionPercentile(),
+			GRPCPort:                              config.GRPCPort(),
 			InsecureFastScrypt:                    config.InsecureFastScrypt(),
 			TriggerFallbackDBPollInterval:         config.TriggerFallbackDBPollInterval(),
 			JobPipelineParallelism:                config.JobPipelineParallelism(),
+			JobPipelineParallelismMin:             config.JobPipelineParallelismMin(),
+			JobPipelineParallelismMax:             config.JobPipelineParallelismMax(),
 			JobPipelineReaperInterval:             config.JobPipelineReaperInterval(),
 			JobPipelineReaperThreshold:            config.JobPipelineReaperThreshold(),
+			JobPipelineWarmupTimeout:              config.JobPipelineWarmupTimeout(),
 			JSONConsole:                           config.JSONConsole(),
 			LinkContractAddress:                   config.LinkContractAddress(),
 			LogLevel:                              config.LogLevel(),
@@ -200,7 +234,10 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			MinimumRequestExpiration:              config.MinimumRequestExpiration(),
 			OCRBootstrapCheckInterval:             config.OCRBootstrapCheckInterval(),
 			OCRContractTransmitterTransmitTimeout: config.OCRContractTransmitterTransmitTimeout(),
+			OCRContractLogLookbackBlocks:          config.OCRContractLogLookbackBlocks(),
 			OCRDatabaseTimeout:                    config.OCRDatabaseTimeout(),
+			OCRDatabaseWriteInterval:              config.OCRDatabaseWriteInterval(),
+			OCRPartitionRetention:                 config.OCRPartitionRetention(),
 			P2PListenIP:                           config.P2PListenIP().String(),
 			P2PListenPort:                         config.P2PListenPort(),
 			OCRIncomingMessageBufferSize:          config.OCRIncomingMessageBufferSize(),
@@ -582,18 +619,19 @@ func (a NewAccount) GetName() string {
 
 // EthTx is a jsonapi wrapper for an Ethereum Transaction.
 type EthTx struct {
-	ID       int64           `json:"-"`
-	State    string          `json:"state,omitempty"`
-	Data     hexutil.Bytes   `json:"data,omitempty"`
-	From     *common.Address `json:"from,omitempty"`
-	GasLimit string          `json:"gasLimit,omitempty"`
-	GasPrice string          `json:"gasPrice,omitempty"`
-	Hash     common.Hash     `json:"hash,omitempty"`
-	Hex      string          `json:"rawHex,omitempty"`
-	Nonce    string          `json:"nonce,omitempty"`
-	SentAt   string          `json:"sentAt,omitempty"`
-	To       *common.Address `json:"to,omitempty"`
-	Value    string          `json:"value,omitempty"`
+	ID           int64           `json:"-"`
+	State        string          `json:"state,omitempty"`
+	Data         hexutil.Bytes   `json:"data,omitempty"`
+	From         *common.Address `json:"from,omitempty"`
+	GasLimit     string          `json:"gasLimit,omitempty"`
+	GasPrice     string          `json:"gasPrice,omitempty"`
+	Hash         common.Hash     `json:"hash,omitempty"`
+	Hex          string          `json:"rawHex,omitempty"`
+	Nonce        string          `json:"nonce,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	SentAt       string          `json:"sentAt,omitempty"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        string          `json:"value,omitempty"`
 }
 
 func NewEthTx(tx models.EthTx) EthTx {
@@ -631,6 +669,9 @@ func newEthTxWithAttempt(tx models.EthTx, txa models.EthTxAttempt) EthTx {
 	if txa.BroadcastBeforeBlockNum != nil {
 		ethTX.SentAt = strconv.FormatUint(uint64(*txa.BroadcastBeforeBlockNum), 10)
 	}
+	if txa.RevertReason != nil {
+		ethTX.RevertReason = *txa.RevertReason
+	}
 	return ethTX
 }
 
@@ -651,6 +692,78 @@ func (t *EthTx) SetID(hex string) error {
 	return nil
 }
 
+// NonceLadder is a jsonapi wrapper for the nonces of an ETH key that haven't
+// been mined yet, and the gaps between them.
+type NonceLadder struct {
+	Address      string      `json:"address"`
+	MinedNonce   uint64      `json:"minedNonce"`
+	PendingNonce uint64      `json:"pendingNonce"`
+	NextNonce    int64       `json:"nextNonce"`
+	Gaps         []int64     `json:"gaps"`
+	Rungs        []NonceRung `json:"rungs"`
+}
+
+// NonceRung is a nonce of a NonceLadder, with the state of the transaction
+// that uses it.
+type NonceRung struct {
+	Nonce   int64  `json:"nonce"`
+	State   string `json:"state"`
+	EthTxID int64  `json:"ethTxID,omitempty"`
+}
+
+// GetID returns the jsonapi ID.
+func (l NonceLadder) GetID() string {
+	return l.Address
+}
+
+// GetName returns the collection name for jsonapi.
+func (NonceLadder) GetName() string {
+	return "nonceLadders"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (l *NonceLadder) SetID(value string) error {
+	l.Address = value
+	return nil
+}
+
+// RPCUsageReport is a jsonapi wrapper for the eth RPC calls made in a month,
+// and how many will have been made by the end of it at the same rate.
+type RPCUsageReport struct {
+	Month       string     `json:"month"`
+	MonthToDate int64      `json:"monthToDate"`
+	Projected   int64      `json:"projected"`
+	Quota       uint64     `json:"quota"`
+	Usage       []RPCUsage `json:"usage"`
+}
+
+// RPCUsage is the number of calls made with one method to one endpoint on one
+// day of an RPCUsageReport.
+type RPCUsage struct {
+	Day      string `json:"day"`
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+	Calls    int64  `json:"calls"`
+}
+
+// GetID returns the jsonapi ID.
+func (r RPCUsageReport) GetID() string {
+	return r.Month
+}
+
+// GetName returns the collection name for jsonapi.
+func (RPCUsageReport) GetName() string {
+	return "rpcUsageReports"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (r *RPCUsageReport) SetID(value string) error {
+	r.Month = value
+	return nil
+}
+
 // ExternalInitiatorAuthentication includes initiator and authentication details.
 type ExternalInitiatorAuthentication struct {
 	Name           string        `json:"name,omitempty"`
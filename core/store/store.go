@@ -2,11 +2,15 @@ package store
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/coreos/go-semver/semver"
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -15,6 +19,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/gracefulpanic"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/store/migrations"
@@ -51,6 +56,7 @@ type Store struct {
 	EthClient      eth.Client
 	NotifyNewEthTx NotifyNewEthTx
 	AdvisoryLocker postgres.AdvisoryLocker
+	Events         *eventbus.Bus
 	closeOnce      *sync.Once
 }
 
@@ -108,6 +114,7 @@ func newStoreWithKeyStore(
 		OCRKeyStore:    offchainreporting.NewKeyStore(orm.DB, scryptParams),
 		ORM:            orm,
 		EthClient:      ethClient,
+		Events:         eventbus.NewBus(),
 		closeOnce:      &sync.Once{},
 	}
 	store.VRFKeyStore = NewVRFKeyStore(store)
@@ -191,7 +198,7 @@ func (s *Store) ArchiveKey(address common.Address) error {
 		return err
 	}
 
-	archivedKeysDir := filepath.Join(s.Config.RootDir(), "archivedkeys")
+	archivedKeysDir := s.archivedKeysDir()
 	err = utils.EnsureDirAndMaxPerms(archivedKeysDir, os.FileMode(0700))
 	if err != nil {
 		return errors.Wrap(err, "could not create "+archivedKeysDir)
@@ -207,6 +214,96 @@ func (s *Store) ArchiveKey(address common.Address) error {
 	return s.KeyStore.Delete(address)
 }
 
+// RestoreKey undoes ArchiveKey for the key whose address matches the
+// supplied address, provided that it has not been purged yet.
+func (s *Store) RestoreKey(address common.Address) error {
+	paths, err := s.archivedKeyFiles(address)
+	if err != nil {
+		return err
+	} else if len(paths) == 0 {
+		return errors.Wrapf(os.ErrNotExist, "no archived key file found for %s", address.Hex())
+	}
+	// The most recently archived file sorts last
+	keyJSON, err := ioutil.ReadFile(paths[len(paths)-1])
+	if os.IsNotExist(err) {
+		return errors.Wrapf(os.ErrNotExist, "archived key file for %s was removed", address.Hex())
+	} else if err != nil {
+		return errors.Wrap(err, "could not read archived key file")
+	}
+
+	return postgres.GormTransaction(context.Background(), s.ORM.DB, func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.Key{}).
+			Where("address = ? AND deleted_at IS NOT NULL", address).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return errors.Wrap(result.Error, "while restoring ETH key in DB")
+		} else if result.RowsAffected == 0 {
+			return errors.Wrapf(gorm.ErrRecordNotFound, "no archived key with address %s", address.Hex())
+		}
+		if _, err := s.KeyStore.Restore(keyJSON); err != nil {
+			return err
+		}
+		return removeFiles(paths)
+	})
+}
+
+// PurgeArchivedKeys hard-deletes all keys that were archived before the
+// given time, along with their archived key files. Keys that are still
+// referenced by a transaction or a job are kept.
+func (s *Store) PurgeArchivedKeys(before time.Time) error {
+	var keys []models.Key
+	err := s.ORM.DB.Unscoped().
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM eth_txes WHERE eth_txes.from_address = keys.address)").
+		Where("NOT EXISTS (SELECT 1 FROM offchainreporting_oracle_specs WHERE offchainreporting_oracle_specs.transmitter_address = keys.address)").
+		Where("NOT EXISTS (SELECT 1 FROM keeper_specs WHERE keeper_specs.from_address = keys.address)").
+		Find(&keys).Error
+	if err != nil {
+		return errors.Wrap(err, "while loading archived ETH keys")
+	}
+
+	var merr error
+	for _, key := range keys {
+		address := key.Address.Address()
+		paths, err := s.archivedKeyFiles(address)
+		if err != nil {
+			merr = multierr.Append(merr, err)
+			continue
+		}
+		if err := s.ORM.DeleteKey(address); err != nil {
+			merr = multierr.Append(merr, errors.Wrapf(err, "while purging archived ETH key %s", address.Hex()))
+			continue
+		}
+		merr = multierr.Append(merr, removeFiles(paths))
+		logger.Infow("Purged archived ETH key", "address", address.Hex())
+	}
+	return merr
+}
+
+func (s *Store) archivedKeysDir() string {
+	return filepath.Join(s.Config.RootDir(), "archivedkeys")
+}
+
+// archivedKeyFiles returns the archived key files for the given address,
+// which are named after the address like any other geth key file
+func (s *Store) archivedKeyFiles(address common.Address) ([]string, error) {
+	pattern := filepath.Join(s.archivedKeysDir(), "*--"+hex.EncodeToString(address.Bytes()))
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list archived key files")
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func removeFiles(paths []string) error {
+	var merr error
+	for _, path := range paths {
+		merr = multierr.Append(merr, os.Remove(path))
+	}
+	return merr
+}
+
 func (s *Store) ImportKey(keyJSON []byte, oldPassword string) error {
 	return postgres.GormTransaction(context.Background(), s.ORM.DB, func(tx *gorm.DB) error {
 		_, err := s.KeyStore.Import(keyJSON, oldPassword)
@@ -263,6 +263,26 @@ func TestStore_ArchiveKey(t *testing.T) {
 	require.Len(t, keys, 0)
 }
 
+func TestStore_PurgeArchivedKeys(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	unused := cltest.MustInsertRandomKey(t, store.DB)
+	referenced := cltest.MustInsertRandomKey(t, store.DB)
+	cltest.MustInsertFatalErrorEthTx(t, store, referenced.Address.Address())
+
+	archivedAt := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, store.DB.Exec(`UPDATE keys SET deleted_at = ?`, archivedAt).Error)
+
+	require.NoError(t, store.PurgeArchivedKeys(time.Now().Add(-time.Hour)))
+
+	var addresses []common.Address
+	require.NoError(t, store.DB.Raw(`SELECT address FROM keys`).Scan(&addresses).Error)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, referenced.Address.Address(), addresses[0])
+	assert.NotEqual(t, unused.Address.Address(), addresses[0])
+}
+
 func TestStore_ImportKey(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -44,12 +44,19 @@ func init() {
 	Client = &http.Client{Transport: tr}
 
 	unrestrictedTr := newDefaultTransport()
+	unrestrictedTr.RegisterProtocol(UnixSocketScheme, newUnixSocketTransport())
 	UnrestrictedClient = &http.Client{Transport: unrestrictedTr}
 }
 
 type HTTPRequest struct {
 	Request *http.Request
 	Config  HTTPRequestConfig
+	// PrepareAttempt, if set, is called with the request of each attempt
+	// before it is sent, for example to sign it afresh
+	PrepareAttempt func(*http.Request) error
+	// AttemptDone, if set, is called after each attempt with the response
+	// body it received, which is nil if it failed before reading one
+	AttemptDone func(responseBody []byte)
 }
 
 // HTTPRequestConfig holds the configurable settings for an http request
@@ -61,6 +68,13 @@ type HTTPRequestConfig struct {
 }
 
 func (h *HTTPRequest) SendRequest(ctx context.Context) (responseBody []byte, statusCode int, err error) {
+	responseBody, statusCode, _, err = h.SendRequestWithHeaders(ctx)
+	return responseBody, statusCode, err
+}
+
+// SendRequestWithHeaders is like SendRequest, but also returns the headers of
+// the response
+func (h *HTTPRequest) SendRequestWithHeaders(ctx context.Context) (responseBody []byte, statusCode int, headers http.Header, err error) {
 	var c *http.Client
 	if h.Config.AllowUnrestrictedNetworkAccess {
 		c = UnrestrictedClient
@@ -68,7 +82,7 @@ func (h *HTTPRequest) SendRequest(ctx context.Context) (responseBody []byte, sta
 		c = Client
 	}
 
-	return withRetry(ctx, c, h.Request, h.Config)
+	return withRetry(ctx, c, h.Request, h.Config, h.PrepareAttempt, h.AttemptDone)
 }
 
 // withRetry executes the http request in a retry. Timeout is controlled with a context
@@ -79,7 +93,9 @@ func withRetry(
 	client *http.Client,
 	originalRequest *http.Request,
 	config HTTPRequestConfig,
-) (responseBody []byte, statusCode int, err error) {
+	prepareAttempt func(*http.Request) error,
+	attemptDone func(responseBody []byte),
+) (responseBody []byte, statusCode int, headers http.Header, err error) {
 	bb := &backoff.Backoff{
 		Min:    100,
 		Max:    20 * time.Minute, // We stop retrying on the number of attempts!
@@ -91,33 +107,41 @@ func withRetry(
 
 		requestWithTimeout, err := cloneRequest(timeoutCtx, originalRequest)
 		if err != nil {
-			return responseBody, statusCode, err
+			return responseBody, statusCode, headers, err
+		}
+		if prepareAttempt != nil {
+			if err = prepareAttempt(requestWithTimeout); err != nil {
+				return responseBody, statusCode, headers, err
+			}
 		}
 
-		responseBody, statusCode, err = makeHTTPCall(client, requestWithTimeout, config)
+		responseBody, statusCode, headers, err = makeHTTPCall(client, requestWithTimeout, config)
+		if attemptDone != nil {
+			attemptDone(responseBody)
+		}
 		if err == nil {
-			return responseBody, statusCode, nil
+			return responseBody, statusCode, headers, nil
 		}
 		if uint(bb.Attempt())+1 >= config.MaxAttempts { // Stop retrying.
-			return responseBody, statusCode, err
+			return responseBody, statusCode, headers, err
 		}
 		switch err.(type) {
 		// There is no point in retrying a request if the response was
 		// too large since it's likely that all retries will suffer the
 		// same problem
 		case *HTTPResponseTooLargeError:
-			return responseBody, statusCode, err
+			return responseBody, statusCode, headers, err
 		}
 		// Sleep and retry, unless the parent context is
 		// cancelled.
 		select {
 		case <-timeoutCtx.Done():
 			if timeoutCtx.Err() != context.DeadlineExceeded {
-				return responseBody, statusCode, timeoutCtx.Err()
+				return responseBody, statusCode, headers, timeoutCtx.Err()
 			}
 		case <-time.After(bb.Duration()):
 		case <-ctx.Done():
-			return responseBody, statusCode, ctx.Err()
+			return responseBody, statusCode, headers, ctx.Err()
 		}
 		logger.Debugw("http adapter error, will retry", "error", err.Error(), "attempt", bb.Attempt(), "timeout", config.Timeout)
 	}
@@ -127,18 +151,19 @@ func makeHTTPCall(
 	client *http.Client,
 	request *http.Request,
 	config HTTPRequestConfig,
-) (responseBody []byte, statusCode int, _ error) {
+) (responseBody []byte, statusCode int, headers http.Header, _ error) {
 
 	start := time.Now()
 
 	r, err := client.Do(request)
 	if err != nil {
 		logger.Warnw("http adapter got error", "error", err)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 	defer logger.ErrorIfCalling(r.Body.Close)
 
 	statusCode = r.StatusCode
+	headers = r.Header
 	elapsed := time.Since(start)
 	logger.Debugw(fmt.Sprintf("http adapter got %v in %s", statusCode, elapsed), "statusCode", statusCode, "timeElapsedSeconds", elapsed)
 
@@ -146,7 +171,7 @@ func makeHTTPCall(
 	bytes, err := ioutil.ReadAll(source)
 	if err != nil {
 		logger.Errorw("http adapter error reading body", "error", err)
-		return nil, statusCode, err
+		return nil, statusCode, headers, err
 	}
 	elapsed = time.Since(start)
 	logger.Debugw(fmt.Sprintf("http adapter finished after %s", elapsed), "statusCode", statusCode, "timeElapsedSeconds", elapsed)
@@ -155,10 +180,10 @@ func makeHTTPCall(
 
 	// Retry on 5xx since this might give a different result
 	if 500 <= r.StatusCode && r.StatusCode < 600 {
-		return responseBody, statusCode, &RemoteServerError{responseBody, statusCode}
+		return responseBody, statusCode, headers, &RemoteServerError{responseBody, statusCode}
 	}
 
-	return responseBody, statusCode, nil
+	return responseBody, statusCode, headers, nil
 }
 
 func cloneRequest(ctx context.Context, originalRequest *http.Request) (*http.Request, error) {
@@ -191,6 +216,16 @@ func (e *RemoteServerError) Error() string {
 	return fmt.Sprintf("remote server error: %v\nResponse body: %v", e.statusCode, string(e.responseBody))
 }
 
+// StatusCode is the 5xx status code the remote server responded with
+func (e *RemoteServerError) StatusCode() int {
+	return e.statusCode
+}
+
+// ResponseBody is the body of the remote server's response
+func (e *RemoteServerError) ResponseBody() []byte {
+	return e.responseBody
+}
+
 // MaxBytesReader is inspired by
 // https://github.com/gin-contrib/size/blob/master/size.go
 type MaxBytesReader struct {
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// UnixSocketScheme is the URL scheme of HTTP servers listening on a unix
+// domain socket, such as external adapters deployed alongside the node. The
+// path of the URL is the path of the socket, e.g.
+// unix:///var/run/adapter.sock, and requests are made to "/" on the server.
+//
+// Only UnrestrictedClient can make requests to unix sockets, since they
+// reach local servers just like loopback addresses do.
+const UnixSocketScheme = "unix"
+
+// unixSocketTransport makes HTTP requests to servers listening on unix
+// sockets, with a pool of connections for each socket
+type unixSocketTransport struct {
+	mu         sync.Mutex
+	transports map[string]*http.Transport
+}
+
+func newUnixSocketTransport() *unixSocketTransport {
+	return &unixSocketTransport{transports: make(map[string]*http.Transport)}
+}
+
+func (u *unixSocketTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	socket := req.URL.Path
+	if socket == "" {
+		return nil, errors.Errorf("%s URL %s has no socket path", UnixSocketScheme, req.URL)
+	}
+
+	r := req.Clone(req.Context())
+	r.URL.Scheme = "http"
+	r.URL.Host = UnixSocketScheme
+	r.URL.Path = "/"
+	r.URL.RawPath = ""
+	r.Host = UnixSocketScheme
+	return u.transportFor(socket).RoundTrip(r)
+}
+
+func (u *unixSocketTransport) transportFor(socket string) *http.Transport {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if t, exists := u.transports[socket]; exists {
+		return t
+	}
+	t := newDefaultTransport()
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", socket)
+	}
+	u.transports[socket] = t
+	return t
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRequest_UnixSocket(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "adapter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "adapter.sock")
+
+	lis, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.Path + " " + string(body)))
+	})}
+	go func() { _ = server.Serve(lis) }()
+	defer server.Close()
+
+	config := HTTPRequestConfig{
+		Timeout:     5 * time.Second,
+		MaxAttempts: 1,
+		SizeLimit:   1024,
+	}
+	newRequest := func() *http.Request {
+		request, err := http.NewRequest("POST", UnixSocketScheme+"://"+socket, strings.NewReader(`{"id":"1"}`))
+		require.NoError(t, err)
+		return request
+	}
+
+	t.Run("unrestricted", func(t *testing.T) {
+		config := config
+		config.AllowUnrestrictedNetworkAccess = true
+		request := HTTPRequest{Request: newRequest(), Config: config}
+
+		body, statusCode, err := request.SendRequest(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, `POST / {"id":"1"}`, string(body))
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		request := HTTPRequest{Request: newRequest(), Config: config}
+
+		_, _, err := request.SendRequest(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,197 @@
+package web
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// remoteIP returns the address of the peer the request came from. Unlike
+// gin's ClientIP, it ignores forwarding headers, which clients can set to
+// anything.
+func remoteIP(c *gin.Context) net.IP {
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		host = c.Request.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
+
+// ipAllowlist rejects requests from addresses outside of the given networks.
+// All requests are allowed if no networks are given.
+func ipAllowlist(allowed []*net.IPNet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IPAllowed(allowed, remoteIP(c)) {
+			c.Next()
+			return
+		}
+		jsonAPIError(c, http.StatusForbidden, errors.New("requests from this address are not allowed"))
+		c.Abort()
+	}
+}
+
+// IPAllowed returns whether ip is inside one of the allowed networks, or
+// true if no networks are given
+func IPAllowed(allowed []*net.IPNet, ip net.IP) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range allowed {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenBucketLimiter limits each client address to limit requests in a
+// burst, refilled at a rate of limit per period. A limit of 0 disables it.
+func tokenBucketLimiter(period time.Duration, limit int64) gin.HandlerFunc {
+	limiter := NewClientRateLimiter(period, limit)
+	if limiter == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return func(c *gin.Context) {
+		key := ""
+		if ip := remoteIP(c); ip != nil {
+			key = ip.String()
+		}
+		if ok, wait := limiter.Take(key); !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			jsonAPIError(c, http.StatusTooManyRequests, errors.New("rate limit exceeded"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// ClientRateLimiter limits each client to limit requests in a burst,
+// refilled at a rate of limit per period. It is used by the APIs that are not
+// served by gin.
+type ClientRateLimiter struct {
+	buckets *tokenBuckets
+}
+
+// NewClientRateLimiter returns a ClientRateLimiter, or nil if limit is 0. A
+// nil ClientRateLimiter allows every request.
+func NewClientRateLimiter(period time.Duration, limit int64) *ClientRateLimiter {
+	if limit <= 0 || period <= 0 {
+		return nil
+	}
+	return &ClientRateLimiter{newTokenBuckets(float64(limit)/period.Seconds(), float64(limit), time.Now)}
+}
+
+// Take counts a request from client against its limit. If the limit has been
+// reached it returns false, along with how long until it may try again.
+func (l *ClientRateLimiter) Take(client string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	return l.buckets.take(client)
+}
+
+// Check is like Take, but doesn't count a request
+func (l *ClientRateLimiter) Check(client string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	return l.buckets.check(client)
+}
+
+type (
+	tokenBuckets struct {
+		rate  float64 // tokens per second
+		burst float64
+		now   func() time.Time
+
+		mu        sync.Mutex
+		buckets   map[string]*tokenBucket
+		lastSweep time.Time
+	}
+
+	tokenBucket struct {
+		tokens float64
+		last   time.Time
+	}
+)
+
+func newTokenBuckets(rate, burst float64, now func() time.Time) *tokenBuckets {
+	return &tokenBuckets{
+		rate:      rate,
+		burst:     burst,
+		now:       now,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: now(),
+	}
+}
+
+// take removes a token from key's bucket. If the bucket is empty it returns
+// false, along with how long until a token is available.
+func (b *tokenBuckets) take(key string) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucket := b.refill(key)
+	if bucket.tokens < 1 {
+		return false, b.wait(bucket)
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// check returns whether key's bucket has a token, without removing it
+func (b *tokenBuckets) check(key string) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucket := b.refill(key)
+	if bucket.tokens < 1 {
+		return false, b.wait(bucket)
+	}
+	return true, 0
+}
+
+// refill returns key's bucket, topped up with the tokens added since it was
+// last used. It must be called with the lock held.
+func (b *tokenBuckets) refill(key string) *tokenBucket {
+	now := b.now()
+	b.sweep(now)
+
+	bucket, exists := b.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: b.burst, last: now}
+		b.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(b.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*b.rate)
+	bucket.last = now
+	return bucket
+}
+
+func (b *tokenBuckets) wait(bucket *tokenBucket) time.Duration {
+	return time.Duration((1 - bucket.tokens) / b.rate * float64(time.Second))
+}
+
+// sweep forgets buckets that would have refilled completely, so that memory
+// use is bounded by the number of recently active clients
+func (b *tokenBuckets) sweep(now time.Time) {
+	refill := time.Duration(b.burst / b.rate * float64(time.Second))
+	if now.Sub(b.lastSweep) < refill {
+		return
+	}
+	for key, bucket := range b.buckets {
+		if now.Sub(bucket.last) >= refill {
+			delete(b.buckets, key)
+		}
+	}
+	b.lastSweep = now
+}
@@ -0,0 +1,130 @@
+package web
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPAllowlist(t *testing.T) {
+	t.Parallel()
+
+	_, private, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ipAllowlist([]*net.IPNet{private}))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		status     int
+	}{
+		{"inside the range", "10.1.2.3:1234", "", http.StatusOK},
+		{"outside the range", "192.168.1.1:1234", "", http.StatusForbidden},
+		{"forwarding headers are ignored", "192.168.1.1:1234", "10.1.2.3", http.StatusForbidden},
+		{"unparseable address", "nonsense", "", http.StatusForbidden},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = test.remoteAddr
+			if test.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", test.forwarded)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, test.status, w.Code)
+		})
+	}
+}
+
+func TestTokenBuckets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	// 2 requests per 10s
+	buckets := newTokenBuckets(0.2, 2, clock)
+
+	ok, _ := buckets.take("a")
+	assert.True(t, ok)
+	ok, _ = buckets.take("a")
+	assert.True(t, ok)
+	ok, wait := buckets.take("a")
+	assert.False(t, ok)
+	assert.InDelta(t, float64(5*time.Second), float64(wait), float64(time.Millisecond))
+
+	// Other clients have their own bucket
+	ok, _ = buckets.take("b")
+	assert.True(t, ok)
+
+	now = now.Add(5 * time.Second)
+	ok, _ = buckets.take("a")
+	assert.True(t, ok)
+	ok, _ = buckets.take("a")
+	assert.False(t, ok)
+
+	// Idle buckets are forgotten once they would have refilled
+	now = now.Add(time.Minute)
+	ok, _ = buckets.take("c")
+	assert.True(t, ok)
+	assert.Len(t, buckets.buckets, 1)
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewClientRateLimiter(time.Minute, 1)
+	ok, _ := limiter.Check("a")
+	assert.True(t, ok)
+	ok, _ = limiter.Take("a")
+	assert.True(t, ok)
+	ok, wait := limiter.Check("a")
+	assert.False(t, ok)
+	assert.InDelta(t, float64(time.Minute), float64(wait), float64(time.Second))
+	ok, _ = limiter.Take("a")
+	assert.False(t, ok)
+
+	// A limit of 0 disables it
+	limiter = NewClientRateLimiter(time.Minute, 0)
+	assert.Nil(t, limiter)
+	for i := 0; i < 10; i++ {
+		ok, _ = limiter.Take("a")
+		assert.True(t, ok)
+	}
+}
+
+func TestTokenBucketLimiter(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", tokenBucketLimiter(time.Minute, 1), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	router = gin.New()
+	router.GET("/", tokenBucketLimiter(time.Minute, 0), func(c *gin.Context) { c.Status(http.StatusOK) })
+	for i := 0; i < 10; i++ {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 )
 
 const (
@@ -50,6 +52,36 @@ func ParsePaginatedRequest(sizeParam, pageParam string) (int, int, int, error) {
 	return size, page, offset, nil
 }
 
+// ParsePagination parses the parameters that select a page of a collection,
+// returning the page number along with the pagination. As well as size and
+// page, it accepts:
+//   - sort=field to order by a field, or sort=-field for descending order
+//   - filter[field]=value to only list records whose field has the value
+//   - cursor=key to start the page after the record with that key
+func ParsePagination(query url.Values) (orm.Pagination, int, error) {
+	size, page, offset, err := ParsePaginatedRequest(query.Get("size"), query.Get("page"))
+	if err != nil {
+		return orm.Pagination{}, 0, err
+	}
+
+	p := orm.Pagination{
+		Offset: offset,
+		Limit:  size,
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+	}
+	for param, values := range query {
+		if !strings.HasPrefix(param, "filter[") || !strings.HasSuffix(param, "]") || len(values) == 0 {
+			continue
+		}
+		if p.Filters == nil {
+			p.Filters = make(map[string]string)
+		}
+		p.Filters[strings.TrimSuffix(strings.TrimPrefix(param, "filter["), "]")] = values[0]
+	}
+	return p, page, nil
+}
+
 func paginationLink(url url.URL, size, page int) jsonapi.Link {
 	query := url.Query()
 	query.Set("size", strconv.Itoa(size))
@@ -98,6 +130,30 @@ func NewPaginatedResponse(url url.URL, size, page, count int, resource interface
 	return json.Marshal(document)
 }
 
+// NewCursorPaginatedResponse returns a jsonapi.Document with a link to the
+// next collection page, which starts after the record whose key is
+// nextCursor. There is no next page when nextCursor is empty.
+func NewCursorPaginatedResponse(url url.URL, size, count int, nextCursor string, resource interface{}) ([]byte, error) {
+	document, err := jsonapi.MarshalToStruct(resource, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource to struct: %+v", err)
+	}
+
+	document.Meta = make(jsonapi.Meta)
+	document.Meta["count"] = count
+
+	document.Links = make(jsonapi.Links)
+	if nextCursor != "" {
+		query := url.Query()
+		query.Del("page")
+		query.Set("size", strconv.Itoa(size))
+		query.Set("cursor", nextCursor)
+		url.RawQuery = query.Encode()
+		document.Links[KeyNextLink] = jsonapi.Link{Href: url.String()}
+	}
+	return json.Marshal(document)
+}
+
 // ParsePaginatedResponse parse a JSONAPI response for a document with links
 func ParsePaginatedResponse(input []byte, resource interface{}, links *jsonapi.Links) error {
 	err := ParseJSONAPIResponse(input, resource)
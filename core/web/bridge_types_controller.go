@@ -8,8 +8,10 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eagrpc"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -60,10 +62,13 @@ func (btc *BridgeTypesController) Create(c *gin.Context) {
 	}
 }
 
-// Index lists Bridges, one page at a time.
-func (btc *BridgeTypesController) Index(c *gin.Context, size, page, offset int) {
-	bridges, count, err := btc.App.GetStore().BridgeTypes(offset, size)
-	paginatedResponse(c, "Bridges", size, page, bridges, count, err)
+// Index lists Bridges, one page at a time. Bridges can be sorted and filtered
+// by name, url, confirmations and createdAt.
+// Example:
+// "GET <application>/bridge_types?sort=-createdAt"
+func (btc *BridgeTypesController) Index(c *gin.Context, p orm.Pagination, page int) {
+	bridges, count, err := btc.App.GetStore().BridgeTypes(p)
+	paginatedResponse(c, "Bridges", p.Limit, page, bridges, count, err)
 }
 
 // Show returns the details of a specific Bridge.
@@ -126,6 +131,71 @@ func (btc *BridgeTypesController) Update(c *gin.Context) {
 	jsonAPIResponse(c, bt, "bridge")
 }
 
+// Upsert creates the named bridge, or updates it if it already exists. The
+// response records whether the bridge was created and whether anything
+// changed, so the same request can safely be repeated.
+// Example:
+// "PUT <application>/bridge_types/:BridgeName"
+func (btc *BridgeTypesController) Upsert(c *gin.Context) {
+	taskType, err := models.NewTaskType(c.Param("BridgeName"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	btr := &models.BridgeTypeRequest{}
+	if err = c.ShouldBindJSON(btr); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if btr.Name != "" && btr.Name != taskType {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("bridge name %v does not match %v", btr.Name, taskType))
+		return
+	}
+	btr.Name = taskType
+	if err = services.ValidateBridgeType(btr, btc.App.GetStore()); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	store := btc.App.GetStore()
+	bt, err := store.FindBridge(taskType)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		bta, newBT, err := models.NewBridgeType(btr)
+		if err != nil {
+			jsonAPIError(c, StatusCodeForError(err), err)
+			return
+		}
+		if err = store.CreateBridgeType(newBT); err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		var incomingToken string
+		if btr.IncomingToken == "" {
+			incomingToken = bta.IncomingToken
+		}
+		jsonAPIResponseWithStatus(c, presenters.NewBridgeUpsertResource(*newBT, incomingToken, true, true), "bridge", http.StatusCreated)
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	changed, err := bt.UpdateFrom(btr)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if changed {
+		if err = store.SaveBridgeType(&bt); err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	jsonAPIResponse(c, presenters.NewBridgeUpsertResource(bt, "", false, changed), "bridge")
+}
+
 // Destroy removes a specific Bridge.
 func (btc *BridgeTypesController) Destroy(c *gin.Context) {
 	name := c.Param("BridgeName")
@@ -167,6 +237,7 @@ func (btc *BridgeTypesController) Destroy(c *gin.Context) {
 		jsonAPIError(c, StatusCodeForError(err), fmt.Errorf("failed to delete bridge: %+v", err))
 		return
 	}
+	eagrpc.Evict(bt.Name.String())
 
 	jsonAPIResponse(c, bt, "bridge")
 }
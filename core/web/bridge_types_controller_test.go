@@ -15,6 +15,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/web"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
 
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
@@ -90,6 +91,49 @@ func TestBridgeTypesController_Index(t *testing.T) {
 	assert.Equal(t, bt[1].Confirmations, bridges[0].Confirmations, "should have the same Confirmations")
 }
 
+func TestBridgeTypesController_Index_SortAndFilter(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplication(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	bt, err := setupBridgeControllerIndex(t, app.Store)
+	require.NoError(t, err)
+
+	resp, cleanup := client.Get("/v2/bridge_types?sort=-name")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	bridges := []models.BridgeType{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &bridges))
+	require.Len(t, bridges, 2)
+	assert.Equal(t, bt[1].Name, bridges[0].Name)
+	assert.Equal(t, bt[0].Name, bridges[1].Name)
+
+	resp, cleanup = client.Get("/v2/bridge_types?filter[url]=https://testing.com/tari")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	bridges = []models.BridgeType{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &bridges))
+	require.Len(t, bridges, 1)
+	assert.Equal(t, bt[1].Name, bridges[0].Name)
+
+	resp, cleanup = client.Get("/v2/bridge_types?sort=outgoingToken")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Get("/v2/bridge_types?filter[incomingTokenHash]=x")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
 func setupBridgeControllerIndex(t testing.TB, store *store.Store) ([]*models.BridgeType, error) {
 
 	bt1 := &models.BridgeType{
@@ -172,6 +216,65 @@ func TestBridgeTypesController_Update_Success(t *testing.T) {
 	assert.Equal(t, cltest.WebURL(t, "http://yourbridge"), ubt.URL)
 }
 
+func TestBridgeTypesController_Upsert(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplication(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	upsert := func(body string) (*http.Response, presenters.BridgeUpsertResource) {
+		resp, cleanup := client.Put("/v2/bridge_types/upserted", bytes.NewBufferString(body))
+		defer cleanup()
+		var resource presenters.BridgeUpsertResource
+		if resp.StatusCode < 300 {
+			require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &resource))
+		}
+		return resp, resource
+	}
+
+	resp, created := upsert(`{"url": "http://mybridge", "outgoingToken": "outgoing"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.True(t, created.Created)
+	assert.True(t, created.Changed)
+	assert.Equal(t, "outgoing", created.OutgoingToken)
+	assert.NotEmpty(t, created.IncomingToken)
+
+	bt, err := app.Store.FindBridge(models.MustNewTaskType("upserted"))
+	require.NoError(t, err)
+	ok, err := models.AuthenticateBridgeType(&bt, created.IncomingToken)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	resp, unchanged := upsert(`{"url": "http://mybridge"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.False(t, unchanged.Created)
+	assert.False(t, unchanged.Changed)
+	assert.Empty(t, unchanged.IncomingToken)
+
+	resp, updated := upsert(`{"url": "http://yourbridge", "confirmations": 2, "incomingToken": "incoming"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.False(t, updated.Created)
+	assert.True(t, updated.Changed)
+
+	bt, err = app.Store.FindBridge(models.MustNewTaskType("upserted"))
+	require.NoError(t, err)
+	assert.Equal(t, cltest.WebURL(t, "http://yourbridge"), bt.URL)
+	assert.Equal(t, uint32(2), bt.Confirmations)
+	assert.Equal(t, "outgoing", bt.OutgoingToken)
+	ok, err = models.AuthenticateBridgeType(&bt, "incoming")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	resp, _ = upsert(`{"name": "other", "url": "http://yourbridge"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
 func TestBridgeController_Show(t *testing.T) {
 	t.Parallel()
 
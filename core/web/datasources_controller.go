@@ -0,0 +1,95 @@
+package web
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// DatasourcesController manages the external databases that sql tasks may
+// query
+type DatasourcesController struct {
+	App chainlink.Application
+}
+
+// Index lists datasources
+// Example:
+// "GET <application>/datasources"
+func (dc *DatasourcesController) Index(c *gin.Context) {
+	dss, err := dc.App.GetStore().Datasources()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, dss, "datasources")
+}
+
+// Create registers a datasource
+// Example:
+// "POST <application>/datasources"
+func (dc *DatasourcesController) Create(c *gin.Context) {
+	var request models.DatasourceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	ds, err := models.NewDatasource(request)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if dc.isNodeDatabase(ds.ConnectionString) {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("the node's own database cannot be registered as a datasource"))
+		return
+	}
+
+	err = dc.App.GetStore().CreateDatasource(&ds)
+	var pqErr *pgconn.PgError
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		jsonAPIError(c, http.StatusConflict, errors.Errorf("datasource %s already exists", ds.Name))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, ds, "datasources", http.StatusCreated)
+}
+
+// Delete removes a datasource
+// Example:
+// "DELETE <application>/datasources/:name"
+func (dc *DatasourcesController) Delete(c *gin.Context) {
+	name, err := models.NewTaskType(c.Param("name"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = dc.App.GetStore().DeleteDatasource(name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Datasource not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, nil, "datasource", http.StatusNoContent)
+}
+
+// isNodeDatabase reports whether the connection string points at the node's
+// own database, which sql tasks must never be able to read
+func (dc *DatasourcesController) isNodeDatabase(connectionString string) bool {
+	u, err := url.Parse(connectionString)
+	if err != nil {
+		return false
+	}
+	nodeURL := dc.App.GetStore().Config.DatabaseURL()
+	return u.Host == nodeURL.Host && u.Path == nodeURL.Path
+}
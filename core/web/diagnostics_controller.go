@@ -0,0 +1,107 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/static"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	storepresenters "github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// unfinishedJobRunStatuses are the statuses of v1 job runs that are queued
+// or waiting on something
+var unfinishedJobRunStatuses = []models.RunStatus{
+	models.RunStatusUnstarted,
+	models.RunStatusInProgress,
+	models.RunStatusPendingIncomingConfirmations,
+	models.RunStatusPendingConnection,
+	models.RunStatusPendingBridge,
+	models.RunStatusPendingSleep,
+	models.RunStatusPendingOutgoingConfirmations,
+}
+
+// DiagnosticsController serves runtime diagnostics, so that support can debug
+// a node without shell access to it
+type DiagnosticsController struct {
+	App chainlink.Application
+}
+
+// GC returns the Go runtime's memory and garbage collection stats
+// Example:
+// "GET <application>/debug/gc"
+func (dc *DiagnosticsController) GC(c *gin.Context) {
+	c.JSON(http.StatusOK, presenters.NewGCStats())
+}
+
+// Show returns a one-shot diagnostics bundle: the runtime stats, the depths
+// of the node's queues, the database pool stats and the config
+// Example:
+// "GET <application>/debug/diagnostics"
+func (dc *DiagnosticsController) Show(c *gin.Context) {
+	store := dc.App.GetStore()
+
+	config, err := storepresenters.NewConfigPrinter(store)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, errors.Wrap(err, "failed to build config whitelist"))
+		return
+	}
+	queues, err := dc.queueDepths()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	sqlDB, err := store.DB.DB()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	now := time.Now()
+	jsonAPIResponse(c, presenters.DiagnosticsResource{
+		JAID:      presenters.JAID{ID: now.UTC().Format(time.RFC3339)},
+		Version:   static.Version,
+		CommitSHA: static.Sha,
+		CreatedAt: now,
+		Runtime:   presenters.NewGCStats(),
+		Queues:    queues,
+		DBPool:    presenters.NewDBPoolStats(sqlDB.Stats()),
+		Config:    config,
+	}, "diagnostics")
+}
+
+func (dc *DiagnosticsController) queueDepths() (presenters.QueueDepths, error) {
+	db := dc.App.GetStore().DB
+	queues := presenters.QueueDepths{EthTxes: make(map[string]int64)}
+
+	err := db.Raw(`SELECT COUNT(*) FROM pipeline_runs WHERE finished_at IS NULL`).Scan(&queues.UnfinishedPipelineRuns).Error
+	if err != nil {
+		return queues, errors.Wrap(err, "could not count unfinished pipeline runs")
+	}
+	err = db.Raw(`SELECT COUNT(*) FROM job_runs WHERE status IN (?)`, unfinishedJobRunStatuses).Scan(&queues.UnfinishedJobRuns).Error
+	if err != nil {
+		return queues, errors.Wrap(err, "could not count unfinished job runs")
+	}
+
+	var ethTxes []struct {
+		State string
+		Count int64
+	}
+	err = db.Raw(`SELECT state, COUNT(*) AS count FROM eth_txes WHERE state IN (?) GROUP BY state`, []models.EthTxState{
+		models.EthTxUnstarted,
+		models.EthTxInProgress,
+		models.EthTxUnconfirmed,
+	}).Scan(&ethTxes).Error
+	if err != nil {
+		return queues, errors.Wrap(err, "could not count pending eth transactions")
+	}
+	for _, row := range ethTxes {
+		queues.EthTxes[row.State] = row.Count
+	}
+	return queues, nil
+}
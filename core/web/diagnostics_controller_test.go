@@ -0,0 +1,60 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/static"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestDiagnosticsController(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	t.Run("requires authentication", func(t *testing.T) {
+		resp, err := http.Get(app.Server.URL + "/debug/diagnostics")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("GC", func(t *testing.T) {
+		resp, cleanup := client.Get("/debug/gc")
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var stats presenters.GCStats
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+		assert.Greater(t, stats.NumGoroutine, 0)
+		assert.Greater(t, stats.HeapAlloc, uint64(0))
+	})
+
+	t.Run("Show", func(t *testing.T) {
+		resp, cleanup := client.Get("/debug/diagnostics")
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var diagnostics presenters.DiagnosticsResource
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &diagnostics))
+		assert.Equal(t, static.Version, diagnostics.Version)
+		assert.Greater(t, diagnostics.Runtime.NumGoroutine, 0)
+		assert.Greater(t, diagnostics.DBPool.OpenConnections, 0)
+		assert.Equal(t, int64(0), diagnostics.Queues.UnfinishedPipelineRuns)
+		assert.Equal(t, uint16(6688), diagnostics.Config.Port)
+	})
+}
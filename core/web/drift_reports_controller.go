@@ -0,0 +1,45 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// DriftReportsController compares the node's jobs against a set of expected
+// job specs
+type DriftReportsController struct {
+	App chainlink.Application
+}
+
+// DriftReportRequest is the set of job specs that are expected to be running
+// on the node, identified by name and checksum (see job.SpecChecksum)
+type DriftReportRequest struct {
+	Specs []job.ExpectedSpec `json:"specs"`
+}
+
+// Create reports which of the expected specs have no matching job, which jobs
+// don't match any expected spec, and which jobs differ from the expected spec
+// of the same name.
+// Example:
+// "POST <application>/drift_reports"
+func (drc *DriftReportsController) Create(c *gin.Context) {
+	request := DriftReportRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jobs, err := drc.App.GetJobORM().JobsV2()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	report := job.DetectDrift(jobs, request.Specs)
+	jsonAPIResponse(c, presenters.NewDriftReportResource(report), "driftReports")
+}
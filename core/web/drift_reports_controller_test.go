@@ -0,0 +1,51 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestDriftReportsController_Create(t *testing.T) {
+	_, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	specTOML := string(cltest.MustReadFile(t, "testdata/keeper-spec.toml"))
+	body, _ := json.Marshal(models.CreateJobSpecRequest{TOML: specTOML})
+	resp, cleanup := client.Post("/v2/jobs", bytes.NewReader(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var created presenters.JobResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &created))
+	checksum, err := job.SpecChecksum(specTOML)
+	require.NoError(t, err)
+	assert.Equal(t, checksum, created.SpecChecksum)
+
+	body, _ = json.Marshal(web.DriftReportRequest{Specs: []job.ExpectedSpec{
+		{Name: "example keeper spec", Checksum: "0123"},
+		{Name: "missing spec", Checksum: checksum},
+	}})
+	resp, cleanup = client.Post("/v2/drift_reports", bytes.NewReader(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var report presenters.DriftReportResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &report))
+	assert.Equal(t, []job.ExpectedSpec{{Name: "missing spec", Checksum: checksum}}, report.Missing)
+	assert.Empty(t, report.Extra)
+	require.Len(t, report.Drifted, 1)
+	assert.Equal(t, created.ID, report.Drifted[0].JobID)
+	assert.Equal(t, checksum, report.Drifted[0].Checksum)
+	assert.Equal(t, "0123", report.Drifted[0].ExpectedChecksum)
+}
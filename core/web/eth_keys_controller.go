@@ -3,16 +3,22 @@ package web
 import (
 	"io/ioutil"
 	"net/http"
+	"os"
 	"strconv"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 )
 
 // KeysController manages account keys
@@ -20,13 +26,19 @@ type ETHKeysController struct {
 	App chainlink.Application
 }
 
-// Index returns the node's Ethereum keys and the account balances of ETH & LINK.
+// Index returns a page of the node's Ethereum keys and the account balances of
+// ETH & LINK. Keys can be sorted and filtered by id, isFunding, isTransmitter
+// and createdAt.
 // Example:
 //  "<application>/keys/eth"
-func (ekc *ETHKeysController) Index(c *gin.Context) {
+//  "<application>/keys/eth?filter[isFunding]=false"
+func (ekc *ETHKeysController) Index(c *gin.Context, p orm.Pagination, page int) {
 	store := ekc.App.GetStore()
-	keys, err := store.AllKeys()
-	if err != nil {
+	keys, count, err := store.Keys(p)
+	if errors.Cause(err) == orm.ErrInvalidPagination {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
 		err = errors.Errorf("error fetching ETH keys from database: %v", err)
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
@@ -61,12 +73,13 @@ func (ekc *ETHKeysController) Index(c *gin.Context) {
 			NextNonce:   k.NextNonce,
 			LastUsed:    k.LastUsed,
 			IsFunding:   k.IsFunding,
+			KeySettings: k.Settings(),
 			CreatedAt:   k.CreatedAt,
 			UpdatedAt:   k.UpdatedAt,
 			DeletedAt:   k.DeletedAt,
 		})
 	}
-	jsonAPIResponse(c, pkeys, "keys")
+	paginatedResponse(c, "keys", p.Limit, page, pkeys, count, nil)
 }
 
 // Create adds a new account
@@ -82,6 +95,7 @@ func (ekc *ETHKeysController) Create(c *gin.Context) {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
+	ekc.App.GetStore().Events.Publish(eventbus.KeyGenerated{Type: eventbus.KeyTypeETH, ID: account.Address.Hex()})
 
 	key, err := ekc.App.GetStore().KeyByAddress(account.Address)
 	if err != nil {
@@ -105,6 +119,7 @@ func (ekc *ETHKeysController) Create(c *gin.Context) {
 		NextNonce:   key.NextNonce,
 		LastUsed:    key.LastUsed,
 		IsFunding:   key.IsFunding,
+		KeySettings: key.Settings(),
 		CreatedAt:   key.CreatedAt,
 		UpdatedAt:   key.UpdatedAt,
 		DeletedAt:   key.DeletedAt,
@@ -173,6 +188,7 @@ func (ekc *ETHKeysController) Delete(c *gin.Context) {
 		NextNonce:   key.NextNonce,
 		LastUsed:    key.LastUsed,
 		IsFunding:   key.IsFunding,
+		KeySettings: key.Settings(),
 		CreatedAt:   key.CreatedAt,
 		UpdatedAt:   key.UpdatedAt,
 		DeletedAt:   key.DeletedAt,
@@ -232,6 +248,7 @@ func (ekc *ETHKeysController) Import(c *gin.Context) {
 		NextNonce:   key.NextNonce,
 		LastUsed:    key.LastUsed,
 		IsFunding:   key.IsFunding,
+		KeySettings: key.Settings(),
 		CreatedAt:   key.CreatedAt,
 		UpdatedAt:   key.UpdatedAt,
 		DeletedAt:   key.DeletedAt,
@@ -253,3 +270,230 @@ func (ekc *ETHKeysController) Export(c *gin.Context) {
 	}
 	c.Data(http.StatusOK, MediaType, bytes)
 }
+
+// Update changes the limits and role of an ETH key
+// Example:
+// "PATCH <application>/keys/eth/:keyID"
+func (ekc *ETHKeysController) Update(c *gin.Context) {
+	if !common.IsHexAddress(c.Param("keyID")) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("invalid address"))
+		return
+	}
+	address := common.HexToAddress(c.Param("keyID"))
+
+	var settings models.KeySettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if settings.MaxGasPriceWei != nil && settings.MaxGasPriceWei.ToInt().Sign() <= 0 {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("maxGasPriceWei must be positive"))
+		return
+	}
+	if settings.MaxInFlightTransactions != nil && *settings.MaxInFlightTransactions == 0 {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("maxInFlightTransactions must be positive"))
+		return
+	}
+
+	key, err := ekc.App.GetStore().UpdateKeySettings(address, settings)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Key does not exist"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	ethBalance, err := ekc.App.GetStore().EthClient.BalanceAt(c.Request.Context(), address, nil)
+	if err != nil {
+		logger.Errorf("error calling getEthBalance on Ethereum node: %v", err)
+	}
+	linkAddress := common.HexToAddress(ekc.App.GetStore().Config.LinkContractAddress())
+	linkBalance, err := ekc.App.GetStore().EthClient.GetLINKBalance(linkAddress, address)
+	if err != nil {
+		logger.Errorf("error calling getLINKBalance on Ethereum node: %v", err)
+	}
+
+	pek := presenters.ETHKey{
+		Address:     address.Hex(),
+		EthBalance:  (*assets.Eth)(ethBalance),
+		LinkBalance: linkBalance,
+		NextNonce:   key.NextNonce,
+		LastUsed:    key.LastUsed,
+		IsFunding:   key.IsFunding,
+		KeySettings: key.Settings(),
+		CreatedAt:   key.CreatedAt,
+		UpdatedAt:   key.UpdatedAt,
+		DeletedAt:   key.DeletedAt,
+	}
+	jsonAPIResponse(c, pek, "account")
+}
+
+// Restore restores an archived ETH key
+// Example:
+// "POST <application>/keys/eth/restore/:keyID"
+func (ekc *ETHKeysController) Restore(c *gin.Context) {
+	if !common.IsHexAddress(c.Param("keyID")) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("invalid address"))
+		return
+	}
+	address := common.HexToAddress(c.Param("keyID"))
+
+	err := ekc.App.GetStore().RestoreKey(address)
+	if errors.Is(err, os.ErrNotExist) {
+		jsonAPIError(c, http.StatusNotFound, errors.Errorf("cannot restore key %s: %v; it may have been purged", address.Hex(), err))
+		return
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	key, err := ekc.App.GetStore().KeyByAddress(address)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	ethBalance, err := ekc.App.GetStore().EthClient.BalanceAt(c.Request.Context(), address, nil)
+	if err != nil {
+		logger.Errorf("error calling getEthBalance on Ethereum node: %v", err)
+	}
+	linkAddress := common.HexToAddress(ekc.App.GetStore().Config.LinkContractAddress())
+	linkBalance, err := ekc.App.GetStore().EthClient.GetLINKBalance(linkAddress, address)
+	if err != nil {
+		logger.Errorf("error calling getLINKBalance on Ethereum node: %v", err)
+	}
+
+	pek := presenters.ETHKey{
+		Address:     address.Hex(),
+		EthBalance:  (*assets.Eth)(ethBalance),
+		LinkBalance: linkBalance,
+		NextNonce:   key.NextNonce,
+		LastUsed:    key.LastUsed,
+		IsFunding:   key.IsFunding,
+		KeySettings: key.Settings(),
+		CreatedAt:   key.CreatedAt,
+		UpdatedAt:   key.UpdatedAt,
+		DeletedAt:   key.DeletedAt,
+	}
+	jsonAPIResponse(c, pek, "account")
+}
+
+// Nonces returns the nonces of an ETH key that haven't been mined yet, and the
+// gaps between them that leave its transactions stuck
+// Example:
+// "GET <application>/keys/eth/nonces/:address"
+func (ekc *ETHKeysController) Nonces(c *gin.Context) {
+	address, ok := ekc.existingKeyAddress(c)
+	if !ok {
+		return
+	}
+	store := ekc.App.GetStore()
+	ladder, err := bulletprooftxmanager.FindNonceLadder(c.Request.Context(), store.DB, store.EthClient, address)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presentNonceLadder(ladder), "nonceLadder")
+}
+
+// FillNonceGapsRequest lists the gaps in a key's nonces to fill. All of them
+// are filled if it is empty.
+type FillNonceGapsRequest struct {
+	Nonces []int64 `json:"nonces"`
+}
+
+// FillNonceGaps fills gaps in the nonces of an ETH key with empty
+// transactions, so that the transactions after them can be mined, and returns
+// the key's nonces
+// Example:
+// "POST <application>/keys/eth/nonces/:address"
+func (ekc *ETHKeysController) FillNonceGaps(c *gin.Context) {
+	address, ok := ekc.existingKeyAddress(c)
+	if !ok {
+		return
+	}
+	var request FillNonceGapsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+
+	store := ekc.App.GetStore()
+	ctx := c.Request.Context()
+	ladder, err := bulletprooftxmanager.FindNonceLadder(ctx, store.DB, store.EthClient, address)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	gaps := ladder.Gaps()
+	if len(request.Nonces) > 0 {
+		isGap := make(map[int64]bool)
+		for _, nonce := range gaps {
+			isGap[nonce] = true
+		}
+		for _, nonce := range request.Nonces {
+			if !isGap[nonce] {
+				jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("nonce %d is not a gap", nonce))
+				return
+			}
+		}
+		gaps = request.Nonces
+	}
+
+	if len(gaps) > 0 {
+		if _, err = bulletprooftxmanager.FillNonceGaps(ctx, store, store.Config, address, gaps); err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		ladder, err = bulletprooftxmanager.FindNonceLadder(ctx, store.DB, store.EthClient, address)
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	jsonAPIResponse(c, presentNonceLadder(ladder), "nonceLadder")
+}
+
+func (ekc *ETHKeysController) existingKeyAddress(c *gin.Context) (common.Address, bool) {
+	if !common.IsHexAddress(c.Param("address")) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("invalid address"))
+		return common.Address{}, false
+	}
+	address := common.HexToAddress(c.Param("address"))
+	if exists, err := ekc.App.GetStore().KeyExists(address); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return common.Address{}, false
+	} else if !exists {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Key does not exist"))
+		return common.Address{}, false
+	}
+	return address, true
+}
+
+func presentNonceLadder(ladder bulletprooftxmanager.NonceLadder) presenters.NonceLadder {
+	pl := presenters.NonceLadder{
+		Address:      ladder.Address.Hex(),
+		MinedNonce:   ladder.MinedNonce,
+		PendingNonce: ladder.PendingNonce,
+		NextNonce:    ladder.NextNonce,
+		Gaps:         ladder.Gaps(),
+		Rungs:        []presenters.NonceRung{},
+	}
+	if pl.Gaps == nil {
+		pl.Gaps = []int64{}
+	}
+	for _, rung := range ladder.Rungs {
+		pl.Rungs = append(pl.Rungs, presenters.NonceRung{
+			Nonce:   rung.Nonce,
+			State:   string(rung.State),
+			EthTxID: rung.EthTxID,
+		})
+	}
+	return pl
+}
@@ -1,10 +1,12 @@
 package web_test
 
 import (
+	"bytes"
 	"math/big"
 	"net/http"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
@@ -115,3 +117,55 @@ func TestETHKeysController_CreateSuccess(t *testing.T) {
 
 	ethClient.AssertExpectations(t)
 }
+
+func TestETHKeysController_NonceGaps(t *testing.T) {
+	t.Parallel()
+
+	config, _ := cltest.NewConfig(t)
+	ethClient := new(mocks.Client)
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config, ethClient)
+	defer cleanup()
+
+	verify := cltest.MockApplicationEthCalls(t, app, ethClient)
+	defer verify()
+
+	_, from := cltest.MustAddRandomKeyToKeystore(t, app.Store, 2)
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, app.Store, 1, from)
+	ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_getTransactionCount", from, "latest").
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*hexutil.Uint64) = 0
+		})
+	ethClient.On("PendingNonceAt", mock.Anything, from).Return(uint64(0), nil)
+
+	client := app.NewHTTPClient()
+	require.NoError(t, app.StartAndConnect())
+
+	resp, cleanup := client.Get("/v2/keys/eth/nonces/" + from.Hex())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var ladder presenters.NonceLadder
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &ladder))
+	assert.Equal(t, from.Hex(), ladder.Address)
+	assert.Equal(t, int64(2), ladder.NextNonce)
+	assert.Equal(t, []int64{0}, ladder.Gaps)
+	assert.Equal(t, []presenters.NonceRung{
+		{Nonce: 0, State: "gap"},
+		{Nonce: 1, State: "unconfirmed", EthTxID: etx.ID},
+	}, ladder.Rungs)
+
+	resp, cleanup = client.Post("/v2/keys/eth/nonces/"+from.Hex(), bytes.NewBufferString(`{"nonces":[1]}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Post("/v2/keys/eth/nonces/"+from.Hex(), bytes.NewBufferString(`{"nonces":[0]}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &ladder))
+	assert.Empty(t, ladder.Gaps)
+	require.Len(t, ladder.Rungs, 2)
+	assert.Equal(t, "unconfirmed", ladder.Rungs[0].State)
+	cltest.AssertCount(t, app.Store, models.EthTx{}, 2)
+}
@@ -0,0 +1,59 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+)
+
+// EventsController streams the node's lifecycle events
+type EventsController struct {
+	App chainlink.Application
+}
+
+// Stream sends the events of the comma separated topics, or all events, as
+// server-sent events until the client disconnects. The event name is the
+// topic and the data is the event as JSON. Streams are cut off after
+// HTTP_SERVER_WRITE_TIMEOUT, so clients are expected to reconnect.
+// Example:
+// "GET <application>/events?topics=job_created,run_finished"
+func (ec *EventsController) Stream(c *gin.Context) {
+	var topics []eventbus.Topic
+	if param := c.Query("topics"); param != "" {
+		for _, name := range strings.Split(param, ",") {
+			topic, err := eventbus.ParseTopic(strings.TrimSpace(name))
+			if err != nil {
+				jsonAPIError(c, http.StatusUnprocessableEntity, err)
+				return
+			}
+			topics = append(topics, topic)
+		}
+	}
+
+	sub := ec.App.GetStore().Events.Subscribe(topics...)
+	defer sub.Close()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Content-Type", "text/event-stream")
+	// The headers are sent up front, so that clients know that they are
+	// subscribed before the first event
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return false
+			}
+			c.SSEvent(string(event.Topic()), event)
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// FeatureFlagsController manages the feature flags gating experimental job
+// types and tasks
+type FeatureFlagsController struct {
+	App chainlink.Application
+}
+
+// FeatureFlagPatchRequest overrides a feature flag at runtime
+type FeatureFlagPatchRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// Index lists every feature flag and whether it is enabled
+// Example:
+// "GET <application>/feature_flags"
+func (ffc *FeatureFlagsController) Index(c *gin.Context) {
+	jsonAPIResponse(c, presenters.NewFeatureFlagResources(ffc.App.GetStore().Config), "featureFlags")
+}
+
+// Update overrides a feature flag at runtime. The override takes precedence
+// over the flag's env var, and persists across restarts.
+// Example:
+// "PATCH <application>/feature_flags/FEATURE_OFFCHAIN_REPORTING"
+func (ffc *FeatureFlagsController) Update(c *gin.Context) {
+	feature, err := orm.ParseFeature(c.Param("name"))
+	if err != nil {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	}
+	request := &FeatureFlagPatchRequest{}
+	if err = c.ShouldBindJSON(request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if request.Enabled == nil {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("enabled must be set"))
+		return
+	}
+
+	config := ffc.App.GetStore().Config
+	if err = config.SetFeatureEnabled(c.Request.Context(), feature, *request.Enabled); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewFeatureFlagResource(config, feature), "featureFlags")
+}
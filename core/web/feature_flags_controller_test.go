@@ -0,0 +1,52 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestFeatureFlagsController(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/feature_flags")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var flags []presenters.FeatureFlagResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &flags))
+	require.Len(t, flags, len(orm.Features))
+	for _, flag := range flags {
+		assert.False(t, flag.Overridden)
+	}
+
+	resp, cleanup = client.Patch("/v2/feature_flags/FEATURE_EXPERIMENTAL_TASKS", bytes.NewBufferString(`{"enabled": true}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var flag presenters.FeatureFlagResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &flag))
+	assert.Equal(t, "FEATURE_EXPERIMENTAL_TASKS", flag.ID)
+	assert.True(t, flag.Enabled)
+	assert.True(t, flag.Overridden)
+	assert.True(t, app.Store.Config.FeatureExperimentalTasks())
+
+	resp, cleanup = client.Patch("/v2/feature_flags/FEATURE_TIME_TRAVEL", bytes.NewBufferString(`{"enabled": true}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
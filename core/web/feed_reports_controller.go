@@ -0,0 +1,92 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+const (
+	defaultFeedReportBlocks = 1000
+	maxFeedReportBlocks     = 100000
+	feedReportRunCount      = 10
+)
+
+// FeedReportsController compares OCR feeds with this node's part in them
+type FeedReportsController struct {
+	App chainlink.Application
+}
+
+// Show compares the rounds an OCR contract received over the last `blocks`
+// blocks (default 1000) with the observations and transmissions of the
+// node's job for that contract.
+// Example:
+// "GET <application>/feed_reports/:contractAddress?blocks=1000"
+func (frc *FeedReportsController) Show(c *gin.Context) {
+	if !common.IsHexAddress(c.Param("contractAddress")) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("invalid contract address"))
+		return
+	}
+	contractAddress := common.HexToAddress(c.Param("contractAddress"))
+
+	blocks := uint64(defaultFeedReportBlocks)
+	if param := c.Query("blocks"); param != "" {
+		var err error
+		blocks, err = strconv.ParseUint(param, 10, 64)
+		if err != nil || blocks == 0 || blocks > maxFeedReportBlocks {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("blocks must be between 1 and %d", maxFeedReportBlocks))
+			return
+		}
+	}
+
+	jobs, err := frc.App.GetJobORM().JobsV2()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	var ocrJob *job.Job
+	for i, jb := range jobs {
+		spec := jb.OffchainreportingOracleSpec
+		if spec != nil && !spec.IsBootstrapPeer && spec.ContractAddress.Address() == contractAddress {
+			ocrJob = &jobs[i]
+		}
+	}
+	if ocrJob == nil {
+		jsonAPIError(c, http.StatusNotFound, errors.New("no offchain reporting job for this contract"))
+		return
+	}
+
+	// Reports are transmitted from the forwarder when the job uses one
+	spec := ocrJob.OffchainreportingOracleSpec
+	transmitterAddress := spec.TransmitterAddress
+	if spec.ForwarderAddress != nil {
+		transmitterAddress = spec.ForwarderAddress
+	}
+	if transmitterAddress == nil {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("the job has no transmitter address"))
+		return
+	}
+
+	report, err := offchainreporting.NewFeedReport(c.Request.Context(), frc.App.GetStore().EthClient, contractAddress, transmitterAddress.Address(), blocks)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	runs, _, err := frc.App.GetJobORM().PipelineRunsByJobID(ocrJob.ID, orm.Pagination{Limit: feedReportRunCount})
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	report.SetLatestLocalObservation(runs)
+
+	jsonAPIResponse(c, presenters.NewFeedReportResource(ocrJob.ID, report), "feedReports")
+}
@@ -0,0 +1,88 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// ForwardersController manages the forwarder contracts that OCR jobs may
+// transmit through
+type ForwardersController struct {
+	App chainlink.Application
+}
+
+// CreateForwarderRequest is the request body for registering a forwarder
+type CreateForwarderRequest struct {
+	Address common.Address `json:"address"`
+}
+
+// Index lists forwarders
+// Example:
+// "GET <application>/forwarders"
+func (fc *ForwardersController) Index(c *gin.Context) {
+	fwds, err := fc.App.GetStore().Forwarders()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, fwds, "forwarders")
+}
+
+// Create registers a forwarder
+// Example:
+// "POST <application>/forwarders"
+func (fc *ForwardersController) Create(c *gin.Context) {
+	var request CreateForwarderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if request.Address == (common.Address{}) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("address is required"))
+		return
+	}
+
+	fwd, err := fc.App.GetStore().CreateForwarder(request.Address)
+	var pqErr *pgconn.PgError
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		jsonAPIError(c, http.StatusConflict, errors.New("forwarder already exists"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, fwd, "forwarders", http.StatusCreated)
+}
+
+// Delete removes a forwarder that no job uses
+// Example:
+// "DELETE <application>/forwarders/:fwdID"
+func (fc *ForwardersController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("fwdID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = fc.App.GetStore().DeleteForwarder(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Forwarder not found"))
+		return
+	} else if errors.Is(err, orm.ErrForwarderInUse) {
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, nil, "forwarder", http.StatusNoContent)
+}
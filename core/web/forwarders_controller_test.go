@@ -0,0 +1,58 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func TestForwardersController_CreateIndexDelete(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	address := cltest.NewAddress()
+	body := fmt.Sprintf(`{"address":"%s"}`, address.Hex())
+
+	resp, cleanup := client.Post("/v2/forwarders", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	var fwd models.Forwarder
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &fwd))
+	assert.Equal(t, address, fwd.Address.Address())
+
+	resp, cleanup = client.Post("/v2/forwarders", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Get("/v2/forwarders")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var fwds []models.Forwarder
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &fwds))
+	require.Len(t, fwds, 1)
+	assert.Equal(t, fwd.ID, fwds[0].ID)
+
+	resp, cleanup = client.Delete(fmt.Sprintf("/v2/forwarders/%d", fwd.ID))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+
+	resp, cleanup = client.Delete(fmt.Sprintf("/v2/forwarders/%d", fwd.ID))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
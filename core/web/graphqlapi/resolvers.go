@@ -0,0 +1,478 @@
+// Package graphqlapi serves the operator API as GraphQL, so that clients can
+// fetch nested resources, such as a job's recent runs and their errored task
+// runs, in a single request.
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// NewHandler returns the HTTP handler serving GraphQL queries against app
+func NewHandler(app chainlink.Application) http.Handler {
+	return &relay.Handler{Schema: graphql.MustParseSchema(schema, &Resolver{app})}
+}
+
+// Resolver resolves the root Query type
+type Resolver struct {
+	app chainlink.Application
+}
+
+func (r *Resolver) Jobs(args struct{ Limit, Offset int32 }) ([]*jobResolver, error) {
+	jobs, _, err := r.app.GetJobORM().PaginatedJobsV2(page(args.Limit, args.Offset), false)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*jobResolver, len(jobs))
+	for i := range jobs {
+		resolvers[i] = &jobResolver{r.app, jobs[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Job(args struct{ ID graphql.ID }) (*jobResolver, error) {
+	id, err := strconv.ParseInt(string(args.ID), 10, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid job ID")
+	}
+	jb, err := r.app.GetJobORM().FindJob(int32(id))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &jobResolver{r.app, jb}, nil
+}
+
+func (r *Resolver) Run(ctx context.Context, args struct{ ID graphql.ID }) (*runResolver, error) {
+	id, err := strconv.ParseInt(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid run ID")
+	}
+	var run pipeline.Run
+	err = r.app.GetStore().DB.WithContext(ctx).
+		Preload("PipelineTaskRuns").
+		First(&run, "id = ?", id).
+		Error
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &runResolver{run}, nil
+}
+
+func (r *Resolver) Bridges(args struct{ Limit, Offset int32 }) ([]*bridgeResolver, error) {
+	bridges, _, err := r.app.GetStore().BridgeTypes(page(args.Limit, args.Offset))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*bridgeResolver, len(bridges))
+	for i := range bridges {
+		resolvers[i] = &bridgeResolver{bridges[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Bridge(args struct{ Name string }) (*bridgeResolver, error) {
+	name, err := models.NewTaskType(args.Name)
+	if err != nil {
+		return nil, err
+	}
+	bt, err := r.app.GetStore().FindBridge(name)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &bridgeResolver{bt}, nil
+}
+
+func (r *Resolver) Keys() (*keysResolver, error) {
+	store := r.app.GetStore()
+	var resolver keysResolver
+	var err error
+	if resolver.eth, err = store.AllKeys(); err != nil {
+		return nil, err
+	}
+	if resolver.ocr, err = store.OCRKeyStore.FindEncryptedOCRKeyBundles(); err != nil {
+		return nil, err
+	}
+	if resolver.p2p, err = store.OCRKeyStore.FindEncryptedP2PKeys(); err != nil {
+		return nil, err
+	}
+	return &resolver, nil
+}
+
+type jobResolver struct {
+	app chainlink.Application
+	job job.Job
+}
+
+func (r *jobResolver) ID() graphql.ID {
+	return graphql.ID(r.job.GetID())
+}
+
+func (r *jobResolver) Name() *string {
+	return r.job.Name.Ptr()
+}
+
+func (r *jobResolver) Type() string {
+	return r.job.Type.String()
+}
+
+func (r *jobResolver) SchemaVersion() int32 {
+	return int32(r.job.SchemaVersion)
+}
+
+func (r *jobResolver) MaxTaskDuration() string {
+	return time.Duration(r.job.MaxTaskDuration).String()
+}
+
+func (r *jobResolver) SpecTOML() (string, error) {
+	return job.JobToSpecTOML(r.job)
+}
+
+func (r *jobResolver) PipelineSpec() *pipelineSpecResolver {
+	if r.job.PipelineSpec == nil {
+		return &pipelineSpecResolver{r.app, pipeline.Spec{ID: r.job.PipelineSpecID}}
+	}
+	return &pipelineSpecResolver{r.app, *r.job.PipelineSpec}
+}
+
+func (r *jobResolver) Runs(args struct{ Limit, Offset int32 }) ([]*runResolver, error) {
+	runs, _, err := r.app.GetJobORM().PipelineRunsByJobID(r.job.ID, page(args.Limit, args.Offset))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*runResolver, len(runs))
+	for i := range runs {
+		resolvers[i] = &runResolver{runs[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *jobResolver) Errors() []*jobErrorResolver {
+	resolvers := make([]*jobErrorResolver, len(r.job.JobSpecErrors))
+	for i := range r.job.JobSpecErrors {
+		resolvers[i] = &jobErrorResolver{r.job.JobSpecErrors[i]}
+	}
+	return resolvers
+}
+
+type pipelineSpecResolver struct {
+	app  chainlink.Application
+	spec pipeline.Spec
+}
+
+func (r *pipelineSpecResolver) ID() graphql.ID {
+	return graphql.ID(strconv.FormatInt(int64(r.spec.ID), 10))
+}
+
+func (r *pipelineSpecResolver) DotDagSource() string {
+	return r.spec.DotDagSource
+}
+
+func (r *pipelineSpecResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.spec.CreatedAt}
+}
+
+func (r *pipelineSpecResolver) ContentHash() *string {
+	if r.spec.ContentHash == nil {
+		return nil
+	}
+	hash := r.spec.ContentHash.String()
+	return &hash
+}
+
+func (r *pipelineSpecResolver) JobIDs() ([]graphql.ID, error) {
+	jobIDs, err := r.app.GetJobORM().FindJobIDsWithPipelineSpec(r.spec.ID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, len(jobIDs))
+	for i, id := range jobIDs {
+		ids[i] = graphql.ID(strconv.FormatInt(int64(id), 10))
+	}
+	return ids, nil
+}
+
+type jobErrorResolver struct {
+	specError job.SpecError
+}
+
+func (r *jobErrorResolver) ID() graphql.ID {
+	return graphql.ID(strconv.FormatInt(r.specError.ID, 10))
+}
+
+func (r *jobErrorResolver) Description() string {
+	return r.specError.Description
+}
+
+func (r *jobErrorResolver) Occurrences() int32 {
+	return int32(r.specError.Occurrences)
+}
+
+func (r *jobErrorResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.specError.CreatedAt}
+}
+
+func (r *jobErrorResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.specError.UpdatedAt}
+}
+
+type runResolver struct {
+	run pipeline.Run
+}
+
+func (r *runResolver) ID() graphql.ID {
+	return graphql.ID(r.run.GetID())
+}
+
+func (r *runResolver) Status() string {
+	switch r.run.Status() {
+	case pipeline.RunStatusInProgress:
+		return "in_progress"
+	case pipeline.RunStatusCompleted:
+		return "completed"
+	case pipeline.RunStatusErrored:
+		return "errored"
+	case pipeline.RunStatusSuppressed:
+		return "suppressed"
+	default:
+		return "unknown"
+	}
+}
+
+func (r *runResolver) Outputs() (string, error) {
+	return marshalJSON(r.run.Outputs)
+}
+
+func (r *runResolver) Errors() []*string {
+	errs := make([]*string, len(r.run.Errors))
+	for i := range r.run.Errors {
+		errs[i] = r.run.Errors[i].Ptr()
+	}
+	return errs
+}
+
+func (r *runResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.run.CreatedAt}
+}
+
+func (r *runResolver) FinishedAt() *graphql.Time {
+	return timePtr(r.run.FinishedAt)
+}
+
+func (r *runResolver) TaskRuns(args struct{ Errored bool }) []*taskRunResolver {
+	var resolvers []*taskRunResolver
+	for i := range r.run.PipelineTaskRuns {
+		tr := r.run.PipelineTaskRuns[i]
+		if args.Errored && !tr.Error.Valid {
+			continue
+		}
+		resolvers = append(resolvers, &taskRunResolver{tr})
+	}
+	return resolvers
+}
+
+type taskRunResolver struct {
+	taskRun pipeline.TaskRun
+}
+
+func (r *taskRunResolver) ID() graphql.ID {
+	return graphql.ID(r.taskRun.GetID())
+}
+
+func (r *taskRunResolver) Type() string {
+	return string(r.taskRun.Type)
+}
+
+func (r *taskRunResolver) DotID() string {
+	return r.taskRun.DotID
+}
+
+func (r *taskRunResolver) Output() (*string, error) {
+	if r.taskRun.Output == nil {
+		return nil, nil
+	}
+	output, err := marshalJSON(r.taskRun.Output)
+	return &output, err
+}
+
+func (r *taskRunResolver) Error() *string {
+	return r.taskRun.Error.Ptr()
+}
+
+func (r *taskRunResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.taskRun.CreatedAt}
+}
+
+func (r *taskRunResolver) StartedAt() *graphql.Time {
+	return timePtr(r.taskRun.StartedAt)
+}
+
+func (r *taskRunResolver) FinishedAt() *graphql.Time {
+	return timePtr(r.taskRun.FinishedAt)
+}
+
+func (r *taskRunResolver) QueueWait() string {
+	return time.Duration(r.taskRun.QueueWait).String()
+}
+
+func (r *taskRunResolver) StackTrace() *string {
+	return r.taskRun.StackTrace.Ptr()
+}
+
+type bridgeResolver struct {
+	bridge models.BridgeType
+}
+
+func (r *bridgeResolver) Name() string {
+	return r.bridge.Name.String()
+}
+
+func (r *bridgeResolver) URL() string {
+	return r.bridge.URL.String()
+}
+
+func (r *bridgeResolver) Confirmations() int32 {
+	return int32(r.bridge.Confirmations)
+}
+
+func (r *bridgeResolver) MinimumContractPayment() *string {
+	if r.bridge.MinimumContractPayment == nil {
+		return nil
+	}
+	payment := r.bridge.MinimumContractPayment.String()
+	return &payment
+}
+
+type keysResolver struct {
+	eth []models.Key
+	ocr []ocrkey.EncryptedKeyBundle
+	p2p []p2pkey.EncryptedP2PKey
+}
+
+func (r *keysResolver) ETH() []*ethKeyResolver {
+	resolvers := make([]*ethKeyResolver, len(r.eth))
+	for i := range r.eth {
+		resolvers[i] = &ethKeyResolver{r.eth[i]}
+	}
+	return resolvers
+}
+
+func (r *keysResolver) OCR() []*ocrKeyBundleResolver {
+	resolvers := make([]*ocrKeyBundleResolver, len(r.ocr))
+	for i := range r.ocr {
+		resolvers[i] = &ocrKeyBundleResolver{r.ocr[i]}
+	}
+	return resolvers
+}
+
+func (r *keysResolver) P2P() []*p2pKeyResolver {
+	resolvers := make([]*p2pKeyResolver, len(r.p2p))
+	for i := range r.p2p {
+		resolvers[i] = &p2pKeyResolver{r.p2p[i]}
+	}
+	return resolvers
+}
+
+type ethKeyResolver struct {
+	key models.Key
+}
+
+func (r *ethKeyResolver) Address() string {
+	return r.key.Address.Hex()
+}
+
+func (r *ethKeyResolver) NextNonce() int32 {
+	return int32(r.key.NextNonce)
+}
+
+func (r *ethKeyResolver) IsFunding() bool {
+	return r.key.IsFunding
+}
+
+func (r *ethKeyResolver) LastUsed() *graphql.Time {
+	return timePtr(r.key.LastUsed)
+}
+
+func (r *ethKeyResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.key.CreatedAt}
+}
+
+type ocrKeyBundleResolver struct {
+	bundle ocrkey.EncryptedKeyBundle
+}
+
+func (r *ocrKeyBundleResolver) ID() graphql.ID {
+	return graphql.ID(r.bundle.ID.String())
+}
+
+func (r *ocrKeyBundleResolver) OnChainSigningAddress() string {
+	return r.bundle.OnChainSigningAddress.String()
+}
+
+func (r *ocrKeyBundleResolver) OffChainPublicKey() string {
+	return r.bundle.OffChainPublicKey.String()
+}
+
+func (r *ocrKeyBundleResolver) ConfigPublicKey() string {
+	return r.bundle.ConfigPublicKey.String()
+}
+
+func (r *ocrKeyBundleResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.bundle.CreatedAt}
+}
+
+type p2pKeyResolver struct {
+	key p2pkey.EncryptedP2PKey
+}
+
+func (r *p2pKeyResolver) ID() graphql.ID {
+	return graphql.ID(strconv.FormatInt(int64(r.key.ID), 10))
+}
+
+func (r *p2pKeyResolver) PeerID() string {
+	return r.key.PeerID.String()
+}
+
+func (r *p2pKeyResolver) PublicKey() string {
+	return r.key.PubKey.String()
+}
+
+func (r *p2pKeyResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.key.CreatedAt}
+}
+
+func page(limit, offset int32) orm.Pagination {
+	return orm.Pagination{Offset: int(offset), Limit: int(limit)}
+}
+
+func marshalJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func timePtr(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
@@ -0,0 +1,75 @@
+package graphqlapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestResolver_Query(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	_, bt := cltest.NewBridgeType(t, "voter_turnout", "http://example.com")
+	require.NoError(t, app.Store.CreateBridgeType(bt))
+
+	client := app.NewHTTPClient()
+	query := `{
+		bridges { name url }
+		bridge(name: "voter_turnout") { confirmations }
+		job(id: "9999") { id runs(limit: 10) { id taskRuns(errored: true) { dotId error } } }
+		keys { eth { address } }
+	}`
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+
+	resp, cleanup := client.Post("/v2/graphql", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var result struct {
+		Data struct {
+			Bridges []struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"bridges"`
+			Bridge *struct {
+				Confirmations int `json:"confirmations"`
+			} `json:"bridge"`
+			Job  *struct{} `json:"job"`
+			Keys struct {
+				ETH []struct {
+					Address string `json:"address"`
+				} `json:"eth"`
+			} `json:"keys"`
+		} `json:"data"`
+		Errors []interface{} `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Empty(t, result.Errors)
+
+	require.Len(t, result.Data.Bridges, 1)
+	assert.Equal(t, "voter_turnout", result.Data.Bridges[0].Name)
+	assert.Equal(t, "http://example.com", result.Data.Bridges[0].URL)
+	require.NotNil(t, result.Data.Bridge)
+	assert.Nil(t, result.Data.Job)
+	var addresses []string
+	for _, key := range result.Data.Keys.ETH {
+		addresses = append(addresses, key.Address)
+	}
+	assert.Contains(t, addresses, cltest.DefaultKeyAddress)
+}
@@ -0,0 +1,119 @@
+package graphqlapi
+
+// schema is the GraphQL schema of the operator API. Lists nested within other
+// types, such as a job's runs, are paged with limit and offset.
+const schema = `
+schema {
+	query: Query
+}
+
+scalar Time
+
+type Query {
+	jobs(limit: Int = 25, offset: Int = 0): [Job!]!
+	job(id: ID!): Job
+	run(id: ID!): Run
+	bridges(limit: Int = 25, offset: Int = 0): [Bridge!]!
+	bridge(name: String!): Bridge
+	keys: Keys!
+}
+
+type Job {
+	id: ID!
+	name: String
+	type: String!
+	schemaVersion: Int!
+	maxTaskDuration: String!
+	# The job's spec, reconstructed as TOML
+	specTOML: String!
+	pipelineSpec: PipelineSpec!
+	# The job's runs, most recent first
+	runs(limit: Int = 10, offset: Int = 0): [Run!]!
+	errors: [JobError!]!
+}
+
+type PipelineSpec {
+	id: ID!
+	dotDagSource: String!
+	createdAt: Time!
+	# Identifies the spec by its contents. Jobs with the same pipeline share a
+	# spec.
+	contentHash: String
+	# The jobs that run this pipeline
+	jobIDs: [ID!]!
+}
+
+type JobError {
+	id: ID!
+	description: String!
+	occurrences: Int!
+	createdAt: Time!
+	updatedAt: Time!
+}
+
+type Run {
+	id: ID!
+	# One of in_progress, completed, errored or suppressed
+	status: String!
+	# The run's outputs as JSON
+	outputs: String!
+	errors: [String]!
+	createdAt: Time!
+	finishedAt: Time
+	# The run's task runs, in the order they started. With errored: true only
+	# the task runs that errored are included.
+	taskRuns(errored: Boolean = false): [TaskRun!]!
+}
+
+type TaskRun {
+	id: ID!
+	type: String!
+	dotId: String!
+	# The task's output as JSON
+	output: String
+	error: String
+	createdAt: Time!
+	startedAt: Time
+	finishedAt: Time
+	# How long the task waited to start after its inputs were ready
+	queueWait: String!
+	# Where the task panicked, if it did
+	stackTrace: String
+}
+
+type Bridge {
+	name: String!
+	url: String!
+	confirmations: Int!
+	minimumContractPayment: String
+}
+
+type Keys {
+	eth: [ETHKey!]!
+	ocr: [OCRKeyBundle!]!
+	p2p: [P2PKey!]!
+}
+
+type ETHKey {
+	address: String!
+	nextNonce: Int!
+	isFunding: Boolean!
+	lastUsed: Time
+	createdAt: Time!
+}
+
+type OCRKeyBundle {
+	id: ID!
+	onChainSigningAddress: String!
+	offChainPublicKey: String!
+	configPublicKey: String!
+	createdAt: Time!
+}
+
+type P2PKey {
+	id: ID!
+	peerId: String!
+	publicKey: String!
+	createdAt: Time!
+}
+`
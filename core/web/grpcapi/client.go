@@ -0,0 +1,47 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+)
+
+// Client calls the operator API of a node
+type Client struct {
+	OperatorClient
+	conn *grpc.ClientConn
+}
+
+// Dial connects to the operator API at target, authenticating every call with
+// the API token
+func Dial(target string, token auth.Token, opts ...grpc.DialOption) (*Client, error) {
+	opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(token)))
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{NewOperatorClient(conn), conn}, nil
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// tokenCredentials sends the API token as metadata with every call
+type tokenCredentials auth.Token
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		APIKeyMetadata:    t.AccessKey,
+		APISecretMetadata: t.Secret,
+	}, nil
+}
+
+// RequireTransportSecurity allows the token to be sent without TLS, as the
+// REST API does when it isn't served over TLS
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
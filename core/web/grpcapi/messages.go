@@ -0,0 +1,217 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/web"
+	webpresenters "github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// pagination returns the page to select
+func (r *PageRequest) pagination() orm.Pagination {
+	size, page := int(r.GetSize()), int(r.GetPage())
+	if size < 1 {
+		size = web.PaginationDefault
+	}
+	if page < 1 {
+		page = 1
+	}
+	return orm.Pagination{
+		Offset:  (page - 1) * size,
+		Limit:   size,
+		Sort:    r.GetSort(),
+		Filters: r.GetFilters(),
+		Cursor:  r.GetCursor(),
+	}
+}
+
+// newJob returns the message of a job. The spec of the job's type is the
+// REST API's presenter of it, so that the two APIs agree on its fields.
+func newJob(j job.Job) (*Job, error) {
+	r := webpresenters.NewJobResource(j)
+	var spec interface{}
+	switch {
+	case r.DirectRequestSpec != nil:
+		spec = r.DirectRequestSpec
+	case r.FluxMonitorSpec != nil:
+		spec = r.FluxMonitorSpec
+	case r.OffChainReportingSpec != nil:
+		spec = r.OffChainReportingSpec
+	case r.KeeperSpec != nil:
+		spec = r.KeeperSpec
+	case r.ShadowSpec != nil:
+		spec = r.ShadowSpec
+	case r.MessageQueueSpec != nil:
+		spec = r.MessageQueueSpec
+	}
+	specStruct := new(structpb.Struct)
+	if spec != nil {
+		if err := unmarshalJSON(spec, specStruct); err != nil {
+			return nil, err
+		}
+	}
+
+	msg := &Job{
+		Id:            j.ID,
+		Name:          r.Name,
+		Type:          string(r.Type),
+		SchemaVersion: r.SchemaVersion,
+		SpecChecksum:  r.SpecChecksum,
+		Spec:          specStruct,
+		DotDagSource:  r.PipelineSpec.DotDAGSource,
+		ArchivedAt:    newTimestamp(r.ArchivedAt),
+	}
+	if d := time.Duration(r.MaxTaskDuration); d != 0 {
+		msg.MaxTaskDuration = d.String()
+	}
+	for _, e := range r.Errors {
+		msg.Errors = append(msg.Errors, &JobError{
+			Id:          e.ID,
+			Description: e.Description,
+			Occurrences: uint32(e.Occurrences),
+			CreatedAt:   timestamppb.New(e.CreatedAt),
+			UpdatedAt:   timestamppb.New(e.UpdatedAt),
+		})
+	}
+	return msg, nil
+}
+
+func newJobs(jobs []job.Job) ([]*Job, error) {
+	msgs := make([]*Job, len(jobs))
+	for i, j := range jobs {
+		msg, err := newJob(j)
+		if err != nil {
+			return nil, err
+		}
+		msgs[i] = msg
+	}
+	return msgs, nil
+}
+
+// newRun returns the message of a run and its task runs
+func newRun(run pipeline.Run) (*Run, error) {
+	msg := &Run{
+		Id:         run.ID,
+		CreatedAt:  timestamppb.New(run.CreatedAt),
+		FinishedAt: newTimestamp(run.FinishedAt),
+	}
+	if run.JobID != nil {
+		msg.JobId = *run.JobID
+	}
+	var err error
+	if msg.Meta, err = newValue(run.Meta.Val); err != nil {
+		return nil, err
+	}
+	for _, e := range run.Errors {
+		msg.Errors = append(msg.Errors, e.ValueOrZero())
+	}
+	if outputs, ok := run.Outputs.Val.([]interface{}); ok {
+		for _, output := range outputs {
+			value, err := newValue(output)
+			if err != nil {
+				return nil, err
+			}
+			msg.Outputs = append(msg.Outputs, value)
+		}
+	}
+	for _, tr := range run.PipelineTaskRuns {
+		taskRun := &TaskRun{
+			Type:       string(tr.Type),
+			DotId:      tr.DotID,
+			Error:      tr.Error.ValueOrZero(),
+			CreatedAt:  timestamppb.New(tr.CreatedAt),
+			StartedAt:  newTimestamp(tr.StartedAt),
+			FinishedAt: newTimestamp(tr.FinishedAt),
+		}
+		if tr.Output != nil {
+			if taskRun.Output, err = newValue(tr.Output.Val); err != nil {
+				return nil, err
+			}
+		}
+		msg.TaskRuns = append(msg.TaskRuns, taskRun)
+	}
+	return msg, nil
+}
+
+func newRuns(runs []pipeline.Run) ([]*Run, error) {
+	msgs := make([]*Run, len(runs))
+	for i, run := range runs {
+		msg, err := newRun(run)
+		if err != nil {
+			return nil, err
+		}
+		msgs[i] = msg
+	}
+	return msgs, nil
+}
+
+// newBridge returns the message of a bridge, which leaves out its tokens
+func newBridge(bt models.BridgeType) *Bridge {
+	msg := &Bridge{
+		Name:               bt.Name.String(),
+		Url:                bt.URL.String(),
+		Confirmations:      bt.Confirmations,
+		GrpcTarget:         bt.GRPCTarget,
+		MaxConcurrentCalls: bt.MaxConcurrentCalls,
+	}
+	if bt.MinimumContractPayment != nil {
+		msg.MinimumContractPayment = bt.MinimumContractPayment.ToInt().String()
+	}
+	return msg
+}
+
+func newOCRKeyBundle(k ocrkey.EncryptedKeyBundle) *OCRKeyBundle {
+	return &OCRKeyBundle{
+		Id:                    k.ID.String(),
+		OnChainSigningAddress: k.OnChainSigningAddress.String(),
+		OffChainPublicKey:     k.OffChainPublicKey.String(),
+		ConfigPublicKey:       k.ConfigPublicKey.String(),
+		CreatedAt:             timestamppb.New(k.CreatedAt),
+	}
+}
+
+func newP2PKey(k p2pkey.EncryptedP2PKey) *P2PKey {
+	return &P2PKey{
+		Id:        k.ID,
+		PeerId:    k.PeerID.String(),
+		PublicKey: k.PubKey.String(),
+		CreatedAt: timestamppb.New(k.CreatedAt),
+	}
+}
+
+// newValue returns v as it would be encoded as JSON
+func newValue(v interface{}) (*structpb.Value, error) {
+	value := new(structpb.Value)
+	if err := unmarshalJSON(v, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// unmarshalJSON sets msg to the JSON encoding of v
+func unmarshalJSON(v interface{}, msg proto.Message) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(b, msg)
+}
+
+func newTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,237 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	null "gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// runPollInterval is how often StreamRuns checks for newly finished runs
+var runPollInterval = time.Second
+
+// operator implements the operator API
+type operator struct {
+	app chainlink.Application
+}
+
+var _ OperatorServer = (*operator)(nil)
+
+// ListJobs returns a page of the jobs that haven't been archived
+func (o *operator) ListJobs(ctx context.Context, req *PageRequest) (*JobsResponse, error) {
+	jobs, count, err := o.app.GetJobORM().PaginatedJobsV2(req.pagination(), false)
+	if err != nil {
+		return nil, paginationError(err)
+	}
+	msgs, err := newJobs(jobs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &JobsResponse{Jobs: msgs, Count: int32(count)}, nil
+}
+
+// GetJob returns a job
+func (o *operator) GetJob(ctx context.Context, req *JobRequest) (*Job, error) {
+	jb, err := o.app.GetJobORM().FindJob(req.Id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return nil, status.Errorf(codes.NotFound, "job %v not found", req.Id)
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	msg, err := newJob(jb)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return msg, nil
+}
+
+// CreateJob validates, saves and starts a job given as TOML
+func (o *operator) CreateJob(ctx context.Context, req *CreateJobRequest) (*Job, error) {
+	var genericJS struct {
+		Type job.Type `toml:"type"`
+	}
+	if err := toml.Unmarshal([]byte(req.Toml), &genericJS); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse job TOML: %v", err)
+	}
+	jb, err := provisioning.ResolvedJobSpec(ctx, o.app.GetJobValidators(), o.app.GetStore().Config, o.app.GetStore().EthClient, req.Toml)
+	if errors.Cause(err) == job.ErrUnknownJobType {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown job type: %s", genericJS.Type)
+	} else if errors.Cause(err) == job.ErrFeatureDisabled {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	checksum, err := job.SpecChecksum(req.Toml)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	jb.SpecChecksum = null.StringFrom(checksum)
+
+	jobID, err := o.app.AddJobV2(ctx, jb, jb.Name)
+	switch errors.Cause(err) {
+	case nil:
+	case job.ErrNoSuchKeyBundle, job.ErrNoSuchPeerID, job.ErrNoSuchTransmitterAddress, job.ErrNoSuchForwarder, job.ErrInvalidShadowOf, job.ErrInvalidDependsOn:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return o.GetJob(ctx, &JobRequest{Id: jobID})
+}
+
+// DeleteJob archives a job, or with Purge deletes it and its runs. The job's
+// runs in progress are cancelled unless WaitForRuns is set, as with the REST
+// API's force parameter.
+func (o *operator) DeleteJob(ctx context.Context, req *DeleteJobRequest) (*Empty, error) {
+	var err error
+	force := !req.WaitForRuns
+	if req.Purge {
+		err = o.app.DeleteJobV2(ctx, req.Id, force)
+	} else {
+		err = o.app.ArchiveJobV2(ctx, req.Id, force)
+	}
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return nil, status.Errorf(codes.NotFound, "job %v not found", req.Id)
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &Empty{}, nil
+}
+
+// ListRuns returns a page of a job's runs, most recent first
+func (o *operator) ListRuns(ctx context.Context, req *ListRunsRequest) (*RunsResponse, error) {
+	runs, count, err := o.app.GetJobORM().PipelineRunsByJobID(req.JobId, req.GetPage().pagination())
+	if err != nil {
+		return nil, paginationError(err)
+	}
+	msgs, err := newRuns(runs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &RunsResponse{Runs: msgs, Count: int32(count)}, nil
+}
+
+// StreamRuns sends each run of a job as it finishes, until the client cancels
+// the stream
+func (o *operator) StreamRuns(req *JobRequest, stream Operator_StreamRunsServer) error {
+	jb, err := o.app.GetJobORM().FindJob(req.Id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return status.Errorf(codes.NotFound, "job %v not found", req.Id)
+	} else if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	db := o.app.GetStore().DB
+	since := time.Now()
+	ticker := time.NewTicker(runPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		var runs []pipeline.Run
+		err := db.WithContext(stream.Context()).
+			Preload("PipelineTaskRuns").
+			Where("job_id = ? AND finished_at > ?", jb.ID, since).
+			Order("finished_at ASC, id ASC").
+			Find(&runs).
+			Error
+		if err != nil {
+			if stream.Context().Err() != nil {
+				return nil
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		for i := range runs {
+			msg, err := newRun(runs[i])
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+			since = *runs[i].FinishedAt
+		}
+	}
+}
+
+// ListBridges returns a page of bridges
+func (o *operator) ListBridges(ctx context.Context, req *PageRequest) (*BridgesResponse, error) {
+	bridges, count, err := o.app.GetStore().BridgeTypes(req.pagination())
+	if err != nil {
+		return nil, paginationError(err)
+	}
+	resp := &BridgesResponse{Count: int32(count)}
+	for _, bt := range bridges {
+		resp.Bridges = append(resp.Bridges, newBridge(bt))
+	}
+	return resp, nil
+}
+
+// ListKeys lists the node's ETH, OCR and P2P keys, with the ETH and LINK
+// balances of its ETH keys
+func (o *operator) ListKeys(ctx context.Context, _ *Empty) (*KeysResponse, error) {
+	store := o.app.GetStore()
+	keys, err := store.AllKeys()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	linkAddress := common.HexToAddress(store.Config.LinkContractAddress())
+	var resp KeysResponse
+	for _, k := range keys {
+		ethBalance, err := store.EthClient.BalanceAt(ctx, k.Address.Address(), nil)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "error calling getEthBalance on Ethereum node: %v", err)
+		}
+		linkBalance, err := store.EthClient.GetLINKBalance(linkAddress, k.Address.Address())
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "error calling getLINKBalance on Ethereum node: %v", err)
+		}
+		resp.Eth = append(resp.Eth, &ETHKey{
+			Address:     k.Address.Hex(),
+			EthBalance:  ethBalance.String(),
+			LinkBalance: linkBalance.ToInt().String(),
+			NextNonce:   k.NextNonce,
+			IsFunding:   k.IsFunding,
+			LastUsed:    newTimestamp(k.LastUsed),
+			CreatedAt:   timestamppb.New(k.CreatedAt),
+		})
+	}
+	ocrKeys, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, k := range ocrKeys {
+		resp.Ocr = append(resp.Ocr, newOCRKeyBundle(k))
+	}
+	p2pKeys, err := store.OCRKeyStore.FindEncryptedP2PKeys()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, k := range p2pKeys {
+		resp.P2P = append(resp.P2P, newP2PKey(k))
+	}
+	return &resp, nil
+}
+
+// paginationError returns the status of a listing that failed with err
+func paginationError(err error) error {
+	if errors.Cause(err) == orm.ErrInvalidPagination {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,155 @@
+// Package grpcapi serves the operator API over gRPC, for programs that manage
+// a node. It covers jobs, runs, bridges and keys, and streams runs as they
+// finish.
+//
+// The service is defined in operator.proto, and operator.pb.go is generated
+// from it. Callers authenticate with the same API token as the REST API, sent
+// as x-api-key and x-api-secret metadata, and are held to the same IP
+// allowlist and rate limits.
+package grpcapi
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. operator.proto
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web"
+)
+
+const (
+	// APIKeyMetadata is the metadata key for the API token identifier
+	APIKeyMetadata = "x-api-key"
+	// APISecretMetadata is the metadata key for the API token secret
+	APISecretMetadata = "x-api-secret"
+)
+
+// NewServer returns a gRPC server serving the operator API for app. Calls
+// are subject to the same IP allowlist and rate limits as the REST API.
+func NewServer(app chainlink.Application, opts ...grpc.ServerOption) *grpc.Server {
+	config := app.GetStore().Config
+	allowedIPs, err := config.APIAllowedIPs()
+	if err != nil {
+		logger.Panic(err)
+	}
+	a := authenticator{
+		app:        app,
+		allowedIPs: allowedIPs,
+		limiter: web.NewClientRateLimiter(
+			config.AuthenticatedRateLimitPeriod().Duration(),
+			config.AuthenticatedRateLimit(),
+		),
+		authFailureLimiter: web.NewClientRateLimiter(
+			config.UnAuthenticatedRateLimitPeriod().Duration(),
+			config.UnAuthenticatedRateLimit(),
+		),
+	}
+	opts = append(opts,
+		grpc.UnaryInterceptor(a.unary),
+		grpc.StreamInterceptor(a.stream),
+	)
+	server := grpc.NewServer(opts...)
+	RegisterOperatorServer(server, &operator{app})
+	return server
+}
+
+// authenticator rejects calls from addresses outside of API_ALLOWED_IPS and
+// calls without a valid API token. Every call counts against
+// AUTHENTICATED_RATE_LIMIT, and failed authentications also count against
+// UNAUTHENTICATED_RATE_LIMIT, so that tokens can't be brute-forced.
+type authenticator struct {
+	app                chainlink.Application
+	allowedIPs         []*net.IPNet
+	limiter            *web.ClientRateLimiter
+	authFailureLimiter *web.ClientRateLimiter
+}
+
+func (a authenticator) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a authenticator) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (a authenticator) authorize(ctx context.Context) error {
+	ip := peerIP(ctx)
+	if !web.IPAllowed(a.allowedIPs, ip) {
+		return status.Error(codes.PermissionDenied, "requests from this address are not allowed")
+	}
+	client := ""
+	if ip != nil {
+		client = ip.String()
+	}
+	if ok, wait := a.limiter.Take(client); !ok {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %v", wait.Round(time.Second))
+	}
+	if ok, wait := a.authFailureLimiter.Check(client); !ok {
+		return status.Errorf(codes.ResourceExhausted, "too many failed authentications, retry in %v", wait.Round(time.Second))
+	}
+	err := a.authenticate(ctx)
+	if status.Code(err) == codes.Unauthenticated {
+		a.authFailureLimiter.Take(client)
+	}
+	return err
+}
+
+func (a authenticator) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := &auth.Token{
+		AccessKey: firstValue(md, APIKeyMetadata),
+		Secret:    firstValue(md, APISecretMetadata),
+	}
+	if token.AccessKey == "" || token.Secret == "" {
+		return status.Error(codes.Unauthenticated, "missing API token")
+	}
+
+	user, err := a.app.GetStore().FindUser()
+	if err != nil {
+		return status.Error(codes.Unauthenticated, auth.ErrorAuthFailed.Error())
+	}
+	ok, err := models.AuthenticateUserByToken(token, &user)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	} else if !ok {
+		return status.Error(codes.Unauthenticated, auth.ErrorAuthFailed.Error())
+	}
+	return nil
+}
+
+// peerIP returns the address of the peer the call came from
+func peerIP(ctx context.Context) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return net.ParseIP(host)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
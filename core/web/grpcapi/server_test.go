@@ -0,0 +1,119 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/web/grpcapi"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	user := cltest.MustRandomUser()
+	apiToken := auth.Token{AccessKey: cltest.APIKey, Secret: cltest.APISecret}
+	require.NoError(t, user.SetAuthToken(&apiToken))
+	require.NoError(t, app.Store.SaveUser(&user))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpcapi.NewServer(app.ChainlinkApplication)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	ctx := context.Background()
+
+	t.Run("rejects calls without a valid token", func(t *testing.T) {
+		client, err := grpcapi.Dial(lis.Addr().String(), auth.Token{AccessKey: cltest.APIKey, Secret: "wrong"}, grpc.WithInsecure())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.ListJobs(ctx, &grpcapi.PageRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	client, err := grpcapi.Dial(lis.Addr().String(), apiToken, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer client.Close()
+
+	t.Run("jobs", func(t *testing.T) {
+		_, err := client.CreateJob(ctx, &grpcapi.CreateJobRequest{Toml: `
+type              = "shadow"
+schemaVersion     = 1
+shadowOf          = 1
+observationSource = """
+    ds1 [type=http method=GET url="https://example.com"];
+"""
+`})
+		// There is no job 1 to shadow
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.CreateJob(ctx, &grpcapi.CreateJobRequest{Toml: `
+type              = "keeper"
+schemaVersion     = 1
+contractAddress   = "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba"
+fromAddress       = "0x2F4dF6E0E4B8E4E7e2c5A35C5cF2fA3E1A5bCd10"
+dependsOn         = "no-such-job"
+`})
+		// There is no job named no-such-job to depend on
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		jobs, err := client.ListJobs(ctx, &grpcapi.PageRequest{})
+		require.NoError(t, err)
+		assert.Len(t, jobs.Jobs, 0)
+
+		_, err = client.GetJob(ctx, &grpcapi.JobRequest{Id: 9999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("bridges", func(t *testing.T) {
+		_, bt := cltest.NewBridgeType(t, "voter_turnout", "http://example.com")
+		require.NoError(t, app.Store.CreateBridgeType(bt))
+
+		bridges, err := client.ListBridges(ctx, &grpcapi.PageRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), bridges.Count)
+		require.Len(t, bridges.Bridges, 1)
+		assert.Equal(t, "voter_turnout", bridges.Bridges[0].Name)
+
+		_, err = client.ListBridges(ctx, &grpcapi.PageRequest{Sort: "outgoingToken"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("throttles failed authentications", func(t *testing.T) {
+		badClient, err := grpcapi.Dial(lis.Addr().String(), auth.Token{AccessKey: cltest.APIKey, Secret: "wrong"}, grpc.WithInsecure())
+		require.NoError(t, err)
+		defer badClient.Close()
+
+		// One failure was already counted above, of UNAUTHENTICATED_RATE_LIMIT's
+		// default of 5
+		for i := 0; i < 4; i++ {
+			_, err = badClient.ListJobs(ctx, &grpcapi.PageRequest{})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		}
+		_, err = badClient.ListJobs(ctx, &grpcapi.PageRequest{})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		// Valid tokens from the same address are throttled too
+		_, err = client.ListJobs(ctx, &grpcapi.PageRequest{})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+}
@@ -42,13 +42,35 @@ func paginatedResponse(
 	count int,
 	err error,
 ) {
+	pageResponse(c, name, err, func() ([]byte, error) {
+		return NewPaginatedResponse(*c.Request.URL, size, page, count, resource)
+	})
+}
+
+func cursorPaginatedResponse(
+	c *gin.Context,
+	name string,
+	size int,
+	nextCursor string,
+	resource interface{},
+	count int,
+	err error,
+) {
+	pageResponse(c, name, err, func() ([]byte, error) {
+		return NewCursorPaginatedResponse(*c.Request.URL, size, count, nextCursor, resource)
+	})
+}
+
+func pageResponse(c *gin.Context, name string, err error, document func() ([]byte, error)) {
 	if errors.Cause(err) == orm.ErrorNotFound {
 		err = nil
 	}
 
-	if err != nil {
+	if errors.Cause(err) == orm.ErrInvalidPagination {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+	} else if err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("error getting paged %s: %+v", name, err))
-	} else if buffer, err := NewPaginatedResponse(*c.Request.URL, size, page, count, resource); err != nil {
+	} else if buffer, err := document(); err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("failed to marshal document: %+v", err))
 	} else {
 		c.Data(http.StatusOK, MediaType, buffer)
@@ -66,6 +88,32 @@ func paginatedRequest(action func(*gin.Context, int, int, int)) func(*gin.Contex
 	}
 }
 
+// listRequest parses the params that select a page of a collection, as
+// ParsePagination does, and passes the pagination and page number to action
+func listRequest(action func(*gin.Context, orm.Pagination, int)) func(*gin.Context) {
+	return func(c *gin.Context) {
+		p, page, err := ParsePagination(c.Request.URL.Query())
+		if err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		action(c, p, page)
+	}
+}
+
+// staticParam sends requests whose path param is exactly value to
+// staticAction and all others to action. gin does not allow a static path
+// segment to be registered alongside a param in the same position.
+func staticParam(param, value string, staticAction, action gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Param(param) == value {
+			staticAction(c)
+			return
+		}
+		action(c)
+	}
+}
+
 func jsonAPIResponseWithStatus(c *gin.Context, resource interface{}, name string, status int) {
 	json, err := jsonapi.Marshal(resource)
 	if err != nil {
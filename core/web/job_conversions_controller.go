@@ -0,0 +1,37 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/jobmigration"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// JobConversionsController converts v1 job specs to v2 TOML job specs
+type JobConversionsController struct {
+	App chainlink.Application
+}
+
+// Create converts the v1 JSON job spec in the body to a v2 TOML job spec,
+// with warnings about the parts of it that could not be converted. Nothing is
+// created; the TOML is to be reviewed and then posted to /v2/jobs.
+// Example:
+// "POST <application>/job_conversions"
+func (jcc *JobConversionsController) Create(c *gin.Context) {
+	var jsr models.JobSpecRequest
+	if err := c.ShouldBindJSON(&jsr); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	conversion, err := jobmigration.ConvertV1JobSpec(jsr)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewJobConversionResource(conversion), "jobConversions")
+}
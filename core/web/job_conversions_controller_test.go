@@ -0,0 +1,45 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestJobConversionsController_Create(t *testing.T) {
+	_, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	spec := `{
+		"initiators": [{"type": "runlog", "params": {"address": "0x613a38AC1659769640aaE063C651F48E0250454C"}}],
+		"tasks": [
+			{"type": "httpget", "params": {"get": "https://example.com"}},
+			{"type": "jsonparse", "params": {"path": ["USD"]}},
+			{"type": "ethtx"}
+		]
+	}`
+	resp, cleanup := client.Post("/v2/job_conversions", bytes.NewBufferString(spec))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var result presenters.JobConversionResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &result))
+	assert.Contains(t, result.TOML, `type = "directrequest"`)
+	assert.Contains(t, result.TOML, `http1 -> jsonparse2;`)
+	assert.Equal(t, []string{"Task 2 (ethtx): v2 pipelines have no task that encodes results or sends transactions, so it was left out"}, result.Warnings)
+}
+
+func TestJobConversionsController_Create_Invalid(t *testing.T) {
+	_, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	resp, cleanup := client.Post("/v2/job_conversions", bytes.NewBufferString(`{"tasks": [{"type": "multiply", "params": {"times": "lots"}}]}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
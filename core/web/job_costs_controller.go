@@ -0,0 +1,68 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// jobCostsDefaultDays is how many days of costs are returned when no range
+// is given
+const jobCostsDefaultDays = 30
+
+// JobCostsController reports what jobs cost to run
+type JobCostsController struct {
+	App chainlink.Application
+}
+
+// Index returns a job's costs for each day, kind of cost and target. The
+// days are selected by the from and to query parameters, as YYYY-MM-DD UTC
+// dates, and default to the last 30 days.
+// Example:
+// "GET <application>/jobs/:ID/costs?from=2021-05-01&to=2021-05-31"
+func (jcc *JobCostsController) Index(c *gin.Context) {
+	jobSpec := job.Job{}
+	if err := jobSpec.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	to := time.Now().UTC()
+	if param := c.Query("to"); param != "" {
+		var err error
+		if to, err = time.Parse("2006-01-02", param); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid to"))
+			return
+		}
+	}
+	from := to.AddDate(0, 0, 1-jobCostsDefaultDays)
+	if param := c.Query("from"); param != "" {
+		var err error
+		if from, err = time.Parse("2006-01-02", param); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid from"))
+			return
+		}
+	}
+	if from.After(to) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("from must not be after to"))
+		return
+	}
+
+	costs, err := jcc.App.GetJobORM().CostsByJobID(jobSpec.ID, from, to)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := []presenters.JobCostResource{}
+	for _, cost := range costs {
+		resources = append(resources, *presenters.NewJobCostResource(cost))
+	}
+	jsonAPIResponse(c, resources, "jobCosts")
+}
@@ -0,0 +1,77 @@
+package web_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestJobCostsController_Index(t *testing.T) {
+	app, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	var ocrJob job.Job
+	tree, err := toml.LoadFile("testdata/oracle-spec.toml")
+	require.NoError(t, err)
+	require.NoError(t, tree.Unmarshal(&ocrJob))
+	var ocrSpec job.OffchainReportingOracleSpec
+	require.NoError(t, tree.Unmarshal(&ocrSpec))
+	ocrSpec.TransmitterAddress = &app.Key.Address
+	ocrJob.OffchainreportingOracleSpec = &ocrSpec
+	jobID, err := app.AddJobV2(context.Background(), ocrJob, null.String{})
+	require.NoError(t, err)
+
+	require.NoError(t, app.Store.DB.Exec(`
+		INSERT INTO job_costs (job_id, day, kind, target, calls, bytes_sent, bytes_received, gas_used, fee_wei) VALUES
+		(?, '2021-05-01', 'bridge', 'bridge.example.com', 2, 20, 200, 0, 0),
+		(?, '2021-05-02', 'transaction', '0x0000000000000000000000000000000000000001', 1, 0, 0, 21000, 21000000000000),
+		(?, '2021-05-03', 'http', 'example.com', 1, 0, 100, 0, 0)
+	`, jobID, jobID, jobID).Error)
+
+	t.Run("lists the job's costs for the days asked for", func(t *testing.T) {
+		resp, cleanup := client.Get(fmt.Sprintf("/v2/jobs/%v/costs?from=2021-05-01&to=2021-05-02", jobID))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var costs []presenters.JobCostResource
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &costs))
+		require.Len(t, costs, 2)
+		assert.Equal(t, "2021-05-01", costs[0].Day)
+		assert.Equal(t, job.CostKindBridge, costs[0].Kind)
+		assert.Equal(t, "bridge.example.com", costs[0].Target)
+		assert.Equal(t, int64(2), costs[0].Calls)
+		assert.Equal(t, int64(200), costs[0].BytesReceived)
+		assert.Equal(t, "2021-05-02", costs[1].Day)
+		assert.Equal(t, job.CostKindTransaction, costs[1].Kind)
+		assert.Equal(t, "21000", costs[1].GasUsed.String())
+		assert.Equal(t, "21000000000000", costs[1].FeeWei.String())
+	})
+
+	t.Run("defaults to the last 30 days", func(t *testing.T) {
+		resp, cleanup := client.Get(fmt.Sprintf("/v2/jobs/%v/costs", jobID))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var costs []presenters.JobCostResource
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &costs))
+		assert.Len(t, costs, 0)
+	})
+
+	t.Run("rejects invalid days", func(t *testing.T) {
+		for _, query := range []string{"from=yesterday", "to=2021-13-01", "from=2021-05-02&to=2021-05-01"} {
+			resp, cleanup := client.Get(fmt.Sprintf("/v2/jobs/%v/costs?%s", jobID, query))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+		}
+	})
+}
@@ -2,21 +2,22 @@ package web
 
 import (
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 
-	"github.com/smartcontractkit/chainlink/core/services/directrequest"
-	"github.com/smartcontractkit/chainlink/core/services/keeper"
-	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
 	"github.com/smartcontractkit/chainlink/core/web/presenters"
 
-	"github.com/smartcontractkit/chainlink/core/services/fluxmonitorv2"
-
 	"github.com/gin-gonic/gin"
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"go.uber.org/multierr"
 	"gopkg.in/guregu/null.v4"
 )
 
@@ -25,17 +26,45 @@ type JobsController struct {
 	App chainlink.Application
 }
 
-// Index lists all jobs
+// Index lists jobs, one page at a time. Jobs can be sorted and filtered by
+// id, name, type and schemaVersion. Passing include=claims also returns the
+// node which currently claims each job, and archived=true lists archived jobs
+// instead.
 // Example:
 // "GET <application>/jobs"
-func (jc *JobsController) Index(c *gin.Context) {
-	jobs, err := jc.App.GetJobORM().JobsV2()
-	if err != nil {
+// "GET <application>/jobs?size=10&page=2&sort=-id"
+// "GET <application>/jobs?filter[type]=fluxmonitor"
+// "GET <application>/jobs?include=claims"
+// "GET <application>/jobs?archived=true"
+func (jc *JobsController) Index(c *gin.Context, p orm.Pagination, page int) {
+	jobs, count, err := jc.App.GetJobORM().PaginatedJobsV2(p, c.Query("archived") == "true")
+	if errors.Cause(err) == orm.ErrInvalidPagination {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	jsonAPIResponse(c, presenters.NewJobResources(jobs), "jobs")
+	resources := presenters.NewJobResources(jobs)
+	if c.Query("include") == "claims" {
+		claims, err := jc.App.GetJobORM().Claims()
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		claimsByJobID := make(map[int32]job.Claim, len(claims))
+		for _, claim := range claims {
+			claimsByJobID[claim.JobID] = claim
+		}
+		for i, j := range jobs {
+			if claim, exists := claimsByJobID[j.ID]; exists {
+				resources[i].Claim = presenters.NewJobClaim(claim)
+			}
+		}
+	}
+
+	paginatedResponse(c, "jobs", p.Limit, page, resources, count, nil)
 }
 
 // Show returns the details of a job
@@ -69,9 +98,14 @@ type GenericJobSpec struct {
 	Name          null.String `toml:"name"`
 }
 
-// Create validates, saves and starts a new job.
+// Create validates, saves and starts a new job. With validateOnly=true the
+// spec is only validated, and the job is returned as it would be created,
+// without an ID. Keys, peer IDs and transmitter addresses that the spec
+// refers to are only checked when the job is created. Errors in a TOML spec
+// are returned with their line, and column if it is known.
 // Example:
 // "POST <application>/jobs"
+// "POST <application>/jobs?validateOnly=true"
 func (jc *JobsController) Create(c *gin.Context) {
 	request := models.CreateJobSpecRequest{}
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -79,38 +113,64 @@ func (jc *JobsController) Create(c *gin.Context) {
 		return
 	}
 
+	// Errors are only positioned in specs given as TOML, as the positions in
+	// a spec converted from JSON would mean nothing to the client
+	specTOML := request.TOML
+	if len(request.JSON) > 0 {
+		if request.TOML != "" {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("job spec must be given as either toml or json, not both"))
+			return
+		}
+		specTOML, err := job.SpecJSONToTOML(request.JSON)
+		if err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "failed to parse V2 job JSON"))
+			return
+		}
+		request.TOML = specTOML
+	}
+
 	genericJS := GenericJobSpec{}
 	err := toml.Unmarshal([]byte(request.TOML), &genericJS)
 	if err != nil {
-		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "failed to parse V2 job TOML. HINT: If you are trying to add a V1 job spec (json) via the CLI, try `job_specs create` instead"))
+		jsonAPIError(c, http.StatusUnprocessableEntity, jobSpecErrors(specTOML, errors.Wrap(err, "failed to parse V2 job TOML. HINT: If you are trying to add a V1 job spec (json) via the CLI, try `job_specs create` instead")))
+		return
 	}
 
-	var js job.Job
-	config := jc.App.GetStore().Config
-	switch genericJS.Type {
-	case job.OffchainReporting:
-		js, err = offchainreporting.ValidatedOracleSpecToml(jc.App.GetStore().Config, request.TOML)
-		if !config.Dev() && !config.FeatureOffchainReporting() {
-			jsonAPIError(c, http.StatusNotImplemented, errors.New("The Offchain Reporting feature is disabled by configuration"))
-			return
-		}
-	case job.DirectRequest:
-		js, err = directrequest.ValidatedDirectRequestSpec(request.TOML)
-	case job.FluxMonitor:
-		js, err = fluxmonitorv2.ValidatedFluxMonitorSpec(jc.App.GetStore().Config, request.TOML)
-	case job.Keeper:
-		js, err = keeper.ValidatedKeeperSpec(request.TOML)
-	default:
-		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("unknown job type: %s", genericJS.Type))
+	store := jc.App.GetStore()
+	js, err := provisioning.ResolvedJobSpec(c.Request.Context(), jc.App.GetJobValidators(), store.Config, store.EthClient, request.TOML)
+	if errors.Cause(err) == job.ErrUnknownJobType {
+		jsonAPIError(c, http.StatusUnprocessableEntity, jobSpecErrors(specTOML, errors.Errorf("unknown job type: %s", genericJS.Type)))
+		return
+	}
+	if errors.Cause(err) == job.ErrFeatureDisabled {
+		jsonAPIError(c, http.StatusNotImplemented, err)
+		return
 	}
 	if err != nil {
-		jsonAPIError(c, http.StatusBadRequest, err)
+		jsonAPIError(c, http.StatusBadRequest, jobSpecErrors(specTOML, err))
+		return
+	}
+
+	checksum, err := job.SpecChecksum(request.TOML)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	js.SpecChecksum = null.StringFrom(checksum)
+
+	if c.Query("validateOnly") == "true" {
+		js.PipelineSpec = &pipeline.Spec{
+			DotDagSource:    js.Pipeline.DOTSource,
+			MaxTaskDuration: js.MaxTaskDuration,
+			NumericPolicy:   js.NumericPolicy,
+		}
+		jsonAPIResponse(c, presenters.NewJobResource(js), js.Type.String())
 		return
 	}
 
 	jobID, err := jc.App.AddJobV2(c.Request.Context(), js, js.Name)
 	if err != nil {
-		if errors.Cause(err) == job.ErrNoSuchKeyBundle || errors.Cause(err) == job.ErrNoSuchPeerID || errors.Cause(err) == job.ErrNoSuchTransmitterAddress {
+		if errors.Cause(err) == job.ErrNoSuchKeyBundle || errors.Cause(err) == job.ErrNoSuchPeerID || errors.Cause(err) == job.ErrNoSuchTransmitterAddress || errors.Cause(err) == job.ErrNoSuchForwarder || errors.Cause(err) == job.ErrInvalidShadowOf || errors.Cause(err) == job.ErrInvalidDependsOn {
 			jsonAPIError(c, http.StatusBadRequest, err)
 			return
 		}
@@ -127,9 +187,64 @@ func (jc *JobsController) Create(c *gin.Context) {
 	jsonAPIResponse(c, presenters.NewJobResource(job), job.Type.String())
 }
 
-// Delete soft deletes an OCR job spec.
+// tomlPositionRegexp matches the position that go-toml prefixes its parsing
+// and decoding errors with
+var tomlPositionRegexp = regexp.MustCompile(`\((\d+), (\d+)\): `)
+
+// tomlKeyRegexp matches the words of an error that may be keys of a spec,
+// including dotted keys of its tables
+var tomlKeyRegexp = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`)
+
+// jobSpecErrors splits err into one JSONAPI error per error that it combines,
+// each positioned in specTOML where it can be. Errors are not positioned if
+// specTOML is empty.
+func jobSpecErrors(specTOML string, err error) *models.JSONAPIErrors {
+	var tree *toml.Tree
+	if specTOML != "" {
+		// A spec that fails to load still positions its errors from go-toml
+		tree, _ = toml.Load(specTOML)
+	}
+	jae := models.NewJSONAPIErrors()
+	for _, e := range multierr.Errors(err) {
+		detail := e.Error()
+		var meta *models.JSONAPIErrorMeta
+		if specTOML != "" {
+			detail, meta = positionedError(tree, detail)
+		}
+		jae.Errors = append(jae.Errors, models.JSONAPIError{Detail: detail, Meta: meta})
+	}
+	return jae
+}
+
+// positionedError returns the position of an error in a spec. Errors from
+// go-toml carry their position, which is removed from the detail. Otherwise
+// an error is positioned at the first key of the spec that it names.
+func positionedError(tree *toml.Tree, detail string) (string, *models.JSONAPIErrorMeta) {
+	if match := tomlPositionRegexp.FindStringSubmatchIndex(detail); match != nil {
+		line, _ := strconv.Atoi(detail[match[2]:match[3]])
+		column, _ := strconv.Atoi(detail[match[4]:match[5]])
+		return detail[:match[0]] + detail[match[1]:], &models.JSONAPIErrorMeta{Line: line, Column: column}
+	}
+	if tree == nil {
+		return detail, nil
+	}
+	for _, key := range tomlKeyRegexp.FindAllString(detail, -1) {
+		path := strings.Split(key, ".")
+		if tree.HasPath(path) {
+			pos := tree.GetPositionPath(path)
+			return detail, &models.JSONAPIErrorMeta{Line: pos.Line, Column: pos.Col}
+		}
+	}
+	return detail, nil
+}
+
+// Delete archives a job, stopping it but keeping it and its runs until
+// JOB_ARCHIVE_RETENTION has passed. With purge=true the job and its runs are
+// deleted immediately. The job's in-flight runs are cancelled, or with
+// force=false waited for.
 // Example:
-// "DELETE <application>/specs/:ID"
+// "DELETE <application>/jobs/:ID"
+// "DELETE <application>/jobs/:ID?purge=true&force=false"
 func (jc *JobsController) Delete(c *gin.Context) {
 	jobSpec := job.Job{}
 	err := jobSpec.SetID(c.Param("ID"))
@@ -138,7 +253,12 @@ func (jc *JobsController) Delete(c *gin.Context) {
 		return
 	}
 
-	err = jc.App.DeleteJobV2(c.Request.Context(), jobSpec.ID)
+	force := c.Query("force") != "false"
+	if c.Query("purge") == "true" {
+		err = jc.App.DeleteJobV2(c.Request.Context(), jobSpec.ID, force)
+	} else {
+		err = jc.App.ArchiveJobV2(c.Request.Context(), jobSpec.ID, force)
+	}
 	if errors.Cause(err) == orm.ErrorNotFound {
 		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
 		return
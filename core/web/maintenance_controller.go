@@ -0,0 +1,60 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// MaintenanceController puts the node into maintenance mode and resumes it
+type MaintenanceController struct {
+	App chainlink.Application
+}
+
+// MaintenanceRequest puts the node into maintenance mode
+type MaintenanceRequest struct {
+	PauseObservations bool   `json:"pauseObservations"`
+	Reason            string `json:"reason"`
+}
+
+// Show returns the maintenance mode of the node
+// Example:
+// "GET <application>/maintenance"
+func (mc *MaintenanceController) Show(c *gin.Context) {
+	jsonAPIResponse(c, presenters.NewMaintenanceResource(mc.App.GetMaintenance().Mode()), "maintenance")
+}
+
+// Enable puts the node into maintenance mode: no runs are created, runs of v1
+// jobs are held, and OCR jobs stop transmitting, and also observing if
+// pauseObservations is set. The mode persists across restarts.
+// Example:
+// "POST <application>/maintenance"
+func (mc *MaintenanceController) Enable(c *gin.Context) {
+	request := &MaintenanceRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	mode, err := mc.App.GetMaintenance().Enable(c.Request.Context(), request.PauseObservations, request.Reason)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewMaintenanceResource(mode), "maintenance")
+}
+
+// Resume takes the node out of maintenance mode, and queues the runs held
+// during it
+// Example:
+// "DELETE <application>/maintenance"
+func (mc *MaintenanceController) Resume(c *gin.Context) {
+	mode, err := mc.App.GetMaintenance().Resume(c.Request.Context())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewMaintenanceResource(mode), "maintenance")
+}
@@ -0,0 +1,52 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestMaintenanceController(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/maintenance")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var mode presenters.MaintenanceResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &mode))
+	assert.False(t, mode.Enabled)
+
+	resp, cleanup = client.Post("/v2/maintenance", bytes.NewBufferString(`{"pauseObservations": true, "reason": "vacuum"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &mode))
+	assert.True(t, mode.Enabled)
+	assert.True(t, mode.PauseObservations)
+	assert.Equal(t, "vacuum", mode.Reason)
+	assert.NotNil(t, mode.Since)
+	assert.True(t, app.GetMaintenance().ObservationsPaused())
+
+	resp, cleanup = client.Delete("/v2/maintenance")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	mode = presenters.MaintenanceResource{}
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &mode))
+	assert.False(t, mode.Enabled)
+	assert.False(t, app.GetMaintenance().RunsPaused())
+}
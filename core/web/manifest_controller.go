@@ -0,0 +1,49 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// ManifestController applies manifests of the bridges, keys and config
+// overrides that the node should have
+type ManifestController struct {
+	App chainlink.Application
+}
+
+// Apply makes the node match a manifest, returning the changes made. With
+// dryRun=true, nothing is changed and the changes that would be made are
+// returned. Manifests with unknown fields are rejected, so that a typo isn't
+// silently ignored.
+// Example:
+// "POST <application>/manifest"
+// "POST <application>/manifest?dryRun=true"
+func (mfc *ManifestController) Apply(c *gin.Context) {
+	var manifest provisioning.Manifest
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	dryRun := c.Query("dryRun") == "true"
+	changes, err := provisioning.ApplyManifest(c.Request.Context(), mfc.App.GetStore(), manifest, dryRun)
+	var jae *models.JSONAPIErrors
+	if errors.As(err, &jae) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, jae)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewManifestChangesResource(changes, dryRun), "manifestChanges")
+}
@@ -0,0 +1,80 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestManifestController_Apply(t *testing.T) {
+	app, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	manifest := `{"bridges": [{"name": "voter_turnout", "url": "http://changed.example.com"}, {"name": "added", "url": "http://added.example.com"}]}`
+
+	resp, cleanup := client.Post("/v2/manifest?dryRun=true", bytes.NewBufferString(manifest))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var result presenters.ManifestChangesResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &result))
+	assert.True(t, result.DryRun)
+	require.Len(t, result.Changes, 2)
+	assert.Equal(t, provisioning.ManifestChange{
+		Action: provisioning.ActionUpdate,
+		Kind:   provisioning.KindBridge,
+		Name:   "voter_turnout",
+		Diff:   []string{"url: http://blah.com -> http://changed.example.com"},
+	}, result.Changes[0])
+	assert.Equal(t, provisioning.ActionCreate, result.Changes[1].Action)
+	_, err := app.Store.FindBridge("added")
+	require.Error(t, err)
+
+	resp, cleanup = client.Post("/v2/manifest", bytes.NewBufferString(manifest))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	result = presenters.ManifestChangesResource{}
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &result))
+	assert.False(t, result.DryRun)
+	assert.Len(t, result.Changes, 2)
+	bridge, err := app.Store.FindBridge("voter_turnout")
+	require.NoError(t, err)
+	assert.Equal(t, "http://changed.example.com", bridge.URL.String())
+	_, err = app.Store.FindBridge("added")
+	require.NoError(t, err)
+}
+
+func TestManifestController_Apply_Invalid(t *testing.T) {
+	_, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		manifest string
+		detail   string
+	}{
+		{"unknown field", `{"bridge": []}`, `unknown field "bridge"`},
+		{"invalid override", `{"configOverrides": {"LOG_LEVEL": "loud"}}`, "config LOG_LEVEL"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			resp, cleanup := client.Post("/v2/manifest", bytes.NewBufferString(test.manifest))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+			var errs models.JSONAPIErrors
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&errs))
+			require.Len(t, errs.Errors, 1)
+			assert.Contains(t, errs.Errors[0].Detail, test.detail)
+		})
+	}
+}
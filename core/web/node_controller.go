@@ -0,0 +1,72 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/static"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// NodeController describes the node itself, for fleet management tooling
+type NodeController struct {
+	App chainlink.Application
+}
+
+// Show returns the node's ID, version, enabled features, chain IDs and the
+// number of jobs of each type it runs
+// Example:
+// "GET <application>/node"
+func (nc *NodeController) Show(c *gin.Context) {
+	store := nc.App.GetStore()
+	config := store.Config
+
+	jobCounts, err := nc.App.GetJobORM().CountJobsByType()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jobSpecsV1, err := store.CountOf(&models.JobSpec{})
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	for _, t := range []job.Type{job.DirectRequest, job.FluxMonitor, job.OffchainReporting, job.Keeper, job.Shadow, job.MessageQueue} {
+		if _, exists := jobCounts[t]; !exists {
+			jobCounts[t] = 0
+		}
+	}
+
+	featureFlags := make(map[string]bool, len(orm.Features))
+	for _, f := range orm.Features {
+		featureFlags[f.EnvVarName()] = config.FeatureEnabled(f)
+	}
+
+	resource := presenters.NodeResource{
+		JAID:      presenters.JAID{ID: config.NodeID()},
+		Version:   static.Version,
+		CommitSHA: static.Sha,
+		Features: map[string]bool{
+			"externalInitiators": config.FeatureExternalInitiators(),
+			"fluxMonitor":        config.FeatureFluxMonitor(),
+			"fluxMonitorV2":      config.Dev() || config.FeatureFluxMonitorV2(),
+			"offchainReporting":  (config.Dev() && config.P2PListenPort() > 0) || config.FeatureOffchainReporting(),
+			// Keeper, shadow, message queue and VRF jobs have no feature flag,
+			// so are always enabled
+			"keeper":       true,
+			"messageQueue": true,
+			"shadow":       true,
+			"vrf":          true,
+		},
+		FeatureFlags: featureFlags,
+		ChainIDs:     []string{config.ChainID().String()},
+		JobCounts:    jobCounts,
+		JobSpecsV1:   jobSpecsV1,
+	}
+	jsonAPIResponse(c, resource, "nodes")
+}
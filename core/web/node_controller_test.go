@@ -0,0 +1,41 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/static"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestNodeController_Show(t *testing.T) {
+	app, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	body, _ := json.Marshal(models.CreateJobSpecRequest{TOML: string(cltest.MustReadFile(t, "testdata/keeper-spec.toml"))})
+	resp, cleanup := client.Post("/v2/jobs", bytes.NewReader(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	resp, cleanup = client.Get("/v2/node")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var node presenters.NodeResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &node))
+	assert.Equal(t, app.Store.Config.NodeID(), node.ID)
+	assert.Equal(t, static.Version, node.Version)
+	assert.Equal(t, static.Sha, node.CommitSHA)
+	assert.True(t, node.Features["keeper"])
+	assert.Equal(t, []string{app.Store.Config.ChainID().String()}, node.ChainIDs)
+	assert.Equal(t, 1, node.JobCounts[job.Keeper])
+	assert.Equal(t, 0, node.JobCounts[job.OffchainReporting])
+}
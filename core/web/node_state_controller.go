@@ -0,0 +1,51 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// NodeStateController exports the node's state as an archive, and imports
+// such an archive to rebuild a node
+type NodeStateController struct {
+	App chainlink.Application
+}
+
+// Export returns an archive of the node's jobs, bridges, external initiators
+// and config overrides. It excludes keys, but includes bridge and external
+// initiator credentials.
+// Example:
+// "GET <application>/node_state"
+func (nsc *NodeStateController) Export(c *gin.Context) {
+	archive, err := provisioning.ExportArchive(nsc.App.GetStore(), nsc.App.GetJobORM())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, archive)
+}
+
+// Import recreates the state in an archive, skipping what already exists on
+// the node. The archive is rejected if it isn't compatible with the node.
+// Example:
+// "POST <application>/node_state"
+func (nsc *NodeStateController) Import(c *gin.Context) {
+	var archive provisioning.Archive
+	if err := c.ShouldBindJSON(&archive); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	result, err := provisioning.ImportArchive(c.Request.Context(), nsc.App.GetStore(), nsc.App.GetJobORM(), nsc.App.GetJobValidators(), nsc.App, archive)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	result.Log()
+	jsonAPIResponse(c, presenters.NewNodeStateImportResource(result), "nodeStateImports")
+}
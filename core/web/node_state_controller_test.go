@@ -0,0 +1,97 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestNodeStateController_ExportImport(t *testing.T) {
+	app, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	body, _ := json.Marshal(models.CreateJobSpecRequest{TOML: string(cltest.MustReadFile(t, "testdata/keeper-spec.toml"))})
+	resp, cleanup := client.Post("/v2/jobs", bytes.NewReader(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var created presenters.JobResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &created))
+
+	require.NoError(t, app.Store.DB.Model(&models.BridgeType{}).Where("name = ?", "voter_turnout").Updates(map[string]interface{}{
+		"grpc_target":          "adapter.example.com:443",
+		"grpc_tls":             true,
+		"grpc_tls_ca_cert":     "-----BEGIN CERTIFICATE-----",
+		"max_concurrent_calls": 4,
+	}).Error)
+
+	resp, cleanup = client.Get("/v2/node_state")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var archive provisioning.Archive
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&archive))
+
+	assert.Equal(t, provisioning.ArchiveFormatVersion, archive.FormatVersion)
+	assert.NotEmpty(t, archive.SchemaVersion)
+	require.Len(t, archive.Bridges, 2)
+	assert.Equal(t, "election_winner", archive.Bridges[0].Name.String())
+	assert.NotEmpty(t, archive.Bridges[0].IncomingTokenHash)
+	assert.NotEmpty(t, archive.Bridges[0].OutgoingToken)
+	require.Len(t, archive.Jobs, 1)
+	assert.Equal(t, "example keeper spec", archive.Jobs[0].Name)
+	assert.Contains(t, archive.Jobs[0].TOML, `type = "keeper"`)
+
+	bridge, err := app.Store.FindBridge("voter_turnout")
+	require.NoError(t, err)
+	require.NoError(t, app.Store.DeleteBridgeType(&bridge))
+	resp, cleanup = client.Delete(fmt.Sprintf("/v2/jobs/%s", created.ID))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+
+	t.Run("recreates what is missing", func(t *testing.T) {
+		body, _ := json.Marshal(archive)
+		resp, cleanup := client.Post("/v2/node_state", bytes.NewReader(body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var result presenters.NodeStateImportResource
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &result))
+		assert.Empty(t, result.Errors)
+		assert.ElementsMatch(t, []string{"bridge voter_turnout", "job example keeper spec"}, result.Created)
+		assert.Equal(t, []string{"bridge election_winner"}, result.Skipped)
+
+		restored, err := app.Store.FindBridge("voter_turnout")
+		require.NoError(t, err)
+		assert.Equal(t, bridge.IncomingTokenHash, restored.IncomingTokenHash)
+		assert.Equal(t, bridge.OutgoingToken, restored.OutgoingToken)
+		assert.Equal(t, "adapter.example.com:443", restored.GRPCTarget)
+		assert.True(t, restored.GRPCTLS)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", restored.GRPCTLSCACert)
+		assert.Equal(t, uint32(4), restored.MaxConcurrentCalls)
+		jobs, err := app.JobORM.JobsV2()
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, "example keeper spec", jobs[0].Name.ValueOrZero())
+	})
+
+	t.Run("rejects incompatible archives", func(t *testing.T) {
+		for _, incompatible := range []provisioning.Archive{
+			{FormatVersion: provisioning.ArchiveFormatVersion + 1, SchemaVersion: archive.SchemaVersion},
+			{FormatVersion: provisioning.ArchiveFormatVersion, SchemaVersion: "9999_unknown"},
+		} {
+			body, _ := json.Marshal(incompatible)
+			resp, cleanup := client.Post("/v2/node_state", bytes.NewReader(body))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+		}
+	})
+}
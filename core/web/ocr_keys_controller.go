@@ -9,7 +9,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+	"gorm.io/gorm"
 )
 
 // OCRKeysController manages OCR key bundles
@@ -29,6 +32,33 @@ func (ocrkc *OCRKeysController) Index(c *gin.Context) {
 	jsonAPIResponse(c, keys, "offChainReportingKeyBundle")
 }
 
+// Usage lists every OCR key bundle along with the jobs referencing it, when
+// it was last used and the on-chain configs it has been seen in
+// Example:
+// "GET <application>/keys/ocr/usage"
+func (ocrkc *OCRKeysController) Usage(c *gin.Context) {
+	keys, err := ocrkc.App.GetStore().OCRKeyStore.FindEncryptedOCRKeyBundles()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	var defaultID *models.Sha256Hash
+	if id, err := ocrkc.App.GetStore().Config.OCRKeyBundleID(nil); err == nil {
+		defaultID = &id
+	}
+	usages, err := ocrkc.App.GetJobORM().OCRKeyBundleUsage(defaultID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := []presenters.KeyUsageResource{}
+	for _, key := range keys {
+		resources = append(resources, *presenters.NewKeyUsageResource(key.ID.String(), usages[key.ID]))
+	}
+	jsonAPIResponse(c, resources, "keyUsages")
+}
+
 // Create and return an OCR key bundle
 // Example:
 // "POST <application>/keys/ocr"
@@ -38,6 +68,7 @@ func (ocrkc *OCRKeysController) Create(c *gin.Context) {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
+	ocrkc.App.GetStore().Events.Publish(eventbus.KeyGenerated{Type: eventbus.KeyTypeOCR, ID: encryptedKeyBundle.ID.String()})
 	jsonAPIResponse(c, encryptedKeyBundle, "offChainReportingKeyBundle")
 }
 
@@ -78,6 +109,26 @@ func (ocrkc *OCRKeysController) Delete(c *gin.Context) {
 	jsonAPIResponse(c, ekb, "offChainReportingKeyBundle")
 }
 
+// Restore restores an archived OCR key bundle
+// Example:
+// "POST <application>/keys/ocr/restore/:keyID"
+func (ocrkc *OCRKeysController) Restore(c *gin.Context) {
+	id, err := models.Sha256HashFromHex(c.Param("keyID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	ekb, err := ocrkc.App.GetStore().OCRKeyStore.RestoreEncryptedOCRKeyBundle(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, ekb, "offChainReportingKeyBundle")
+}
+
 // Import imports an OCR key bundle
 // Example:
 // "Post <application>/keys/ocr/import"
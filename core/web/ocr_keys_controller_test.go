@@ -11,6 +11,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/chainlink/core/web"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,6 +39,30 @@ func TestOCRKeysController_Index_HappyPath(t *testing.T) {
 	assert.Equal(t, keys[0].ConfigPublicKey, ocrKeys[0].ConfigPublicKey)
 }
 
+func TestOCRKeysController_Usage_HappyPath(t *testing.T) {
+	client, cleanup, _, jobID, _, _ := setupJobSpecsControllerTestsWithJobs(t)
+	defer cleanup()
+
+	response, cleanup := client.Get("/v2/keys/ocr/usage")
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	usages := []presenters.KeyUsageResource{}
+	err := web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &usages)
+	require.NoError(t, err)
+
+	var found bool
+	for _, usage := range usages {
+		if usage.ID == cltest.DefaultOCRKeyBundleID {
+			found = true
+			assert.Equal(t, []int32{jobID}, usage.JobIDs)
+			assert.Nil(t, usage.LastUsedAt)
+			assert.Empty(t, usage.ConfigDigests)
+		}
+	}
+	assert.True(t, found, "expected usage of the default OCR key bundle")
+}
+
 func TestOCRKeysController_Create_HappyPath(t *testing.T) {
 	client, OCRKeyStore, cleanup := setupOCRKeysControllerTests(t)
 	defer cleanup()
@@ -104,6 +129,29 @@ func TestOCRKeysController_Delete_HappyPath(t *testing.T) {
 	assert.Equal(t, initialLength, len(keys))
 }
 
+func TestOCRKeysController_Restore_HappyPath(t *testing.T) {
+	client, OCRKeyStore, cleanup := setupOCRKeysControllerTests(t)
+	defer cleanup()
+	require.NoError(t, OCRKeyStore.Unlock(cltest.Password))
+
+	_, encryptedKeyBundle, _ := OCRKeyStore.GenerateEncryptedOCRKeyBundle()
+	require.NoError(t, OCRKeyStore.ArchiveEncryptedOCRKeyBundle(&encryptedKeyBundle))
+	_, exists := OCRKeyStore.DecryptedOCRKey(encryptedKeyBundle.ID)
+	require.False(t, exists)
+
+	response, cleanup := client.Post("/v2/keys/ocr/restore/"+encryptedKeyBundle.ID.String(), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	require.NoError(t, utils.JustError(OCRKeyStore.FindEncryptedOCRKeyBundleByID(encryptedKeyBundle.ID)))
+	_, exists = OCRKeyStore.DecryptedOCRKey(encryptedKeyBundle.ID)
+	assert.True(t, exists)
+
+	response, cleanup = client.Post("/v2/keys/ocr/restore/"+encryptedKeyBundle.ID.String(), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusNotFound)
+}
+
 func setupOCRKeysControllerTests(t *testing.T) (cltest.HTTPClientCleaner, *offchainreporting.KeyStore, func()) {
 	t.Parallel()
 
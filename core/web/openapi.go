@@ -0,0 +1,498 @@
+package web
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// openAPIVersion is the version of the OpenAPI specification that the
+// document conforms to
+const openAPIVersion = "3.0.3"
+
+type (
+	// openAPIDocument is an OpenAPI 3 document, with only the fields that the
+	// node's API needs
+	openAPIDocument struct {
+		OpenAPI    string                                  `json:"openapi"`
+		Info       openAPIInfo                             `json:"info"`
+		Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+		Components openAPIComponents                       `json:"components"`
+		Security   []map[string][]string                   `json:"security"`
+	}
+
+	openAPIInfo struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Version     string `json:"version"`
+	}
+
+	openAPIComponents struct {
+		Schemas         map[string]*openAPISchema         `json:"schemas"`
+		SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes"`
+	}
+
+	openAPISecurityScheme struct {
+		Type        string `json:"type"`
+		In          string `json:"in"`
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+	}
+
+	openAPIOperation struct {
+		OperationID string                      `json:"operationId"`
+		Summary     string                      `json:"summary"`
+		Description string                      `json:"description,omitempty"`
+		Tags        []string                    `json:"tags"`
+		Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+		RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+		Responses   map[string]*openAPIResponse `json:"responses"`
+		// Security is empty for operations that don't require a user
+		Security *[]map[string][]string `json:"security,omitempty"`
+	}
+
+	openAPIParameter struct {
+		Name        string         `json:"name"`
+		In          string         `json:"in"`
+		Description string         `json:"description,omitempty"`
+		Required    bool           `json:"required,omitempty"`
+		Schema      *openAPISchema `json:"schema"`
+	}
+
+	openAPIRequestBody struct {
+		Required bool                         `json:"required"`
+		Content  map[string]*openAPIMediaType `json:"content"`
+	}
+
+	openAPIResponse struct {
+		Description string                       `json:"description"`
+		Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+	}
+
+	openAPIMediaType struct {
+		Schema *openAPISchema `json:"schema"`
+	}
+
+	// openAPISchema is a schema object. The empty schema allows any value.
+	openAPISchema struct {
+		Ref                  string                    `json:"$ref,omitempty"`
+		Type                 string                    `json:"type,omitempty"`
+		Format               string                    `json:"format,omitempty"`
+		Description          string                    `json:"description,omitempty"`
+		Nullable             bool                      `json:"nullable,omitempty"`
+		Items                *openAPISchema            `json:"items,omitempty"`
+		Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+		Required             []string                  `json:"required,omitempty"`
+		AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	}
+)
+
+// apiOperation annotates a route with what the OpenAPI document says about
+// it. Request and response are values of the Go types that the handler
+// decodes and encodes, and are reflected into schemas.
+type apiOperation struct {
+	method string
+	// path is the gin path of the route
+	path string
+	// documentedPath is the path documented instead of path, for routes
+	// that serve a static path segment through staticParam
+	documentedPath string
+	tag            string
+	summary        string
+	description    string
+	params         []apiParam
+	// request is the JSON body of the request, if it has one
+	request interface{}
+	// requestType is the media type of a request body that is not JSON,
+	// which is documented as any value
+	requestType string
+	// response is the resource, or slice of resources, of the JSON API
+	// document that is responded with. A nil response documents an empty
+	// 204 response.
+	response interface{}
+	// paginated responses are pages of a collection, selected with the page
+	// and size params, with the size of the collection in their meta
+	paginated bool
+	// responseType is the media type of a response that is not a JSON API
+	// document. The response is documented as a plain JSON value of its type
+	// for "application/json" with a response, and as any value otherwise.
+	responseType string
+	// status is the status of successful responses. It defaults to 200, or
+	// 204 for nil responses.
+	status int
+	// public operations don't require a user to be authenticated
+	public bool
+}
+
+type apiParam struct {
+	name, description string
+}
+
+// paginationParams select a page of paginated operations
+var paginationParams = []apiParam{
+	{"page", "The 1-based page number"},
+	{"size", "The number of items on a page"},
+}
+
+var (
+	ginParamRegexp     = regexp.MustCompile(`:([A-Za-z]+)`)
+	openAPIParamRegexp = regexp.MustCompile(`{([A-Za-z]+)}`)
+)
+
+// newOpenAPIDocument returns the OpenAPI document of the routes in
+// apiOperations, for the given version of the node
+func newOpenAPIDocument(version string) *openAPIDocument {
+	g := newSchemaGenerator()
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title: "Chainlink node API",
+			Description: "The operator API of a Chainlink node. Responses are JSON API documents " +
+				"unless stated otherwise, and errors are JSON API error documents.",
+			Version: version,
+		},
+		Paths: make(map[string]map[string]*openAPIOperation),
+		Components: openAPIComponents{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]*openAPISecurityScheme{
+				"session": {
+					Type:        "apiKey",
+					In:          "cookie",
+					Name:        SessionName,
+					Description: "The session cookie set by POST /sessions",
+				},
+				"apiKey": {Type: "apiKey", In: "header", Name: APIKey},
+				"apiSecret": {
+					Type:        "apiKey",
+					In:          "header",
+					Name:        APISecret,
+					Description: "The secret of the API token whose access key is in " + APIKey,
+				},
+			},
+		},
+		Security: []map[string][]string{
+			{"session": {}},
+			{"apiKey": {}, "apiSecret": {}},
+		},
+	}
+	for _, op := range apiOperations {
+		path := op.path
+		if op.documentedPath != "" {
+			path = op.documentedPath
+		}
+		path = ginParamRegexp.ReplaceAllString(path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[path][strings.ToLower(op.method)] = g.operation(op, path)
+	}
+	return doc
+}
+
+func (g *schemaGenerator) operation(op apiOperation, path string) *openAPIOperation {
+	o := &openAPIOperation{
+		OperationID: operationID(op.summary),
+		Summary:     op.summary,
+		Description: op.description,
+		Tags:        []string{op.tag},
+		Responses:   make(map[string]*openAPIResponse),
+	}
+	if op.public {
+		o.Security = &[]map[string][]string{}
+	}
+
+	for _, match := range openAPIParamRegexp.FindAllStringSubmatch(path, -1) {
+		o.Parameters = append(o.Parameters, openAPIParameter{
+			Name: match[1], In: "path", Required: true, Schema: &openAPISchema{Type: "string"},
+		})
+	}
+	params := op.params
+	if op.paginated {
+		params = append(append([]apiParam{}, paginationParams...), params...)
+	}
+	for _, p := range params {
+		o.Parameters = append(o.Parameters, openAPIParameter{
+			Name: p.name, In: "query", Description: p.description, Schema: &openAPISchema{Type: "string"},
+		})
+	}
+
+	switch {
+	case op.request != nil:
+		o.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]*openAPIMediaType{"application/json": {Schema: g.schema(reflect.TypeOf(op.request))}},
+		}
+	case op.requestType != "":
+		o.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]*openAPIMediaType{op.requestType: {Schema: &openAPISchema{}}},
+		}
+	}
+
+	status := op.status
+	var response *openAPIResponse
+	switch {
+	case op.responseType == "application/json" && op.response != nil:
+		response = &openAPIResponse{Content: map[string]*openAPIMediaType{
+			op.responseType: {Schema: g.schema(reflect.TypeOf(op.response))},
+		}}
+	case op.responseType != "":
+		response = &openAPIResponse{Content: map[string]*openAPIMediaType{
+			op.responseType: {Schema: &openAPISchema{}},
+		}}
+	case op.response != nil:
+		response = &openAPIResponse{Content: map[string]*openAPIMediaType{
+			MediaType: {Schema: g.document(reflect.TypeOf(op.response), op.paginated)},
+		}}
+	default:
+		response = &openAPIResponse{}
+		if status == 0 {
+			status = http.StatusNoContent
+		}
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response.Description = http.StatusText(status)
+	o.Responses[fmt.Sprint(status)] = response
+	o.Responses["default"] = &openAPIResponse{
+		Description: "Error",
+		Content: map[string]*openAPIMediaType{
+			MediaType: {Schema: g.schema(reflect.TypeOf(models.JSONAPIErrors{}))},
+		},
+	}
+	return o
+}
+
+// operationID turns a summary such as "List jobs" into "listJobs"
+func operationID(summary string) string {
+	var b strings.Builder
+	for i, word := range strings.FieldsFunc(summary, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word[:1]) + word[1:])
+		} else {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// schemaGenerator reflects Go types into schemas. Named struct types are
+// added to the document's components and referred to, so that recursive
+// types terminate.
+type schemaGenerator struct {
+	schemas map[string]*openAPISchema
+	names   map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*openAPISchema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// document returns the schema of a JSON API document whose data is the
+// resource, or resources, of type t
+func (g *schemaGenerator) document(t reflect.Type, paginated bool) *openAPISchema {
+	doc := &openAPISchema{Type: "object", Required: []string{"data"}}
+	if t.Kind() == reflect.Slice {
+		doc.Properties = map[string]*openAPISchema{
+			"data": {Type: "array", Items: g.resource(t.Elem())},
+		}
+	} else {
+		doc.Properties = map[string]*openAPISchema{"data": g.resource(t)}
+	}
+	if paginated {
+		doc.Properties["meta"] = &openAPISchema{
+			Type:       "object",
+			Properties: map[string]*openAPISchema{"count": {Type: "integer", Description: "The size of the collection"}},
+		}
+		doc.Properties["links"] = &openAPISchema{
+			Type:       "object",
+			Properties: map[string]*openAPISchema{"prev": {Type: "string"}, "next": {Type: "string"}},
+		}
+	}
+	return doc
+}
+
+// resource returns the schema of a JSON API resource object whose
+// attributes are of type t
+func (g *schemaGenerator) resource(t reflect.Type) *openAPISchema {
+	return &openAPISchema{
+		Type:     "object",
+		Required: []string{"type", "id", "attributes"},
+		Properties: map[string]*openAPISchema{
+			"type":       {Type: "string"},
+			"id":         {Type: "string"},
+			"attributes": g.schema(t),
+		},
+	}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// knownSchemas are the schemas of types that are marshaled as something
+	// other than what reflecting on them would suggest
+	knownSchemas = map[reflect.Type]openAPISchema{
+		reflect.TypeOf(time.Time{}):                 {Type: "string", Format: "date-time"},
+		reflect.TypeOf(gorm.DeletedAt{}):            {Type: "string", Format: "date-time", Nullable: true},
+		reflect.TypeOf(null.Time{}):                 {Type: "string", Format: "date-time", Nullable: true},
+		reflect.TypeOf(null.String{}):               {Type: "string", Nullable: true},
+		reflect.TypeOf(null.Int{}):                  {Type: "integer", Nullable: true},
+		reflect.TypeOf(null.Float{}):                {Type: "number", Nullable: true},
+		reflect.TypeOf(null.Bool{}):                 {Type: "boolean", Nullable: true},
+		reflect.TypeOf(big.Int{}):                   {Type: "integer"},
+		reflect.TypeOf(utils.Big{}):                 {Type: "string", Description: "A decimal integer"},
+		reflect.TypeOf(assets.Link{}):               {Type: "string", Description: "An amount of LINK in juels"},
+		reflect.TypeOf(assets.Eth{}):                {Type: "string", Description: "An amount of ETH in wei"},
+		reflect.TypeOf(common.Address{}):            {Type: "string", Description: "A hex encoded address"},
+		reflect.TypeOf(common.Hash{}):               {Type: "string", Description: "A hex encoded hash"},
+		reflect.TypeOf(hexutil.Bytes{}):             {Type: "string", Description: "Hex encoded bytes"},
+		reflect.TypeOf(models.JSON{}):               {},
+		reflect.TypeOf(pipeline.JSONSerializable{}): {},
+		reflect.TypeOf(json.RawMessage{}):           {},
+	}
+)
+
+// schema returns the schema of values of type t as encoding/json marshals
+// them
+func (g *schemaGenerator) schema(t reflect.Type) *openAPISchema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+	if known, exists := knownSchemas[t]; exists {
+		known.Nullable = known.Nullable || nullable
+		return &known
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+			return &openAPISchema{Type: "string", Nullable: nullable}
+		}
+		return &openAPISchema{Description: "Custom JSON of " + t.String(), Nullable: nullable}
+	}
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return &openAPISchema{Type: "string", Nullable: nullable}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean", Nullable: nullable}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Nullable: nullable}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number", Nullable: nullable}
+	case reflect.String:
+		return &openAPISchema{Type: "string", Nullable: nullable}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte", Nullable: nullable}
+		}
+		return &openAPISchema{Type: "array", Items: g.schema(t.Elem()), Nullable: t.Kind() == reflect.Slice || nullable}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + g.component(t)}
+	default:
+		return &openAPISchema{}
+	}
+}
+
+// component adds the schema of the named struct type t to the document's
+// components, if it has not been yet, and returns its name. Types of the same
+// name from different packages are told apart by their package.
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, exists := g.names[t]; exists {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	for i := 2; ; i++ {
+		if _, taken := g.schemas[name]; !taken {
+			break
+		}
+		name = fmt.Sprintf("%s%d", t.Name(), i)
+	}
+	g.names[t] = name
+	// The placeholder is replaced once the properties, which may refer back
+	// to t, have been generated
+	g.schemas[name] = &openAPISchema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+// structSchema returns the schema of a struct's fields, with the fields of
+// embedded structs promoted as encoding/json does
+func (g *schemaGenerator) structSchema(t reflect.Type) *openAPISchema {
+	s := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if _, known := knownSchemas[fieldType]; !known && !fieldType.Implements(jsonMarshalerType) {
+				embedded := g.structSchema(fieldType)
+				for name, property := range embedded.Properties {
+					if _, exists := s.Properties[name]; !exists {
+						s.Properties[name] = property
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schema(field.Type)
+		if strings.Contains(opts, "string") {
+			property = &openAPISchema{Type: "string"}
+		}
+		s.Properties[name] = property
+	}
+	return s
+}
@@ -0,0 +1,33 @@
+package web
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/static"
+)
+
+// OpenAPIController serves the OpenAPI document of the API, for generating
+// clients and contract testing
+type OpenAPIController struct {
+	App chainlink.Application
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIDoc  *openAPIDocument
+)
+
+// Show returns the OpenAPI 3 document of the API, which is generated from
+// apiOperations the first time it is requested
+// Example:
+// "GET <application>/openapi.json"
+func (oac *OpenAPIController) Show(c *gin.Context) {
+	openAPIOnce.Do(func() {
+		openAPIDoc = newOpenAPIDocument(static.Version)
+	})
+	c.JSON(http.StatusOK, openAPIDoc)
+}
@@ -0,0 +1,108 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/web"
+)
+
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Paths   map[string]map[string]struct {
+		OperationID string                     `json:"operationId"`
+		Security    *[]map[string][]string     `json:"security"`
+		Responses   map[string]json.RawMessage `json:"responses"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func setupOpenAPIControllerTests(t *testing.T) (*cltest.TestApplication, openAPIDocument, func()) {
+	t.Parallel()
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplication(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	require.NoError(t, app.Start())
+
+	// The document is public
+	resp, err := http.Get(app.Server.URL + "/v2/openapi.json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var doc openAPIDocument
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	return app, doc, cleanup
+}
+
+func TestOpenAPIController_Show(t *testing.T) {
+	_, doc, cleanup := setupOpenAPIControllerTests(t)
+	defer cleanup()
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+	listRuns, exists := doc.Paths["/v2/jobs/{ID}/runs"]["get"]
+	require.True(t, exists)
+	assert.Equal(t, "listRuns", listRuns.OperationID)
+	assert.Nil(t, listRuns.Security)
+	assert.Contains(t, listRuns.Responses, "200")
+	assert.Contains(t, listRuns.Responses, "default")
+	assert.Contains(t, doc.Components.Schemas["PipelineRunResource"].Properties, "taskRuns")
+
+	login := doc.Paths["/sessions"]["post"]
+	require.NotNil(t, login.Security)
+	assert.Empty(t, *login.Security)
+
+	assert.Contains(t, doc.Paths["/v2/jobs/{ID}"]["delete"].Responses, "204")
+	assert.Contains(t, doc.Paths, "/v2/specs/schema")
+
+	operationIDs := make(map[string]bool)
+	for path, operations := range doc.Paths {
+		for method, op := range operations {
+			assert.False(t, operationIDs[op.OperationID], "%s %s has a duplicate operationId %s", method, path, op.OperationID)
+			operationIDs[op.OperationID] = true
+		}
+	}
+}
+
+func TestOpenAPIController_DocumentsEveryRoute(t *testing.T) {
+	app, doc, cleanup := setupOpenAPIControllerTests(t)
+	defer cleanup()
+
+	ginParam := regexp.MustCompile(`:([A-Za-z]+)`)
+	routes := make(map[string]bool)
+	for _, route := range web.Router(app).Routes() {
+		if !strings.HasPrefix(route.Path, "/v2/") && route.Path != "/sessions" {
+			continue
+		}
+		path := ginParam.ReplaceAllString(route.Path, "{$1}")
+		routes[strings.ToLower(route.Method)+" "+path] = true
+		_, documented := doc.Paths[path][strings.ToLower(route.Method)]
+		assert.True(t, documented, "%s %s is not documented", route.Method, route.Path)
+	}
+
+	for path, operations := range doc.Paths {
+		routed := path
+		if path == "/v2/specs/schema" {
+			// Served by the GET /v2/specs/:SpecID route
+			routed = "/v2/specs/{SpecID}"
+		}
+		for method := range operations {
+			assert.True(t, routes[method+" "+routed], "%s %s is documented but not routed", method, path)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	webpresenters "github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// sortParam sorts the collections of operations whose routes are wrapped in
+// listRequest. Those collections can also be filtered by their attributes,
+// with params such as filter[name].
+var sortParam = apiParam{"sort", `The attribute to sort by, prefixed with "-" for descending order`}
+
+// hardDeleteParam deletes keys instead of archiving them
+var hardDeleteParam = apiParam{"hard", "Delete the key permanently instead of archiving it"}
+
+// apiOperations documents every route of the API, other than the debug
+// routes, in the order of the router. TestOpenAPIController_DocumentsEveryRoute fails
+// for routes that are added without being documented here.
+var apiOperations = []apiOperation{
+	// Sessions
+	{method: "POST", path: "/sessions", tag: "Sessions", summary: "Log in", public: true,
+		description: "Sets the session cookie that authenticates later requests",
+		request:     models.SessionRequest{}, response: Session{}},
+	{method: "DELETE", path: "/sessions", tag: "Sessions", summary: "Log out",
+		response: Session{}},
+
+	// Requests from bridges and peers
+	{method: "PATCH", path: "/v2/runs/:RunID", tag: "Job runs (v1)", summary: "Resume a pending bridge run", public: true,
+		description: "Authenticated with the bridge's incoming token as a bearer token",
+		request:     models.BridgeRunResult{}, response: models.JobRun{}},
+	{method: "POST", path: "/v2/service_agreements", tag: "Service agreements", summary: "Create a service agreement", public: true,
+		request: models.ServiceAgreementRequest{}, response: models.ServiceAgreement{}},
+
+	// User
+	{method: "PATCH", path: "/v2/user/password", tag: "User", summary: "Change the password",
+		request: models.ChangePasswordRequest{}, response: presenters.UserPresenter{}},
+	{method: "POST", path: "/v2/user/token", tag: "User", summary: "Create an API token",
+		request: models.ChangeAuthTokenRequest{}, response: auth.Token{}, status: http.StatusCreated},
+	{method: "POST", path: "/v2/user/token/delete", tag: "User", summary: "Delete the API token",
+		request: models.ChangeAuthTokenRequest{}},
+	{method: "POST", path: "/v2/user/2fa/totp", tag: "User", summary: "Begin TOTP enrollment",
+		request: models.TwoFactorRequest{}, response: webpresenters.TOTPEnrollmentResource{}, status: http.StatusCreated},
+	{method: "POST", path: "/v2/user/2fa/totp/confirm", tag: "User", summary: "Confirm TOTP enrollment",
+		request: models.TwoFactorRequest{}, response: webpresenters.RecoveryCodesResource{}},
+	{method: "POST", path: "/v2/user/2fa/recovery_codes", tag: "User", summary: "Regenerate the recovery codes",
+		request: models.TwoFactorRequest{}, response: webpresenters.RecoveryCodesResource{}, status: http.StatusCreated},
+	{method: "POST", path: "/v2/user/2fa/delete", tag: "User", summary: "Disable two-factor authentication",
+		request: models.TwoFactorRequest{}},
+
+	// External initiators
+	{method: "POST", path: "/v2/external_initiators", tag: "External initiators", summary: "Create an external initiator",
+		request: models.ExternalInitiatorRequest{}, response: presenters.ExternalInitiatorAuthentication{}, status: http.StatusCreated},
+	{method: "DELETE", path: "/v2/external_initiators/:Name", tag: "External initiators", summary: "Delete an external initiator"},
+
+	// v1 jobs
+	{method: "POST", path: "/v2/specs", tag: "Jobs (v1)", summary: "Create a v1 job",
+		request: models.JobSpecRequest{}, response: presenters.JobSpec{}},
+	{method: "GET", path: "/v2/specs", tag: "Jobs (v1)", summary: "List v1 jobs",
+		params:   []apiParam{{"sort", `"-createdAt" lists the newest jobs first`}},
+		response: []presenters.JobSpec{}, paginated: true},
+	{method: "GET", path: "/v2/specs/:SpecID", documentedPath: "/v2/specs/schema", tag: "Jobs (v2)", summary: "List job spec schemas",
+		description: "Lists the JSON schemas of the TOML specs of each v2 job type, or responds with the schema of one type",
+		params:      []apiParam{{"type", "The job type to respond with the schema of"}},
+		response:    []webpresenters.SpecSchemaResource{}},
+	{method: "GET", path: "/v2/specs/:SpecID", tag: "Jobs (v1)", summary: "Get a v1 job",
+		response: presenters.JobSpec{}},
+	{method: "DELETE", path: "/v2/specs/:SpecID", tag: "Jobs (v1)", summary: "Archive a v1 job"},
+	{method: "GET", path: "/v2/runs", tag: "Job runs (v1)", summary: "List v1 job runs",
+		params: []apiParam{
+			{"jobSpecId", "Only list the runs of this job"},
+			{"sort", `"-createdAt" lists the newest runs first`},
+		},
+		response: []presenters.JobRun{}, paginated: true},
+	{method: "GET", path: "/v2/runs/:RunID", tag: "Job runs (v1)", summary: "Get a v1 job run",
+		response: presenters.JobRun{}},
+	{method: "PUT", path: "/v2/runs/:RunID/cancellation", tag: "Job runs (v1)", summary: "Cancel a v1 job run",
+		response: presenters.JobRun{}},
+	{method: "DELETE", path: "/v2/job_spec_errors/:jobSpecErrorID", tag: "Jobs (v1)", summary: "Dismiss a v1 job error"},
+	{method: "GET", path: "/v2/service_agreements/:SAID", tag: "Service agreements", summary: "Get a service agreement",
+		response: presenters.ServiceAgreement{}},
+
+	// Bridges
+	{method: "GET", path: "/v2/bridge_types", tag: "Bridges", summary: "List bridges",
+		params: []apiParam{sortParam}, response: []models.BridgeType{}, paginated: true},
+	{method: "POST", path: "/v2/bridge_types", tag: "Bridges", summary: "Create a bridge",
+		request: models.BridgeTypeRequest{}, response: models.BridgeTypeAuthentication{}},
+	{method: "GET", path: "/v2/bridge_types/:BridgeName", tag: "Bridges", summary: "Get a bridge",
+		response: models.BridgeType{}},
+	{method: "PATCH", path: "/v2/bridge_types/:BridgeName", tag: "Bridges", summary: "Update a bridge",
+		request: models.BridgeTypeRequest{}, response: models.BridgeType{}},
+	{method: "PUT", path: "/v2/bridge_types/:BridgeName", tag: "Bridges", summary: "Create or update a bridge",
+		description: "Responds with 201 Created, and the bridge's outgoing token, if the bridge was created",
+		request:     models.BridgeTypeRequest{}, response: webpresenters.BridgeUpsertResource{}},
+	{method: "DELETE", path: "/v2/bridge_types/:BridgeName", tag: "Bridges", summary: "Delete a bridge",
+		response: models.BridgeType{}},
+
+	// Transactions
+	{method: "POST", path: "/v2/transfers", tag: "Transactions", summary: "Transfer ETH",
+		request: models.SendEtherRequest{}, response: presenters.EthTx{}},
+
+	// Config
+	{method: "GET", path: "/v2/config", tag: "Config", summary: "Get the config",
+		response: presenters.ConfigPrinter{}},
+	{method: "PATCH", path: "/v2/config", tag: "Config", summary: "Update the config",
+		request: configPatchRequest{}, response: ConfigPatchResponse{}},
+	{method: "GET", path: "/v2/rpc_usage", tag: "Config", summary: "Get the eth RPC usage",
+		description: "Responds with the eth RPC calls made so far this month, by day, method and endpoint, and how many will have been made by the end of the month at the same rate",
+		response:    presenters.RPCUsageReport{}},
+
+	{method: "GET", path: "/v2/tx_attempts", tag: "Transactions", summary: "List transaction attempts",
+		response: []presenters.EthTx{}, paginated: true},
+	{method: "GET", path: "/v2/transactions", tag: "Transactions", summary: "List transactions",
+		response: []presenters.EthTx{}, paginated: true},
+	{method: "GET", path: "/v2/transactions/:TxHash", tag: "Transactions", summary: "Get a transaction",
+		description: "Responds with the transaction that has an attempt with the hash",
+		response:    presenters.EthTx{}},
+	{method: "POST", path: "/v2/tx_simulations", tag: "Transactions", summary: "Simulate a transaction",
+		request: TxSimulationRequest{}, response: webpresenters.TxSimulationResource{}},
+	{method: "DELETE", path: "/v2/bulk_delete_runs", tag: "Job runs (v1)", summary: "Delete v1 job runs",
+		request: models.BulkDeleteRunRequest{}},
+
+	// ETH keys
+	{method: "GET", path: "/v2/keys/eth", tag: "Keys", summary: "List ETH keys",
+		params: []apiParam{sortParam}, response: []presenters.ETHKey{}, paginated: true},
+	{method: "POST", path: "/v2/keys/eth", tag: "Keys", summary: "Create an ETH key",
+		response: presenters.ETHKey{}, status: http.StatusCreated},
+	{method: "DELETE", path: "/v2/keys/eth/:keyID", tag: "Keys", summary: "Delete an ETH key",
+		params: []apiParam{hardDeleteParam}, response: presenters.ETHKey{}},
+	{method: "PATCH", path: "/v2/keys/eth/:keyID", tag: "Keys", summary: "Update the settings of an ETH key",
+		request: models.KeySettings{}, response: presenters.ETHKey{}},
+	{method: "POST", path: "/v2/keys/eth/import", tag: "Keys", summary: "Import an ETH key",
+		params:      []apiParam{{"oldpassword", "The password the key is encrypted with"}},
+		requestType: "application/json", response: presenters.ETHKey{}},
+	{method: "POST", path: "/v2/keys/eth/export/:address", tag: "Keys", summary: "Export an ETH key",
+		params:       []apiParam{{"newpassword", "The password to encrypt the key with"}},
+		responseType: "application/json"},
+	{method: "POST", path: "/v2/keys/eth/restore/:keyID", tag: "Keys", summary: "Restore an archived ETH key",
+		response: presenters.ETHKey{}},
+	{method: "GET", path: "/v2/keys/eth/nonces/:address", tag: "Keys", summary: "List the unmined nonces of an ETH key",
+		description: "Responds with the key's nonces that haven't been mined yet, and the gaps between them that leave its transactions stuck",
+		response:    presenters.NonceLadder{}},
+	{method: "POST", path: "/v2/keys/eth/nonces/:address", tag: "Keys", summary: "Fill the nonce gaps of an ETH key",
+		description: "Fills the gaps with empty transactions, all of them if no nonces are given, and responds with the key's nonces",
+		request:     FillNonceGapsRequest{}, response: presenters.NonceLadder{}},
+
+	// OCR keys
+	{method: "GET", path: "/v2/keys/ocr", tag: "Keys", summary: "List OCR key bundles",
+		response: []ocrkey.EncryptedKeyBundle{}},
+	{method: "GET", path: "/v2/keys/ocr/usage", tag: "Keys", summary: "List the jobs using each OCR key bundle",
+		response: []webpresenters.KeyUsageResource{}},
+	{method: "POST", path: "/v2/keys/ocr", tag: "Keys", summary: "Create an OCR key bundle",
+		response: ocrkey.EncryptedKeyBundle{}},
+	{method: "DELETE", path: "/v2/keys/ocr/:keyID", tag: "Keys", summary: "Delete an OCR key bundle",
+		params: []apiParam{hardDeleteParam}, response: ocrkey.EncryptedKeyBundle{}},
+	{method: "POST", path: "/v2/keys/ocr/import", tag: "Keys", summary: "Import an OCR key bundle",
+		params:      []apiParam{{"oldpassword", "The password the key bundle is encrypted with"}},
+		requestType: "application/json", response: ocrkey.EncryptedKeyBundle{}},
+	{method: "POST", path: "/v2/keys/ocr/export/:ID", tag: "Keys", summary: "Export an OCR key bundle",
+		params:       []apiParam{{"newpassword", "The password to encrypt the key bundle with"}},
+		responseType: "application/json"},
+	{method: "POST", path: "/v2/keys/ocr/restore/:keyID", tag: "Keys", summary: "Restore an archived OCR key bundle",
+		response: ocrkey.EncryptedKeyBundle{}},
+
+	// P2P keys
+	{method: "GET", path: "/v2/keys/p2p", tag: "Keys", summary: "List P2P keys",
+		response: []p2pkey.EncryptedP2PKey{}},
+	{method: "GET", path: "/v2/keys/p2p/usage", tag: "Keys", summary: "List the jobs using each P2P key",
+		response: []webpresenters.KeyUsageResource{}},
+	{method: "POST", path: "/v2/keys/p2p", tag: "Keys", summary: "Create a P2P key",
+		response: p2pkey.EncryptedP2PKey{}},
+	{method: "DELETE", path: "/v2/keys/p2p/:keyID", tag: "Keys", summary: "Delete a P2P key",
+		params: []apiParam{hardDeleteParam}, response: p2pkey.EncryptedP2PKey{}},
+	{method: "POST", path: "/v2/keys/p2p/import", tag: "Keys", summary: "Import a P2P key",
+		params:      []apiParam{{"oldpassword", "The password the key is encrypted with"}},
+		requestType: "application/json", response: p2pkey.EncryptedP2PKey{}},
+	{method: "POST", path: "/v2/keys/p2p/export/:ID", tag: "Keys", summary: "Export a P2P key",
+		params:       []apiParam{{"newpassword", "The password to encrypt the key with"}},
+		responseType: "application/json"},
+	{method: "POST", path: "/v2/keys/p2p/restore/:keyID", tag: "Keys", summary: "Restore an archived P2P key",
+		response: p2pkey.EncryptedP2PKey{}},
+
+	// Forwarders and datasources
+	{method: "GET", path: "/v2/forwarders", tag: "Forwarders", summary: "List forwarders",
+		response: []models.Forwarder{}},
+	{method: "POST", path: "/v2/forwarders", tag: "Forwarders", summary: "Create a forwarder",
+		request: CreateForwarderRequest{}, response: models.Forwarder{}, status: http.StatusCreated},
+	{method: "DELETE", path: "/v2/forwarders/:fwdID", tag: "Forwarders", summary: "Delete a forwarder"},
+	{method: "GET", path: "/v2/datasources", tag: "Datasources", summary: "List datasources",
+		response: []models.Datasource{}},
+	{method: "POST", path: "/v2/datasources", tag: "Datasources", summary: "Create a datasource",
+		request: models.DatasourceRequest{}, response: models.Datasource{}, status: http.StatusCreated},
+	{method: "DELETE", path: "/v2/datasources/:name", tag: "Datasources", summary: "Delete a datasource"},
+
+	// v2 jobs
+	{method: "GET", path: "/v2/jobs", tag: "Jobs (v2)", summary: "List jobs",
+		params: []apiParam{
+			sortParam,
+			{"archived", `"true" lists the archived jobs instead`},
+			{"include", `"claims" includes the claim each job is run under in HA deployments`},
+		},
+		response: []webpresenters.JobResource{}, paginated: true},
+	{method: "GET", path: "/v2/jobs/:ID", tag: "Jobs (v2)", summary: "Get a job",
+		response: webpresenters.JobResource{}},
+	{method: "POST", path: "/v2/jobs", tag: "Jobs (v2)", summary: "Create a job",
+		description: "Errors in TOML specs are positioned by the line, and column if it is known, in their meta",
+		params:      []apiParam{{"validateOnly", `"true" only validates the spec, and responds with the job as it would be created`}},
+		request:     models.CreateJobSpecRequest{}, response: webpresenters.JobResource{}},
+	{method: "DELETE", path: "/v2/jobs/:ID", tag: "Jobs (v2)", summary: "Delete a job",
+		description: "Archives the job, which is deleted along with its runs once JOB_ARCHIVE_RETENTION has passed",
+		params: []apiParam{
+			{"purge", `"true" deletes the job and its runs immediately`},
+			{"force", `"false" waits for the job's in-flight runs to finish, rather than cancelling them`},
+		}},
+
+	// v2 job runs
+	{method: "GET", path: "/v2/jobs/:ID/runs", tag: "Job runs (v2)", summary: "List runs",
+		params: []apiParam{
+			sortParam,
+			{"cursor", "Pages by run ID instead of page number. Pass it empty for the first page, and follow the next link."},
+		},
+		response: []webpresenters.PipelineRunResource{}, paginated: true},
+	{method: "GET", path: "/v2/jobs/:ID/runs/:runID", tag: "Job runs (v2)", summary: "Get a run",
+		response: webpresenters.PipelineRunResource{}},
+	{method: "GET", path: "/v2/jobs/:ID/runs/:runID/audit", tag: "Job runs (v2)", summary: "Get the audit of a run",
+		response: webpresenters.RunAuditResource{}},
+	{method: "GET", path: "/v2/jobs/:ID/runs/:runID/task_runs", tag: "Job runs (v2)", summary: "List the task runs of a run",
+		response: []webpresenters.PipelineTaskRunResource{}},
+	{method: "POST", path: "/v2/jobs/:ID/runs", tag: "Job runs (v2)", summary: "Run a job",
+		description: "The optional body is the run's meta, which must match the job's metaSchema",
+		request:     map[string]interface{}{}, response: webpresenters.PipelineRunResource{}},
+	{method: "GET", path: "/v2/jobs/:ID/shadow_comparisons", tag: "Jobs (v2)", summary: "List the comparisons of a shadow job",
+		response: []pipeline.ShadowComparison{}, paginated: true},
+	{method: "GET", path: "/v2/jobs/:ID/transmitter_rotations", tag: "Jobs (v2)", summary: "List the transmitter rotations of a job",
+		response: []webpresenters.TransmitterRotationResource{}},
+	{method: "POST", path: "/v2/jobs/:ID/transmitter_rotations", tag: "Jobs (v2)", summary: "Rotate the transmitter of a job",
+		request: CreateTransmitterRotationRequest{}, response: webpresenters.TransmitterRotationResource{}, status: http.StatusCreated},
+	{method: "GET", path: "/v2/jobs/:ID/costs", tag: "Jobs (v2)", summary: "List the costs of a job",
+		description: "The HTTP calls, bytes transferred and gas spent by the job's runs, for each day, kind of cost and target",
+		params: []apiParam{
+			{"from", "The first day, as a YYYY-MM-DD UTC date. Defaults to 29 days before to."},
+			{"to", "The last day, as a YYYY-MM-DD UTC date. Defaults to today."},
+		},
+		response: []webpresenters.JobCostResource{}},
+	{method: "POST", path: "/v2/job_conversions", tag: "Jobs (v2)", summary: "Convert a v1 job spec to a v2 TOML job spec",
+		description: "Nothing is created. The warnings list the parts of the v1 spec that were not converted, or need reviewing.",
+		request:     models.JobSpecRequest{}, response: webpresenters.JobConversionResource{}},
+	{method: "POST", path: "/v2/drift_reports", tag: "Jobs (v2)", summary: "Create a drift report",
+		request: DriftReportRequest{}, response: webpresenters.DriftReportResource{}},
+
+	// Node
+	{method: "GET", path: "/v2/node", tag: "Node", summary: "Get the node",
+		response: webpresenters.NodeResource{}},
+	{method: "GET", path: "/v2/feature_flags", tag: "Node", summary: "List feature flags",
+		response: []webpresenters.FeatureFlagResource{}},
+	{method: "PATCH", path: "/v2/feature_flags/:name", tag: "Node", summary: "Update a feature flag",
+		request: FeatureFlagPatchRequest{}, response: webpresenters.FeatureFlagResource{}},
+	{method: "GET", path: "/v2/node_state", tag: "Node", summary: "Export the node state",
+		response: provisioning.Archive{}, responseType: "application/json"},
+	{method: "POST", path: "/v2/node_state", tag: "Node", summary: "Import a node state",
+		request: provisioning.Archive{}, response: webpresenters.NodeStateImportResource{}},
+	{method: "POST", path: "/v2/manifest", tag: "Node", summary: "Apply a manifest",
+		params:  []apiParam{{"dryRun", `"true" responds with the changes without making them`}},
+		request: provisioning.Manifest{}, response: webpresenters.ManifestChangesResource{}},
+	{method: "GET", path: "/v2/maintenance", tag: "Node", summary: "Get the maintenance mode",
+		response: webpresenters.MaintenanceResource{}},
+	{method: "POST", path: "/v2/maintenance", tag: "Node", summary: "Enter maintenance mode",
+		request: MaintenanceRequest{}, response: webpresenters.MaintenanceResource{}},
+	{method: "DELETE", path: "/v2/maintenance", tag: "Node", summary: "Leave maintenance mode",
+		response: webpresenters.MaintenanceResource{}},
+	{method: "GET", path: "/v2/events", tag: "Node", summary: "Stream events",
+		description:  "Streams the events of the topics as server-sent events, whose names are the topics",
+		params:       []apiParam{{"topics", "The comma separated topics to stream. All topics are streamed by default."}},
+		responseType: "text/event-stream"},
+	{method: "GET", path: "/v2/feed_reports/:contractAddress", tag: "Jobs (v2)", summary: "Get a feed report",
+		params:   []apiParam{{"blocks", "The number of blocks to report on"}},
+		response: webpresenters.FeedReportResource{}},
+	{method: "GET", path: "/v2/log", tag: "Node", summary: "Get the log config",
+		response: webpresenters.LogResource{}},
+	{method: "PATCH", path: "/v2/log", tag: "Node", summary: "Update the log config",
+		request: LogPatchRequest{}, response: webpresenters.LogResource{}},
+	{method: "POST", path: "/v2/graphql", tag: "Node", summary: "Query the GraphQL API",
+		requestType: "application/json", responseType: "application/json"},
+
+	// Requests from users or external initiators
+	{method: "POST", path: "/v2/specs/:SpecID/runs", tag: "Job runs (v1)", summary: "Run a v1 job",
+		description: "Also authenticated by external initiators' access keys and secrets",
+		requestType: "application/json", response: presenters.JobRun{}},
+	{method: "GET", path: "/v2/ping", tag: "Node", summary: "Ping the node",
+		response: map[string]string{}, responseType: "application/json"},
+
+	{method: "GET", path: "/v2/openapi.json", tag: "Node", summary: "Get the OpenAPI document", public: true,
+		response: map[string]interface{}{}, responseType: "application/json"},
+}
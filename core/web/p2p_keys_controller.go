@@ -9,7 +9,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eventbus"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+	"gorm.io/gorm"
 )
 
 // P2PKeysController manages P2P keys
@@ -29,6 +33,33 @@ func (p2pkc *P2PKeysController) Index(c *gin.Context) {
 	jsonAPIResponse(c, keys, "p2pKey")
 }
 
+// Usage lists every P2P key along with the jobs referencing it, when it was
+// last used and the on-chain configs it has been seen in
+// Example:
+// "GET <application>/keys/p2p/usage"
+func (p2pkc *P2PKeysController) Usage(c *gin.Context) {
+	keys, err := p2pkc.App.GetStore().OCRKeyStore.FindEncryptedP2PKeys()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	var defaultPeerID *models.PeerID
+	if peerID, err := p2pkc.App.GetStore().Config.P2PPeerID(nil); err == nil {
+		defaultPeerID = &peerID
+	}
+	usages, err := p2pkc.App.GetJobORM().P2PKeyUsage(defaultPeerID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := []presenters.KeyUsageResource{}
+	for _, key := range keys {
+		resources = append(resources, *presenters.NewKeyUsageResource(key.PeerID.Raw(), usages[key.PeerID]))
+	}
+	jsonAPIResponse(c, resources, "keyUsages")
+}
+
 // Create and return a P2P key
 // Example:
 // "POST <application>/keys/p2p"
@@ -38,6 +69,7 @@ func (p2pkc *P2PKeysController) Create(c *gin.Context) {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
+	p2pkc.App.GetStore().Events.Publish(eventbus.KeyGenerated{Type: eventbus.KeyTypeP2P, ID: encryptedP2PKey.PeerID.String()})
 	jsonAPIResponse(c, encryptedP2PKey, "p2pKey")
 }
 
@@ -79,6 +111,27 @@ func (p2pkc *P2PKeysController) Delete(c *gin.Context) {
 	jsonAPIResponse(c, encryptedP2PKeyPointer, "p2pKey")
 }
 
+// Restore restores an archived P2P key
+// Example:
+// "POST <application>/keys/p2p/restore/:keyID"
+func (p2pkc *P2PKeysController) Restore(c *gin.Context) {
+	ep2pk := p2pkey.EncryptedP2PKey{}
+	err := ep2pk.SetID(c.Param("keyID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	encryptedP2PKeyPointer, err := p2pkc.App.GetStore().OCRKeyStore.RestoreEncryptedP2PKey(ep2pk.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, encryptedP2PKeyPointer, "p2pKey")
+}
+
 // Import imports a P2P key
 // Example:
 // "Post <application>/keys/p2p/import"
@@ -12,6 +12,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/chainlink/core/web"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,6 +41,28 @@ func TestP2PKeysController_Index_HappyPath(t *testing.T) {
 	assert.Equal(t, keys[0].PeerID, p2pKeys[0].PeerID)
 }
 
+func TestP2PKeysController_Usage_HappyPath(t *testing.T) {
+	client, cleanup, _, jobID, _, _ := setupJobSpecsControllerTestsWithJobs(t)
+	defer cleanup()
+
+	response, cleanup := client.Get("/v2/keys/p2p/usage")
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	usages := []presenters.KeyUsageResource{}
+	err := web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &usages)
+	require.NoError(t, err)
+
+	var found bool
+	for _, usage := range usages {
+		if usage.ID == cltest.DefaultPeerID {
+			found = true
+			assert.Equal(t, []int32{jobID}, usage.JobIDs)
+		}
+	}
+	assert.True(t, found, "expected usage of the default P2P key")
+}
+
 func TestP2PKeysController_Create_HappyPath(t *testing.T) {
 	t.Parallel()
 
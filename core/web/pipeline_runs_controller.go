@@ -1,13 +1,21 @@
 package web
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"gorm.io/gorm"
 )
 
@@ -16,10 +24,17 @@ type PipelineRunsController struct {
 	App chainlink.Application
 }
 
-// Index returns all pipeline runs for a job.
+// Index returns a page of the pipeline runs for a job, most recent first by
+// default. Runs can be sorted by id, createdAt and finishedAt.
+//
+// Passing cursor pages by run ID instead of page number, so that runs created
+// while paging do not shift later pages. Start with an empty cursor and follow
+// the next link, which holds the ID of the last run on the page.
 // Example:
 // "GET <application>/jobs/:ID/runs"
-func (prc *PipelineRunsController) Index(c *gin.Context, size, page, offset int) {
+// "GET <application>/jobs/:ID/runs?sort=finishedAt"
+// "GET <application>/jobs/:ID/runs?cursor=&size=100"
+func (prc *PipelineRunsController) Index(c *gin.Context, p orm.Pagination, page int) {
 	jobSpec := job.Job{}
 	err := jobSpec.SetID(c.Param("ID"))
 	if err != nil {
@@ -27,13 +42,29 @@ func (prc *PipelineRunsController) Index(c *gin.Context, size, page, offset int)
 		return
 	}
 
-	pipelineRuns, count, err := prc.App.GetJobORM().PipelineRunsByJobID(jobSpec.ID, offset, size)
-	if err != nil {
-		jsonAPIError(c, http.StatusInternalServerError, err)
+	_, cursorPaging := c.GetQuery("cursor")
+	if cursorPaging && p.Cursor != "" {
+		if _, err = strconv.ParseInt(p.Cursor, 10, 64); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid cursor"))
+			return
+		}
+	}
+	if cursorPaging && p.Sort == "" {
+		p.Sort = "-id"
+	}
+
+	pipelineRuns, count, err := prc.App.GetJobORM().PipelineRunsByJobID(jobSpec.ID, p)
+	resources := presenters.NewPipelineRunResources(pipelineRuns)
+	if !cursorPaging {
+		paginatedResponse(c, "offChainReportingPipelineRun", p.Limit, page, resources, count, err)
 		return
 	}
 
-	paginatedResponse(c, "offChainReportingPipelineRun", size, page, pipelineRuns, count, err)
+	var nextCursor string
+	if len(pipelineRuns) == p.Limit {
+		nextCursor = pipelineRuns[len(pipelineRuns)-1].GetID()
+	}
+	cursorPaginatedResponse(c, "offChainReportingPipelineRun", p.Limit, nextCursor, resources, count, err)
 }
 
 // Show returns a specified pipeline run.
@@ -56,10 +87,80 @@ func (prc *PipelineRunsController) Show(c *gin.Context) {
 		return
 	}
 
-	jsonAPIResponse(c, pipelineRun, "offChainReportingPipelineRun")
+	jsonAPIResponse(c, presenters.NewPipelineRunResource(pipelineRun), "offChainReportingPipelineRun")
+}
+
+// TaskRuns returns the task runs of a pipeline run of the job, in the order
+// they were created.
+// Example:
+// "GET <application>/jobs/:ID/runs/:runID/task_runs"
+func (prc *PipelineRunsController) TaskRuns(c *gin.Context) {
+	jobSpec := job.Job{}
+	err := jobSpec.SetID(c.Param("ID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	pipelineRun := pipeline.Run{}
+	err = pipelineRun.SetID(c.Param("runID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = preloadPipelineRunDependencies(prc.App.GetStore().DB).
+		Where("pipeline_runs.id = ? AND pipeline_runs.job_id = ?", pipelineRun.ID, jobSpec.ID).
+		First(&pipelineRun).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("pipeline run not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewPipelineTaskRunResources(pipelineRun.PipelineTaskRuns), "pipelineTaskRuns")
+}
+
+// Audit returns the audit recorded for a pipeline run of the job. Runs are
+// only audited while JOB_PIPELINE_AUDIT_MODE is enabled.
+// Example:
+// "GET <application>/jobs/:ID/runs/:runID/audit"
+func (prc *PipelineRunsController) Audit(c *gin.Context) {
+	jobSpec := job.Job{}
+	err := jobSpec.SetID(c.Param("ID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	pipelineRun := pipeline.Run{}
+	err = pipelineRun.SetID(c.Param("runID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = prc.App.GetStore().DB.
+		Where("pipeline_runs.id = ? AND pipeline_runs.job_id = ?", pipelineRun.ID, jobSpec.ID).
+		First(&pipelineRun).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("pipeline run not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if pipelineRun.Audit == nil {
+		jsonAPIError(c, http.StatusNotFound, errors.New("no audit was recorded for this pipeline run"))
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewRunAuditResource(pipelineRun), "pipelineRunAudits")
 }
 
-// Create triggers a pipeline run for a job.
+// Create triggers a pipeline run for a job. The request body, if any, is a
+// JSON object that becomes the run's meta. Meta that doesn't match the job's
+// metaSchema is rejected with an error for each offending field.
 // Example:
 // "POST <application>/jobs/:ID/runs"
 func (prc *PipelineRunsController) Create(c *gin.Context) {
@@ -70,9 +171,26 @@ func (prc *PipelineRunsController) Create(c *gin.Context) {
 		return
 	}
 
-	jobRunID, err := prc.App.RunJobV2(c, jobSpec.ID, nil)
-
+	var meta map[string]interface{}
+	body, err := ioutil.ReadAll(c.Request.Body)
 	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err = json.Unmarshal(body, &meta); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "run meta must be a JSON object"))
+			return
+		}
+	}
+
+	jobRunID, err := prc.App.RunJobV2(c, jobSpec.ID, meta)
+
+	var metaErr *pipeline.MetaValidationError
+	if errors.As(err, &metaErr) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, metaValidationErrors(metaErr))
+		return
+	} else if err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
@@ -87,7 +205,17 @@ func (prc *PipelineRunsController) Create(c *gin.Context) {
 		return
 	}
 
-	jsonAPIResponse(c, pipelineRun, "offChainReportingPipelineRun")
+	jsonAPIResponse(c, presenters.NewPipelineRunResource(pipelineRun), "offChainReportingPipelineRun")
+}
+
+// metaValidationErrors lists each field that failed meta validation as a
+// separate JSON API error
+func metaValidationErrors(err *pipeline.MetaValidationError) *models.JSONAPIErrors {
+	errs := models.NewJSONAPIErrors()
+	for _, field := range err.Fields {
+		errs.Add(field.Error())
+	}
+	return errs
 }
 
 func preloadPipelineRunDependencies(db *gorm.DB) *gorm.DB {
@@ -9,11 +9,12 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 
+	"github.com/manyminds/api2go/jsonapi"
 	"github.com/pelletier/go-toml"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
-	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/web"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v4"
@@ -55,13 +56,15 @@ func TestPipelineRunsController_Create_HappyPath(t *testing.T) {
 	defer cleanup()
 	cltest.AssertServerResponse(t, response, http.StatusOK)
 
-	var parsedResponse pipeline.Run
+	var parsedResponse presenters.PipelineRunResource
 	err = web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &parsedResponse)
 	assert.NoError(t, err)
-	assert.NotNil(t, parsedResponse.ID)
+	assert.NotEmpty(t, parsedResponse.ID)
 	assert.NotNil(t, parsedResponse.CreatedAt)
 	assert.Nil(t, parsedResponse.FinishedAt)
-	require.Len(t, parsedResponse.PipelineTaskRuns, 8)
+	require.Len(t, parsedResponse.TaskRuns, 8)
+	assert.Equal(t, fmt.Sprintf("%v", jobID), parsedResponse.JobID)
+	assert.Len(t, parsedResponse.TaskRunIDs, 8)
 }
 
 func TestPipelineRunsController_Index_HappyPath(t *testing.T) {
@@ -72,7 +75,7 @@ func TestPipelineRunsController_Index_HappyPath(t *testing.T) {
 	defer cleanup()
 	cltest.AssertServerResponse(t, response, http.StatusOK)
 
-	var parsedResponse []pipeline.Run
+	var parsedResponse []presenters.PipelineRunResource
 	responseBytes := cltest.ParseResponseBody(t, response)
 	assert.Contains(t, string(responseBytes), `"meta":null,"errors":[null],"outputs":["3"]`)
 
@@ -80,10 +83,10 @@ func TestPipelineRunsController_Index_HappyPath(t *testing.T) {
 	assert.NoError(t, err)
 
 	require.Len(t, parsedResponse, 2)
-	assert.Equal(t, parsedResponse[1].ID, runIDs[0])
+	assert.Equal(t, fmt.Sprintf("%v", runIDs[0]), parsedResponse[1].ID)
 	assert.NotNil(t, parsedResponse[1].CreatedAt)
 	assert.NotNil(t, parsedResponse[1].FinishedAt)
-	require.Len(t, parsedResponse[1].PipelineTaskRuns, 4)
+	require.Len(t, parsedResponse[1].TaskRuns, 4)
 }
 
 func TestPipelineRunsController_Index_Pagination(t *testing.T) {
@@ -94,7 +97,7 @@ func TestPipelineRunsController_Index_Pagination(t *testing.T) {
 	defer cleanup()
 	cltest.AssertServerResponse(t, response, http.StatusOK)
 
-	var parsedResponse []pipeline.Run
+	var parsedResponse []presenters.PipelineRunResource
 	responseBytes := cltest.ParseResponseBody(t, response)
 	assert.Contains(t, string(responseBytes), `"meta":null,"errors":[null],"outputs":["3"]`)
 	assert.Contains(t, string(responseBytes), `"meta":{"count":2}`)
@@ -103,10 +106,42 @@ func TestPipelineRunsController_Index_Pagination(t *testing.T) {
 	assert.NoError(t, err)
 
 	require.Len(t, parsedResponse, 1)
-	assert.Equal(t, parsedResponse[0].ID, runIDs[1])
+	assert.Equal(t, fmt.Sprintf("%v", runIDs[1]), parsedResponse[0].ID)
 	assert.NotNil(t, parsedResponse[0].CreatedAt)
 	assert.NotNil(t, parsedResponse[0].FinishedAt)
-	require.Len(t, parsedResponse[0].PipelineTaskRuns, 4)
+	require.Len(t, parsedResponse[0].TaskRuns, 4)
+}
+
+func TestPipelineRunsController_Index_CursorPagination(t *testing.T) {
+	client, jobID, runIDs, cleanup := setupPipelineRunsControllerTests(t)
+	defer cleanup()
+
+	response, cleanup := client.Get(fmt.Sprintf("/v2/jobs/%v/runs?cursor=&size=1", jobID))
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	var parsedResponse []presenters.PipelineRunResource
+	var links jsonapi.Links
+	responseBytes := cltest.ParseResponseBody(t, response)
+	require.NoError(t, web.ParsePaginatedResponse(responseBytes, &parsedResponse, &links))
+	require.Len(t, parsedResponse, 1)
+	assert.Equal(t, fmt.Sprintf("%v", runIDs[1]), parsedResponse[0].ID)
+	require.NotEmpty(t, links["next"].Href)
+	assert.Contains(t, links["next"].Href, fmt.Sprintf("cursor=%v", runIDs[1]))
+
+	response, cleanup = client.Get(links["next"].Href)
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	parsedResponse = nil
+	responseBytes = cltest.ParseResponseBody(t, response)
+	require.NoError(t, web.ParsePaginatedResponse(responseBytes, &parsedResponse, &links))
+	require.Len(t, parsedResponse, 1)
+	assert.Equal(t, fmt.Sprintf("%v", runIDs[0]), parsedResponse[0].ID)
+
+	response, cleanup = client.Get(fmt.Sprintf("/v2/jobs/%v/runs?cursor=%v&sort=finishedAt", jobID, runIDs[1]))
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusUnprocessableEntity)
 }
 
 func TestPipelineRunsController_Show_HappyPath(t *testing.T) {
@@ -117,17 +152,56 @@ func TestPipelineRunsController_Show_HappyPath(t *testing.T) {
 	defer cleanup()
 	cltest.AssertServerResponse(t, response, http.StatusOK)
 
-	var parsedResponse pipeline.Run
+	var parsedResponse presenters.PipelineRunResource
 	responseBytes := cltest.ParseResponseBody(t, response)
 	assert.Contains(t, string(responseBytes), `"meta":null,"errors":[null],"outputs":["3"]`)
 
 	err := web.ParseJSONAPIResponse(responseBytes, &parsedResponse)
 	assert.NoError(t, err)
 
-	assert.Equal(t, parsedResponse.ID, runIDs[0])
+	assert.Equal(t, fmt.Sprintf("%v", runIDs[0]), parsedResponse.ID)
 	assert.NotNil(t, parsedResponse.CreatedAt)
 	assert.NotNil(t, parsedResponse.FinishedAt)
-	require.Len(t, parsedResponse.PipelineTaskRuns, 4)
+	require.Len(t, parsedResponse.TaskRuns, 4)
+	assert.Equal(t, fmt.Sprintf("%v", jobID), parsedResponse.JobID)
+	assert.Len(t, parsedResponse.TaskRunIDs, 4)
+}
+
+func TestPipelineRunsController_TaskRuns(t *testing.T) {
+	client, jobID, runIDs, cleanup := setupPipelineRunsControllerTests(t)
+	defer cleanup()
+
+	response, cleanup := client.Get(fmt.Sprintf("/v2/jobs/%v/runs/%v/task_runs", jobID, runIDs[0]))
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	var taskRuns []presenters.PipelineTaskRunResource
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &taskRuns))
+	require.Len(t, taskRuns, 4)
+	for _, tr := range taskRuns {
+		assert.NotEmpty(t, tr.ID)
+		assert.NotEmpty(t, tr.DotID)
+		assert.NotNil(t, tr.FinishedAt)
+		assert.Equal(t, fmt.Sprintf("%v", runIDs[0]), tr.PipelineRunID)
+	}
+
+	response, cleanup = client.Get(fmt.Sprintf("/v2/jobs/%v/runs/%v/task_runs", jobID, runIDs[1]+100))
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusNotFound)
+
+	// The run must belong to the job
+	response, cleanup = client.Get(fmt.Sprintf("/v2/jobs/%v/runs/%v/task_runs", jobID+100, runIDs[0]))
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusNotFound)
+}
+
+func TestPipelineRunsController_Audit_NotRecorded(t *testing.T) {
+	client, jobID, runIDs, cleanup := setupPipelineRunsControllerTests(t)
+	defer cleanup()
+
+	response, cleanup := client.Get("/v2/jobs/" + fmt.Sprintf("%v", jobID) + "/runs/" + fmt.Sprintf("%v", runIDs[0]) + "/audit")
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusNotFound)
 }
 
 func TestPipelineRunsController_ShowRun_InvalidID(t *testing.T) {
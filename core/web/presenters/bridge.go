@@ -0,0 +1,47 @@
+package presenters
+
+import (
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// BridgeUpsertResource is the result of creating or updating a bridge by
+// name. IncomingToken is only set when the node generated it for a newly
+// created bridge, since it can't be retrieved later.
+type BridgeUpsertResource struct {
+	JAID
+	Name                   string       `json:"name"`
+	URL                    string       `json:"url"`
+	Confirmations          uint32       `json:"confirmations"`
+	OutgoingToken          string       `json:"outgoingToken"`
+	IncomingToken          string       `json:"incomingToken,omitempty"`
+	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
+	GRPCTarget             string       `json:"grpcTarget,omitempty"`
+	GRPCTLS                bool         `json:"grpcTLS,omitempty"`
+	MaxConcurrentCalls     uint32       `json:"maxConcurrentCalls"`
+	Created                bool         `json:"created"`
+	Changed                bool         `json:"changed"`
+}
+
+// NewBridgeUpsertResource initializes a new JSONAPI bridge upsert resource
+func NewBridgeUpsertResource(bt models.BridgeType, incomingToken string, created, changed bool) *BridgeUpsertResource {
+	return &BridgeUpsertResource{
+		JAID:                   JAID{ID: bt.Name.String()},
+		Name:                   bt.Name.String(),
+		URL:                    bt.URL.String(),
+		Confirmations:          bt.Confirmations,
+		OutgoingToken:          bt.OutgoingToken,
+		IncomingToken:          incomingToken,
+		MinimumContractPayment: bt.MinimumContractPayment,
+		GRPCTarget:             bt.GRPCTarget,
+		GRPCTLS:                bt.GRPCTLS,
+		MaxConcurrentCalls:     bt.MaxConcurrentCalls,
+		Created:                created,
+		Changed:                changed,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r BridgeUpsertResource) GetName() string {
+	return "bridges"
+}
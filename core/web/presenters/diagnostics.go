@@ -0,0 +1,109 @@
+package presenters
+
+import (
+	"database/sql"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	storepresenters "github.com/smartcontractkit/chainlink/core/store/presenters"
+)
+
+// GCStats summarises the Go runtime's memory and garbage collection
+type GCStats struct {
+	NumGC          int64           `json:"numGC"`
+	LastGC         time.Time       `json:"lastGC"`
+	PauseTotal     time.Duration   `json:"pauseTotal"`
+	RecentPauses   []time.Duration `json:"recentPauses"`
+	HeapAlloc      uint64          `json:"heapAlloc"`
+	HeapSys        uint64          `json:"heapSys"`
+	HeapObjects    uint64          `json:"heapObjects"`
+	NextGC         uint64          `json:"nextGC"`
+	GCCPUFraction  float64         `json:"gcCPUFraction"`
+	NumGoroutine   int             `json:"numGoroutine"`
+	GOMAXPROCS     int             `json:"gomaxprocs"`
+	GoVersion      string          `json:"goVersion"`
+	SysMemoryTotal uint64          `json:"sysMemoryTotal"`
+}
+
+// NewGCStats reads the current GC stats from the runtime. It keeps the last
+// 10 GC pauses, most recent first.
+func NewGCStats() GCStats {
+	var gc debug.GCStats
+	gc.Pause = make([]time.Duration, 10)
+	debug.ReadGCStats(&gc)
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	recent := gc.Pause
+	if int64(len(recent)) > gc.NumGC {
+		recent = recent[:gc.NumGC]
+	}
+	return GCStats{
+		NumGC:          gc.NumGC,
+		LastGC:         gc.LastGC,
+		PauseTotal:     gc.PauseTotal,
+		RecentPauses:   recent,
+		HeapAlloc:      mem.HeapAlloc,
+		HeapSys:        mem.HeapSys,
+		HeapObjects:    mem.HeapObjects,
+		NextGC:         mem.NextGC,
+		GCCPUFraction:  mem.GCCPUFraction,
+		NumGoroutine:   runtime.NumGoroutine(),
+		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		GoVersion:      runtime.Version(),
+		SysMemoryTotal: mem.Sys,
+	}
+}
+
+// QueueDepths are the amounts of work waiting to be done by the node
+type QueueDepths struct {
+	UnfinishedPipelineRuns int64            `json:"unfinishedPipelineRuns"`
+	UnfinishedJobRuns      int64            `json:"unfinishedJobRuns"`
+	EthTxes                map[string]int64 `json:"ethTxes"`
+}
+
+// DBPoolStats are the stats of the node's database connection pool
+type DBPoolStats struct {
+	MaxOpenConnections int           `json:"maxOpenConnections"`
+	OpenConnections    int           `json:"openConnections"`
+	InUse              int           `json:"inUse"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"waitCount"`
+	WaitDuration       time.Duration `json:"waitDuration"`
+	MaxIdleClosed      int64         `json:"maxIdleClosed"`
+	MaxLifetimeClosed  int64         `json:"maxLifetimeClosed"`
+}
+
+// NewDBPoolStats converts the stats of a connection pool
+func NewDBPoolStats(stats sql.DBStats) DBPoolStats {
+	return DBPoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration,
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
+
+// DiagnosticsResource is a snapshot of the node's runtime state, for
+// debugging a node without shell access to it. Config is the same whitelist
+// served by the config endpoint, so it contains no secrets.
+type DiagnosticsResource struct {
+	JAID
+	Version   string                        `json:"version"`
+	CommitSHA string                        `json:"commitSHA"`
+	CreatedAt time.Time                     `json:"createdAt"`
+	Runtime   GCStats                       `json:"runtime"`
+	Queues    QueueDepths                   `json:"queues"`
+	DBPool    DBPoolStats                   `json:"dbPool"`
+	Config    storepresenters.ConfigPrinter `json:"config"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r DiagnosticsResource) GetName() string {
+	return "diagnostics"
+}
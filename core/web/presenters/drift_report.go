@@ -0,0 +1,57 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// DriftReportResource represents the difference between the jobs on the
+// node and a set of expected job specs
+type DriftReportResource struct {
+	JAID
+	Missing []job.ExpectedSpec `json:"missing"`
+	Extra   []JobDrift         `json:"extra"`
+	Drifted []JobDrift         `json:"drifted"`
+}
+
+// JobDrift identifies a job that is extra to or differs from the expected
+// specs
+type JobDrift struct {
+	JobID            string `json:"jobID"`
+	Name             string `json:"name"`
+	Checksum         string `json:"checksum"`
+	ExpectedChecksum string `json:"expectedChecksum,omitempty"`
+}
+
+// NewDriftReportResource initializes a new JSONAPI drift report resource
+func NewDriftReportResource(report job.DriftReport) *DriftReportResource {
+	resource := &DriftReportResource{
+		JAID:    JAID{ID: "drift"},
+		Missing: []job.ExpectedSpec{},
+		Extra:   []JobDrift{},
+		Drifted: []JobDrift{},
+	}
+	resource.Missing = append(resource.Missing, report.Missing...)
+	for _, jb := range report.Extra {
+		resource.Extra = append(resource.Extra, newJobDrift(jb, ""))
+	}
+	for _, drifted := range report.Drifted {
+		resource.Drifted = append(resource.Drifted, newJobDrift(drifted.Job, drifted.Expected.Checksum))
+	}
+	return resource
+}
+
+func newJobDrift(jb job.Job, expectedChecksum string) JobDrift {
+	return JobDrift{
+		JobID:            strconv.Itoa(int(jb.ID)),
+		Name:             jb.Name.ValueOrZero(),
+		Checksum:         jb.SpecChecksum.ValueOrZero(),
+		ExpectedChecksum: expectedChecksum,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r DriftReportResource) GetName() string {
+	return "driftReports"
+}
@@ -0,0 +1,38 @@
+package presenters
+
+import (
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// FeatureFlagResource represents a feature flag, identified by its env var
+type FeatureFlagResource struct {
+	JAID
+	Enabled bool `json:"enabled"`
+	// Overridden is true if the flag was set at runtime rather than by its
+	// env var
+	Overridden bool `json:"overridden"`
+}
+
+// NewFeatureFlagResource initializes a new JSONAPI feature flag resource
+func NewFeatureFlagResource(config *orm.Config, f orm.Feature) *FeatureFlagResource {
+	return &FeatureFlagResource{
+		JAID:       JAID{ID: f.EnvVarName()},
+		Enabled:    config.FeatureEnabled(f),
+		Overridden: config.FeatureOverride(f) != nil,
+	}
+}
+
+// NewFeatureFlagResources initializes a slice of JSONAPI resources for every
+// feature flag
+func NewFeatureFlagResources(config *orm.Config) []FeatureFlagResource {
+	rs := []FeatureFlagResource{}
+	for _, f := range orm.Features {
+		rs = append(rs, *NewFeatureFlagResource(config, f))
+	}
+	return rs
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r FeatureFlagResource) GetName() string {
+	return "featureFlags"
+}
@@ -0,0 +1,88 @@
+package presenters
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// FeedReportResource represents a comparison of an OCR feed's recent rounds
+// with this node's observations and transmissions
+type FeedReportResource struct {
+	JAID
+	JobID                  int32               `json:"jobID"`
+	TransmitterAddress     models.EIP55Address `json:"transmitterAddress"`
+	OracleIndex            int                 `json:"oracleIndex"`
+	ConfigDigest           string              `json:"configDigest"`
+	FromBlock              uint64              `json:"fromBlock"`
+	ToBlock                uint64              `json:"toBlock"`
+	ParticipatedRounds     int                 `json:"participatedRounds"`
+	MissedRounds           int                 `json:"missedRounds"`
+	TransmittedRounds      int                 `json:"transmittedRounds"`
+	MaxDivergence          float64             `json:"maxDivergence"`
+	LatestLocalObservation *LocalObservation   `json:"latestLocalObservation"`
+	Rounds                 []FeedRound         `json:"rounds"`
+}
+
+// FeedRound represents a round transmitted to an OCR feed
+type FeedRound struct {
+	RoundID      uint32              `json:"roundID"`
+	BlockNumber  uint64              `json:"blockNumber"`
+	Answer       *utils.Big          `json:"answer"`
+	Transmitter  models.EIP55Address `json:"transmitter"`
+	Participated bool                `json:"participated"`
+	Transmitted  bool                `json:"transmitted"`
+	Observation  *utils.Big          `json:"observation"`
+	Divergence   float64             `json:"divergence"`
+}
+
+// LocalObservation represents a value observed by this node's pipeline
+type LocalObservation struct {
+	Value      string    `json:"value"`
+	ObservedAt time.Time `json:"observedAt"`
+}
+
+// NewFeedReportResource initializes a new JSONAPI feed report resource
+func NewFeedReportResource(jobID int32, report offchainreporting.FeedReport) *FeedReportResource {
+	resource := &FeedReportResource{
+		JAID:               JAID{ID: report.ContractAddress.Hex()},
+		JobID:              jobID,
+		TransmitterAddress: models.EIP55Address(report.TransmitterAddress.Hex()),
+		OracleIndex:        report.OracleIndex,
+		ConfigDigest:       hex.EncodeToString(report.ConfigDigest[:]),
+		FromBlock:          report.FromBlock,
+		ToBlock:            report.ToBlock,
+		ParticipatedRounds: report.ParticipatedRounds,
+		MissedRounds:       report.MissedRounds,
+		TransmittedRounds:  report.TransmittedRounds,
+		MaxDivergence:      report.MaxDivergence,
+		Rounds:             []FeedRound{},
+	}
+	if obs := report.LatestLocalObservation; obs != nil {
+		resource.LatestLocalObservation = &LocalObservation{Value: obs.Value.String(), ObservedAt: obs.ObservedAt}
+	}
+	for _, round := range report.Rounds {
+		r := FeedRound{
+			RoundID:      round.RoundID,
+			BlockNumber:  round.BlockNumber,
+			Answer:       utils.NewBig(round.Answer),
+			Transmitter:  models.EIP55Address(round.Transmitter.Hex()),
+			Participated: round.Participated,
+			Transmitted:  round.Transmitted,
+			Divergence:   round.Divergence,
+		}
+		if round.Observation != nil {
+			r.Observation = utils.NewBig(round.Observation)
+		}
+		resource.Rounds = append(resource.Rounds, r)
+	}
+	return resource
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r FeedReportResource) GetName() string {
+	return "feedReports"
+}
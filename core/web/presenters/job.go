@@ -1,13 +1,16 @@
 package presenters
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/manyminds/api2go/jsonapi"
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
 // JobSpecType defines the the the spec type of the job
@@ -87,11 +90,17 @@ type OffChainReportingSpec struct {
 	ContractAddress                        models.EIP55Address  `json:"contractAddress"`
 	P2PPeerID                              *models.PeerID       `json:"p2pPeerID"`
 	P2PBootstrapPeers                      pq.StringArray       `json:"p2pBootstrapPeers"`
+	P2PPeers                               pq.StringArray       `json:"p2pPeers"`
 	IsBootstrapPeer                        bool                 `json:"isBootstrapPeer"`
 	EncryptedOCRKeyBundleID                *models.Sha256Hash   `json:"keyBundleID"`
+	MonitoringEndpoint                     string               `json:"monitoringEndpoint"`
 	TransmitterAddress                     *models.EIP55Address `json:"transmitterAddress"`
+	TransmitDisabled                       bool                 `json:"transmitDisabled"`
+	Decimals                               *uint8               `json:"decimals"`
 	ObservationTimeout                     models.Interval      `json:"observationTimeout"`
+	LatencyBudget                          bool                 `json:"latencyBudget"`
 	BlockchainTimeout                      models.Interval      `json:"blockchainTimeout"`
+	TransmissionDeadline                   models.Interval      `json:"transmissionDeadline"`
 	ContractConfigTrackerSubscribeInterval models.Interval      `json:"contractConfigTrackerSubscribeInterval"`
 	ContractConfigTrackerPollInterval      models.Interval      `json:"contractConfigTrackerPollInterval"`
 	ContractConfigConfirmations            uint16               `json:"contractConfigConfirmations"`
@@ -106,11 +115,17 @@ func NewOffChainReportingSpec(spec *job.OffchainReportingOracleSpec) *OffChainRe
 		ContractAddress:                        spec.ContractAddress,
 		P2PPeerID:                              spec.P2PPeerID,
 		P2PBootstrapPeers:                      spec.P2PBootstrapPeers,
+		P2PPeers:                               spec.P2PPeers,
 		IsBootstrapPeer:                        spec.IsBootstrapPeer,
 		EncryptedOCRKeyBundleID:                spec.EncryptedOCRKeyBundleID,
+		MonitoringEndpoint:                     spec.MonitoringEndpoint,
 		TransmitterAddress:                     spec.TransmitterAddress,
+		TransmitDisabled:                       spec.TransmitDisabled,
+		Decimals:                               spec.Decimals,
 		ObservationTimeout:                     spec.ObservationTimeout,
+		LatencyBudget:                          spec.LatencyBudget,
 		BlockchainTimeout:                      spec.BlockchainTimeout,
+		TransmissionDeadline:                   spec.TransmissionDeadline,
 		ContractConfigTrackerSubscribeInterval: spec.ContractConfigTrackerSubscribeInterval,
 		ContractConfigTrackerPollInterval:      spec.ContractConfigTrackerPollInterval,
 		ContractConfigConfirmations:            spec.ContractConfigConfirmations,
@@ -121,15 +136,17 @@ func NewOffChainReportingSpec(spec *job.OffchainReportingOracleSpec) *OffChainRe
 
 // PipelineSpec defines the spec details of the pipeline
 type PipelineSpec struct {
-	ID           int32  `json:"id"`
-	DotDAGSource string `json:"dotDagSource"`
+	ID            int32                  `json:"id"`
+	DotDAGSource  string                 `json:"dotDagSource"`
+	NumericPolicy pipeline.NumericPolicy `json:"numericPolicy"`
 }
 
 // NewPipelineSpec generates a new PipelineSpec from a pipeline.Spec
 func NewPipelineSpec(spec *pipeline.Spec) PipelineSpec {
 	return PipelineSpec{
-		ID:           spec.ID,
-		DotDAGSource: spec.DotDagSource,
+		ID:            spec.ID,
+		DotDAGSource:  spec.DotDagSource,
+		NumericPolicy: spec.NumericPolicy,
 	}
 }
 
@@ -151,6 +168,47 @@ func NewKeeperSpec(spec *job.KeeperSpec) *KeeperSpec {
 	}
 }
 
+// ShadowSpec defines the spec details of a Shadow Job
+type ShadowSpec struct {
+	ShadowOfJobID int32     `json:"shadowOfJobID"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// NewShadowSpec generates a new ShadowSpec from a job.ShadowSpec
+func NewShadowSpec(spec *job.ShadowSpec) *ShadowSpec {
+	return &ShadowSpec{
+		ShadowOfJobID: spec.ShadowOfJobID,
+		CreatedAt:     spec.CreatedAt,
+		UpdatedAt:     spec.UpdatedAt,
+	}
+}
+
+// MessageQueueSpec defines the spec details of a MessageQueue Job
+type MessageQueueSpec struct {
+	URL           string    `json:"url"`
+	Topic         string    `json:"topic"`
+	ConsumerGroup string    `json:"consumerGroup"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// NewMessageQueueSpec generates a new MessageQueueSpec from a
+// job.MessageQueueSpec. Any password in the URL is redacted.
+func NewMessageQueueSpec(spec *job.MessageQueueSpec) *MessageQueueSpec {
+	u := spec.URL
+	if parsed, err := url.Parse(spec.URL); err == nil {
+		u = parsed.Redacted()
+	}
+	return &MessageQueueSpec{
+		URL:           u,
+		Topic:         spec.Topic,
+		ConsumerGroup: spec.ConsumerGroup,
+		CreatedAt:     spec.CreatedAt,
+		UpdatedAt:     spec.UpdatedAt,
+	}
+}
+
 // JobError represents errors on the job
 type JobError struct {
 	ID          int64     `json:"id"`
@@ -170,6 +228,55 @@ func NewJobError(e job.SpecError) JobError {
 	}
 }
 
+// JobClaim identifies the node which currently claims the job
+type JobClaim struct {
+	NodeID          string    `json:"nodeID"`
+	ClaimedAt       time.Time `json:"claimedAt"`
+	LastHeartbeatAt time.Time `json:"lastHeartbeatAt"`
+}
+
+// NewJobClaim initializes a new JobClaim from a job.Claim
+func NewJobClaim(c job.Claim) *JobClaim {
+	return &JobClaim{
+		NodeID:          c.NodeID,
+		ClaimedAt:       c.ClaimedAt,
+		LastHeartbeatAt: c.LastHeartbeatAt,
+	}
+}
+
+// JobOnComplete is what the node does once each of the job's runs has
+// finished. The webhook's secret and the queue's credentials are not shown.
+type JobOnComplete struct {
+	WebhookURL string `json:"webhookURL,omitempty"`
+	// WebhookSigned is whether the webhook's requests are signed
+	WebhookSigned bool   `json:"webhookSigned"`
+	QueueURL      string `json:"queueURL,omitempty"`
+	QueueTopic    string `json:"queueTopic,omitempty"`
+	QueueFormat   string `json:"queueFormat,omitempty"`
+}
+
+func newJobOnComplete(oc job.OnComplete) *JobOnComplete {
+	if !oc.WebhookURL.Valid && !oc.QueueURL.Valid {
+		return nil
+	}
+	resource := &JobOnComplete{
+		WebhookURL:    oc.WebhookURL.String,
+		WebhookSigned: oc.WebhookSecret.Valid,
+	}
+	if oc.QueueURL.Valid {
+		// The URL is validated when the job is created
+		if u, err := url.Parse(oc.QueueURL.String); err == nil {
+			resource.QueueURL = u.Redacted()
+		}
+		resource.QueueTopic = oc.QueueTopic.String
+		resource.QueueFormat = job.QueueFormatJSON
+		if oc.QueueFormat.Valid {
+			resource.QueueFormat = oc.QueueFormat.String
+		}
+	}
+	return resource
+}
+
 // JobResource represents a JobResource
 type JobResource struct {
 	JAID
@@ -177,12 +284,29 @@ type JobResource struct {
 	Type                  JobSpecType            `json:"type"`
 	SchemaVersion         uint32                 `json:"schemaVersion"`
 	MaxTaskDuration       models.Interval        `json:"maxTaskDuration"`
+	SpecChecksum          string                 `json:"specChecksum"`
+	MetaSchema            *string                `json:"metaSchema,omitempty"`
+	DependsOn             *string                `json:"dependsOn,omitempty"`
+	ContractENSName       *string                `json:"contractENSName,omitempty"`
+	MaxGasCostWei         *utils.Big             `json:"maxGasCostWei,omitempty"`
+	OnComplete            *JobOnComplete         `json:"onComplete,omitempty"`
 	DirectRequestSpec     *DirectRequestSpec     `json:"directRequestSpec"`
 	FluxMonitorSpec       *FluxMonitorSpec       `json:"fluxMonitorSpec"`
 	OffChainReportingSpec *OffChainReportingSpec `json:"offChainReportingOracleSpec"`
 	KeeperSpec            *KeeperSpec            `json:"KeeperSpec"`
+	ShadowSpec            *ShadowSpec            `json:"shadowSpec"`
+	MessageQueueSpec      *MessageQueueSpec      `json:"messageQueueSpec"`
 	PipelineSpec          PipelineSpec           `json:"pipelineSpec"`
 	Errors                []JobError             `json:"errors"`
+	Claim                 *JobClaim              `json:"claim,omitempty"`
+	ArchivedAt            *time.Time             `json:"archivedAt,omitempty"`
+	// BridgeNames is the bridges relationship, the bridges the pipeline calls
+	BridgeNames []string `json:"-"`
+	// ETHKeyAddresses is the ethKeys relationship, the keys the job sends
+	// transactions from
+	ETHKeyAddresses []string `json:"-"`
+	// OCRKeyBundleIDs is the ocrKeyBundles relationship
+	OCRKeyBundleIDs []string `json:"-"`
 }
 
 // NewJobResource initializes a new JSONAPI job resource
@@ -193,7 +317,21 @@ func NewJobResource(j job.Job) *JobResource {
 		Type:            JobSpecType(j.Type),
 		SchemaVersion:   j.SchemaVersion,
 		MaxTaskDuration: j.MaxTaskDuration,
+		SpecChecksum:    j.SpecChecksum.ValueOrZero(),
+		MetaSchema:      j.MetaSchema.Ptr(),
+		DependsOn:       j.DependsOn.Ptr(),
+		ContractENSName: j.ContractENSName.Ptr(),
+		MaxGasCostWei:   j.MaxGasCostWei,
 		PipelineSpec:    NewPipelineSpec(j.PipelineSpec),
+		ArchivedAt:      j.ArchivedAt.Ptr(),
+		BridgeNames:     []string{},
+		ETHKeyAddresses: []string{},
+		OCRKeyBundleIDs: []string{},
+	}
+	resource.OnComplete = newJobOnComplete(j.OnComplete)
+	// The pipeline was parsed when the job was created
+	if names, err := j.BridgeNames(); err == nil {
+		resource.BridgeNames = names
 	}
 
 	switch j.Type {
@@ -203,8 +341,19 @@ func NewJobResource(j job.Job) *JobResource {
 		resource.FluxMonitorSpec = NewFluxMonitorSpec(j.FluxMonitorSpec)
 	case job.OffchainReporting:
 		resource.OffChainReportingSpec = NewOffChainReportingSpec(j.OffchainreportingOracleSpec)
+		if address := j.OffchainreportingOracleSpec.TransmitterAddress; address != nil {
+			resource.ETHKeyAddresses = append(resource.ETHKeyAddresses, address.Hex())
+		}
+		if id := j.OffchainreportingOracleSpec.EncryptedOCRKeyBundleID; id != nil {
+			resource.OCRKeyBundleIDs = append(resource.OCRKeyBundleIDs, id.String())
+		}
 	case job.Keeper:
 		resource.KeeperSpec = NewKeeperSpec(j.KeeperSpec)
+		resource.ETHKeyAddresses = append(resource.ETHKeyAddresses, j.KeeperSpec.FromAddress.Hex())
+	case job.Shadow:
+		resource.ShadowSpec = NewShadowSpec(j.ShadowSpec)
+	case job.MessageQueue:
+		resource.MessageQueueSpec = NewMessageQueueSpec(j.MessageQueueSpec)
 	}
 
 	jes := []JobError{}
@@ -231,3 +380,49 @@ func NewJobResources(js []job.Job) []JobResource {
 func (r JobResource) GetName() string {
 	return "jobs"
 }
+
+// The types of the resources related to jobs, as the bridges and keys
+// controllers render them
+const (
+	bridgeResourceType       = "bridges"
+	ethKeyResourceType       = "eTHKeys"
+	ocrKeyBundleResourceType = "encryptedKeyBundles"
+)
+
+// GetReferences implements the api2go MarshalReferences interface
+func (r JobResource) GetReferences() []jsonapi.Reference {
+	return []jsonapi.Reference{
+		{Type: bridgeResourceType, Name: "bridges", Relationship: jsonapi.ToManyRelationship},
+		{Type: ethKeyResourceType, Name: "ethKeys", Relationship: jsonapi.ToManyRelationship},
+		{Type: ocrKeyBundleResourceType, Name: "ocrKeyBundles", Relationship: jsonapi.ToManyRelationship},
+	}
+}
+
+// GetReferencedIDs implements the api2go MarshalLinkedRelations interface
+func (r JobResource) GetReferencedIDs() []jsonapi.ReferenceID {
+	var ids []jsonapi.ReferenceID
+	for _, name := range r.BridgeNames {
+		ids = append(ids, jsonapi.ReferenceID{ID: name, Type: bridgeResourceType, Name: "bridges", Relationship: jsonapi.ToManyRelationship})
+	}
+	for _, address := range r.ETHKeyAddresses {
+		ids = append(ids, jsonapi.ReferenceID{ID: address, Type: ethKeyResourceType, Name: "ethKeys", Relationship: jsonapi.ToManyRelationship})
+	}
+	for _, id := range r.OCRKeyBundleIDs {
+		ids = append(ids, jsonapi.ReferenceID{ID: id, Type: ocrKeyBundleResourceType, Name: "ocrKeyBundles", Relationship: jsonapi.ToManyRelationship})
+	}
+	return ids
+}
+
+// SetToManyReferenceIDs implements the api2go UnmarshalToManyRelations
+// interface
+func (r *JobResource) SetToManyReferenceIDs(name string, IDs []string) error {
+	switch name {
+	case "bridges":
+		r.BridgeNames = IDs
+	case "ethKeys":
+		r.ETHKeyAddresses = IDs
+	case "ocrKeyBundles":
+		r.OCRKeyBundleIDs = IDs
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package presenters
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/jobmigration"
+)
+
+// JobConversionResource represents a v1 job spec converted to a v2 TOML job
+// spec
+type JobConversionResource struct {
+	JAID
+	TOML     string   `json:"toml"`
+	Warnings []string `json:"warnings"`
+}
+
+// NewJobConversionResource initializes a new JSONAPI job conversion resource
+func NewJobConversionResource(conversion jobmigration.Conversion) *JobConversionResource {
+	return &JobConversionResource{
+		JAID:     JAID{ID: "conversion"},
+		TOML:     conversion.TOML,
+		Warnings: conversion.Warnings,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobConversionResource) GetName() string {
+	return "jobConversions"
+}
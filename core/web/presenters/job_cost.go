@@ -0,0 +1,45 @@
+package presenters
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// JobCostResource represents the resources that a job used on a day, for one
+// kind of cost and one target
+type JobCostResource struct {
+	JAID
+	JobID         int32        `json:"jobID"`
+	Day           string       `json:"day"`
+	Kind          job.CostKind `json:"kind"`
+	Target        string       `json:"target"`
+	Calls         int64        `json:"calls"`
+	BytesSent     int64        `json:"bytesSent"`
+	BytesReceived int64        `json:"bytesReceived"`
+	GasUsed       *utils.Big   `json:"gasUsed"`
+	FeeWei        *utils.Big   `json:"feeWei"`
+}
+
+// NewJobCostResource initializes a new JSONAPI job cost resource
+func NewJobCostResource(cost job.Cost) *JobCostResource {
+	day := cost.Day.Format("2006-01-02")
+	return &JobCostResource{
+		JAID:          JAID{ID: fmt.Sprintf("%s-%s-%s", day, cost.Kind, cost.Target)},
+		JobID:         cost.JobID,
+		Day:           day,
+		Kind:          cost.Kind,
+		Target:        cost.Target,
+		Calls:         cost.Calls,
+		BytesSent:     cost.BytesSent,
+		BytesReceived: cost.BytesReceived,
+		GasUsed:       cost.GasUsed,
+		FeeWei:        cost.FeeWei,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobCostResource) GetName() string {
+	return "jobCosts"
+}
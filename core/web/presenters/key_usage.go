@@ -0,0 +1,38 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// KeyUsageResource represents the jobs and on-chain configs referencing a key
+type KeyUsageResource struct {
+	JAID
+	JobIDs        []int32    `json:"jobIDs"`
+	LastUsedAt    *time.Time `json:"lastUsedAt"`
+	ConfigDigests []string   `json:"configDigests"`
+}
+
+// NewKeyUsageResource initializes a new JSONAPI key usage resource for the
+// key with the given ID
+func NewKeyUsageResource(keyID string, usage job.KeyUsage) *KeyUsageResource {
+	resource := &KeyUsageResource{
+		JAID:          JAID{ID: keyID},
+		JobIDs:        usage.JobIDs,
+		LastUsedAt:    usage.LastUsedAt,
+		ConfigDigests: usage.ConfigDigests,
+	}
+	if resource.JobIDs == nil {
+		resource.JobIDs = []int32{}
+	}
+	if resource.ConfigDigests == nil {
+		resource.ConfigDigests = []string{}
+	}
+	return resource
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r KeyUsageResource) GetName() string {
+	return "keyUsages"
+}
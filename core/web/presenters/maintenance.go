@@ -0,0 +1,32 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/maintenance"
+)
+
+// MaintenanceResource represents the maintenance mode of the node
+type MaintenanceResource struct {
+	JAID
+	Enabled           bool       `json:"enabled"`
+	PauseObservations bool       `json:"pauseObservations"`
+	Reason            string     `json:"reason,omitempty"`
+	Since             *time.Time `json:"since,omitempty"`
+}
+
+// NewMaintenanceResource constructs a new MaintenanceResource
+func NewMaintenanceResource(mode maintenance.Mode) *MaintenanceResource {
+	return &MaintenanceResource{
+		JAID:              JAID{ID: "maintenance"},
+		Enabled:           mode.Enabled,
+		PauseObservations: mode.PauseObservations,
+		Reason:            mode.Reason,
+		Since:             mode.Since,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r MaintenanceResource) GetName() string {
+	return "maintenance"
+}
@@ -0,0 +1,28 @@
+package presenters
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+)
+
+// ManifestChangesResource represents the changes that applying a manifest
+// made, or would make in a dry run
+type ManifestChangesResource struct {
+	JAID
+	DryRun  bool                          `json:"dryRun"`
+	Changes []provisioning.ManifestChange `json:"changes"`
+}
+
+// NewManifestChangesResource initializes a new JSONAPI manifest changes
+// resource
+func NewManifestChangesResource(changes []provisioning.ManifestChange, dryRun bool) *ManifestChangesResource {
+	return &ManifestChangesResource{
+		JAID:    JAID{ID: "manifest"},
+		DryRun:  dryRun,
+		Changes: changes,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r ManifestChangesResource) GetName() string {
+	return "manifestChanges"
+}
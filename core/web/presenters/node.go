@@ -0,0 +1,23 @@
+package presenters
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// NodeResource represents the identity, version and capabilities of the node,
+// for inventorying a fleet of nodes. FeatureFlags are keyed by their env var.
+type NodeResource struct {
+	JAID
+	Version      string           `json:"version"`
+	CommitSHA    string           `json:"commitSHA"`
+	Features     map[string]bool  `json:"features"`
+	FeatureFlags map[string]bool  `json:"featureFlags"`
+	ChainIDs     []string         `json:"chainIDs"`
+	JobCounts    map[job.Type]int `json:"jobCounts"`
+	JobSpecsV1   int              `json:"jobSpecsV1"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r NodeResource) GetName() string {
+	return "nodes"
+}
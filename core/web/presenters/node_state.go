@@ -0,0 +1,36 @@
+package presenters
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/provisioning"
+)
+
+// NodeStateImportResource represents the outcome of importing a node state
+// archive
+type NodeStateImportResource struct {
+	JAID
+	Created []string          `json:"created"`
+	Skipped []string          `json:"skipped"`
+	Errors  map[string]string `json:"errors"`
+}
+
+// NewNodeStateImportResource initializes a new JSONAPI node state import
+// resource
+func NewNodeStateImportResource(result provisioning.Result) *NodeStateImportResource {
+	resource := &NodeStateImportResource{
+		JAID:    JAID{ID: "import"},
+		Created: []string{},
+		Skipped: []string{},
+		Errors:  make(map[string]string),
+	}
+	resource.Created = append(resource.Created, result.Created...)
+	resource.Skipped = append(resource.Skipped, result.Skipped...)
+	for name, err := range result.Errors {
+		resource.Errors[name] = err.Error()
+	}
+	return resource
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r NodeStateImportResource) GetName() string {
+	return "nodeStateImports"
+}
@@ -0,0 +1,186 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// PipelineTaskRunResource represents a task run of a pipeline run
+type PipelineTaskRunResource struct {
+	JAID
+	Type       pipeline.TaskType          `json:"type"`
+	Output     *pipeline.JSONSerializable `json:"output"`
+	Error      null.String                `json:"error"`
+	CreatedAt  time.Time                  `json:"createdAt"`
+	StartedAt  *time.Time                 `json:"startedAt"`
+	FinishedAt *time.Time                 `json:"finishedAt"`
+	QueueWait  models.Interval            `json:"queueWait"`
+	Index      int32                      `json:"index"`
+	DotID      string                     `json:"dotId"`
+	StackTrace null.String                `json:"stackTrace"`
+	// PipelineRunID is the pipelineRun relationship
+	PipelineRunID string `json:"-"`
+}
+
+// NewPipelineTaskRunResource initializes a new JSONAPI task run resource
+func NewPipelineTaskRunResource(tr pipeline.TaskRun) PipelineTaskRunResource {
+	return PipelineTaskRunResource{
+		JAID:          JAID{ID: tr.GetID()},
+		Type:          tr.Type,
+		Output:        tr.Output,
+		Error:         tr.Error,
+		CreatedAt:     tr.CreatedAt,
+		StartedAt:     tr.StartedAt,
+		FinishedAt:    tr.FinishedAt,
+		QueueWait:     tr.QueueWait,
+		Index:         tr.Index,
+		DotID:         tr.DotID,
+		StackTrace:    tr.StackTrace,
+		PipelineRunID: strconv.FormatInt(tr.PipelineRunID, 10),
+	}
+}
+
+// NewPipelineTaskRunResources initializes a slice of JSONAPI task run
+// resources
+func NewPipelineTaskRunResources(trs []pipeline.TaskRun) []PipelineTaskRunResource {
+	rs := []PipelineTaskRunResource{}
+	for _, tr := range trs {
+		rs = append(rs, NewPipelineTaskRunResource(tr))
+	}
+	return rs
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r PipelineTaskRunResource) GetName() string {
+	return "pipelineTaskRuns"
+}
+
+// GetReferences implements the api2go MarshalReferences interface
+func (r PipelineTaskRunResource) GetReferences() []jsonapi.Reference {
+	return []jsonapi.Reference{
+		{Type: "pipelineRuns", Name: "pipelineRun", Relationship: jsonapi.ToOneRelationship},
+	}
+}
+
+// GetReferencedIDs implements the api2go MarshalLinkedRelations interface
+func (r PipelineTaskRunResource) GetReferencedIDs() []jsonapi.ReferenceID {
+	return []jsonapi.ReferenceID{
+		{ID: r.PipelineRunID, Type: "pipelineRuns", Name: "pipelineRun", Relationship: jsonapi.ToOneRelationship},
+	}
+}
+
+// SetToOneReferenceID implements the api2go UnmarshalToOneRelations interface
+func (r *PipelineTaskRunResource) SetToOneReferenceID(name, ID string) error {
+	if name == "pipelineRun" {
+		r.PipelineRunID = ID
+	}
+	return nil
+}
+
+// PipelineRunResource represents a pipeline run of a v2 job. The task runs
+// are both embedded, as the operator UI reads them, and related by ID.
+type PipelineRunResource struct {
+	JAID
+	PipelineSpec PipelineSpec              `json:"pipelineSpec"`
+	Meta         pipeline.JSONSerializable `json:"meta"`
+	Errors       pipeline.RunErrors        `json:"errors"`
+	Outputs      pipeline.JSONSerializable `json:"outputs"`
+	Errored      bool                      `json:"errored"`
+	Suppressed   bool                      `json:"suppressed"`
+	CreatedAt    time.Time                 `json:"createdAt"`
+	FinishedAt   *time.Time                `json:"finishedAt"`
+	TaskRuns     []PipelineTaskRunResource `json:"taskRuns"`
+	DedupKey     null.String               `json:"dedupKey"`
+	// Costs is the resources used by the run. It is empty for runs that
+	// have not finished.
+	Costs *pipeline.RunCosts `json:"costs,omitempty"`
+	// JobID is the job relationship. It is empty for runs of deleted jobs.
+	JobID string `json:"-"`
+	// TaskRunIDs is the pipelineTaskRuns relationship
+	TaskRunIDs []string `json:"-"`
+}
+
+// NewPipelineRunResource initializes a new JSONAPI pipeline run resource.
+// The run's pipeline spec and task runs must have been preloaded.
+func NewPipelineRunResource(run pipeline.Run) PipelineRunResource {
+	resource := PipelineRunResource{
+		JAID:         JAID{ID: run.GetID()},
+		PipelineSpec: NewPipelineSpec(&run.PipelineSpec),
+		Meta:         run.Meta,
+		Errors:       run.Errors,
+		Outputs:      run.Outputs,
+		Errored:      run.HasErrors(),
+		Suppressed:   run.Suppressed,
+		CreatedAt:    run.CreatedAt,
+		FinishedAt:   run.FinishedAt,
+		TaskRuns:     NewPipelineTaskRunResources(run.PipelineTaskRuns),
+		DedupKey:     run.DedupKey,
+		Costs:        run.Costs,
+		TaskRunIDs:   []string{},
+	}
+	if run.JobID != nil {
+		resource.JobID = strconv.Itoa(int(*run.JobID))
+	}
+	for _, tr := range run.PipelineTaskRuns {
+		resource.TaskRunIDs = append(resource.TaskRunIDs, tr.GetID())
+	}
+	return resource
+}
+
+// NewPipelineRunResources initializes a slice of JSONAPI pipeline run
+// resources
+func NewPipelineRunResources(runs []pipeline.Run) []PipelineRunResource {
+	rs := []PipelineRunResource{}
+	for _, run := range runs {
+		rs = append(rs, NewPipelineRunResource(run))
+	}
+	return rs
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r PipelineRunResource) GetName() string {
+	return "pipelineRuns"
+}
+
+// GetReferences implements the api2go MarshalReferences interface
+func (r PipelineRunResource) GetReferences() []jsonapi.Reference {
+	return []jsonapi.Reference{
+		{Type: "jobs", Name: "job", Relationship: jsonapi.ToOneRelationship},
+		{Type: "pipelineTaskRuns", Name: "pipelineTaskRuns", Relationship: jsonapi.ToManyRelationship},
+	}
+}
+
+// GetReferencedIDs implements the api2go MarshalLinkedRelations interface
+func (r PipelineRunResource) GetReferencedIDs() []jsonapi.ReferenceID {
+	var ids []jsonapi.ReferenceID
+	if r.JobID != "" {
+		ids = append(ids, jsonapi.ReferenceID{ID: r.JobID, Type: "jobs", Name: "job", Relationship: jsonapi.ToOneRelationship})
+	}
+	for _, id := range r.TaskRunIDs {
+		ids = append(ids, jsonapi.ReferenceID{ID: id, Type: "pipelineTaskRuns", Name: "pipelineTaskRuns", Relationship: jsonapi.ToManyRelationship})
+	}
+	return ids
+}
+
+// SetToOneReferenceID implements the api2go UnmarshalToOneRelations interface
+func (r *PipelineRunResource) SetToOneReferenceID(name, ID string) error {
+	if name == "job" {
+		r.JobID = ID
+	}
+	return nil
+}
+
+// SetToManyReferenceIDs implements the api2go UnmarshalToManyRelations
+// interface
+func (r *PipelineRunResource) SetToManyReferenceIDs(name string, IDs []string) error {
+	if name == "pipelineTaskRuns" {
+		r.TaskRunIDs = IDs
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+// RunAuditResource is the audit recorded for a pipeline run
+type RunAuditResource struct {
+	JAID
+	Steps    []pipeline.AuditStep     `json:"steps"`
+	Rounding []pipeline.AuditRounding `json:"rounding"`
+}
+
+// NewRunAuditResource initializes a new JSONAPI run audit resource
+func NewRunAuditResource(run pipeline.Run) *RunAuditResource {
+	r := &RunAuditResource{
+		JAID:     JAID{ID: strconv.FormatInt(run.ID, 10)},
+		Rounding: []pipeline.AuditRounding{},
+	}
+	if run.Audit != nil {
+		r.Steps = run.Audit.Steps
+		if run.Audit.Rounding != nil {
+			r.Rounding = run.Audit.Rounding
+		}
+	}
+	return r
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r RunAuditResource) GetName() string {
+	return "pipelineRunAudits"
+}
@@ -0,0 +1,26 @@
+package presenters
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// SpecSchemaResource represents the schema of a job type's TOML spec
+type SpecSchemaResource struct {
+	JAID
+	Fields []job.FieldSchema `json:"fields"`
+	Tasks  []job.TaskSchema  `json:"tasks"`
+}
+
+// NewSpecSchemaResource initializes a new JSONAPI spec schema resource
+func NewSpecSchemaResource(schema job.SpecSchema) *SpecSchemaResource {
+	return &SpecSchemaResource{
+		JAID:   JAID{ID: string(schema.Type)},
+		Fields: schema.Fields,
+		Tasks:  schema.Tasks,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r SpecSchemaResource) GetName() string {
+	return "specSchemas"
+}
@@ -0,0 +1,47 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/ocrrotation"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// TransmitterRotationResource represents the progress of an OCR job's
+// transmitter rotation
+type TransmitterRotationResource struct {
+	JAID
+	JobID          int32               `json:"jobID"`
+	OldAddress     models.EIP55Address `json:"oldAddress"`
+	NewAddress     models.EIP55Address `json:"newAddress"`
+	FundingAmount  assets.Eth          `json:"fundingAmount"`
+	FundingEthTxID *int64              `json:"fundingEthTxID"`
+	State          ocrrotation.State   `json:"state"`
+	Error          *string             `json:"error"`
+	CreatedAt      time.Time           `json:"createdAt"`
+	UpdatedAt      time.Time           `json:"updatedAt"`
+}
+
+// NewTransmitterRotationResource initializes a new JSONAPI transmitter
+// rotation resource
+func NewTransmitterRotationResource(rotation ocrrotation.Rotation) *TransmitterRotationResource {
+	return &TransmitterRotationResource{
+		JAID:           JAID{ID: strconv.FormatInt(rotation.ID, 10)},
+		JobID:          rotation.JobID,
+		OldAddress:     models.EIP55Address(rotation.OldAddress.Hex()),
+		NewAddress:     models.EIP55Address(rotation.NewAddress.Hex()),
+		FundingAmount:  rotation.FundingAmount,
+		FundingEthTxID: rotation.FundingEthTxID,
+		State:          rotation.State,
+		Error:          rotation.Error.Ptr(),
+		CreatedAt:      rotation.CreatedAt,
+		UpdatedAt:      rotation.UpdatedAt,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r TransmitterRotationResource) GetName() string {
+	return "transmitterRotations"
+}
@@ -0,0 +1,44 @@
+package presenters
+
+// TOTPEnrollmentResource is the secret to be added to an authenticator app
+// to enable two-factor authentication. URI encodes the secret in the
+// otpauth:// format used for QR codes.
+type TOTPEnrollmentResource struct {
+	JAID
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// NewTOTPEnrollmentResource initializes a new JSONAPI TOTP enrollment resource
+func NewTOTPEnrollmentResource(email, secret, uri string) *TOTPEnrollmentResource {
+	return &TOTPEnrollmentResource{
+		JAID:   JAID{ID: email},
+		Secret: secret,
+		URI:    uri,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r TOTPEnrollmentResource) GetName() string {
+	return "totpEnrollments"
+}
+
+// RecoveryCodesResource holds the user's recovery codes. They are only
+// returned when generated, as just their hashes are stored.
+type RecoveryCodesResource struct {
+	JAID
+	Codes []string `json:"codes"`
+}
+
+// NewRecoveryCodesResource initializes a new JSONAPI recovery codes resource
+func NewRecoveryCodesResource(email string, codes []string) *RecoveryCodesResource {
+	return &RecoveryCodesResource{
+		JAID:  JAID{ID: email},
+		Codes: codes,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r RecoveryCodesResource) GetName() string {
+	return "recoveryCodes"
+}
@@ -0,0 +1,67 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// TxSimulationResource represents the simulation of a transaction with
+// eth_call at each of the latest and pending states
+type TxSimulationResource struct {
+	JAID
+	EthTxID     *int64              `json:"ethTxID,omitempty"`
+	FromAddress models.EIP55Address `json:"fromAddress"`
+	ToAddress   models.EIP55Address `json:"toAddress"`
+	Data        hexutil.Bytes       `json:"data"`
+	Value       *utils.Big          `json:"value"`
+	GasLimit    uint64              `json:"gasLimit"`
+	Results     []TxSimulation      `json:"results"`
+}
+
+// TxSimulation represents the outcome of a simulation at one block tag
+type TxSimulation struct {
+	BlockTag     string        `json:"blockTag"`
+	Success      bool          `json:"success"`
+	ReturnData   hexutil.Bytes `json:"returnData,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	RevertReason string        `json:"revertReason,omitempty"`
+}
+
+// NewTxSimulationResource initializes a new JSONAPI transaction simulation
+// resource. Queued transactions are identified by their ID.
+func NewTxSimulationResource(ethTxID *int64, tx models.EthTx, results []eth.SimulationResult) *TxSimulationResource {
+	id := "hypothetical"
+	if ethTxID != nil {
+		id = strconv.FormatInt(*ethTxID, 10)
+	}
+	resource := &TxSimulationResource{
+		JAID:        JAID{ID: id},
+		EthTxID:     ethTxID,
+		FromAddress: models.EIP55Address(tx.FromAddress.Hex()),
+		ToAddress:   models.EIP55Address(tx.ToAddress.Hex()),
+		Data:        tx.EncodedPayload,
+		Value:       utils.NewBig(tx.Value.ToInt()),
+		GasLimit:    tx.GasLimit,
+		Results:     []TxSimulation{},
+	}
+	for _, r := range results {
+		resource.Results = append(resource.Results, TxSimulation{
+			BlockTag:     r.BlockTag,
+			Success:      r.Success,
+			ReturnData:   r.ReturnData,
+			Error:        r.Error,
+			RevertReason: r.RevertReason,
+		})
+	}
+	return resource
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r TxSimulationResource) GetName() string {
+	return "txSimulations"
+}
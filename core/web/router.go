@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/smartcontractkit/chainlink/core/web/graphqlapi"
 	"github.com/ulule/limiter"
 	mgin "github.com/ulule/limiter/drivers/middleware/gin"
 	"github.com/ulule/limiter/drivers/store/memory"
@@ -81,6 +83,10 @@ func Router(app chainlink.Application) *gin.Engine {
 	if err != nil {
 		logger.Panic(err)
 	}
+	allowedIPs, err := config.APIAllowedIPs()
+	if err != nil {
+		logger.Panic(err)
+	}
 	sessionStore := sessions.NewCookieStore(secret)
 	sessionStore.Options(config.SessionOptions())
 	cors := uiCorsHandler(config)
@@ -90,6 +96,7 @@ func Router(app chainlink.Application) *gin.Engine {
 		limits.RequestSizeLimiter(config.DefaultHTTPLimit()),
 		loggerFunc(),
 		gin.Recovery(),
+		ipAllowlist(allowedIPs),
 		cors,
 		secureMiddleware(config),
 		prometheus.Instrument(),
@@ -159,27 +166,67 @@ func secureMiddleware(config orm.ConfigReader) gin.HandlerFunc {
 	return secureFunc
 }
 func metricRoutes(app chainlink.Application, r *gin.RouterGroup) {
-	group := r.Group("/debug", RequireAuth(app.GetStore(), AuthenticateBySession))
+	group := r.Group("/debug", RequireAuth(app.GetStore(), AuthenticateByToken, AuthenticateBySession))
 	group.GET("/vars", expvar.Handler())
 
+	dc := DiagnosticsController{app}
+	group.GET("/gc", dc.GC)
+	group.GET("/diagnostics", dc.Show)
+
+	// Outside of development, profiles are fetched with an API token, e.g.
+	// with curl, and then opened with `go tool pprof`
+	pprofGroup := group.Group("/pprof")
 	if app.GetStore().Config.Dev() {
 		// No authentication because `go tool pprof` doesn't support it
-		pprofGroup := r.Group("/debug/pprof")
-		pprofGroup.GET("/", pprofHandler(pprof.Index))
-		pprofGroup.GET("/cmdline", pprofHandler(pprof.Cmdline))
-		pprofGroup.GET("/profile", pprofHandler(pprof.Profile))
-		pprofGroup.POST("/symbol", pprofHandler(pprof.Symbol))
-		pprofGroup.GET("/symbol", pprofHandler(pprof.Symbol))
-		pprofGroup.GET("/trace", pprofHandler(pprof.Trace))
-		pprofGroup.GET("/allocs", pprofHandler(pprof.Handler("allocs").ServeHTTP))
-		pprofGroup.GET("/block", pprofHandler(pprof.Handler("block").ServeHTTP))
-		pprofGroup.GET("/goroutine", pprofHandler(pprof.Handler("goroutine").ServeHTTP))
-		pprofGroup.GET("/heap", pprofHandler(pprof.Handler("heap").ServeHTTP))
-		pprofGroup.GET("/mutex", pprofHandler(pprof.Handler("mutex").ServeHTTP))
-		pprofGroup.GET("/threadcreate", pprofHandler(pprof.Handler("threadcreate").ServeHTTP))
+		pprofGroup = r.Group("/debug/pprof")
 	}
+	pprofGroup.GET("/", pprofHandler(pprof.Index))
+	pprofGroup.GET("/cmdline", pprofHandler(pprof.Cmdline))
+	writeTimeout := app.GetStore().Config.HTTPServerWriteTimeout()
+	pprofGroup.GET("/profile", pprofDurationHandler(pprof.Profile, writeTimeout))
+	pprofGroup.POST("/symbol", pprofHandler(pprof.Symbol))
+	pprofGroup.GET("/symbol", pprofHandler(pprof.Symbol))
+	pprofGroup.GET("/trace", pprofDurationHandler(pprof.Trace, writeTimeout))
+	pprofGroup.GET("/allocs", pprofHandler(pprof.Handler("allocs").ServeHTTP))
+	pprofGroup.GET("/block", pprofHandler(pprof.Handler("block").ServeHTTP))
+	pprofGroup.GET("/goroutine", pprofHandler(pprof.Handler("goroutine").ServeHTTP))
+	pprofGroup.GET("/heap", pprofHandler(pprof.Handler("heap").ServeHTTP))
+	pprofGroup.GET("/mutex", pprofHandler(pprof.Handler("mutex").ServeHTTP))
+	pprofGroup.GET("/threadcreate", pprofHandler(pprof.Handler("threadcreate").ServeHTTP))
 }
 
+// pprofDurationHandler serves a profile or trace that is collected for the
+// seconds given in the request, 30 by default. The duration is clamped to a
+// second less than HTTP_SERVER_WRITE_TIMEOUT, as the response would
+// otherwise be cut off by it.
+func pprofDurationHandler(h http.HandlerFunc, writeTimeout time.Duration) gin.HandlerFunc {
+	if writeTimeout <= 0 {
+		return pprofHandler(h)
+	}
+	maxSeconds := int64((writeTimeout - time.Second) / time.Second)
+	if maxSeconds < 1 {
+		maxSeconds = 1
+	}
+	handler := pprofHandler(h)
+	return func(c *gin.Context) {
+		seconds, err := strconv.ParseInt(c.Query("seconds"), 10, 64)
+		if err != nil || seconds <= 0 {
+			seconds = pprofDefaultSeconds
+		}
+		if seconds > maxSeconds {
+			seconds = maxSeconds
+		}
+		query := c.Request.URL.Query()
+		query.Set("seconds", strconv.FormatInt(seconds, 10))
+		c.Request.URL.RawQuery = query.Encode()
+		handler(c)
+	}
+}
+
+// pprofDefaultSeconds is how long net/http/pprof profiles for when no
+// duration is given
+const pprofDefaultSeconds = 30
+
 func pprofHandler(h http.HandlerFunc) gin.HandlerFunc {
 	handler := http.HandlerFunc(h)
 	return func(c *gin.Context) {
@@ -189,7 +236,7 @@ func pprofHandler(h http.HandlerFunc) gin.HandlerFunc {
 
 func sessionRoutes(app chainlink.Application, r *gin.RouterGroup) {
 	config := app.GetStore().Config
-	unauth := r.Group("/", rateLimiter(
+	unauth := r.Group("/", tokenBucketLimiter(
 		config.UnAuthenticatedRateLimitPeriod().Duration(),
 		config.UnAuthenticatedRateLimit(),
 	))
@@ -200,14 +247,22 @@ func sessionRoutes(app chainlink.Application, r *gin.RouterGroup) {
 }
 
 func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
+	config := app.GetStore().Config
+	runTriggerLimiter := tokenBucketLimiter(
+		config.RunTriggerRateLimitPeriod().Duration(),
+		config.RunTriggerRateLimit(),
+	)
 	unauthedv2 := r.Group("/v2")
 
 	jr := JobRunsController{app}
-	unauthedv2.PATCH("/runs/:RunID", jr.Update)
+	unauthedv2.PATCH("/runs/:RunID", RequireClientCertificate(config, nil), jr.Update)
 
 	sa := ServiceAgreementsController{app}
 	unauthedv2.POST("/service_agreements", sa.Create)
 
+	oac := OpenAPIController{app}
+	unauthedv2.GET("/openapi.json", oac.Show)
+
 	j := JobSpecsController{app}
 	jsec := JobSpecErrorsController{app}
 
@@ -217,14 +272,20 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.PATCH("/user/password", uc.UpdatePassword)
 		authv2.POST("/user/token", uc.NewAPIToken)
 		authv2.POST("/user/token/delete", uc.DeleteAPIToken)
+		authv2.POST("/user/2fa/totp", uc.BeginTOTPEnrollment)
+		authv2.POST("/user/2fa/totp/confirm", uc.ConfirmTOTPEnrollment)
+		authv2.POST("/user/2fa/recovery_codes", uc.NewRecoveryCodes)
+		authv2.POST("/user/2fa/delete", uc.DisableTwoFactor)
 
 		eia := ExternalInitiatorsController{app}
 		authv2.POST("/external_initiators", eia.Create)
 		authv2.DELETE("/external_initiators/:Name", eia.Destroy)
 
+		ssc := SpecSchemasController{app}
 		authv2.POST("/specs", j.Create)
 		authv2.GET("/specs", paginatedRequest(j.Index))
-		authv2.GET("/specs/:SpecID", j.Show)
+		// GET /specs/schema is served by the spec schemas controller
+		authv2.GET("/specs/:SpecID", staticParam("SpecID", "schema", ssc.Show, j.Show))
 		authv2.DELETE("/specs/:SpecID", j.Destroy)
 
 		authv2.GET("/runs", paginatedRequest(jr.Index))
@@ -236,10 +297,11 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/service_agreements/:SAID", sa.Show)
 
 		bt := BridgeTypesController{app}
-		authv2.GET("/bridge_types", paginatedRequest(bt.Index))
+		authv2.GET("/bridge_types", listRequest(bt.Index))
 		authv2.POST("/bridge_types", bt.Create)
 		authv2.GET("/bridge_types/:BridgeName", bt.Show)
 		authv2.PATCH("/bridge_types/:BridgeName", bt.Update)
+		authv2.PUT("/bridge_types/:BridgeName", bt.Upsert)
 		authv2.DELETE("/bridge_types/:BridgeName", bt.Destroy)
 
 		ts := TransfersController{app}
@@ -249,6 +311,9 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/config", cc.Show)
 		authv2.PATCH("/config", cc.Patch)
 
+		ruc := RPCUsageController{app}
+		authv2.GET("/rpc_usage", ruc.Show)
+
 		tas := TxAttemptsController{app}
 		authv2.GET("/tx_attempts", paginatedRequest(tas.Index))
 
@@ -256,44 +321,110 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/transactions", paginatedRequest(txs.Index))
 		authv2.GET("/transactions/:TxHash", txs.Show)
 
+		tsc := TxSimulationsController{app}
+		authv2.POST("/tx_simulations", tsc.Create)
+
 		bdc := BulkDeletesController{app}
 		authv2.DELETE("/bulk_delete_runs", bdc.Delete)
 
 		ekc := ETHKeysController{app}
-		authv2.GET("/keys/eth", ekc.Index)
+		authv2.GET("/keys/eth", listRequest(ekc.Index))
 		authv2.POST("/keys/eth", ekc.Create)
 		authv2.DELETE("/keys/eth/:keyID", ekc.Delete)
+		authv2.PATCH("/keys/eth/:keyID", ekc.Update)
 		authv2.POST("/keys/eth/import", ekc.Import)
 		authv2.POST("/keys/eth/export/:address", ekc.Export)
+		authv2.POST("/keys/eth/restore/:keyID", ekc.Restore)
+		authv2.GET("/keys/eth/nonces/:address", ekc.Nonces)
+		authv2.POST("/keys/eth/nonces/:address", ekc.FillNonceGaps)
 
 		ocrkc := OCRKeysController{app}
 		authv2.GET("/keys/ocr", ocrkc.Index)
+		authv2.GET("/keys/ocr/usage", ocrkc.Usage)
 		authv2.POST("/keys/ocr", ocrkc.Create)
 		authv2.DELETE("/keys/ocr/:keyID", ocrkc.Delete)
 		authv2.POST("/keys/ocr/import", ocrkc.Import)
 		authv2.POST("/keys/ocr/export/:ID", ocrkc.Export)
+		authv2.POST("/keys/ocr/restore/:keyID", ocrkc.Restore)
 
 		p2pkc := P2PKeysController{app}
 		authv2.GET("/keys/p2p", p2pkc.Index)
+		authv2.GET("/keys/p2p/usage", p2pkc.Usage)
 		authv2.POST("/keys/p2p", p2pkc.Create)
 		authv2.DELETE("/keys/p2p/:keyID", p2pkc.Delete)
 		authv2.POST("/keys/p2p/import", p2pkc.Import)
 		authv2.POST("/keys/p2p/export/:ID", p2pkc.Export)
+		authv2.POST("/keys/p2p/restore/:keyID", p2pkc.Restore)
+
+		fwc := ForwardersController{app}
+		authv2.GET("/forwarders", fwc.Index)
+		authv2.POST("/forwarders", fwc.Create)
+		authv2.DELETE("/forwarders/:fwdID", fwc.Delete)
+
+		dsc := DatasourcesController{app}
+		authv2.GET("/datasources", dsc.Index)
+		authv2.POST("/datasources", dsc.Create)
+		authv2.DELETE("/datasources/:name", dsc.Delete)
 
 		jc := JobsController{app}
-		authv2.GET("/jobs", jc.Index)
+		authv2.GET("/jobs", listRequest(jc.Index))
 		authv2.GET("/jobs/:ID", jc.Show)
 		authv2.POST("/jobs", jc.Create)
 		authv2.DELETE("/jobs/:ID", jc.Delete)
 
 		prc := PipelineRunsController{app}
-		authv2.GET("/jobs/:ID/runs", paginatedRequest(prc.Index))
+		authv2.GET("/jobs/:ID/runs", listRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs/:runID", prc.Show)
-		authv2.POST("/jobs/:ID/runs", prc.Create)
+		authv2.GET("/jobs/:ID/runs/:runID/audit", prc.Audit)
+		authv2.GET("/jobs/:ID/runs/:runID/task_runs", prc.TaskRuns)
+		authv2.POST("/jobs/:ID/runs", runTriggerLimiter, prc.Create)
+
+		scc := ShadowComparisonsController{app}
+		authv2.GET("/jobs/:ID/shadow_comparisons", paginatedRequest(scc.Index))
+
+		trc := TransmitterRotationsController{app}
+		authv2.GET("/jobs/:ID/transmitter_rotations", trc.Index)
+		authv2.POST("/jobs/:ID/transmitter_rotations", trc.Create)
+
+		jcc := JobCostsController{app}
+		authv2.GET("/jobs/:ID/costs", jcc.Index)
+
+		jcvc := JobConversionsController{app}
+		authv2.POST("/job_conversions", jcvc.Create)
+
+		drc := DriftReportsController{app}
+		authv2.POST("/drift_reports", drc.Create)
+
+		nc := NodeController{app}
+		authv2.GET("/node", nc.Show)
+
+		ffc := FeatureFlagsController{app}
+		authv2.GET("/feature_flags", ffc.Index)
+		authv2.PATCH("/feature_flags/:name", ffc.Update)
+
+		nsc := NodeStateController{app}
+		authv2.GET("/node_state", nsc.Export)
+		authv2.POST("/node_state", nsc.Import)
+
+		mfc := ManifestController{app}
+		authv2.POST("/manifest", mfc.Apply)
+
+		mc := MaintenanceController{app}
+		authv2.GET("/maintenance", mc.Show)
+		authv2.POST("/maintenance", mc.Enable)
+		authv2.DELETE("/maintenance", mc.Resume)
+
+		evc := EventsController{app}
+		authv2.GET("/events", evc.Stream)
+
+		frc := FeedReportsController{app}
+		authv2.GET("/feed_reports/:contractAddress", frc.Show)
 
 		lgc := LogController{app}
 		authv2.GET("/log", lgc.Get)
 		authv2.PATCH("/log", lgc.Patch)
+
+		authv2.POST("/graphql", gin.WrapH(graphqlapi.NewHandler(app)))
 	}
 
 	ping := PingController{app}
@@ -301,8 +432,8 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		AuthenticateExternalInitiator,
 		AuthenticateByToken,
 		AuthenticateBySession,
-	))
-	userOrEI.POST("/specs/:SpecID/runs", jr.Create)
+	), RequireClientCertificate(config, isExternalInitiator))
+	userOrEI.POST("/specs/:SpecID/runs", runTriggerLimiter, jr.Create)
 	userOrEI.GET("/ping", ping.Show)
 }
 
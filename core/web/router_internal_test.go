@@ -0,0 +1,43 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPprofDurationHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		query        string
+		writeTimeout time.Duration
+		seconds      string
+	}{
+		{"default is clamped", "", 10 * time.Second, "9"},
+		{"long durations are clamped", "?seconds=60", 10 * time.Second, "9"},
+		{"short durations are kept", "?seconds=3", 10 * time.Second, "3"},
+		{"invalid durations use the default", "?seconds=nonsense", time.Minute, "30"},
+		{"at least a second", "?seconds=5", time.Second, "1"},
+		{"no write timeout", "?seconds=60", 0, "60"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var seconds string
+			h := func(w http.ResponseWriter, r *http.Request) { seconds = r.FormValue("seconds") }
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/profile", pprofDurationHandler(h, test.writeTimeout))
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/profile"+test.query, nil))
+			assert.Equal(t, test.seconds, seconds)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/rpcusage"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+)
+
+// RPCUsageController reports the node's usage of its eth RPC endpoints
+type RPCUsageController struct {
+	App chainlink.Application
+}
+
+// Show returns the eth RPC calls made so far this month, by day, method and
+// endpoint, and how many will have been made by the end of the month at the
+// same rate
+// Example:
+// "<application>/rpc_usage"
+func (ruc *RPCUsageController) Show(c *gin.Context) {
+	store := ruc.App.GetStore()
+	report, err := rpcusage.MakeReport(store.DB, time.Now(), store.Config.EthRPCMonthlyQuota())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	pr := presenters.RPCUsageReport{
+		Month:       report.Month,
+		MonthToDate: report.MonthToDate,
+		Projected:   report.Projected,
+		Quota:       report.Quota,
+		Usage:       []presenters.RPCUsage{},
+	}
+	for _, u := range report.Usage {
+		pr.Usage = append(pr.Usage, presenters.RPCUsage{
+			Day:      u.Day.Format("2006-01-02"),
+			Method:   u.Method,
+			Endpoint: u.Endpoint,
+			Calls:    u.Calls,
+		})
+	}
+	jsonAPIResponse(c, pr, "rpcUsageReport")
+}
@@ -0,0 +1,49 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/rpcusage"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCUsageController_Show(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	now := time.Now()
+	require.NoError(t, rpcusage.Save(app.Store.DB, now, map[eth.RPCCallKey]uint64{
+		{Method: "eth_getLogs", Endpoint: "mainnet.infura.io"}: 42,
+	}))
+
+	resp, cleanup := client.Get("/v2/rpc_usage")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var report presenters.RPCUsageReport
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &report))
+
+	assert.Equal(t, now.UTC().Format("2006-01"), report.Month)
+	assert.GreaterOrEqual(t, report.MonthToDate, int64(42))
+	assert.Equal(t, uint64(0), report.Quota)
+	assert.Contains(t, report.Usage, presenters.RPCUsage{
+		Day:      now.UTC().Format("2006-01-02"),
+		Method:   "eth_getLogs",
+		Endpoint: "mainnet.infura.io",
+		Calls:    42,
+	})
+}
@@ -0,0 +1,35 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// ShadowComparisonsController lists how the runs of a shadow job compared
+// with the runs of the job it shadows.
+type ShadowComparisonsController struct {
+	App chainlink.Application
+}
+
+// Index returns the comparisons recorded by a shadow job, most recent first.
+// Example:
+// "GET <application>/jobs/:ID/shadow_comparisons"
+func (scc *ShadowComparisonsController) Index(c *gin.Context, size, page, offset int) {
+	jobSpec := job.Job{}
+	err := jobSpec.SetID(c.Param("ID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	comparisons, count, err := scc.App.GetJobORM().ShadowComparisonsByJobID(jobSpec.ID, offset, size)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	paginatedResponse(c, "shadowComparisons", size, page, comparisons, count, err)
+}
@@ -0,0 +1,47 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// SpecSchemasController describes the job spec formats that the node accepts
+type SpecSchemasController struct {
+	App chainlink.Application
+}
+
+// Show returns the schema of a single job type when the type param is given,
+// or of every job type otherwise
+// Example:
+// "GET <application>/specs/schema?type=offchainreporting"
+func (ssc *SpecSchemasController) Show(c *gin.Context) {
+	if t := c.Query("type"); t != "" {
+		schema, err := job.SpecSchemaFor(job.Type(t))
+		if errors.Is(err, job.ErrUnknownJobType) {
+			jsonAPIError(c, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		jsonAPIResponse(c, presenters.NewSpecSchemaResource(schema), "specSchemas")
+		return
+	}
+
+	schemas, err := job.SpecSchemas()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	resources := []presenters.SpecSchemaResource{}
+	for _, schema := range schemas {
+		resources = append(resources, *presenters.NewSpecSchemaResource(schema))
+	}
+	jsonAPIResponse(c, resources, "specSchemas")
+}
@@ -0,0 +1,58 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestSpecSchemasController_Show(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	t.Run("single type", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/specs/schema?type=offchainreporting")
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var schema presenters.SpecSchemaResource
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &schema))
+		assert.Equal(t, "offchainreporting", schema.ID)
+		assert.NotEmpty(t, schema.Fields)
+		assert.NotEmpty(t, schema.Tasks)
+	})
+
+	t.Run("all types", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/specs/schema")
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var schemas []presenters.SpecSchemaResource
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &schemas))
+		var types []string
+		for _, s := range schemas {
+			types = append(types, s.ID)
+		}
+		assert.Equal(t, []string{"directrequest", "fluxmonitor", "keeper", "messagequeue", "offchainreporting", "shadow"}, types)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/specs/schema?type=nope")
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,173 @@
+package web
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+)
+
+// CertReloader serves the TLS certificate for the node's HTTPS server,
+// reloading it from disk when the node receives SIGHUP or when the files
+// change, so that certificates can be rotated without a restart.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modTimes [2]time.Time
+}
+
+// NewCertReloader loads the given certificate and key. It errors if they
+// can't be loaded, as the server would be unable to serve HTTPS.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the certificate and key from disk. The current certificate
+// keeps being served if they can't be loaded.
+func (r *CertReloader) Reload() error {
+	modTimes, err := r.fileModTimes()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load TLS certificate")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.modTimes = modTimes
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Run reloads the certificate on SIGHUP and, if pollInterval is non-zero,
+// whenever the certificate or key file is modified. It returns once chStop
+// is closed.
+func (r *CertReloader) Run(chStop <-chan struct{}, pollInterval time.Duration) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	var chPoll <-chan time.Time
+	if pollInterval > 0 {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		chPoll = ticker.C
+	}
+
+	for {
+		select {
+		case <-chStop:
+			return
+		case <-sighup:
+			r.reloadAndLog("received SIGHUP")
+		case <-chPoll:
+			if r.changed() {
+				r.reloadAndLog("certificate files changed")
+			}
+		}
+	}
+}
+
+func (r *CertReloader) reloadAndLog(reason string) {
+	if err := r.Reload(); err != nil {
+		logger.Errorw("Failed to reload TLS certificate, continuing to serve the previous one", "reason", reason, "err", err)
+		return
+	}
+	logger.Infow("Reloaded TLS certificate", "reason", reason, "certFile", r.certFile)
+}
+
+func (r *CertReloader) changed() bool {
+	modTimes, err := r.fileModTimes()
+	if err != nil {
+		logger.Warnw("Unable to check TLS certificate files for changes", "err", err)
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return modTimes != r.modTimes
+}
+
+func (r *CertReloader) fileModTimes() (modTimes [2]time.Time, err error) {
+	for i, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return modTimes, errors.Wrap(err, "failed to stat TLS file")
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// NewServerTLSConfig returns the TLS config for the node's HTTPS server.
+// When clientCAFile is set, clients may present a certificate, which is
+// verified against the CAs in that file. Routes that require one are guarded
+// by RequireClientCertificate.
+func NewServerTLSConfig(reloader *CertReloader, clientCAFile string) (*tls.Config, error) {
+	config := &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+	if clientCAFile == "" {
+		return config, nil
+	}
+	pem, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read TLS client CA file")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("no certificates found in TLS client CA file %s", clientCAFile)
+	}
+	config.ClientCAs = pool
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+	return config, nil
+}
+
+// RequireClientCertificate aborts requests that were not made over TLS with
+// a verified client certificate, if TLS_CLIENT_CA_PATH is set. When only is
+// given, requests for which it returns false are let through regardless.
+func RequireClientCertificate(config *orm.Config, only func(*gin.Context) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if config.TLSClientCAPath() == "" || (only != nil && !only(c)) {
+			c.Next()
+			return
+		}
+		if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 {
+			jsonAPIError(c, http.StatusUnauthorized, errors.New("a verified TLS client certificate is required"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func isExternalInitiator(c *gin.Context) bool {
+	_, is := authenticatedEI(c)
+	return is
+}
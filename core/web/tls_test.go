@@ -0,0 +1,170 @@
+package web_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/web"
+)
+
+// writeSelfSignedCert writes a new self-signed certificate and its key to dir
+func writeSelfSignedCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, commonName+".crt")
+	keyFile = filepath.Join(dir, commonName+".key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return parsed.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeSelfSignedCert(t, dir, "first")
+	reloader, err := web.NewCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", commonName(t, cert))
+
+	t.Run("reloads when the files change", func(t *testing.T) {
+		chStop := make(chan struct{})
+		defer close(chStop)
+		go reloader.Run(chStop, 10*time.Millisecond)
+
+		newCertFile, newKeyFile := writeSelfSignedCert(t, dir, "second")
+		// Ensure the modification time differs on filesystems with coarse timestamps
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Rename(newCertFile, certFile))
+		require.NoError(t, os.Rename(newKeyFile, keyFile))
+		require.NoError(t, os.Chtimes(certFile, later, later))
+		require.NoError(t, os.Chtimes(keyFile, later, later))
+
+		assert.Eventually(t, func() bool {
+			cert, err := reloader.GetCertificate(nil)
+			require.NoError(t, err)
+			return commonName(t, cert) == "second"
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("keeps the previous certificate if the new one is invalid", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(certFile, []byte("not a certificate"), 0600))
+		require.Error(t, reloader.Reload())
+		cert, err := reloader.GetCertificate(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "second", commonName(t, cert))
+	})
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeSelfSignedCert(t, dir, "server")
+	reloader, err := web.NewCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+
+	config, err := web.NewServerTLSConfig(reloader, "")
+	require.NoError(t, err)
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+
+	caFile, _ := writeSelfSignedCert(t, dir, "ca")
+	config, err = web.NewServerTLSConfig(reloader, caFile)
+	require.NoError(t, err)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, config.ClientAuth)
+	assert.NotNil(t, config.ClientCAs)
+
+	_, err = web.NewServerTLSConfig(reloader, keyFile)
+	require.Error(t, err)
+}
+
+func TestRequireClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", web.RequireClientCertificate(config.Config, nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	verified := func() *http.Request {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+		return req
+	}
+
+	t.Run("allows any request when no client CA is configured", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	config.Set("TLS_CLIENT_CA_PATH", "/path/to/ca.crt")
+
+	t.Run("rejects requests without a verified client certificate", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+		req.TLS = &tls.ConnectionState{}
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("allows requests with a verified client certificate", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, verified())
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
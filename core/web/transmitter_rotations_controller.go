@@ -0,0 +1,88 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/ocrrotation"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// TransmitterRotationsController manages rotations of OCR jobs' transmitter
+// addresses
+type TransmitterRotationsController struct {
+	App chainlink.Application
+}
+
+// CreateTransmitterRotationRequest is the request body for starting a
+// transmitter rotation
+type CreateTransmitterRotationRequest struct {
+	NewTransmitterAddress common.Address `json:"newTransmitterAddress"`
+	FundingAmount         assets.Eth     `json:"fundingAmount"`
+}
+
+// Index lists the transmitter rotations of a job, most recent first
+// Example:
+// "GET <application>/jobs/:ID/transmitter_rotations"
+func (trc *TransmitterRotationsController) Index(c *gin.Context) {
+	jobSpec := job.Job{}
+	if err := jobSpec.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	rotations, err := trc.App.GetTransmitterRotator().RotationsForJob(jobSpec.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := []presenters.TransmitterRotationResource{}
+	for _, rotation := range rotations {
+		resources = append(resources, *presenters.NewTransmitterRotationResource(rotation))
+	}
+	jsonAPIResponse(c, resources, "transmitterRotations")
+}
+
+// Create starts rotating a job's transmitter to a new address. Progress can
+// be followed with Index.
+// Example:
+// "POST <application>/jobs/:ID/transmitter_rotations"
+func (trc *TransmitterRotationsController) Create(c *gin.Context) {
+	jobSpec := job.Job{}
+	if err := jobSpec.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	var request CreateTransmitterRotationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	rotation, err := trc.App.GetTransmitterRotator().Rotate(c.Request.Context(), jobSpec.ID, request.NewTransmitterAddress, request.FundingAmount)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonAPIError(c, http.StatusNotFound, errors.New("Job not found"))
+		return
+	case errors.Is(err, ocrrotation.ErrRotationInProgress):
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	case errors.Is(err, ocrrotation.ErrNotOCRJob), errors.Is(err, ocrrotation.ErrNoSuchKey):
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	case err != nil:
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonAPIResponseWithStatus(c, presenters.NewTransmitterRotationResource(rotation), "transmitterRotations", http.StatusCreated)
+}
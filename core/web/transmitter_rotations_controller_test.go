@@ -0,0 +1,74 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/ocrrotation"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestTransmitterRotationsController_CreateAndIndex(t *testing.T) {
+	app, client, cleanup := setupJobsControllerTests(t)
+	defer cleanup()
+
+	var ocrJob job.Job
+	tree, err := toml.LoadFile("testdata/oracle-spec.toml")
+	require.NoError(t, err)
+	require.NoError(t, tree.Unmarshal(&ocrJob))
+	var ocrSpec job.OffchainReportingOracleSpec
+	require.NoError(t, tree.Unmarshal(&ocrSpec))
+	ocrSpec.TransmitterAddress = &app.Key.Address
+	ocrJob.OffchainreportingOracleSpec = &ocrSpec
+	jobID, err := app.AddJobV2(context.Background(), ocrJob, null.String{})
+	require.NoError(t, err)
+
+	newKey := cltest.MustInsertRandomKey(t, app.Store.DB)
+	path := fmt.Sprintf("/v2/jobs/%v/transmitter_rotations", jobID)
+	body := []byte(fmt.Sprintf(`{"newTransmitterAddress": "%s", "fundingAmount": "0"}`, newKey.Address.Hex()))
+
+	resp, cleanup := client.Post(path, bytes.NewReader(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+
+	var rotation presenters.TransmitterRotationResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &rotation))
+	assert.Equal(t, jobID, rotation.JobID)
+	assert.Equal(t, app.Key.Address, rotation.OldAddress)
+	assert.Equal(t, newKey.Address, rotation.NewAddress)
+	assert.Equal(t, ocrrotation.StateFunding, rotation.State)
+
+	t.Run("rejects a second concurrent rotation", func(t *testing.T) {
+		resp, cleanup := client.Post(path, bytes.NewReader(body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusConflict)
+	})
+
+	t.Run("rejects unknown keys", func(t *testing.T) {
+		body := []byte(fmt.Sprintf(`{"newTransmitterAddress": "%s"}`, cltest.NewAddress().Hex()))
+		resp, cleanup := client.Post(path, bytes.NewReader(body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("lists the job's rotations", func(t *testing.T) {
+		resp, cleanup := client.Get(path)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var rotations []presenters.TransmitterRotationResource
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &rotations))
+		require.Len(t, rotations, 1)
+		assert.Equal(t, rotation.ID, rotations[0].ID)
+	})
+}
@@ -0,0 +1,92 @@
+package web
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// TxSimulationsController simulates transactions to debug failing
+// fulfillments
+type TxSimulationsController struct {
+	App chainlink.Application
+}
+
+// TxSimulationRequest is either the ID of a transaction in the node's queue,
+// or a hypothetical transaction
+type TxSimulationRequest struct {
+	EthTxID     *int64          `json:"ethTxID"`
+	FromAddress *common.Address `json:"fromAddress"`
+	ToAddress   *common.Address `json:"toAddress"`
+	Data        hexutil.Bytes   `json:"data"`
+	Value       *utils.Big      `json:"value"`
+	GasLimit    uint64          `json:"gasLimit"`
+}
+
+// Create simulates the transaction with eth_call at both the latest and the
+// pending state, returning the revert reasons of calls that fail.
+// Example:
+// "POST <application>/tx_simulations"
+func (tsc *TxSimulationsController) Create(c *gin.Context) {
+	request := TxSimulationRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	var tx models.EthTx
+	if request.EthTxID != nil {
+		var err error
+		tx, err = tsc.App.GetStore().FindEthTxWithAttempts(*request.EthTxID)
+		if errors.Cause(err) == orm.ErrorNotFound {
+			jsonAPIError(c, http.StatusNotFound, errors.New("Transaction not found"))
+			return
+		} else if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+	} else {
+		if request.FromAddress == nil || request.ToAddress == nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("either ethTxID, or fromAddress and toAddress, must be given"))
+			return
+		}
+		tx = models.EthTx{
+			FromAddress:    *request.FromAddress,
+			ToAddress:      *request.ToAddress,
+			EncodedPayload: request.Data,
+			GasLimit:       request.GasLimit,
+		}
+		if request.Value != nil {
+			tx.Value = assets.Eth(*request.Value.ToInt())
+		}
+	}
+
+	to := tx.ToAddress
+	msg := ethereum.CallMsg{
+		From:  tx.FromAddress,
+		To:    &to,
+		Data:  tx.EncodedPayload,
+		Gas:   tx.GasLimit,
+		Value: new(big.Int).Set(tx.Value.ToInt()),
+	}
+	ethClient := tsc.App.GetStore().EthClient
+	var results []eth.SimulationResult
+	for _, blockTag := range []string{eth.BlockTagLatest, eth.BlockTagPending} {
+		results = append(results, eth.SimulateCall(c.Request.Context(), ethClient, msg, blockTag))
+	}
+
+	jsonAPIResponse(c, presenters.NewTxSimulationResource(request.EthTxID, tx, results), "txSimulations")
+}
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 	"github.com/smartcontractkit/chainlink/core/utils"
+	webpresenters "github.com/smartcontractkit/chainlink/core/web/presenters"
 
 	"github.com/gin-gonic/contrib/sessions"
 	"github.com/gin-gonic/gin"
@@ -104,6 +107,146 @@ func (c *UserController) DeleteAPIToken(ctx *gin.Context) {
 	}
 }
 
+// BeginTOTPEnrollment generates a TOTP secret for the current User. Two-factor
+// authentication is not required to log in until the secret is confirmed.
+// Example:
+// "POST <application>/user/2fa/totp"
+func (c *UserController) BeginTOTPEnrollment(ctx *gin.Context) {
+	user, ok := c.authorizeTwoFactorChange(ctx, false)
+	if !ok {
+		return
+	}
+	if user.TOTPEnabled {
+		jsonAPIError(ctx, http.StatusConflict, errors.New("two-factor authentication is already enabled"))
+		return
+	}
+	secret, err := user.BeginTOTPEnrollment()
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if err := c.App.GetStore().SaveUser(&user); err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	uri := auth.TOTPURI(secret, "Chainlink", user.Email)
+	jsonAPIResponseWithStatus(ctx, webpresenters.NewTOTPEnrollmentResource(user.Email, secret, uri), "totpEnrollments", http.StatusCreated)
+}
+
+// ConfirmTOTPEnrollment enables two-factor authentication for the current User
+// once they have shown a valid code for the secret from BeginTOTPEnrollment.
+// All other sessions are logged out, and the User's recovery codes returned.
+// Example:
+// "POST <application>/user/2fa/totp/confirm"
+func (c *UserController) ConfirmTOTPEnrollment(ctx *gin.Context) {
+	var request models.TwoFactorRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(ctx, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	user, err := c.App.GetStore().FindUser()
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to obtain current user record: %+v", err))
+		return
+	}
+	codes, err := user.ConfirmTOTPEnrollment(request.TOTPCode, time.Now())
+	if errors.Is(err, models.ErrInvalidSecondFactor) {
+		jsonAPIError(ctx, http.StatusUnauthorized, err)
+		return
+	} else if err != nil {
+		jsonAPIError(ctx, http.StatusConflict, err)
+		return
+	}
+	sessionID, err := c.getCurrentSessionID(ctx)
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if err := c.App.GetStore().ClearNonCurrentSessions(sessionID); err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to clear non current user sessions: %+v", err))
+		return
+	}
+	if err := c.App.GetStore().SaveUser(&user); err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(ctx, webpresenters.NewRecoveryCodesResource(user.Email, codes), "recoveryCodes")
+}
+
+// NewRecoveryCodes replaces the current User's recovery codes.
+// Example:
+// "POST <application>/user/2fa/recovery_codes"
+func (c *UserController) NewRecoveryCodes(ctx *gin.Context) {
+	user, ok := c.authorizeTwoFactorChange(ctx, true)
+	if !ok {
+		return
+	}
+	codes, err := user.RegenerateRecoveryCodes()
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if err := c.App.GetStore().SaveUser(&user); err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponseWithStatus(ctx, webpresenters.NewRecoveryCodesResource(user.Email, codes), "recoveryCodes", http.StatusCreated)
+}
+
+// DisableTwoFactor turns off two-factor authentication for the current User.
+// Example:
+// "POST <application>/user/2fa/delete"
+func (c *UserController) DisableTwoFactor(ctx *gin.Context) {
+	user, ok := c.authorizeTwoFactorChange(ctx, true)
+	if !ok {
+		return
+	}
+	user.DisableTOTP()
+	if err := c.App.GetStore().SaveUser(&user); err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponseWithStatus(ctx, nil, "totpEnrollments", http.StatusNoContent)
+}
+
+// authorizeTwoFactorChange checks the password in the request and, if
+// requireEnabled is set, a TOTP or recovery code. It responds with an error
+// and returns false if they don't match.
+func (c *UserController) authorizeTwoFactorChange(ctx *gin.Context, requireEnabled bool) (models.User, bool) {
+	var request models.TwoFactorRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(ctx, http.StatusUnprocessableEntity, err)
+		return models.User{}, false
+	}
+
+	user, err := c.App.GetStore().FindUser()
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to obtain current user record: %+v", err))
+		return user, false
+	}
+	if !utils.CheckPasswordHash(request.Password, user.HashedPassword) {
+		jsonAPIError(ctx, http.StatusUnauthorized, errors.New("incorrect password"))
+		return user, false
+	}
+	if !requireEnabled {
+		return user, true
+	}
+	if !user.TOTPEnabled {
+		jsonAPIError(ctx, http.StatusConflict, errors.New("two-factor authentication is not enabled"))
+		return user, false
+	}
+	if err := user.VerifySecondFactor(request.TOTPCode, request.RecoveryCode, time.Now()); err != nil {
+		jsonAPIError(ctx, http.StatusUnauthorized, err)
+		return user, false
+	}
+	return user, true
+}
+
 func (c *UserController) getCurrentSessionID(ctx *gin.Context) (string, error) {
 	session := sessions.Default(ctx)
 	sessionID, ok := session.Get(SessionIDKey).(string)
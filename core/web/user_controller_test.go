@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -147,3 +149,82 @@ func TestUserController_DeleteAPIKey_unauthorized(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
+
+func TestUserController_TwoFactor(t *testing.T) {
+	t.Parallel()
+
+	rpcClient, gethClient, _, assertMocksCalled := cltest.NewEthMocksWithStartupAssertions(t)
+	defer assertMocksCalled()
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		eth.NewClientWith(rpcClient, gethClient),
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	login := func(sr models.SessionRequest) int {
+		body, err := json.Marshal(sr)
+		require.NoError(t, err)
+		resp, err := http.Post(app.Config.ClientNodeURL()+"/sessions", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Wrong password
+	resp, cleanup := client.Post("/v2/user/2fa/totp", bytes.NewBufferString(`{"password": "wrong password"}`))
+	defer cleanup()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Begin enrollment
+	body, err := json.Marshal(models.TwoFactorRequest{Password: cltest.Password})
+	require.NoError(t, err)
+	resp, cleanup = client.Post("/v2/user/2fa/totp", bytes.NewBuffer(body))
+	defer cleanup()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var enrollment presenters.TOTPEnrollmentResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &enrollment))
+	require.NotEmpty(t, enrollment.Secret)
+	assert.Contains(t, enrollment.URI, enrollment.Secret)
+
+	// Not required to log in until confirmed
+	assert.Equal(t, http.StatusOK, login(models.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password}))
+
+	// Confirm with an incorrect code
+	resp, cleanup = client.Post("/v2/user/2fa/totp/confirm", bytes.NewBufferString(`{"totpCode": "abcdef"}`))
+	defer cleanup()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Confirm
+	code, err := auth.TOTPCode(enrollment.Secret, auth.TOTPCounter(time.Now()))
+	require.NoError(t, err)
+	body, err = json.Marshal(models.TwoFactorRequest{TOTPCode: code})
+	require.NoError(t, err)
+	resp, cleanup = client.Post("/v2/user/2fa/totp/confirm", bytes.NewBuffer(body))
+	defer cleanup()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var recovery presenters.RecoveryCodesResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &recovery))
+	require.Len(t, recovery.Codes, models.RecoveryCodeCount)
+
+	// Logging in now needs a second factor
+	assert.Equal(t, http.StatusUnauthorized, login(models.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password}))
+	assert.Equal(t, http.StatusUnauthorized, login(models.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password, TOTPCode: code}))
+	assert.Equal(t, http.StatusOK, login(models.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password, RecoveryCode: recovery.Codes[0]}))
+	assert.Equal(t, http.StatusUnauthorized, login(models.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password, RecoveryCode: recovery.Codes[0]}))
+
+	// Disabling requires a second factor
+	body, err = json.Marshal(models.TwoFactorRequest{Password: cltest.Password})
+	require.NoError(t, err)
+	resp, cleanup = client.Post("/v2/user/2fa/delete", bytes.NewBuffer(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	body, err = json.Marshal(models.TwoFactorRequest{Password: cltest.Password, RecoveryCode: recovery.Codes[1]})
+	require.NoError(t, err)
+	resp, cleanup = client.Post("/v2/user/2fa/delete", bytes.NewBuffer(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	assert.Equal(t, http.StatusOK, login(models.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password}))
+}